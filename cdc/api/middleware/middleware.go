@@ -15,6 +15,8 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +26,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"go.uber.org/zap"
 )
@@ -37,8 +40,8 @@ func LogMiddleware() gin.HandlerFunc {
 		start := time.Now()
 		path := c.Request.URL.Path
 		query := c.Request.URL.RawQuery
-		user, _, _ := c.Request.BasicAuth()
 		c.Next()
+		user := getUsername(c)
 
 		cost := time.Since(start)
 
@@ -74,6 +77,8 @@ func ErrorHandleMiddleware() gin.HandlerFunc {
 			// put the error into response
 			if api.IsHTTPBadRequestError(err) {
 				c.IndentedJSON(http.StatusBadRequest, model.NewHTTPError(err))
+			} else if errors.ErrPermissionDenied.Equal(err) {
+				c.IndentedJSON(http.StatusForbidden, model.NewHTTPError(err))
 			} else {
 				c.IndentedJSON(http.StatusInternalServerError, model.NewHTTPError(err))
 			}
@@ -115,25 +120,9 @@ func CheckServerReadyMiddleware(capture capture.Capture) gin.HandlerFunc {
 }
 
 // AuthenticateMiddleware authenticates the request by query upstream TiDB.
+// The admin permission is required if the roles of client users are configured.
 func AuthenticateMiddleware(capture capture.Capture) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		serverCfg := config.GetGlobalServerConfig()
-		if serverCfg.Security.ClientUserRequired {
-			up, err := getUpstream(capture)
-			if err != nil {
-				_ = ctx.Error(err)
-				ctx.Abort()
-				return
-			}
-
-			if err := verify(ctx, up); err != nil {
-				ctx.IndentedJSON(http.StatusUnauthorized, model.NewHTTPError(err))
-				ctx.Abort()
-				return
-			}
-		}
-		ctx.Next()
-	}
+	return AuthorizeMiddleware(capture, security.PermissionAdmin)
 }
 
 func getUpstream(capture capture.Capture) (*upstream.Upstream, error) {
@@ -144,16 +133,54 @@ func getUpstream(capture capture.Capture) (*upstream.Upstream, error) {
 	return m.GetDefaultUpstream()
 }
 
-func verify(ctx *gin.Context, up *upstream.Upstream) error {
-	// get the username and password from the authorization header
-	username, password, ok := ctx.Request.BasicAuth()
-	if !ok {
+// authenticate identifies the request user by the bearer token, the user and
+// password of the upstream TiDB, or the client certificate in turn, and saves
+// the user into the context. The user must be in the client-allowed-user list.
+func authenticate(ctx *gin.Context, capture capture.Capture) (string, error) {
+	serverCfg := config.GetGlobalServerConfig()
+	clientAuth := serverCfg.Security.ClientAuth
+	authenticator := getClientAuthenticator(clientAuth)
+
+	var (
+		username string
+		verify   func() error
+	)
+	authorization := ctx.GetHeader("Authorization")
+	if token, ok := parseBearerToken(authorization); ok && clientAuth.IsTokenEnabled() {
+		user, err := authenticator.AuthenticateToken(token)
+		if err != nil {
+			return "", errors.ErrUnauthorized.GenWithStackByArgs(user, err.Error())
+		}
+		username = user
+	} else if user, password, ok := ctx.Request.BasicAuth(); ok {
+		username = user
+		// verify the password after the user is allowed to avoid
+		// querying the upstream TiDB for the unknown users.
+		verify = func() error {
+			up, err := getUpstream(capture)
+			if err != nil {
+				return err
+			}
+			if err := up.VerifyTiDBUser(ctx, username, password); err != nil {
+				return errors.ErrUnauthorized.GenWithStackByArgs(username, err.Error())
+			}
+			return nil
+		}
+	} else if authorization == "" && clientAuth.IsCertIdentityEnabled() && ctx.Request.TLS != nil {
+		user, err := authenticator.AuthenticateCert(ctx.Request.TLS)
+		if err != nil {
+			return "", errors.ErrUnauthorized.GenWithStackByArgs(user, err.Error())
+		}
+		username = user
+	} else {
 		errMsg := "please specify the user and password via authorization header"
-		return errors.ErrCredentialNotFound.GenWithStackByArgs(errMsg)
+		if clientAuth.IsTokenEnabled() {
+			errMsg = "please specify the bearer token or the user and password via authorization header"
+		}
+		return "", errors.ErrCredentialNotFound.GenWithStackByArgs(errMsg)
 	}
 
 	allowed := false
-	serverCfg := config.GetGlobalServerConfig()
 	for _, user := range serverCfg.Security.ClientAllowedUser {
 		if user == username {
 			allowed = true
@@ -165,10 +192,49 @@ func verify(ctx *gin.Context, up *upstream.Upstream) error {
 		if username == "" {
 			errMsg = "Empty username is not allowed."
 		}
-		return errors.ErrUnauthorized.GenWithStackByArgs(username, errMsg)
+		return "", errors.ErrUnauthorized.GenWithStackByArgs(username, errMsg)
+	}
+	if verify != nil {
+		if err := verify(); err != nil {
+			return "", err
+		}
+	}
+	ctx.Set(usernameKey, username)
+	return username, nil
+}
+
+func parseBearerToken(authorization string) (string, bool) {
+	const prefix = "Bearer "
+	if len(authorization) < len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(authorization[len(prefix):]), true
+}
+
+// clientAuthenticator caches the authenticator of the client auth config,
+// so the token file and the JWKS file are not loaded for each request.
+var clientAuthenticator struct {
+	sync.Mutex
+	cfg           *security.ClientAuthConfig
+	authenticator *security.ClientAuthenticator
+}
+
+func getClientAuthenticator(cfg *security.ClientAuthConfig) *security.ClientAuthenticator {
+	clientAuthenticator.Lock()
+	defer clientAuthenticator.Unlock()
+	if clientAuthenticator.authenticator == nil || clientAuthenticator.cfg != cfg {
+		clientAuthenticator.cfg = cfg
+		clientAuthenticator.authenticator = security.NewClientAuthenticator(cfg)
 	}
-	if err := up.VerifyTiDBUser(ctx, username, password); err != nil {
-		return errors.ErrUnauthorized.GenWithStackByArgs(username, err.Error())
+	return clientAuthenticator.authenticator
+}
+
+// getUsername returns the authenticated user of the request, or the user in
+// the basic auth header if the request is not authenticated.
+func getUsername(ctx *gin.Context) string {
+	if v, ok := ctx.Get(usernameKey); ok {
+		return v.(string)
 	}
-	return nil
+	username, _, _ := ctx.Request.BasicAuth()
+	return username
 }
@@ -0,0 +1,184 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/capture"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"go.uber.org/zap"
+)
+
+const (
+	// usernameKey is the key of the authenticated user in the gin context.
+	usernameKey = "cdc-client-username"
+	// userRoleKey is the key of the role of the request user in the gin context.
+	userRoleKey = "cdc-client-user-role"
+)
+
+// AuthorizeMiddleware authenticates the request user by the bearer token, the
+// upstream TiDB or the client certificate, and checks whether the role of the user is granted the permission.
+// The requests which require the write or admin permission are audit-logged.
+func AuthorizeMiddleware(capture capture.Capture, perm security.Permission) gin.HandlerFunc {
+	return authorizeMiddleware(capture, perm, false, false)
+}
+
+// AuthorizeNamespaceMiddleware is like AuthorizeMiddleware, and it also checks
+// whether the user can access the changefeed namespace of the request.
+func AuthorizeNamespaceMiddleware(capture capture.Capture, perm security.Permission) gin.HandlerFunc {
+	return authorizeMiddleware(capture, perm, true, false)
+}
+
+// AuthorizeIfRBACEnabledMiddleware is like AuthorizeMiddleware, but the request
+// is only authorized if the roles of client users are configured. It's used by
+// the APIs which were not authenticated before, such as setting the log level,
+// to keep compatible with the clusters which only require the client user.
+func AuthorizeIfRBACEnabledMiddleware(capture capture.Capture, perm security.Permission) gin.HandlerFunc {
+	return authorizeMiddleware(capture, perm, false, true)
+}
+
+func authorizeMiddleware(
+	capture capture.Capture, perm security.Permission, namespaced, rbacOnly bool,
+) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if perm != security.PermissionRead {
+			defer auditLog(ctx, perm)
+		}
+		namespace := ""
+		if namespaced {
+			namespace = ctx.Query(api.APIOpVarNamespace)
+			if namespace == "" {
+				namespace = model.DefaultNamespace
+			}
+		}
+		if err := authorize(ctx, capture, perm, namespace, rbacOnly); err != nil {
+			switch {
+			case errors.ErrPermissionDenied.Equal(err):
+				ctx.IndentedJSON(http.StatusForbidden, model.NewHTTPError(err))
+			case errors.ErrUnauthorized.Equal(err), errors.ErrCredentialNotFound.Equal(err):
+				ctx.IndentedJSON(http.StatusUnauthorized, model.NewHTTPError(err))
+			default:
+				_ = ctx.Error(err)
+			}
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func authorize(
+	ctx *gin.Context, capture capture.Capture,
+	perm security.Permission, namespace string, rbacOnly bool,
+) error {
+	serverCfg := config.GetGlobalServerConfig()
+	if !serverCfg.Security.ClientUserRequired {
+		return nil
+	}
+	if rbacOnly && !serverCfg.Security.IsRBACEnabled() {
+		return nil
+	}
+	// The read-only APIs are not authenticated unless the roles are configured,
+	// to keep compatible with the clients which don't send the credential.
+	if perm == security.PermissionRead && !serverCfg.Security.IsRBACEnabled() {
+		return nil
+	}
+	username, err := authenticate(ctx, capture)
+	if err != nil {
+		return err
+	}
+	return checkPermission(ctx, serverCfg.Security, username, perm, namespace)
+}
+
+// checkPermission checks the role of the verified user, and saves the role
+// into the context for the later namespace checks.
+func checkPermission(
+	ctx *gin.Context, credential *security.Credential,
+	username string, perm security.Permission, namespace string,
+) error {
+	role, ok := credential.GetClientUserRole(username)
+	if !ok {
+		return errors.ErrPermissionDenied.GenWithStackByArgs(username, "no role is granted")
+	}
+	if !role.Allows(perm, namespace) {
+		errMsg := fmt.Sprintf("role %s is not granted the %s permission", role.Role, perm)
+		if role.Role.Grants(perm) {
+			errMsg = fmt.Sprintf("the user is limited to namespaces %v", role.Namespaces)
+		}
+		return errors.ErrPermissionDenied.GenWithStackByArgs(username, errMsg)
+	}
+	ctx.Set(userRoleKey, role)
+	return nil
+}
+
+func getUserRole(ctx *gin.Context) *security.ClientUserRole {
+	if v, ok := ctx.Get(userRoleKey); ok {
+		return v.(*security.ClientUserRole)
+	}
+	return nil
+}
+
+// AuthorizeNamespace checks whether the request user can access the namespace,
+// it's used by the APIs which take the namespace from the request body.
+func AuthorizeNamespace(ctx *gin.Context, namespace string) error {
+	if IsNamespaceAllowed(ctx, namespace) {
+		return nil
+	}
+	return errors.ErrPermissionDenied.GenWithStackByArgs(getUsername(ctx),
+		fmt.Sprintf("namespace %s is not accessible", namespace))
+}
+
+// IsNamespaceAllowed returns true if the request user can access the namespace,
+// all namespaces are allowed if the user is not authorized by AuthorizeMiddleware.
+func IsNamespaceAllowed(ctx *gin.Context, namespace string) bool {
+	role := getUserRole(ctx)
+	return role == nil || role.AllowsNamespace(namespace)
+}
+
+// auditLog logs the request which requires the write or admin permission,
+// no matter it's permitted or not.
+func auditLog(ctx *gin.Context, perm security.Permission) {
+	username := getUsername(ctx)
+	var role security.ClientRole
+	if r := getUserRole(ctx); r != nil {
+		role = r.Role
+	}
+	var stdErr error
+	if err := ctx.Errors.Last(); err != nil {
+		stdErr = err.Err
+	}
+	result := "success"
+	if ctx.IsAborted() || stdErr != nil || ctx.Writer.Status() >= http.StatusBadRequest {
+		result = "failed"
+	}
+	log.Info("cdc open api audit",
+		zap.String("username", username),
+		zap.String("role", string(role)),
+		zap.Stringer("permission", perm),
+		zap.String("method", ctx.Request.Method),
+		zap.String("path", ctx.Request.URL.Path),
+		zap.String("query", ctx.Request.URL.RawQuery),
+		zap.String("ip", ctx.ClientIP()),
+		zap.String("result", result),
+		zap.Error(stdErr),
+	)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPermission(t *testing.T) {
+	t.Parallel()
+
+	credential := &security.Credential{
+		ClientUserRequired: true,
+		ClientAllowedUser:  []string{"root", "ops", "monitor"},
+		ClientUserRoles: map[string]*security.ClientUserRole{
+			"root":    {Role: security.ClientRoleAdmin},
+			"ops":     {Role: security.ClientRoleOperator, Namespaces: []string{"team-a"}},
+			"monitor": {Role: security.ClientRoleViewer},
+		},
+	}
+	newContext := func() *gin.Context {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		return ctx
+	}
+
+	ctx := newContext()
+	require.NoError(t, checkPermission(ctx, credential, "root", security.PermissionAdmin, ""))
+	require.NoError(t, AuthorizeNamespace(ctx, "team-b"))
+
+	ctx = newContext()
+	err := checkPermission(ctx, credential, "monitor", security.PermissionWrite, "default")
+	require.True(t, errors.ErrPermissionDenied.Equal(err))
+	require.ErrorContains(t, err, "role viewer is not granted the write permission")
+	require.NoError(t, checkPermission(ctx, credential, "monitor", security.PermissionRead, "default"))
+
+	ctx = newContext()
+	err = checkPermission(ctx, credential, "ops", security.PermissionWrite, "default")
+	require.ErrorContains(t, err, "the user is limited to namespaces [team-a]")
+	err = checkPermission(ctx, credential, "ops", security.PermissionAdmin, "")
+	require.True(t, errors.ErrPermissionDenied.Equal(err))
+	require.NoError(t, checkPermission(ctx, credential, "ops", security.PermissionWrite, "team-a"))
+	// the namespace in the request body is checked by the handler.
+	require.True(t, IsNamespaceAllowed(ctx, "team-a"))
+	require.False(t, IsNamespaceAllowed(ctx, "team-b"))
+	require.True(t, errors.ErrPermissionDenied.Equal(AuthorizeNamespace(ctx, "team-b")))
+
+	err = checkPermission(newContext(), credential, "unknown", security.PermissionRead, "")
+	require.ErrorContains(t, err, "no role is granted")
+}
+
+func TestAuthorizeMiddlewareWithoutClientUser(t *testing.T) {
+	// the client user is not required by default, all requests are permitted.
+	router := gin.New()
+	router.Use(ErrorHandleMiddleware())
+	router.POST("/test/:changefeed_id",
+		AuthorizeNamespaceMiddleware(nil, security.PermissionWrite),
+		func(c *gin.Context) {
+			require.True(t, IsNamespaceAllowed(c, "any"))
+			c.Status(http.StatusOK)
+		})
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(),
+		http.MethodPost, "/test/cf?namespace=team-a", nil)
+	require.NoError(t, err)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthenticateBearerToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-ops ops\ntoken-guest guest\n"), 0o600))
+
+	oldCfg := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(oldCfg)
+	cfg := config.GetDefaultServerConfig()
+	cfg.Security.ClientUserRequired = true
+	cfg.Security.ClientAllowedUser = []string{"ops", "guest"}
+	cfg.Security.ClientUserRoles = map[string]*security.ClientUserRole{
+		"ops":   {Role: security.ClientRoleOperator},
+		"guest": {Role: security.ClientRoleViewer},
+	}
+	cfg.Security.ClientAuth = &security.ClientAuthConfig{TokenFile: tokenFile}
+	config.StoreGlobalServerConfig(cfg)
+
+	router := gin.New()
+	router.Use(ErrorHandleMiddleware())
+	router.POST("/test/:changefeed_id",
+		AuthorizeNamespaceMiddleware(nil, security.PermissionWrite),
+		func(c *gin.Context) {
+			require.Equal(t, "ops", getUsername(c))
+			c.Status(http.StatusOK)
+		})
+	request := func(authorization string) int {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(context.Background(),
+			http.MethodPost, "/test/cf", nil)
+		require.NoError(t, err)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, request("Bearer token-ops"))
+	require.Equal(t, http.StatusForbidden, request("Bearer token-guest"))
+	require.Equal(t, http.StatusUnauthorized, request("Bearer token-unknown"))
+	require.Equal(t, http.StatusUnauthorized, request(""))
+}
+
+func TestAuthorizeIfRBACEnabledMiddleware(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-root root\ntoken-guest guest\n"), 0o600))
+
+	oldCfg := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(oldCfg)
+	cfg := config.GetDefaultServerConfig()
+	cfg.Security.ClientUserRequired = true
+	cfg.Security.ClientAllowedUser = []string{"root", "guest"}
+	cfg.Security.ClientAuth = &security.ClientAuthConfig{TokenFile: tokenFile}
+	config.StoreGlobalServerConfig(cfg)
+
+	router := gin.New()
+	router.Use(ErrorHandleMiddleware())
+	router.POST("/log", AuthorizeIfRBACEnabledMiddleware(nil, security.PermissionAdmin),
+		func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+	request := func(authorization string) int {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/log", nil)
+		require.NoError(t, err)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// the API is not authenticated if only the client user is required.
+	require.Equal(t, http.StatusOK, request(""))
+
+	cfg = cfg.Clone()
+	cfg.Security.ClientUserRoles = map[string]*security.ClientUserRole{
+		"root":  {Role: security.ClientRoleAdmin},
+		"guest": {Role: security.ClientRoleViewer},
+	}
+	config.StoreGlobalServerConfig(cfg)
+	require.Equal(t, http.StatusOK, request("Bearer token-root"))
+	require.Equal(t, http.StatusForbidden, request("Bearer token-guest"))
+	require.Equal(t, http.StatusUnauthorized, request(""))
+}
@@ -39,6 +39,10 @@ var httpBadRequestError = []*errors.Error{
 	cerror.ErrChangeFeedNotExists, cerror.ErrTargetTsBeforeStartTs, cerror.ErrTableIneligible,
 	cerror.ErrFilterRuleInvalid, cerror.ErrChangefeedUpdateRefused, cerror.ErrMySQLConnectionError,
 	cerror.ErrMySQLInvalidConfig, cerror.ErrCaptureNotExist, cerror.ErrSchedulerRequestFailed,
+	cerror.ErrUpstreamAlreadyExists, cerror.ErrUpstreamInUse,
+	cerror.ErrNamespaceNotFound, cerror.ErrNamespaceAlreadyExists, cerror.ErrNamespaceInUse,
+	cerror.ErrNamespaceQuotaExceeded, cerror.ErrChangefeedTemplateNotFound,
+	cerror.ErrChangefeedTemplateAlreadyExists,
 }
 
 const (
@@ -63,6 +67,8 @@ const (
 	APIOpVarTiCDCUser = "user"
 	// APIOpVarTiCDCPassword is the key of ticdc password in HTTP API.
 	APIOpVarTiCDCPassword = "password"
+	// APIOpVarTiCDCToken is the key of ticdc bearer token in HTTP API.
+	APIOpVarTiCDCToken = "token"
 
 	// forwardFromCapture is a header to be set when forwarding requests to owner
 	forwardFromCapture = "TiCDC-ForwardFromCapture"
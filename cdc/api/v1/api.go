@@ -31,6 +31,7 @@ import (
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/logutil"
 	"github.com/pingcap/tiflow/pkg/retry"
+	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/pingcap/tiflow/pkg/version"
 	"github.com/tikv/client-go/v2/oracle"
@@ -72,15 +73,21 @@ func RegisterOpenAPIRoutes(router *gin.Engine, api OpenAPI) {
 	// common API
 	v1.GET("/status", api.ServerStatus)
 	v1.GET("/health", api.Health)
-	v1.POST("/log", SetLogLevel)
 
 	ownerMiddleware := middleware.ForwardToOwnerMiddleware(api.capture)
 	authenticateMiddleware := middleware.AuthenticateMiddleware(api.capture)
+	// The v1 APIs only access the default namespace, which is checked by the
+	// handlers, and the changefeeds of other namespaces are filtered out.
+	readMiddleware := middleware.AuthorizeMiddleware(api.capture, security.PermissionRead)
+	// the APIs not authenticated before RBAC are only checked if the roles are configured.
+	rbacAdminMiddleware := middleware.AuthorizeIfRBACEnabledMiddleware(api.capture, security.PermissionAdmin)
+
+	v1.POST("/log", rbacAdminMiddleware, SetLogLevel)
 
 	// changefeed API
 	changefeedGroup := v1.Group("/changefeeds")
-	changefeedGroup.GET("", ownerMiddleware, api.ListChangefeed)
-	changefeedGroup.GET("/:changefeed_id", ownerMiddleware, api.GetChangefeed)
+	changefeedGroup.GET("", ownerMiddleware, readMiddleware, api.ListChangefeed)
+	changefeedGroup.GET("/:changefeed_id", ownerMiddleware, readMiddleware, api.GetChangefeed)
 	changefeedGroup.POST("", ownerMiddleware, authenticateMiddleware, api.CreateChangefeed)
 	changefeedGroup.PUT("/:changefeed_id", ownerMiddleware, authenticateMiddleware, api.UpdateChangefeed)
 	changefeedGroup.POST("/:changefeed_id/pause", ownerMiddleware, authenticateMiddleware, api.PauseChangefeed)
@@ -91,19 +98,19 @@ func RegisterOpenAPIRoutes(router *gin.Engine, api OpenAPI) {
 
 	// owner API
 	ownerGroup := v1.Group("/owner")
-	ownerGroup.POST("/resign", ownerMiddleware, api.ResignController)
+	ownerGroup.POST("/resign", ownerMiddleware, rbacAdminMiddleware, api.ResignController)
 
 	// processor API
 	processorGroup := v1.Group("/processors")
-	processorGroup.GET("", ownerMiddleware, api.ListProcessor)
+	processorGroup.GET("", ownerMiddleware, readMiddleware, api.ListProcessor)
 	processorGroup.GET("/:changefeed_id/:capture_id",
-		ownerMiddleware, api.GetProcessor)
+		ownerMiddleware, readMiddleware, api.GetProcessor)
 
 	// capture API
 	captureGroup := v1.Group("/captures")
 	captureGroup.Use(ownerMiddleware)
-	captureGroup.GET("", api.ListCapture)
-	captureGroup.PUT("/drain", api.DrainCapture)
+	captureGroup.GET("", readMiddleware, api.ListCapture)
+	captureGroup.PUT("/drain", rbacAdminMiddleware, api.DrainCapture)
 }
 
 // ListChangefeed lists all changgefeeds in cdc cluster
@@ -159,6 +166,10 @@ func (h *OpenAPI) ListChangefeed(c *gin.Context) {
 		if !cfInfo.State.IsNeeded(state) {
 			continue
 		}
+		// skip the changefeeds of the namespaces not accessible by the user.
+		if !middleware.IsNamespaceAllowed(c, cfID.Namespace) {
+			continue
+		}
 
 		resp := &model.ChangefeedCommonInfo{
 			UpstreamID: cfInfo.UpstreamID,
@@ -196,6 +207,10 @@ func (h *OpenAPI) GetChangefeed(c *gin.Context) {
 			changefeedID.ID))
 		return
 	}
+	if err := middleware.AuthorizeNamespace(c, changefeedID.Namespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	info, err := h.statusProvider().GetChangeFeedInfo(ctx, changefeedID)
 	if err != nil {
@@ -713,6 +728,10 @@ func (h *OpenAPI) GetProcessor(c *gin.Context) {
 			changefeedID.ID))
 		return
 	}
+	if err := middleware.AuthorizeNamespace(c, changefeedID.Namespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	captureID := c.Param(api.APIOpVarCaptureID)
 	if err := model.ValidateChangefeedID(captureID); err != nil {
@@ -786,14 +805,18 @@ func (h *OpenAPI) ListProcessor(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
-	resps := make([]*model.ProcessorCommonInfo, len(infos))
-	for i, info := range infos {
+	resps := make([]*model.ProcessorCommonInfo, 0, len(infos))
+	for _, info := range infos {
+		// skip the processors of the namespaces not accessible by the user.
+		if !middleware.IsNamespaceAllowed(c, info.CfID.Namespace) {
+			continue
+		}
 		resp := &model.ProcessorCommonInfo{
 			Namespace: info.CfID.Namespace,
 			CfID:      info.CfID.ID,
 			CaptureID: info.CaptureID,
 		}
-		resps[i] = resp
+		resps = append(resps, resp)
 	}
 	c.IndentedJSON(http.StatusOK, resps)
 }
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -32,8 +34,10 @@ import (
 	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
 	"github.com/pingcap/tiflow/cdc/scheduler"
 	"github.com/pingcap/tiflow/pkg/check"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
+	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/stretchr/testify/mock"
@@ -124,6 +128,20 @@ func (p *mockStatusProvider) IsChangefeedExists(ctx context.Context, id model.Ch
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (p *mockStatusProvider) GetChangeFeedDiagnosis(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) (*model.OwnerDiagnosis, error) {
+	args := p.Called(ctx)
+	return args.Get(0).(*model.OwnerDiagnosis), args.Error(1)
+}
+
+func (p *mockStatusProvider) GetChangeFeedStatistics(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) (*model.ChangefeedStatistics, error) {
+	args := p.Called(ctx)
+	return args.Get(0).(*model.ChangefeedStatistics), args.Error(1)
+}
+
 func newRouter(c capture.Capture, p owner.StatusProvider) *gin.Engine {
 	router := gin.New()
 	RegisterOpenAPIRoutes(router, NewOpenAPI4Test(c, p))
@@ -1075,6 +1093,74 @@ func TestHealth(t *testing.T) {
 	require.Equal(t, 200, w.Code)
 }
 
+func TestAuthorizeAPIs(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-ops ops\ntoken-guest guest\n"), 0o600))
+
+	oldCfg := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(oldCfg)
+	cfg := config.GetDefaultServerConfig()
+	cfg.Security.ClientUserRequired = true
+	cfg.Security.ClientAllowedUser = []string{"ops", "guest"}
+	cfg.Security.ClientUserRoles = map[string]*security.ClientUserRole{
+		"ops":   {Role: security.ClientRoleOperator},
+		"guest": {Role: security.ClientRoleViewer, Namespaces: []string{"ab"}},
+	}
+	cfg.Security.ClientAuth = &security.ClientAuthConfig{TokenFile: tokenFile}
+	config.StoreGlobalServerConfig(cfg)
+
+	ctrl := gomock.NewController(t)
+	provider := mock_owner.NewMockStatusProvider(ctrl)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().StatusProvider().Return(provider).AnyTimes()
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	provider.EXPECT().GetAllChangeFeedCheckpointTs(gomock.Any()).Return(
+		map[model.ChangeFeedID]uint64{
+			model.ChangeFeedID4Test("ab", "123"):  1,
+			model.ChangeFeedID4Test("def", "456"): 2,
+		}, nil).AnyTimes()
+	provider.EXPECT().GetAllChangeFeedInfo(gomock.Any()).Return(
+		map[model.ChangeFeedID]*model.ChangeFeedInfo{
+			model.ChangeFeedID4Test("ab", "123"):  {State: model.StateNormal},
+			model.ChangeFeedID4Test("def", "456"): {State: model.StateNormal},
+		}, nil).AnyTimes()
+	router := newRouterWithoutStatusProvider(cp)
+	request := func(api testCase, authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), api.method, api.url, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// the admin APIs are rejected before reaching the handlers.
+	for _, api := range []testCase{
+		{url: "/api/v1/log", method: "POST"},
+		{url: "/api/v1/owner/resign", method: "POST"},
+		{url: "/api/v1/captures/drain", method: "PUT"},
+	} {
+		require.Equal(t, http.StatusUnauthorized, request(api, "").Code, api.url)
+		require.Equal(t, http.StatusForbidden, request(api, "Bearer token-ops").Code, api.url)
+	}
+
+	// the read APIs require a role, and only the accessible namespaces are listed.
+	listChangefeeds := testCase{url: "/api/v1/changefeeds", method: "GET"}
+	require.Equal(t, http.StatusUnauthorized, request(listChangefeeds, "").Code)
+	w := request(listChangefeeds, "Bearer token-guest")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp []model.ChangefeedCommonInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp, 1)
+	require.Equal(t, "ab", resp[0].Namespace)
+
+	// the v1 APIs access the changefeeds in the default namespace.
+	getChangefeed := testCase{url: "/api/v1/changefeeds/test?namespace=ab", method: "GET"}
+	require.Equal(t, http.StatusForbidden, request(getChangefeed, "Bearer token-guest").Code)
+}
+
 // TODO: finished these test cases after we decouple those APIs from etcdClient.
 func TestCreateChangefeed(t *testing.T) {}
 func TestUpdateChangefeed(t *testing.T) {}
@@ -17,22 +17,26 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/tiflow/cdc/api/middleware"
 	"github.com/pingcap/tiflow/cdc/capture"
+	"github.com/pingcap/tiflow/pkg/security"
 )
 
 // OpenAPIV2 provides CDC v2 APIs
 type OpenAPIV2 struct {
 	capture capture.Capture
 	helpers APIV2Helpers
+	// quotaLocks serializes the changefeeds of a namespace being checked
+	// against the quota and saved.
+	quotaLocks *namespaceLocks
 }
 
 // NewOpenAPIV2 creates a new OpenAPIV2.
 func NewOpenAPIV2(c capture.Capture) OpenAPIV2 {
-	return OpenAPIV2{c, APIV2HelpersImpl{}}
+	return OpenAPIV2{c, APIV2HelpersImpl{}, newNamespaceLocks()}
 }
 
 // NewOpenAPIV2ForTest creates a new OpenAPIV2.
 func NewOpenAPIV2ForTest(c capture.Capture, h APIV2Helpers) OpenAPIV2 {
-	return OpenAPIV2{c, h}
+	return OpenAPIV2{c, h, newNamespaceLocks()}
 }
 
 // RegisterOpenAPIV2Routes registers routes for OpenAPI
@@ -43,52 +47,103 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	v2.Use(middleware.LogMiddleware())
 	v2.Use(middleware.ErrorHandleMiddleware())
 
+	ownerMiddleware := middleware.ForwardToOwnerMiddleware(api.capture)
+	// The role of the user is checked by the authorize middlewares, the
+	// namespace middlewares also check the changefeed namespace of the request.
+	readMiddleware := middleware.AuthorizeMiddleware(api.capture, security.PermissionRead)
+	adminMiddleware := middleware.AuthorizeMiddleware(api.capture, security.PermissionAdmin)
+	// the APIs not authenticated before RBAC are only checked if the roles are configured.
+	rbacAdminMiddleware := middleware.AuthorizeIfRBACEnabledMiddleware(api.capture, security.PermissionAdmin)
+	namespaceReadMiddleware := middleware.AuthorizeNamespaceMiddleware(api.capture, security.PermissionRead)
+	namespaceWriteMiddleware := middleware.AuthorizeNamespaceMiddleware(api.capture, security.PermissionWrite)
+
 	v2.GET("health", api.health)
 	v2.GET("status", api.serverStatus)
-	v2.POST("log", api.setLogLevel)
-
-	ownerMiddleware := middleware.ForwardToOwnerMiddleware(api.capture)
-	authenticateMiddleware := middleware.AuthenticateMiddleware(api.capture)
+	v2.POST("log", rbacAdminMiddleware, api.setLogLevel)
 
 	// changefeed apis
 	changefeedGroup := v2.Group("/changefeeds")
-	changefeedGroup.GET("/:changefeed_id", ownerMiddleware, api.getChangeFeed)
-	changefeedGroup.POST("", ownerMiddleware, authenticateMiddleware, api.createChangefeed)
-	changefeedGroup.GET("", ownerMiddleware, api.listChangeFeeds)
-	changefeedGroup.PUT("/:changefeed_id", ownerMiddleware, authenticateMiddleware, api.updateChangefeed)
-	changefeedGroup.DELETE("/:changefeed_id", ownerMiddleware, authenticateMiddleware, api.deleteChangefeed)
-	changefeedGroup.GET("/:changefeed_id/meta_info", ownerMiddleware, api.getChangeFeedMetaInfo)
-	changefeedGroup.POST("/:changefeed_id/resume", ownerMiddleware, authenticateMiddleware, api.resumeChangefeed)
-	changefeedGroup.POST("/:changefeed_id/pause", ownerMiddleware, authenticateMiddleware, api.pauseChangefeed)
-	changefeedGroup.GET("/:changefeed_id/status", ownerMiddleware, api.status)
-	changefeedGroup.GET("/:changefeed_id/synced", ownerMiddleware, api.synced)
+	changefeedGroup.GET("/:changefeed_id", ownerMiddleware, namespaceReadMiddleware, api.getChangeFeed)
+	// the namespace of the new changefeed is checked after the request body is parsed.
+	changefeedGroup.POST("", ownerMiddleware, middleware.AuthorizeMiddleware(api.capture, security.PermissionWrite),
+		api.createChangefeed)
+	changefeedGroup.GET("", ownerMiddleware, namespaceReadMiddleware, api.listChangeFeeds)
+	changefeedGroup.PUT("/:changefeed_id", ownerMiddleware, namespaceWriteMiddleware, api.updateChangefeed)
+	changefeedGroup.DELETE("/:changefeed_id", ownerMiddleware, namespaceWriteMiddleware, api.deleteChangefeed)
+	changefeedGroup.GET("/:changefeed_id/meta_info", ownerMiddleware, namespaceReadMiddleware, api.getChangeFeedMetaInfo)
+	changefeedGroup.POST("/:changefeed_id/resume", ownerMiddleware, namespaceWriteMiddleware, api.resumeChangefeed)
+	changefeedGroup.POST("/:changefeed_id/pause", ownerMiddleware, namespaceWriteMiddleware, api.pauseChangefeed)
+	changefeedGroup.GET("/:changefeed_id/status", ownerMiddleware, namespaceReadMiddleware, api.status)
+	changefeedGroup.GET("/:changefeed_id/synced", ownerMiddleware, namespaceReadMiddleware, api.synced)
+	changefeedGroup.GET("/:changefeed_id/schema_history", ownerMiddleware, namespaceReadMiddleware, api.getSchemaHistory)
+	changefeedGroup.GET("/:changefeed_id/export", ownerMiddleware, namespaceReadMiddleware, api.exportChangefeed)
+	changefeedGroup.POST("/import", ownerMiddleware, middleware.AuthorizeMiddleware(api.capture, security.PermissionWrite),
+		api.importChangefeed)
+	changefeedGroup.POST("/:changefeed_id/clone", ownerMiddleware, namespaceWriteMiddleware, api.cloneChangefeed)
+	changefeedGroup.GET("/:changefeed_id/resolved_ts_diagnosis", ownerMiddleware, namespaceReadMiddleware,
+		api.getResolvedTsDiagnosis)
+	changefeedGroup.GET("/:changefeed_id/statistics", ownerMiddleware, namespaceReadMiddleware,
+		api.getChangefeedStatistics)
 
 	// capture apis
 	captureGroup := v2.Group("/captures")
 	captureGroup.Use(ownerMiddleware)
-	captureGroup.POST("/:capture_id/drain", api.drainCapture)
-	captureGroup.GET("", api.listCaptures)
+	captureGroup.POST("/:capture_id/drain", rbacAdminMiddleware, api.drainCapture)
+	captureGroup.GET("", readMiddleware, api.listCaptures)
+
+	// upstream apis
+	upstreamGroup := v2.Group("/upstreams")
+	upstreamGroup.Use(ownerMiddleware)
+	upstreamGroup.GET("", namespaceReadMiddleware, api.listUpstreams)
+	upstreamGroup.POST("", namespaceWriteMiddleware, api.createUpstream)
+	upstreamGroup.DELETE("/:upstream_id", namespaceWriteMiddleware, api.deleteUpstream)
+	upstreamGroup.GET("/:upstream_id/health", namespaceReadMiddleware, api.getUpstreamHealth)
+	upstreamGroup.GET("/:upstream_id/gc_safepoint", namespaceReadMiddleware, api.getUpstreamGCSafePoint)
+
+	// namespace apis, the settings of namespaces are managed by the admins.
+	namespaceGroup := v2.Group("/namespaces")
+	namespaceGroup.Use(ownerMiddleware)
+	// the namespaces not accessible by the user are checked by the handlers.
+	namespaceGroup.GET("", readMiddleware, api.listNamespaces)
+	namespaceGroup.GET("/:namespace", readMiddleware, api.getNamespace)
+	namespaceGroup.GET("/:namespace/changefeed_config", readMiddleware, api.getNamespaceChangefeedConfig)
+	namespaceGroup.POST("", adminMiddleware, api.createNamespace)
+	namespaceGroup.PUT("/:namespace", adminMiddleware, api.updateNamespace)
+	namespaceGroup.DELETE("/:namespace", adminMiddleware, api.deleteNamespace)
+
+	// changefeed template apis
+	templateGroup := v2.Group("/templates")
+	templateGroup.Use(ownerMiddleware)
+	templateGroup.GET("", namespaceReadMiddleware, api.listChangefeedTemplates)
+	templateGroup.GET("/:template_name", namespaceReadMiddleware, api.getChangefeedTemplate)
+	templateGroup.POST("", namespaceWriteMiddleware, api.createChangefeedTemplate)
+	templateGroup.PUT("/:template_name", namespaceWriteMiddleware, api.updateChangefeedTemplate)
+	templateGroup.DELETE("/:template_name", namespaceWriteMiddleware, api.deleteChangefeedTemplate)
 
 	// processor apis
 	processorGroup := v2.Group("/processors")
-	processorGroup.GET("/:changefeed_id/:capture_id", ownerMiddleware, api.getProcessor)
-	processorGroup.GET("", ownerMiddleware, api.listProcessors)
+	processorGroup.GET("/:changefeed_id/:capture_id", ownerMiddleware, namespaceReadMiddleware, api.getProcessor)
+	// the diagnosis is answered by the capture which runs the processor.
+	processorGroup.GET("/:changefeed_id/:capture_id/resolved_ts_diagnosis", namespaceReadMiddleware,
+		api.getProcessorResolvedTsDiagnosis)
+	// the processors of the namespaces not accessible are filtered out.
+	processorGroup.GET("", ownerMiddleware, readMiddleware, api.listProcessors)
 
 	verifyTableGroup := v2.Group("/verify_table")
-	verifyTableGroup.POST("", api.verifyTable)
+	verifyTableGroup.POST("", readMiddleware, api.verifyTable)
 
 	// unsafe apis
 	unsafeGroup := v2.Group("/unsafe")
 	unsafeGroup.Use(ownerMiddleware)
-	unsafeGroup.GET("/metadata", authenticateMiddleware, api.CDCMetaData)
-	unsafeGroup.POST("/resolve_lock", authenticateMiddleware, api.ResolveLock)
-	unsafeGroup.DELETE("/service_gc_safepoint", authenticateMiddleware, api.DeleteServiceGcSafePoint)
+	unsafeGroup.GET("/metadata", adminMiddleware, api.CDCMetaData)
+	unsafeGroup.POST("/resolve_lock", adminMiddleware, api.ResolveLock)
+	unsafeGroup.DELETE("/service_gc_safepoint", adminMiddleware, api.DeleteServiceGcSafePoint)
 
 	// owner apis
 	ownerGroup := v2.Group("/owner")
 	ownerGroup.Use(ownerMiddleware)
-	ownerGroup.POST("/resign", api.resignOwner)
+	ownerGroup.POST("/resign", rbacAdminMiddleware, api.resignOwner)
 
 	// common APIs
-	v2.POST("/tso", api.QueryTso)
+	v2.POST("/tso", readMiddleware, api.QueryTso)
 }
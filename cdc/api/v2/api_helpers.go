@@ -16,7 +16,11 @@ package v2
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +38,9 @@ import (
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/txnutil/gc"
@@ -103,6 +110,12 @@ type APIV2Helpers interface {
 		credential *security.Credential,
 	) (pd.Client, error)
 
+	// getPDAPIClient returns a client of the PD http API
+	getPDAPIClient(
+		pdClient pd.Client,
+		credential *security.Credential,
+	) (pdutil.PDAPIClient, error)
+
 	// getEtcdClient returns an Etcd client given the PD endpoints and
 	// tls config
 	getEtcdClient(
@@ -127,6 +140,25 @@ type APIV2Helpers interface {
 	) (ineligibleTables,
 		eligibleTables []model.TableName, err error,
 	)
+
+	// exportSchemaHistory wraps entry.ExportSchemaHistory to increase testability
+	exportSchemaHistory(
+		ctx context.Context,
+		changefeedID model.ChangeFeedID,
+		replicaConfig *config.ReplicaConfig,
+		storage tidbkv.Storage,
+		startTs, endTs uint64,
+	) (*schemahistory.History, error)
+
+	// diagnoseProcessor gets the resolved ts diagnosis of a changefeed
+	// from a remote capture
+	diagnoseProcessor(
+		ctx context.Context,
+		capture *model.CaptureInfo,
+		changefeedID model.ChangeFeedID,
+		limit int,
+		header http.Header,
+	) (*model.ProcessorDiagnosis, error)
 }
 
 // APIV2HelpersImpl is an implementation of AVIV2Helpers interface
@@ -179,10 +211,13 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 		return nil, cerror.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client")
 	}
 	currentTSO := oracle.ComposeTS(ts, logical)
+	// a changefeed with delayed start waits until the upstream reaches its start-ts
+	delayedStart := cfg.ReplicaConfig != nil && cfg.ReplicaConfig.Schedule != nil &&
+		cfg.ReplicaConfig.Schedule.DelayedStart
 	// verify start ts
 	if cfg.StartTs == 0 {
 		cfg.StartTs = currentTSO
-	} else if cfg.StartTs > currentTSO {
+	} else if cfg.StartTs > currentTSO && !delayedStart {
 		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
 			"invalid start-ts %v, larger than current tso %v", cfg.StartTs, currentTSO)
 	}
@@ -222,7 +257,13 @@ func (APIV2HelpersImpl) verifyCreateChangefeedConfig(
 	if err != nil {
 		return nil, errors.Cause(err)
 	}
-	tableInfos, ineligibleTables, _, err := entry.VerifyTables(f, kvStorage, cfg.StartTs)
+	// the tables can't be read at a start-ts in the future, verify them at
+	// the current tso instead.
+	verifyTs := cfg.StartTs
+	if verifyTs > currentTSO {
+		verifyTs = currentTSO
+	}
+	tableInfos, ineligibleTables, _, err := entry.VerifyTables(f, kvStorage, verifyTs)
 	if err != nil {
 		return nil, errors.Cause(err)
 	}
@@ -478,6 +519,14 @@ func (APIV2HelpersImpl) getPDClient(
 	return pdClient, nil
 }
 
+// getPDAPIClient wraps pdutil.NewPDAPIClient to increase testability
+func (APIV2HelpersImpl) getPDAPIClient(
+	pdClient pd.Client,
+	credential *security.Credential,
+) (pdutil.PDAPIClient, error) {
+	return pdutil.NewPDAPIClient(pdClient, credential)
+}
+
 func (h APIV2HelpersImpl) getEtcdClient(
 	ctx context.Context,
 	pdAddrs []string,
@@ -582,3 +631,54 @@ func (h APIV2HelpersImpl) getVerifiedTables(
 
 	return ineligibleTables, eligibleTables, nil
 }
+
+func (h APIV2HelpersImpl) exportSchemaHistory(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	replicaConfig *config.ReplicaConfig,
+	storage tidbkv.Storage,
+	startTs, endTs uint64,
+) (*schemahistory.History, error) {
+	f, err := filter.NewFilter(replicaConfig, "")
+	if err != nil {
+		return nil, err
+	}
+	return entry.ExportSchemaHistory(ctx, changefeedID, storage, f, startTs, endTs)
+}
+
+func (h APIV2HelpersImpl) diagnoseProcessor(
+	ctx context.Context,
+	capture *model.CaptureInfo,
+	changefeedID model.ChangeFeedID,
+	limit int,
+	header http.Header,
+) (*model.ProcessorDiagnosis, error) {
+	credential := config.GetGlobalServerConfig().Security
+	cli, err := httputil.NewClient(credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	scheme := "http"
+	if tlsConfig, _ := credential.ToTLSConfigWithVerify(); tlsConfig != nil {
+		scheme = "https"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   capture.AdvertiseAddr,
+		Path: fmt.Sprintf("/api/v2/processors/%s/%s/resolved_ts_diagnosis",
+			changefeedID.ID, capture.ID),
+		RawQuery: url.Values{
+			"namespace":   []string{changefeedID.Namespace},
+			apiOpVarLimit: []string{strconv.Itoa(limit)},
+		}.Encode(),
+	}
+	content, err := cli.DoRequest(ctx, u.String(), http.MethodGet, header, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := &model.ProcessorDiagnosis{}
+	if err := json.Unmarshal(content, res); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
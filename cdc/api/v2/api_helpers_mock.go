@@ -7,6 +7,7 @@ package v2
 import (
 	context "context"
 	tls "crypto/tls"
+	http "net/http"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -14,6 +15,8 @@ import (
 	model "github.com/pingcap/tiflow/cdc/model"
 	owner "github.com/pingcap/tiflow/cdc/owner"
 	config "github.com/pingcap/tiflow/pkg/config"
+	pdutil "github.com/pingcap/tiflow/pkg/pdutil"
+	schemahistory "github.com/pingcap/tiflow/pkg/schemahistory"
 	security "github.com/pingcap/tiflow/pkg/security"
 	client "github.com/tikv/pd/client"
 	v3 "go.etcd.io/etcd/client/v3"
@@ -57,6 +60,36 @@ func (mr *MockAPIV2HelpersMockRecorder) createTiStore(ctx, pdAddrs, credential i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createTiStore", reflect.TypeOf((*MockAPIV2Helpers)(nil).createTiStore), ctx, pdAddrs, credential)
 }
 
+// diagnoseProcessor mocks base method.
+func (m *MockAPIV2Helpers) diagnoseProcessor(ctx context.Context, capture *model.CaptureInfo, changefeedID model.ChangeFeedID, limit int, header http.Header) (*model.ProcessorDiagnosis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "diagnoseProcessor", ctx, capture, changefeedID, limit, header)
+	ret0, _ := ret[0].(*model.ProcessorDiagnosis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// diagnoseProcessor indicates an expected call of diagnoseProcessor.
+func (mr *MockAPIV2HelpersMockRecorder) diagnoseProcessor(ctx, capture, changefeedID, limit, header interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "diagnoseProcessor", reflect.TypeOf((*MockAPIV2Helpers)(nil).diagnoseProcessor), ctx, capture, changefeedID, limit, header)
+}
+
+// exportSchemaHistory mocks base method.
+func (m *MockAPIV2Helpers) exportSchemaHistory(ctx context.Context, changefeedID model.ChangeFeedID, replicaConfig *config.ReplicaConfig, storage kv.Storage, startTs, endTs uint64) (*schemahistory.History, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "exportSchemaHistory", ctx, changefeedID, replicaConfig, storage, startTs, endTs)
+	ret0, _ := ret[0].(*schemahistory.History)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// exportSchemaHistory indicates an expected call of exportSchemaHistory.
+func (mr *MockAPIV2HelpersMockRecorder) exportSchemaHistory(ctx, changefeedID, replicaConfig, storage, startTs, endTs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "exportSchemaHistory", reflect.TypeOf((*MockAPIV2Helpers)(nil).exportSchemaHistory), ctx, changefeedID, replicaConfig, storage, startTs, endTs)
+}
+
 // getEtcdClient mocks base method.
 func (m *MockAPIV2Helpers) getEtcdClient(ctx context.Context, pdAddrs []string, tlsConfig *tls.Config) (*v3.Client, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +105,21 @@ func (mr *MockAPIV2HelpersMockRecorder) getEtcdClient(ctx, pdAddrs, tlsConfig in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getEtcdClient", reflect.TypeOf((*MockAPIV2Helpers)(nil).getEtcdClient), ctx, pdAddrs, tlsConfig)
 }
 
+// getPDAPIClient mocks base method.
+func (m *MockAPIV2Helpers) getPDAPIClient(pdClient client.Client, credential *security.Credential) (pdutil.PDAPIClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPDAPIClient", pdClient, credential)
+	ret0, _ := ret[0].(pdutil.PDAPIClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPDAPIClient indicates an expected call of getPDAPIClient.
+func (mr *MockAPIV2HelpersMockRecorder) getPDAPIClient(pdClient, credential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPDAPIClient", reflect.TypeOf((*MockAPIV2Helpers)(nil).getPDAPIClient), pdClient, credential)
+}
+
 // getPDClient mocks base method.
 func (m *MockAPIV2Helpers) getPDClient(ctx context.Context, pdAddrs []string, credential *security.Credential) (client.Client, error) {
 	m.ctrl.T.Helper()
@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -28,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	tidbkv "github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/api/middleware"
 	"github.com/pingcap/tiflow/cdc/capture"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/check"
@@ -61,13 +63,76 @@ const (
 // @Failure 500,400 {object} model.HTTPError
 // @Router	/api/v2/changefeeds [post]
 func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
-	ctx := c.Request.Context()
-	cfg := &ChangefeedConfig{ReplicaConfig: GetDefaultReplicaConfig()}
-
-	if err := c.BindJSON(&cfg); err != nil {
+	body, err := c.GetRawData()
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	// the namespace and the template are decoded first to find out
+	// the config the changefeed inherits.
+	probe := &ChangefeedConfig{}
+	if err := json.Unmarshal(body, probe); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	namespace := probe.Namespace
+	if namespace == "" {
+		namespace = model.DefaultNamespace
+	}
+	if err := middleware.AuthorizeNamespace(c, namespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfg, err := h.resolveChangefeedConfig(c.Request.Context(), namespace, probe.Template)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	templateSinkURI := cfg.SinkURI
+	if err := json.Unmarshal(body, cfg); err != nil {
 		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
 		return
 	}
+	if cfg.SinkURI == "" {
+		cfg.SinkURI = templateSinkURI
+	}
+	h.createChangefeedWithConfig(c, cfg)
+}
+
+// createChangefeedWithConfig creates a changefeed with the given config and
+// responds with the created changefeed, it's shared by create, import and clone.
+func (h *OpenAPIV2) createChangefeedWithConfig(c *gin.Context, cfg *ChangefeedConfig) {
+	ctx := c.Request.Context()
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = model.DefaultNamespace
+	}
+	if err := middleware.AuthorizeNamespace(c, namespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	// create the changefeed against a registered upstream.
+	var namedUpstream *model.UpstreamInfo
+	if cfg.UpstreamName != "" {
+		if len(cfg.PDAddrs) != 0 {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+				"pd_addrs and upstream_name can not be set at the same time"))
+			return
+		}
+		var err error
+		namedUpstream, err = h.getUpstreamByName(ctx, namespace, cfg.UpstreamName)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if namedUpstream.PDEndpoints != "" {
+			cfg.PDAddrs = strings.Split(namedUpstream.PDEndpoints, ",")
+		}
+		cfg.CAPath = namedUpstream.CAPath
+		cfg.CertPath = namedUpstream.CertPath
+		cfg.KeyPath = namedUpstream.KeyPath
+		cfg.CertAllowedCN = namedUpstream.CertAllowedCN
+	}
 	var pdClient pd.Client
 	var kvStorage kv.Storage
 	// if PDAddrs is empty, use the default pdClient
@@ -134,8 +199,29 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 			return
 		}
 	}()
+	if namedUpstream != nil && namedUpstream.ID != info.UpstreamID {
+		needRemoveGCSafePoint = true
+		_ = c.Error(cerror.ErrUpstreamMissMatch.GenWithStackByArgs(
+			namedUpstream.ID, info.UpstreamID))
+		return
+	}
+	var memoryQuota uint64
+	if info.Config != nil {
+		memoryQuota = info.Config.MemoryQuota
+	}
+	// the quota lock is held until the changefeed is saved.
+	unlock := h.quotaLocks.lock(info.Namespace)
+	defer unlock()
+	err = h.checkNamespaceQuota(ctx,
+		model.ChangeFeedID{Namespace: info.Namespace, ID: info.ID}, memoryQuota)
+	if err != nil {
+		needRemoveGCSafePoint = true
+		_ = c.Error(err)
+		return
+	}
 	upstreamInfo := &model.UpstreamInfo{
 		ID:            info.UpstreamID,
+		Name:          cfg.UpstreamName,
 		PDEndpoints:   strings.Join(cfg.PDAddrs, ","),
 		KeyPath:       cfg.KeyPath,
 		CertPath:      cfg.CertPath,
@@ -158,7 +244,14 @@ func (h *OpenAPIV2) createChangefeed(c *gin.Context) {
 
 	var etcdCli *clientv3.Client
 	if len(cfg.PDAddrs) == 0 {
-		etcdCli = h.capture.GetEtcdClient().GetEtcdClient().Unwrap()
+		// The etcd of the default upstream may differ from the one of the
+		// cluster metadata, which can be stored in the meta store.
+		up, err := getCaptureDefaultUpstream(h.capture)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		etcdCli = up.GetEtcdClient().Unwrap()
 	} else {
 		credential := cfg.PDConfig.toCredential()
 		// cannot create changefeed if there are running lightning/restore tasks
@@ -520,6 +613,18 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		_ = c.Error(errors.Trace(err))
 		return
 	}
+	// the quota of the namespace is only checked if more memory is required,
+	// the quota lock is held until the changefeed is saved.
+	unlock := h.quotaLocks.lock(namespace)
+	defer unlock()
+	if newCfInfo.Config != nil && (oldCfInfo.Config == nil ||
+		newCfInfo.Config.MemoryQuota > oldCfInfo.Config.MemoryQuota) {
+		err = h.checkNamespaceQuota(ctx, changefeedID, newCfInfo.Config.MemoryQuota)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
 
 	notSame, err := check.UpstreamDownstreamNotSame(ctx, pdClient, newCfInfo.SinkURI)
 	if err != nil {
@@ -0,0 +1,267 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+)
+
+const apiOpVarStartTsPolicy = "start_ts_policy"
+
+// exportChangefeed exports a changefeed as a portable document
+// @Summary Export a changefeed
+// @Description export a changefeed as a portable document, the secrets in it are replaced with variables
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param start_ts_policy query string false "now or checkpoint"
+// @Success 200 {object} ChangefeedExport
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/export [get]
+func (h *OpenAPIV2) exportChangefeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	changefeedID := model.ChangeFeedID{Namespace: namespace, ID: c.Param(api.APIOpVarChangefeedID)}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	policy := c.DefaultQuery(apiOpVarStartTsPolicy, StartTsPolicyNow)
+	if policy != StartTsPolicyNow && policy != StartTsPolicyCheckpoint {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid start_ts_policy: %s", policy))
+		return
+	}
+
+	cfInfo, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	status, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	doc, err := toChangefeedExport(cfInfo, status.CheckpointTs, policy)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
+
+// importChangefeed creates a changefeed from an exported document
+// @Summary Import a changefeed
+// @Description create a changefeed from a document exported by the export API
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed body ChangefeedImportConfig true "import config"
+// @Success 200 {object} ChangeFeedInfo
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/import [post]
+func (h *OpenAPIV2) importChangefeed(c *gin.Context) {
+	importCfg := &ChangefeedImportConfig{
+		Document: &ChangefeedExport{ReplicaConfig: GetDefaultReplicaConfig()},
+	}
+	if err := c.BindJSON(importCfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	cfg, err := importCfg.toChangefeedConfig()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.createChangefeedWithConfig(c, cfg)
+}
+
+// cloneChangefeed creates a changefeed from an existing one
+// @Summary Clone a changefeed
+// @Description create a changefeed with the config of an existing changefeed in the same cluster
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param changefeed body ChangefeedCloneConfig true "clone config"
+// @Success 200 {object} ChangeFeedInfo
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/clone [post]
+func (h *OpenAPIV2) cloneChangefeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	changefeedID := model.ChangeFeedID{Namespace: namespace, ID: c.Param(api.APIOpVarChangefeedID)}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	cloneCfg := &ChangefeedCloneConfig{}
+	if err := c.BindJSON(cloneCfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	if cloneCfg.ID == "" {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"changefeed_id of the new changefeed is required"))
+		return
+	}
+
+	cfInfo, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	status, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfg := &ChangefeedConfig{
+		Namespace:     cloneCfg.Namespace,
+		ID:            cloneCfg.ID,
+		StartTs:       cloneCfg.StartTs,
+		TargetTs:      cfInfo.TargetTs,
+		SinkURI:       cloneCfg.SinkURI,
+		ReplicaConfig: ToAPIReplicaConfig(cfInfo.Config),
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = changefeedID.Namespace
+	}
+	if cfg.StartTs == 0 {
+		cfg.StartTs = status.CheckpointTs
+	}
+	if cfg.SinkURI == "" {
+		cfg.SinkURI = cfInfo.SinkURI
+	}
+
+	// create the new changefeed against the upstream of the source changefeed.
+	up, err := getCaptureDefaultUpstream(h.capture)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if cfInfo.UpstreamID != up.ID {
+		upInfo, err := h.capture.GetUpstreamInfo(ctx, cfInfo.UpstreamID, changefeedID.Namespace)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if upInfo.Name != "" && cfg.Namespace == changefeedID.Namespace {
+			cfg.UpstreamName = upInfo.Name
+		} else {
+			cfg.PDAddrs = strings.Split(upInfo.PDEndpoints, ",")
+			cfg.CAPath = upInfo.CAPath
+			cfg.CertPath = upInfo.CertPath
+			cfg.KeyPath = upInfo.KeyPath
+			cfg.CertAllowedCN = upInfo.CertAllowedCN
+		}
+	}
+	h.createChangefeedWithConfig(c, cfg)
+}
+
+// toChangefeedExport converts a changefeed to a ChangefeedExport, the
+// secrets of the changefeed are replaced with variables.
+func toChangefeedExport(
+	info *model.ChangeFeedInfo, checkpointTs uint64, policy string,
+) (*ChangefeedExport, error) {
+	sinkURI, variables, err := util.TemplateSensitiveDataInURI(info.SinkURI, "sink")
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	replicaConfig := info.Config.Clone()
+	configVariables, err := replicaConfig.TemplateSensitiveData()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ChangefeedExport{
+		FormatVersion: ChangefeedExportFormatVersion,
+		Namespace:     info.Namespace,
+		ID:            info.ID,
+		SinkURI:       sinkURI,
+		StartTsPolicy: policy,
+		CheckpointTs:  checkpointTs,
+		TargetTs:      info.TargetTs,
+		Variables:     append(variables, configVariables...),
+		ReplicaConfig: ToAPIReplicaConfig(replicaConfig),
+	}, nil
+}
+
+// toChangefeedConfig converts the import config to a ChangefeedConfig, the
+// variables in the document are rendered with the given values.
+func (cfg *ChangefeedImportConfig) toChangefeedConfig() (*ChangefeedConfig, error) {
+	doc := cfg.Document
+	if doc == nil {
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack("document is required")
+	}
+	if doc.FormatVersion > ChangefeedExportFormatVersion {
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"unsupported format_version %d, the latest supported version is %d",
+			doc.FormatVersion, ChangefeedExportFormatVersion)
+	}
+	res := &ChangefeedConfig{
+		Namespace:    doc.Namespace,
+		ID:           doc.ID,
+		TargetTs:     doc.TargetTs,
+		UpstreamName: cfg.UpstreamName,
+	}
+	if cfg.Namespace != "" {
+		res.Namespace = cfg.Namespace
+	}
+	if cfg.ID != "" {
+		res.ID = cfg.ID
+	}
+	if cfg.TargetTs != 0 {
+		res.TargetTs = cfg.TargetTs
+	}
+	switch {
+	case cfg.StartTs != 0:
+		res.StartTs = cfg.StartTs
+	case doc.StartTsPolicy == StartTsPolicyCheckpoint:
+		res.StartTs = doc.CheckpointTs
+	case doc.StartTsPolicy == StartTsPolicyNow, doc.StartTsPolicy == "":
+		// the current ts of the upstream is used if StartTs is 0.
+	default:
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid start_ts_policy: %s", doc.StartTsPolicy)
+	}
+
+	var missing []string
+	res.SinkURI, missing = util.RenderURITemplate(doc.SinkURI, cfg.Variables)
+	replicaConfig := GetDefaultReplicaConfig().ToInternalReplicaConfig()
+	if doc.ReplicaConfig != nil {
+		replicaConfig = doc.ReplicaConfig.ToInternalReplicaConfig()
+	}
+	missing = append(missing, replicaConfig.RenderSensitiveData(cfg.Variables)...)
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"the values of variables %s are not provided", strings.Join(missing, ", "))
+	}
+	res.ReplicaConfig = ToAPIReplicaConfig(replicaConfig)
+	return res, nil
+}
@@ -69,6 +69,10 @@ func TestCreateChangefeed(t *testing.T) {
 	etcdClient.EXPECT().
 		GetEnsureGCServiceID(gomock.Any()).
 		Return(etcd.GcServiceIDForTest()).AnyTimes()
+	etcdClient.EXPECT().
+		GetNamespaceInfo(gomock.Any(), gomock.Any()).
+		Return(nil, cerrors.ErrNamespaceNotFound.GenWithStackByArgs(changeFeedID.Namespace)).
+		AnyTimes()
 	cp.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
 	cp.EXPECT().GetUpstreamManager().Return(mockUpManager, nil).AnyTimes()
 	cp.EXPECT().IsReady().Return(true).AnyTimes()
@@ -249,6 +253,63 @@ func TestCreateChangefeed(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, mysqlSink, resp.SinkURI)
 	require.Equal(t, http.StatusOK, w.Code)
+
+	// case 7: create the changefeed against a named upstream
+	namedConfig := &ChangefeedConfig{
+		ID:           changeFeedID.ID,
+		Namespace:    changeFeedID.Namespace,
+		SinkURI:      mysqlSink,
+		UpstreamName: "up1",
+	}
+	namedConfig.PDAddrs = []string{"http://127.0.0.1:2379"}
+	body, err = json.Marshal(namedConfig)
+	require.Nil(t, err)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), create.method,
+		create.url, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	respErr = model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+
+	namedConfig.PDAddrs = nil
+	body, err = json.Marshal(namedConfig)
+	require.Nil(t, err)
+	etcdClient.EXPECT().GetUpstreamInfos(gomock.Any(), changeFeedID.Namespace).
+		Return(map[model.UpstreamID]*model.UpstreamInfo{}, nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), create.method,
+		create.url, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	respErr = model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrUpstreamNotFound")
+
+	// the cluster id of the upstream is changed
+	etcdClient.EXPECT().GetUpstreamInfos(gomock.Any(), changeFeedID.Namespace).
+		Return(map[model.UpstreamID]*model.UpstreamInfo{
+			2: {ID: 2, Name: "up1", PDEndpoints: "http://127.0.0.1:2379"},
+		}, nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), create.method,
+		create.url, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	respErr = model.HTTPError{}
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrUpstreamMissMatch")
+
+	etcdClient.EXPECT().GetUpstreamInfos(gomock.Any(), changeFeedID.Namespace).
+		Return(map[model.UpstreamID]*model.UpstreamInfo{
+			1: {ID: 1, Name: "up1", PDEndpoints: "http://127.0.0.1:2379"},
+		}, nil)
+	helpers.EXPECT().
+		getEtcdClient(gomock.Any(), []string{"http://127.0.0.1:2379"}, gomock.Any()).
+		Return(testEtcdCluster.RandClient(), nil)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(context.Background(), create.method,
+		create.url, bytes.NewReader(body))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
 }
 
 func TestGetChangeFeed(t *testing.T) {
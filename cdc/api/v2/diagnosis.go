@@ -0,0 +1,282 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	apiOpVarLimit = "limit"
+
+	defaultDiagnosisLimit = 5
+	maxDiagnosisLimit     = 100
+	// diagnoseProcessorTimeout is the timeout of querying the diagnosis of
+	// a processor on a remote capture.
+	diagnoseProcessorTimeout = 10 * time.Second
+)
+
+// getResolvedTsDiagnosis diagnoses which stage holds back the checkpoint of a changefeed
+// @Summary Diagnose the resolved ts of a changefeed
+// @Description Walk through the replication pipeline of a changefeed on all captures,
+// @Description and report the slowest table spans of each stage and the stage which
+// @Description holds back the checkpoint ts the most
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param limit query integer false "max number of table spans of each stage, default 5"
+// @Success 200 {object} model.ResolvedTsDiagnosis
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/resolved_ts_diagnosis [get]
+func (h *OpenAPIV2) getResolvedTsDiagnosis(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	changefeedID := model.ChangeFeedID{Namespace: namespace, ID: c.Param(api.APIOpVarChangefeedID)}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	limit, err := parseDiagnosisLimit(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	provider := h.capture.StatusProvider()
+	ownerDiagnosis, err := provider.GetChangeFeedDiagnosis(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	procInfos, err := provider.GetProcessors(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	captures, err := provider.GetCaptures(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	self, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	captureInfos := make(map[model.CaptureID]*model.CaptureInfo, len(captures))
+	for _, capture := range captures {
+		captureInfos[capture.ID] = capture
+	}
+	processors := make([]*model.ProcessorDiagnosis, 0)
+	errs := make(map[model.CaptureID]string)
+	for _, info := range procInfos {
+		if info.CfID != changefeedID {
+			continue
+		}
+		var diagnosis *model.ProcessorDiagnosis
+		if info.CaptureID == self.ID {
+			diagnosis, err = h.capture.DiagnoseProcessor(ctx, changefeedID, limit)
+		} else if capture, ok := captureInfos[info.CaptureID]; ok {
+			diagnosis, err = h.diagnoseRemoteProcessor(c, capture, changefeedID, limit)
+		} else {
+			err = cerror.ErrCaptureNotExist.GenWithStackByArgs(info.CaptureID)
+		}
+		if err != nil {
+			log.Warn("failed to diagnose the resolved ts of the processor",
+				zap.String("namespace", changefeedID.Namespace),
+				zap.String("changefeed", changefeedID.ID),
+				zap.String("capture", info.CaptureID),
+				zap.Error(err))
+			errs[info.CaptureID] = err.Error()
+			continue
+		}
+		processors = append(processors, diagnosis)
+	}
+
+	resp := mergeResolvedTsDiagnosis(ownerDiagnosis, processors, limit)
+	if len(errs) > 0 {
+		resp.Errors = errs
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *OpenAPIV2) diagnoseRemoteProcessor(
+	c *gin.Context, capture *model.CaptureInfo,
+	changefeedID model.ChangeFeedID, limit int,
+) (*model.ProcessorDiagnosis, error) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), diagnoseProcessorTimeout)
+	defer cancel()
+	// Pass the headers through to be authorized by the remote capture.
+	return h.helpers.diagnoseProcessor(ctx, capture, changefeedID, limit,
+		c.Request.Header.Clone())
+}
+
+// getProcessorResolvedTsDiagnosis diagnoses the resolved ts of a processor
+// @Summary Diagnose the resolved ts of a processor
+// @Description Walk through the replication pipeline of the tables of a changefeed
+// @Description replicated by a capture
+// @Tags processor,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param capture_id  path  string  true  "capture_id"
+// @Param namespace query string false "default"
+// @Param limit query integer false "max number of table spans of each stage, default 5"
+// @Success 200 {object} model.ProcessorDiagnosis
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/processors/{changefeed_id}/{capture_id}/resolved_ts_diagnosis [get]
+func (h *OpenAPIV2) getProcessorResolvedTsDiagnosis(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	changefeedID := model.ChangeFeedID{Namespace: namespace, ID: c.Param(api.APIOpVarChangefeedID)}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	limit, err := parseDiagnosisLimit(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	self, err := h.capture.Info()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	captureID := c.Param(apiOpVarCaptureID)
+	if captureID != self.ID {
+		// Only the owner knows the addresses of other captures.
+		if !h.capture.IsOwner() {
+			api.ForwardToOwner(c, h.capture)
+			return
+		}
+		captures, err := h.capture.StatusProvider().GetCaptures(ctx)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		for _, capture := range captures {
+			if capture.ID == captureID {
+				api.ForwardToCapture(c, self.ID, capture.AdvertiseAddr)
+				return
+			}
+		}
+		_ = c.Error(cerror.ErrCaptureNotExist.GenWithStackByArgs(captureID))
+		return
+	}
+
+	diagnosis, err := h.capture.DiagnoseProcessor(ctx, changefeedID, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, diagnosis)
+}
+
+// parseDiagnosisLimit parses the limit of table spans of each stage.
+func parseDiagnosisLimit(c *gin.Context) (int, error) {
+	return parseLimit(c, defaultDiagnosisLimit)
+}
+
+// parseLimit parses the limit query parameter, defaultLimit is returned if
+// it's not specified.
+func parseLimit(c *gin.Context, defaultLimit int) (int, error) {
+	value := c.Query(apiOpVarLimit)
+	if value == "" {
+		return defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxDiagnosisLimit {
+		return 0, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid limit: %s, it should be in [1, %d]", value, maxDiagnosisLimit)
+	}
+	return limit, nil
+}
+
+// mergeResolvedTsDiagnosis merges the diagnosis of the owner and processors,
+// the blocking stage of the changefeed is the one of the slowest table.
+func mergeResolvedTsDiagnosis(
+	owner *model.OwnerDiagnosis, processors []*model.ProcessorDiagnosis, limit int,
+) *model.ResolvedTsDiagnosis {
+	res := &model.ResolvedTsDiagnosis{
+		CheckpointTs: owner.CheckpointTs,
+		ResolvedTs:   owner.ResolvedTs,
+		Owner:        owner,
+	}
+	var stages []model.StageDiagnosis
+	for _, p := range processors {
+		if p.CurrentTs > res.CurrentTs {
+			res.CurrentTs = p.CurrentTs
+		}
+		if p.SlowestTable != nil && (res.SlowestTable == nil ||
+			p.SlowestTable.CheckpointTs < res.SlowestTable.CheckpointTs) {
+			res.SlowestTable = p.SlowestTable
+		}
+		stages = append(stages, p.Stages...)
+		if p.SorterDiskUsage != nil {
+			if res.SorterDiskUsage == nil {
+				res.SorterDiskUsage = make(map[model.CaptureID]*model.SorterDiskUsage)
+			}
+			res.SorterDiskUsage[p.CaptureID] = p.SorterDiskUsage
+		}
+	}
+	res.Stages = model.TopStageDiagnoses(stages, limit)
+	if res.SlowestTable == nil {
+		return res
+	}
+	if blocking := res.SlowestTable.Blocking(); blocking != nil {
+		b := *blocking
+		if b.Stage == model.DiagnosisStageBarrier && b.Message == "" {
+			b.Message = explainBarrier(owner, b.ResolvedTs)
+		}
+		res.Blocking = &b
+	}
+	return res
+}
+
+// explainBarrier finds out why the owner holds back the barrier ts of tables.
+func explainBarrier(owner *model.OwnerDiagnosis, barrierTs uint64) string {
+	for _, ddl := range owner.PendingDDLs {
+		if ddl.CommitTs != barrierTs {
+			continue
+		}
+		if ddl.Executing {
+			return fmt.Sprintf("the DDL %q of table %s is being executed", ddl.Query, ddl.TableName)
+		}
+		return fmt.Sprintf("the DDL %q of table %s is waiting for all tables to reach its commit ts",
+			ddl.Query, ddl.TableName)
+	}
+	if owner.BarrierTs == barrierTs {
+		return fmt.Sprintf("the %s barrier of the changefeed is not passed yet", owner.BarrierType)
+	}
+	if owner.DDLResolvedTs <= barrierTs {
+		return fmt.Sprintf("the resolved ts of the DDL puller is %d", owner.DDLResolvedTs)
+	}
+	return ""
+}
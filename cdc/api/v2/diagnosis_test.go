@@ -0,0 +1,197 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestGetResolvedTsDiagnosis(t *testing.T) {
+	t.Parallel()
+
+	url := "/api/v2/changefeeds/%s/resolved_ts_diagnosis?%s"
+	changefeedID := model.DefaultChangeFeedID("test")
+	ctrl := gomock.NewController(t)
+	helpers := NewMockAPIV2Helpers(ctrl)
+	provider := mock_owner.NewMockStatusProvider(ctrl)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(provider).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{ID: "capture-1"}, nil).AnyTimes()
+
+	apiV2 := NewOpenAPIV2ForTest(cp, helpers)
+	router := newRouter(apiV2)
+	request := func(id, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			http.MethodGet, fmt.Sprintf(url, id, query), nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// invalid changefeed id and limit
+	for _, w := range []*httptest.ResponseRecorder{
+		request("@^Invalid", ""),
+		request(changefeedID.ID, "limit=abc"),
+		request(changefeedID.ID, "limit=0"),
+	} {
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		respErr := model.HTTPError{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&respErr))
+		require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+	}
+
+	ts := func(second int64) uint64 {
+		return oracle.ComposeTS(second*1000, 0)
+	}
+	provider.EXPECT().GetChangeFeedDiagnosis(gomock.Any(), changefeedID).
+		Return(&model.OwnerDiagnosis{
+			CheckpointTs:  ts(100),
+			ResolvedTs:    ts(100),
+			DDLResolvedTs: ts(300),
+			PendingDDLs: []model.PendingDDLDiagnosis{{
+				TableName: "test.t1", CommitTs: ts(101), Query: "ALTER TABLE t1 ADD COLUMN c INT",
+			}},
+		}, nil)
+	provider.EXPECT().GetProcessors(gomock.Any()).Return([]*model.ProcInfoSnap{
+		{CfID: changefeedID, CaptureID: "capture-1"},
+		{CfID: changefeedID, CaptureID: "capture-2"},
+		{CfID: changefeedID, CaptureID: "capture-3"},
+		{CfID: model.DefaultChangeFeedID("other"), CaptureID: "capture-1"},
+	}, nil)
+	provider.EXPECT().GetCaptures(gomock.Any()).Return([]*model.CaptureInfo{
+		{ID: "capture-1", AdvertiseAddr: "127.0.0.1:8300"},
+		{ID: "capture-2", AdvertiseAddr: "127.0.0.1:8301"},
+	}, nil)
+
+	// the table 2 on the local capture is the slowest one, and it's held
+	// back by the barrier of a DDL.
+	barrier := model.StageDiagnosis{
+		Stage: model.DiagnosisStageBarrier, CaptureID: "capture-1", TableID: 2,
+		ResolvedTs: ts(101), Lag: 199,
+	}
+	cp.EXPECT().DiagnoseProcessor(gomock.Any(), changefeedID, 2).
+		Return(&model.ProcessorDiagnosis{
+			CaptureID: "capture-1",
+			CurrentTs: ts(300),
+			SlowestTable: &model.TableDiagnosis{
+				CaptureID: "capture-1", TableID: 2, CheckpointTs: ts(100),
+				Stages: []model.StageDiagnosis{
+					{Stage: model.DiagnosisStageSorter, TableID: 2, ResolvedTs: ts(300)},
+					barrier,
+					{Stage: model.DiagnosisStageSink, TableID: 2, ResolvedTs: ts(100), Lag: 1},
+				},
+			},
+			Stages: []model.StageDiagnosis{
+				{Stage: model.DiagnosisStageKVClient, TableID: 2, RegionID: 1, StoreID: 1, ResolvedTs: ts(300)},
+				barrier,
+			},
+		}, nil)
+	helpers.EXPECT().diagnoseProcessor(gomock.Any(),
+		&model.CaptureInfo{ID: "capture-2", AdvertiseAddr: "127.0.0.1:8301"},
+		changefeedID, 2, gomock.Any()).
+		Return(&model.ProcessorDiagnosis{
+			CaptureID: "capture-2",
+			CurrentTs: ts(301),
+			SlowestTable: &model.TableDiagnosis{
+				CaptureID: "capture-2", TableID: 3, CheckpointTs: ts(200),
+			},
+			Stages: []model.StageDiagnosis{
+				{Stage: model.DiagnosisStageKVClient, TableID: 3, RegionID: 2, StoreID: 2, ResolvedTs: ts(250)},
+				{Stage: model.DiagnosisStageKVClient, TableID: 3, RegionID: 3, StoreID: 1, ResolvedTs: ts(200)},
+			},
+			SorterDiskUsage: &model.SorterDiskUsage{UsedBytes: 100, QuotaBytes: 100, Exceeded: true},
+		}, nil)
+
+	w := request(changefeedID.ID, "limit=2")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &model.ResolvedTsDiagnosis{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, ts(100), resp.CheckpointTs)
+	require.Equal(t, ts(301), resp.CurrentTs)
+	require.Equal(t, model.TableID(2), resp.SlowestTable.TableID)
+	require.Equal(t, model.DiagnosisStageBarrier, resp.Blocking.Stage)
+	require.Contains(t, resp.Blocking.Message, "ALTER TABLE t1 ADD COLUMN c INT")
+	require.Len(t, resp.Stages, 3)
+	require.Equal(t, uint64(3), resp.Stages[0].RegionID)
+	require.Equal(t, uint64(2), resp.Stages[1].RegionID)
+	require.Equal(t, model.DiagnosisStageBarrier, resp.Stages[2].Stage)
+	require.Len(t, resp.Errors, 1)
+	require.Contains(t, resp.Errors["capture-3"], "ErrCaptureNotExist")
+	require.Len(t, resp.SorterDiskUsage, 1)
+	require.True(t, resp.SorterDiskUsage["capture-2"].Exceeded)
+
+	// the changefeed doesn't exist
+	provider.EXPECT().GetChangeFeedDiagnosis(gomock.Any(), changefeedID).
+		Return(nil, errors.New("changefeed not exists"))
+	w = request(changefeedID.ID, "")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetProcessorResolvedTsDiagnosis(t *testing.T) {
+	t.Parallel()
+
+	url := "/api/v2/processors/%s/%s/resolved_ts_diagnosis"
+	changefeedID := model.DefaultChangeFeedID("test")
+	ctrl := gomock.NewController(t)
+	provider := mock_owner.NewMockStatusProvider(ctrl)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(provider).AnyTimes()
+	cp.EXPECT().Info().Return(model.CaptureInfo{ID: "capture-1"}, nil).AnyTimes()
+
+	apiV2 := NewOpenAPIV2ForTest(cp, NewMockAPIV2Helpers(ctrl))
+	router := newRouter(apiV2)
+	request := func(captureID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			http.MethodGet, fmt.Sprintf(url, changefeedID.ID, captureID), nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// the processor runs on this capture
+	cp.EXPECT().DiagnoseProcessor(gomock.Any(), changefeedID, defaultDiagnosisLimit).
+		Return(&model.ProcessorDiagnosis{CaptureID: "capture-1", CurrentTs: 100}, nil)
+	w := request("capture-1")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &model.ProcessorDiagnosis{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, "capture-1", resp.CaptureID)
+	require.Equal(t, uint64(100), resp.CurrentTs)
+
+	// the capture doesn't exist
+	provider.EXPECT().GetCaptures(gomock.Any()).Return([]*model.CaptureInfo{
+		{ID: "capture-1", AdvertiseAddr: "127.0.0.1:8300"},
+	}, nil)
+	w = request("capture-2")
+	respErr := model.HTTPError{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, "ErrCaptureNotExist")
+}
@@ -111,6 +111,31 @@ type SyncedStatusConfig struct {
 	CheckpointInterval int64 `json:"checkpoint_interval"`
 }
 
+// ScheduleConfig pauses, resumes and delays the start of a changefeed by time
+// This is a duplicate of config.ScheduleConfig
+type ScheduleConfig struct {
+	// PauseCron is a standard cron expression, the changefeed is paused when
+	// it fires.
+	PauseCron string `json:"pause_cron,omitempty"`
+	// ResumeCron is a standard cron expression, the changefeed paused is
+	// resumed when it fires.
+	ResumeCron string `json:"resume_cron,omitempty"`
+	// TimeZone is the time zone that the cron expressions are evaluated in.
+	TimeZone string `json:"time_zone,omitempty"`
+	// DelayedStart allows the start-ts of the changefeed to be later than the
+	// current tso, the changefeed waits until the upstream reaches it.
+	DelayedStart bool `json:"delayed_start,omitempty"`
+}
+
+// HookConfig notifies a webhook or runs a command when the state of a
+// changefeed changes
+// This is a duplicate of config.HookConfig
+type HookConfig struct {
+	Events  []string `json:"events"`
+	URL     string   `json:"url,omitempty"`
+	Command string   `json:"command,omitempty"`
+}
+
 // MarshalJSON marshal changefeed common info to json
 // we need to set feed state to normal if it is uninitialized and pending to warning
 // to hide the detail of uninitialized and pending state from user
@@ -140,9 +165,74 @@ type ChangefeedConfig struct {
 	TargetTs      uint64         `json:"target_ts"`
 	SinkURI       string         `json:"sink_uri"`
 	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+	// UpstreamName is the name of a registered upstream, the changefeed is
+	// created against it instead of PDConfig if it's set.
+	UpstreamName string `json:"upstream_name,omitempty"`
+	// Template is the name of a changefeed template in the namespace, the
+	// changefeed inherits the sink uri and the replica config of it.
+	Template string `json:"template,omitempty"`
 	PDConfig
 }
 
+// ChangefeedExportFormatVersion is the version of the ChangefeedExport format,
+// it's increased when the format is changed incompatibly.
+const ChangefeedExportFormatVersion = 1
+
+const (
+	// StartTsPolicyNow makes an imported changefeed start from the current
+	// ts of its upstream.
+	StartTsPolicyNow = "now"
+	// StartTsPolicyCheckpoint makes an imported changefeed start from the
+	// checkpoint ts of the exported changefeed.
+	StartTsPolicyCheckpoint = "checkpoint"
+)
+
+// ChangefeedExport is a portable document describing a changefeed, it can
+// be imported to recreate the changefeed in another cluster.
+type ChangefeedExport struct {
+	FormatVersion int    `json:"format_version"`
+	Namespace     string `json:"namespace"`
+	ID            string `json:"changefeed_id"`
+	// SinkURI is templated, the secrets in it are replaced with
+	// variables like ${SINK_PASSWORD}.
+	SinkURI       string `json:"sink_uri"`
+	StartTsPolicy string `json:"start_ts_policy"`
+	// CheckpointTs is the checkpoint ts of the changefeed when it's exported.
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	TargetTs     uint64 `json:"target_ts"`
+	// Variables are the names of the variables in SinkURI and ReplicaConfig,
+	// their values must be provided when the document is imported.
+	Variables     []string       `json:"variables,omitempty"`
+	ReplicaConfig *ReplicaConfig `json:"replica_config"`
+}
+
+// ChangefeedImportConfig is used to create a changefeed from a ChangefeedExport.
+type ChangefeedImportConfig struct {
+	Document *ChangefeedExport `json:"document"`
+	// Namespace, ID, StartTs and TargetTs override the ones derived from
+	// the document if they are set.
+	Namespace string `json:"namespace,omitempty"`
+	ID        string `json:"changefeed_id,omitempty"`
+	StartTs   uint64 `json:"start_ts,omitempty"`
+	TargetTs  uint64 `json:"target_ts,omitempty"`
+	// Variables are the values of the variables in the document.
+	Variables    map[string]string `json:"variables,omitempty"`
+	UpstreamName string            `json:"upstream_name,omitempty"`
+}
+
+// ChangefeedCloneConfig is used to create a changefeed from an existing one
+// in the same cluster.
+type ChangefeedCloneConfig struct {
+	// Namespace is the namespace of the new changefeed, it's the namespace
+	// of the source changefeed if it's empty.
+	Namespace string `json:"namespace,omitempty"`
+	ID        string `json:"changefeed_id"`
+	// StartTs is the checkpoint ts of the source changefeed if it's 0.
+	StartTs uint64 `json:"start_ts,omitempty"`
+	// SinkURI is the sink uri of the source changefeed if it's empty.
+	SinkURI string `json:"sink_uri,omitempty"`
+}
+
 // ProcessorCommonInfo holds the common info of a processor
 type ProcessorCommonInfo struct {
 	Namespace    string `json:"namespace"`
@@ -189,6 +279,7 @@ type ReplicaConfig struct {
 	ForceReplicate        bool   `json:"force_replicate"`
 	IgnoreIneligibleTable bool   `json:"ignore_ineligible_table"`
 	CheckGCSafePoint      bool   `json:"check_gc_safe_point"`
+	SorterDiskQuota       uint64 `json:"sorter_disk_quota,omitempty"`
 	EnableSyncPoint       *bool  `json:"enable_sync_point,omitempty"`
 	EnableTableMonitor    *bool  `json:"enable_table_monitor,omitempty"`
 	BDRMode               *bool  `json:"bdr_mode,omitempty"`
@@ -204,6 +295,8 @@ type ReplicaConfig struct {
 	Integrity                    *IntegrityConfig           `json:"integrity"`
 	ChangefeedErrorStuckDuration *JSONDuration              `json:"changefeed_error_stuck_duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig        `json:"synced_status,omitempty"`
+	Schedule                     *ScheduleConfig            `json:"schedule,omitempty"`
+	Hooks                        []*HookConfig              `json:"hooks,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `json:"sql_mode,omitempty"`
@@ -222,6 +315,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 	res.CaseSensitive = c.CaseSensitive
 	res.ForceReplicate = c.ForceReplicate
 	res.CheckGCSafePoint = c.CheckGCSafePoint
+	res.SorterDiskQuota = c.SorterDiskQuota
 	res.EnableSyncPoint = c.EnableSyncPoint
 	res.EnableTableMonitor = c.EnableTableMonitor
 	res.IgnoreIneligibleTable = c.IgnoreIneligibleTable
@@ -275,7 +369,11 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				PartitionRule:  rule.PartitionRule,
 				IndexName:      rule.IndexName,
 				Columns:        rule.Columns,
+				HashFunction:   rule.HashFunction,
+				Partitions:     rule.Partitions,
+				PartitionNum:   rule.PartitionNum,
 				TopicRule:      rule.TopicRule,
+				TopicConfig:    rule.TopicConfig.toInternalTopicConfig(),
 			})
 		}
 		var columnSelectors []*config.ColumnSelector
@@ -342,6 +440,9 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 					AvroDecimalHandlingMode:        oldConfig.AvroDecimalHandlingMode,
 					AvroBigintUnsignedHandlingMode: oldConfig.AvroBigintUnsignedHandlingMode,
 					EncodingFormat:                 oldConfig.EncodingFormat,
+					AvroSubjectNameStrategy:        oldConfig.AvroSubjectNameStrategy,
+					AvroSchemaCompatibilityCheck:   oldConfig.AvroSchemaCompatibilityCheck,
+					AvroIncompatibleSchemaFallback: oldConfig.AvroIncompatibleSchemaFallback,
 				}
 			}
 
@@ -425,6 +526,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				EnableBatchDML:               c.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         c.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: c.Sink.MySQLConfig.EnableCachePreparedStatement,
+				VerifyChecksum:               c.Sink.MySQLConfig.VerifyChecksum,
+				EnableCheckpointTable:        c.Sink.MySQLConfig.EnableCheckpointTable,
 			}
 		}
 		var cloudStorageConfig *config.CloudStorageConfig
@@ -443,7 +546,9 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		var debeziumConfig *config.DebeziumConfig
 		if c.Sink.DebeziumConfig != nil {
 			debeziumConfig = &config.DebeziumConfig{
-				OutputOldValue: c.Sink.DebeziumConfig.OutputOldValue,
+				OutputOldValue:    c.Sink.DebeziumConfig.OutputOldValue,
+				SchemaChangeTopic: c.Sink.DebeziumConfig.SchemaChangeTopic,
+				TransactionTopic:  c.Sink.DebeziumConfig.TransactionTopic,
 			}
 		}
 		var openProtocolConfig *config.OpenProtocolConfig
@@ -519,6 +624,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		res.Integrity = &integrity.Config{
 			IntegrityCheckLevel:   c.Integrity.IntegrityCheckLevel,
 			CorruptionHandleLevel: c.Integrity.CorruptionHandleLevel,
+			CorruptionLogDir:      c.Integrity.CorruptionLogDir,
 		}
 	}
 	if c.ChangefeedErrorStuckDuration != nil {
@@ -530,6 +636,24 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			CheckpointInterval:  c.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if c.Schedule != nil {
+		res.Schedule = &config.ScheduleConfig{
+			PauseCron:    c.Schedule.PauseCron,
+			ResumeCron:   c.Schedule.ResumeCron,
+			TimeZone:     c.Schedule.TimeZone,
+			DelayedStart: c.Schedule.DelayedStart,
+		}
+	}
+	if c.Hooks != nil {
+		res.Hooks = make([]*config.HookConfig, 0, len(c.Hooks))
+		for _, hook := range c.Hooks {
+			res.Hooks = append(res.Hooks, &config.HookConfig{
+				Events:  hook.Events,
+				URL:     hook.URL,
+				Command: hook.Command,
+			})
+		}
+	}
 	return res
 }
 
@@ -543,6 +667,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		ForceReplicate:        cloned.ForceReplicate,
 		IgnoreIneligibleTable: cloned.IgnoreIneligibleTable,
 		CheckGCSafePoint:      cloned.CheckGCSafePoint,
+		SorterDiskQuota:       cloned.SorterDiskQuota,
 		EnableSyncPoint:       cloned.EnableSyncPoint,
 		EnableTableMonitor:    cloned.EnableTableMonitor,
 		BDRMode:               cloned.BDRMode,
@@ -579,7 +704,11 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				PartitionRule: rule.PartitionRule,
 				IndexName:     rule.IndexName,
 				Columns:       rule.Columns,
+				HashFunction:  rule.HashFunction,
+				Partitions:    rule.Partitions,
+				PartitionNum:  rule.PartitionNum,
 				TopicRule:     rule.TopicRule,
+				TopicConfig:   toAPITopicConfig(rule.TopicConfig),
 			})
 		}
 		var columnSelectors []*ColumnSelector
@@ -613,6 +742,9 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 					AvroDecimalHandlingMode:        oldConfig.AvroDecimalHandlingMode,
 					AvroBigintUnsignedHandlingMode: oldConfig.AvroBigintUnsignedHandlingMode,
 					EncodingFormat:                 oldConfig.EncodingFormat,
+					AvroSubjectNameStrategy:        oldConfig.AvroSubjectNameStrategy,
+					AvroSchemaCompatibilityCheck:   oldConfig.AvroSchemaCompatibilityCheck,
+					AvroIncompatibleSchemaFallback: oldConfig.AvroIncompatibleSchemaFallback,
 				}
 			}
 
@@ -696,6 +828,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				EnableBatchDML:               cloned.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         cloned.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: cloned.Sink.MySQLConfig.EnableCachePreparedStatement,
+				VerifyChecksum:               cloned.Sink.MySQLConfig.VerifyChecksum,
+				EnableCheckpointTable:        cloned.Sink.MySQLConfig.EnableCheckpointTable,
 			}
 		}
 		var pulsarConfig *PulsarConfig
@@ -746,7 +880,9 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		var debeziumConfig *DebeziumConfig
 		if cloned.Sink.Debezium != nil {
 			debeziumConfig = &DebeziumConfig{
-				OutputOldValue: cloned.Sink.Debezium.OutputOldValue,
+				OutputOldValue:    cloned.Sink.Debezium.OutputOldValue,
+				SchemaChangeTopic: cloned.Sink.Debezium.SchemaChangeTopic,
+				TransactionTopic:  cloned.Sink.Debezium.TransactionTopic,
 			}
 		}
 		var openProtocolConfig *OpenProtocolConfig
@@ -843,6 +979,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		res.Integrity = &IntegrityConfig{
 			IntegrityCheckLevel:   cloned.Integrity.IntegrityCheckLevel,
 			CorruptionHandleLevel: cloned.Integrity.CorruptionHandleLevel,
+			CorruptionLogDir:      cloned.Integrity.CorruptionLogDir,
 		}
 	}
 	if cloned.ChangefeedErrorStuckDuration != nil {
@@ -854,6 +991,24 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			CheckpointInterval:  cloned.SyncedStatus.CheckpointInterval,
 		}
 	}
+	if cloned.Schedule != nil {
+		res.Schedule = &ScheduleConfig{
+			PauseCron:    cloned.Schedule.PauseCron,
+			ResumeCron:   cloned.Schedule.ResumeCron,
+			TimeZone:     cloned.Schedule.TimeZone,
+			DelayedStart: cloned.Schedule.DelayedStart,
+		}
+	}
+	if cloned.Hooks != nil {
+		res.Hooks = make([]*HookConfig, 0, len(cloned.Hooks))
+		for _, hook := range cloned.Hooks {
+			res.Hooks = append(res.Hooks, &HookConfig{
+				Events:  hook.Events,
+				URL:     hook.URL,
+				Command: hook.Command,
+			})
+		}
+	}
 	return res
 }
 
@@ -886,6 +1041,10 @@ type EventFilterRule struct {
 	IgnoreUpdateNewValueExpr string `json:"ignore_update_new_value_expr"`
 	IgnoreUpdateOldValueExpr string `json:"ignore_update_old_value_expr"`
 	IgnoreDeleteValueExpr    string `json:"ignore_delete_value_expr"`
+	// columns that an update is ignored if it only changes them
+	IgnoreUpdateChangedColumns []string `json:"ignore_update_changed_columns"`
+	// sql expression on ddl metadata
+	IgnoreDDLExpr string `json:"ignore_ddl_expr"`
 }
 
 // ToInternalEventFilterRule converts EventFilterRule to *config.EventFilterRule
@@ -897,6 +1056,11 @@ func (e EventFilterRule) ToInternalEventFilterRule() *config.EventFilterRule {
 		IgnoreUpdateNewValueExpr: e.IgnoreUpdateNewValueExpr,
 		IgnoreUpdateOldValueExpr: e.IgnoreUpdateOldValueExpr,
 		IgnoreDeleteValueExpr:    e.IgnoreDeleteValueExpr,
+		IgnoreDDLExpr:            e.IgnoreDDLExpr,
+	}
+	if len(e.IgnoreUpdateChangedColumns) != 0 {
+		res.IgnoreUpdateChangedColumns = make([]string, len(e.IgnoreUpdateChangedColumns))
+		copy(res.IgnoreUpdateChangedColumns, e.IgnoreUpdateChangedColumns)
 	}
 	if len(e.IgnoreEvent) != 0 {
 		res.IgnoreEvent = make([]bf.EventType, len(e.IgnoreEvent))
@@ -914,6 +1078,11 @@ func ToAPIEventFilterRule(er *config.EventFilterRule) EventFilterRule {
 		IgnoreUpdateNewValueExpr: er.IgnoreUpdateNewValueExpr,
 		IgnoreUpdateOldValueExpr: er.IgnoreUpdateOldValueExpr,
 		IgnoreDeleteValueExpr:    er.IgnoreDeleteValueExpr,
+		IgnoreDDLExpr:            er.IgnoreDDLExpr,
+	}
+	if len(er.IgnoreUpdateChangedColumns) != 0 {
+		res.IgnoreUpdateChangedColumns = make([]string, len(er.IgnoreUpdateChangedColumns))
+		copy(res.IgnoreUpdateChangedColumns, er.IgnoreUpdateChangedColumns)
 	}
 	if len(er.Matcher) != 0 {
 		res.Matcher = make([]string, len(er.Matcher))
@@ -997,11 +1166,54 @@ type LargeMessageHandleConfig struct {
 // DispatchRule represents partition rule for a table
 // This is a duplicate of config.DispatchRule
 type DispatchRule struct {
-	Matcher       []string `json:"matcher,omitempty"`
-	PartitionRule string   `json:"partition,omitempty"`
-	IndexName     string   `json:"index,omitempty"`
-	Columns       []string `json:"columns,omitempty"`
-	TopicRule     string   `json:"topic,omitempty"`
+	Matcher       []string     `json:"matcher,omitempty"`
+	PartitionRule string       `json:"partition,omitempty"`
+	IndexName     string       `json:"index,omitempty"`
+	Columns       []string     `json:"columns,omitempty"`
+	HashFunction  string       `json:"hash_function,omitempty"`
+	Partitions    []int32      `json:"partitions,omitempty"`
+	PartitionNum  int32        `json:"partition_num,omitempty"`
+	TopicRule     string       `json:"topic,omitempty"`
+	TopicConfig   *TopicConfig `json:"topic_config,omitempty"`
+}
+
+// TopicConfig represents the configuration of the topics created by the Kafka sink.
+// This is a duplicate of config.TopicConfig
+type TopicConfig struct {
+	PartitionNum         *int32            `json:"partition_num,omitempty"`
+	ReplicationFactor    *int16            `json:"replication_factor,omitempty"`
+	AllowPartitionGrowth *bool             `json:"allow_partition_growth,omitempty"`
+	RetentionMs          *int64            `json:"retention_ms,omitempty"`
+	CleanupPolicy        *string           `json:"cleanup_policy,omitempty"`
+	Configs              map[string]string `json:"configs,omitempty"`
+}
+
+func (c *TopicConfig) toInternalTopicConfig() *config.TopicConfig {
+	if c == nil {
+		return nil
+	}
+	return &config.TopicConfig{
+		PartitionNum:         c.PartitionNum,
+		ReplicationFactor:    c.ReplicationFactor,
+		AllowPartitionGrowth: c.AllowPartitionGrowth,
+		RetentionMs:          c.RetentionMs,
+		CleanupPolicy:        c.CleanupPolicy,
+		Configs:              c.Configs,
+	}
+}
+
+func toAPITopicConfig(c *config.TopicConfig) *TopicConfig {
+	if c == nil {
+		return nil
+	}
+	return &TopicConfig{
+		PartitionNum:         c.PartitionNum,
+		ReplicationFactor:    c.ReplicationFactor,
+		AllowPartitionGrowth: c.AllowPartitionGrowth,
+		RetentionMs:          c.RetentionMs,
+		CleanupPolicy:        c.CleanupPolicy,
+		Configs:              c.Configs,
+	}
 }
 
 // ColumnSelector represents a column selector for a table.
@@ -1050,6 +1262,7 @@ type ChangefeedSchedulerConfig struct {
 type IntegrityConfig struct {
 	IntegrityCheckLevel   string `json:"integrity_check_level"`
 	CorruptionHandleLevel string `json:"corruption_handle_level"`
+	CorruptionLogDir      string `json:"corruption_log_dir,omitempty"`
 }
 
 // EtcdData contains key/value pair of etcd data
@@ -1153,6 +1366,105 @@ type UpstreamConfig struct {
 	PDConfig
 }
 
+// Upstream holds the information of an upstream TiDB cluster
+type Upstream struct {
+	ID            uint64   `json:"id"`
+	Name          string   `json:"name,omitempty"`
+	PDAddrs       []string `json:"pd_addrs"`
+	CAPath        string   `json:"ca_path"`
+	CertPath      string   `json:"cert_path"`
+	KeyPath       string   `json:"key_path"`
+	CertAllowedCN []string `json:"cert_allowed_cn,omitempty"`
+	// IsDefault is true if it's the upstream that the cdc cluster
+	// is deployed with.
+	IsDefault bool `json:"is_default"`
+	// Changefeeds are the changefeeds replicating from the upstream.
+	Changefeeds []string `json:"changefeeds"`
+}
+
+// CreateUpstreamConfig is the config used to register an upstream
+type CreateUpstreamConfig struct {
+	Name string `json:"name"`
+	PDConfig
+}
+
+// PDMemberHealth holds the health status of a pd member
+type PDMemberHealth struct {
+	Endpoint string `json:"endpoint"`
+	Healthy  bool   `json:"healthy"`
+	Error    string `json:"error,omitempty"`
+}
+
+// UpstreamHealth holds the health status of an upstream
+type UpstreamHealth struct {
+	ID      uint64           `json:"id"`
+	Name    string           `json:"name,omitempty"`
+	Healthy bool             `json:"healthy"`
+	Members []PDMemberHealth `json:"members"`
+	// Tso is the current tso of the upstream, it's zero if the pd is unavailable.
+	Tso   uint64 `json:"tso"`
+	Error string `json:"error,omitempty"`
+}
+
+// ServiceGCSafePoint holds the gc safepoint of a service in pd
+type ServiceGCSafePoint struct {
+	ServiceID string `json:"service_id"`
+	ExpiredAt int64  `json:"expired_at"`
+	SafePoint uint64 `json:"safe_point"`
+}
+
+// UpstreamGCSafePoint holds the gc safepoints of an upstream
+type UpstreamGCSafePoint struct {
+	ID          uint64 `json:"id"`
+	Name        string `json:"name,omitempty"`
+	GCSafePoint uint64 `json:"gc_safe_point"`
+	// CDCServiceID is the gc service id of the cdc cluster.
+	CDCServiceID string `json:"cdc_service_id"`
+	// CDCSafePoint is the service gc safepoint of the cdc cluster,
+	// it's nil if there is no changefeed replicating from the upstream.
+	CDCSafePoint      *ServiceGCSafePoint  `json:"cdc_safe_point,omitempty"`
+	ServiceSafePoints []ServiceGCSafePoint `json:"service_safe_points"`
+}
+
+// NamespaceQuota limits the changefeeds of a namespace, zero means unlimited.
+type NamespaceQuota struct {
+	MaxChangefeeds int `json:"max_changefeeds"`
+	// MaxMemoryQuota is the max sum of the memory quota of the changefeeds
+	// in the namespace, in bytes.
+	MaxMemoryQuota uint64 `json:"max_memory_quota"`
+}
+
+// NamespaceConfig is the config used to create or update the settings of
+// a namespace
+type NamespaceConfig struct {
+	Name string `json:"name"`
+	// DefaultReplicaConfig is a partial replica config, the changefeeds
+	// created in the namespace inherit the fields set in it.
+	DefaultReplicaConfig json.RawMessage   `json:"default_replica_config,omitempty" swaggertype:"object"`
+	Quota                NamespaceQuota    `json:"quota"`
+	Labels               map[string]string `json:"labels,omitempty"`
+}
+
+// Namespace holds the settings and the usage of a namespace
+type Namespace struct {
+	NamespaceConfig
+	// Changefeeds are the changefeeds in the namespace.
+	Changefeeds []string `json:"changefeeds"`
+	// MemoryQuotaUsed is the sum of the memory quota of the changefeeds.
+	MemoryQuotaUsed uint64 `json:"memory_quota_used"`
+}
+
+// ChangefeedTemplate is a named changefeed config of a namespace
+type ChangefeedTemplate struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	SinkURI     string `json:"sink_uri,omitempty"`
+	// ReplicaConfig is a partial replica config which overrides the default
+	// replica config of the namespace.
+	ReplicaConfig json.RawMessage `json:"replica_config,omitempty" swaggertype:"object"`
+}
+
 // ProcessorDetail holds the detail info of a processor
 type ProcessorDetail struct {
 	// All table ids that this processor are replicating.
@@ -1190,6 +1502,9 @@ type CodecConfig struct {
 	AvroDecimalHandlingMode        *string `json:"avro_decimal_handling_mode,omitempty"`
 	AvroBigintUnsignedHandlingMode *string `json:"avro_bigint_unsigned_handling_mode,omitempty"`
 	EncodingFormat                 *string `json:"encoding_format,omitempty"`
+	AvroSubjectNameStrategy        *string `json:"avro_subject_name_strategy,omitempty"`
+	AvroSchemaCompatibilityCheck   *bool   `json:"avro_schema_compatibility_check,omitempty"`
+	AvroIncompatibleSchemaFallback *string `json:"avro_incompatible_schema_fallback,omitempty"`
 }
 
 // PulsarConfig represents a pulsar sink configuration
@@ -1282,6 +1597,8 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `json:"enable_batch_dml,omitempty"`
 	EnableMultiStatement         *bool   `json:"enable_multi_statement,omitempty"`
 	EnableCachePreparedStatement *bool   `json:"enable_cache_prepared_statement,omitempty"`
+	VerifyChecksum               *bool   `json:"verify_checksum,omitempty"`
+	EnableCheckpointTable        *bool   `json:"enable_checkpoint_table,omitempty"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -1325,5 +1642,7 @@ type OpenProtocolConfig struct {
 
 // DebeziumConfig represents the configurations for debezium protocol encoding
 type DebeziumConfig struct {
-	OutputOldValue bool `json:"output_old_value"`
+	OutputOldValue    bool   `json:"output_old_value"`
+	SchemaChangeTopic string `json:"schema_change_topic,omitempty"`
+	TransactionTopic  string `json:"transaction_topic,omitempty"`
 }
@@ -208,22 +208,26 @@ func TestEventFilterRuleConvert(t *testing.T) {
 	}{
 		{
 			inRule: &config.EventFilterRule{
-				Matcher:                  []string{"test.t1", "test.t2"},
-				IgnoreEvent:              []bf.EventType{bf.AllDML, bf.AllDDL, bf.AlterTable},
-				IgnoreSQL:                []string{"^DROP TABLE", "ADD COLUMN"},
-				IgnoreInsertValueExpr:    "c >= 0",
-				IgnoreUpdateNewValueExpr: "age <= 55",
-				IgnoreUpdateOldValueExpr: "age >= 84",
-				IgnoreDeleteValueExpr:    "age > 20",
+				Matcher:                    []string{"test.t1", "test.t2"},
+				IgnoreEvent:                []bf.EventType{bf.AllDML, bf.AllDDL, bf.AlterTable},
+				IgnoreSQL:                  []string{"^DROP TABLE", "ADD COLUMN"},
+				IgnoreInsertValueExpr:      "c >= 0",
+				IgnoreUpdateNewValueExpr:   "age <= 55",
+				IgnoreUpdateOldValueExpr:   "age >= 84",
+				IgnoreDeleteValueExpr:      "age > 20",
+				IgnoreUpdateChangedColumns: []string{"last_seen_at"},
+				IgnoreDDLExpr:              "ddl_type = 'drop table'",
 			},
 			apiRule: EventFilterRule{
-				Matcher:                  []string{"test.t1", "test.t2"},
-				IgnoreEvent:              []string{"all dml", "all ddl", "alter table"},
-				IgnoreSQL:                []string{"^DROP TABLE", "ADD COLUMN"},
-				IgnoreInsertValueExpr:    "c >= 0",
-				IgnoreUpdateNewValueExpr: "age <= 55",
-				IgnoreUpdateOldValueExpr: "age >= 84",
-				IgnoreDeleteValueExpr:    "age > 20",
+				Matcher:                    []string{"test.t1", "test.t2"},
+				IgnoreEvent:                []string{"all dml", "all ddl", "alter table"},
+				IgnoreSQL:                  []string{"^DROP TABLE", "ADD COLUMN"},
+				IgnoreInsertValueExpr:      "c >= 0",
+				IgnoreUpdateNewValueExpr:   "age <= 55",
+				IgnoreUpdateOldValueExpr:   "age >= 84",
+				IgnoreDeleteValueExpr:      "age > 20",
+				IgnoreUpdateChangedColumns: []string{"last_seen_at"},
+				IgnoreDDLExpr:              "ddl_type = 'drop table'",
 			},
 		},
 	}
@@ -0,0 +1,705 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/api/middleware"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+const (
+	apiOpVarNamespaceName = "namespace"
+	apiOpVarTemplateName  = "template_name"
+	apiOpVarTemplate      = "template"
+)
+
+// listNamespaces lists all namespaces
+// @Summary List namespaces
+// @Description list the namespaces which have settings or changefeeds
+// @Tags namespace,v2
+// @Produce json
+// @Success 200 {array} Namespace
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/namespaces [get]
+func (h *OpenAPIV2) listNamespaces(c *gin.Context) {
+	ctx := c.Request.Context()
+	infos, err := h.capture.GetEtcdClient().GetNamespaceInfos(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	usages, err := h.getNamespaceUsages(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for name := range usages {
+		if _, ok := infos[name]; !ok {
+			infos[name] = &model.NamespaceInfo{Name: name}
+		}
+	}
+
+	namespaces := make([]Namespace, 0, len(infos))
+	for name, info := range infos {
+		// skip the namespaces not accessible by the user.
+		if !middleware.IsNamespaceAllowed(c, name) {
+			continue
+		}
+		namespaces = append(namespaces, toAPINamespace(info, usages[name]))
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+	c.JSON(http.StatusOK, &ListResponse[Namespace]{
+		Total: len(namespaces),
+		Items: namespaces,
+	})
+}
+
+// getNamespace gets the settings of a namespace
+// @Summary Get a namespace
+// @Description get the settings and the usage of a namespace
+// @Tags namespace,v2
+// @Produce json
+// @Param namespace path string true "namespace"
+// @Success 200 {object} Namespace
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/namespaces/{namespace} [get]
+func (h *OpenAPIV2) getNamespace(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := c.Param(apiOpVarNamespaceName)
+	if err := middleware.AuthorizeNamespace(c, namespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	usages, err := h.getNamespaceUsages(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	info, err := h.capture.GetEtcdClient().GetNamespaceInfo(ctx, namespace)
+	if err != nil {
+		// a namespace without settings exists as long as it has changefeeds.
+		if !cerror.ErrNamespaceNotFound.Equal(err) || usages[namespace] == nil {
+			_ = c.Error(err)
+			return
+		}
+		info = &model.NamespaceInfo{Name: namespace}
+	}
+	c.JSON(http.StatusOK, toAPINamespace(info, usages[namespace]))
+}
+
+// createNamespace creates the settings of a namespace
+// @Summary Create a namespace
+// @Description create the settings of a namespace, including the default
+// @Description replica config, the quota and the labels
+// @Tags namespace,v2
+// @Accept json
+// @Produce json
+// @Param namespace body NamespaceConfig true "namespace config"
+// @Success 200 {object} Namespace
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/namespaces [post]
+func (h *OpenAPIV2) createNamespace(c *gin.Context) {
+	ctx := c.Request.Context()
+	cfg := &NamespaceConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	info, err := cfg.toNamespaceInfo()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.capture.GetEtcdClient().CreateNamespaceInfo(ctx, info); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("namespace is created",
+		zap.String("namespace", info.Name),
+		zap.Any("quota", info.Quota),
+		zap.Any("labels", info.Labels))
+	h.respondNamespace(c, info)
+}
+
+// updateNamespace updates the settings of a namespace
+// @Summary Update a namespace
+// @Description replace the settings of a namespace, the changefeeds created
+// @Description before are not affected
+// @Tags namespace,v2
+// @Accept json
+// @Produce json
+// @Param namespace path string true "namespace"
+// @Param config body NamespaceConfig true "namespace config"
+// @Success 200 {object} Namespace
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/namespaces/{namespace} [put]
+func (h *OpenAPIV2) updateNamespace(c *gin.Context) {
+	ctx := c.Request.Context()
+	cfg := &NamespaceConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	namespace := c.Param(apiOpVarNamespaceName)
+	if cfg.Name != "" && cfg.Name != namespace {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"the name of a namespace can not be changed"))
+		return
+	}
+	cfg.Name = namespace
+	info, err := cfg.toNamespaceInfo()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.capture.GetEtcdClient().UpdateNamespaceInfo(ctx, info); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("namespace is updated",
+		zap.String("namespace", info.Name),
+		zap.Any("quota", info.Quota),
+		zap.Any("labels", info.Labels))
+	h.respondNamespace(c, info)
+}
+
+// deleteNamespace removes the settings of a namespace
+// @Summary Remove a namespace
+// @Description remove the settings and the changefeed templates of a
+// @Description namespace which has no changefeed
+// @Tags namespace,v2
+// @Produce json
+// @Param namespace path string true "namespace"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/namespaces/{namespace} [delete]
+func (h *OpenAPIV2) deleteNamespace(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := c.Param(apiOpVarNamespaceName)
+	usages, err := h.getNamespaceUsages(ctx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if usage := usages[namespace]; usage != nil {
+		_ = c.Error(cerror.ErrNamespaceInUse.GenWithStackByArgs(
+			namespace, usage.changefeeds))
+		return
+	}
+	if err := h.capture.GetEtcdClient().DeleteNamespaceInfo(ctx, namespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("namespace is removed", zap.String("namespace", namespace))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// getNamespaceChangefeedConfig gets the config a changefeed inherits
+// @Summary Get the inherited changefeed config of a namespace
+// @Description get the sink uri and the replica config a new changefeed in
+// @Description the namespace inherits, with an optional changefeed template
+// @Tags namespace,v2
+// @Produce json
+// @Param namespace path string true "namespace"
+// @Param template query string false "changefeed template name"
+// @Success 200 {object} ChangefeedConfig
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/namespaces/{namespace}/changefeed_config [get]
+func (h *OpenAPIV2) getNamespaceChangefeedConfig(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := c.Param(apiOpVarNamespaceName)
+	if err := middleware.AuthorizeNamespace(c, namespace); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfg, err := h.resolveChangefeedConfig(ctx, namespace, c.Query(apiOpVarTemplate))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfg.SinkURI = maskTemplateSinkURI(cfg.SinkURI)
+	c.JSON(http.StatusOK, cfg)
+}
+
+// listChangefeedTemplates lists the changefeed templates of a namespace
+// @Summary List changefeed templates
+// @Description list all changefeed templates of a namespace
+// @Tags template,v2
+// @Produce json
+// @Param namespace query string false "default"
+// @Success 200 {array} ChangefeedTemplate
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/templates [get]
+func (h *OpenAPIV2) listChangefeedTemplates(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	infos, err := h.capture.GetEtcdClient().GetChangefeedTemplates(ctx, namespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	templates := make([]ChangefeedTemplate, 0, len(infos))
+	for _, info := range infos {
+		templates = append(templates, toAPIChangefeedTemplate(info))
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	c.JSON(http.StatusOK, &ListResponse[ChangefeedTemplate]{
+		Total: len(templates),
+		Items: templates,
+	})
+}
+
+// getChangefeedTemplate gets a changefeed template
+// @Summary Get a changefeed template
+// @Description get a changefeed template of a namespace
+// @Tags template,v2
+// @Produce json
+// @Param template_name path string true "template_name"
+// @Param namespace query string false "default"
+// @Success 200 {object} ChangefeedTemplate
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/templates/{template_name} [get]
+func (h *OpenAPIV2) getChangefeedTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	info, err := h.capture.GetEtcdClient().GetChangefeedTemplate(ctx,
+		namespace, c.Param(apiOpVarTemplateName))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIChangefeedTemplate(info))
+}
+
+// createChangefeedTemplate creates a changefeed template
+// @Summary Create a changefeed template
+// @Description create a named changefeed config which changefeeds can be created with
+// @Tags template,v2
+// @Accept json
+// @Produce json
+// @Param namespace query string false "default"
+// @Param template body ChangefeedTemplate true "changefeed template"
+// @Success 200 {object} ChangefeedTemplate
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/templates [post]
+func (h *OpenAPIV2) createChangefeedTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	template := &ChangefeedTemplate{}
+	if err := c.BindJSON(template); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	template.Namespace = getNamespaceValueWithDefault(c)
+	info, err := template.toChangefeedTemplate()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.capture.GetEtcdClient().CreateChangefeedTemplate(ctx, info); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("changefeed template is created",
+		zap.String("namespace", info.Namespace),
+		zap.String("name", info.Name))
+	c.JSON(http.StatusOK, toAPIChangefeedTemplate(info))
+}
+
+// updateChangefeedTemplate updates a changefeed template
+// @Summary Update a changefeed template
+// @Description replace a changefeed template, the changefeeds created
+// @Description with it before are not affected, an empty sink uri keeps
+// @Description the sink uri of the template
+// @Tags template,v2
+// @Accept json
+// @Produce json
+// @Param template_name path string true "template_name"
+// @Param namespace query string false "default"
+// @Param template body ChangefeedTemplate true "changefeed template"
+// @Success 200 {object} ChangefeedTemplate
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/templates/{template_name} [put]
+func (h *OpenAPIV2) updateChangefeedTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	template := &ChangefeedTemplate{}
+	if err := c.BindJSON(template); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	name := c.Param(apiOpVarTemplateName)
+	if template.Name != "" && template.Name != name {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"the name of a changefeed template can not be changed"))
+		return
+	}
+	template.Name = name
+	template.Namespace = getNamespaceValueWithDefault(c)
+	etcdClient := h.capture.GetEtcdClient()
+	if template.SinkURI == "" {
+		// the sink uri responded is masked, so it is kept if not specified.
+		old, err := etcdClient.GetChangefeedTemplate(ctx, template.Namespace, name)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		template.SinkURI = old.SinkURI
+	}
+	info, err := template.toChangefeedTemplate()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := etcdClient.UpdateChangefeedTemplate(ctx, info); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("changefeed template is updated",
+		zap.String("namespace", info.Namespace),
+		zap.String("name", info.Name))
+	c.JSON(http.StatusOK, toAPIChangefeedTemplate(info))
+}
+
+// deleteChangefeedTemplate removes a changefeed template
+// @Summary Remove a changefeed template
+// @Description remove a changefeed template, the changefeeds created
+// @Description with it are not affected
+// @Tags template,v2
+// @Produce json
+// @Param template_name path string true "template_name"
+// @Param namespace query string false "default"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/templates/{template_name} [delete]
+func (h *OpenAPIV2) deleteChangefeedTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	name := c.Param(apiOpVarTemplateName)
+	err := h.capture.GetEtcdClient().DeleteChangefeedTemplate(ctx, namespace, name)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("changefeed template is removed",
+		zap.String("namespace", namespace),
+		zap.String("name", name))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// resolveChangefeedConfig returns the config a new changefeed of the
+// namespace starts with: the default replica config, overridden by the
+// default replica config of the namespace, and then by the template.
+func (h *OpenAPIV2) resolveChangefeedConfig(
+	ctx context.Context, namespace, templateName string,
+) (*ChangefeedConfig, error) {
+	cfg := &ChangefeedConfig{
+		Namespace:     namespace,
+		Template:      templateName,
+		ReplicaConfig: GetDefaultReplicaConfig(),
+	}
+	etcdClient := h.capture.GetEtcdClient()
+	info, err := etcdClient.GetNamespaceInfo(ctx, namespace)
+	if err != nil && !cerror.ErrNamespaceNotFound.Equal(err) {
+		return nil, errors.Trace(err)
+	}
+	if info != nil && len(info.DefaultReplicaConfig) != 0 {
+		if err := json.Unmarshal(info.DefaultReplicaConfig, cfg.ReplicaConfig); err != nil {
+			return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+		}
+	}
+	if templateName == "" {
+		return cfg, nil
+	}
+	template, err := etcdClient.GetChangefeedTemplate(ctx, namespace, templateName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg.SinkURI = template.SinkURI
+	if len(template.ReplicaConfig) != 0 {
+		if err := json.Unmarshal(template.ReplicaConfig, cfg.ReplicaConfig); err != nil {
+			return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+		}
+	}
+	return cfg, nil
+}
+
+// namespaceLocks are the locks of namespaces.
+type namespaceLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newNamespaceLocks() *namespaceLocks {
+	return &namespaceLocks{locks: make(map[string]*sync.Mutex)}
+}
+
+// lock locks the namespace and returns the function to unlock it.
+func (l *namespaceLocks) lock(namespace string) func() {
+	l.mu.Lock()
+	lock, ok := l.locks[namespace]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[namespace] = lock
+	}
+	l.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// checkNamespaceQuota checks whether the changefeeds of a namespace exceed
+// its quota after the given changefeed is created or updated with the
+// memory quota. All changefeeds count, no matter they are stopped or not.
+// The caller must hold the quota lock of the namespace until the changefeed
+// is saved, otherwise the concurrent requests can exceed the quota together.
+// The changefeeds are read from etcd since the owner may not see the ones
+// saved just now.
+func (h *OpenAPIV2) checkNamespaceQuota(
+	ctx context.Context, id model.ChangeFeedID, memoryQuota uint64,
+) error {
+	info, err := h.capture.GetEtcdClient().GetNamespaceInfo(ctx, id.Namespace)
+	if err != nil {
+		if cerror.ErrNamespaceNotFound.Equal(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	quota := info.Quota
+	if quota.MaxChangefeeds <= 0 && quota.MaxMemoryQuota == 0 {
+		return nil
+	}
+	infos, err := h.capture.GetEtcdClient().GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	count, used := 1, memoryQuota
+	usages := namespaceUsagesOf(infos)
+	if usage := usages[id.Namespace]; usage != nil {
+		for i, cfID := range usage.changefeeds {
+			if cfID == id.ID {
+				continue
+			}
+			count++
+			used += usage.memoryQuotas[i]
+		}
+	}
+	if quota.MaxChangefeeds > 0 && count > quota.MaxChangefeeds {
+		return cerror.ErrNamespaceQuotaExceeded.GenWithStackByArgs(id.Namespace,
+			fmt.Sprintf("max-changefeeds: %d", quota.MaxChangefeeds))
+	}
+	if quota.MaxMemoryQuota > 0 && used > quota.MaxMemoryQuota {
+		return cerror.ErrNamespaceQuotaExceeded.GenWithStackByArgs(id.Namespace,
+			fmt.Sprintf("max-memory-quota: %d, required: %d", quota.MaxMemoryQuota, used))
+	}
+	return nil
+}
+
+// namespaceUsage is the changefeeds of a namespace and their memory quota.
+type namespaceUsage struct {
+	changefeeds  []string
+	memoryQuotas []uint64
+}
+
+func (u *namespaceUsage) memoryQuotaUsed() uint64 {
+	var used uint64
+	for _, quota := range u.memoryQuotas {
+		used += quota
+	}
+	return used
+}
+
+// getNamespaceUsages returns the usage of each namespace which has changefeeds,
+// the changefeeds are sorted by their ids.
+func (h *OpenAPIV2) getNamespaceUsages(
+	ctx context.Context,
+) (map[string]*namespaceUsage, error) {
+	infos, err := h.capture.StatusProvider().GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return namespaceUsagesOf(infos), nil
+}
+
+// namespaceUsagesOf groups the changefeeds by their namespaces.
+func namespaceUsagesOf(
+	infos map[model.ChangeFeedID]*model.ChangeFeedInfo,
+) map[string]*namespaceUsage {
+	ids := make([]model.ChangeFeedID, 0, len(infos))
+	for id := range infos {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].ID < ids[j].ID
+	})
+	res := make(map[string]*namespaceUsage)
+	for _, id := range ids {
+		usage, ok := res[id.Namespace]
+		if !ok {
+			usage = &namespaceUsage{}
+			res[id.Namespace] = usage
+		}
+		var memoryQuota uint64
+		if cfg := infos[id].Config; cfg != nil {
+			memoryQuota = cfg.MemoryQuota
+		}
+		usage.changefeeds = append(usage.changefeeds, id.ID)
+		usage.memoryQuotas = append(usage.memoryQuotas, memoryQuota)
+	}
+	return res
+}
+
+// respondNamespace responds with the settings and the usage of a namespace.
+func (h *OpenAPIV2) respondNamespace(c *gin.Context, info *model.NamespaceInfo) {
+	usages, err := h.getNamespaceUsages(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPINamespace(info, usages[info.Name]))
+}
+
+// verifyPartialReplicaConfig checks whether a partial replica config can be
+// decoded on top of the default replica config.
+func verifyPartialReplicaConfig(data json.RawMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(GetDefaultReplicaConfig()); err != nil {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid replica config: %s", err.Error())
+	}
+	return nil
+}
+
+func (c *NamespaceConfig) toNamespaceInfo() (*model.NamespaceInfo, error) {
+	if err := model.ValidateNamespace(c.Name); err != nil {
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid namespace: %s", c.Name)
+	}
+	if c.Quota.MaxChangefeeds < 0 {
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid max_changefeeds: %d", c.Quota.MaxChangefeeds)
+	}
+	if err := verifyPartialReplicaConfig(c.DefaultReplicaConfig); err != nil {
+		return nil, err
+	}
+	return &model.NamespaceInfo{
+		Name:                 c.Name,
+		DefaultReplicaConfig: c.DefaultReplicaConfig,
+		Quota: model.NamespaceQuota{
+			MaxChangefeeds: c.Quota.MaxChangefeeds,
+			MaxMemoryQuota: c.Quota.MaxMemoryQuota,
+		},
+		Labels: c.Labels,
+	}, nil
+}
+
+func (t *ChangefeedTemplate) toChangefeedTemplate() (*model.ChangefeedTemplate, error) {
+	if err := model.ValidateChangefeedID(t.Name); err != nil {
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid template name: %s", t.Name)
+	}
+	if err := model.ValidateNamespace(t.Namespace); err != nil {
+		return nil, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid namespace: %s", t.Namespace)
+	}
+	if t.SinkURI != "" {
+		if _, err := url.Parse(t.SinkURI); err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+		}
+	}
+	if err := verifyPartialReplicaConfig(t.ReplicaConfig); err != nil {
+		return nil, err
+	}
+	return &model.ChangefeedTemplate{
+		Namespace:     t.Namespace,
+		Name:          t.Name,
+		Description:   t.Description,
+		SinkURI:       t.SinkURI,
+		ReplicaConfig: t.ReplicaConfig,
+	}, nil
+}
+
+func toAPINamespace(info *model.NamespaceInfo, usage *namespaceUsage) Namespace {
+	namespace := Namespace{
+		NamespaceConfig: NamespaceConfig{
+			Name:                 info.Name,
+			DefaultReplicaConfig: info.DefaultReplicaConfig,
+			Quota: NamespaceQuota{
+				MaxChangefeeds: info.Quota.MaxChangefeeds,
+				MaxMemoryQuota: info.Quota.MaxMemoryQuota,
+			},
+			Labels: info.Labels,
+		},
+		Changefeeds: []string{},
+	}
+	if usage != nil {
+		namespace.Changefeeds = usage.changefeeds
+		namespace.MemoryQuotaUsed = usage.memoryQuotaUsed()
+	}
+	return namespace
+}
+
+func toAPIChangefeedTemplate(info *model.ChangefeedTemplate) ChangefeedTemplate {
+	return ChangefeedTemplate{
+		Namespace:     info.Namespace,
+		Name:          info.Name,
+		Description:   info.Description,
+		SinkURI:       maskTemplateSinkURI(info.SinkURI),
+		ReplicaConfig: info.ReplicaConfig,
+	}
+}
+
+// maskTemplateSinkURI masks the credentials in the sink uri of a template,
+// a changefeed created with the template still uses the original one.
+// The uri without credentials is returned as is, since masking re-encodes
+// it, e.g. `blackhole://` becomes `blackhole:`.
+func maskTemplateSinkURI(sinkURI string) string {
+	if sinkURI == "" {
+		return ""
+	}
+	parsed, err := url.Parse(sinkURI)
+	if err != nil {
+		log.Error("failed to parse sink URI", zap.Error(err))
+		return ""
+	}
+	if _, ok := parsed.User.Password(); !ok && !parsed.Query().Has("sasl-password") {
+		return sinkURI
+	}
+	masked, err := util.MaskSinkURI(sinkURI)
+	if err != nil {
+		log.Error("failed to mask sink URI", zap.Error(err))
+	}
+	return masked
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+const (
+	apiOpVarStartTs = "start_ts"
+	apiOpVarEndTs   = "end_ts"
+)
+
+// getSchemaHistory exports the schema history of a changefeed
+// @Summary Export the schema history of a changefeed
+// @Description Export the versioned table definitions of a changefeed between start_ts and end_ts
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param start_ts query integer false "start ts"
+// @Param end_ts query integer false "end ts"
+// @Success 200 {object} schemahistory.History
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/schema_history [get]
+func (h *OpenAPIV2) getSchemaHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	changefeedID := model.ChangeFeedID{Namespace: namespace, ID: c.Param(api.APIOpVarChangefeedID)}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	startTs, err := parseTsQuery(c, apiOpVarStartTs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	endTs, err := parseTsQuery(c, apiOpVarEndTs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	cfInfo, err := h.capture.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if endTs == 0 {
+		status, err := h.capture.StatusProvider().GetChangeFeedStatus(ctx, changefeedID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		endTs = status.CheckpointTs
+	}
+	if startTs == 0 {
+		startTs = endTs
+	}
+	if startTs > endTs {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"start_ts %d is greater than end_ts %d", startTs, endTs))
+		return
+	}
+
+	upManager, err := h.capture.GetUpstreamManager()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	up, ok := upManager.Get(cfInfo.UpstreamID)
+	if !ok {
+		_ = c.Error(cerror.ErrUpstreamNotFound.GenWithStackByArgs(cfInfo.UpstreamID))
+		return
+	}
+
+	history, err := h.helpers.exportSchemaHistory(ctx, changefeedID,
+		cfInfo.Config, up.KVStorage, startTs, endTs)
+	if err != nil {
+		_ = c.Error(errors.Trace(err))
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// parseTsQuery parses the ts in the query, 0 is returned if it's not set.
+func parseTsQuery(c *gin.Context, key string) (uint64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	ts, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, cerror.ErrAPIInvalidParam.GenWithStack("invalid %s: %s", key, value)
+	}
+	return ts, nil
+}
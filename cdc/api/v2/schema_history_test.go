@@ -0,0 +1,98 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
+	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSchemaHistory(t *testing.T) {
+	t.Parallel()
+
+	url := "/api/v2/changefeeds/%s/schema_history?%s"
+	changefeedID := model.DefaultChangeFeedID("test")
+	statusProvider := &mockStatusProvider{
+		changefeedInfo: &model.ChangeFeedInfo{
+			ID:     changefeedID.ID,
+			Config: config.GetDefaultReplicaConfig(),
+		},
+		changefeedStatus: &model.ChangeFeedStatusForAPI{CheckpointTs: 200},
+	}
+	helpers := NewMockAPIV2Helpers(gomock.NewController(t))
+	cp := mock_capture.NewMockCapture(gomock.NewController(t))
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(statusProvider).AnyTimes()
+	cp.EXPECT().GetUpstreamManager().Return(upstream.NewManager4Test(&mockPDClient{}), nil).AnyTimes()
+
+	apiV2 := NewOpenAPIV2ForTest(cp, helpers)
+	router := newRouter(apiV2)
+
+	request := func(id, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			http.MethodGet, fmt.Sprintf(url, id, query), nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+	requireError := func(w *httptest.ResponseRecorder, code string) {
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		respErr := model.HTTPError{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&respErr))
+		require.Contains(t, respErr.Code, code)
+	}
+
+	// invalid changefeed id and ts
+	requireError(request("@^Invalid", ""), "ErrAPIInvalidParam")
+	requireError(request(changefeedID.ID, "start_ts=abc"), "ErrAPIInvalidParam")
+	requireError(request(changefeedID.ID, "start_ts=300"), "ErrAPIInvalidParam")
+
+	// the ts default to the checkpoint ts
+	history := schemahistory.NewHistory(changefeedID, 200, 200)
+	helpers.EXPECT().
+		exportSchemaHistory(gomock.Any(), changefeedID, gomock.Any(), gomock.Any(),
+			uint64(200), uint64(200)).
+		Return(history, nil)
+	w := request(changefeedID.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &schemahistory.History{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, uint64(200), resp.StartTs)
+	require.Equal(t, schemahistory.FormatVersion, resp.FormatVersion)
+
+	history = schemahistory.NewHistory(changefeedID, 100, 150)
+	helpers.EXPECT().
+		exportSchemaHistory(gomock.Any(), changefeedID, gomock.Any(), gomock.Any(),
+			uint64(100), uint64(150)).
+		Return(history, nil)
+	w = request(changefeedID.ID, "start_ts=100&end_ts=150")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &schemahistory.History{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, uint64(100), resp.StartTs)
+	require.Equal(t, uint64(150), resp.EndTs)
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// defaultSlowestTablesLimit is the default number of the slowest tables
+// in the statistics of a changefeed.
+const defaultSlowestTablesLimit = 10
+
+// getChangefeedStatistics gets the throughput and the lag of a changefeed
+// @Summary Get the statistics of a changefeed
+// @Description Get the throughput, the executed DDLs, the lag of each table, the slowest
+// @Description tables and the sorter and sink queue sizes of a changefeed, which are
+// @Description aggregated by the owner from the heartbeats of the processors
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param namespace query string false "default"
+// @Param limit query integer false "max number of the slowest tables, default 10"
+// @Success 200 {object} model.ChangefeedStatistics
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/statistics [get]
+func (h *OpenAPIV2) getChangefeedStatistics(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	changefeedID := model.ChangeFeedID{Namespace: namespace, ID: c.Param(api.APIOpVarChangefeedID)}
+	if err := model.ValidateChangefeedID(changefeedID.ID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedID.ID))
+		return
+	}
+	limit, err := parseLimit(c, defaultSlowestTablesLimit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	stats, err := h.capture.StatusProvider().GetChangeFeedStatistics(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	stats.FillSlowestTables(limit)
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	mock_owner "github.com/pingcap/tiflow/cdc/owner/mock"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChangefeedStatistics(t *testing.T) {
+	t.Parallel()
+
+	url := "/api/v2/changefeeds/%s/statistics?%s"
+	changefeedID := model.DefaultChangeFeedID("test")
+	ctrl := gomock.NewController(t)
+	provider := mock_owner.NewMockStatusProvider(ctrl)
+	cp := mock_capture.NewMockCapture(ctrl)
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(provider).AnyTimes()
+
+	apiV2 := NewOpenAPIV2ForTest(cp, APIV2HelpersImpl{})
+	router := newRouter(apiV2)
+	request := func(id, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(),
+			http.MethodGet, fmt.Sprintf(url, id, query), nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// invalid changefeed id and limit
+	for _, w := range []*httptest.ResponseRecorder{
+		request("@^Invalid", ""),
+		request(changefeedID.ID, "limit=abc"),
+		request(changefeedID.ID, "limit=0"),
+	} {
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		respErr := model.HTTPError{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&respErr))
+		require.Contains(t, respErr.Code, "ErrAPIInvalidParam")
+	}
+
+	// changefeed not exists
+	provider.EXPECT().GetChangeFeedStatistics(gomock.Any(), changefeedID).
+		Return(nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedID.ID))
+	w := request(changefeedID.ID, "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	newStatistics := func() *model.ChangefeedStatistics {
+		tables := make([]model.TableStatistics, 0, 20)
+		for i := 1; i <= 20; i++ {
+			tables = append(tables, model.TableStatistics{
+				TableID: model.TableID(i), CheckpointTs: uint64(100 - i),
+			})
+		}
+		return &model.ChangefeedStatistics{
+			CheckpointTs: 80, RowsPerSecond: 100, ExecutedDDLs: 3, Tables: tables,
+		}
+	}
+	provider.EXPECT().GetChangeFeedStatistics(gomock.Any(), changefeedID).
+		Return(newStatistics(), nil)
+	w = request(changefeedID.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &model.ChangefeedStatistics{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, float64(100), resp.RowsPerSecond)
+	require.Equal(t, uint64(3), resp.ExecutedDDLs)
+	require.Len(t, resp.Tables, 20)
+	require.Len(t, resp.SlowestTables, defaultSlowestTablesLimit)
+	require.Equal(t, model.TableID(20), resp.SlowestTables[0].TableID)
+
+	provider.EXPECT().GetChangeFeedStatistics(gomock.Any(), changefeedID).
+		Return(newStatistics(), nil)
+	w = request(changefeedID.ID, "limit=3")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &model.ChangefeedStatistics{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Len(t, resp.SlowestTables, 3)
+	require.Equal(t, model.TableID(18), resp.SlowestTables[2].TableID)
+}
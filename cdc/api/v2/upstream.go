@@ -0,0 +1,493 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/zap"
+)
+
+const apiOpVarUpstreamID = "upstream_id"
+
+// listUpstreams lists all upstreams of a namespace
+// @Summary List upstreams
+// @Description list all upstreams of a namespace, including the default upstream
+// @Tags upstream,v2
+// @Produce json
+// @Param namespace query string false "default"
+// @Success 200 {array} Upstream
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/upstreams [get]
+func (h *OpenAPIV2) listUpstreams(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+
+	infos, err := h.capture.GetEtcdClient().GetUpstreamInfos(ctx, namespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defaultUp, err := getCaptureDefaultUpstream(h.capture)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	changefeeds, err := h.getUpstreamChangefeeds(ctx, namespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	upstreamInfos := make([]*model.UpstreamInfo, 0, len(infos)+1)
+	for _, info := range infos {
+		upstreamInfos = append(upstreamInfos, info)
+	}
+	if _, ok := infos[defaultUp.ID]; !ok {
+		upstreamInfos = append(upstreamInfos, &model.UpstreamInfo{ID: defaultUp.ID})
+	}
+	upstreams := make([]Upstream, 0, len(upstreamInfos))
+	for _, info := range upstreamInfos {
+		upstream := toAPIUpstream(info)
+		if info.ID == defaultUp.ID {
+			upstream.IsDefault = true
+			// the pd endpoints of the default upstream are not stored in etcd.
+			if len(upstream.PDAddrs) == 0 {
+				upstream.PDAddrs = defaultUp.PdEndpoints
+			}
+		}
+		upstream.Changefeeds = changefeeds[info.ID]
+		if upstream.Changefeeds == nil {
+			upstream.Changefeeds = []string{}
+		}
+		upstreams = append(upstreams, upstream)
+	}
+	sort.Slice(upstreams, func(i, j int) bool {
+		return upstreams[i].ID < upstreams[j].ID
+	})
+	c.JSON(http.StatusOK, &ListResponse[Upstream]{
+		Total: len(upstreams),
+		Items: upstreams,
+	})
+}
+
+// createUpstream registers an upstream
+// @Summary Create an upstream
+// @Description register an upstream TiDB cluster with a name
+// @Tags upstream,v2
+// @Accept json
+// @Produce json
+// @Param namespace query string false "default"
+// @Param upstream body CreateUpstreamConfig true "upstream config"
+// @Success 200 {object} Upstream
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/upstreams [post]
+func (h *OpenAPIV2) createUpstream(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+
+	cfg := &CreateUpstreamConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIInvalidParam, err))
+		return
+	}
+	if cfg.Name == "" {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("upstream name is empty"))
+		return
+	}
+	if len(cfg.PDAddrs) == 0 {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("pd address is empty"))
+		return
+	}
+
+	infos, err := h.capture.GetEtcdClient().GetUpstreamInfos(ctx, namespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for _, info := range infos {
+		if info.Name == cfg.Name {
+			_ = c.Error(cerror.ErrUpstreamAlreadyExists.GenWithStackByArgs(
+				"name: " + cfg.Name))
+			return
+		}
+	}
+
+	// get the cluster id of the upstream.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	pdClient, err := h.helpers.getPDClient(timeoutCtx, cfg.PDAddrs, cfg.toCredential())
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIGetPDClientFailed, err))
+		return
+	}
+	defer pdClient.Close()
+
+	info := &model.UpstreamInfo{
+		ID:            pdClient.GetClusterID(ctx),
+		Name:          cfg.Name,
+		PDEndpoints:   strings.Join(cfg.PDAddrs, ","),
+		KeyPath:       cfg.KeyPath,
+		CertPath:      cfg.CertPath,
+		CAPath:        cfg.CAPath,
+		CertAllowedCN: cfg.CertAllowedCN,
+	}
+	err = h.capture.GetEtcdClient().CreateUpstreamInfo(ctx, info, namespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("upstream is created",
+		zap.String("namespace", namespace),
+		zap.Uint64("upstreamID", info.ID),
+		zap.String("name", info.Name),
+		zap.String("pdEndpoints", info.PDEndpoints))
+
+	upstream := toAPIUpstream(info)
+	upstream.Changefeeds = []string{}
+	c.JSON(http.StatusOK, upstream)
+}
+
+// deleteUpstream removes an upstream
+// @Summary Remove an upstream
+// @Description remove an upstream which is not used by any changefeed
+// @Tags upstream,v2
+// @Produce json
+// @Param upstream_id path integer true "upstream_id"
+// @Param namespace query string false "default"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/upstreams/{upstream_id} [delete]
+func (h *OpenAPIV2) deleteUpstream(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	upstreamID, err := parseUpstreamID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	defaultUp, err := getCaptureDefaultUpstream(h.capture)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if upstreamID == defaultUp.ID {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"cannot remove the default upstream %d", upstreamID))
+		return
+	}
+	changefeeds, err := h.getUpstreamChangefeeds(ctx, namespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if len(changefeeds[upstreamID]) > 0 {
+		_ = c.Error(cerror.ErrUpstreamInUse.GenWithStackByArgs(
+			upstreamID, changefeeds[upstreamID]))
+		return
+	}
+
+	err = h.capture.GetEtcdClient().DeleteUpstreamInfo(ctx, upstreamID, namespace)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	log.Info("upstream is removed",
+		zap.String("namespace", namespace),
+		zap.Uint64("upstreamID", upstreamID))
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
+
+// getUpstreamHealth checks the health of an upstream
+// @Summary Get the health of an upstream
+// @Description check the pd members and the tso service of an upstream
+// @Tags upstream,v2
+// @Produce json
+// @Param upstream_id path integer true "upstream_id"
+// @Param namespace query string false "default"
+// @Success 200 {object} UpstreamHealth
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/upstreams/{upstream_id}/health [get]
+func (h *OpenAPIV2) getUpstreamHealth(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	upstreamID, err := parseUpstreamID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	up, err := h.resolveUpstream(ctx, namespace, upstreamID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer up.release()
+
+	resp := &UpstreamHealth{
+		ID:      upstreamID,
+		Name:    up.name,
+		Members: []PDMemberHealth{},
+	}
+	if err := h.checkUpstreamHealth(ctx, up, resp); err != nil {
+		resp.Error = err.Error()
+	}
+	resp.Healthy = resp.Error == ""
+	c.JSON(http.StatusOK, resp)
+}
+
+// getUpstreamGCSafePoint gets the gc safepoints of an upstream
+// @Summary Get the gc safepoints of an upstream
+// @Description get the gc safepoint and the service gc safepoints of an upstream
+// @Tags upstream,v2
+// @Produce json
+// @Param upstream_id path integer true "upstream_id"
+// @Param namespace query string false "default"
+// @Success 200 {object} UpstreamGCSafePoint
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/upstreams/{upstream_id}/gc_safepoint [get]
+func (h *OpenAPIV2) getUpstreamGCSafePoint(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := getNamespaceValueWithDefault(c)
+	upstreamID, err := parseUpstreamID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	up, err := h.resolveUpstream(ctx, namespace, upstreamID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer up.release()
+	if up.pdClient == nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrAPIGetPDClientFailed, up.err))
+		return
+	}
+
+	apiClient, err := h.helpers.getPDAPIClient(up.pdClient, up.credential)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer apiClient.Close()
+	safePoints, err := apiClient.ListGcServiceSafePoint(ctx)
+	if err != nil {
+		_ = c.Error(cerror.WrapError(cerror.ErrInternalServerError, err))
+		return
+	}
+
+	resp := &UpstreamGCSafePoint{
+		ID:                upstreamID,
+		Name:              up.name,
+		GCSafePoint:       safePoints.GCSafePoint,
+		CDCServiceID:      h.capture.GetEtcdClient().GetGCServiceID(),
+		ServiceSafePoints: make([]ServiceGCSafePoint, 0, len(safePoints.ServiceGCSafepoints)),
+	}
+	for _, sp := range safePoints.ServiceGCSafepoints {
+		safePoint := ServiceGCSafePoint{
+			ServiceID: sp.ServiceID,
+			ExpiredAt: sp.ExpiredAt,
+			SafePoint: sp.SafePoint,
+		}
+		if sp.ServiceID == resp.CDCServiceID {
+			resp.CDCSafePoint = &safePoint
+		}
+		resp.ServiceSafePoints = append(resp.ServiceSafePoints, safePoint)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// resolvedUpstream is an upstream resolved by its id.
+type resolvedUpstream struct {
+	name       string
+	pdClient   pd.Client
+	credential *security.Credential
+	// err is the error encountered when connecting to the upstream,
+	// pdClient is nil if it's not nil.
+	err     error
+	release func()
+}
+
+// resolveUpstream finds the pd client of an upstream. The upstream in the
+// upstream manager is used if it's available, otherwise a temporary pd client
+// is created with the upstream info in etcd.
+func (h *OpenAPIV2) resolveUpstream(
+	ctx context.Context, namespace string, upstreamID model.UpstreamID,
+) (*resolvedUpstream, error) {
+	upManager, err := h.capture.GetUpstreamManager()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defaultUp, err := upManager.GetDefaultUpstream()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := h.capture.GetEtcdClient().GetUpstreamInfo(ctx, upstreamID, namespace)
+	if err != nil {
+		if !cerror.ErrUpstreamNotFound.Equal(err) || upstreamID != defaultUp.ID {
+			return nil, errors.Trace(err)
+		}
+		info = &model.UpstreamInfo{ID: upstreamID}
+	}
+
+	res := &resolvedUpstream{name: info.Name, release: func() {}}
+	if up, ok := upManager.Get(upstreamID); ok && up.PDClient != nil {
+		res.pdClient = up.PDClient
+		res.credential = up.SecurityConfig
+		res.err = up.Error()
+		return res, nil
+	}
+
+	res.credential = &security.Credential{
+		CAPath:        info.CAPath,
+		CertPath:      info.CertPath,
+		KeyPath:       info.KeyPath,
+		CertAllowedCN: info.CertAllowedCN,
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	pdClient, err := h.helpers.getPDClient(timeoutCtx,
+		strings.Split(info.PDEndpoints, ","), res.credential)
+	if err != nil {
+		res.err = err
+		return res, nil
+	}
+	res.pdClient = pdClient
+	res.release = pdClient.Close
+	return res, nil
+}
+
+// checkUpstreamHealth fills the pd members and the tso of an upstream,
+// the first error encountered is returned.
+func (h *OpenAPIV2) checkUpstreamHealth(
+	ctx context.Context, up *resolvedUpstream, resp *UpstreamHealth,
+) error {
+	if up.pdClient == nil {
+		return up.err
+	}
+	apiClient, err := h.helpers.getPDAPIClient(up.pdClient, up.credential)
+	if err != nil {
+		return err
+	}
+	defer apiClient.Close()
+
+	var firstErr error
+	endpoints, err := apiClient.CollectMemberEndpoints(ctx)
+	if err != nil {
+		firstErr = err
+	}
+	for _, endpoint := range endpoints {
+		member := PDMemberHealth{Endpoint: endpoint, Healthy: true}
+		if err := apiClient.Healthy(ctx, endpoint); err != nil {
+			member.Healthy = false
+			member.Error = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		resp.Members = append(resp.Members, member)
+	}
+
+	physical, logical, err := up.pdClient.GetTS(ctx)
+	if err != nil {
+		if firstErr == nil {
+			firstErr = err
+		}
+	} else {
+		resp.Tso = oracle.ComposeTS(physical, logical)
+	}
+	if firstErr == nil {
+		firstErr = up.err
+	}
+	return firstErr
+}
+
+// getUpstreamChangefeeds returns the sorted changefeed ids of each
+// upstream in a namespace.
+func (h *OpenAPIV2) getUpstreamChangefeeds(
+	ctx context.Context, namespace string,
+) (map[model.UpstreamID][]string, error) {
+	infos, err := h.capture.StatusProvider().GetAllChangeFeedInfo(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := make(map[model.UpstreamID][]string)
+	for id, info := range infos {
+		if id.Namespace != namespace {
+			continue
+		}
+		res[info.UpstreamID] = append(res[info.UpstreamID], id.ID)
+	}
+	for _, ids := range res {
+		sort.Strings(ids)
+	}
+	return res, nil
+}
+
+// getUpstreamByName returns the upstream info with the given name.
+func (h *OpenAPIV2) getUpstreamByName(
+	ctx context.Context, namespace, name string,
+) (*model.UpstreamInfo, error) {
+	infos, err := h.capture.GetEtcdClient().GetUpstreamInfos(ctx, namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, info := range infos {
+		if info.Name == name {
+			return info, nil
+		}
+	}
+	return nil, cerror.ErrUpstreamNotFound.GenWithStack(
+		"upstream not found, name: %s", name)
+}
+
+func parseUpstreamID(c *gin.Context) (model.UpstreamID, error) {
+	value := c.Param(apiOpVarUpstreamID)
+	upstreamID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, cerror.ErrAPIInvalidParam.GenWithStack(
+			"invalid upstream_id: %s", value)
+	}
+	return upstreamID, nil
+}
+
+func toAPIUpstream(info *model.UpstreamInfo) Upstream {
+	upstream := Upstream{
+		ID:            info.ID,
+		Name:          info.Name,
+		PDAddrs:       []string{},
+		CAPath:        info.CAPath,
+		CertPath:      info.CertPath,
+		KeyPath:       info.KeyPath,
+		CertAllowedCN: info.CertAllowedCN,
+	}
+	if info.PDEndpoints != "" {
+		upstream.PDAddrs = strings.Split(info.PDEndpoints, ",")
+	}
+	return upstream
+}
@@ -0,0 +1,302 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	mock_capture "github.com/pingcap/tiflow/cdc/capture/mock"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	mock_etcd "github.com/pingcap/tiflow/pkg/etcd/mock"
+	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/upstream"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// mockPDAPIClient mocks pdutil.PDAPIClient to facilitate unit testing.
+type mockPDAPIClient struct {
+	pdutil.PDAPIClient
+	members    []string
+	unhealthy  map[string]error
+	safePoints *pdutil.ListServiceGCSafepoint
+}
+
+func (m *mockPDAPIClient) CollectMemberEndpoints(ctx context.Context) ([]string, error) {
+	return m.members, nil
+}
+
+func (m *mockPDAPIClient) Healthy(ctx context.Context, endpoint string) error {
+	return m.unhealthy[endpoint]
+}
+
+func (m *mockPDAPIClient) ListGcServiceSafePoint(
+	ctx context.Context,
+) (*pdutil.ListServiceGCSafepoint, error) {
+	return m.safePoints, nil
+}
+
+func (m *mockPDAPIClient) Close() {}
+
+type upstreamTestSuite struct {
+	router     http.Handler
+	helpers    *MockAPIV2Helpers
+	etcdClient *mock_etcd.MockCDCEtcdClient
+	provider   *mockStatusProvider
+}
+
+func newUpstreamTestSuite(t *testing.T) *upstreamTestSuite {
+	ctrl := gomock.NewController(t)
+	cp := mock_capture.NewMockCapture(ctrl)
+	helpers := NewMockAPIV2Helpers(ctrl)
+	etcdClient := mock_etcd.NewMockCDCEtcdClient(ctrl)
+	provider := &mockStatusProvider{}
+	cp.EXPECT().IsReady().Return(true).AnyTimes()
+	cp.EXPECT().IsOwner().Return(true).AnyTimes()
+	cp.EXPECT().GetEtcdClient().Return(etcdClient).AnyTimes()
+	cp.EXPECT().StatusProvider().Return(provider).AnyTimes()
+	cp.EXPECT().GetUpstreamManager().
+		Return(upstream.NewManager4Test(&mockPDClient{timestamp: 100, logicTime: 1}), nil).
+		AnyTimes()
+	return &upstreamTestSuite{
+		router:     newRouter(NewOpenAPIV2ForTest(cp, helpers)),
+		helpers:    helpers,
+		etcdClient: etcdClient,
+		provider:   provider,
+	}
+}
+
+func (s *upstreamTestSuite) request(method, url string, body []byte) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), method, url, bytes.NewReader(body))
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func requireHTTPError(t *testing.T, w *httptest.ResponseRecorder, status int, code string) {
+	require.Equal(t, status, w.Code)
+	respErr := model.HTTPError{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&respErr))
+	require.Contains(t, respErr.Code, code)
+}
+
+func TestListUpstreams(t *testing.T) {
+	t.Parallel()
+
+	s := newUpstreamTestSuite(t)
+	s.etcdClient.EXPECT().GetUpstreamInfos(gomock.Any(), "default").Return(nil,
+		cerror.ErrPDEtcdAPIError)
+	w := s.request(http.MethodGet, "/api/v2/upstreams", nil)
+	requireHTTPError(t, w, http.StatusInternalServerError, "ErrPDEtcdAPIError")
+
+	s.etcdClient.EXPECT().GetUpstreamInfos(gomock.Any(), "default").Return(
+		map[model.UpstreamID]*model.UpstreamInfo{
+			123: {ID: 123, Name: "up1", PDEndpoints: "http://127.0.0.1:2379,http://127.0.0.1:2479"},
+		}, nil)
+	s.provider.changefeedInfos = map[model.ChangeFeedID]*model.ChangeFeedInfo{
+		model.DefaultChangeFeedID("cf2"):        {UpstreamID: 123},
+		model.DefaultChangeFeedID("cf1"):        {UpstreamID: 123},
+		model.DefaultChangeFeedID("cf3"):        {UpstreamID: 0},
+		model.ChangeFeedID4Test("other", "cf4"): {UpstreamID: 123},
+	}
+	w = s.request(http.MethodGet, "/api/v2/upstreams", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &ListResponse[Upstream]{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, 2, resp.Total)
+	// the default upstream is listed even if it's not stored in etcd.
+	require.Equal(t, uint64(0), resp.Items[0].ID)
+	require.True(t, resp.Items[0].IsDefault)
+	require.Equal(t, []string{"cf3"}, resp.Items[0].Changefeeds)
+	require.Equal(t, uint64(123), resp.Items[1].ID)
+	require.Equal(t, "up1", resp.Items[1].Name)
+	require.False(t, resp.Items[1].IsDefault)
+	require.Equal(t, []string{"http://127.0.0.1:2379", "http://127.0.0.1:2479"},
+		resp.Items[1].PDAddrs)
+	require.Equal(t, []string{"cf1", "cf2"}, resp.Items[1].Changefeeds)
+}
+
+func TestCreateUpstream(t *testing.T) {
+	t.Parallel()
+
+	s := newUpstreamTestSuite(t)
+	url := "/api/v2/upstreams"
+
+	// invalid config
+	w := s.request(http.MethodPost, url, []byte("{"))
+	requireHTTPError(t, w, http.StatusBadRequest, "ErrAPIInvalidParam")
+	body, _ := json.Marshal(&CreateUpstreamConfig{
+		PDConfig: PDConfig{PDAddrs: []string{"http://127.0.0.1:2379"}},
+	})
+	w = s.request(http.MethodPost, url, body)
+	requireHTTPError(t, w, http.StatusBadRequest, "ErrAPIInvalidParam")
+	body, _ = json.Marshal(&CreateUpstreamConfig{Name: "up1"})
+	w = s.request(http.MethodPost, url, body)
+	requireHTTPError(t, w, http.StatusBadRequest, "ErrAPIInvalidParam")
+
+	// duplicated name
+	body, _ = json.Marshal(&CreateUpstreamConfig{
+		Name:     "up1",
+		PDConfig: PDConfig{PDAddrs: []string{"http://127.0.0.1:2379"}, CAPath: "ca.pem"},
+	})
+	s.etcdClient.EXPECT().GetUpstreamInfos(gomock.Any(), "default").Return(
+		map[model.UpstreamID]*model.UpstreamInfo{1: {ID: 1, Name: "up1"}}, nil)
+	w = s.request(http.MethodPost, url, body)
+	requireHTTPError(t, w, http.StatusBadRequest, "ErrUpstreamAlreadyExists")
+
+	// failed to connect to the upstream
+	s.etcdClient.EXPECT().GetUpstreamInfos(gomock.Any(), "default").Return(nil, nil).Times(2)
+	s.helpers.EXPECT().getPDClient(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection refused"))
+	w = s.request(http.MethodPost, url, body)
+	requireHTTPError(t, w, http.StatusInternalServerError, "ErrAPIGetPDClientFailed")
+
+	// success
+	s.helpers.EXPECT().getPDClient(gomock.Any(), []string{"http://127.0.0.1:2379"}, gomock.Any()).
+		Return(&mockPDClient{}, nil)
+	s.etcdClient.EXPECT().CreateUpstreamInfo(gomock.Any(), &model.UpstreamInfo{
+		ID:          123,
+		Name:        "up1",
+		PDEndpoints: "http://127.0.0.1:2379",
+		CAPath:      "ca.pem",
+	}, "default").Return(nil)
+	w = s.request(http.MethodPost, url, body)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &Upstream{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, uint64(123), resp.ID)
+	require.Equal(t, "up1", resp.Name)
+}
+
+func TestDeleteUpstream(t *testing.T) {
+	t.Parallel()
+
+	s := newUpstreamTestSuite(t)
+
+	w := s.request(http.MethodDelete, "/api/v2/upstreams/abc", nil)
+	requireHTTPError(t, w, http.StatusBadRequest, "ErrAPIInvalidParam")
+	// the default upstream can not be removed
+	w = s.request(http.MethodDelete, "/api/v2/upstreams/0", nil)
+	requireHTTPError(t, w, http.StatusBadRequest, "ErrAPIInvalidParam")
+
+	s.provider.changefeedInfos = map[model.ChangeFeedID]*model.ChangeFeedInfo{
+		model.DefaultChangeFeedID("cf1"): {UpstreamID: 123},
+	}
+	w = s.request(http.MethodDelete, "/api/v2/upstreams/123", nil)
+	requireHTTPError(t, w, http.StatusBadRequest, "ErrUpstreamInUse")
+
+	s.etcdClient.EXPECT().DeleteUpstreamInfo(gomock.Any(), uint64(123), "other").Return(nil)
+	w = s.request(http.MethodDelete, "/api/v2/upstreams/123?namespace=other", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetUpstreamHealth(t *testing.T) {
+	t.Parallel()
+
+	s := newUpstreamTestSuite(t)
+
+	// the default upstream
+	s.etcdClient.EXPECT().GetUpstreamInfo(gomock.Any(), uint64(0), "default").
+		Return(nil, cerror.ErrUpstreamNotFound.GenWithStackByArgs(0))
+	s.helpers.EXPECT().getPDAPIClient(gomock.Any(), gomock.Any()).Return(&mockPDAPIClient{
+		members:   []string{"http://127.0.0.1:2379", "http://127.0.0.1:2479"},
+		unhealthy: map[string]error{"http://127.0.0.1:2479": errors.New("timeout")},
+	}, nil)
+	w := s.request(http.MethodGet, "/api/v2/upstreams/0/health", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &UpstreamHealth{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.False(t, resp.Healthy)
+	require.Equal(t, "timeout", resp.Error)
+	require.Len(t, resp.Members, 2)
+	require.True(t, resp.Members[0].Healthy)
+	require.False(t, resp.Members[1].Healthy)
+	require.Equal(t, oracle.ComposeTS(100, 1), resp.Tso)
+
+	// an upstream which is not used by any changefeed
+	s.etcdClient.EXPECT().GetUpstreamInfo(gomock.Any(), uint64(123), "default").
+		Return(&model.UpstreamInfo{ID: 123, Name: "up1", PDEndpoints: "http://127.0.0.1:2379"}, nil).
+		Times(2)
+	s.helpers.EXPECT().getPDClient(gomock.Any(), []string{"http://127.0.0.1:2379"}, gomock.Any()).
+		Return(&mockPDClient{timestamp: 200}, nil)
+	s.helpers.EXPECT().getPDAPIClient(gomock.Any(), gomock.Any()).Return(&mockPDAPIClient{
+		members: []string{"http://127.0.0.1:2379"},
+	}, nil)
+	w = s.request(http.MethodGet, "/api/v2/upstreams/123/health", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &UpstreamHealth{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.True(t, resp.Healthy)
+	require.Equal(t, "up1", resp.Name)
+	require.Equal(t, oracle.ComposeTS(200, 0), resp.Tso)
+
+	// the upstream is unreachable
+	s.helpers.EXPECT().getPDClient(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection refused"))
+	w = s.request(http.MethodGet, "/api/v2/upstreams/123/health", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &UpstreamHealth{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.False(t, resp.Healthy)
+	require.Contains(t, resp.Error, "connection refused")
+
+	// an unknown upstream
+	s.etcdClient.EXPECT().GetUpstreamInfo(gomock.Any(), uint64(456), "default").
+		Return(nil, cerror.ErrUpstreamNotFound.GenWithStackByArgs(456))
+	w = s.request(http.MethodGet, "/api/v2/upstreams/456/health", nil)
+	requireHTTPError(t, w, http.StatusInternalServerError, "ErrUpstreamNotFound")
+}
+
+func TestGetUpstreamGCSafePoint(t *testing.T) {
+	t.Parallel()
+
+	s := newUpstreamTestSuite(t)
+	s.etcdClient.EXPECT().GetGCServiceID().Return("ticdc-default-1").AnyTimes()
+	s.etcdClient.EXPECT().GetUpstreamInfo(gomock.Any(), uint64(0), "default").
+		Return(&model.UpstreamInfo{ID: 0, Name: "default-up"}, nil).Times(2)
+
+	s.helpers.EXPECT().getPDAPIClient(gomock.Any(), gomock.Any()).Return(&mockPDAPIClient{
+		safePoints: &pdutil.ListServiceGCSafepoint{
+			GCSafePoint: 10,
+			ServiceGCSafepoints: []*pdutil.ServiceSafePoint{
+				{ServiceID: "gc_worker", SafePoint: 10},
+				{ServiceID: "ticdc-default-1", SafePoint: 20, ExpiredAt: 100},
+			},
+		},
+	}, nil)
+	w := s.request(http.MethodGet, "/api/v2/upstreams/0/gc_safepoint", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &UpstreamGCSafePoint{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+	require.Equal(t, "default-up", resp.Name)
+	require.Equal(t, uint64(10), resp.GCSafePoint)
+	require.Equal(t, "ticdc-default-1", resp.CDCServiceID)
+	require.Equal(t, &ServiceGCSafePoint{
+		ServiceID: "ticdc-default-1", SafePoint: 20, ExpiredAt: 100,
+	}, resp.CDCSafePoint)
+	require.Len(t, resp.ServiceSafePoints, 2)
+
+	s.helpers.EXPECT().getPDAPIClient(gomock.Any(), gomock.Any()).Return(nil,
+		cerror.ErrInternalServerError)
+	w = s.request(http.MethodGet, "/api/v2/upstreams/0/gc_safepoint", nil)
+	requireHTTPError(t, w, http.StatusInternalServerError, "ErrInternalServerError")
+}
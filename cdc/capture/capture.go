@@ -70,6 +70,10 @@ type Capture interface {
 	Info() (model.CaptureInfo, error)
 	StatusProvider() owner.StatusProvider
 	WriteDebugInfo(ctx context.Context, w io.Writer)
+	// DiagnoseProcessor collects the progress of the tables of a changefeed
+	// replicated by the capture in each stage of the replication pipeline.
+	DiagnoseProcessor(ctx context.Context, changefeedID model.ChangeFeedID,
+		limit int) (*model.ProcessorDiagnosis, error)
 
 	GetUpstreamManager() (*upstream.Manager, error)
 	GetEtcdClient() etcd.CDCEtcdClient
@@ -98,6 +102,9 @@ type captureImpl struct {
 	election election
 
 	EtcdClient etcd.CDCEtcdClient
+	// upstreamEtcdClient is the etcd client of the default upstream, which
+	// differs from EtcdClient if the metadata is stored in the meta store.
+	upstreamEtcdClient *etcd.Client
 
 	sortEngineFactory *factory.SortEngineFactory
 
@@ -140,6 +147,7 @@ func (c *captureImpl) GetUpstreamInfo(ctx context.Context, id model.UpstreamID,
 // NewCapture returns a new Capture instance
 func NewCapture(pdEndpoints []string,
 	etcdClient etcd.CDCEtcdClient,
+	upstreamEtcdClient *etcd.Client,
 	grpcService *p2p.ServerWrapper,
 	sortEngineMangerFactory *factory.SortEngineFactory,
 	pdClient pd.Client,
@@ -149,6 +157,7 @@ func NewCapture(pdEndpoints []string,
 		config:              config.GetGlobalServerConfig(),
 		liveness:            model.LivenessCaptureAlive,
 		EtcdClient:          etcdClient,
+		upstreamEtcdClient:  upstreamEtcdClient,
 		grpcService:         grpcService,
 		cancel:              func() {},
 		pdEndpoints:         pdEndpoints,
@@ -249,7 +258,7 @@ func (c *captureImpl) reset(ctx context.Context) (*vars.GlobalVars, error) {
 		GCServiceID: c.EtcdClient.GetGCServiceID(),
 		SessionTTL:  int64(c.config.CaptureSessionTTL),
 	})
-	_, err = c.upstreamManager.AddDefaultUpstream(c.pdEndpoints, c.config.Security, c.pdClient, c.EtcdClient.GetEtcdClient())
+	_, err = c.upstreamManager.AddDefaultUpstream(c.pdEndpoints, c.config.Security, c.pdClient, c.upstreamEtcdClient)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -746,6 +755,33 @@ func (c *captureImpl) WriteDebugInfo(ctx context.Context, w io.Writer) {
 	wait(doneM)
 }
 
+// DiagnoseProcessor collects the progress of the tables of a changefeed
+// replicated by the capture in each stage of the replication pipeline.
+func (c *captureImpl) DiagnoseProcessor(
+	ctx context.Context, changefeedID model.ChangeFeedID, limit int,
+) (*model.ProcessorDiagnosis, error) {
+	result := &model.ProcessorDiagnosis{}
+	done := make(chan error, 1)
+	c.captureMu.Lock()
+	if c.processorManager == nil {
+		c.captureMu.Unlock()
+		return nil, cerror.ErrCaptureNotInitialized.GenWithStackByArgs()
+	}
+	c.processorManager.Diagnose(ctx, changefeedID, limit, result, done)
+	// Release the lock before waiting, see WriteDebugInfo.
+	c.captureMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	case err := <-done:
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
 // IsOwner returns whether the capture is an owner
 func (c *captureImpl) IsOwner() bool {
 	c.ownerMu.Lock()
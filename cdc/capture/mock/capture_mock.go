@@ -51,6 +51,21 @@ func (mr *MockCaptureMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCapture)(nil).Close))
 }
 
+// DiagnoseProcessor mocks base method.
+func (m *MockCapture) DiagnoseProcessor(ctx context.Context, changefeedID model.ChangeFeedID, limit int) (*model.ProcessorDiagnosis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiagnoseProcessor", ctx, changefeedID, limit)
+	ret0, _ := ret[0].(*model.ProcessorDiagnosis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiagnoseProcessor indicates an expected call of DiagnoseProcessor.
+func (mr *MockCaptureMockRecorder) DiagnoseProcessor(ctx, changefeedID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiagnoseProcessor", reflect.TypeOf((*MockCapture)(nil).DiagnoseProcessor), ctx, changefeedID, limit)
+}
+
 // Drain mocks base method.
 func (m *MockCapture) Drain() <-chan struct{} {
 	m.ctrl.T.Helper()
@@ -0,0 +1,186 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	tidbkv "github.com/pingcap/tidb/pkg/kv"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tiflow/cdc/entry/schema"
+	"github.com/pingcap/tiflow/cdc/kv"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
+)
+
+// ExportSchemaHistory exports the schema history of the tables matched by
+// the filter between startTs and endTs.
+//
+// The history is read from the MVCC versions of the TiDB meta instead of the
+// schema storage of the changefeed, so it is available even if the changefeed
+// is not running, as long as startTs is not earlier than the GC safepoint.
+// For every table existing at endTs, the versions are walked back through
+// the `UpdateTS` of the table info until the version effective at startTs.
+// Tables dropped before endTs only have the version effective at startTs.
+// The versions of the intermediate states of DDL jobs are skipped.
+func ExportSchemaHistory(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	storage tidbkv.Storage,
+	f filter.Filter,
+	startTs, endTs uint64,
+) (*schemahistory.History, error) {
+	if startTs > endTs {
+		return nil, cerror.ErrSchemaHistoryInvalid.GenWithStackByArgs(
+			"start ts is greater than end ts")
+	}
+
+	snapshotAt := func(ts uint64) (*schema.Snapshot, error) {
+		meta := kv.GetSnapshotMeta(storage, ts)
+		snap, err := schema.NewSnapshotFromMeta(changefeedID, meta, ts, true /* forceReplicate */, f)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return snap, nil
+	}
+	startSnap, err := snapshotAt(startTs)
+	if err != nil {
+		return nil, err
+	}
+	endSnap, err := snapshotAt(endTs)
+	if err != nil {
+		return nil, err
+	}
+
+	history := schemahistory.NewHistory(changefeedID, startTs, endTs)
+	add := func(info *model.TableInfo) {
+		history.Add(info.SchemaID, info.TableName.Schema, info.TableInfo)
+	}
+
+	// walk adds the versions of the table from info back to the version
+	// effective at startTs.
+	walk := func(info *model.TableInfo) error {
+		for {
+			public := isPublicTable(info)
+			// The versions of the intermediate states of a DDL job are skipped,
+			// like the schema storage which only applies the finished jobs.
+			if public {
+				add(info)
+			}
+			if (public && info.UpdateTS <= startTs) || info.UpdateTS == 0 {
+				return nil
+			}
+			// The version before the current one is visible right before
+			// the DDL which creates the current version is started.
+			if err := ctx.Err(); err != nil {
+				return errors.Trace(err)
+			}
+			prev, err := readTableAt(storage, f, info.SchemaID, info.ID, info.UpdateTS-1)
+			if err != nil {
+				return err
+			}
+			// The table is created after startTs.
+			if prev == nil || prev.UpdateTS >= info.UpdateTS {
+				return nil
+			}
+			info = prev
+		}
+	}
+
+	var tables []*model.TableInfo
+	endSnap.IterTables(true, func(info *model.TableInfo) {
+		tables = append(tables, info)
+	})
+	startSnap.IterTables(true, func(info *model.TableInfo) {
+		if _, ok := endSnap.PhysicalTableByID(info.ID); !ok {
+			tables = append(tables, info)
+		}
+	})
+	for _, info := range tables {
+		if err := walk(info); err != nil {
+			return nil, err
+		}
+	}
+	history.Sort()
+	return history, nil
+}
+
+// readTableAt reads the version of the table effective at ts from the meta.
+// Only the table and its schema are read instead of a whole snapshot, since it's
+// called for every version of every table. The table is looked up in the other
+// schemas if it's moved from them by `RENAME TABLE`.
+// It returns nil if the table doesn't exist at ts or it's filtered.
+func readTableAt(
+	storage tidbkv.Storage,
+	f filter.Filter,
+	schemaID, tableID int64,
+	ts uint64,
+) (*model.TableInfo, error) {
+	meta := kv.GetSnapshotMeta(storage, ts)
+	dbInfo, err := meta.GetDatabase(schemaID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tableInfo *timodel.TableInfo
+	if dbInfo != nil {
+		tableInfo, err = meta.GetTable(schemaID, tableID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if tableInfo == nil {
+		dbInfos, err := meta.ListDatabases()
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMetaListDatabases, err)
+		}
+		for _, db := range dbInfos {
+			if db.ID == schemaID {
+				continue
+			}
+			tableInfo, err = meta.GetTable(db.ID, tableID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if tableInfo != nil {
+				dbInfo = db
+				break
+			}
+		}
+	}
+	if tableInfo == nil ||
+		f.ShouldIgnoreSchema(dbInfo.Name.O) || f.ShouldIgnoreTable(dbInfo.Name.O, tableInfo.Name.O) {
+		return nil, nil
+	}
+	return model.WrapTableInfo(dbInfo.ID, dbInfo.Name.O, ts, tableInfo), nil
+}
+
+func isPublicTable(info *model.TableInfo) bool {
+	if info.State != timodel.StatePublic {
+		return false
+	}
+	for _, col := range info.Columns {
+		if col.State != timodel.StatePublic {
+			return false
+		}
+	}
+	for _, idx := range info.Indices {
+		if idx.State != timodel.StatePublic {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestExportSchemaHistory(t *testing.T) {
+	helper := NewSchemaTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("create table test.t1(id int primary key)")
+	helper.Tk().MustExec("create table test.t3(id int primary key)")
+	startVer, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+
+	helper.Tk().MustExec("alter table test.t1 add column a int")
+	helper.Tk().MustExec("create table test.t2(id int primary key)")
+	helper.Tk().MustExec("drop table test.t3")
+	endVer, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+
+	f, err := filter.NewFilter(config.GetDefaultReplicaConfig(), "")
+	require.NoError(t, err)
+	changefeedID := model.DefaultChangeFeedID("test")
+	ctx := context.Background()
+
+	_, err = ExportSchemaHistory(ctx, changefeedID, helper.Storage(), f, endVer.Ver, startVer.Ver)
+	require.ErrorContains(t, err, "start ts is greater than end ts")
+
+	history, err := ExportSchemaHistory(ctx, changefeedID, helper.Storage(), f, startVer.Ver, endVer.Ver)
+	require.NoError(t, err)
+	require.NoError(t, history.Validate())
+	require.Equal(t, startVer.Ver, history.StartTs)
+	require.Equal(t, endVer.Ver, history.EndTs)
+
+	versions := make(map[string]int)
+	for _, v := range history.Tables {
+		if v.Schema == "test" {
+			versions[v.Table]++
+		}
+	}
+	// the intermediate states of adding the column are skipped.
+	require.Equal(t, map[string]int{"t1": 2, "t2": 1, "t3": 1}, versions)
+
+	info, ok := history.Lookup("test", "t1", startVer.Ver)
+	require.True(t, ok)
+	require.Len(t, info.Columns, 1)
+	info, ok = history.Lookup("test", "t1", endVer.Ver)
+	require.True(t, ok)
+	require.Len(t, info.Columns, 2)
+	_, ok = history.Lookup("test", "t2", startVer.Ver)
+	require.False(t, ok)
+	_, ok = history.Lookup("test", "t3", endVer.Ver)
+	require.True(t, ok)
+}
+
+func TestExportSchemaHistoryRenameTableAcrossSchemas(t *testing.T) {
+	helper := NewSchemaTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("create database test2")
+	helper.Tk().MustExec("create table test.t(id int primary key)")
+	startVer, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+
+	// the rename doesn't change the UpdateTS of the table, so the version
+	// created by adding the column is the latest one of test2.t.
+	helper.Tk().MustExec("alter table test.t add column a int")
+	helper.Tk().MustExec("rename table test.t to test2.t")
+	endVer, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+
+	f, err := filter.NewFilter(config.GetDefaultReplicaConfig(), "")
+	require.NoError(t, err)
+	history, err := ExportSchemaHistory(context.Background(), model.DefaultChangeFeedID("test"),
+		helper.Storage(), f, startVer.Ver, endVer.Ver)
+	require.NoError(t, err)
+	require.NoError(t, history.Validate())
+
+	// the version before adding the column is read from the schema it's moved from.
+	info, ok := history.Lookup("test", "t", startVer.Ver)
+	require.True(t, ok)
+	require.Len(t, info.Columns, 1)
+	info, ok = history.Lookup("test2", "t", endVer.Ver)
+	require.True(t, ok)
+	require.Len(t, info.Columns, 2)
+}
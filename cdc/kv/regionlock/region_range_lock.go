@@ -19,6 +19,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -66,6 +67,8 @@ type LockedRangeState struct {
 	ResolvedTs  atomic.Uint64
 	Initialzied atomic.Bool
 	Created     time.Time
+	// StoreID is the store that the region request of the range is sent to.
+	StoreID atomic.Uint64
 }
 
 // rangeLockEntry represents a locked range that defined by [startKey, endKey).
@@ -285,11 +288,24 @@ type RangeLockStatistics struct {
 // LockedRangeStatistic represents a locked range.
 type LockedRangeStatistic struct {
 	RegionID    uint64
+	StoreID     uint64
+	Span        tablepb.Span
 	ResolvedTs  uint64
 	Initialized bool
 	Created     time.Time
 }
 
+func newLockedRangeStatistic(item *rangeLockEntry) LockedRangeStatistic {
+	return LockedRangeStatistic{
+		RegionID:    item.regionID,
+		StoreID:     item.lockedRangeState.StoreID.Load(),
+		Span:        tablepb.Span{StartKey: item.startKey, EndKey: item.endKey},
+		ResolvedTs:  item.lockedRangeState.ResolvedTs.Load(),
+		Initialized: item.lockedRangeState.Initialzied.Load(),
+		Created:     item.lockedRangeState.Created,
+	}
+}
+
 // UnLockRangeStatistic represents a range that is unlocked.
 type UnLockRangeStatistic struct {
 	Span       tablepb.Span
@@ -320,16 +336,10 @@ func (l *RangeLock) IterAll(
 		}
 		resolvedTs := item.lockedRangeState.ResolvedTs.Load()
 		if resolvedTs > r.FastestRegion.ResolvedTs {
-			r.FastestRegion.RegionID = item.regionID
-			r.FastestRegion.ResolvedTs = resolvedTs
-			r.FastestRegion.Initialized = item.lockedRangeState.Initialzied.Load()
-			r.FastestRegion.Created = item.lockedRangeState.Created
+			r.FastestRegion = newLockedRangeStatistic(item)
 		}
 		if resolvedTs < r.SlowestRegion.ResolvedTs {
-			r.SlowestRegion.RegionID = item.regionID
-			r.SlowestRegion.ResolvedTs = resolvedTs
-			r.SlowestRegion.Initialized = item.lockedRangeState.Initialzied.Load()
-			r.SlowestRegion.Created = item.lockedRangeState.Created
+			r.SlowestRegion = newLockedRangeStatistic(item)
 		}
 		lastEnd = item.endKey
 		return true
@@ -342,6 +352,35 @@ func (l *RangeLock) IterAll(
 	return
 }
 
+// SlowestRegions returns at most limit locked ranges with the smallest
+// resolved ts, ordered by resolved ts ascending.
+func (l *RangeLock) SlowestRegions(limit int) []LockedRangeStatistic {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if limit <= 0 {
+		return nil
+	}
+
+	res := make([]LockedRangeStatistic, 0, limit+1)
+	l.lockedRanges.Ascend(func(item *rangeLockEntry) bool {
+		resolvedTs := item.lockedRangeState.ResolvedTs.Load()
+		if len(res) == limit && resolvedTs >= res[limit-1].ResolvedTs {
+			return true
+		}
+		i := sort.Search(len(res), func(i int) bool {
+			return res[i].ResolvedTs > resolvedTs
+		})
+		res = append(res, LockedRangeStatistic{})
+		copy(res[i+1:], res[i:])
+		res[i] = newLockedRangeStatistic(item)
+		if len(res) > limit {
+			res = res[:limit]
+		}
+		return true
+	})
+	return res
+}
+
 // IterForTest iterates all locked ranges in the RangeLock and performs the action on each locked range.
 // It is used for testing only.
 func (l *RangeLock) IterForTest(
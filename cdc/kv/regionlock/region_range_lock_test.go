@@ -236,6 +236,36 @@ func TestRegionRangeLockCollect(t *testing.T) {
 	require.Equal(t, 1, len(attrs.UnLockedRanges))
 }
 
+func TestRegionRangeLockSlowestRegions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := NewRangeLock(1, []byte("a"), []byte("z"), 100, "")
+	require.Empty(t, l.SlowestRegions(3))
+
+	keys := []string{"a", "e", "h", "m", "z"}
+	resolvedTs := []uint64{104, 101, 103, 102}
+	for i := 0; i < len(resolvedTs); i++ {
+		res := l.LockRange(ctx, []byte(keys[i]), []byte(keys[i+1]), uint64(i+1), 1)
+		require.Equal(t, LockRangeStatusSuccess, res.Status)
+		res.LockedRangeState.ResolvedTs.Store(resolvedTs[i])
+		res.LockedRangeState.StoreID.Store(uint64(10 + i))
+	}
+
+	regions := l.SlowestRegions(3)
+	require.Len(t, regions, 3)
+	require.Equal(t, uint64(2), regions[0].RegionID)
+	require.Equal(t, uint64(11), regions[0].StoreID)
+	require.Equal(t, uint64(101), regions[0].ResolvedTs)
+	require.Equal(t, []byte("e"), []byte(regions[0].Span.StartKey))
+	require.Equal(t, []byte("h"), []byte(regions[0].Span.EndKey))
+	require.Equal(t, uint64(4), regions[1].RegionID)
+	require.Equal(t, uint64(3), regions[2].RegionID)
+
+	require.Len(t, l.SlowestRegions(10), 4)
+	require.Empty(t, l.SlowestRegions(0))
+}
+
 func TestCalculateMinResolvedTs(t *testing.T) {
 	l := NewRangeLock(1, []byte("a"), []byte("z"), 100, "")
 
@@ -344,6 +344,19 @@ func (s *SharedClient) RegionCount(subID SubscriptionID) uint64 {
 	return 0
 }
 
+// SlowestRegions returns at most limit subscribed regions with the smallest
+// resolved ts, and the ranges that are not subscribed by any region.
+func (s *SharedClient) SlowestRegions(
+	subID SubscriptionID, limit int,
+) ([]regionlock.LockedRangeStatistic, []regionlock.UnLockRangeStatistic) {
+	s.totalSpans.RLock()
+	defer s.totalSpans.RUnlock()
+	if rt := s.totalSpans.v[subID]; rt != nil {
+		return rt.rangeLock.SlowestRegions(limit), rt.rangeLock.IterAll(nil).UnLockedRanges
+	}
+	return nil, nil
+}
+
 // Run the client.
 func (s *SharedClient) Run(ctx context.Context) error {
 	s.clusterID = s.pd.GetClusterID(ctx)
@@ -442,6 +455,7 @@ func (s *SharedClient) handleRegions(ctx context.Context, eg *errgroup.Group) er
 			}
 
 			store := s.getStore(ctx, eg, region.rpcCtx.Peer.StoreId, region.rpcCtx.Addr)
+			region.lockedRangeState.StoreID.Store(store.storeID)
 			stream := store.getStream()
 			stream.requests.In() <- region
 
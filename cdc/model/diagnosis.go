@@ -0,0 +1,221 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// DiagnosisStage is a stage of the replication pipeline which may hold back
+// the resolved ts and the checkpoint ts of a changefeed.
+type DiagnosisStage string
+
+const (
+	// DiagnosisStageKVClient is the kv client which receives the resolved ts
+	// of regions from TiKV.
+	DiagnosisStageKVClient DiagnosisStage = "kv-client"
+	// DiagnosisStagePuller is the puller which merges the resolved ts of regions.
+	DiagnosisStagePuller DiagnosisStage = "puller"
+	// DiagnosisStageSorter is the sorter which sorts the events of a table.
+	DiagnosisStageSorter DiagnosisStage = "sorter"
+	// DiagnosisStageRedo is the redo log of a table.
+	DiagnosisStageRedo DiagnosisStage = "redo"
+	// DiagnosisStageBarrier is the barrier sent by the owner, e.g. a DDL
+	// which hasn't been executed or a sync point.
+	DiagnosisStageBarrier DiagnosisStage = "barrier"
+	// DiagnosisStageSink is the sink workers which write events to the downstream.
+	DiagnosisStageSink DiagnosisStage = "sink"
+)
+
+// DiagnosisStages are all stages in the order of the replication pipeline.
+var DiagnosisStages = []DiagnosisStage{
+	DiagnosisStageKVClient,
+	DiagnosisStagePuller,
+	DiagnosisStageSorter,
+	DiagnosisStageRedo,
+	DiagnosisStageBarrier,
+	DiagnosisStageSink,
+}
+
+func diagnosisStageIndex(stage DiagnosisStage) int {
+	for i, s := range DiagnosisStages {
+		if s == stage {
+			return i
+		}
+	}
+	return len(DiagnosisStages)
+}
+
+// StageDiagnosis is the progress of a table span in a stage.
+type StageDiagnosis struct {
+	Stage     DiagnosisStage `json:"stage"`
+	CaptureID CaptureID      `json:"capture_id,omitempty"`
+	TableID   TableID        `json:"table_id"`
+	TableName string         `json:"table_name,omitempty"`
+	// StartKey and EndKey are hex encoded.
+	StartKey string `json:"start_key"`
+	EndKey   string `json:"end_key"`
+	// RegionID and StoreID are only set in the kv-client stage.
+	RegionID   uint64 `json:"region_id,omitempty"`
+	StoreID    uint64 `json:"store_id,omitempty"`
+	ResolvedTs uint64 `json:"resolved_ts"`
+	// Lag is the seconds that the stage holds back the resolved ts compared
+	// with the stage before it, the lag of the kv-client stage is compared
+	// with the current ts of the upstream.
+	Lag float64 `json:"lag"`
+	// PendingEvents is only set in the sink stage.
+	PendingEvents int    `json:"pending_events,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// TableDiagnosis is the progress of a table span in all stages.
+type TableDiagnosis struct {
+	CaptureID    CaptureID        `json:"capture_id"`
+	TableID      TableID          `json:"table_id"`
+	TableName    string           `json:"table_name,omitempty"`
+	StartKey     string           `json:"start_key"`
+	EndKey       string           `json:"end_key"`
+	CheckpointTs uint64           `json:"checkpoint_ts"`
+	Stages       []StageDiagnosis `json:"stages"`
+}
+
+// FillLags calculates the lag of each stage of the table, the stages must be
+// in the order of the replication pipeline, a stage can have multiple entries,
+// e.g. regions in the kv-client stage.
+func (t *TableDiagnosis) FillLags(currentTs uint64) {
+	input, output := currentTs, currentTs
+	for i := range t.Stages {
+		if i > 0 && t.Stages[i].Stage != t.Stages[i-1].Stage {
+			input = output
+		}
+		ts := t.Stages[i].ResolvedTs
+		if ts > input {
+			ts = input
+		}
+		t.Stages[i].Lag = float64(oracle.ExtractPhysical(input)-oracle.ExtractPhysical(ts)) / 1000
+		if ts < output {
+			output = ts
+		}
+	}
+}
+
+// Blocking returns the stage which holds back the resolved ts of the table
+// the most, nil is returned if there is no lag in any stage.
+func (t *TableDiagnosis) Blocking() *StageDiagnosis {
+	var res *StageDiagnosis
+	for i := range t.Stages {
+		if t.Stages[i].Lag <= 0 {
+			continue
+		}
+		if res == nil || t.Stages[i].Lag > res.Lag {
+			res = &t.Stages[i]
+		}
+	}
+	return res
+}
+
+// ProcessorDiagnosis is the diagnosis of the tables of a changefeed
+// replicated by a capture.
+type ProcessorDiagnosis struct {
+	CaptureID CaptureID `json:"capture_id"`
+	CurrentTs uint64    `json:"current_ts"`
+	// SlowestTable is the table with the smallest checkpoint ts.
+	SlowestTable *TableDiagnosis `json:"slowest_table,omitempty"`
+	// Stages are the slowest table spans of each stage.
+	Stages []StageDiagnosis `json:"stages"`
+	// SorterDiskUsage is the disk usage of the sort engine of the changefeed.
+	SorterDiskUsage *SorterDiskUsage `json:"sorter_disk_usage,omitempty"`
+}
+
+// SorterDiskUsage is the disk usage of the sort engine of a changefeed
+// on a capture.
+type SorterDiskUsage struct {
+	UsedBytes uint64 `json:"used_bytes"`
+	// QuotaBytes is the quota of the changefeed, 0 means no limit.
+	QuotaBytes uint64 `json:"quota_bytes"`
+	// CaptureUsedBytes and CaptureQuotaBytes are the usage and the quota
+	// of all sort engines on the capture.
+	CaptureUsedBytes  uint64 `json:"capture_used_bytes"`
+	CaptureQuotaBytes uint64 `json:"capture_quota_bytes"`
+	// Exceeded is true if any of the quotas is exceeded.
+	Exceeded bool `json:"exceeded"`
+}
+
+// PendingDDLDiagnosis is a DDL which hasn't been executed by the owner.
+type PendingDDLDiagnosis struct {
+	TableName string `json:"table_name"`
+	CommitTs  uint64 `json:"commit_ts"`
+	Query     string `json:"query"`
+	Executing bool   `json:"executing"`
+}
+
+// OwnerDiagnosis is the state of a changefeed in the owner which may hold
+// back the checkpoint ts.
+type OwnerDiagnosis struct {
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	ResolvedTs   uint64 `json:"resolved_ts"`
+	// DDLResolvedTs is the resolved ts of the DDL puller.
+	DDLResolvedTs uint64 `json:"ddl_resolved_ts"`
+	// BarrierTs and BarrierType are the minimum barrier except DDLs,
+	// e.g. a sync point or the target ts of the changefeed.
+	BarrierTs   uint64                `json:"barrier_ts"`
+	BarrierType string                `json:"barrier_type"`
+	PendingDDLs []PendingDDLDiagnosis `json:"pending_ddls,omitempty"`
+}
+
+// ResolvedTsDiagnosis reports which stage holds back the checkpoint ts
+// of a changefeed.
+type ResolvedTsDiagnosis struct {
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	ResolvedTs   uint64 `json:"resolved_ts"`
+	CurrentTs    uint64 `json:"current_ts"`
+	// Blocking is the stage of the slowest table which holds back the
+	// checkpoint ts the most.
+	Blocking     *StageDiagnosis  `json:"blocking,omitempty"`
+	SlowestTable *TableDiagnosis  `json:"slowest_table,omitempty"`
+	Stages       []StageDiagnosis `json:"stages"`
+	Owner        *OwnerDiagnosis  `json:"owner,omitempty"`
+	// SorterDiskUsage is the disk usage of the sort engine on each capture.
+	SorterDiskUsage map[CaptureID]*SorterDiskUsage `json:"sorter_disk_usage,omitempty"`
+	// Errors are the captures that fail to report their processors.
+	Errors map[CaptureID]string `json:"errors,omitempty"`
+}
+
+// TopStageDiagnoses keeps at most limit table spans with the smallest resolved
+// ts of each stage, the result is sorted in the order of the replication
+// pipeline, and then in the order of resolved ts.
+func TopStageDiagnoses(stages []StageDiagnosis, limit int) []StageDiagnosis {
+	sort.SliceStable(stages, func(i, j int) bool {
+		si, sj := diagnosisStageIndex(stages[i].Stage), diagnosisStageIndex(stages[j].Stage)
+		if si != sj {
+			return si < sj
+		}
+		return stages[i].ResolvedTs < stages[j].ResolvedTs
+	})
+	res := make([]StageDiagnosis, 0, len(stages))
+	count := 0
+	for i := range stages {
+		if i > 0 && stages[i].Stage != stages[i-1].Stage {
+			count = 0
+		}
+		if count >= limit {
+			continue
+		}
+		res = append(res, stages[i])
+		count++
+	}
+	return res
+}
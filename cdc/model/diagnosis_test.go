@@ -0,0 +1,78 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestTableDiagnosisFillLags(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	ts := func(lag time.Duration) uint64 {
+		return oracle.GoTimeToTS(now.Add(-lag))
+	}
+	table := &TableDiagnosis{
+		Stages: []StageDiagnosis{
+			{Stage: DiagnosisStageKVClient, RegionID: 1, ResolvedTs: ts(3 * time.Second)},
+			{Stage: DiagnosisStageKVClient, RegionID: 2, ResolvedTs: ts(time.Second)},
+			{Stage: DiagnosisStagePuller, ResolvedTs: ts(3 * time.Second)},
+			{Stage: DiagnosisStageSorter, ResolvedTs: ts(4 * time.Second)},
+			// the barrier is larger than the resolved ts of the sorter
+			{Stage: DiagnosisStageBarrier, ResolvedTs: ts(time.Second)},
+			{Stage: DiagnosisStageSink, ResolvedTs: ts(10 * time.Second)},
+		},
+	}
+	table.FillLags(ts(0))
+	require.InDelta(t, 3, table.Stages[0].Lag, 0.01)
+	require.InDelta(t, 1, table.Stages[1].Lag, 0.01)
+	require.InDelta(t, 0, table.Stages[2].Lag, 0.01)
+	require.InDelta(t, 1, table.Stages[3].Lag, 0.01)
+	require.InDelta(t, 0, table.Stages[4].Lag, 0.01)
+	require.InDelta(t, 6, table.Stages[5].Lag, 0.01)
+
+	blocking := table.Blocking()
+	require.NotNil(t, blocking)
+	require.Equal(t, DiagnosisStageSink, blocking.Stage)
+
+	table.Stages = table.Stages[:0]
+	require.Nil(t, table.Blocking())
+}
+
+func TestTopStageDiagnoses(t *testing.T) {
+	t.Parallel()
+
+	stages := []StageDiagnosis{
+		{Stage: DiagnosisStageSink, TableID: 1, ResolvedTs: 10},
+		{Stage: DiagnosisStageKVClient, TableID: 1, ResolvedTs: 5},
+		{Stage: DiagnosisStageSink, TableID: 2, ResolvedTs: 8},
+		{Stage: DiagnosisStageKVClient, TableID: 2, ResolvedTs: 3},
+		{Stage: DiagnosisStageKVClient, TableID: 3, ResolvedTs: 4},
+		{Stage: DiagnosisStageSorter, TableID: 3, ResolvedTs: 4},
+	}
+	res := TopStageDiagnoses(stages, 2)
+	require.Len(t, res, 5)
+	require.Equal(t, DiagnosisStageKVClient, res[0].Stage)
+	require.Equal(t, TableID(2), res[0].TableID)
+	require.Equal(t, TableID(3), res[1].TableID)
+	require.Equal(t, DiagnosisStageSorter, res[2].Stage)
+	require.Equal(t, DiagnosisStageSink, res[3].Stage)
+	require.Equal(t, TableID(2), res[3].TableID)
+	require.Equal(t, TableID(1), res[4].TableID)
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// MaxPendingHookEvents is the max number of hook events of a changefeed
+// waiting to be delivered, the oldest ones are dropped when it's exceeded.
+const MaxPendingHookEvents = 64
+
+// HookEvent is delivered to the hooks of a changefeed when its state changes.
+type HookEvent struct {
+	// ID identifies the event, the receivers can deduplicate the events with
+	// it since an event is delivered at least once.
+	ID           uint64        `json:"id"`
+	Namespace    string        `json:"namespace"`
+	ChangefeedID string        `json:"changefeed_id"`
+	State        FeedState     `json:"state"`
+	PrevState    FeedState     `json:"prev_state"`
+	CheckpointTs uint64        `json:"checkpoint_ts"`
+	Error        *RunningError `json:"error,omitempty"`
+	Time         time.Time     `json:"time"`
+}
+
+// PendingHookEvent is a hook event stored in the changefeed status until it's
+// delivered, so that it's not lost when the owner changes.
+type PendingHookEvent struct {
+	// HookIndex is the index of the hook in the hooks of the changefeed config.
+	// The hook itself is not stored since its url may carry credentials.
+	HookIndex int        `json:"hook-index"`
+	Event     *HookEvent `json:"event"`
+}
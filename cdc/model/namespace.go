@@ -0,0 +1,88 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// NamespaceInfo stores the settings of a namespace in etcd.
+type NamespaceInfo struct {
+	Name string `json:"name"`
+	// DefaultReplicaConfig is a partial replica config in the json format of
+	// the OpenAPI v2, the changefeeds created in the namespace inherit the
+	// fields set in it and override them selectively.
+	DefaultReplicaConfig json.RawMessage   `json:"default-replica-config,omitempty"`
+	Quota                NamespaceQuota    `json:"quota"`
+	Labels               map[string]string `json:"labels,omitempty"`
+}
+
+// NamespaceQuota limits the changefeeds of a namespace, zero means unlimited.
+type NamespaceQuota struct {
+	// MaxChangefeeds is the max number of changefeeds in the namespace.
+	MaxChangefeeds int `json:"max-changefeeds"`
+	// MaxMemoryQuota is the max sum of the memory quota of the changefeeds
+	// in the namespace, in bytes.
+	MaxMemoryQuota uint64 `json:"max-memory-quota"`
+}
+
+// Marshal using json.Marshal.
+func (n *NamespaceInfo) Marshal() ([]byte, error) {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+
+	return data, nil
+}
+
+// Unmarshal from binary data.
+func (n *NamespaceInfo) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, n)
+	return errors.Annotatef(cerror.WrapError(cerror.ErrUnmarshalFailed, err),
+		"unmarshal data: %v", data)
+}
+
+// ChangefeedTemplate is a named changefeed config stored in etcd, a
+// changefeed created with the template inherits its sink uri and replica
+// config, on top of the defaults of the namespace.
+type ChangefeedTemplate struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	SinkURI     string `json:"sink-uri,omitempty"`
+	// ReplicaConfig is a partial replica config in the json format of the
+	// OpenAPI v2.
+	ReplicaConfig json.RawMessage `json:"replica-config,omitempty"`
+}
+
+// Marshal using json.Marshal.
+func (t *ChangefeedTemplate) Marshal() ([]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMarshalFailed, err)
+	}
+
+	return data, nil
+}
+
+// Unmarshal from binary data.
+func (t *ChangefeedTemplate) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, t)
+	return errors.Annotatef(cerror.WrapError(cerror.ErrUnmarshalFailed, err),
+		"unmarshal data: %v", data)
+}
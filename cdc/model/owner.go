@@ -16,6 +16,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pingcap/errors"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
@@ -278,6 +279,12 @@ type ChangeFeedStatus struct {
 	// TODO: remove this filed after we don't use ChangeFeedStatus to
 	// control processor. This is too ambiguous.
 	AdminJobType AdminJobType `json:"admin-job-type"`
+	// PendingHookEvents are the hook events waiting to be delivered.
+	PendingHookEvents []*PendingHookEvent `json:"pending-hook-events,omitempty"`
+	// ScheduleFiredAt is the last time the schedule of the changefeed fired,
+	// or the time the schedule was set up if it has never fired. A new owner
+	// computes the firings it missed from it.
+	ScheduleFiredAt *time.Time `json:"schedule-fired-at,omitempty"`
 }
 
 // Marshal returns json encoded string of ChangeFeedStatus, only contains necessary fields stored in storage
@@ -367,6 +367,9 @@ type RowChangedEvent struct {
 	//    tidb will make a hidden column called "_tidb_rowid" as the handle.
 	//    due to the type of "_tidb_rowid" is int, so we also use IntHandle to represent.
 	HandleKey kv.Handle
+	// TxnEventOrder is the 1-based position of the event in its transaction.
+	// It's only set by the MQ sink when the transaction metadata is emitted.
+	TxnEventOrder int64
 }
 
 // RowChangedEventInRedoLog is used to store RowChangedEvent in redo log v2 format
@@ -1310,6 +1313,10 @@ type TopicPartitionKey struct {
 	Partition      int32
 	PartitionKey   string
 	TotalPartition int32
+	// HashedKey is true if the partition is computed by hashing the PartitionKey
+	// with a non-default hash function, the PartitionKey is sent as the key of
+	// the kafka message then.
+	HashedKey bool
 }
 
 // ColumnDataX is like ColumnData, but contains more informations.
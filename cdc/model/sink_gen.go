@@ -2594,6 +2594,12 @@ func (z *TopicPartitionKey) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "TotalPartition")
 				return
 			}
+		case "HashedKey":
+			z.HashedKey, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "HashedKey")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -2607,9 +2613,9 @@ func (z *TopicPartitionKey) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *TopicPartitionKey) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
+	// map header, size 5
 	// write "Topic"
-	err = en.Append(0x84, 0xa5, 0x54, 0x6f, 0x70, 0x69, 0x63)
+	err = en.Append(0x85, 0xa5, 0x54, 0x6f, 0x70, 0x69, 0x63)
 	if err != nil {
 		return
 	}
@@ -2648,15 +2654,25 @@ func (z *TopicPartitionKey) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "TotalPartition")
 		return
 	}
+	// write "HashedKey"
+	err = en.Append(0xa9, 0x48, 0x61, 0x73, 0x68, 0x65, 0x64, 0x4b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.HashedKey)
+	if err != nil {
+		err = msgp.WrapError(err, "HashedKey")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *TopicPartitionKey) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
+	// map header, size 5
 	// string "Topic"
-	o = append(o, 0x84, 0xa5, 0x54, 0x6f, 0x70, 0x69, 0x63)
+	o = append(o, 0x85, 0xa5, 0x54, 0x6f, 0x70, 0x69, 0x63)
 	o = msgp.AppendString(o, z.Topic)
 	// string "Partition"
 	o = append(o, 0xa9, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e)
@@ -2667,6 +2683,9 @@ func (z *TopicPartitionKey) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "TotalPartition"
 	o = append(o, 0xae, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e)
 	o = msgp.AppendInt32(o, z.TotalPartition)
+	// string "HashedKey"
+	o = append(o, 0xa9, 0x48, 0x61, 0x73, 0x68, 0x65, 0x64, 0x4b, 0x65, 0x79)
+	o = msgp.AppendBool(o, z.HashedKey)
 	return
 }
 
@@ -2712,6 +2731,12 @@ func (z *TopicPartitionKey) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "TotalPartition")
 				return
 			}
+		case "HashedKey":
+			z.HashedKey, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "HashedKey")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2726,6 +2751,6 @@ func (z *TopicPartitionKey) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *TopicPartitionKey) Msgsize() (s int) {
-	s = 1 + 6 + msgp.StringPrefixSize + len(z.Topic) + 10 + msgp.Int32Size + 13 + msgp.StringPrefixSize + len(z.PartitionKey) + 15 + msgp.Int32Size
+	s = 1 + 6 + msgp.StringPrefixSize + len(z.Topic) + 10 + msgp.Int32Size + 13 + msgp.StringPrefixSize + len(z.PartitionKey) + 15 + msgp.Int32Size + 10 + msgp.BoolSize
 	return
 }
@@ -0,0 +1,105 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// TableStatistics is the progress and the throughput of a table, the
+// statistics of all spans of the table are aggregated.
+type TableStatistics struct {
+	TableID      TableID `json:"table_id"`
+	TableName    string  `json:"table_name,omitempty"`
+	CheckpointTs uint64  `json:"checkpoint_ts"`
+	ResolvedTs   uint64  `json:"resolved_ts"`
+	// CheckpointLag is the seconds between the checkpoint ts and the
+	// current ts of the upstream.
+	CheckpointLag float64 `json:"checkpoint_lag"`
+	// EmittedRows and EmittedBytes are the number and the approximate bytes
+	// of the rows emitted to the sink since the table is added to the capture
+	// which replicates it.
+	EmittedRows  uint64 `json:"emitted_rows"`
+	EmittedBytes uint64 `json:"emitted_bytes"`
+	// SorterDiskUsage is the approximate bytes of the events of the table
+	// in the sort engine.
+	SorterDiskUsage uint64 `json:"sorter_disk_usage"`
+	// SinkPendingEvents is the number of events written to the sink but not
+	// flushed to the downstream.
+	SinkPendingEvents uint64 `json:"sink_pending_events"`
+}
+
+// ChangefeedStatistics is the throughput and the lag of a changefeed, it's
+// aggregated by the owner from the table statistics in the heartbeats of
+// the processors.
+type ChangefeedStatistics struct {
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	ResolvedTs   uint64 `json:"resolved_ts"`
+	CurrentTs    uint64 `json:"current_ts"`
+	// CheckpointLag and ResolvedLag are the seconds between the checkpoint
+	// ts, the resolved ts and the current ts of the upstream.
+	CheckpointLag float64 `json:"checkpoint_lag"`
+	ResolvedLag   float64 `json:"resolved_lag"`
+	// RowsPerSecond and BytesPerSecond are the throughput of the sink
+	// measured in the last statistics interval.
+	RowsPerSecond  float64 `json:"rows_per_second"`
+	BytesPerSecond float64 `json:"bytes_per_second"`
+	EmittedRows    uint64  `json:"emitted_rows"`
+	EmittedBytes   uint64  `json:"emitted_bytes"`
+	// ExecutedDDLs is the number of DDLs executed since the changefeed is
+	// started by the current owner.
+	ExecutedDDLs      uint64 `json:"executed_ddls"`
+	SorterDiskUsage   uint64 `json:"sorter_disk_usage"`
+	SinkPendingEvents uint64 `json:"sink_pending_events"`
+	// Tables are ordered by table id.
+	Tables []TableStatistics `json:"tables"`
+	// SlowestTables are the tables with the largest checkpoint lag.
+	SlowestTables []TableStatistics `json:"slowest_tables"`
+}
+
+// FillLags calculates the lags of the changefeed and its tables compared
+// with the current ts of the upstream.
+func (s *ChangefeedStatistics) FillLags(currentTs uint64) {
+	s.CurrentTs = currentTs
+	s.CheckpointLag = tsLag(currentTs, s.CheckpointTs)
+	s.ResolvedLag = tsLag(currentTs, s.ResolvedTs)
+	for i := range s.Tables {
+		s.Tables[i].CheckpointLag = tsLag(currentTs, s.Tables[i].CheckpointTs)
+	}
+}
+
+// FillSlowestTables keeps at most limit tables with the largest checkpoint
+// lag in SlowestTables.
+func (s *ChangefeedStatistics) FillSlowestTables(limit int) {
+	slowest := make([]TableStatistics, len(s.Tables))
+	copy(slowest, s.Tables)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].CheckpointTs < slowest[j].CheckpointTs
+	})
+	if len(slowest) > limit {
+		slowest = slowest[:limit]
+	}
+	s.SlowestTables = slowest
+}
+
+// tsLag returns the seconds between ts and currentTs, it's 0 if ts is
+// not behind currentTs.
+func tsLag(currentTs, ts uint64) float64 {
+	if ts >= currentTs {
+		return 0
+	}
+	return float64(oracle.ExtractPhysical(currentTs)-oracle.ExtractPhysical(ts)) / 1000
+}
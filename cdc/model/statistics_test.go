@@ -0,0 +1,57 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestChangefeedStatistics(t *testing.T) {
+	t.Parallel()
+
+	ts := func(second int64) uint64 {
+		return oracle.ComposeTS(second*1000, 0)
+	}
+	stats := &ChangefeedStatistics{
+		CheckpointTs: ts(100),
+		ResolvedTs:   ts(105),
+		Tables: []TableStatistics{
+			{TableID: 1, CheckpointTs: ts(120)},
+			{TableID: 2, CheckpointTs: ts(100)},
+			{TableID: 3, CheckpointTs: ts(130)},
+			{TableID: 4, CheckpointTs: ts(110)},
+		},
+	}
+	stats.FillLags(ts(125))
+	require.Equal(t, ts(125), stats.CurrentTs)
+	require.Equal(t, float64(25), stats.CheckpointLag)
+	require.Equal(t, float64(20), stats.ResolvedLag)
+	require.Equal(t, float64(5), stats.Tables[0].CheckpointLag)
+	require.Equal(t, float64(25), stats.Tables[1].CheckpointLag)
+	// The checkpoint ts is ahead of the current ts of the owner.
+	require.Equal(t, float64(0), stats.Tables[2].CheckpointLag)
+
+	stats.FillSlowestTables(2)
+	require.Len(t, stats.SlowestTables, 2)
+	require.Equal(t, TableID(2), stats.SlowestTables[0].TableID)
+	require.Equal(t, TableID(4), stats.SlowestTables[1].TableID)
+	// The tables are still ordered by table id.
+	require.Equal(t, TableID(1), stats.Tables[0].TableID)
+
+	stats.FillSlowestTables(10)
+	require.Len(t, stats.SlowestTables, 4)
+}
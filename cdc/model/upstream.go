@@ -25,7 +25,9 @@ type UpstreamID = uint64
 
 // UpstreamInfo store in etcd.
 type UpstreamInfo struct {
-	ID            uint64   `json:"id"`
+	ID uint64 `json:"id"`
+	// Name is an optional alias of the upstream, it is unique in a namespace.
+	Name          string   `json:"name,omitempty"`
 	PDEndpoints   string   `json:"pd-endpoints"`
 	KeyPath       string   `json:"key-path"`
 	CertPath      string   `json:"cert-path"`
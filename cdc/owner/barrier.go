@@ -28,6 +28,16 @@ const (
 	finishBarrier
 )
 
+func (t barrierType) String() string {
+	switch t {
+	case syncPointBarrier:
+		return "sync-point"
+	case finishBarrier:
+		return "finish"
+	}
+	return "unknown"
+}
+
 // barriers stores some barrierType and barrierTs, and can calculate the min barrierTs
 // barriers is NOT-THREAD-SAFE
 type barriers struct {
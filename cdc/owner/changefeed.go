@@ -1017,6 +1017,50 @@ func (c *changefeed) GetInfoProvider() scheduler.InfoProvider {
 	return nil
 }
 
+// diagnose returns the state of the changefeed which may hold back the
+// checkpoint ts, e.g. the DDLs that haven't been executed.
+func (c *changefeed) diagnose() *model.OwnerDiagnosis {
+	res := &model.OwnerDiagnosis{ResolvedTs: c.resolvedTs}
+	if c.latestStatus != nil {
+		res.CheckpointTs = c.latestStatus.CheckpointTs
+	}
+	if !c.initialized.Load() {
+		return res
+	}
+	barrierTp, barrierTs := c.barriers.Min()
+	res.BarrierTs = barrierTs
+	res.BarrierType = barrierTp.String()
+	res.DDLResolvedTs = c.ddlManager.ddlResolvedTs
+	res.PendingDDLs = c.ddlManager.pendingDDLDiagnoses()
+	return res
+}
+
+// statistics returns the throughput and the lag of the changefeed and its
+// tables, which are aggregated from the heartbeats of the processors.
+func (c *changefeed) statistics() *model.ChangefeedStatistics {
+	res := &model.ChangefeedStatistics{}
+	provider := c.GetInfoProvider()
+	if c.initialized.Load() && provider != nil {
+		res = provider.GetStatistics()
+	}
+	res.ResolvedTs = c.resolvedTs
+	if c.latestStatus != nil {
+		res.CheckpointTs = c.latestStatus.CheckpointTs
+	}
+	if !c.initialized.Load() {
+		return res
+	}
+	res.ExecutedDDLs = c.ddlManager.executedDDLCount
+	snap := c.schema.GetLastSnapshot()
+	for i := range res.Tables {
+		if info, ok := snap.PhysicalTableByID(res.Tables[i].TableID); ok {
+			res.Tables[i].TableName = info.TableName.QuoteString()
+		}
+	}
+	res.FillLags(oracle.GoTimeToTS(c.upstream.PDClock.CurrentTime()))
+	return res
+}
+
 // checkUpstream returns skip = true if the upstream is still in initializing phase,
 // and returns an error if the upstream is unavailable.
 func (c *changefeed) checkUpstream() (skip bool, err error) {
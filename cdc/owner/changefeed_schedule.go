@@ -0,0 +1,94 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/robfig/cron"
+)
+
+// changefeedSchedule tells when a changefeed should be paused or resumed by
+// the cron expressions of its schedule. It's edge triggered, a changefeed
+// resumed manually is not paused again until the pause cron fires next time.
+type changefeedSchedule struct {
+	cfg    config.ScheduleConfig
+	pause  cron.Schedule
+	resume cron.Schedule
+	loc    *time.Location
+	// lastCheck is the time of the last check, only the firings after it are
+	// taken into account.
+	lastCheck time.Time
+}
+
+// newChangefeedSchedule creates a changefeedSchedule, the firings after from
+// are taken into account by the first check.
+func newChangefeedSchedule(cfg *config.ScheduleConfig, from time.Time) (*changefeedSchedule, error) {
+	pause, err := cfg.ParseCron(cfg.PauseCron)
+	if err != nil {
+		return nil, err
+	}
+	resume, err := cfg.ParseCron(cfg.ResumeCron)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	return &changefeedSchedule{
+		cfg:       *cfg,
+		pause:     pause,
+		resume:    resume,
+		loc:       loc,
+		lastCheck: from.In(loc),
+	}, nil
+}
+
+// check returns AdminStop or AdminResume and the firing time if the pause or
+// resume cron fired since the last check, the one fired later wins if both of
+// them fired. AdminNone and a zero time are returned if none of them fired.
+func (s *changefeedSchedule) check(now time.Time) (model.AdminJobType, time.Time) {
+	now = now.In(s.loc)
+	if !now.After(s.lastCheck) {
+		return model.AdminNone, time.Time{}
+	}
+	pauseAt := lastFiring(s.pause, s.lastCheck, now)
+	resumeAt := lastFiring(s.resume, s.lastCheck, now)
+	s.lastCheck = now
+
+	switch {
+	case pauseAt.IsZero() && resumeAt.IsZero():
+		return model.AdminNone, time.Time{}
+	case resumeAt.After(pauseAt):
+		return model.AdminResume, resumeAt
+	default:
+		return model.AdminStop, pauseAt
+	}
+}
+
+// lastFiring returns the last time that the schedule fires in (from, to],
+// a zero time is returned if it doesn't fire.
+func lastFiring(schedule cron.Schedule, from, to time.Time) time.Time {
+	var last time.Time
+	if schedule == nil {
+		return last
+	}
+	for next := schedule.Next(from); !next.IsZero() && !next.After(to); next = schedule.Next(next) {
+		last = next
+	}
+	return last
+}
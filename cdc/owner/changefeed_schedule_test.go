@@ -0,0 +1,92 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedScheduleCheck(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.ScheduleConfig{
+		PauseCron:  "0 8 * * *",
+		ResumeCron: "0 20 * * *",
+		TimeZone:   "Asia/Shanghai",
+	}
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	start := time.Date(2023, 1, 1, 7, 0, 0, 0, loc)
+	s, err := newChangefeedSchedule(cfg, start)
+	require.NoError(t, err)
+
+	// nothing fires
+	require.Equal(t, model.AdminNone, checkJob(s, start.Add(30*time.Minute)))
+	// the pause cron fires once
+	require.Equal(t, model.AdminStop, checkJob(s, start.Add(time.Hour)))
+	require.Equal(t, model.AdminNone, checkJob(s, start.Add(2*time.Hour)))
+	// the time is checked in the time zone of the schedule
+	require.Equal(t, model.AdminResume, checkJob(s, start.Add(13*time.Hour).UTC()))
+	require.Equal(t, model.AdminStop, checkJob(s, start.Add(25*time.Hour)))
+	require.Equal(t, model.AdminResume, checkJob(s, start.Add(38*time.Hour)))
+	// both of them fire, the later one wins
+	require.Equal(t, model.AdminResume, checkJob(s, start.Add(62*time.Hour)))
+	require.Equal(t, model.AdminStop, checkJob(s, start.Add(98*time.Hour)))
+	// the time goes back
+	require.Equal(t, model.AdminNone, checkJob(s, start))
+
+	// only the pause cron
+	s, err = newChangefeedSchedule(&config.ScheduleConfig{PauseCron: "*/5 * * * *"}, start)
+	require.NoError(t, err)
+	require.Nil(t, s.resume)
+	require.Equal(t, model.AdminStop, checkJob(s, start.Add(10*time.Minute)))
+
+	_, err = newChangefeedSchedule(&config.ScheduleConfig{PauseCron: "invalid"}, start)
+	require.Error(t, err)
+	_, err = newChangefeedSchedule(&config.ScheduleConfig{TimeZone: "invalid"}, start)
+	require.Error(t, err)
+}
+
+func checkJob(s *changefeedSchedule, now time.Time) model.AdminJobType {
+	jobType, _ := s.check(now)
+	return jobType
+}
+
+func TestChangefeedScheduleFiredAt(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 1, 1, 7, 0, 0, 0, time.UTC)
+	cfg := &config.ScheduleConfig{PauseCron: "0 8 * * *", ResumeCron: "0 20 * * *"}
+	s, err := newChangefeedSchedule(cfg, start)
+	require.NoError(t, err)
+	jobType, firedAt := s.check(start.Add(30 * time.Minute))
+	require.Equal(t, model.AdminNone, jobType)
+	require.True(t, firedAt.IsZero())
+	jobType, firedAt = s.check(start.Add(2 * time.Hour))
+	require.Equal(t, model.AdminStop, jobType)
+	require.True(t, start.Add(time.Hour).Equal(firedAt))
+
+	// a schedule built from the last firing by a new owner computes the
+	// firings it missed.
+	s, err = newChangefeedSchedule(cfg, firedAt)
+	require.NoError(t, err)
+	jobType, firedAt = s.check(start.Add(14 * time.Hour))
+	require.Equal(t, model.AdminResume, jobType)
+	require.True(t, start.Add(13*time.Hour).Equal(firedAt))
+}
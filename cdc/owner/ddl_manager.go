@@ -123,6 +123,9 @@ type ddlManager struct {
 	// justSentDDL is the ddl that just be sent to the downstream in the current tick.
 	// we need it to prevent the checkpointTs from advancing in the same tick.
 	justSentDDL *model.DDLEvent
+	// executedDDLCount is the number of DDLs executed since the changefeed
+	// is started by the owner, it's reported in the changefeed statistics.
+	executedDDLCount uint64
 	// tableInfoCache is the tables that the changefeed is watching.
 	// And it contains only the tables of the ddl that have been processed.
 	// The ones that have not been executed yet do not have.
@@ -468,6 +471,7 @@ func (m *ddlManager) executeDDL(ctx context.Context) error {
 		return errors.Trace(err)
 	}
 	if done {
+		m.executedDDLCount++
 		m.cleanCache("execute a ddl event successfully")
 	}
 	return nil
@@ -504,6 +508,28 @@ func (m *ddlManager) getAllTableNextDDL() []*model.DDLEvent {
 	return res
 }
 
+// pendingDDLDiagnoses returns the next DDL of all tables ordered by
+// commit ts, these DDLs are the barriers of the related tables.
+func (m *ddlManager) pendingDDLDiagnoses() []model.PendingDDLDiagnosis {
+	ddls := m.getAllTableNextDDL()
+	res := make([]model.PendingDDLDiagnosis, 0, len(ddls))
+	for _, ddl := range ddls {
+		d := model.PendingDDLDiagnosis{
+			CommitTs:  ddl.CommitTs,
+			Query:     ddl.Query,
+			Executing: ddl == m.executingDDL,
+		}
+		if ddl.TableInfo != nil {
+			d.TableName = ddl.TableInfo.TableName.QuoteString()
+		}
+		res = append(res, d)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CommitTs < res[j].CommitTs
+	})
+	return res
+}
+
 // barrier returns ddlResolvedTs and tableBarrier
 func (m *ddlManager) barrier() *schedulepb.BarrierWithMinTs {
 	barrier := schedulepb.NewBarrierWithMinTs(m.ddlResolvedTs)
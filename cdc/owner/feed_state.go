@@ -13,7 +13,11 @@
 
 package owner
 
-import "github.com/pingcap/tiflow/cdc/model"
+import (
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
 
 // ChangefeedState is the interface for changefeed state in underlying storage.
 type ChangefeedState interface {
@@ -39,4 +43,11 @@ type ChangefeedState interface {
 	CleanUpTaskPositions()
 	// UpdateChangefeedState returns the task status of the changefeed.
 	UpdateChangefeedState(model.FeedState, model.AdminJobType, uint64)
+	// AddHookEvents appends hook events waiting to be delivered.
+	AddHookEvents(...*model.PendingHookEvent)
+	// RemoveHookEvent removes a delivered hook event.
+	RemoveHookEvent(uint64)
+	// SetScheduleFiredAt sets the last time the schedule fired, a zero time
+	// clears it.
+	SetScheduleFiredAt(time.Time)
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -45,6 +46,8 @@ const (
 // 2. Handle errors
 // 3. Handle warnings
 // 4. Control the status of a changefeed
+// 5. Pause and resume a changefeed by its schedule
+// 6. Record the hook events of state transitions
 type FeedStateManager interface {
 	// PushAdminJob pushed an admin job to the admin job queue
 	PushAdminJob(job *model.AdminJob)
@@ -91,6 +94,10 @@ type feedStateManager struct {
 	checkpointTsAdvanced time.Time
 
 	changefeedErrorStuckDuration time.Duration
+
+	// schedule pauses and resumes the changefeed by the cron expressions,
+	// it's nil if the changefeed has no schedule.
+	schedule *changefeedSchedule
 }
 
 // NewFeedStateManager creates feedStateManager and initialize the exponential backoff
@@ -165,6 +172,7 @@ func (m *feedStateManager) Tick(resolvedTs model.Ts,
 		}
 	}()
 
+	m.checkSchedule(info)
 	if m.handleAdminJob() {
 		// `handleAdminJob` returns true means that some admin jobs are pending
 		// skip to the next tick until all the admin jobs is handled
@@ -208,7 +216,7 @@ func (m *feedStateManager) Tick(resolvedTs model.Ts,
 				zap.Uint64("lastRetryCheckpointTs", m.lastErrorRetryCheckpointTs),
 			)
 			m.shouldBeRunning = false
-			m.patchState(model.StateFailed)
+			m.patchStateWithError(model.StateFailed, info.Error)
 			return
 		}
 
@@ -217,7 +225,7 @@ func (m *feedStateManager) Tick(resolvedTs model.Ts,
 		if status != nil {
 			m.lastErrorRetryCheckpointTs = m.state.GetChangefeedStatus().CheckpointTs
 		}
-		m.patchState(model.StateWarning)
+		m.patchStateWithError(model.StateWarning, info.Error)
 		log.Info("changefeed retry backoff interval is elapsed,"+
 			"chengefeed will be restarted",
 			zap.String("namespace", m.state.GetID().Namespace),
@@ -225,6 +233,10 @@ func (m *feedStateManager) Tick(resolvedTs model.Ts,
 			zap.Time("lastErrorRetryTime", m.lastErrorRetryTime),
 			zap.Duration("nextRetryInterval", m.backoffInterval))
 	case model.StateNormal, model.StateWarning:
+		if m.waitForStartTs(info) {
+			m.shouldBeRunning = false
+			return
+		}
 		m.checkAndChangeState()
 		errs := m.state.TakeProcessorErrors()
 		m.HandleError(errs...)
@@ -361,6 +373,12 @@ func (m *feedStateManager) pushAdminJob(job *model.AdminJob) {
 }
 
 func (m *feedStateManager) patchState(feedState model.FeedState) {
+	m.patchStateWithError(feedState, nil)
+}
+
+// patchStateWithError patches the state of the changefeed, and records the
+// hook events if the state is changed, err is the error that causes it.
+func (m *feedStateManager) patchStateWithError(feedState model.FeedState, err *model.RunningError) {
 	var updateEpoch bool
 	var adminJobType model.AdminJobType
 	switch feedState {
@@ -388,6 +406,123 @@ func (m *feedStateManager) patchState(feedState model.FeedState) {
 		}
 	}
 	m.state.UpdateChangefeedState(feedState, adminJobType, epoch)
+	m.addHookEvents(feedState, err)
+}
+
+// addHookEvents records an event for every hook of the changefeed which is
+// triggered by the state transition, the events are stored in the changefeed
+// status and delivered by the owner asynchronously.
+func (m *feedStateManager) addHookEvents(feedState model.FeedState, err *model.RunningError) {
+	info := m.state.GetChangefeedInfo()
+	if info == nil || info.Config == nil || len(info.Config.Hooks) == 0 ||
+		info.State == feedState {
+		return
+	}
+	now := time.Now()
+	checkpointTs := info.GetCheckpointTs(m.state.GetChangefeedStatus())
+	var events []*model.PendingHookEvent
+	for i, hook := range info.Config.Hooks {
+		if !hook.HasEvent(string(feedState)) {
+			continue
+		}
+		events = append(events, &model.PendingHookEvent{
+			HookIndex: i,
+			Event: &model.HookEvent{
+				ID:           uint64(now.UnixNano()) + uint64(len(events)),
+				Namespace:    m.state.GetID().Namespace,
+				ChangefeedID: m.state.GetID().ID,
+				State:        feedState,
+				PrevState:    info.State,
+				CheckpointTs: checkpointTs,
+				Error:        err,
+				Time:         now,
+			},
+		})
+	}
+	m.state.AddHookEvents(events...)
+}
+
+// checkSchedule pushes an admin job to pause or resume the changefeed when
+// the cron expressions of its schedule fire.
+func (m *feedStateManager) checkSchedule(info *model.ChangeFeedInfo) {
+	var cfg *config.ScheduleConfig
+	if info != nil && info.Config != nil {
+		cfg = info.Config.Schedule
+	}
+	if cfg == nil || (cfg.PauseCron == "" && cfg.ResumeCron == "") {
+		m.schedule = nil
+		if status := m.state.GetChangefeedStatus(); status != nil && status.ScheduleFiredAt != nil {
+			m.state.SetScheduleFiredAt(time.Time{})
+		}
+		return
+	}
+	now := time.Now()
+	if m.schedule == nil || m.schedule.cfg != *cfg {
+		// The schedule is not built by this owner yet, the firings missed
+		// while the owner changed are computed from the last firing stored
+		// in the status. A new or changed schedule starts from now.
+		from := now
+		status := m.state.GetChangefeedStatus()
+		if m.schedule == nil && status != nil && status.ScheduleFiredAt != nil {
+			from = *status.ScheduleFiredAt
+		}
+		schedule, err := newChangefeedSchedule(cfg, from)
+		if err != nil {
+			log.Warn("invalid changefeed schedule, ignore it",
+				zap.String("namespace", m.state.GetID().Namespace),
+				zap.String("changefeed", m.state.GetID().ID),
+				zap.Error(err))
+			m.schedule = nil
+			return
+		}
+		m.schedule = schedule
+		m.state.SetScheduleFiredAt(from)
+	}
+
+	jobType, firedAt := m.schedule.check(now)
+	if !firedAt.IsZero() {
+		m.state.SetScheduleFiredAt(firedAt)
+	}
+	switch jobType {
+	case model.AdminStop:
+		switch info.State {
+		case model.StateNormal, model.StateWarning, model.StatePending:
+		default:
+			return
+		}
+		log.Info("pause the changefeed by its schedule",
+			zap.String("namespace", m.state.GetID().Namespace),
+			zap.String("changefeed", m.state.GetID().ID),
+			zap.String("pauseCron", cfg.PauseCron))
+		m.pushAdminJob(&model.AdminJob{CfID: m.state.GetID(), Type: model.AdminStop})
+	case model.AdminResume:
+		if info.State != model.StateStopped {
+			return
+		}
+		log.Info("resume the changefeed by its schedule",
+			zap.String("namespace", m.state.GetID().Namespace),
+			zap.String("changefeed", m.state.GetID().ID),
+			zap.String("resumeCron", cfg.ResumeCron))
+		m.pushAdminJob(&model.AdminJob{CfID: m.state.GetID(), Type: model.AdminResume})
+	}
+}
+
+// waitForStartTs returns true if the changefeed has a delayed start and the
+// upstream has not reached its start-ts yet.
+func (m *feedStateManager) waitForStartTs(info *model.ChangeFeedInfo) bool {
+	if info.Config == nil || info.Config.Schedule == nil || !info.Config.Schedule.DelayedStart {
+		return false
+	}
+	currentTs := oracle.GoTimeToTS(m.upstream.PDClock.CurrentTime())
+	if info.StartTs <= currentTs {
+		return false
+	}
+	log.Debug("changefeed is waiting for the upstream to reach its start-ts",
+		zap.String("namespace", m.state.GetID().Namespace),
+		zap.String("changefeed", m.state.GetID().ID),
+		zap.Uint64("startTs", info.StartTs),
+		zap.Uint64("currentTs", currentTs))
+	return true
 }
 
 func (m *feedStateManager) cleanUp() {
@@ -408,7 +543,7 @@ func (m *feedStateManager) HandleError(errs ...*model.RunningError) {
 			err.ShouldFailChangefeed() {
 			m.state.SetError(err)
 			m.shouldBeRunning = false
-			m.patchState(model.StateFailed)
+			m.patchStateWithError(model.StateFailed, err)
 			return
 		}
 	}
@@ -438,7 +573,7 @@ func (m *feedStateManager) HandleError(errs ...*model.RunningError) {
 	if lastError != nil {
 		log.Warn("changefeed meets an error", zap.Any("error", lastError))
 		m.shouldBeRunning = false
-		m.patchState(model.StatePending)
+		m.patchStateWithError(model.StatePending, lastError)
 
 		// patch the last error to changefeed info
 		m.state.SetError(lastError)
@@ -483,7 +618,7 @@ func (m *feedStateManager) HandleWarning(errs ...*model.RunningError) {
 		}
 	}
 
-	m.patchState(model.StateWarning)
+	m.patchStateWithError(model.StateWarning, lastError)
 	m.state.SetWarning(lastError)
 }
 
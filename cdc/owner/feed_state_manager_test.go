@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
 )
 
@@ -1166,3 +1167,236 @@ func TestUpdateChangefeedWithChangefeedErrorStuckDuration(t *testing.T) {
 	require.False(t, manager.ShouldRunning())
 	require.Equal(t, state.Info.State, model.StateFailed)
 }
+
+func TestHookEvents(t *testing.T) {
+	t.Parallel()
+
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		model.DefaultChangeFeedID("test-hook"))
+	manager.state = state
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{
+			SinkURI: "123",
+			State:   model.StateNormal,
+			Config: &config.ReplicaConfig{
+				Hooks: []*config.HookConfig{
+					{Events: []string{"failed", "stopped"}, URL: "http://127.0.0.1/hook"},
+					{Events: []string{"warning"}, Command: "true"},
+				},
+			},
+		}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{CheckpointTs: 100}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.checkpointTsAdvanced = time.Now()
+
+	// a warning triggers the second hook only once
+	for i := 0; i < 2; i++ {
+		manager.HandleWarning(&model.RunningError{Message: "warning"})
+		tester.MustApplyPatches()
+	}
+	require.Equal(t, model.StateWarning, state.Info.State)
+	require.Len(t, state.Status.PendingHookEvents, 1)
+	pending := state.Status.PendingHookEvents[0]
+	require.Equal(t, 1, pending.HookIndex)
+	require.Equal(t, model.StateWarning, pending.Event.State)
+	require.Equal(t, model.StateNormal, pending.Event.PrevState)
+	require.Equal(t, uint64(100), pending.Event.CheckpointTs)
+	require.Equal(t, "warning", pending.Event.Error.Message)
+
+	// a fast fail error triggers the first hook
+	manager.HandleError(&model.RunningError{
+		Code:    "CDC:ErrStartTsBeforeGC",
+		Message: "fast fail",
+	})
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateFailed, state.Info.State)
+	require.Len(t, state.Status.PendingHookEvents, 2)
+	pending = state.Status.PendingHookEvents[1]
+	require.Equal(t, 0, pending.HookIndex)
+	require.Equal(t, model.StateFailed, pending.Event.State)
+	require.Equal(t, model.StateWarning, pending.Event.PrevState)
+	require.Equal(t, "fast fail", pending.Event.Error.Message)
+
+	// the delivered event is removed
+	state.RemoveHookEvent(state.Status.PendingHookEvents[0].Event.ID)
+	tester.MustApplyPatches()
+	require.Len(t, state.Status.PendingHookEvents, 1)
+	require.Equal(t, model.StateFailed, state.Status.PendingHookEvents[0].Event.State)
+
+	// the pending events are kept when the checkpoint is overwritten
+	manager.PushAdminJob(&model.AdminJob{
+		CfID:                  state.ID,
+		Type:                  model.AdminResume,
+		OverwriteCheckpointTs: 200,
+	})
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateNormal, state.Info.State)
+	require.Equal(t, uint64(200), state.Status.CheckpointTs)
+	require.Len(t, state.Status.PendingHookEvents, 1)
+
+	// too many events, the oldest ones are dropped
+	events := make([]*model.PendingHookEvent, 0, model.MaxPendingHookEvents)
+	for i := 0; i < model.MaxPendingHookEvents; i++ {
+		events = append(events, &model.PendingHookEvent{
+			Event: &model.HookEvent{ID: uint64(i), State: model.StateFailed},
+		})
+	}
+	state.AddHookEvents(events...)
+	tester.MustApplyPatches()
+	require.Len(t, state.Status.PendingHookEvents, model.MaxPendingHookEvents)
+	require.Equal(t, uint64(0), state.Status.PendingHookEvents[0].Event.ID)
+}
+
+func TestScheduleChangefeed(t *testing.T) {
+	t.Parallel()
+
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		model.DefaultChangeFeedID("test-schedule"))
+	manager.state = state
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	schedule := &config.ScheduleConfig{PauseCron: "* * * * *"}
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{
+			SinkURI: "123",
+			State:   model.StateNormal,
+			Config:  &config.ReplicaConfig{Schedule: schedule},
+		}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+
+	// the schedule is built at the first tick, nothing fires.
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.NotNil(t, manager.schedule)
+
+	require.NotNil(t, state.Status.ScheduleFiredAt)
+
+	// the pause cron fired since the last check
+	manager.schedule.lastCheck = time.Now().Add(-2 * time.Minute)
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateStopped, state.Info.State)
+	firedAt := *state.Status.ScheduleFiredAt
+	require.True(t, firedAt.After(time.Now().Add(-2*time.Minute)))
+
+	// the resume cron fires, the stopped changefeed is resumed
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.Config.Schedule = &config.ScheduleConfig{ResumeCron: "* * * * *"}
+		return info, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.Equal(t, model.StateStopped, state.Info.State)
+	manager.schedule.lastCheck = time.Now().Add(-2 * time.Minute)
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+	require.Equal(t, model.StateNormal, state.Info.State)
+
+	// the schedule is removed
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.Config.Schedule = nil
+		return info, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.Nil(t, manager.schedule)
+	require.True(t, manager.ShouldRunning())
+	require.Nil(t, state.Status.ScheduleFiredAt)
+}
+
+func TestScheduleChangefeedAfterOwnerChanged(t *testing.T) {
+	t.Parallel()
+
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		model.DefaultChangeFeedID("test-schedule-owner-changed"))
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{
+			SinkURI: "123",
+			State:   model.StateNormal,
+			Config: &config.ReplicaConfig{
+				Schedule: &config.ScheduleConfig{PauseCron: "* * * * *"},
+			},
+		}, true, nil
+	})
+	// the old owner recorded the schedule and went away before the pause
+	// cron fired.
+	lastFiredAt := time.Now().Add(-2 * time.Minute)
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{ScheduleFiredAt: &lastFiredAt}, true, nil
+	})
+	tester.MustApplyPatches()
+
+	// the new owner pauses the changefeed by the firing it missed.
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	manager.state = state
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateStopped, state.Info.State)
+	require.True(t, state.Status.ScheduleFiredAt.After(lastFiredAt))
+}
+
+func TestDelayedStart(t *testing.T) {
+	t.Parallel()
+
+	manager := newFeedStateManager4Test(200, 1600, 0, 2.0)
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		model.DefaultChangeFeedID("test-delayed-start"))
+	manager.state = state
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	startTs := oracle.GoTimeToTS(time.Now().Add(time.Hour))
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		require.Nil(t, info)
+		return &model.ChangeFeedInfo{
+			SinkURI: "123",
+			StartTs: startTs,
+			State:   model.StateNormal,
+			Config: &config.ReplicaConfig{
+				Schedule: &config.ScheduleConfig{DelayedStart: true},
+			},
+		}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		require.Nil(t, status)
+		return &model.ChangeFeedStatus{CheckpointTs: startTs}, true, nil
+	})
+	tester.MustApplyPatches()
+
+	// the upstream has not reached the start-ts
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.False(t, manager.ShouldRunning())
+	require.Equal(t, model.StateNormal, state.Info.State)
+
+	// the upstream reaches the start-ts
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.StartTs = oracle.GoTimeToTS(time.Now().Add(-time.Second))
+		return info, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(0, state.Status, state.Info)
+	tester.MustApplyPatches()
+	require.True(t, manager.ShouldRunning())
+}
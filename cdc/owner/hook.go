@@ -0,0 +1,229 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/httputil"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"go.uber.org/zap"
+)
+
+const (
+	// hookTimeout is the time limit of delivering a hook event once.
+	hookTimeout = 10 * time.Second
+	// hookInitRetryInterval and hookMaxRetryInterval bound the interval of
+	// redelivering a hook event which is failed to be delivered.
+	hookInitRetryInterval = time.Second
+	hookMaxRetryInterval  = time.Minute
+)
+
+// hookDelivery is the delivery of the first pending hook event of a changefeed.
+type hookDelivery struct {
+	eventID uint64
+	hook    *config.HookConfig
+	cancel  context.CancelFunc
+	// result receives the result of the running delivery, it's nil if there
+	// is no delivery running.
+	result        chan error
+	delivered     bool
+	retryAt       time.Time
+	retryInterval time.Duration
+}
+
+// hookDispatcher delivers the pending hook events stored in the changefeed
+// status in order. An event is removed from the status after it's delivered,
+// so it's delivered at least once even if the owner changes.
+type hookDispatcher struct {
+	allowCommand bool
+	client       *httputil.Client
+	deliveries   map[model.ChangeFeedID]*hookDelivery
+
+	// deliver is replaceable in tests.
+	deliver func(ctx context.Context, hook *config.HookConfig, event *model.HookEvent) error
+}
+
+func newHookDispatcher(allowCommand bool) *hookDispatcher {
+	// the client never fails to be created without credentials.
+	client, _ := httputil.NewClient(nil)
+	client.SetTimeout(hookTimeout)
+	d := &hookDispatcher{
+		allowCommand: allowCommand,
+		client:       client,
+		deliveries:   make(map[model.ChangeFeedID]*hookDelivery),
+	}
+	d.deliver = d.deliverEvent
+	return d
+}
+
+// tick checks the delivery of the first pending hook event of the changefeed,
+// and starts or retries it if needed. It never blocks.
+func (d *hookDispatcher) tick(state *orchestrator.ChangefeedReactorState) {
+	if state.Status == nil || len(state.Status.PendingHookEvents) == 0 {
+		d.remove(state.ID)
+		return
+	}
+	head := state.Status.PendingHookEvents[0]
+	delivery, ok := d.deliveries[state.ID]
+	if ok && delivery.eventID != head.Event.ID {
+		// the event is removed or dropped, deliver the next one.
+		d.remove(state.ID)
+		ok = false
+	}
+	if !ok {
+		hook := lookupHook(state.Info, head)
+		if hook == nil {
+			log.Warn("the hook of the event is removed from the changefeed, drop the hook event",
+				zap.String("namespace", state.ID.Namespace),
+				zap.String("changefeed", state.ID.ID),
+				zap.Uint64("eventID", head.Event.ID),
+				zap.Int("hookIndex", head.HookIndex))
+			state.RemoveHookEvent(head.Event.ID)
+			return
+		}
+		if hook.Command != "" && !d.allowCommand {
+			log.Warn("hook commands are not allowed on this server, drop the hook event",
+				zap.String("namespace", state.ID.Namespace),
+				zap.String("changefeed", state.ID.ID),
+				zap.Uint64("eventID", head.Event.ID),
+				zap.Stringer("hook", hook))
+			state.RemoveHookEvent(head.Event.ID)
+			return
+		}
+		delivery = &hookDelivery{
+			eventID:       head.Event.ID,
+			hook:          hook,
+			retryInterval: hookInitRetryInterval,
+		}
+		d.deliveries[state.ID] = delivery
+	}
+
+	if delivery.delivered {
+		// the event has been delivered, but the status is not updated yet.
+		state.RemoveHookEvent(head.Event.ID)
+		return
+	}
+	if delivery.result != nil {
+		select {
+		case err := <-delivery.result:
+			delivery.cancel()
+			delivery.result = nil
+			if err == nil {
+				log.Info("hook event is delivered",
+					zap.String("namespace", state.ID.Namespace),
+					zap.String("changefeed", state.ID.ID),
+					zap.Uint64("eventID", head.Event.ID),
+					zap.String("state", string(head.Event.State)),
+					zap.Stringer("hook", delivery.hook))
+				delivery.delivered = true
+				state.RemoveHookEvent(head.Event.ID)
+				return
+			}
+			log.Warn("failed to deliver the hook event, it will be retried",
+				zap.String("namespace", state.ID.Namespace),
+				zap.String("changefeed", state.ID.ID),
+				zap.Uint64("eventID", head.Event.ID),
+				zap.Stringer("hook", delivery.hook),
+				zap.Duration("retryInterval", delivery.retryInterval),
+				zap.Error(err))
+			delivery.retryAt = time.Now().Add(delivery.retryInterval)
+			delivery.retryInterval *= 2
+			if delivery.retryInterval > hookMaxRetryInterval {
+				delivery.retryInterval = hookMaxRetryInterval
+			}
+		default:
+		}
+		return
+	}
+	if time.Now().Before(delivery.retryAt) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	delivery.cancel = cancel
+	delivery.result = make(chan error, 1)
+	go func(result chan<- error) {
+		result <- d.deliver(ctx, delivery.hook, head.Event)
+	}(delivery.result)
+}
+
+// lookupHook returns the hook in the changefeed config that the pending event
+// is delivered to. It returns nil if the hook is removed or changed to not be
+// triggered by the state of the event.
+func lookupHook(info *model.ChangeFeedInfo, pending *model.PendingHookEvent) *config.HookConfig {
+	if info == nil || info.Config == nil ||
+		pending.HookIndex < 0 || pending.HookIndex >= len(info.Config.Hooks) {
+		return nil
+	}
+	hook := info.Config.Hooks[pending.HookIndex]
+	if !hook.HasEvent(string(pending.Event.State)) {
+		return nil
+	}
+	return hook
+}
+
+// remove cancels the delivery of the changefeed.
+func (d *hookDispatcher) remove(id model.ChangeFeedID) {
+	if delivery, ok := d.deliveries[id]; ok {
+		if delivery.cancel != nil {
+			delivery.cancel()
+		}
+		delete(d.deliveries, id)
+	}
+}
+
+// close cancels all the deliveries.
+func (d *hookDispatcher) close() {
+	for id := range d.deliveries {
+		d.remove(id)
+	}
+}
+
+// deliverEvent posts the event to the webhook, or runs the command with the
+// event from the stdin.
+func (d *hookDispatcher) deliverEvent(
+	ctx context.Context, hook *config.HookConfig, event *model.HookEvent,
+) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if hook.URL != "" {
+		header := http.Header{}
+		header.Set("Content-Type", "application/json")
+		_, err = d.client.DoRequest(ctx, hook.URL, http.MethodPost,
+			header, bytes.NewReader(body))
+		return errors.Trace(err)
+	}
+	return runHookCommand(ctx, hook, body)
+}
+
+func runHookCommand(ctx context.Context, hook *config.HookConfig, body []byte) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook.Command)
+	cmd.Stdin = bytes.NewReader(body)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Annotatef(err, "hook command failed, output: %s", output)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/stretchr/testify/require"
+)
+
+func newHookTestState(
+	t *testing.T, hooks []*config.HookConfig, events ...*model.PendingHookEvent,
+) (*orchestrator.ChangefeedReactorState, *orchestrator.ReactorStateTester) {
+	state := orchestrator.NewChangefeedReactorState(etcd.DefaultCDCClusterID,
+		model.DefaultChangeFeedID("test-hook"))
+	tester := orchestrator.NewReactorStateTester(t, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		return &model.ChangeFeedInfo{Config: &config.ReplicaConfig{Hooks: hooks}}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		return &model.ChangeFeedStatus{PendingHookEvents: events}, true, nil
+	})
+	tester.MustApplyPatches()
+	return state, tester
+}
+
+func TestHookDispatcherWebhook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests int
+		received []uint64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		// the first request is failed, the event should be redelivered.
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		event := &model.HookEvent{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(event))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = append(received, event.ID)
+	}))
+	defer server.Close()
+
+	hooks := []*config.HookConfig{{Events: []string{"failed", "finished"}, URL: server.URL}}
+	state, tester := newHookTestState(t, hooks,
+		&model.PendingHookEvent{Event: &model.HookEvent{ID: 1, State: model.StateFailed}},
+		&model.PendingHookEvent{Event: &model.HookEvent{ID: 2, State: model.StateFinished}},
+	)
+	d := newHookDispatcher(false)
+	defer d.close()
+	require.Eventually(t, func() bool {
+		d.tick(state)
+		tester.MustApplyPatches()
+		return len(state.Status.PendingHookEvents) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []uint64{1, 2}, received)
+	require.Equal(t, 3, requests)
+	d.tick(state)
+	require.Empty(t, d.deliveries)
+}
+
+func TestHookDispatcherCommand(t *testing.T) {
+	t.Parallel()
+
+	hooks := []*config.HookConfig{{Events: []string{"failed"}, Command: "cat"}}
+	event := &model.PendingHookEvent{
+		Event: &model.HookEvent{ID: 1, State: model.StateFailed},
+	}
+
+	// the command is not allowed, the event is dropped.
+	state, tester := newHookTestState(t, hooks, event)
+	d := newHookDispatcher(false)
+	d.deliver = func(context.Context, *config.HookConfig, *model.HookEvent) error {
+		require.FailNow(t, "unexpected delivery")
+		return nil
+	}
+	d.tick(state)
+	tester.MustApplyPatches()
+	require.Empty(t, state.Status.PendingHookEvents)
+
+	// the command is allowed
+	state, tester = newHookTestState(t, hooks, event)
+	d = newHookDispatcher(true)
+	delivered := make(chan uint64, 1)
+	d.deliver = func(_ context.Context, hook *config.HookConfig, event *model.HookEvent) error {
+		require.Equal(t, "cat", hook.Command)
+		delivered <- event.ID
+		return nil
+	}
+	require.Eventually(t, func() bool {
+		d.tick(state)
+		tester.MustApplyPatches()
+		return len(state.Status.PendingHookEvents) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(1), <-delivered)
+}
+
+func TestHookDispatcherHookRemoved(t *testing.T) {
+	t.Parallel()
+
+	// the hook is removed, or changed to not be triggered by the event.
+	hooks := []*config.HookConfig{{Events: []string{"stopped"}, Command: "cat"}}
+	state, tester := newHookTestState(t, hooks,
+		&model.PendingHookEvent{HookIndex: 1, Event: &model.HookEvent{ID: 1, State: model.StateFailed}},
+		&model.PendingHookEvent{HookIndex: 0, Event: &model.HookEvent{ID: 2, State: model.StateFailed}},
+	)
+	d := newHookDispatcher(true)
+	d.deliver = func(context.Context, *config.HookConfig, *model.HookEvent) error {
+		require.FailNow(t, "unexpected delivery")
+		return nil
+	}
+	for i := 0; i < 2; i++ {
+		d.tick(state)
+		tester.MustApplyPatches()
+	}
+	require.Empty(t, state.Status.PendingHookEvents)
+}
+
+func TestRunHookCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	body := []byte(`{"id":1,"changefeed_id":"test"}`)
+	require.NoError(t, runHookCommand(ctx,
+		&config.HookConfig{Command: `grep -q '"changefeed_id":"test"'`}, body))
+	err := runHookCommand(ctx, &config.HookConfig{Command: "echo oops; exit 1"}, body)
+	require.ErrorContains(t, err, "oops")
+}
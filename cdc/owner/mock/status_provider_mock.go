@@ -95,6 +95,21 @@ func (mr *MockStatusProviderMockRecorder) GetCaptures(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaptures", reflect.TypeOf((*MockStatusProvider)(nil).GetCaptures), ctx)
 }
 
+// GetChangeFeedDiagnosis mocks base method.
+func (m *MockStatusProvider) GetChangeFeedDiagnosis(ctx context.Context, changefeedID model.ChangeFeedID) (*model.OwnerDiagnosis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeFeedDiagnosis", ctx, changefeedID)
+	ret0, _ := ret[0].(*model.OwnerDiagnosis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeFeedDiagnosis indicates an expected call of GetChangeFeedDiagnosis.
+func (mr *MockStatusProviderMockRecorder) GetChangeFeedDiagnosis(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedDiagnosis", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedDiagnosis), ctx, changefeedID)
+}
+
 // GetChangeFeedInfo mocks base method.
 func (m *MockStatusProvider) GetChangeFeedInfo(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangeFeedInfo, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +125,21 @@ func (mr *MockStatusProviderMockRecorder) GetChangeFeedInfo(ctx, changefeedID in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedInfo", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedInfo), ctx, changefeedID)
 }
 
+// GetChangeFeedStatistics mocks base method.
+func (m *MockStatusProvider) GetChangeFeedStatistics(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeFeedStatistics", ctx, changefeedID)
+	ret0, _ := ret[0].(*model.ChangefeedStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeFeedStatistics indicates an expected call of GetChangeFeedStatistics.
+func (mr *MockStatusProviderMockRecorder) GetChangeFeedStatistics(ctx, changefeedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedStatistics", reflect.TypeOf((*MockStatusProvider)(nil).GetChangeFeedStatistics), ctx, changefeedID)
+}
+
 // GetChangeFeedStatus mocks base method.
 func (m *MockStatusProvider) GetChangeFeedStatus(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangeFeedStatusForAPI, error) {
 	m.ctrl.T.Helper()
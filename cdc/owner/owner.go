@@ -141,6 +141,9 @@ type ownerImpl struct {
 	cfg *config.SchedulerConfig
 
 	globalVars *vars.GlobalVars
+
+	// hooks delivers the hook events of changefeeds.
+	hooks *hookDispatcher
 }
 
 var (
@@ -163,6 +166,7 @@ func NewOwner(
 		cfg:             cfg,
 		etcdClient:      globalVars.EtcdClient,
 		globalVars:      globalVars,
+		hooks:           newHookDispatcher(config.GetGlobalServerConfig().AllowHookCommand),
 	}
 }
 
@@ -233,6 +237,9 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 		if !preflightCheck(changefeedState, captures) {
 			continue
 		}
+		if o.hooks != nil {
+			o.hooks.tick(changefeedState)
+		}
 		checkpointTs, minTableBarrierTs := cfReactor.Tick(stdCtx, changefeedState.Info, changefeedState.Status, captures)
 		updateStatus(changefeedState, checkpointTs, minTableBarrierTs)
 	}
@@ -246,6 +253,9 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 			}
 			reactor.Close(stdCtx)
 			delete(o.changefeeds, changefeedID)
+			if o.hooks != nil {
+				o.hooks.remove(changefeedID)
+			}
 		}
 	}
 
@@ -254,6 +264,9 @@ func (o *ownerImpl) Tick(stdCtx context.Context, rawState orchestrator.ReactorSt
 		for _, reactor := range o.changefeeds {
 			reactor.Close(stdCtx)
 		}
+		if o.hooks != nil {
+			o.hooks.close()
+		}
 		return state, cerror.ErrReactorFinished.GenWithStackByArgs()
 	}
 
@@ -766,6 +779,20 @@ func (o *ownerImpl) handleQueries(query *Query) error {
 	case QueryExists:
 		_, ok := o.changefeeds[query.ChangeFeedID]
 		query.Data = ok
+	case QueryChangeFeedDiagnosis:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok {
+			query.Data = nil
+			return nil
+		}
+		query.Data = cfReactor.diagnose()
+	case QueryChangeFeedStatistics:
+		cfReactor, ok := o.changefeeds[query.ChangeFeedID]
+		if !ok {
+			query.Data = nil
+			return nil
+		}
+		query.Data = cfReactor.statistics()
 	}
 	return nil
 }
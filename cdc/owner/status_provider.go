@@ -54,6 +54,14 @@ type StatusProvider interface {
 	IsHealthy(ctx context.Context) (bool, error)
 	// IsChangefeedExists returns true if a changefeed exits
 	IsChangefeedExists(ctx context.Context, id model.ChangeFeedID) (bool, error)
+
+	// GetChangeFeedDiagnosis returns the state of a changefeed in the owner
+	// which may hold back the checkpoint ts.
+	GetChangeFeedDiagnosis(ctx context.Context, changefeedID model.ChangeFeedID) (*model.OwnerDiagnosis, error)
+
+	// GetChangeFeedStatistics returns the throughput and the lag of a
+	// changefeed aggregated from the heartbeats of the processors.
+	GetChangeFeedStatistics(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedStatistics, error)
 }
 
 // QueryType is the type of different queries.
@@ -82,6 +90,10 @@ const (
 	QueryAllChangeFeedSCheckpointTs
 	// QueryExists is the type of query check if a changefeed exists
 	QueryExists
+	// QueryChangeFeedDiagnosis is the type of query changefeed diagnosis
+	QueryChangeFeedDiagnosis
+	// QueryChangeFeedStatistics is the type of query changefeed statistics
+	QueryChangeFeedStatistics
 )
 
 // Query wraps query command and return results.
@@ -230,6 +242,42 @@ func (p *ownerStatusProvider) IsChangefeedExists(ctx context.Context, id model.C
 	return query.Data.(bool), nil
 }
 
+// GetChangeFeedDiagnosis returns the state of a changefeed in the owner
+// which may hold back the checkpoint ts.
+func (p *ownerStatusProvider) GetChangeFeedDiagnosis(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) (*model.OwnerDiagnosis, error) {
+	query := &Query{
+		Tp:           QueryChangeFeedDiagnosis,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if query.Data == nil {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedID)
+	}
+	return query.Data.(*model.OwnerDiagnosis), nil
+}
+
+// GetChangeFeedStatistics returns the throughput and the lag of a
+// changefeed aggregated from the heartbeats of the processors.
+func (p *ownerStatusProvider) GetChangeFeedStatistics(ctx context.Context,
+	changefeedID model.ChangeFeedID,
+) (*model.ChangefeedStatistics, error) {
+	query := &Query{
+		Tp:           QueryChangeFeedStatistics,
+		ChangeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if query.Data == nil {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedID)
+	}
+	return query.Data.(*model.ChangefeedStatistics), nil
+}
+
 func (p *ownerStatusProvider) sendQueryToOwner(ctx context.Context, query *Query) error {
 	doneCh := make(chan error, 1)
 	p.owner.Query(query, doneCh)
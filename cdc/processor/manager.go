@@ -38,6 +38,7 @@ type commandTp int
 const (
 	commandTpUnknown commandTp = iota
 	commandTpWriteDebugInfo
+	commandTpDiagnose
 	processorLogsWarnDuration = 1 * time.Second
 )
 
@@ -56,6 +57,17 @@ type Manager interface {
 	Close()
 
 	WriteDebugInfo(ctx context.Context, w io.Writer, done chan<- error)
+
+	// Diagnose collects the progress of the tables of a changefeed in each
+	// stage of the replication pipeline. The result is filled after done is closed.
+	Diagnose(ctx context.Context, changefeedID model.ChangeFeedID, limit int,
+		result *model.ProcessorDiagnosis, done chan<- error)
+}
+
+type diagnoseCommand struct {
+	changefeedID model.ChangeFeedID
+	limit        int
+	result       *model.ProcessorDiagnosis
 }
 
 // managerImpl is a manager of processor, which maintains the state and behavior of processors
@@ -321,6 +333,19 @@ func (m *managerImpl) WriteDebugInfo(
 	}
 }
 
+// Diagnose collects the progress of the tables of a changefeed in each
+// stage of the replication pipeline.
+func (m *managerImpl) Diagnose(
+	ctx context.Context, changefeedID model.ChangeFeedID, limit int,
+	result *model.ProcessorDiagnosis, done chan<- error,
+) {
+	payload := &diagnoseCommand{changefeedID: changefeedID, limit: limit, result: result}
+	err := m.sendCommand(ctx, commandTpDiagnose, payload, done)
+	if err != nil {
+		log.Warn("send command commandTpDiagnose failed", zap.Error(err))
+	}
+}
+
 // sendCommands sends command to manager.
 // `done` is closed upon command completion or sendCommand returns error.
 func (m *managerImpl) sendCommand(
@@ -352,6 +377,13 @@ func (m *managerImpl) handleCommand() {
 		if err != nil {
 			cmd.done <- err
 		}
+	case commandTpDiagnose:
+		payload := cmd.payload.(*diagnoseCommand)
+		payload.result.CaptureID = m.captureInfo.ID
+		// A capture may not have any table of the changefeed.
+		if p, ok := m.processors[payload.changefeedID]; ok {
+			*payload.result = *p.diagnose(payload.limit)
+		}
 	default:
 		log.Warn("Unknown command in processor manager", zap.Any("command", cmd))
 	}
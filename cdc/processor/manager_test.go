@@ -194,6 +194,21 @@ func TestDebugInfo(t *testing.T) {
 	<-doneM
 	require.Greater(t, len(buf.String()), 0)
 
+	diagnosis := &model.ProcessorDiagnosis{}
+	doneD := make(chan error, 1)
+	s.manager.Diagnose(ctx, changefeedID, 3, diagnosis, doneD)
+	require.NoError(t, <-doneD)
+	require.Equal(t, "capture-test", diagnosis.CaptureID)
+	require.Empty(t, diagnosis.Stages)
+
+	// the capture doesn't replicate the changefeed
+	diagnosis = &model.ProcessorDiagnosis{}
+	doneD = make(chan error, 1)
+	s.manager.Diagnose(ctx, model.DefaultChangeFeedID("unknown"), 3, diagnosis, doneD)
+	require.NoError(t, <-doneD)
+	require.Equal(t, "capture-test", diagnosis.CaptureID)
+	require.Nil(t, diagnosis.SlowestTable)
+
 	// Stop tick so that we can close manager safely.
 	cancel()
 	<-done
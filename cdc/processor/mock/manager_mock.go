@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/pingcap/tiflow/cdc/model"
 	orchestrator "github.com/pingcap/tiflow/pkg/orchestrator"
 )
 
@@ -48,6 +49,18 @@ func (mr *MockManagerMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockManager)(nil).Close))
 }
 
+// Diagnose mocks base method.
+func (m *MockManager) Diagnose(ctx context.Context, changefeedID model.ChangeFeedID, limit int, result *model.ProcessorDiagnosis, done chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Diagnose", ctx, changefeedID, limit, result, done)
+}
+
+// Diagnose indicates an expected call of Diagnose.
+func (mr *MockManagerMockRecorder) Diagnose(ctx, changefeedID, limit, result, done interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diagnose", reflect.TypeOf((*MockManager)(nil).Diagnose), ctx, changefeedID, limit, result, done)
+}
+
 // Tick mocks base method.
 func (m *MockManager) Tick(ctx context.Context, state orchestrator.ReactorState) (orchestrator.ReactorState, error) {
 	m.ctrl.T.Helper()
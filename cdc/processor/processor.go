@@ -15,6 +15,7 @@ package processor
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/url"
@@ -389,8 +390,11 @@ func (p *processor) getStatsFromSourceManagerAndSinkManager(
 	pullerStats := p.sourceManager.r.GetTablePullerStats(span)
 
 	stats := tablepb.Stats{
-		RegionCount: pullerStats.RegionCount,
-		BarrierTs:   sinkStats.BarrierTs,
+		RegionCount:           pullerStats.RegionCount,
+		BarrierTs:             sinkStats.BarrierTs,
+		EmittedRowCount:       sinkStats.EmittedRows,
+		EmittedBytes:          sinkStats.EmittedBytes,
+		SinkPendingEventCount: uint64(sinkStats.PendingEvents),
 		StageCheckpoints: map[string]tablepb.Checkpoint{
 			"puller-ingress": {
 				CheckpointTs: pullerStats.CheckpointTsIngress,
@@ -412,6 +416,7 @@ func (p *processor) getStatsFromSourceManagerAndSinkManager(
 		CheckpointTs: sortStats.ReceivedMaxCommitTs,
 		ResolvedTs:   sortStats.ReceivedMaxResolvedTs,
 	}
+	stats.SorterDiskUsage = sortStats.DiskUsage
 	stats.StageCheckpoints["sorter-egress"] = tablepb.Checkpoint{
 		CheckpointTs: sinkStats.ResolvedTs,
 		ResolvedTs:   sinkStats.ResolvedTs,
@@ -676,7 +681,7 @@ func (p *processor) lazyInitImpl(_ context.Context) (err error) {
 	p.redo.changefeedID = p.changefeedID
 	p.redo.spawn(ctx)
 
-	sortEngine, err := p.globalVars.SortEngineFactory.Create(p.changefeedID)
+	sortEngine, err := p.globalVars.SortEngineFactory.Create(p.changefeedID, cfConfig.SorterDiskQuota)
 	log.Info("Processor creates sort engine",
 		zap.String("namespace", p.changefeedID.Namespace),
 		zap.String("changefeed", p.changefeedID.ID),
@@ -989,6 +994,99 @@ func (p *processor) WriteDebugInfo(w io.Writer) error {
 	return nil
 }
 
+// diagnose collects the progress of the tables in each stage of the
+// replication pipeline, at most limit slowest table spans of each stage and
+// at most limit slowest regions of each table are reported.
+func (p *processor) diagnose(limit int) *model.ProcessorDiagnosis {
+	res := &model.ProcessorDiagnosis{CaptureID: p.captureInfo.ID}
+	if !p.initialized.Load() {
+		return res
+	}
+	res.CurrentTs = oracle.GoTimeToTS(p.upstream.PDClock.CurrentTime())
+	diskUsage := p.sourceManager.r.GetSorterDiskUsage()
+	res.SorterDiskUsage = &diskUsage
+
+	var stages []model.StageDiagnosis
+	for _, span := range p.sinkManager.r.GetAllCurrentTableSpans() {
+		sinkState, ok := p.sinkManager.r.GetTableDiagnosis(span)
+		if !ok {
+			continue
+		}
+		table := &model.TableDiagnosis{
+			CaptureID:    p.captureInfo.ID,
+			TableID:      span.TableID,
+			TableName:    p.getTableNameForDiagnosis(span.TableID),
+			StartKey:     hex.EncodeToString(span.StartKey),
+			EndKey:       hex.EncodeToString(span.EndKey),
+			CheckpointTs: sinkState.CheckpointTs,
+		}
+		newStage := func(stage model.DiagnosisStage, resolvedTs model.Ts) model.StageDiagnosis {
+			return model.StageDiagnosis{
+				Stage:      stage,
+				CaptureID:  table.CaptureID,
+				TableID:    table.TableID,
+				TableName:  table.TableName,
+				StartKey:   table.StartKey,
+				EndKey:     table.EndKey,
+				ResolvedTs: resolvedTs,
+			}
+		}
+
+		regions, holes := p.sourceManager.r.GetTableSlowestRegions(span, limit)
+		for _, hole := range holes {
+			stage := newStage(model.DiagnosisStageKVClient, hole.ResolvedTs)
+			stage.StartKey = hex.EncodeToString(hole.Span.StartKey)
+			stage.EndKey = hex.EncodeToString(hole.Span.EndKey)
+			stage.Message = "the range is not captured by any region"
+			table.Stages = append(table.Stages, stage)
+		}
+		for _, region := range regions {
+			stage := newStage(model.DiagnosisStageKVClient, region.ResolvedTs)
+			stage.StartKey = hex.EncodeToString(region.Span.StartKey)
+			stage.EndKey = hex.EncodeToString(region.Span.EndKey)
+			stage.RegionID = region.RegionID
+			stage.StoreID = region.StoreID
+			if !region.Initialized {
+				stage.Message = fmt.Sprintf("the region is not initialized since %s",
+					region.Created.Format(time.RFC3339))
+			}
+			table.Stages = append(table.Stages, stage)
+		}
+		pullerStats := p.sourceManager.r.GetTablePullerStats(span)
+		pullerStage := newStage(model.DiagnosisStagePuller, pullerStats.ResolvedTsEgress)
+		if diskUsage.Exceeded {
+			pullerStage.Message = "the puller is blocked as the sorter disk quota is exceeded"
+		}
+		table.Stages = append(table.Stages, pullerStage,
+			newStage(model.DiagnosisStageSorter, sinkState.ReceivedSorterResolvedTs))
+		if sinkState.RedoEnabled {
+			table.Stages = append(table.Stages,
+				newStage(model.DiagnosisStageRedo, sinkState.RedoResolvedTs))
+		}
+		sinkStage := newStage(model.DiagnosisStageSink, sinkState.CheckpointTs)
+		sinkStage.PendingEvents = sinkState.PendingEvents
+		table.Stages = append(table.Stages,
+			newStage(model.DiagnosisStageBarrier, sinkState.BarrierTs), sinkStage)
+		table.FillLags(res.CurrentTs)
+
+		stages = append(stages, table.Stages...)
+		if res.SlowestTable == nil || table.CheckpointTs < res.SlowestTable.CheckpointTs {
+			res.SlowestTable = table
+		}
+	}
+	res.Stages = model.TopStageDiagnoses(stages, limit)
+	return res
+}
+
+// getTableNameForDiagnosis returns the quoted name of the table, it doesn't
+// retry like getTableName as the diagnosis must not block the processor.
+func (p *processor) getTableNameForDiagnosis(tableID model.TableID) string {
+	if x, ok := p.ddlHandler.r.schemaStorage.GetLastSnapshot().PhysicalTableByID(tableID); ok {
+		return x.TableName.QuoteString()
+	}
+	return strconv.Itoa(int(tableID))
+}
+
 func (p *processor) calculateTableBarrierTs(
 	barrier *schedulepb.Barrier,
 ) map[model.TableID]model.Ts {
@@ -60,6 +60,29 @@ type TableStats struct {
 	ResolvedTs   model.Ts
 	LastSyncedTs model.Ts
 	BarrierTs    model.Ts
+	// EmittedRows and EmittedBytes are the number and the approximate bytes
+	// of the rows emitted to the table sink since the table is added.
+	EmittedRows  uint64
+	EmittedBytes uint64
+	// PendingEvents is the number of events written to the sink backend
+	// but not flushed to the downstream.
+	PendingEvents int
+}
+
+// TableDiagnosis is the state of a table in the sink manager, it is used to
+// find out which stage holds back the checkpoint ts of the table.
+type TableDiagnosis struct {
+	CheckpointTs model.Ts
+	// ReceivedSorterResolvedTs is the resolved ts that the sorter outputs.
+	ReceivedSorterResolvedTs model.Ts
+	BarrierTs                model.Ts
+	// RedoResolvedTs is the resolved ts of the redo log, it is only
+	// meaningful if RedoEnabled is true.
+	RedoEnabled    bool
+	RedoResolvedTs model.Ts
+	// PendingEvents is the number of events that are written to the
+	// backend sink but not flushed to the downstream.
+	PendingEvents int
 }
 
 // SinkManager is the implementation of SinkManager.
@@ -1021,11 +1044,36 @@ func (m *SinkManager) GetTableStats(span tablepb.Span) TableStats {
 			zap.Any("checkpointTs", checkpointTs))
 	}
 	return TableStats{
-		CheckpointTs: checkpointTs.ResolvedMark(),
-		ResolvedTs:   resolvedTs,
-		LastSyncedTs: lastSyncedTs,
-		BarrierTs:    tableSink.barrierTs.Load(),
+		CheckpointTs:  checkpointTs.ResolvedMark(),
+		ResolvedTs:    resolvedTs,
+		LastSyncedTs:  lastSyncedTs,
+		BarrierTs:     tableSink.barrierTs.Load(),
+		EmittedRows:   tableSink.emittedRows.Load(),
+		EmittedBytes:  tableSink.emittedBytes.Load(),
+		PendingEvents: tableSink.getPendingEventCount(),
+	}
+}
+
+// GetTableDiagnosis returns the diagnosis of the table,
+// false is returned if the table is not found.
+func (m *SinkManager) GetTableDiagnosis(span tablepb.Span) (TableDiagnosis, bool) {
+	value, ok := m.tableSinks.Load(span)
+	if !ok {
+		return TableDiagnosis{}, false
+	}
+	tableSink := value.(*tableSinkWrapper)
+
+	res := TableDiagnosis{
+		CheckpointTs:             tableSink.getCheckpointTs().ResolvedMark(),
+		ReceivedSorterResolvedTs: tableSink.getReceivedSorterResolvedTs(),
+		BarrierTs:                tableSink.barrierTs.Load(),
+		PendingEvents:            tableSink.getPendingEventCount(),
+	}
+	if m.redoDMLMgr != nil {
+		res.RedoEnabled = true
+		res.RedoResolvedTs = m.redoDMLMgr.GetResolvedTs(span)
 	}
+	return res, true
 }
 
 // WaitForReady implements pkg/util.Runnable.
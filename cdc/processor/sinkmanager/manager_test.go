@@ -281,6 +281,39 @@ func TestGetTableStatsToReleaseMemQuota(t *testing.T) {
 	}, 5*time.Second, 10*time.Millisecond)
 }
 
+func TestGetTableDiagnosis(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changefeedInfo := getChangefeedInfo()
+	manager, _, e := CreateManagerWithMemEngine(t, ctx, model.DefaultChangeFeedID("1"),
+		changefeedInfo, make(chan error, 1))
+	defer func() {
+		cancel()
+		manager.Close()
+	}()
+
+	span := spanz.TableIDToComparableSpan(1)
+	_, ok := manager.GetTableDiagnosis(span)
+	require.False(t, ok)
+
+	manager.AddTable(span, 1, 100)
+	addTableAndAddEventsToSortEngine(t, e, span)
+	manager.UpdateBarrierTs(4, nil)
+	manager.UpdateReceivedSorterResolvedTs(span, 5)
+	manager.schemaStorage.AdvanceResolvedTs(5)
+	require.NoError(t, manager.StartTable(span, 0))
+
+	require.Eventually(t, func() bool {
+		d, ok := manager.GetTableDiagnosis(span)
+		return ok && d.CheckpointTs == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	d, _ := manager.GetTableDiagnosis(span)
+	require.Equal(t, uint64(4), d.BarrierTs)
+	require.Equal(t, uint64(5), d.ReceivedSorterResolvedTs)
+	require.False(t, d.RedoEnabled)
+}
+
 func TestDoNotGenerateTableSinkTaskWhenTableIsNotReplicating(t *testing.T) {
 	t.Parallel()
 
@@ -77,6 +77,12 @@ type tableSinkWrapper struct {
 	// We use this to advance the redo log.
 	receivedSorterResolvedTs atomic.Uint64
 
+	// emittedRows and emittedBytes are the number and the approximate bytes
+	// of the rows emitted to the table sink, they are reported to the owner
+	// to calculate the throughput of the changefeed.
+	emittedRows  atomic.Uint64
+	emittedBytes atomic.Uint64
+
 	// replicateTs is the ts that the table sink has started to replicate.
 	replicateTs    atomic.Uint64
 	genReplicateTs func(ctx context.Context) (model.Ts, error)
@@ -192,6 +198,12 @@ func (t *tableSinkWrapper) appendRowChangedEvents(events ...*model.RowChangedEve
 		return tablesink.NewSinkInternalError(errors.New("table sink cleared"))
 	}
 	t.tableSink.s.AppendRowChangedEvents(events...)
+	size := 0
+	for _, event := range events {
+		size += event.ApproximateBytes()
+	}
+	t.emittedRows.Add(uint64(len(events)))
+	t.emittedBytes.Add(uint64(size))
 	return nil
 }
 
@@ -229,6 +241,15 @@ func (t *tableSinkWrapper) getLastSyncedTs() uint64 {
 	return t.tableSink.lastSyncedTs
 }
 
+func (t *tableSinkWrapper) getPendingEventCount() int {
+	t.tableSink.RLock()
+	defer t.tableSink.RUnlock()
+	if t.tableSink.s != nil {
+		return t.tableSink.s.GetPendingEventCount()
+	}
+	return 0
+}
+
 func (t *tableSinkWrapper) getCheckpointTs() model.ResolvedTs {
 	t.tableSink.RLock()
 	defer t.tableSink.RUnlock()
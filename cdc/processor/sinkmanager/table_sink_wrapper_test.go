@@ -154,6 +154,26 @@ func TestUpdateReceivedSorterResolvedTs(t *testing.T) {
 	require.Equal(t, tablepb.TableStatePrepared, wrapper.getState())
 }
 
+func TestAppendRowChangedEventsCountsEmittedRows(t *testing.T) {
+	t.Parallel()
+
+	wrapper, sink := createTableSinkWrapper(
+		model.DefaultChangeFeedID("1"), spanz.TableIDToComparableSpan(1))
+	rows := []*model.RowChangedEvent{
+		{CommitTs: 1, Columns: []*model.ColumnData{{ColumnID: 1, Value: 1}}},
+		{CommitTs: 2, Columns: []*model.ColumnData{{ColumnID: 1, Value: 2}}},
+	}
+	require.NoError(t, wrapper.appendRowChangedEvents(rows...))
+	require.NoError(t, wrapper.updateResolvedTs(model.NewResolvedTs(2)))
+	require.Len(t, sink.GetEvents(), 2)
+
+	require.Equal(t, uint64(2), wrapper.emittedRows.Load())
+	require.Equal(t, uint64(rows[0].ApproximateBytes()+rows[1].ApproximateBytes()),
+		wrapper.emittedBytes.Load())
+	// The rows are written to the backend sink but not flushed.
+	require.Equal(t, 2, wrapper.getPendingEventCount())
+}
+
 func TestHandleNilRowChangedEvents(t *testing.T) {
 	t.Parallel()
 
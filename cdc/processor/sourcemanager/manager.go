@@ -15,11 +15,14 @@ package sourcemanager
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/entry"
 	"github.com/pingcap/tiflow/cdc/kv"
+	"github.com/pingcap/tiflow/cdc/kv/regionlock"
 	"github.com/pingcap/tiflow/cdc/kv/sharedconn"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/memquota"
@@ -27,13 +30,20 @@ import (
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/puller"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/txnutil"
 	"github.com/pingcap/tiflow/pkg/upstream"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
-const defaultMaxBatchSize = 256
+const (
+	defaultMaxBatchSize = 256
+	// diskQuotaCheckInterval is the interval to check whether the disk quota
+	// of the sort engine is exceeded.
+	diskQuotaCheckInterval = 10 * time.Second
+)
 
 // PullerSplitUpdateMode is the mode to split update events in puller.
 type PullerSplitUpdateMode int32
@@ -139,6 +149,11 @@ func newSourceManager(
 				zap.String("changefeed", mgr.changefeedID.ID))
 		}
 		if raw != nil {
+			// Block the puller if the sort engine runs out of disk quota,
+			// until some sorted events are cleaned.
+			if err := mgr.engine.WaitForDiskQuota(ctx); err != nil {
+				return errors.Trace(err)
+			}
 			if shouldSplitKVEntry(raw) {
 				deleteKVEntry, insertKVEntry, err := model.SplitUpdateKVEntry(raw)
 				if err != nil {
@@ -227,19 +242,74 @@ func (m *SourceManager) GetTablePullerStats(span tablepb.Span) puller.Stats {
 	return m.puller.Stats(span)
 }
 
+// GetTableSlowestRegions returns the slowest regions and the uncaptured
+// ranges of the table.
+func (m *SourceManager) GetTableSlowestRegions(
+	span tablepb.Span, limit int,
+) ([]regionlock.LockedRangeStatistic, []regionlock.UnLockRangeStatistic) {
+	return m.puller.SlowestRegions(span, limit)
+}
+
 // GetTableSorterStats returns the sorter stats of the table.
 func (m *SourceManager) GetTableSorterStats(span tablepb.Span) sorter.TableStats {
 	return m.engine.GetStatsByTable(span)
 }
 
+// GetSorterDiskUsage returns the disk usage of the sort engine.
+func (m *SourceManager) GetSorterDiskUsage() model.SorterDiskUsage {
+	return m.engine.GetDiskUsage()
+}
+
 // Run implements util.Runnable.
-func (m *SourceManager) Run(ctx context.Context, _ ...chan<- error) error {
+func (m *SourceManager) Run(ctx context.Context, warnings ...chan<- error) error {
 	close(m.ready)
 	// Only nil in unit tests.
 	if m.puller == nil {
 		return nil
 	}
-	return m.puller.Run(ctx)
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return m.puller.Run(ctx)
+	})
+	if len(warnings) > 0 {
+		eg.Go(func() error {
+			return m.checkDiskQuota(ctx, warnings[0])
+		})
+	}
+	return eg.Wait()
+}
+
+// checkDiskQuota reports a warning periodically if the disk quota of the
+// sort engine is exceeded, so that it's visible in the changefeed state.
+func (m *SourceManager) checkDiskQuota(ctx context.Context, warnings chan<- error) error {
+	ticker := time.NewTicker(diskQuotaCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+		}
+		usage := m.engine.GetDiskUsage()
+		if !usage.Exceeded {
+			continue
+		}
+		log.Warn("sorter disk quota is exceeded, pullers are blocked",
+			zap.String("namespace", m.changefeedID.Namespace),
+			zap.String("changefeed", m.changefeedID.ID),
+			zap.Uint64("usedBytes", usage.UsedBytes),
+			zap.Uint64("quotaBytes", usage.QuotaBytes),
+			zap.Uint64("captureUsedBytes", usage.CaptureUsedBytes),
+			zap.Uint64("captureQuotaBytes", usage.CaptureQuotaBytes))
+		err := cerror.ErrSorterDiskQuotaExceeded.GenWithStackByArgs(
+			fmt.Sprintf("changefeed used %d of %d bytes, capture used %d of %d bytes",
+				usage.UsedBytes, usage.QuotaBytes,
+				usage.CaptureUsedBytes, usage.CaptureQuotaBytes))
+		select {
+		case warnings <- err:
+		default:
+		}
+	}
 }
 
 // WaitForReady implements util.Runnable.
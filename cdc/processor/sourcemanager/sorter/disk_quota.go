@@ -0,0 +1,140 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sorter
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// DiskQuota traces the disk usage of sort engines. The quota of a changefeed
+// takes the quota of the capture as its parent, so the usage is charged to
+// both of them.
+type DiskQuota struct {
+	// totalBytes is the quota, 0 means no limit.
+	totalBytes uint64
+	usedBytes  atomic.Uint64
+	parent     *DiskQuota
+
+	// released is closed and renewed when some usage is released,
+	// to wake up the waiters. It's only used in the root quota.
+	mu       sync.Mutex
+	released chan struct{}
+}
+
+// NewDiskQuota creates a DiskQuota, parent can be nil.
+func NewDiskQuota(totalBytes uint64, parent *DiskQuota) *DiskQuota {
+	return &DiskQuota{
+		totalBytes: totalBytes,
+		parent:     parent,
+		released:   make(chan struct{}),
+	}
+}
+
+// Consume records nBytes are written into the disk.
+func (q *DiskQuota) Consume(nBytes uint64) {
+	if nBytes == 0 {
+		return
+	}
+	for c := q; c != nil; c = c.parent {
+		c.usedBytes.Add(nBytes)
+	}
+}
+
+// Release records nBytes are cleaned from the disk. At most the bytes held by
+// the quota are released, and only those are released from its parents.
+func (q *DiskQuota) Release(nBytes uint64) {
+	if nBytes == 0 {
+		return
+	}
+	for c := q; c != nil && nBytes > 0; c = c.parent {
+		nBytes = c.release(nBytes)
+	}
+	// The usage released from any quota is also released from the root,
+	// so it's enough to notify the waiters of the root.
+	root := q.root()
+	root.mu.Lock()
+	close(root.released)
+	root.released = make(chan struct{})
+	root.mu.Unlock()
+}
+
+// release releases at most nBytes from the quota itself and returns the bytes
+// actually released.
+func (q *DiskQuota) release(nBytes uint64) uint64 {
+	for {
+		used := q.usedBytes.Load()
+		released := nBytes
+		if used < released {
+			released = used
+		}
+		if q.usedBytes.CompareAndSwap(used, used-released) {
+			return released
+		}
+	}
+}
+
+// Exceeded returns true if the quota or any of its parents is exceeded.
+func (q *DiskQuota) Exceeded() bool {
+	for c := q; c != nil; c = c.parent {
+		if c.totalBytes > 0 && c.usedBytes.Load() >= c.totalBytes {
+			return true
+		}
+	}
+	return false
+}
+
+// Released returns a channel which is closed when some usage is released from
+// the quota or its parents. It should be called before checking Exceeded to
+// avoid missing the notification.
+func (q *DiskQuota) Released() <-chan struct{} {
+	root := q.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	return root.released
+}
+
+func (q *DiskQuota) root() *DiskQuota {
+	c := q
+	for c.parent != nil {
+		c = c.parent
+	}
+	return c
+}
+
+// UsedBytes returns the bytes used.
+func (q *DiskQuota) UsedBytes() uint64 {
+	return q.usedBytes.Load()
+}
+
+// TotalBytes returns the quota, 0 means no limit.
+func (q *DiskQuota) TotalBytes() uint64 {
+	return q.totalBytes
+}
+
+// Usage returns the disk usage of the quota and its parent.
+func (q *DiskQuota) Usage() model.SorterDiskUsage {
+	res := model.SorterDiskUsage{
+		UsedBytes:  q.UsedBytes(),
+		QuotaBytes: q.TotalBytes(),
+		Exceeded:   q.Exceeded(),
+	}
+	if q.parent != nil {
+		res.CaptureUsedBytes = q.parent.UsedBytes()
+		res.CaptureQuotaBytes = q.parent.TotalBytes()
+	}
+	return res
+}
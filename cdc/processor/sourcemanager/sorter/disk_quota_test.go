@@ -0,0 +1,63 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sorter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskQuota(t *testing.T) {
+	t.Parallel()
+
+	capture := NewDiskQuota(100, nil)
+	cf1 := NewDiskQuota(60, capture)
+	cf2 := NewDiskQuota(0, capture)
+
+	cf1.Consume(50)
+	require.False(t, cf1.Exceeded())
+	require.Equal(t, uint64(50), capture.UsedBytes())
+
+	// The quota of a changefeed is exceeded.
+	cf1.Consume(10)
+	require.True(t, cf1.Exceeded())
+	require.False(t, cf2.Exceeded())
+
+	// The quota of the capture is exceeded.
+	cf2.Consume(40)
+	require.True(t, cf2.Exceeded())
+	usage := cf2.Usage()
+	require.Equal(t, uint64(40), usage.UsedBytes)
+	require.Equal(t, uint64(0), usage.QuotaBytes)
+	require.Equal(t, uint64(100), usage.CaptureUsedBytes)
+	require.Equal(t, uint64(100), usage.CaptureQuotaBytes)
+	require.True(t, usage.Exceeded)
+
+	// Waiters are notified after some usage is released.
+	released := cf2.Released()
+	cf1.Release(30)
+	select {
+	case <-released:
+	default:
+		require.FailNow(t, "waiters should be notified")
+	}
+	require.False(t, cf1.Exceeded())
+	require.False(t, cf2.Exceeded())
+
+	// Release more than used.
+	cf2.Release(100)
+	require.Equal(t, uint64(0), cf2.UsedBytes())
+	require.Equal(t, uint64(30), capture.UsedBytes())
+}
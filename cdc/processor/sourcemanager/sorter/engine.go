@@ -14,6 +14,8 @@
 package sorter
 
 import (
+	"context"
+
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 )
@@ -71,6 +73,14 @@ type SortEngine interface {
 	// GetStatsByTable gets the statistics of the given table.
 	GetStatsByTable(span tablepb.Span) TableStats
 
+	// WaitForDiskQuota blocks until the disk usage is under the quota, it's
+	// used to apply back-pressure to the puller. It returns directly if the
+	// engine can't release any disk space by cleaning resolved events.
+	WaitForDiskQuota(ctx context.Context) error
+
+	// GetDiskUsage gets the disk usage of the engine.
+	GetDiskUsage() model.SorterDiskUsage
+
 	// Close closes the engine. All data written by this instance can be deleted.
 	//
 	// NOTE: it leads an undefined behavior to close an engine with active iterators.
@@ -168,4 +178,6 @@ func (p Position) IsCommitFence() bool {
 type TableStats struct {
 	ReceivedMaxCommitTs   model.Ts
 	ReceivedMaxResolvedTs model.Ts
+	// DiskUsage is the approximate bytes of events stored in the engine.
+	DiskUsage uint64
 }
@@ -14,6 +14,7 @@
 package factory
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"sync"
@@ -22,10 +23,13 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
 	epebble "github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter/pebble"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter/tiered"
 	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/atomic"
 	"go.uber.org/multierr"
 )
@@ -35,8 +39,11 @@ type sortEngineType int
 const (
 	// pebbleEngine details are in package document of pkg/sorter/pebble.
 	pebbleEngine sortEngineType = iota + 1
+	// tieredEngine details are in package document of pkg/sorter/tiered.
+	tieredEngine
 
 	metricsCollectInterval = 15 * time.Second
+	createStorageTimeout   = 30 * time.Second
 )
 
 var (
@@ -51,6 +58,9 @@ type SortEngineFactory struct {
 	engineType      sortEngineType
 	dir             string
 	memQuotaInBytes uint64
+	// diskQuota is the disk quota of all engines, and the parent of
+	// the disk quota of each engine.
+	diskQuota *sorter.DiskQuota
 
 	mu      sync.Mutex
 	engines map[model.ChangeFeedID]sorter.SortEngine
@@ -66,16 +76,24 @@ type SortEngineFactory struct {
 
 	// dbs is also readed in the background metrics collector.
 	dbInitialized *atomic.Bool
+
+	// Following fields are valid if engineType is tieredEngine, pebble
+	// engines are used as the local engines of tiered engines.
+	tieringConfig  *config.SorterTieringConfig
+	tieringStorage storage.ExternalStorage
 }
 
 // Create creates a SortEngine. If an engine with same ID already exists,
-// it will be returned directly.
-func (f *SortEngineFactory) Create(ID model.ChangeFeedID) (e sorter.SortEngine, err error) {
+// it will be returned directly. diskQuotaInBytes is the disk quota of the
+// engine, 0 means no limit.
+func (f *SortEngineFactory) Create(
+	ID model.ChangeFeedID, diskQuotaInBytes uint64,
+) (e sorter.SortEngine, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
 	switch f.engineType {
-	case pebbleEngine:
+	case pebbleEngine, tieredEngine:
 		exists := false
 		if e, exists = f.engines[ID]; exists {
 			return e, nil
@@ -87,7 +105,18 @@ func (f *SortEngineFactory) Create(ID model.ChangeFeedID) (e sorter.SortEngine,
 			}
 			f.dbInitialized.Store(true)
 		}
-		e = epebble.New(ID, f.dbs)
+		if f.engineType == tieredEngine && f.tieringStorage == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), createStorageTimeout)
+			defer cancel()
+			f.tieringStorage, err = util.GetExternalStorageFromURI(ctx, f.tieringConfig.StorageURI)
+			if err != nil {
+				return
+			}
+		}
+		e = epebble.NewWithDiskQuota(ID, f.dbs, sorter.NewDiskQuota(diskQuotaInBytes, f.diskQuota))
+		if f.engineType == tieredEngine {
+			e = tiered.New(ID, e, f.tieringStorage, f.tieringConfig)
+		}
 		f.engines[ID] = e
 	default:
 		log.Panic("not implemented")
@@ -105,6 +134,8 @@ func (f *SortEngineFactory) Drop(ID model.ChangeFeedID) error {
 		return nil
 	}
 	delete(f.engines, ID)
+	sorter.DiskQuotaGauge().DeleteLabelValues(ID.Namespace, ID.ID, "used")
+	sorter.DiskQuotaGauge().DeleteLabelValues(ID.Namespace, ID.ID, "total")
 	return engine.Close()
 }
 
@@ -114,12 +145,13 @@ func (f *SortEngineFactory) Close() (err error) {
 	defer factoryMu.Unlock()
 	factory = nil
 
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
+	// Stop the metrics collector before locking f.mu, it also locks f.mu.
 	close(f.closed)
 	f.wg.Wait()
 
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	for _, engine := range f.engines {
 		err = multierr.Append(err, engine.Close())
 	}
@@ -133,19 +165,46 @@ func (f *SortEngineFactory) Close() (err error) {
 }
 
 // NewForPebble will create a SortEngineFactory for the pebble implementation.
-func NewForPebble(dir string, memQuotaInBytes uint64, cfg *config.DBConfig) *SortEngineFactory {
+// diskQuotaInBytes is the disk quota of all engines, 0 means no limit.
+func NewForPebble(
+	dir string, memQuotaInBytes, diskQuotaInBytes uint64, cfg *config.DBConfig,
+) *SortEngineFactory {
 	factoryMu.Lock()
 	defer factoryMu.Unlock()
 	if factory == nil {
-		factory = &SortEngineFactory{
-			engineType:      pebbleEngine,
-			dir:             dir,
-			memQuotaInBytes: memQuotaInBytes,
-			engines:         make(map[model.ChangeFeedID]sorter.SortEngine),
-			closed:          make(chan struct{}),
-			pebbleConfig:    cfg,
-			dbInitialized:   atomic.NewBool(false),
-		}
+		factory = newForPebble(dir, memQuotaInBytes, diskQuotaInBytes, cfg)
+		factory.startMetricsCollector()
+	}
+	return factory
+}
+
+func newForPebble(
+	dir string, memQuotaInBytes, diskQuotaInBytes uint64, cfg *config.DBConfig,
+) *SortEngineFactory {
+	return &SortEngineFactory{
+		engineType:      pebbleEngine,
+		dir:             dir,
+		memQuotaInBytes: memQuotaInBytes,
+		diskQuota:       sorter.NewDiskQuota(diskQuotaInBytes, nil),
+		engines:         make(map[model.ChangeFeedID]sorter.SortEngine),
+		closed:          make(chan struct{}),
+		pebbleConfig:    cfg,
+		dbInitialized:   atomic.NewBool(false),
+	}
+}
+
+// NewForTiered will create a SortEngineFactory for the tiered implementation,
+// which moves cold events of pebble engines to the external storage.
+func NewForTiered(
+	dir string, memQuotaInBytes, diskQuotaInBytes uint64,
+	cfg *config.DBConfig, tieringCfg *config.SorterTieringConfig,
+) *SortEngineFactory {
+	factoryMu.Lock()
+	defer factoryMu.Unlock()
+	if factory == nil {
+		factory = newForPebble(dir, memQuotaInBytes, diskQuotaInBytes, cfg)
+		factory.engineType = tieredEngine
+		factory.tieringConfig = tieringCfg
 		factory.startMetricsCollector()
 	}
 	return factory
@@ -169,7 +228,17 @@ func (f *SortEngineFactory) startMetricsCollector() {
 }
 
 func (f *SortEngineFactory) collectMetrics() {
-	if f.engineType == pebbleEngine && f.dbInitialized.Load() {
+	f.mu.Lock()
+	for ID, engine := range f.engines {
+		usage := engine.GetDiskUsage()
+		sorter.DiskQuotaGauge().WithLabelValues(ID.Namespace, ID.ID, "used").Set(float64(usage.UsedBytes))
+		sorter.DiskQuotaGauge().WithLabelValues(ID.Namespace, ID.ID, "total").Set(float64(usage.QuotaBytes))
+	}
+	f.mu.Unlock()
+	sorter.DiskQuotaGauge().WithLabelValues("", "", "used").Set(float64(f.diskQuota.UsedBytes()))
+	sorter.DiskQuotaGauge().WithLabelValues("", "", "total").Set(float64(f.diskQuota.TotalBytes()))
+
+	if f.dbInitialized.Load() {
 		for i, db := range f.dbs {
 			stats := db.Metrics()
 			id := strconv.Itoa(i + 1)
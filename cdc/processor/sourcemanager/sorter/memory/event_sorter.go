@@ -135,6 +135,16 @@ func (s *EventSorter) GetStatsByTable(span tablepb.Span) sorter.TableStats {
 	return sorter.TableStats{}
 }
 
+// WaitForDiskQuota implements sorter.SortEngine.
+func (s *EventSorter) WaitForDiskQuota(_ context.Context) error {
+	return nil
+}
+
+// GetDiskUsage implements sorter.SortEngine.
+func (s *EventSorter) GetDiskUsage() model.SorterDiskUsage {
+	return model.SorterDiskUsage{}
+}
+
 // Close implements sorter.SortEngine.
 func (s *EventSorter) Close() error {
 	s.tables = spanz.SyncMap{}
@@ -74,6 +74,33 @@ var (
 		Help:      "The amount of pending data stored on-disk by the sorter",
 	}, []string{"id"})
 
+	// diskQuotaGauge is the metric that records the disk quota and usage
+	// of the sorter of each changefeed, and of all changefeeds on the capture
+	// if the labels of the changefeed are empty.
+	diskQuotaGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "disk_quota_bytes",
+		Help:      "The disk quota and usage of the sorter",
+	}, []string{"namespace", "changefeed", "type"})
+
+	// tieredBytesGauge is the metric that records the bytes of events moved
+	// to the external storage by the tiered sort engine.
+	tieredBytesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "tiered_bytes",
+		Help:      "The bytes of events stored in the external storage by the sorter",
+	}, []string{"namespace", "changefeed"})
+
+	tieredRunDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "sorter",
+		Name:      "tiered_run_duration_seconds",
+		Help:      "Bucketed histogram of writing or reading a run of the external storage",
+		Buckets:   prometheus.ExponentialBuckets(0.004, 2.0, 20),
+	}, []string{"namespace", "changefeed", "type"})
+
 	dbIteratorGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "ticdc",
 		Subsystem: "db",
@@ -142,6 +169,21 @@ func OnDiskDataSize() *prometheus.GaugeVec {
 	return onDiskDataSizeGauge
 }
 
+// DiskQuotaGauge returns diskQuotaGauge.
+func DiskQuotaGauge() *prometheus.GaugeVec {
+	return diskQuotaGauge
+}
+
+// TieredBytesGauge returns tieredBytesGauge.
+func TieredBytesGauge() *prometheus.GaugeVec {
+	return tieredBytesGauge
+}
+
+// TieredRunDuration returns tieredRunDurationHistogram.
+func TieredRunDuration() *prometheus.HistogramVec {
+	return tieredRunDurationHistogram
+}
+
 // IteratorGauge returns dbIteratorGauge.
 func IteratorGauge() *prometheus.GaugeVec {
 	return dbIteratorGauge
@@ -176,6 +218,9 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(sorterIterReadDurationHistogram)
 	registry.MustRegister(inMemoryDataSizeGauge)
 	registry.MustRegister(onDiskDataSizeGauge)
+	registry.MustRegister(diskQuotaGauge)
+	registry.MustRegister(tieredBytesGauge)
+	registry.MustRegister(tieredRunDurationHistogram)
 	registry.MustRegister(dbIteratorGauge)
 
 	// TODO: Seems these things belong to pebble instead of engine.
@@ -5,6 +5,7 @@
 package mock_sorter
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -135,6 +136,20 @@ func (mr *MockSortEngineMockRecorder) FetchByTable(span, lowerBound, upperBound
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchByTable", reflect.TypeOf((*MockSortEngine)(nil).FetchByTable), span, lowerBound, upperBound)
 }
 
+// GetDiskUsage mocks base method.
+func (m *MockSortEngine) GetDiskUsage() model.SorterDiskUsage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiskUsage")
+	ret0, _ := ret[0].(model.SorterDiskUsage)
+	return ret0
+}
+
+// GetDiskUsage indicates an expected call of GetDiskUsage.
+func (mr *MockSortEngineMockRecorder) GetDiskUsage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiskUsage", reflect.TypeOf((*MockSortEngine)(nil).GetDiskUsage))
+}
+
 // GetStatsByTable mocks base method.
 func (m *MockSortEngine) GetStatsByTable(span tablepb.Span) sorter.TableStats {
 	m.ctrl.T.Helper()
@@ -202,6 +217,20 @@ func (mr *MockSortEngineMockRecorder) SlotsAndHasher() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SlotsAndHasher", reflect.TypeOf((*MockSortEngine)(nil).SlotsAndHasher))
 }
 
+// WaitForDiskQuota mocks base method.
+func (m *MockSortEngine) WaitForDiskQuota(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForDiskQuota", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForDiskQuota indicates an expected call of WaitForDiskQuota.
+func (mr *MockSortEngineMockRecorder) WaitForDiskQuota(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForDiskQuota", reflect.TypeOf((*MockSortEngine)(nil).WaitForDiskQuota), ctx)
+}
+
 // MockEventIterator is a mock of EventIterator interface.
 type MockEventIterator struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,122 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"sync"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
+)
+
+// tableDiskUsage traces the approximate bytes of events of a table stored
+// in pebble. Events are grouped by the resolved ts received after them, a
+// group can be released after all events in it are cleaned.
+type tableDiskUsage struct {
+	mu sync.Mutex
+	// groups[:len(groups)-1] are sealed by resolved events, the last one is
+	// still receiving events if unsealed is true.
+	groups   []diskUsageGroup
+	unsealed bool
+	total    uint64
+	// released is set after all usage is released, events added after it
+	// are not traced, e.g. the events added while the table is being removed.
+	released bool
+}
+
+type diskUsageGroup struct {
+	// maxCommitTs is the max commit ts of events in the group.
+	maxCommitTs model.Ts
+	bytes       uint64
+}
+
+// consume records an event is added, and returns the bytes of it.
+func (u *tableDiskUsage) consume(event *model.PolymorphicEvent) uint64 {
+	nBytes := uint64(event.RawKV.ApproximateDataSize())
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.released {
+		return 0
+	}
+	if !u.unsealed {
+		u.groups = append(u.groups, diskUsageGroup{})
+		u.unsealed = true
+	}
+	last := &u.groups[len(u.groups)-1]
+	last.bytes += nBytes
+	if event.CRTs > last.maxCommitTs {
+		last.maxCommitTs = event.CRTs
+	}
+	u.total += nBytes
+	return nBytes
+}
+
+// seal seals the events received before a resolved event, it returns the
+// bytes sealed.
+func (u *tableDiskUsage) seal() uint64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.unsealed {
+		return 0
+	}
+	u.unsealed = false
+	return u.groups[len(u.groups)-1].bytes
+}
+
+// release releases the sealed groups which are cleaned, it returns the bytes
+// released.
+func (u *tableDiskUsage) release(upperBound sorter.Position) uint64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	sealed := len(u.groups)
+	if u.unsealed {
+		sealed--
+	}
+	i, nBytes := 0, uint64(0)
+	for ; i < sealed; i++ {
+		// Events with the same commit ts of a non-fence upper bound may be
+		// not cleaned, so the group is kept.
+		ts := u.groups[i].maxCommitTs
+		if ts > upperBound.CommitTs || (ts == upperBound.CommitTs && !upperBound.IsCommitFence()) {
+			break
+		}
+		nBytes += u.groups[i].bytes
+	}
+	u.groups = u.groups[i:]
+	u.total -= nBytes
+	return nBytes
+}
+
+// releaseAll releases all groups, it returns the bytes released and the
+// bytes of them which are sealed.
+func (u *tableDiskUsage) releaseAll() (total uint64, sealed uint64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	total, sealed = u.total, u.total
+	if u.unsealed {
+		sealed -= u.groups[len(u.groups)-1].bytes
+	}
+	u.groups = nil
+	u.unsealed = false
+	u.total = 0
+	u.released = true
+	return
+}
+
+// bytes returns the bytes of events stored.
+func (u *tableDiskUsage) bytes() uint64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.total
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableDiskUsage(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func(commitTs model.Ts) *model.PolymorphicEvent {
+		return model.NewPolymorphicEvent(&model.RawKVEntry{
+			OpType:  model.OpTypePut,
+			Key:     []byte{1},
+			Value:   []byte{2},
+			StartTs: commitTs - 1,
+			CRTs:    commitTs,
+		})
+	}
+
+	u := &tableDiskUsage{}
+	n := u.consume(newEvent(2))
+	require.NotZero(t, n)
+	u.consume(newEvent(3))
+	require.Equal(t, 2*n, u.seal())
+	require.Zero(t, u.seal())
+	u.consume(newEvent(5))
+	require.Equal(t, n, u.seal())
+	u.consume(newEvent(6))
+	require.Equal(t, 4*n, u.bytes())
+
+	// The group can't be released before all events in it are cleaned.
+	require.Zero(t, u.release(sorter.Position{StartTs: 1, CommitTs: 2}))
+	require.Zero(t, u.release(sorter.Position{StartTs: 1, CommitTs: 3}))
+	require.Equal(t, 2*n, u.release(sorter.Position{StartTs: 2, CommitTs: 3}))
+	// The unsealed group is never released.
+	require.Equal(t, n, u.release(sorter.Position{StartTs: 9, CommitTs: 10}))
+	require.Equal(t, n, u.bytes())
+
+	total, sealed := u.releaseAll()
+	require.Equal(t, n, total)
+	require.Zero(t, sealed)
+	// Events added after the table is released are not traced.
+	require.Zero(t, u.consume(newEvent(7)))
+	require.Zero(t, u.bytes())
+}
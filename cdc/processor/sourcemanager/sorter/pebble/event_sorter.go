@@ -14,6 +14,7 @@
 package pebble
 
 import (
+	"context"
 	"math"
 	"strconv"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
@@ -46,6 +48,11 @@ type EventSorter struct {
 	dbs          []*pebble.DB
 	channs       []*chann.DrainableChann[eventWithTableID]
 	serde        encoding.MsgPackGenSerde
+	diskQuota    *sorter.DiskQuota
+
+	// sealedBytes is the bytes of events which are followed by resolved
+	// events, they can be released after being fetched and cleaned.
+	sealedBytes atomic.Uint64
 
 	// To manage background goroutines.
 	wg     sync.WaitGroup
@@ -68,8 +75,14 @@ type EventIter struct {
 	nextDuration prometheus.Observer
 }
 
-// New creates an EventSorter instance.
+// New creates an EventSorter instance without disk quota.
 func New(ID model.ChangeFeedID, dbs []*pebble.DB) *EventSorter {
+	return NewWithDiskQuota(ID, dbs, sorter.NewDiskQuota(0, nil))
+}
+
+// NewWithDiskQuota creates an EventSorter instance, the bytes of events
+// stored are charged to the given disk quota.
+func NewWithDiskQuota(ID model.ChangeFeedID, dbs []*pebble.DB, diskQuota *sorter.DiskQuota) *EventSorter {
 	channs := make([]*chann.DrainableChann[eventWithTableID], 0, len(dbs))
 	for i := 0; i < len(dbs); i++ {
 		channs = append(channs, chann.NewAutoDrainChann[eventWithTableID](chann.Cap(128)))
@@ -79,6 +92,7 @@ func New(ID model.ChangeFeedID, dbs []*pebble.DB) *EventSorter {
 		changefeedID: ID,
 		dbs:          dbs,
 		channs:       channs,
+		diskQuota:    diskQuota,
 		closed:       make(chan struct{}),
 		tables:       spanz.NewHashMap[*tableState](),
 	}
@@ -122,7 +136,8 @@ func (s *EventSorter) AddTable(span tablepb.Span, startTs model.Ts) {
 // RemoveTable implements sorter.SortEngine.
 func (s *EventSorter) RemoveTable(span tablepb.Span) {
 	s.mu.Lock()
-	if _, exists := s.tables.Get(span); !exists {
+	state, exists := s.tables.Get(span)
+	if !exists {
 		s.mu.Unlock()
 		log.Warn("remove an unexist table",
 			zap.String("namespace", s.changefeedID.Namespace),
@@ -132,6 +147,7 @@ func (s *EventSorter) RemoveTable(span tablepb.Span) {
 	}
 	s.tables.Delete(span)
 	s.mu.Unlock()
+	s.releaseTableDiskUsage(state)
 }
 
 // Add implements sorter.SortEngine.
@@ -157,11 +173,13 @@ func (s *EventSorter) Add(span tablepb.Span, events ...*model.PolymorphicEvent)
 				maxResolvedTs = event.CRTs
 				state.maxReceivedResolvedTs.Store(maxResolvedTs)
 			}
+			s.sealedBytes.Add(state.diskUsage.seal())
 		} else {
 			if event.CRTs > maxCommitTs {
 				maxCommitTs = event.CRTs
 				state.maxReceivedCommitTs.Store(maxCommitTs)
 			}
+			s.diskQuota.Consume(state.diskUsage.consume(event))
 		}
 		state.ch.In() <- eventWithTableID{uniqueID: state.uniqueID, span: span, event: event}
 	}
@@ -230,7 +248,13 @@ func (s *EventSorter) CleanByTable(span tablepb.Span, upperBound sorter.Position
 		return nil
 	}
 
-	return s.cleanTable(state, span, upperBound)
+	if err := s.cleanTable(state, span, upperBound); err != nil {
+		return err
+	}
+	nBytes := state.diskUsage.release(upperBound)
+	s.sealedBytes.Add(^(nBytes - 1))
+	s.diskQuota.Release(nBytes)
+	return nil
 }
 
 // CleanAllTables implements sorter.EventSortEngine.
@@ -266,9 +290,37 @@ func (s *EventSorter) GetStatsByTable(span tablepb.Span) sorter.TableStats {
 	return sorter.TableStats{
 		ReceivedMaxCommitTs:   maxCommitTs,
 		ReceivedMaxResolvedTs: maxResolvedTs,
+		DiskUsage:             state.diskUsage.bytes(),
+	}
+}
+
+// WaitForDiskQuota implements sorter.SortEngine.
+func (s *EventSorter) WaitForDiskQuota(ctx context.Context) error {
+	for {
+		// Get the channel before checking the quota to avoid missing
+		// the notification.
+		released := s.diskQuota.Released()
+		// Blocking the puller can't release any disk space if there are no
+		// resolved events to be fetched and cleaned, e.g. the events of a
+		// huge transaction are being received.
+		if !s.diskQuota.Exceeded() || s.sealedBytes.Load() == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-s.closed:
+			return nil
+		case <-released:
+		}
 	}
 }
 
+// GetDiskUsage implements sorter.SortEngine.
+func (s *EventSorter) GetDiskUsage() model.SorterDiskUsage {
+	return s.diskQuota.Usage()
+}
+
 // Close implements sorter.SortEngine.
 func (s *EventSorter) Close() error {
 	s.mu.Lock()
@@ -296,11 +348,19 @@ func (s *EventSorter) Close() error {
 			err = err1
 			return false
 		}
+		s.releaseTableDiskUsage(state)
 		return true
 	})
 	return err
 }
 
+// releaseTableDiskUsage releases all disk usage of a table from the quota.
+func (s *EventSorter) releaseTableDiskUsage(state *tableState) {
+	total, sealed := state.diskUsage.releaseAll()
+	s.sealedBytes.Add(^(sealed - 1))
+	s.diskQuota.Release(total)
+}
+
 // SlotsAndHasher implements sorter.SortEngine.
 func (s *EventSorter) SlotsAndHasher() (slotCount int, hasher func(tablepb.Span, int) int) {
 	return len(s.dbs), spanz.HashTableSpan
@@ -363,6 +423,7 @@ type tableState struct {
 	// For statistics.
 	maxReceivedCommitTs   atomic.Uint64
 	maxReceivedResolvedTs atomic.Uint64
+	diskUsage             tableDiskUsage
 
 	// Following fields are protected by mu.
 	mu      sync.RWMutex
@@ -14,6 +14,7 @@
 package pebble
 
 import (
+	"context"
 	"path/filepath"
 	"sort"
 	"testing"
@@ -182,3 +183,47 @@ func TestCleanData(t *testing.T) {
 	require.NoError(t, s.CleanByTable(spanz.TableIDToComparableSpan(2), sorter.Position{}))
 	require.Nil(t, s.CleanByTable(span, sorter.Position{}))
 }
+
+func TestDiskQuota(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil, nil)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+	s := NewWithDiskQuota(cf, []*pebble.DB{db}, sorter.NewDiskQuota(1, nil))
+	defer s.Close()
+
+	span := spanz.TableIDToComparableSpan(1)
+	s.AddTable(span, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	s.Add(span, model.NewPolymorphicEvent(&model.RawKVEntry{
+		OpType:  model.OpTypePut,
+		Key:     []byte{1},
+		StartTs: 1,
+		CRTs:    2,
+	}))
+	require.NotZero(t, s.GetStatsByTable(span).DiskUsage)
+	require.True(t, s.GetDiskUsage().Exceeded)
+	// Events can't be cleaned before they are resolved, so the puller
+	// mustn't be blocked.
+	require.NoError(t, s.WaitForDiskQuota(ctx))
+
+	s.Add(span, model.NewResolvedPolymorphicEvent(0, 2))
+	require.ErrorIs(t, s.WaitForDiskQuota(ctx), context.DeadlineExceeded)
+
+	// The puller is unblocked after resolved events are cleaned.
+	done := make(chan error, 1)
+	go func() { done <- s.WaitForDiskQuota(context.Background()) }()
+	require.NoError(t, s.CleanByTable(span, sorter.Position{StartTs: 1, CommitTs: 2}))
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "the puller should be unblocked")
+	}
+	require.Zero(t, s.GetStatsByTable(span).DiskUsage)
+	require.False(t, s.GetDiskUsage().Exceeded)
+}
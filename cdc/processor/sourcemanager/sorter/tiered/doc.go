@@ -0,0 +1,23 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tiered is a SortEngine implementation which moves cold sorted events
+// to an external storage with such properties:
+//  1. events are received and sorted by a local SortEngine, e.g. pebble;
+//  2. resolved events of a table which lag behind the resolved ts of the
+//     table long enough are written into the external storage as runs, and
+//     then cleaned from the local engine;
+//  3. runs of a table are continuous, and each run ends at a transaction
+//     boundary, so FetchByTable can chain runs and the local engine without
+//     changing the semantics of Position.
+package tiered
@@ -0,0 +1,536 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiered
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+var (
+	_ sorter.SortEngine    = (*EventSorter)(nil)
+	_ sorter.EventIterator = (*EventIter)(nil)
+)
+
+const (
+	tieringInterval = 10 * time.Second
+	// deleteRunsTimeout is the timeout of deleting runs when the engine is closed.
+	deleteRunsTimeout = 30 * time.Second
+)
+
+// EventSorter is a sort engine which moves cold sorted events of a local
+// sort engine to an external storage.
+type EventSorter struct {
+	// Read-only fields.
+	changefeedID model.ChangeFeedID
+	local        sorter.SortEngine
+	storage      storage.ExternalStorage
+	// prefix is the directory of runs of the engine in the storage.
+	prefix         string
+	minLag         time.Duration
+	tableThreshold uint64
+	runSize        int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	closed chan struct{}
+
+	tieredBytes      atomic.Int64
+	tieredBytesGauge prometheus.Gauge
+	writeDuration    prometheus.Observer
+	readDuration     prometheus.Observer
+
+	// garbage are runs which are cleaned and need to be deleted from the
+	// storage. It's protected by garbageMu.
+	garbageMu sync.Mutex
+	garbage   []run
+
+	// Following fields are protected by mu.
+	mu       sync.RWMutex
+	isClosed bool
+	tables   *spanz.HashMap[*tableState]
+}
+
+type tableState struct {
+	uniqueID uint64
+	// sortedResolved is the resolved ts of events sorted by the local engine.
+	sortedResolved atomic.Uint64
+
+	// tierMu is held while the table is being tiered, fields protected by
+	// it are only accessed by the tiering goroutine and RemoveTable.
+	tierMu  sync.Mutex
+	removed bool
+	nextSeq uint64
+
+	// Following fields are protected by mu.
+	mu sync.Mutex
+	// runs are continuous, runs[i] contains events in
+	// (runs[i-1].upper, runs[i].upper].
+	runs []run
+	// tiered is the upper bound of the last run.
+	tiered  sorter.Position
+	cleaned sorter.Position
+}
+
+// EventIter implements sorter.EventIterator.
+type EventIter struct {
+	engine     *EventSorter
+	runs       []run
+	lowerBound sorter.Position
+	upperBound sorter.Position
+	// events are the remaining events of the current run.
+	events []*model.PolymorphicEvent
+	// local is the iterator of the local engine after all runs.
+	local sorter.EventIterator
+}
+
+// New creates an EventSorter instance. Events are received and sorted by
+// local, and cold events are moved to the given storage. The EventSorter
+// takes the ownership of local.
+func New(
+	ID model.ChangeFeedID, local sorter.SortEngine,
+	storage storage.ExternalStorage, cfg *config.SorterTieringConfig,
+) *EventSorter {
+	ctx, cancel := context.WithCancel(context.Background())
+	runDuration := sorter.TieredRunDuration()
+	s := &EventSorter{
+		changefeedID:   ID,
+		local:          local,
+		storage:        storage,
+		prefix:         fmt.Sprintf("%s/%s/%s", ID.Namespace, ID.ID, uuid.New().String()),
+		minLag:         time.Duration(cfg.MinLag),
+		tableThreshold: cfg.TableThresholdInMB * (1 << 20),
+		runSize:        int(cfg.RunSizeInMB * (1 << 20)),
+		ctx:            ctx,
+		cancel:         cancel,
+		closed:         make(chan struct{}),
+		tables:         spanz.NewHashMap[*tableState](),
+
+		tieredBytesGauge: sorter.TieredBytesGauge().WithLabelValues(ID.Namespace, ID.ID),
+		writeDuration:    runDuration.WithLabelValues(ID.Namespace, ID.ID, "write"),
+		readDuration:     runDuration.WithLabelValues(ID.Namespace, ID.ID, "read"),
+	}
+	local.OnResolve(func(span tablepb.Span, ts model.Ts) {
+		s.mu.RLock()
+		state, exists := s.tables.Get(span)
+		s.mu.RUnlock()
+		if exists {
+			state.sortedResolved.Store(ts)
+		}
+	})
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.tierTables()
+	}()
+	return s
+}
+
+// IsTableBased implements sorter.SortEngine.
+func (s *EventSorter) IsTableBased() bool {
+	return true
+}
+
+// AddTable implements sorter.SortEngine.
+func (s *EventSorter) AddTable(span tablepb.Span, startTs model.Ts) {
+	s.mu.Lock()
+	if _, exists := s.tables.Get(span); !exists {
+		s.tables.ReplaceOrInsert(span, &tableState{uniqueID: genUniqueID()})
+	}
+	s.mu.Unlock()
+	s.local.AddTable(span, startTs)
+}
+
+// RemoveTable implements sorter.SortEngine.
+func (s *EventSorter) RemoveTable(span tablepb.Span) {
+	s.mu.Lock()
+	state, exists := s.tables.Get(span)
+	s.tables.Delete(span)
+	s.mu.Unlock()
+
+	if exists {
+		// Wait for the table being tiered, the local engine can't be
+		// accessed after the table is removed.
+		state.tierMu.Lock()
+		state.removed = true
+		state.tierMu.Unlock()
+
+		state.mu.Lock()
+		runs := state.runs
+		state.runs = nil
+		state.mu.Unlock()
+		s.collectGarbage(runs)
+	}
+	s.local.RemoveTable(span)
+}
+
+// Add implements sorter.SortEngine.
+func (s *EventSorter) Add(span tablepb.Span, events ...*model.PolymorphicEvent) {
+	s.local.Add(span, events...)
+}
+
+// OnResolve implements sorter.SortEngine.
+func (s *EventSorter) OnResolve(action func(tablepb.Span, model.Ts)) {
+	s.local.OnResolve(action)
+}
+
+// FetchByTable implements sorter.SortEngine.
+func (s *EventSorter) FetchByTable(span tablepb.Span, lowerBound, upperBound sorter.Position) sorter.EventIterator {
+	s.mu.RLock()
+	state, exists := s.tables.Get(span)
+	s.mu.RUnlock()
+	if !exists {
+		return s.local.FetchByTable(span, lowerBound, upperBound)
+	}
+
+	iter := &EventIter{engine: s, lowerBound: lowerBound, upperBound: upperBound}
+	// Hold the lock until the local iterator is created, so events moved to
+	// the storage concurrently must be either in runs or in the local iterator.
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	for _, r := range state.runs {
+		if r.upper.Compare(lowerBound) >= 0 && r.lower.Compare(upperBound) <= 0 {
+			iter.runs = append(iter.runs, r)
+		}
+	}
+	localLower := lowerBound
+	if state.tiered.Valid() && state.tiered.Compare(lowerBound) >= 0 {
+		localLower = state.tiered.Next()
+	}
+	if localLower.Compare(upperBound) <= 0 {
+		iter.local = s.local.FetchByTable(span, localLower, upperBound)
+	}
+	return iter
+}
+
+// FetchAllTables implements sorter.SortEngine.
+func (s *EventSorter) FetchAllTables(lowerBound sorter.Position) sorter.EventIterator {
+	log.Panic("FetchAllTables should never be called",
+		zap.String("namespace", s.changefeedID.Namespace),
+		zap.String("changefeed", s.changefeedID.ID))
+	return nil
+}
+
+// CleanByTable implements sorter.SortEngine.
+func (s *EventSorter) CleanByTable(span tablepb.Span, upperBound sorter.Position) error {
+	if err := s.local.CleanByTable(span, upperBound); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	state, exists := s.tables.Get(span)
+	s.mu.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	state.mu.Lock()
+	if upperBound.Compare(state.cleaned) > 0 {
+		state.cleaned = upperBound
+	}
+	i := 0
+	for i < len(state.runs) && state.runs[i].upper.Compare(upperBound) <= 0 {
+		i++
+	}
+	cleaned := state.runs[:i]
+	state.runs = state.runs[i:]
+	state.mu.Unlock()
+
+	s.collectGarbage(cleaned)
+	return nil
+}
+
+// CleanAllTables implements sorter.SortEngine.
+func (s *EventSorter) CleanAllTables(upperBound sorter.Position) error {
+	log.Panic("CleanAllTables should never be called",
+		zap.String("namespace", s.changefeedID.Namespace),
+		zap.String("changefeed", s.changefeedID.ID))
+	return nil
+}
+
+// GetStatsByTable implements sorter.SortEngine.
+func (s *EventSorter) GetStatsByTable(span tablepb.Span) sorter.TableStats {
+	return s.local.GetStatsByTable(span)
+}
+
+// WaitForDiskQuota implements sorter.SortEngine.
+func (s *EventSorter) WaitForDiskQuota(ctx context.Context) error {
+	return s.local.WaitForDiskQuota(ctx)
+}
+
+// GetDiskUsage implements sorter.SortEngine.
+func (s *EventSorter) GetDiskUsage() model.SorterDiskUsage {
+	return s.local.GetDiskUsage()
+}
+
+// Close implements sorter.SortEngine.
+func (s *EventSorter) Close() error {
+	s.mu.Lock()
+	if s.isClosed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isClosed = true
+	s.mu.Unlock()
+
+	close(s.closed)
+	s.cancel()
+	s.wg.Wait()
+
+	s.mu.RLock()
+	s.tables.Range(func(_ tablepb.Span, state *tableState) bool {
+		state.mu.Lock()
+		s.collectGarbage(state.runs)
+		state.runs = nil
+		state.mu.Unlock()
+		return true
+	})
+	s.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), deleteRunsTimeout)
+	defer cancel()
+	s.deleteGarbage(ctx)
+	sorter.TieredBytesGauge().DeleteLabelValues(s.changefeedID.Namespace, s.changefeedID.ID)
+	return s.local.Close()
+}
+
+// SlotsAndHasher implements sorter.SortEngine.
+func (s *EventSorter) SlotsAndHasher() (slotCount int, hasher func(tablepb.Span, int) int) {
+	return s.local.SlotsAndHasher()
+}
+
+// tierTables moves cold events of tables to the storage periodically, and
+// deletes runs which are cleaned.
+func (s *EventSorter) tierTables() {
+	ticker := time.NewTicker(tieringInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+		}
+
+		spans := make([]tablepb.Span, 0)
+		states := make([]*tableState, 0)
+		s.mu.RLock()
+		s.tables.Range(func(span tablepb.Span, state *tableState) bool {
+			spans = append(spans, span)
+			states = append(states, state)
+			return true
+		})
+		s.mu.RUnlock()
+
+		for i := range spans {
+			if err := s.tierTable(spans[i], states[i]); err != nil {
+				if errors.Cause(err) == context.Canceled {
+					return
+				}
+				// Events are kept in the local engine, retry in the next round.
+				log.Warn("failed to move sorted events to the external storage",
+					zap.String("namespace", s.changefeedID.Namespace),
+					zap.String("changefeed", s.changefeedID.ID),
+					zap.Stringer("span", &spans[i]),
+					zap.Error(err))
+			}
+		}
+		s.deleteGarbage(s.ctx)
+	}
+}
+
+// tierTable moves sorted events of the table which lag behind the resolved
+// ts more than minLag to the storage, if the table holds too many events in
+// the local engine.
+func (s *EventSorter) tierTable(span tablepb.Span, state *tableState) error {
+	state.tierMu.Lock()
+	defer state.tierMu.Unlock()
+	if state.removed || s.local.GetStatsByTable(span).DiskUsage < s.tableThreshold {
+		return nil
+	}
+
+	physical := oracle.ExtractPhysical(state.sortedResolved.Load()) - s.minLag.Milliseconds()
+	if physical <= 0 {
+		return nil
+	}
+	upperBound := sorter.GenCommitFence(oracle.ComposeTS(physical, 0))
+	state.mu.Lock()
+	lowerBound := sorter.Position{}
+	if state.tiered.Valid() {
+		lowerBound = state.tiered.Next()
+	}
+	if state.cleaned.Valid() && state.cleaned.Compare(lowerBound) >= 0 {
+		lowerBound = state.cleaned.Next()
+	}
+	state.mu.Unlock()
+	if lowerBound.Compare(upperBound) > 0 {
+		return nil
+	}
+
+	iter := s.local.FetchByTable(span, lowerBound, upperBound)
+	defer iter.Close()
+	builder := &runBuilder{}
+	for {
+		event, txnFinished, err := iter.Next()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if event == nil {
+			break
+		}
+		if err := builder.add(event); err != nil {
+			return err
+		}
+		// A run always ends at a transaction boundary. Events less than
+		// a run are kept in the local engine until more events come.
+		if txnFinished.Valid() && builder.size() >= s.runSize {
+			if err := s.flushRun(span, state, builder); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-s.closed:
+			return errors.Trace(context.Canceled)
+		default:
+		}
+	}
+	return nil
+}
+
+// flushRun writes the run into the storage, and then cleans events of it
+// from the local engine.
+func (s *EventSorter) flushRun(span tablepb.Span, state *tableState, builder *runBuilder) error {
+	r := builder.run
+	r.path = runPath(s.prefix, span.TableID, state.uniqueID, state.nextSeq)
+	start := time.Now()
+	if err := s.storage.WriteFile(s.ctx, r.path, builder.buf); err != nil {
+		return errors.Trace(err)
+	}
+	s.writeDuration.Observe(time.Since(start).Seconds())
+	state.nextSeq++
+	builder.reset()
+	s.tieredBytesGauge.Set(float64(s.tieredBytes.Add(int64(r.bytes))))
+
+	state.mu.Lock()
+	state.tiered = r.upper
+	cleaned := state.cleaned.Compare(r.upper) >= 0
+	if !cleaned {
+		state.runs = append(state.runs, r)
+	}
+	state.mu.Unlock()
+	if cleaned {
+		// The events are committed while they are being moved.
+		s.collectGarbage([]run{r})
+	}
+	log.Debug("sorted events are moved to the external storage",
+		zap.String("namespace", s.changefeedID.Namespace),
+		zap.String("changefeed", s.changefeedID.ID),
+		zap.Stringer("span", &span),
+		zap.String("path", r.path),
+		zap.Int("events", r.events),
+		zap.Int("bytes", r.bytes))
+	return s.local.CleanByTable(span, r.upper)
+}
+
+func (s *EventSorter) collectGarbage(runs []run) {
+	if len(runs) == 0 {
+		return
+	}
+	s.garbageMu.Lock()
+	s.garbage = append(s.garbage, runs...)
+	s.garbageMu.Unlock()
+}
+
+// deleteGarbage deletes cleaned runs from the storage, runs failed to
+// be deleted are retried in the next round.
+func (s *EventSorter) deleteGarbage(ctx context.Context) {
+	s.garbageMu.Lock()
+	garbage := s.garbage
+	s.garbage = nil
+	s.garbageMu.Unlock()
+
+	for i, r := range garbage {
+		if err := s.storage.DeleteFile(ctx, r.path); err != nil {
+			log.Warn("failed to delete the run from the external storage",
+				zap.String("namespace", s.changefeedID.Namespace),
+				zap.String("changefeed", s.changefeedID.ID),
+				zap.String("path", r.path),
+				zap.Error(err))
+			s.collectGarbage(garbage[i:])
+			return
+		}
+		s.tieredBytesGauge.Set(float64(s.tieredBytes.Add(-int64(r.bytes))))
+	}
+}
+
+// Next implements sorter.EventIterator.
+func (s *EventIter) Next() (event *model.PolymorphicEvent, txnFinished sorter.Position, err error) {
+	for len(s.events) == 0 && len(s.runs) > 0 {
+		start := time.Now()
+		var data []byte
+		if data, err = s.engine.storage.ReadFile(s.engine.ctx, s.runs[0].path); err != nil {
+			err = errors.Trace(err)
+			return
+		}
+		s.engine.readDuration.Observe(time.Since(start).Seconds())
+		if s.events, err = decodeRun(data, s.lowerBound, s.upperBound); err != nil {
+			return
+		}
+		s.runs = s.runs[1:]
+	}
+	if len(s.events) > 0 {
+		event = s.events[0]
+		s.events = s.events[1:]
+		// A run always ends at a transaction boundary.
+		if len(s.events) == 0 || s.events[0].CRTs != event.CRTs || s.events[0].StartTs != event.StartTs {
+			txnFinished = sorter.Position{StartTs: event.StartTs, CommitTs: event.CRTs}
+		}
+		return
+	}
+	if s.local != nil {
+		return s.local.Next()
+	}
+	return
+}
+
+// Close implements sorter.EventIterator.
+func (s *EventIter) Close() error {
+	if s.local != nil {
+		return s.local.Close()
+	}
+	return nil
+}
+
+var uniqueIDGen atomic.Uint64
+
+func genUniqueID() uint64 {
+	return uniqueIDGen.Add(1)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiered
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
+	epebble "github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter/pebble"
+	"github.com/pingcap/tiflow/cdc/processor/tablepb"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+type fetchedEvent struct {
+	event       *model.PolymorphicEvent
+	txnFinished sorter.Position
+}
+
+func fetchAll(t *testing.T, iter sorter.EventIterator) []fetchedEvent {
+	defer func() { require.NoError(t, iter.Close()) }()
+	var res []fetchedEvent
+	for {
+		event, txnFinished, err := iter.Next()
+		require.NoError(t, err)
+		if event == nil {
+			return res
+		}
+		res = append(res, fetchedEvent{event: event, txnFinished: txnFinished})
+	}
+}
+
+// expectedInRange returns events in [lowerBound, upperBound], the last event
+// is always a transaction boundary just like the local engine.
+func expectedInRange(all []fetchedEvent, lowerBound, upperBound sorter.Position) []fetchedEvent {
+	var res []fetchedEvent
+	for _, e := range all {
+		pos := sorter.Position{StartTs: e.event.StartTs, CommitTs: e.event.CRTs}
+		if pos.Compare(lowerBound) >= 0 && pos.Compare(upperBound) <= 0 {
+			res = append(res, e)
+		}
+	}
+	if len(res) > 0 {
+		last := res[len(res)-1].event
+		res[len(res)-1].txnFinished = sorter.Position{StartTs: last.StartTs, CommitTs: last.CRTs}
+	}
+	return res
+}
+
+func TestTieredEventSorter(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), t.Name())
+	db, err := epebble.OpenPebble(1, dbPath, &config.DBConfig{Count: 1}, nil, nil)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	extStorage, _, err := util.GetTestExtStorage(ctx, t.TempDir())
+	require.NoError(t, err)
+
+	cf := model.ChangeFeedID{Namespace: "default", ID: "test"}
+	cfg := &config.SorterTieringConfig{StorageURI: "file:///tmp"}
+	require.NoError(t, cfg.ValidateAndAdjust())
+	cfg.MinLag = config.TomlDuration(10 * time.Second)
+	s := New(cf, epebble.New(cf, []*pebble.DB{db}), extStorage, cfg)
+	defer s.Close()
+	// Move events of every transaction into a run.
+	s.tableThreshold = 0
+	s.runSize = 1
+
+	span := spanz.TableIDToComparableSpan(1)
+	s.AddTable(span, 1)
+	resolvedTs := make(chan model.Ts, 1)
+	s.OnResolve(func(_ tablepb.Span, ts model.Ts) { resolvedTs <- ts })
+
+	// 10 transactions with 3 events in each, and the commit ts of them are
+	// 1s, 2s, ..., 10s.
+	for i := 1; i <= 10; i++ {
+		commitTs := oracle.ComposeTS(int64(i*1000), 0)
+		for j := 0; j < 3; j++ {
+			s.Add(span, model.NewPolymorphicEvent(&model.RawKVEntry{
+				OpType:  model.OpTypePut,
+				Key:     []byte{byte(i), byte(j)},
+				Value:   []byte{byte(j)},
+				StartTs: commitTs - 1,
+				CRTs:    commitTs,
+			}))
+		}
+	}
+	resolved := oracle.ComposeTS(15*1000, 0)
+	s.Add(span, model.NewResolvedPolymorphicEvent(0, resolved))
+	select {
+	case ts := <-resolvedTs:
+		require.Equal(t, resolved, ts)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "must get a resolved timestamp instead of timeout")
+	}
+	all := fetchAll(t, s.FetchByTable(span, sorter.Position{}, sorter.GenCommitFence(resolved)))
+	require.Len(t, all, 30)
+
+	// Events with commit ts less than or equal to 5s are moved.
+	s.mu.RLock()
+	state, _ := s.tables.Get(span)
+	s.mu.RUnlock()
+	require.NoError(t, s.tierTable(span, state))
+	require.Len(t, state.runs, 5)
+	require.Equal(t, oracle.ComposeTS(5*1000, 0), state.tiered.CommitTs)
+	exists, err := extStorage.FileExists(ctx, state.runs[0].path)
+	require.NoError(t, err)
+	require.True(t, exists)
+	// Nothing to move in the next round.
+	require.NoError(t, s.tierTable(span, state))
+	require.Len(t, state.runs, 5)
+
+	ranges := [][2]sorter.Position{
+		{{}, sorter.GenCommitFence(resolved)},
+		// In a run.
+		{{StartTs: oracle.ComposeTS(2*1000, 0) - 1, CommitTs: oracle.ComposeTS(2*1000, 0)},
+			sorter.GenCommitFence(oracle.ComposeTS(3*1000, 0))},
+		// Across runs and the local engine.
+		{{StartTs: oracle.ComposeTS(4*1000, 0) - 1, CommitTs: oracle.ComposeTS(4*1000, 0)},
+			sorter.GenCommitFence(oracle.ComposeTS(7*1000, 0))},
+		// Only in the local engine.
+		{{StartTs: oracle.ComposeTS(6*1000, 0) - 1, CommitTs: oracle.ComposeTS(6*1000, 0)},
+			sorter.GenCommitFence(resolved)},
+	}
+	for _, r := range ranges {
+		require.Equal(t, expectedInRange(all, r[0], r[1]),
+			fetchAll(t, s.FetchByTable(span, r[0], r[1])))
+	}
+
+	// Runs are deleted after they are cleaned.
+	cleanedPath := state.runs[0].path
+	require.NoError(t, s.CleanByTable(span, sorter.GenCommitFence(oracle.ComposeTS(2*1000, 0))))
+	require.Len(t, state.runs, 3)
+	s.deleteGarbage(ctx)
+	exists, err = extStorage.FileExists(ctx, cleanedPath)
+	require.NoError(t, err)
+	require.False(t, exists)
+	lowerBound := sorter.GenCommitFence(oracle.ComposeTS(2*1000, 0)).Next()
+	require.Equal(t, expectedInRange(all, lowerBound, sorter.GenCommitFence(resolved)),
+		fetchAll(t, s.FetchByTable(span, lowerBound, sorter.GenCommitFence(resolved))))
+
+	// Runs are deleted after the table is removed.
+	remainPath := state.runs[0].path
+	s.RemoveTable(span)
+	require.NoError(t, s.Close())
+	exists, err = extStorage.FileExists(ctx, remainPath)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiered
+
+import (
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter/pebble/encoding"
+)
+
+// run is a file of sorted events of a table in the external storage.
+type run struct {
+	path string
+	// lower and upper are the positions of the first and the last event
+	// in the run, upper is always a transaction boundary.
+	lower  sorter.Position
+	upper  sorter.Position
+	events int
+	bytes  int
+}
+
+func runPath(prefix string, tableID model.TableID, tableUniqueID uint64, seq uint64) string {
+	return fmt.Sprintf("%s/%d-%d/%020d.run", prefix, tableID, tableUniqueID, seq)
+}
+
+// runBuilder encodes sorted events into a run.
+type runBuilder struct {
+	serde encoding.MsgPackGenSerde
+	buf   []byte
+	value []byte
+	run   run
+}
+
+func (b *runBuilder) add(event *model.PolymorphicEvent) error {
+	var err error
+	// Events are encoded with msgpack, so they can be concatenated directly.
+	b.value, err = b.serde.Marshal(event, b.value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	b.buf = append(b.buf, b.value...)
+	pos := sorter.Position{StartTs: event.StartTs, CommitTs: event.CRTs}
+	if b.run.events == 0 {
+		b.run.lower = pos
+	}
+	b.run.upper = pos
+	b.run.events++
+	b.run.bytes = len(b.buf)
+	return nil
+}
+
+func (b *runBuilder) size() int {
+	return len(b.buf)
+}
+
+func (b *runBuilder) reset() {
+	b.buf = b.buf[:0]
+	b.run = run{}
+}
+
+// decodeRun decodes events of a run in [lowerBound, upperBound].
+func decodeRun(
+	data []byte, lowerBound, upperBound sorter.Position,
+) ([]*model.PolymorphicEvent, error) {
+	var serde encoding.MsgPackGenSerde
+	var events []*model.PolymorphicEvent
+	for len(data) > 0 {
+		event := &model.PolymorphicEvent{}
+		var err error
+		if data, err = serde.Unmarshal(event, data); err != nil {
+			return nil, errors.Trace(err)
+		}
+		pos := sorter.Position{StartTs: event.StartTs, CommitTs: event.CRTs}
+		if pos.Compare(lowerBound) < 0 {
+			continue
+		}
+		if pos.Compare(upperBound) > 0 {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiered
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/processor/sourcemanager/sorter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunEncoding(t *testing.T) {
+	t.Parallel()
+
+	b := &runBuilder{}
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, b.add(model.NewPolymorphicEvent(&model.RawKVEntry{
+			OpType:  model.OpTypePut,
+			Key:     []byte{byte(i)},
+			Value:   []byte{byte(i)},
+			StartTs: uint64(i * 10),
+			CRTs:    uint64(i*10 + 1),
+		})))
+	}
+	require.Equal(t, 3, b.run.events)
+	require.Equal(t, sorter.Position{StartTs: 10, CommitTs: 11}, b.run.lower)
+	require.Equal(t, sorter.Position{StartTs: 30, CommitTs: 31}, b.run.upper)
+	require.Equal(t, b.size(), b.run.bytes)
+
+	events, err := decodeRun(b.buf, sorter.Position{}, sorter.GenCommitFence(100))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, []byte{2}, events[1].RawKV.Value)
+	require.Equal(t, uint64(21), events[1].CRTs)
+
+	events, err = decodeRun(b.buf,
+		sorter.Position{StartTs: 20, CommitTs: 21}, sorter.Position{StartTs: 20, CommitTs: 21})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(20), events[0].StartTs)
+
+	_, err = decodeRun(b.buf[:len(b.buf)-1], sorter.Position{}, sorter.GenCommitFence(100))
+	require.Error(t, err)
+
+	b.reset()
+	require.Zero(t, b.size())
+	require.Equal(t, run{}, b.run)
+}
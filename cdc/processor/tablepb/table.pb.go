@@ -184,6 +184,15 @@ type Stats struct {
 	StageCheckpoints map[string]Checkpoint `protobuf:"bytes,3,rep,name=stage_checkpoints,json=stageCheckpoints,proto3" json:"stage_checkpoints" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// The barrier timestamp of the table.
 	BarrierTs Ts `protobuf:"varint,4,opt,name=barrier_ts,json=barrierTs,proto3,casttype=Ts" json:"barrier_ts,omitempty"`
+	// Number of rows emitted to the table sink.
+	EmittedRowCount uint64 `protobuf:"varint,5,opt,name=emitted_row_count,json=emittedRowCount,proto3" json:"emitted_row_count,omitempty"`
+	// Approximate bytes of the rows emitted to the table sink.
+	EmittedBytes uint64 `protobuf:"varint,6,opt,name=emitted_bytes,json=emittedBytes,proto3" json:"emitted_bytes,omitempty"`
+	// Approximate bytes of the events of the table in the sort engine.
+	SorterDiskUsage uint64 `protobuf:"varint,7,opt,name=sorter_disk_usage,json=sorterDiskUsage,proto3" json:"sorter_disk_usage,omitempty"`
+	// Number of events written to the sink backend but not flushed
+	// to the downstream.
+	SinkPendingEventCount uint64 `protobuf:"varint,8,opt,name=sink_pending_event_count,json=sinkPendingEventCount,proto3" json:"sink_pending_event_count,omitempty"`
 }
 
 func (m *Stats) Reset()         { *m = Stats{} }
@@ -248,6 +257,34 @@ func (m *Stats) GetBarrierTs() Ts {
 	return 0
 }
 
+func (m *Stats) GetEmittedRowCount() uint64 {
+	if m != nil {
+		return m.EmittedRowCount
+	}
+	return 0
+}
+
+func (m *Stats) GetEmittedBytes() uint64 {
+	if m != nil {
+		return m.EmittedBytes
+	}
+	return 0
+}
+
+func (m *Stats) GetSorterDiskUsage() uint64 {
+	if m != nil {
+		return m.SorterDiskUsage
+	}
+	return 0
+}
+
+func (m *Stats) GetSinkPendingEventCount() uint64 {
+	if m != nil {
+		return m.SinkPendingEventCount
+	}
+	return 0
+}
+
 // TableStatus is the running status of a table.
 // TODO rename to TableStatus.
 type TableStatus struct {
@@ -339,51 +376,57 @@ func init() { proto.RegisterFile("processor/tablepb/table.proto", fileDescriptor
 
 var fileDescriptor_ae83c9c6cf5ef75c = []byte{
 	// 716 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xbf, 0x6f, 0xdb, 0x38,
-	0x18, 0x95, 0xe4, 0xdf, 0x9f, 0x7d, 0x81, 0xc2, 0x4b, 0x72, 0x3e, 0x03, 0x67, 0xeb, 0x8c, 0xdc,
-	0x25, 0x48, 0x0e, 0xf2, 0x5d, 0x6e, 0x29, 0xb2, 0xc5, 0x49, 0x5b, 0x04, 0x41, 0x81, 0x42, 0x76,
-	0x3b, 0x74, 0x31, 0x64, 0x89, 0x55, 0x84, 0xb8, 0x94, 0x20, 0xd2, 0x09, 0xbc, 0x75, 0x2c, 0xbc,
-	0x34, 0x53, 0xd1, 0xc5, 0x40, 0xfe, 0x9c, 0x8c, 0x19, 0x3b, 0x14, 0x46, 0xeb, 0x0c, 0x1d, 0xbb,
-	0x67, 0x2a, 0x48, 0x2a, 0x56, 0xec, 0x76, 0x70, 0xb3, 0xd8, 0x14, 0xdf, 0xfb, 0x1e, 0xde, 0x7b,
-	0x24, 0x08, 0x7f, 0x84, 0x51, 0xe0, 0x60, 0x4a, 0x83, 0xa8, 0xc1, 0xec, 0x6e, 0x0f, 0x87, 0x5d,
-	0xf9, 0x6f, 0x86, 0x51, 0xc0, 0x02, 0xb4, 0x1e, 0xfa, 0xc4, 0x73, 0xec, 0xd0, 0x64, 0xfe, 0xcb,
-	0x5e, 0x70, 0x66, 0x3a, 0xae, 0x63, 0x4e, 0x27, 0xcc, 0x78, 0xa2, 0xb2, 0xe2, 0x05, 0x5e, 0x20,
-	0x06, 0x1a, 0x7c, 0x25, 0x67, 0xeb, 0x6f, 0x55, 0x48, 0xb7, 0x42, 0x9b, 0xa0, 0xff, 0x20, 0x2f,
-	0x98, 0x1d, 0xdf, 0x2d, 0xab, 0x86, 0xba, 0x99, 0x6a, 0xae, 0x4d, 0xc6, 0xb5, 0x5c, 0x9b, 0xef,
-	0x1d, 0x1e, 0xdc, 0x24, 0x4b, 0x2b, 0x27, 0x78, 0x87, 0x2e, 0x5a, 0x87, 0x02, 0x65, 0x76, 0xc4,
-	0x3a, 0x27, 0x78, 0x50, 0xd6, 0x0c, 0x75, 0xb3, 0xd4, 0xcc, 0xdd, 0x8c, 0x6b, 0xa9, 0x23, 0x3c,
-	0xb0, 0xf2, 0x02, 0x39, 0xc2, 0x03, 0x64, 0x40, 0x0e, 0x13, 0x57, 0x70, 0x52, 0xb3, 0x9c, 0x2c,
-	0x26, 0xee, 0x11, 0x1e, 0xec, 0x96, 0xde, 0x5c, 0xd4, 0x94, 0xf7, 0x17, 0x35, 0xe5, 0xf5, 0x47,
-	0x43, 0xa9, 0x9f, 0xab, 0x00, 0xfb, 0xc7, 0xd8, 0x39, 0x09, 0x03, 0x9f, 0x30, 0xb4, 0x0d, 0xbf,
-	0x38, 0xd3, 0xaf, 0x0e, 0xa3, 0xc2, 0x5c, 0xba, 0x99, 0xbd, 0x19, 0xd7, 0xb4, 0x36, 0xb5, 0x4a,
-	0x09, 0xd8, 0xa6, 0x68, 0x03, 0x8a, 0x11, 0xa6, 0x41, 0xef, 0x14, 0xbb, 0x9c, 0xaa, 0xcd, 0x50,
-	0xe1, 0x16, 0x6a, 0x53, 0xf4, 0x0f, 0x2c, 0xf5, 0x6c, 0xca, 0x3a, 0x74, 0x40, 0x1c, 0xc9, 0x4d,
-	0xcd, 0xca, 0x72, 0xb4, 0x25, 0xc0, 0x36, 0xad, 0x7f, 0xd1, 0x20, 0xd3, 0x62, 0x36, 0xa3, 0xe8,
-	0x4f, 0x28, 0x45, 0xd8, 0xf3, 0x03, 0xd2, 0x71, 0x82, 0x3e, 0x61, 0xd2, 0x8c, 0x55, 0x94, 0x7b,
-	0xfb, 0x7c, 0x0b, 0x6d, 0x00, 0x38, 0xfd, 0x28, 0xc2, 0xd2, 0xad, 0xb4, 0x90, 0x97, 0xb2, 0x65,
-	0xd5, 0x2a, 0xc4, 0x58, 0x9b, 0x22, 0x06, 0xcb, 0x94, 0xd9, 0x1e, 0xee, 0x24, 0x11, 0xb8, 0x8d,
-	0xd4, 0x66, 0x71, 0x67, 0xcf, 0x5c, 0xe4, 0x48, 0x4d, 0xe1, 0x89, 0xff, 0x7a, 0x38, 0x69, 0x8c,
-	0x3e, 0x24, 0x2c, 0x1a, 0x34, 0xd3, 0x97, 0xe3, 0x9a, 0x62, 0xe9, 0x74, 0x0e, 0x44, 0x7f, 0x01,
-	0x74, 0xed, 0x28, 0xf2, 0x71, 0xc4, 0xed, 0xa5, 0x67, 0x52, 0x17, 0x62, 0xa4, 0x4d, 0x2b, 0x7d,
-	0x58, 0xfd, 0xa1, 0x2e, 0xd2, 0x21, 0xc5, 0x8f, 0x92, 0x07, 0x2f, 0x58, 0x7c, 0x89, 0x1e, 0x41,
-	0xe6, 0xd4, 0xee, 0xf5, 0xb1, 0xc8, 0x5a, 0xdc, 0xf9, 0x77, 0x31, 0xef, 0x89, 0xb0, 0x25, 0xc7,
-	0x77, 0xb5, 0x07, 0x6a, 0xfd, 0xab, 0x06, 0x45, 0x71, 0xcf, 0x78, 0xb4, 0x3e, 0xbd, 0xcf, 0xad,
-	0x3c, 0x80, 0x34, 0x0d, 0x6d, 0x52, 0xce, 0x08, 0x37, 0x5b, 0x0b, 0x36, 0x19, 0xda, 0x24, 0xae,
-	0x4c, 0x4c, 0xf3, 0x50, 0x94, 0xd9, 0x4c, 0x86, 0x5a, 0x5a, 0x34, 0xd4, 0xd4, 0x3a, 0xb6, 0xe4,
-	0x38, 0x7a, 0x0e, 0x90, 0x1c, 0xaf, 0xb8, 0x64, 0xf7, 0x68, 0x28, 0x76, 0x76, 0x47, 0x09, 0x3d,
-	0x96, 0xfe, 0xe4, 0x09, 0x16, 0x77, 0xb6, 0x7f, 0xe2, 0xc2, 0xc4, 0x6a, 0x72, 0x7e, 0xeb, 0x9d,
-	0x06, 0x90, 0xd8, 0x46, 0x75, 0xc8, 0x3d, 0x23, 0x27, 0x24, 0x38, 0x23, 0xba, 0x52, 0x59, 0x1d,
-	0x8e, 0x8c, 0xe5, 0x04, 0x8c, 0x01, 0x64, 0x40, 0x76, 0xaf, 0x4b, 0x31, 0x61, 0xba, 0x5a, 0x59,
-	0x19, 0x8e, 0x0c, 0x3d, 0xa1, 0xc8, 0x7d, 0xf4, 0x37, 0x14, 0x9e, 0x46, 0x38, 0xb4, 0x23, 0x9f,
-	0x78, 0xba, 0x56, 0xf9, 0x6d, 0x38, 0x32, 0x7e, 0x4d, 0x48, 0x53, 0x08, 0xad, 0x43, 0x5e, 0x7e,
-	0x60, 0x57, 0x4f, 0x55, 0xd6, 0x86, 0x23, 0x03, 0xcd, 0xd3, 0xb0, 0x8b, 0xb6, 0xa0, 0x68, 0xe1,
-	0xb0, 0xe7, 0x3b, 0x36, 0xe3, 0x7a, 0xe9, 0xca, 0xef, 0xc3, 0x91, 0xb1, 0x7a, 0xa7, 0xeb, 0x04,
-	0xe4, 0x8a, 0x2d, 0x16, 0x84, 0xbc, 0x0d, 0x3d, 0x33, 0xaf, 0x78, 0x8b, 0xf0, 0x94, 0x62, 0x8d,
-	0x5d, 0x3d, 0x3b, 0x9f, 0x32, 0x06, 0x9a, 0x4f, 0xae, 0x3e, 0x57, 0x95, 0xcb, 0x49, 0x55, 0xbd,
-	0x9a, 0x54, 0xd5, 0x4f, 0x93, 0xaa, 0x7a, 0x7e, 0x5d, 0x55, 0xae, 0xae, 0xab, 0xca, 0x87, 0xeb,
-	0xaa, 0xf2, 0xa2, 0xe1, 0xf9, 0xec, 0xb8, 0xdf, 0x35, 0x9d, 0xe0, 0x55, 0x23, 0xae, 0xbe, 0x21,
-	0xab, 0x6f, 0x38, 0xae, 0xd3, 0xf8, 0xee, 0xc1, 0xee, 0x66, 0xc5, 0x7b, 0xfb, 0xff, 0xb7, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x53, 0x4d, 0xc5, 0xfa, 0xcc, 0x05, 0x00, 0x00,
+	// 785 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x55, 0xbf, 0x6f, 0xd3, 0x40,
+	0x14, 0x8e, 0xf3, 0x3b, 0xe7, 0xb4, 0xb8, 0x47, 0x53, 0x42, 0x24, 0xda, 0x10, 0x0a, 0x54, 0x2d,
+	0x72, 0x20, 0x0c, 0xa0, 0x6e, 0x4d, 0x5b, 0x50, 0x85, 0x90, 0x90, 0x93, 0x32, 0xb0, 0x58, 0x8e,
+	0x7d, 0xa4, 0x56, 0x52, 0xdb, 0xf2, 0x5d, 0x1a, 0x65, 0x63, 0x44, 0x2c, 0x74, 0x42, 0x2c, 0x95,
+	0xf8, 0x73, 0x3a, 0x76, 0x64, 0x40, 0x15, 0x94, 0x3f, 0x80, 0x9d, 0x89, 0x77, 0x77, 0x6e, 0xdc,
+	0x06, 0x86, 0xd0, 0xe1, 0xec, 0xf3, 0xfb, 0xbe, 0xf7, 0xfc, 0xbd, 0x1f, 0x67, 0xa3, 0x5b, 0x41,
+	0xe8, 0xdb, 0x84, 0x52, 0x3f, 0xac, 0x33, 0xab, 0xd3, 0x27, 0x41, 0x47, 0xde, 0x75, 0xb0, 0x33,
+	0x1f, 0x2f, 0x07, 0xae, 0xd7, 0xb5, 0xad, 0x40, 0x67, 0xee, 0xdb, 0xbe, 0x3f, 0xd4, 0x6d, 0xc7,
+	0xd6, 0xc7, 0x1e, 0x7a, 0xe4, 0x51, 0x99, 0xef, 0xfa, 0x5d, 0x5f, 0x38, 0xd4, 0xf9, 0x4e, 0xfa,
+	0xd6, 0x3e, 0x2a, 0x28, 0xdd, 0x0a, 0x2c, 0x0f, 0x3f, 0x42, 0x79, 0xc1, 0x34, 0x5d, 0xa7, 0xac,
+	0x54, 0x95, 0x95, 0x54, 0x73, 0xe1, 0xec, 0x74, 0x29, 0xd7, 0xe6, 0xb6, 0x9d, 0xad, 0xdf, 0xf1,
+	0xd6, 0xc8, 0x09, 0xde, 0x8e, 0x83, 0x97, 0x51, 0x81, 0x32, 0x2b, 0x64, 0x66, 0x8f, 0x8c, 0xca,
+	0x49, 0xf0, 0x29, 0x36, 0x73, 0x40, 0x4c, 0xbd, 0x20, 0x23, 0x23, 0x2f, 0x10, 0xd8, 0xe1, 0x2a,
+	0xca, 0x11, 0xcf, 0x11, 0x9c, 0xd4, 0x65, 0x4e, 0x16, 0xec, 0x70, 0x5f, 0x2f, 0xbe, 0xff, 0xb2,
+	0x94, 0xf8, 0x0c, 0xeb, 0xdd, 0xb7, 0x6a, 0xa2, 0x76, 0xa8, 0x20, 0xb4, 0xb9, 0x47, 0xec, 0x5e,
+	0xe0, 0xbb, 0x1e, 0xc3, 0x6b, 0x68, 0xc6, 0x1e, 0x3f, 0x99, 0x8c, 0x0a, 0x71, 0xe9, 0x66, 0x16,
+	0x82, 0x24, 0xdb, 0xd4, 0x28, 0xc6, 0x60, 0x9b, 0xe2, 0xfb, 0x48, 0x0d, 0x09, 0xf5, 0xfb, 0x07,
+	0xc4, 0xe1, 0xd4, 0xe4, 0x25, 0x2a, 0x3a, 0x87, 0x80, 0xf8, 0x00, 0xcd, 0xf6, 0x2d, 0xca, 0x4c,
+	0x3a, 0xf2, 0x6c, 0xc9, 0x4d, 0x5d, 0x0e, 0xcb, 0xd1, 0x96, 0x00, 0xdb, 0xb4, 0x76, 0x94, 0x46,
+	0x99, 0x16, 0xb3, 0x18, 0xc5, 0xb7, 0x51, 0x31, 0x24, 0x5d, 0xd7, 0xf7, 0x4c, 0xdb, 0x1f, 0x78,
+	0x4c, 0x8a, 0x31, 0x54, 0x69, 0xdb, 0xe4, 0x26, 0xd0, 0x80, 0xec, 0x41, 0x18, 0x12, 0xa9, 0x56,
+	0x4a, 0xc8, 0xcb, 0xb0, 0x65, 0xc5, 0x28, 0x44, 0x18, 0x68, 0x60, 0x68, 0x0e, 0x8a, 0xd4, 0x25,
+	0x66, 0x9c, 0x02, 0x97, 0x91, 0x5a, 0x51, 0x1b, 0x1b, 0xfa, 0x34, 0x2d, 0xd5, 0x85, 0x26, 0x7e,
+	0xed, 0x92, 0xb8, 0x62, 0x74, 0xdb, 0x63, 0xe1, 0xa8, 0x99, 0x3e, 0x3e, 0x5d, 0x4a, 0x18, 0x1a,
+	0x9d, 0x00, 0xf1, 0x5d, 0x84, 0x3a, 0x56, 0x18, 0xba, 0x24, 0xe4, 0xf2, 0xd2, 0x97, 0xb2, 0x2e,
+	0x44, 0x08, 0x88, 0x5b, 0x45, 0x73, 0x64, 0xdf, 0x65, 0x0c, 0x8a, 0x13, 0xfa, 0xc3, 0x28, 0xdb,
+	0x8c, 0xc8, 0xf6, 0x5a, 0x04, 0x18, 0xfe, 0x50, 0x66, 0x7c, 0x07, 0xcd, 0x9c, 0x73, 0x3b, 0x23,
+	0x46, 0x68, 0x39, 0x2b, 0x78, 0xc5, 0xc8, 0xd8, 0xe4, 0x36, 0x1e, 0x10, 0xa4, 0x33, 0x78, 0xad,
+	0xe3, 0xd2, 0x9e, 0x39, 0xa0, 0xa0, 0xab, 0x9c, 0x93, 0x01, 0x25, 0xb0, 0x05, 0xf6, 0x5d, 0x6e,
+	0xc6, 0x4f, 0x50, 0x99, 0xba, 0x5e, 0xcf, 0x0c, 0x60, 0x40, 0xa0, 0x0e, 0x26, 0x39, 0xe0, 0xd5,
+	0x94, 0x1a, 0xf2, 0xc2, 0xa5, 0xc4, 0xf1, 0x57, 0x12, 0xde, 0xe6, 0xa8, 0x50, 0x52, 0x19, 0xa0,
+	0xd2, 0x3f, 0xab, 0x81, 0x35, 0x94, 0xe2, 0x03, 0xc8, 0xdb, 0x55, 0x30, 0xf8, 0x16, 0x3f, 0x43,
+	0x99, 0x03, 0xab, 0x3f, 0x20, 0xa2, 0x43, 0x6a, 0xe3, 0xe1, 0x74, 0x15, 0x8f, 0x03, 0x1b, 0xd2,
+	0x7d, 0x3d, 0xf9, 0x54, 0xa9, 0xfd, 0x4a, 0x22, 0x55, 0x9c, 0x0e, 0xde, 0x90, 0x01, 0xbd, 0xca,
+	0x59, 0xda, 0x42, 0x69, 0x0a, 0xc7, 0x50, 0x94, 0x58, 0x6d, 0xac, 0x4e, 0xd9, 0x7f, 0xf0, 0x88,
+	0x1a, 0x2d, 0xbc, 0x79, 0x52, 0xd0, 0x70, 0x26, 0x93, 0x9a, 0x9d, 0x36, 0xa9, 0xb1, 0x74, 0x62,
+	0x48, 0x77, 0xfc, 0x1a, 0x66, 0x78, 0x9c, 0xa9, 0x38, 0x1a, 0x57, 0xa8, 0x50, 0xa4, 0xec, 0x42,
+	0x24, 0xfc, 0x5c, 0xea, 0x93, 0x73, 0xa7, 0x36, 0xd6, 0xfe, 0x63, 0xcc, 0xa3, 0x68, 0xd2, 0x7f,
+	0xf5, 0x53, 0x12, 0xa1, 0x58, 0x36, 0xae, 0xa1, 0xdc, 0xae, 0xd7, 0xf3, 0xfc, 0xa1, 0xa7, 0x25,
+	0x2a, 0xa5, 0x0f, 0x47, 0xd5, 0xb9, 0x18, 0x8c, 0x00, 0xf8, 0x0e, 0x65, 0x37, 0x3a, 0x14, 0x46,
+	0x45, 0x53, 0x2a, 0xf3, 0x40, 0xd1, 0x62, 0x8a, 0xb4, 0xe3, 0x7b, 0xa8, 0xf0, 0x2a, 0x24, 0x81,
+	0x15, 0x82, 0x28, 0x2d, 0x59, 0xb9, 0x01, 0xa4, 0xeb, 0x31, 0x69, 0x0c, 0xc1, 0x77, 0x2f, 0x2f,
+	0x1f, 0x88, 0xa3, 0xa5, 0x2a, 0x0b, 0x40, 0xc3, 0x93, 0x34, 0xe2, 0xc0, 0xc0, 0xab, 0x06, 0x09,
+	0xfa, 0xae, 0x6d, 0x31, 0x1e, 0x2f, 0x5d, 0xb9, 0x09, 0xc4, 0xd2, 0x85, 0x5a, 0xc7, 0x20, 0x8f,
+	0xd8, 0x62, 0x7e, 0xc0, 0xab, 0xa1, 0x65, 0x26, 0x23, 0x9e, 0x23, 0x3c, 0x4b, 0xb1, 0x87, 0xd7,
+	0x66, 0x27, 0xb3, 0x8c, 0x80, 0xe6, 0xcb, 0x93, 0x1f, 0x8b, 0x89, 0xe3, 0xb3, 0x45, 0xe5, 0x04,
+	0xd6, 0x77, 0x58, 0x87, 0x3f, 0x17, 0x13, 0x27, 0xb0, 0xbe, 0xc2, 0x7a, 0x53, 0xef, 0xba, 0x6c,
+	0x6f, 0xd0, 0xd1, 0x6d, 0x7f, 0xbf, 0x1e, 0x95, 0xbe, 0x2e, 0x4b, 0x5f, 0x87, 0xd2, 0xd7, 0xff,
+	0xfa, 0xcd, 0x74, 0xb2, 0xe2, 0x2f, 0xf1, 0xf8, 0x0f, 0xaf, 0x17, 0x85, 0xc1, 0x82, 0x06, 0x00,
+	0x00,
 }
 
 func (m *Span) Marshal() (dAtA []byte, err error) {
@@ -486,6 +529,26 @@ func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SinkPendingEventCount != 0 {
+		i = encodeVarintTable(dAtA, i, uint64(m.SinkPendingEventCount))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.SorterDiskUsage != 0 {
+		i = encodeVarintTable(dAtA, i, uint64(m.SorterDiskUsage))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.EmittedBytes != 0 {
+		i = encodeVarintTable(dAtA, i, uint64(m.EmittedBytes))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.EmittedRowCount != 0 {
+		i = encodeVarintTable(dAtA, i, uint64(m.EmittedRowCount))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.BarrierTs != 0 {
 		i = encodeVarintTable(dAtA, i, uint64(m.BarrierTs))
 		i--
@@ -664,6 +727,18 @@ func (m *Stats) Size() (n int) {
 	if m.BarrierTs != 0 {
 		n += 1 + sovTable(uint64(m.BarrierTs))
 	}
+	if m.EmittedRowCount != 0 {
+		n += 1 + sovTable(uint64(m.EmittedRowCount))
+	}
+	if m.EmittedBytes != 0 {
+		n += 1 + sovTable(uint64(m.EmittedBytes))
+	}
+	if m.SorterDiskUsage != 0 {
+		n += 1 + sovTable(uint64(m.SorterDiskUsage))
+	}
+	if m.SinkPendingEventCount != 0 {
+		n += 1 + sovTable(uint64(m.SinkPendingEventCount))
+	}
 	return n
 }
 
@@ -1153,6 +1228,82 @@ func (m *Stats) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmittedRowCount", wireType)
+			}
+			m.EmittedRowCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTable
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EmittedRowCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmittedBytes", wireType)
+			}
+			m.EmittedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTable
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EmittedBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SorterDiskUsage", wireType)
+			}
+			m.SorterDiskUsage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTable
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SorterDiskUsage |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinkPendingEventCount", wireType)
+			}
+			m.SinkPendingEventCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTable
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SinkPendingEventCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTable(dAtA[iNdEx:])
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/kv"
+	"github.com/pingcap/tiflow/cdc/kv/regionlock"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/processor/tablepb"
 	"github.com/pingcap/tiflow/cdc/puller/frontier"
@@ -525,3 +526,17 @@ func (p *MultiplexingPuller) Stats(span tablepb.Span) Stats {
 		CheckpointTsEgress:  progress.resolvedTs.Load(),
 	}
 }
+
+// SlowestRegions returns at most limit regions of the span with the smallest
+// resolved ts, and the ranges of the span that are not captured by any region.
+func (p *MultiplexingPuller) SlowestRegions(
+	span tablepb.Span, limit int,
+) ([]regionlock.LockedRangeStatistic, []regionlock.UnLockRangeStatistic) {
+	p.subscriptions.RLock()
+	progress := p.subscriptions.n.GetV(span)
+	p.subscriptions.RUnlock()
+	if progress.tableProgress == nil {
+		return nil, nil
+	}
+	return p.client.SlowestRegions(progress.subID, limit)
+}
@@ -26,4 +26,8 @@ type InfoProvider interface {
 
 	// GetTaskStatuses returns the task statuses.
 	GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus, error)
+
+	// GetStatistics returns the throughput of the changefeed and the
+	// statistics of its tables reported by the processors.
+	GetStatistics() *model.ChangefeedStatistics
 }
@@ -457,5 +457,6 @@ func (c *coordinator) maybeCollectMetrics() {
 
 	c.schedulerM.CollectMetrics()
 	c.replicationM.CollectMetrics(pdTime)
+	c.replicationM.UpdateThroughput(now)
 	c.captureM.CollectMetrics()
 }
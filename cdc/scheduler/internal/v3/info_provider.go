@@ -29,6 +29,15 @@ func (c *coordinator) IsInitialized() bool {
 	return c.captureM.CheckAllCaptureInitialized()
 }
 
+// GetStatistics returns the throughput of the changefeed and the
+// statistics of its tables.
+func (c *coordinator) GetStatistics() *model.ChangefeedStatistics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.replicationM.Statistics()
+}
+
 // GetTaskStatuses returns the task statuses.
 func (c *coordinator) GetTaskStatuses() (map[model.CaptureID]*model.TaskStatus, error) {
 	c.mu.Lock()
@@ -39,6 +39,9 @@ const (
 	logSlowTablesLagThreshold = 30 * time.Second
 	logSlowTablesInterval     = 1 * time.Minute
 	logMissingTableInterval   = 30 * time.Second
+	// throughputIdleInterval is the interval after which the throughput is
+	// reset to 0 if the tables don't report any progress.
+	throughputIdleInterval = 30 * time.Second
 )
 
 // Callback is invoked when something is done.
@@ -158,6 +161,18 @@ type Manager struct { //nolint:revive
 	lastLogSlowTablesTime time.Time
 	lastMissTableID       tablepb.TableID
 	lastLogMissTime       time.Time
+
+	throughput throughput
+}
+
+// throughput is the throughput of the sink of a changefeed calculated from
+// the emitted rows and bytes reported by the processors.
+type throughput struct {
+	sampleTime     time.Time
+	rows           uint64
+	bytes          uint64
+	rowsPerSecond  float64
+	bytesPerSecond float64
 }
 
 // NewReplicationManager returns a new replication manager.
@@ -772,6 +787,73 @@ func (r *Manager) logSlowTableInfo(currentPDTime time.Time) {
 	}
 }
 
+// UpdateThroughput samples the rows and bytes emitted by all tables and
+// updates the throughput of the changefeed.
+func (r *Manager) UpdateThroughput(now time.Time) {
+	var rows, bytes uint64
+	r.spans.Ascend(func(span tablepb.Span, table *ReplicationSet) bool {
+		rows += table.Stats.EmittedRowCount
+		bytes += table.Stats.EmittedBytes
+		return true
+	})
+	t := &r.throughput
+	if t.sampleTime.IsZero() || rows < t.rows || bytes < t.bytes {
+		// The counters restart if tables are moved to other captures,
+		// take a new sample and calculate the throughput next time.
+		t.sampleTime, t.rows, t.bytes = now, rows, bytes
+		return
+	}
+	elapsed := now.Sub(t.sampleTime)
+	if elapsed <= 0 ||
+		(rows == t.rows && bytes == t.bytes && elapsed < throughputIdleInterval) {
+		// The stats are collected periodically, wait for the next report.
+		return
+	}
+	t.rowsPerSecond = float64(rows-t.rows) / elapsed.Seconds()
+	t.bytesPerSecond = float64(bytes-t.bytes) / elapsed.Seconds()
+	t.sampleTime, t.rows, t.bytes = now, rows, bytes
+}
+
+// Statistics returns the throughput of the changefeed and the statistics
+// of all tables ordered by table id, the statistics of the spans of a table
+// are aggregated.
+func (r *Manager) Statistics() *model.ChangefeedStatistics {
+	res := &model.ChangefeedStatistics{
+		RowsPerSecond:  r.throughput.rowsPerSecond,
+		BytesPerSecond: r.throughput.bytesPerSecond,
+		Tables:         make([]model.TableStatistics, 0),
+	}
+	r.spans.Ascend(func(span tablepb.Span, table *ReplicationSet) bool {
+		n := len(res.Tables)
+		if n == 0 || res.Tables[n-1].TableID != span.TableID {
+			res.Tables = append(res.Tables, model.TableStatistics{
+				TableID:      span.TableID,
+				CheckpointTs: table.Checkpoint.CheckpointTs,
+				ResolvedTs:   table.Checkpoint.ResolvedTs,
+			})
+			n++
+		}
+		stats := &res.Tables[n-1]
+		if stats.CheckpointTs > table.Checkpoint.CheckpointTs {
+			stats.CheckpointTs = table.Checkpoint.CheckpointTs
+		}
+		if stats.ResolvedTs > table.Checkpoint.ResolvedTs {
+			stats.ResolvedTs = table.Checkpoint.ResolvedTs
+		}
+		stats.EmittedRows += table.Stats.EmittedRowCount
+		stats.EmittedBytes += table.Stats.EmittedBytes
+		stats.SorterDiskUsage += table.Stats.SorterDiskUsage
+		stats.SinkPendingEvents += table.Stats.SinkPendingEventCount
+
+		res.EmittedRows += table.Stats.EmittedRowCount
+		res.EmittedBytes += table.Stats.EmittedBytes
+		res.SorterDiskUsage += table.Stats.SorterDiskUsage
+		res.SinkPendingEvents += table.Stats.SinkPendingEventCount
+		return true
+	})
+	return res
+}
+
 // CollectMetrics collects metrics.
 func (r *Manager) CollectMetrics(currentPDTime time.Time) {
 	cf := r.changefeedID
@@ -1068,3 +1068,72 @@ func TestLogSlowTableInfo(t *testing.T) {
 	// make sure the slowTableHeap's capacity will not extend
 	require.Equal(t, cap(r.slowTableHeap), 8)
 }
+
+func TestReplicationManagerStatistics(t *testing.T) {
+	t.Parallel()
+	r := NewReplicationManager(1, model.ChangeFeedID{})
+	// Table 1 is split into two spans.
+	span1 := spanz.TableIDToComparableSpan(1)
+	mid := append(append([]byte{}, span1.StartKey...), 1)
+	r.spans.ReplaceOrInsert(tablepb.Span{TableID: 1, StartKey: span1.StartKey, EndKey: mid},
+		&ReplicationSet{
+			Checkpoint: tablepb.Checkpoint{CheckpointTs: 10, ResolvedTs: 30},
+			Stats: tablepb.Stats{
+				EmittedRowCount: 100, EmittedBytes: 1000,
+				SorterDiskUsage: 10, SinkPendingEventCount: 1,
+			},
+		})
+	r.spans.ReplaceOrInsert(tablepb.Span{TableID: 1, StartKey: mid, EndKey: span1.EndKey},
+		&ReplicationSet{
+			Checkpoint: tablepb.Checkpoint{CheckpointTs: 20, ResolvedTs: 25},
+			Stats: tablepb.Stats{
+				EmittedRowCount: 50, EmittedBytes: 500,
+				SorterDiskUsage: 20, SinkPendingEventCount: 2,
+			},
+		})
+	r.spans.ReplaceOrInsert(spanz.TableIDToComparableSpan(2), &ReplicationSet{
+		Checkpoint: tablepb.Checkpoint{CheckpointTs: 15, ResolvedTs: 40},
+		Stats:      tablepb.Stats{EmittedRowCount: 10, EmittedBytes: 100},
+	})
+
+	stats := r.Statistics()
+	require.Equal(t, []model.TableStatistics{{
+		TableID: 1, CheckpointTs: 10, ResolvedTs: 25,
+		EmittedRows: 150, EmittedBytes: 1500, SorterDiskUsage: 30, SinkPendingEvents: 3,
+	}, {
+		TableID: 2, CheckpointTs: 15, ResolvedTs: 40, EmittedRows: 10, EmittedBytes: 100,
+	}}, stats.Tables)
+	require.Equal(t, uint64(160), stats.EmittedRows)
+	require.Equal(t, uint64(1600), stats.EmittedBytes)
+	require.Equal(t, uint64(30), stats.SorterDiskUsage)
+	require.Equal(t, uint64(3), stats.SinkPendingEvents)
+	require.Zero(t, stats.RowsPerSecond)
+
+	// The throughput is calculated from two samples.
+	now := time.Now()
+	r.UpdateThroughput(now)
+	table2, _ := r.spans.Get(spanz.TableIDToComparableSpan(2))
+	table2.Stats.EmittedRowCount += 100
+	table2.Stats.EmittedBytes += 2000
+	r.UpdateThroughput(now.Add(10 * time.Second))
+	stats = r.Statistics()
+	require.Equal(t, float64(10), stats.RowsPerSecond)
+	require.Equal(t, float64(200), stats.BytesPerSecond)
+
+	// The throughput is kept until the next report.
+	r.UpdateThroughput(now.Add(15 * time.Second))
+	require.Equal(t, float64(10), r.Statistics().RowsPerSecond)
+	// The throughput is 0 if there is no progress for a long time.
+	r.UpdateThroughput(now.Add(10*time.Second + throughputIdleInterval))
+	require.Zero(t, r.Statistics().RowsPerSecond)
+
+	// The counters restart if the table is moved to another capture.
+	table2.Stats.EmittedRowCount = 0
+	table2.Stats.EmittedBytes = 0
+	r.UpdateThroughput(now.Add(time.Minute))
+	table2.Stats.EmittedRowCount = 50
+	table2.Stats.EmittedBytes = 50
+	r.UpdateThroughput(now.Add(time.Minute + 5*time.Second))
+	require.Equal(t, float64(10), r.Statistics().RowsPerSecond)
+	require.Equal(t, float64(10), r.Statistics().BytesPerSecond)
+}
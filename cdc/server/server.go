@@ -37,10 +37,13 @@ import (
 	"github.com/pingcap/tiflow/pkg/etcd"
 	"github.com/pingcap/tiflow/pkg/fsutil"
 	clogutil "github.com/pingcap/tiflow/pkg/logutil"
+	"github.com/pingcap/tiflow/pkg/metastore"
 	"github.com/pingcap/tiflow/pkg/p2p"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/tcpserver"
+	"github.com/pingcap/tiflow/pkg/util"
 	p2pProto "github.com/pingcap/tiflow/proto/p2p"
+	"github.com/prometheus/client_golang/prometheus"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 	"golang.org/x/net/netutil"
@@ -173,11 +176,25 @@ func (s *server) prepare(ctx context.Context) error {
 		return errors.Trace(err)
 	}
 
-	cdcEtcdClient, err := etcd.NewCDCEtcdClient(ctx, etcdCli, conf.ClusterID)
+	// The metadata of the cluster is stored in the meta store if CDCV2 is
+	// enabled, while the etcd of PD is still used by the default upstream.
+	metaCli := etcdCli
+	if conf.Debug.CDCV2.Enable {
+		log.Info("create meta store client")
+		metaCli, err = metastore.NewClient(ctx, &conf.Debug.CDCV2.MetaStoreConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	cdcEtcdClient, err := etcd.NewCDCEtcdClient(ctx, metaCli, conf.ClusterID)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	s.etcdClient = cdcEtcdClient
+	upstreamEtcdClient := cdcEtcdClient.GetEtcdClient()
+	if conf.Debug.CDCV2.Enable {
+		upstreamEtcdClient = etcd.Wrap(etcdCli, make(map[string]prometheus.Counter))
+	}
 
 	// Collect all endpoints from pd here to make the server more robust.
 	// Because in some scenarios, the deployer may only provide one pd endpoint,
@@ -196,7 +213,7 @@ func (s *server) prepare(ctx context.Context) error {
 	s.createSortEngineFactory()
 	s.setMemoryLimit()
 
-	s.capture = capture.NewCapture(s.pdEndpoints, cdcEtcdClient,
+	s.capture = capture.NewCapture(s.pdEndpoints, cdcEtcdClient, upstreamEtcdClient,
 		s.grpcService, s.sortEngineFactory, s.pdClient)
 
 	return nil
@@ -236,10 +253,19 @@ func (s *server) createSortEngineFactory() {
 	// See https://github.com/pingcap/tiflow/blob/9dad09/cdc/server.go#L275
 	sortDir := config.GetGlobalServerConfig().Sorter.SortDir
 	memInBytes := conf.Sorter.CacheSizeInMB * uint64(1<<20)
-	s.sortEngineFactory = factory.NewForPebble(sortDir, memInBytes, conf.Debug.DB)
+	diskInBytes := conf.Sorter.MaxDiskUsageInMB * uint64(1<<20)
+	if conf.Sorter.TieringEnabled() {
+		s.sortEngineFactory = factory.NewForTiered(sortDir, memInBytes, diskInBytes,
+			conf.Debug.DB, conf.Sorter.Tiering)
+		log.Info("sorter engine moves cold events to the external storage",
+			zap.String("storage", util.MaskSensitiveDataInURI(conf.Sorter.Tiering.StorageURI)))
+	} else {
+		s.sortEngineFactory = factory.NewForPebble(sortDir, memInBytes, diskInBytes, conf.Debug.DB)
+	}
 	log.Info("sorter engine memory limit",
 		zap.Uint64("bytes", memInBytes),
 		zap.String("memory", humanize.IBytes(memInBytes)),
+		zap.Uint64("diskQuotaBytes", diskInBytes),
 	)
 }
 
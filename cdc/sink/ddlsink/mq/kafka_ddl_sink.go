@@ -77,22 +77,24 @@ func NewKafkaDDLSink(
 		return nil, errors.Trace(err)
 	}
 
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, protocol, topic, sinkURI.Scheme)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	topicConfig := options.DeriveTopicConfig()
+	topicConfig.TopicConfigOf = eventRouter.GetTopicConfig
 	topicManager, err := util.GetTopicManagerAndTryCreateTopic(
 		ctx,
 		changefeedID,
 		topic,
-		options.DeriveTopicConfig(),
+		topicConfig,
 		adminClient,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, protocol, topic, sinkURI.Scheme)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, replicaConfig, options.MaxMessageBytes)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -100,6 +100,14 @@ func newDDLSink(
 // WriteDDLEvent encodes the DDL event and sends it to the MQ system.
 func (k *DDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
 	encoder := k.encoderBuilder.Build()
+	if checker, ok := encoder.(codec.DDLSchemaChecker); ok && ddl.TableInfo != nil {
+		// Check the new schema against the topic of the rows after the DDL,
+		// which may differ from the topic of the DDL if the table is renamed.
+		rowTopic := k.eventRouter.GetTopicForRowChange(&model.RowChangedEvent{TableInfo: ddl.TableInfo})
+		if err := checker.CheckDDLSchema(ctx, rowTopic, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	msg, err := encoder.EncodeDDLEvent(ddl)
 	if err != nil {
 		return errors.Trace(err)
@@ -115,6 +123,12 @@ func (k *DDLSink) WriteDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 
 	topic := k.eventRouter.GetTopicForDDL(ddl)
 	partitionRule := getDDLDispatchRule(k.protocol)
+	if router, ok := encoder.(codec.SchemaChangeTopicRouter); ok && router.SchemaChangeTopic() != "" {
+		// All DDL events are sent to the first partition of the schema change
+		// topic, so that the consumers can replay the DDL history in order.
+		topic = router.SchemaChangeTopic()
+		partitionRule = PartitionZero
+	}
 	log.Debug("Emit ddl event",
 		zap.Uint64("commitTs", ddl.CommitTs),
 		zap.String("query", ddl.Query),
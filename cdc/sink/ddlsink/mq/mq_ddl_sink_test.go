@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	mm "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -138,6 +139,49 @@ func TestWriteDDLEventToZeroPartition(t *testing.T) {
 	require.Len(t, s.producer.(*ddlproducer.MockDDLProducer).GetEvents("mock_topic", 2), 0)
 }
 
+func TestWriteDDLEventToSchemaChangeTopic(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=2" +
+		"&kafka-client-id=unit-test&auto-create-topic=true&compression=gzip&protocol=debezium"
+	uri := fmt.Sprintf(uriTemplate, "127.0.0.1:9092", kafka.DefaultMockTopicName)
+
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Debezium.SchemaChangeTopic = "schema_changes"
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	ctx = context.WithValue(ctx, "testing.T", t)
+	s, err := NewKafkaDDLSink(ctx, model.DefaultChangeFeedID("test"),
+		sinkURI, replicaConfig,
+		kafka.NewMockFactory,
+		ddlproducer.NewMockDDLProducer)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288260,
+		TableInfo: model.BuildTableInfo("cdc", "person", []*model.Column{{
+			Name: "id",
+			Type: mysql.TypeLong,
+			Flag: model.PrimaryKeyFlag | model.HandleKeyFlag,
+		}}, [][]int{{0}}),
+		Query: "create table person(id int, primary key(id))",
+		Type:  mm.ActionCreateTable,
+	}
+	err = s.WriteDDLEvent(ctx, ddl)
+	require.NoError(t, err)
+	producer := s.producer.(*ddlproducer.MockDDLProducer)
+	require.Len(t, producer.GetAllEvents(), 1, "Only zero partition of the schema change topic")
+	require.Len(t, producer.GetEvents("schema_changes", 0), 1)
+	require.Len(t, producer.GetEvents("mock_topic", 0), 0)
+}
+
 func TestWriteCheckpointTsToDefaultTopic(t *testing.T) {
 	t.Parallel()
 
@@ -59,7 +59,8 @@ var _ ddlsink.Sink = (*DDLSink)(nil)
 // DDLSink is a sink that writes DDL events to MySQL.
 type DDLSink struct {
 	// id indicates which processor (changefeed) this sink belongs to.
-	id model.ChangeFeedID
+	id        model.ChangeFeedID
+	clusterID string
 	// db is the database connection.
 	db  *sql.DB
 	cfg *pmysql.Config
@@ -105,6 +106,12 @@ func NewDDLSink(
 		return nil, err
 	}
 
+	if cfg.EnableCheckpointTable {
+		if err = pmysql.CreateCheckpointTable(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+
 	lruCache, err := lru.New(1024)
 	if err != nil {
 		return nil, err
@@ -112,6 +119,7 @@ func NewDDLSink(
 
 	m := &DDLSink{
 		id:                         changefeedID,
+		clusterID:                  config.GetGlobalServerConfig().ClusterID,
 		db:                         db,
 		cfg:                        cfg,
 		statistics:                 metrics.NewStatistics(changefeedID, sink.TxnSink),
@@ -306,9 +314,19 @@ func needFormatDDL(db *sql.DB, cfg *pmysql.Config) bool {
 	return false
 }
 
-// WriteCheckpointTs does nothing.
-func (m *DDLSink) WriteCheckpointTs(_ context.Context, _ uint64, _ []*model.TableInfo) error {
-	// Only for RowSink for now.
+// WriteCheckpointTs writes the checkpoint of the changefeed into the checkpoint
+// table if it's enabled, otherwise it does nothing.
+func (m *DDLSink) WriteCheckpointTs(ctx context.Context, ts uint64, _ []*model.TableInfo) error {
+	if !m.cfg.EnableCheckpointTable {
+		return nil
+	}
+	query, args := pmysql.PrepareCheckpointUpsert(m.clusterID, m.id, pmysql.CheckpointRow{CheckpointTs: ts})
+	writeTimeout, _ := time.ParseDuration(m.cfg.WriteTimeout)
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout+networkDriftDuration)
+	defer cancel()
+	if _, err := m.db.ExecContext(ctx, query, args...); err != nil {
+		return errors.WrapError(errors.ErrMySQLTxnError, err)
+	}
 	return nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"net/url"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -89,6 +90,43 @@ func TestWriteDDLEvent(t *testing.T) {
 	sink.Close()
 }
 
+func TestWriteCheckpointTs(t *testing.T) {
+	dbConnFactory := pmysql.NewDBConnectionFactoryForTest()
+	dbConnFactory.SetStandardConnectionFactory(func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		db, mock, err := sqlmock.New()
+		require.Nil(t, err)
+		mock.ExpectQuery(regexp.QuoteMeta("select tidb_version()")).
+			WillReturnRows(sqlmock.NewRows([]string{"tidb_version()"}).AddRow("5.7.25-TiDB-v4.0.0-beta-191-ga1b3e3b"))
+		mock.ExpectQuery(regexp.QuoteMeta("select tidb_version()")).
+			WillReturnRows(sqlmock.NewRows([]string{"tidb_version()"}).AddRow("5.7.25-TiDB-v4.0.0-beta-191-ga1b3e3b"))
+		mock.ExpectExec("SET SESSION tidb_cdc_write_source = 1").WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE DATABASE IF NOT EXISTS `tidb_cdc`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `tidb_cdc`.`checkpoint_v1`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tidb_cdc`.`checkpoint_v1` "+
+			"(ticdc_cluster_id, namespace, changefeed, schema_name, table_name, checkpoint_ts) "+
+			"VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE checkpoint_ts = VALUES(checkpoint_ts)")).
+			WithArgs(config.GetGlobalServerConfig().ClusterID, "default", "test-changefeed", "", "", 1010).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectClose()
+		return db, nil
+	})
+	GetDBConnImpl = dbConnFactory
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000?enable-checkpoint-table=true")
+	require.Nil(t, err)
+	rc := config.GetDefaultReplicaConfig()
+	sink, err := NewDDLSink(ctx, model.DefaultChangeFeedID("test-changefeed"), sinkURI, rc)
+	require.Nil(t, err)
+
+	err = sink.WriteCheckpointTs(ctx, 1010, nil)
+	require.Nil(t, err)
+	sink.Close()
+}
+
 func TestNeedSwitchDB(t *testing.T) {
 	t.Parallel()
 
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher/topic"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"go.uber.org/zap"
 )
@@ -34,7 +35,10 @@ type EventRouter struct {
 
 	rules []struct {
 		partitionDispatcher partition.Dispatcher
-		topicDispatcher     topic.Dispatcher
+		// keyHashed is true if the partition key is hashed by a non-default hash function.
+		keyHashed       bool
+		topicDispatcher topic.Dispatcher
+		topicConfig     *config.TopicConfig
 		filter.Filter
 	}
 }
@@ -54,7 +58,10 @@ func NewEventRouter(
 
 	rules := make([]struct {
 		partitionDispatcher partition.Dispatcher
-		topicDispatcher     topic.Dispatcher
+		// keyHashed is true if the partition key is hashed by a non-default hash function.
+		keyHashed       bool
+		topicDispatcher topic.Dispatcher
+		topicConfig     *config.TopicConfig
 		filter.Filter
 	}, 0, len(ruleConfigs))
 
@@ -68,17 +75,29 @@ func NewEventRouter(
 		}
 
 		d := getPartitionDispatcher(
-			ruleConfig.PartitionRule, scheme, ruleConfig.IndexName, ruleConfig.Columns,
+			ruleConfig.PartitionRule, scheme, ruleConfig.IndexName, ruleConfig.Columns, ruleConfig.HashFunction,
 		)
+		if len(ruleConfig.Partitions) != 0 || ruleConfig.PartitionNum != 0 {
+			d = partition.NewMappingDispatcher(d, ruleConfig.Partitions, ruleConfig.PartitionNum)
+		}
 		t, err := getTopicDispatcher(ruleConfig.TopicRule, defaultTopic, protocol, scheme)
 		if err != nil {
 			return nil, err
 		}
 		rules = append(rules, struct {
 			partitionDispatcher partition.Dispatcher
-			topicDispatcher     topic.Dispatcher
+			// keyHashed is true if the partition key is hashed by a non-default hash function.
+			keyHashed       bool
+			topicDispatcher topic.Dispatcher
+			topicConfig     *config.TopicConfig
 			filter.Filter
-		}{partitionDispatcher: d, topicDispatcher: t, Filter: f})
+		}{
+			partitionDispatcher: d,
+			keyHashed:           !hash.IsDefaultFunction(ruleConfig.HashFunction),
+			topicDispatcher:     t,
+			topicConfig:         ruleConfig.TopicConfig,
+			Filter:              f,
+		})
 	}
 
 	return &EventRouter{
@@ -123,6 +142,18 @@ func (s *EventRouter) GetPartitionForRowChange(
 		DispatchRowChangedEvent(row, partitionNum)
 }
 
+// IsKeyHashed returns true if the partition of the table is computed by hashing
+// the partition key with a non-default hash function, the partition key is sent
+// as the key of the kafka message then.
+func (s *EventRouter) IsKeyHashed(schema, table string) bool {
+	for _, rule := range s.rules {
+		if rule.MatchTable(schema, table) {
+			return rule.keyHashed
+		}
+	}
+	return false
+}
+
 // GetPartitionDispatcher returns the partition dispatcher for a specific table.
 func (s *EventRouter) GetPartitionDispatcher(schema, table string) partition.Dispatcher {
 	_, partitionDispatcher := s.matchDispatcher(schema, table)
@@ -133,6 +164,9 @@ func (s *EventRouter) GetPartitionDispatcher(schema, table string) partition.Dis
 func (s *EventRouter) VerifyTables(infos []*model.TableInfo) error {
 	for _, table := range infos {
 		_, partitionDispatcher := s.matchDispatcher(table.TableName.Schema, table.TableName.Table)
+		if v, ok := partitionDispatcher.(*partition.MappingDispatcher); ok {
+			partitionDispatcher = v.Inner()
+		}
 		switch v := partitionDispatcher.(type) {
 		case *partition.IndexValueDispatcher:
 			if v.IndexName != "" {
@@ -190,6 +224,17 @@ func (s *EventRouter) GetActiveTopics(activeTables []model.TableName) []string {
 	return topics
 }
 
+// GetTopicConfig returns the topic config of the first rule which sets the topic
+// config and may dispatch events to the topic, nil if there is no such rule.
+func (s *EventRouter) GetTopicConfig(topicName string) *config.TopicConfig {
+	for _, rule := range s.rules {
+		if rule.topicConfig != nil && rule.topicDispatcher.Match(topicName) {
+			return rule.topicConfig
+		}
+	}
+	return nil
+}
+
 // GetDefaultTopic returns the default topic name.
 func (s *EventRouter) GetDefaultTopic() string {
 	return s.defaultTopic
@@ -212,22 +257,22 @@ func (s *EventRouter) matchDispatcher(
 
 // getPartitionDispatcher returns the partition dispatcher for a specific partition rule.
 func getPartitionDispatcher(
-	rule string, scheme string, indexName string, columns []string,
+	rule string, scheme string, indexName string, columns []string, hashFunction string,
 ) partition.Dispatcher {
 	switch strings.ToLower(rule) {
 	case "default":
-		return partition.NewDefaultDispatcher()
+		return partition.NewDefaultDispatcher(hashFunction)
 	case "ts":
 		return partition.NewTsDispatcher()
 	case "table":
-		return partition.NewTableDispatcher()
+		return partition.NewTableDispatcher(hashFunction)
 	case "index-value":
-		return partition.NewIndexValueDispatcher(indexName)
+		return partition.NewIndexValueDispatcher(indexName, hashFunction)
 	case "rowid":
 		log.Warn("rowid is deprecated, index-value is used as the partition dispatcher.")
-		return partition.NewIndexValueDispatcher(indexName)
+		return partition.NewIndexValueDispatcher(indexName, hashFunction)
 	case "columns":
-		return partition.NewColumnsDispatcher(columns)
+		return partition.NewColumnsDispatcher(columns, hashFunction)
 	default:
 	}
 
@@ -237,7 +282,7 @@ func getPartitionDispatcher(
 
 	log.Warn("the partition dispatch rule is not default/ts/table/index-value/columns," +
 		" use the default rule instead.")
-	return partition.NewDefaultDispatcher()
+	return partition.NewDefaultDispatcher(hashFunction)
 }
 
 // getTopicDispatcher returns the topic dispatcher for a specific topic rule (aka topic expression).
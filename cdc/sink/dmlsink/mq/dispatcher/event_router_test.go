@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher/topic"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -270,6 +271,75 @@ func TestGetPartitionForRowChange(t *testing.T) {
 	require.Equal(t, int32(1), p)
 }
 
+func TestGetPartitionWithMapping(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:       []string{"test.*"},
+			PartitionRule: "index-value",
+			HashFunction:  "murmur2",
+			Partitions:    []int32{4, 5, 6},
+			PartitionNum:  8,
+		},
+	}
+	d, err := NewEventRouter(replicaConfig, config.ProtocolCanalJSON, "test", sink.KafkaScheme)
+	require.NoError(t, err)
+
+	cols := []*model.Column{
+		{
+			Name:  "id",
+			Value: 1,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+		},
+	}
+	tableInfo := model.BuildTableInfo("test", "t", cols, [][]int{{0}})
+	row := &model.RowChangedEvent{
+		TableInfo: tableInfo,
+		Columns:   model.Columns2ColumnDatas(cols, tableInfo),
+	}
+	// murmur2("1") & 0x7fffffff is 154038159, 154038159 % 3 = 0.
+	p, key, err := d.GetPartitionForRowChange(row, 8)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), p)
+	// the hashed key is sent as the key of the message.
+	require.Equal(t, "1", key)
+	require.True(t, d.IsKeyHashed("test", "t"))
+	require.False(t, d.IsKeyHashed("other", "t"))
+
+	// the partition number of the topic is not expected.
+	_, _, err = d.GetPartitionForRowChange(row, 16)
+	require.Error(t, err)
+
+	// the mapping is transparent to the table verification.
+	require.NoError(t, d.VerifyTables([]*model.TableInfo{tableInfo}))
+}
+
+func TestGetTopicConfig(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	topicConfig := &config.TopicConfig{PartitionNum: util.AddressOf(int32(6))}
+	replicaConfig.Sink.DispatchRules = []*config.DispatchRule{
+		{
+			Matcher:     []string{"test.*"},
+			TopicRule:   "test_{table}",
+			TopicConfig: topicConfig,
+		},
+		{
+			Matcher:   []string{"*.*"},
+			TopicRule: "{schema}_{table}",
+		},
+	}
+	d, err := NewEventRouter(replicaConfig, config.ProtocolCanalJSON, "test", sink.KafkaScheme)
+	require.NoError(t, err)
+
+	require.Equal(t, topicConfig, d.GetTopicConfig("test_t1"))
+	require.Nil(t, d.GetTopicConfig("other_t1"))
+	require.Nil(t, d.GetTopicConfig("test"))
+}
+
 func TestGetTopicForDDL(t *testing.T) {
 	t.Parallel()
 
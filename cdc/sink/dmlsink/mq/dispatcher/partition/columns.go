@@ -27,16 +27,20 @@ import (
 // ColumnsDispatcher is a partition dispatcher
 // which dispatches events based on the given columns.
 type ColumnsDispatcher struct {
-	hasher *hash.PositionInertia
-	lock   sync.Mutex
+	hasher hash.Hasher
+	// keyOnly is true if only the encoded column values are hashed, the encoded
+	// key is returned as the partition key and sent as the key of the message.
+	keyOnly bool
+	lock    sync.Mutex
 
 	Columns []string
 }
 
 // NewColumnsDispatcher creates a ColumnsDispatcher.
-func NewColumnsDispatcher(columns []string) *ColumnsDispatcher {
+func NewColumnsDispatcher(columns []string, hashFunction string) *ColumnsDispatcher {
 	return &ColumnsDispatcher{
-		hasher:  hash.NewPositionInertia(),
+		hasher:  hash.NewHasher(hashFunction),
+		keyOnly: !hash.IsDefaultFunction(hashFunction),
 		Columns: columns,
 	}
 }
@@ -48,7 +52,9 @@ func (r *ColumnsDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent,
 	defer r.lock.Unlock()
 	r.hasher.Reset()
 
-	r.hasher.Write([]byte(row.TableInfo.GetSchemaName()), []byte(row.TableInfo.GetTableName()))
+	if !r.keyOnly {
+		r.hasher.Write([]byte(row.TableInfo.GetSchemaName()), []byte(row.TableInfo.GetTableName()))
+	}
 
 	dispatchCols := row.Columns
 	if len(dispatchCols) == 0 {
@@ -64,6 +70,20 @@ func (r *ColumnsDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent,
 			"columns not found when dispatch event, table: %v, columns: %v", row.TableInfo.GetTableName(), r.Columns)
 	}
 
+	if r.keyOnly {
+		values := make([]interface{}, 0, len(r.Columns))
+		for idx := 0; idx < len(r.Columns); idx++ {
+			var value interface{}
+			if col := dispatchCols[offsets[idx]]; col != nil {
+				value = col.Value
+			}
+			values = append(values, value)
+		}
+		key := encodeKey(values)
+		r.hasher.Write([]byte(key))
+		return int32(r.hasher.Sum32() % uint32(partitionNum)), key, nil
+	}
+
 	for idx := 0; idx < len(r.Columns); idx++ {
 		col := dispatchCols[offsets[idx]]
 		if col == nil {
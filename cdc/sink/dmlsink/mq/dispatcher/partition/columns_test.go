@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,12 +48,59 @@ func TestColumnsDispatcher(t *testing.T) {
 		},
 	}
 
-	p := NewColumnsDispatcher([]string{"col-2", "col-not-found"})
+	p := NewColumnsDispatcher([]string{"col-2", "col-not-found"}, hash.FunctionDefault)
 	_, _, err := p.DispatchRowChangedEvent(event, 16)
 	require.ErrorIs(t, err, errors.ErrDispatcherFailed)
 
-	p = NewColumnsDispatcher([]string{"col2", "col1"})
+	p = NewColumnsDispatcher([]string{"col2", "col1"}, hash.FunctionDefault)
 	index, _, err := p.DispatchRowChangedEvent(event, 16)
 	require.NoError(t, err)
 	require.Equal(t, int32(15), index)
 }
+
+func TestColumnsDispatcherWithMurmur2(t *testing.T) {
+	t.Parallel()
+
+	tidbTableInfo := &timodel.TableInfo{
+		ID:   100,
+		Name: pmodel.NewCIStr("t1"),
+		Columns: []*timodel.ColumnInfo{
+			{ID: 1, Name: pmodel.NewCIStr("col1"), Offset: 0, FieldType: *types.NewFieldType(mysql.TypeVarchar)},
+			{ID: 2, Name: pmodel.NewCIStr("col2"), Offset: 1, FieldType: *types.NewFieldType(mysql.TypeVarchar)},
+		},
+	}
+	tableInfo := model.WrapTableInfo(100, "test", 33, tidbTableInfo)
+	newEvent := func(col1, col2 interface{}) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			TableInfo: tableInfo,
+			Columns: []*model.ColumnData{
+				{ColumnID: 1, Value: col1},
+				{ColumnID: 2, Value: col2},
+			},
+		}
+	}
+
+	// the values are quoted and joined, the partition is the same as
+	// the Kafka Java client producing the message with the encoded key.
+	p := NewColumnsDispatcher([]string{"col1", "col2"}, hash.FunctionMurmur2)
+	index, key, err := p.DispatchRowChangedEvent(newEvent("12", "3"), 16)
+	require.NoError(t, err)
+	require.Equal(t, `["12","3"]`, key)
+	require.Equal(t, int32(6), index)
+
+	// the keys of different values don't collide.
+	index, key, err = p.DispatchRowChangedEvent(newEvent("1", "23"), 16)
+	require.NoError(t, err)
+	require.Equal(t, `["1","23"]`, key)
+	require.Equal(t, int32(9), index)
+
+	_, key, err = p.DispatchRowChangedEvent(newEvent("a\",\"b", nil), 16)
+	require.NoError(t, err)
+	require.Equal(t, `["a\",\"b",null]`, key)
+
+	// a single value is sent as the key without quoting.
+	p = NewColumnsDispatcher([]string{"col2"}, hash.FunctionMurmur2)
+	_, key, err = p.DispatchRowChangedEvent(newEvent("1", "23"), 16)
+	require.NoError(t, err)
+	require.Equal(t, "23", key)
+}
@@ -23,9 +23,9 @@ type DefaultDispatcher struct {
 }
 
 // NewDefaultDispatcher creates a DefaultDispatcher.
-func NewDefaultDispatcher() *DefaultDispatcher {
+func NewDefaultDispatcher(hashFunction string) *DefaultDispatcher {
 	return &DefaultDispatcher{
-		tbd: NewTableDispatcher(),
+		tbd: NewTableDispatcher(hashFunction),
 	}
 }
 
@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/hash"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,7 +36,7 @@ func TestDefaultDispatcher(t *testing.T) {
 		Columns:   model.Columns2ColumnDatas(cols, tableInfo),
 	}
 
-	targetPartition, _, err := NewDefaultDispatcher().DispatchRowChangedEvent(row, 3)
+	targetPartition, _, err := NewDefaultDispatcher(hash.FunctionDefault).DispatchRowChangedEvent(row, 3)
 	require.NoError(t, err)
 	require.Equal(t, int32(0), targetPartition)
 }
@@ -14,6 +14,9 @@
 package partition
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/pingcap/tiflow/cdc/model"
 )
 
@@ -24,3 +27,33 @@ type Dispatcher interface {
 	// Concurrency Note: This method is thread-safe.
 	DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) (int32, string, error)
 }
+
+// encodeKey encodes the values of the key columns, the encoded key is hashed by
+// the non-default hash functions and sent as the key of the kafka message, so
+// the partition is the same as the one computed by the default partitioner of
+// kafka. A single value is encoded as its string, which is the same as the key
+// used by other systems for a single-column key, and NULL is encoded as an
+// empty key. Multiple values are quoted and joined like `["a","bc",null]`, so
+// the keys of different values never collide, such as ("ab","c") and ("a","bc").
+func encodeKey(values []interface{}) string {
+	if len(values) == 1 {
+		if values[0] == nil {
+			return ""
+		}
+		return model.ColumnValueString(values[0])
+	}
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, value := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if value == nil {
+			b.WriteString("null")
+			continue
+		}
+		b.WriteString(strconv.Quote(model.ColumnValueString(value)))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -26,16 +26,20 @@ import (
 
 // IndexValueDispatcher is a partition dispatcher which dispatches events based on the index value.
 type IndexValueDispatcher struct {
-	hasher *hash.PositionInertia
-	lock   sync.Mutex
+	hasher hash.Hasher
+	// keyOnly is true if only the encoded index values are hashed, the encoded
+	// key is returned as the partition key and sent as the key of the message.
+	keyOnly bool
+	lock    sync.Mutex
 
 	IndexName string
 }
 
 // NewIndexValueDispatcher creates a IndexValueDispatcher.
-func NewIndexValueDispatcher(indexName string) *IndexValueDispatcher {
+func NewIndexValueDispatcher(indexName string, hashFunction string) *IndexValueDispatcher {
 	return &IndexValueDispatcher{
-		hasher:    hash.NewPositionInertia(),
+		hasher:    hash.NewHasher(hashFunction),
+		keyOnly:   !hash.IsDefaultFunction(hashFunction),
 		IndexName: indexName,
 	}
 }
@@ -46,13 +50,16 @@ func (r *IndexValueDispatcher) DispatchRowChangedEvent(row *model.RowChangedEven
 	r.lock.Lock()
 	defer r.lock.Unlock()
 	r.hasher.Reset()
-	r.hasher.Write([]byte(row.TableInfo.GetSchemaName()), []byte(row.TableInfo.GetTableName()))
+	if !r.keyOnly {
+		r.hasher.Write([]byte(row.TableInfo.GetSchemaName()), []byte(row.TableInfo.GetTableName()))
+	}
 
 	dispatchCols := row.Columns
 	if len(row.Columns) == 0 {
 		dispatchCols = row.PreColumns
 	}
 
+	var values []interface{}
 	// the most normal case, index-name is not set, use the handle key columns.
 	if r.IndexName == "" {
 		tableInfo := row.TableInfo
@@ -60,7 +67,12 @@ func (r *IndexValueDispatcher) DispatchRowChangedEvent(row *model.RowChangedEven
 			if col == nil {
 				continue
 			}
-			if tableInfo.ForceGetColumnFlagType(col.ColumnID).IsHandleKey() {
+			if !tableInfo.ForceGetColumnFlagType(col.ColumnID).IsHandleKey() {
+				continue
+			}
+			if r.keyOnly {
+				values = append(values, col.Value)
+			} else {
 				r.hasher.Write([]byte(tableInfo.ForceGetColumnName(col.ColumnID)), []byte(model.ColumnValueString(col.Value)))
 			}
 		}
@@ -75,6 +87,14 @@ func (r *IndexValueDispatcher) DispatchRowChangedEvent(row *model.RowChangedEven
 		}
 		for idx := 0; idx < len(names); idx++ {
 			col := dispatchCols[offsets[idx]]
+			if r.keyOnly {
+				var value interface{}
+				if col != nil {
+					value = col.Value
+				}
+				values = append(values, value)
+				continue
+			}
 			if col == nil {
 				continue
 			}
@@ -82,6 +102,11 @@ func (r *IndexValueDispatcher) DispatchRowChangedEvent(row *model.RowChangedEven
 		}
 	}
 
+	if r.keyOnly {
+		key := encodeKey(values)
+		r.hasher.Write([]byte(key))
+		return int32(r.hasher.Sum32() % uint32(partitionNum)), key, nil
+	}
 	sum32 := r.hasher.Sum32()
 	return int32(sum32 % uint32(partitionNum)), strconv.FormatInt(int64(sum32), 10), nil
 }
@@ -14,6 +14,7 @@
 package partition
 
 import (
+	"strconv"
 	"testing"
 
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
@@ -22,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
 	"github.com/stretchr/testify/require"
 )
 
@@ -135,7 +137,7 @@ func TestIndexValueDispatcher(t *testing.T) {
 			}, tableInfoWithCompositePK),
 		}, expectPartition: 2},
 	}
-	p := NewIndexValueDispatcher("")
+	p := NewIndexValueDispatcher("", hash.FunctionDefault)
 	for _, tc := range testCases {
 		index, _, err := p.DispatchRowChangedEvent(tc.row, 16)
 		require.Equal(t, tc.expectPartition, index)
@@ -176,12 +178,41 @@ func TestIndexValueDispatcherWithIndexName(t *testing.T) {
 		},
 	}
 
-	p := NewIndexValueDispatcher("index2")
+	p := NewIndexValueDispatcher("index2", hash.FunctionDefault)
 	_, _, err := p.DispatchRowChangedEvent(event, 16)
 	require.ErrorIs(t, err, errors.ErrDispatcherFailed)
 
-	p = NewIndexValueDispatcher("index1")
+	p = NewIndexValueDispatcher("index1", hash.FunctionDefault)
 	index, _, err := p.DispatchRowChangedEvent(event, 16)
 	require.NoError(t, err)
 	require.Equal(t, int32(2), index)
 }
+
+func TestIndexValueDispatcherWithMurmur2(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := model.BuildTableInfo("test", "t1", []*model.Column{
+		{
+			Name: "a",
+			Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+		}, {
+			Name: "b",
+		},
+	}, [][]int{{0}})
+	// only the index value is hashed, the partition is the same as
+	// the Kafka Java client producing the message with the key "1" and "2".
+	p := NewIndexValueDispatcher("", hash.FunctionMurmur2)
+	for value, expected := range map[int]int32{1: 15, 2: 8} {
+		row := &model.RowChangedEvent{
+			TableInfo: tableInfo,
+			Columns: model.Columns2ColumnDatas([]*model.Column{
+				{Name: "a", Value: value},
+				{Name: "b", Value: 22},
+			}, tableInfo),
+		}
+		index, key, err := p.DispatchRowChangedEvent(row, 16)
+		require.NoError(t, err)
+		require.Equal(t, expected, index)
+		require.Equal(t, strconv.Itoa(value), key)
+	}
+}
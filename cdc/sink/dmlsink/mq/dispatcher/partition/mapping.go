@@ -0,0 +1,79 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+// MappingDispatcher is a partition dispatcher which dispatches events
+// to the given partitions, the partition is selected by the inner dispatcher.
+// It also verifies the partition number of the topic if it's expected.
+type MappingDispatcher struct {
+	inner Dispatcher
+
+	// Partitions are the partitions the events are dispatched to,
+	// all partitions of the topic are used if it's empty.
+	Partitions []int32
+	// PartitionNum is the expected partition number of the topic,
+	// it's not verified if it's 0.
+	PartitionNum int32
+}
+
+// NewMappingDispatcher creates a MappingDispatcher.
+func NewMappingDispatcher(inner Dispatcher, partitions []int32, partitionNum int32) *MappingDispatcher {
+	return &MappingDispatcher{
+		inner:        inner,
+		Partitions:   partitions,
+		PartitionNum: partitionNum,
+	}
+}
+
+// Inner returns the dispatcher which selects the partition.
+func (m *MappingDispatcher) Inner() Dispatcher {
+	return m.inner
+}
+
+// DispatchRowChangedEvent returns the target partition to which
+// a row changed event should be dispatched.
+func (m *MappingDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, partitionNum int32) (int32, string, error) {
+	if err := m.Verify(partitionNum); err != nil {
+		return 0, "", err
+	}
+	if len(m.Partitions) == 0 {
+		return m.inner.DispatchRowChangedEvent(row, partitionNum)
+	}
+	index, key, err := m.inner.DispatchRowChangedEvent(row, int32(len(m.Partitions)))
+	if err != nil {
+		return 0, "", err
+	}
+	return m.Partitions[index], key, nil
+}
+
+// Verify returns error if the partition number of the topic doesn't match the mapping.
+func (m *MappingDispatcher) Verify(partitionNum int32) error {
+	if m.PartitionNum != 0 && m.PartitionNum != partitionNum {
+		return errors.ErrDispatcherFailed.GenWithStack(
+			"the partition number of the topic is %d, but %d is expected", partitionNum, m.PartitionNum)
+	}
+	for _, partition := range m.Partitions {
+		if partition >= partitionNum {
+			return errors.ErrDispatcherFailed.GenWithStack(
+				"the partition %d is out of the range of the topic, the partition number is %d",
+				partition, partitionNum)
+		}
+	}
+	return nil
+}
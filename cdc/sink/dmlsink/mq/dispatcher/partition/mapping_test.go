@@ -0,0 +1,63 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappingDispatcher(t *testing.T) {
+	t.Parallel()
+
+	row := &model.RowChangedEvent{
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema: "test",
+				Table:  "t1",
+			},
+		},
+		CommitTs: 1,
+	}
+
+	// murmur2("`test`.`t1`") is 597676536, the same as the Kafka Java client.
+	index, key, err := NewTableDispatcher(hash.FunctionMurmur2).DispatchRowChangedEvent(row, 16)
+	require.NoError(t, err)
+	require.Equal(t, int32(8), index)
+	require.Equal(t, "`test`.`t1`", key)
+
+	// 597676536 % 3 = 0, so the first partition is selected.
+	p := NewMappingDispatcher(NewTableDispatcher(hash.FunctionMurmur2), []int32{3, 7, 9}, 0)
+	index, key, err = p.DispatchRowChangedEvent(row, 16)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), index)
+	require.Equal(t, "`test`.`t1`", key)
+
+	// the partition is out of the range of the topic.
+	_, _, err = p.DispatchRowChangedEvent(row, 8)
+	require.True(t, errors.ErrDispatcherFailed.Equal(err))
+
+	// the partition number of the topic is not expected.
+	p = NewMappingDispatcher(NewTableDispatcher(hash.FunctionDefault), nil, 16)
+	require.NoError(t, p.Verify(16))
+	_, _, err = p.DispatchRowChangedEvent(row, 8)
+	require.True(t, errors.ErrDispatcherFailed.Equal(err))
+	index, _, err = p.DispatchRowChangedEvent(row, 16)
+	require.NoError(t, err)
+	require.Equal(t, int32(15), index)
+}
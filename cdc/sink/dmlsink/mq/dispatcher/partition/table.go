@@ -23,14 +23,16 @@ import (
 // TableDispatcher is a partition dispatcher which dispatches events
 // based on the schema and table name.
 type TableDispatcher struct {
-	hasher *hash.PositionInertia
-	lock   sync.Mutex
+	hasher  hash.Hasher
+	keyOnly bool
+	lock    sync.Mutex
 }
 
 // NewTableDispatcher creates a TableDispatcher.
-func NewTableDispatcher() *TableDispatcher {
+func NewTableDispatcher(hashFunction string) *TableDispatcher {
 	return &TableDispatcher{
-		hasher: hash.NewPositionInertia(),
+		hasher:  hash.NewHasher(hashFunction),
+		keyOnly: !hash.IsDefaultFunction(hashFunction),
 	}
 }
 
@@ -41,6 +43,12 @@ func (t *TableDispatcher) DispatchRowChangedEvent(row *model.RowChangedEvent, pa
 	defer t.lock.Unlock()
 	t.hasher.Reset()
 	// distribute partition by table
+	if t.keyOnly {
+		// the quoted table name is hashed and sent as the key of the message.
+		key := row.TableInfo.TableName.QuoteString()
+		t.hasher.Write([]byte(key))
+		return int32(t.hasher.Sum32() % uint32(partitionNum)), key, nil
+	}
 	t.hasher.Write([]byte(row.TableInfo.GetSchemaName()), []byte(row.TableInfo.GetTableName()))
 	return int32(t.hasher.Sum32() % uint32(partitionNum)), row.TableInfo.TableName.String(), nil
 }
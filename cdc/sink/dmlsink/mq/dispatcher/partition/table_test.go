@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/hash"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,7 +92,7 @@ func TestTableDispatcher(t *testing.T) {
 			CommitTs: 3,
 		}, expectPartition: 3},
 	}
-	p := NewTableDispatcher()
+	p := NewTableDispatcher(hash.FunctionDefault)
 	for _, tc := range testCases {
 		index, _, err := p.DispatchRowChangedEvent(tc.row, 16)
 		require.NoError(t, err)
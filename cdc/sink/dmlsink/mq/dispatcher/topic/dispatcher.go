@@ -15,12 +15,15 @@ package topic
 
 import (
 	"fmt"
+	"regexp"
 )
 
 // Dispatcher is an abstraction for dispatching rows and ddls into different topics.
 type Dispatcher interface {
 	fmt.Stringer
 	Substitute(schema, table string) string
+	// Match returns true if the topic may be dispatched by the dispatcher.
+	Match(topic string) bool
 }
 
 // StaticTopicDispatcher is a topic dispatcher which dispatches rows and DDL to the specific topic.
@@ -40,6 +43,11 @@ func (s *StaticTopicDispatcher) Substitute(schema, table string) string {
 	return s.topic
 }
 
+// Match returns true if the topic is the specific topic.
+func (s *StaticTopicDispatcher) Match(topic string) bool {
+	return s.topic == topic
+}
+
 func (s *StaticTopicDispatcher) String() string {
 	return s.topic
 }
@@ -48,12 +56,14 @@ func (s *StaticTopicDispatcher) String() string {
 // dynamically to the target topics.
 type DynamicTopicDispatcher struct {
 	expression Expression
+	matchRE    *regexp.Regexp
 }
 
 // NewDynamicTopicDispatcher creates a DynamicTopicDispatcher.
 func NewDynamicTopicDispatcher(topicExpr Expression) *DynamicTopicDispatcher {
 	return &DynamicTopicDispatcher{
 		expression: topicExpr,
+		matchRE:    topicExpr.MatchRE(),
 	}
 }
 
@@ -62,6 +72,11 @@ func (d *DynamicTopicDispatcher) Substitute(schema, table string) string {
 	return d.expression.Substitute(schema, table)
 }
 
+// Match returns true if the topic may be substituted from the expression.
+func (d *DynamicTopicDispatcher) Match(topic string) bool {
+	return d.matchRE.MatchString(topic)
+}
+
 func (d *DynamicTopicDispatcher) String() string {
 	return string(d.expression)
 }
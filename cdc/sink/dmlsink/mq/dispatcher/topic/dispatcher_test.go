@@ -102,3 +102,17 @@ func TestDynamicTopicDispatcherForTable(t *testing.T) {
 		require.Equal(t, tc.expectedTopic, p.Substitute(tc.schema, tc.table))
 	}
 }
+
+func TestTopicDispatcherMatch(t *testing.T) {
+	t.Parallel()
+
+	p := NewStaticTopicDispatcher("cdctest")
+	require.True(t, p.Match("cdctest"))
+	require.False(t, p.Match("cdctest1"))
+
+	d := NewDynamicTopicDispatcher(Expression("hello_{schema}.{table}_world"))
+	require.True(t, d.Match(d.Substitute("db1", "tbl1")))
+	require.True(t, d.Match(d.Substitute("db-1", "tbl$1")))
+	require.False(t, d.Match("hello_db1_tbl1_world"))
+	require.False(t, d.Match("cdctest"))
+}
@@ -108,6 +108,15 @@ func (e Expression) Substitute(schema, table string) string {
 	return topicName
 }
 
+// MatchRE returns the regular expression matching the topic names substituted
+// from the expression.
+func (e Expression) MatchRE() *regexp.Regexp {
+	topicExpr := regexp.QuoteMeta(string(e))
+	topicExpr = strings.ReplaceAll(topicExpr, regexp.QuoteMeta("{schema}"), `[A-Za-z0-9\._\-]*`)
+	topicExpr = strings.ReplaceAll(topicExpr, regexp.QuoteMeta("{table}"), `[A-Za-z0-9\._\-]*`)
+	return regexp.MustCompile("^" + topicExpr + "$")
+}
+
 // PulsarValidate checks whether a pulsar topic name is valid or not.
 func (e Expression) PulsarValidate() error {
 	// validate the topic expression
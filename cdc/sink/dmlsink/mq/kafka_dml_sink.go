@@ -81,23 +81,25 @@ func NewKafkaDMLSink(
 		return nil, errors.Trace(err)
 	}
 
+	scheme := sink.GetScheme(sinkURI)
+	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, protocol, topic, scheme)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	topicConfig := options.DeriveTopicConfig()
+	topicConfig.TopicConfigOf = eventRouter.GetTopicConfig
 	topicManager, err := util.GetTopicManagerAndTryCreateTopic(
 		ctx,
 		changefeedID,
 		topic,
-		options.DeriveTopicConfig(),
+		topicConfig,
 		adminClient,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	scheme := sink.GetScheme(sinkURI)
-	eventRouter, err := dispatcher.NewEventRouter(replicaConfig, protocol, topic, scheme)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	trans, err := columnselector.New(replicaConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -113,17 +115,36 @@ func NewKafkaDMLSink(
 		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
 	}
 
+	metricsCollector := factory.MetricsCollector(tiflowutil.RoleProcessor, adminClient)
+	if options.EnableTransaction {
+		// The bootstrap messages are sent by the encoder group out of any transaction.
+		if replicaConfig.Sink.ShouldSendBootstrapMsg() {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"the bootstrap message of simple protocol is not supported if the transaction is enabled")
+		}
+		txnWorker := newTxnWorker(changefeedID, protocol, factory, options.TransactionalID,
+			encoderBuilder, metricsCollector)
+		s := newTxnDMLSink(ctx, changefeedID, txnWorker, adminClient, topicManager, eventRouter, trans,
+			protocol, scheme, replicaConfig.Sink.KafkaConfig.GetOutputRawChangeEvent(), errCh)
+		s.txnMetadataEncoder = getTxnMetadataEncoder(encoderBuilder)
+		log.Info("DML sink transactional producer enabled",
+			zap.String("namespace", changefeedID.Namespace),
+			zap.String("changefeedID", changefeedID.ID),
+			zap.String("transactionalID", options.TransactionalID))
+		return s, nil
+	}
+
 	failpointCh := make(chan error, 1)
 	asyncProducer, err := factory.AsyncProducer(ctx, failpointCh)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
 	}
 
-	metricsCollector := factory.MetricsCollector(tiflowutil.RoleProcessor, adminClient)
 	dmlProducer := producerCreator(ctx, changefeedID, asyncProducer, metricsCollector, errCh, failpointCh)
 	encoderGroup := codec.NewEncoderGroup(replicaConfig.Sink, encoderBuilder, changefeedID)
 	s := newDMLSink(ctx, changefeedID, dmlProducer, adminClient, topicManager, eventRouter, trans, encoderGroup,
 		protocol, scheme, replicaConfig.Sink.KafkaConfig.GetOutputRawChangeEvent(), errCh)
+	s.txnMetadataEncoder = getTxnMetadataEncoder(encoderBuilder)
 	log.Info("DML sink producer created",
 		zap.String("namespace", changefeedID.Namespace),
 		zap.String("changefeedID", changefeedID.ID))
@@ -238,20 +238,26 @@ func (m *kafkaTopicManager) createTopic(
 				"and %s not found", topicName))
 	}
 
+	detail := m.cfg.DesiredTopicDetail(topicName)
+	if detail == nil {
+		detail = &kafka.TopicDetail{
+			Name:              topicName,
+			NumPartitions:     m.cfg.PartitionNum,
+			ReplicationFactor: m.cfg.ReplicationFactor,
+		}
+	}
+
 	start := time.Now()
-	err := m.admin.CreateTopic(ctx, &kafka.TopicDetail{
-		Name:              topicName,
-		NumPartitions:     m.cfg.PartitionNum,
-		ReplicationFactor: m.cfg.ReplicationFactor,
-	}, false)
+	err := m.admin.CreateTopic(ctx, detail, false)
 	if err != nil {
 		log.Error(
 			"Kafka admin client create the topic failed",
 			zap.String("namespace", m.changefeedID.Namespace),
 			zap.String("changefeed", m.changefeedID.ID),
 			zap.String("topic", topicName),
-			zap.Int32("partitionNumber", m.cfg.PartitionNum),
-			zap.Int16("replicationFactor", m.cfg.ReplicationFactor),
+			zap.Int32("partitionNumber", detail.NumPartitions),
+			zap.Int16("replicationFactor", detail.ReplicationFactor),
+			zap.Any("configEntries", detail.ConfigEntries),
 			zap.Error(err),
 			zap.Duration("duration", time.Since(start)),
 		)
@@ -263,13 +269,14 @@ func (m *kafkaTopicManager) createTopic(
 		zap.String("namespace", m.changefeedID.Namespace),
 		zap.String("changefeed", m.changefeedID.ID),
 		zap.String("topic", topicName),
-		zap.Int32("partitionNumber", m.cfg.PartitionNum),
-		zap.Int16("replicationFactor", m.cfg.ReplicationFactor),
+		zap.Int32("partitionNumber", detail.NumPartitions),
+		zap.Int16("replicationFactor", detail.ReplicationFactor),
+		zap.Any("configEntries", detail.ConfigEntries),
 		zap.Duration("duration", time.Since(start)),
 	)
-	m.tryUpdatePartitionsAndLogging(topicName, m.cfg.PartitionNum)
+	m.tryUpdatePartitionsAndLogging(topicName, detail.NumPartitions)
 
-	return m.cfg.PartitionNum, nil
+	return detail.NumPartitions, nil
 }
 
 // CreateTopicAndWaitUntilVisible wraps createTopic and waitUntilTopicVisible together.
@@ -287,6 +294,13 @@ func (m *kafkaTopicManager) CreateTopicAndWaitUntilVisible(
 		numPartition := detail.NumPartitions
 		if topicName == m.defaultTopic {
 			numPartition = m.cfg.PartitionNum
+		} else if desired := m.cfg.DesiredTopicDetail(topicName); desired != nil {
+			// the topic may be created before, or by others, make it match the dispatch rule.
+			numPartition, err = kafka.ReconcileTopic(ctx, m.admin, m.changefeedID, detail, desired,
+				m.cfg.AllowPartitionGrowth(topicName))
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
 		}
 		m.tryUpdatePartitionsAndLogging(topicName, numPartition)
 		return numPartition, nil
@@ -18,7 +18,9 @@ import (
 	"testing"
 
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -101,3 +103,77 @@ func TestCreateTopicWithDelay(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int32(2), partitionNum)
 }
+
+func TestCreateAndReconcileTopicByRule(t *testing.T) {
+	t.Parallel()
+
+	adminClient := kafka.NewClusterAdminClientMockImpl()
+	defer adminClient.Close()
+	topicConfig := &config.TopicConfig{
+		PartitionNum:  util.AddressOf(int32(4)),
+		RetentionMs:   util.AddressOf(int64(86400000)),
+		CleanupPolicy: util.AddressOf("compact"),
+	}
+	cfg := &kafka.AutoCreateTopicConfig{
+		AutoCreate:        true,
+		PartitionNum:      2,
+		ReplicationFactor: 1,
+		TopicConfigOf: func(topic string) *config.TopicConfig {
+			if topic == "rule-topic" {
+				return topicConfig
+			}
+			return nil
+		},
+	}
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	ctx := context.Background()
+	manager := NewKafkaTopicManager(ctx, kafka.DefaultMockTopicName, changefeedID, adminClient, cfg)
+	defer manager.Close()
+
+	// the topic is created by the topic config of the rule.
+	partitionNum, err := manager.CreateTopicAndWaitUntilVisible(ctx, "rule-topic")
+	require.NoError(t, err)
+	require.Equal(t, int32(4), partitionNum)
+	retention, err := adminClient.GetTopicConfig(ctx, "rule-topic", kafka.RetentionMsConfigName)
+	require.NoError(t, err)
+	require.Equal(t, "86400000", retention)
+	cleanupPolicy, err := adminClient.GetTopicConfig(ctx, "rule-topic", kafka.CleanupPolicyConfigName)
+	require.NoError(t, err)
+	require.Equal(t, "compact", cleanupPolicy)
+
+	// the other topics are not affected.
+	partitionNum, err = manager.CreateTopicAndWaitUntilVisible(ctx, "other-topic")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), partitionNum)
+
+	// the partitions are not added to the existing topic by default.
+	topicConfig.PartitionNum = util.AddressOf(int32(6))
+	manager = NewKafkaTopicManager(ctx, kafka.DefaultMockTopicName, changefeedID, adminClient, cfg)
+	defer manager.Close()
+	partitionNum, err = manager.GetPartitionNum(ctx, "rule-topic")
+	require.NoError(t, err)
+	require.Equal(t, int32(4), partitionNum)
+	partitions, err := adminClient.GetTopicsPartitionsNum(ctx, []string{"rule-topic"})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), partitions["rule-topic"])
+
+	// the partitions are added to the existing topic if the growth is allowed.
+	topicConfig.AllowPartitionGrowth = util.AddressOf(true)
+	manager = NewKafkaTopicManager(ctx, kafka.DefaultMockTopicName, changefeedID, adminClient, cfg)
+	defer manager.Close()
+	partitionNum, err = manager.GetPartitionNum(ctx, "rule-topic")
+	require.NoError(t, err)
+	require.Equal(t, int32(6), partitionNum)
+	partitions, err = adminClient.GetTopicsPartitionsNum(ctx, []string{"rule-topic"})
+	require.NoError(t, err)
+	require.Equal(t, int32(6), partitions["rule-topic"])
+
+	// the partitions can not be removed, the existing partition number is used.
+	topicConfig.PartitionNum = util.AddressOf(int32(3))
+	manager = NewKafkaTopicManager(ctx, kafka.DefaultMockTopicName, changefeedID, adminClient, cfg)
+	defer manager.Close()
+	partitionNum, err = manager.GetPartitionNum(ctx, "rule-topic")
+	require.NoError(t, err)
+	require.Equal(t, int32(6), partitionNum)
+}
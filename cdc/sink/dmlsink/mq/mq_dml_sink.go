@@ -55,7 +55,9 @@ type dmlSink struct {
 		// It is also responsible for creating topics.
 		topicManager manager.TopicManager
 		worker       *worker
-		isDead       bool
+		// txnWorker is used instead of worker if the kafka transaction is enabled.
+		txnWorker *txnWorker
+		isDead    bool
 	}
 
 	// adminClient is used to query kafka cluster information, it's shared among
@@ -70,6 +72,20 @@ type dmlSink struct {
 
 	scheme               string
 	outputRawChangeEvent bool
+
+	// txnMetadataEncoder encodes the BEGIN and END events of each transaction,
+	// it's nil if the transaction metadata is disabled.
+	txnMetadataEncoder codec.TxnMetadataEncoder
+}
+
+// getTxnMetadataEncoder returns the transaction metadata encoder of the
+// encoder builder, or nil if the protocol doesn't emit the transaction metadata.
+func getTxnMetadataEncoder(encoderBuilder codec.RowEventEncoderBuilder) codec.TxnMetadataEncoder {
+	builder, ok := encoderBuilder.(codec.TxnMetadataEncoderBuilder)
+	if !ok {
+		return nil
+	}
+	return builder.BuildTxnMetadataEncoder()
 }
 
 func newDMLSink(
@@ -108,36 +124,85 @@ func newDMLSink(
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
-		err := s.alive.worker.run(ctx)
+		s.handleWorkerExit(ctx, s.alive.worker.run(ctx), errCh)
+	}()
 
-		s.alive.Lock()
-		s.alive.isDead = true
-		s.alive.worker.close()
-		s.alive.Unlock()
-		close(s.dead)
+	return s
+}
 
-		if err != nil {
-			if errors.Cause(err) == context.Canceled {
-				err = context.Cause(ctx)
-			}
-			select {
-			case errCh <- err:
-				log.Warn("mq dml sink meet error",
-					zap.String("namespace", s.id.Namespace),
-					zap.String("changefeed", s.id.ID),
-					zap.Error(err))
-			default:
-				log.Info("mq dml sink meet error, ignored",
-					zap.String("namespace", s.id.Namespace),
-					zap.String("changefeed", s.id.ID),
-					zap.Error(err))
-			}
-		}
+// newTxnDMLSink creates a dml sink which sends the events of each table
+// in kafka transactions.
+func newTxnDMLSink(
+	ctx context.Context,
+	changefeedID model.ChangeFeedID,
+	txnWorker *txnWorker,
+	adminClient kafka.ClusterAdminClient,
+	topicManager manager.TopicManager,
+	eventRouter *dispatcher.EventRouter,
+	transformer transformer.Transformer,
+	protocol config.Protocol,
+	scheme string,
+	outputRawChangeEvent bool,
+	errCh chan error,
+) *dmlSink {
+	ctx, cancel := context.WithCancelCause(ctx)
+	s := &dmlSink{
+		id:                   changefeedID,
+		protocol:             protocol,
+		adminClient:          adminClient,
+		ctx:                  ctx,
+		cancel:               cancel,
+		dead:                 make(chan struct{}),
+		scheme:               scheme,
+		outputRawChangeEvent: outputRawChangeEvent,
+	}
+	s.alive.transformer = transformer
+	s.alive.eventRouter = eventRouter
+	s.alive.topicManager = topicManager
+	s.alive.txnWorker = txnWorker
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.handleWorkerExit(ctx, s.alive.txnWorker.run(ctx), errCh)
 	}()
 
 	return s
 }
 
+// handleWorkerExit marks the sink as dead after the worker exited,
+// and reports the error of the worker.
+func (s *dmlSink) handleWorkerExit(ctx context.Context, err error, errCh chan error) {
+	s.alive.Lock()
+	s.alive.isDead = true
+	if s.alive.worker != nil {
+		s.alive.worker.close()
+	}
+	if s.alive.txnWorker != nil {
+		s.alive.txnWorker.close()
+	}
+	s.alive.Unlock()
+	close(s.dead)
+
+	if err != nil {
+		if errors.Cause(err) == context.Canceled {
+			err = context.Cause(ctx)
+		}
+		select {
+		case errCh <- err:
+			log.Warn("mq dml sink meet error",
+				zap.String("namespace", s.id.Namespace),
+				zap.String("changefeed", s.id.ID),
+				zap.Error(err))
+		default:
+			log.Info("mq dml sink meet error, ignored",
+				zap.String("namespace", s.id.Namespace),
+				zap.String("changefeed", s.id.ID),
+				zap.Error(err))
+		}
+	}
+}
+
 // WriteEvents writes events to the sink.
 // This is an asynchronously and thread-safe method.
 func (s *dmlSink) WriteEvents(txns ...*dmlsink.CallbackableEvent[*model.SingleTableTxn]) error {
@@ -158,6 +223,8 @@ func (s *dmlSink) WriteEvents(txns ...*dmlsink.CallbackableEvent[*model.SingleTa
 		}
 	}
 
+	// All events are sent in one transaction if the kafka transaction is enabled.
+	var batch []mqEvent
 	for _, txn := range txns {
 		if txn.GetTableSinkState() != state.TableSinkSinking {
 			// The table where the event comes from is in stopping, so it's safe
@@ -165,8 +232,32 @@ func (s *dmlSink) WriteEvents(txns ...*dmlsink.CallbackableEvent[*model.SingleTa
 			txn.Callback()
 			continue
 		}
-		rowCallback := toRowCallback(txn.Callback, uint64(len(txn.Event.Rows)))
-		for _, row := range txn.Event.Rows {
+		var begin, end *mqEvent
+		totalCount := uint64(len(txn.Event.Rows))
+		if s.txnMetadataEncoder != nil {
+			var err error
+			begin, end, err = s.encodeTxnMetadata(txn.Event)
+			if err != nil {
+				s.cancel(err)
+				return errors.Trace(err)
+			}
+			// The transaction is flushed after its BEGIN and END events are sent.
+			totalCount += 2
+		}
+		rowCallback := toRowCallback(txn.Callback, totalCount)
+		if begin != nil {
+			begin.message.Callback = rowCallback
+			end.message.Callback = rowCallback
+			if s.alive.txnWorker != nil {
+				batch = append(batch, *begin)
+			} else {
+				s.alive.worker.msgChan.In() <- *begin
+			}
+		}
+		for i, row := range txn.Event.Rows {
+			if begin != nil {
+				row.TxnEventOrder = int64(i + 1)
+			}
 			topic := s.alive.eventRouter.GetTopicForRowChange(row)
 			partitionNum, err := s.alive.topicManager.GetPartitionNum(s.ctx, topic)
 			failpoint.Inject("MQSinkGetPartitionError", func() {
@@ -191,15 +282,14 @@ func (s *dmlSink) WriteEvents(txns ...*dmlsink.CallbackableEvent[*model.SingleTa
 				return errors.Trace(err)
 			}
 
-			// This never be blocked because this is an unbounded channel.
-			// We already limit the memory usage by MemoryQuota at SinkManager level.
-			// So it is safe to send the event to a unbounded channel here.
-			s.alive.worker.msgChan.In() <- mqEvent{
+			event := mqEvent{
 				key: model.TopicPartitionKey{
 					Topic:          topic,
 					Partition:      index,
 					PartitionKey:   key,
 					TotalPartition: partitionNum,
+					HashedKey: s.alive.eventRouter.IsKeyHashed(
+						row.TableInfo.GetSchemaName(), row.TableInfo.GetTableName()),
 				},
 				rowEvent: &dmlsink.RowChangeCallbackableEvent{
 					Event:     row,
@@ -207,11 +297,50 @@ func (s *dmlSink) WriteEvents(txns ...*dmlsink.CallbackableEvent[*model.SingleTa
 					SinkState: txn.SinkState,
 				},
 			}
+			if s.alive.txnWorker != nil {
+				batch = append(batch, event)
+				continue
+			}
+			// This never be blocked because this is an unbounded channel.
+			// We already limit the memory usage by MemoryQuota at SinkManager level.
+			// So it is safe to send the event to a unbounded channel here.
+			s.alive.worker.msgChan.In() <- event
+		}
+		if end != nil {
+			if s.alive.txnWorker != nil {
+				batch = append(batch, *end)
+			} else {
+				s.alive.worker.msgChan.In() <- *end
+			}
 		}
 	}
+	if len(batch) != 0 {
+		s.alive.txnWorker.batchChan.In() <- batch
+	}
 	return nil
 }
 
+// encodeTxnMetadata encodes the BEGIN and END events of the transaction,
+// they are sent to the first partition of the transaction topic.
+func (s *dmlSink) encodeTxnMetadata(txn *model.SingleTableTxn) (*mqEvent, *mqEvent, error) {
+	topic := s.txnMetadataEncoder.TransactionTopic()
+	// GetPartitionNum creates the topic if it doesn't exist.
+	partitionNum, err := s.alive.topicManager.GetPartitionNum(s.ctx, topic)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	beginMsg, endMsg, err := s.txnMetadataEncoder.EncodeTxnEvents(txn)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	key := model.TopicPartitionKey{
+		Topic:          topic,
+		Partition:      0,
+		TotalPartition: partitionNum,
+	}
+	return &mqEvent{key: key, message: beginMsg}, &mqEvent{key: key, message: endMsg}, nil
+}
+
 // Close closes the sink.
 func (s *dmlSink) Close() {
 	if s.cancel != nil {
@@ -15,6 +15,7 @@ package mq
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"testing"
@@ -26,8 +27,10 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
 	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/kafka"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 )
 
 func TestNewKafkaDMLSinkFailed(t *testing.T) {
@@ -112,3 +115,185 @@ func TestWriteEvents(t *testing.T) {
 	require.Len(t, errCh, 0)
 	require.Len(t, s.alive.worker.producer.(*dmlproducer.MockDMLProducer).GetAllEvents(), 3000)
 }
+
+func TestWriteEventsInTransaction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uriTemplate := "kafka://%s/%s?kafka-version=2.4.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1&enable-transaction=true" +
+		"&kafka-client-id=unit-test&auto-create-topic=false&compression=gzip&protocol=open-protocol"
+	uri := fmt.Sprintf(uriTemplate, "127.0.0.1:9092", kafka.DefaultMockTopicName)
+
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 1)
+
+	ctx = context.WithValue(ctx, "testing.T", t)
+	changefeedID := model.DefaultChangeFeedID("test")
+	s, err := NewKafkaDMLSink(ctx, changefeedID, sinkURI, replicaConfig, errCh,
+		kafka.NewMockFactory, dmlproducer.NewDMLMockProducer)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	defer s.Close()
+	require.NotNil(t, s.alive.txnWorker)
+
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+
+	sql := `create table test.t(a varchar(255) primary key)`
+	job := helper.DDL2Job(sql)
+	tableInfo := model.WrapTableInfo(0, "test", 1, job.BinlogInfo.TableInfo)
+
+	tableStatus := state.TableSinkSinking
+	var flushed atomic.Int64
+	newEvents := func() []*dmlsink.CallbackableEvent[*model.SingleTableTxn] {
+		events := make([]*dmlsink.CallbackableEvent[*model.SingleTableTxn], 0, 10)
+		for i := 0; i < 10; i++ {
+			events = append(events, &dmlsink.TxnCallbackableEvent{
+				Event: &model.SingleTableTxn{
+					Rows: []*model.RowChangedEvent{{
+						CommitTs:        uint64(i + 1),
+						PhysicalTableID: tableInfo.ID,
+						TableInfo:       tableInfo,
+						Columns: model.Columns2ColumnDatas(
+							[]*model.Column{{Name: "a", Value: fmt.Sprintf("a%d", i)}}, tableInfo),
+					}},
+				},
+				Callback:  func() { flushed.Inc() },
+				SinkState: &tableStatus,
+			})
+		}
+		return events
+	}
+
+	// the events passed by one call are committed in one transaction.
+	require.NoError(t, s.WriteEvents(newEvents()...))
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 10
+	}, 5*time.Second, 10*time.Millisecond)
+	producer := s.alive.txnWorker.producers[tableInfo.ID].producer.(*kafka.MockTransactionalProducer)
+	require.NotEmpty(t, producer.CommittedMessages())
+	require.Len(t, errCh, 0)
+
+	// the producer is fenced after the table is moved to another capture.
+	transactionalID := fmt.Sprintf("ticdc-default-test-%d", tableInfo.ID)
+	_, err = s.alive.txnWorker.factory.TransactionalProducer(ctx, transactionalID)
+	require.NoError(t, err)
+	require.NoError(t, s.WriteEvents(newEvents()...))
+	select {
+	case err = <-errCh:
+		require.True(t, cerror.ErrKafkaProducerFenced.Equal(err))
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the fenced error is not reported")
+	}
+	require.Equal(t, int64(10), flushed.Load())
+}
+
+func TestWriteEventsWithTxnMetadata(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uriTemplate := "kafka://%s/%s?kafka-version=0.9.0.0&max-batch-size=1" +
+		"&max-message-bytes=1048576&partition-num=1" +
+		"&kafka-client-id=unit-test&auto-create-topic=true&protocol=debezium"
+	uri := fmt.Sprintf(uriTemplate, "127.0.0.1:9092", kafka.DefaultMockTopicName)
+
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.Debezium.TransactionTopic = "transactions"
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	errCh := make(chan error, 1)
+
+	ctx = context.WithValue(ctx, "testing.T", t)
+	changefeedID := model.DefaultChangeFeedID("test")
+	s, err := NewKafkaDMLSink(ctx, changefeedID, sinkURI, replicaConfig, errCh,
+		kafka.NewMockFactory, dmlproducer.NewDMLMockProducer)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	defer s.Close()
+	require.NotNil(t, s.txnMetadataEncoder)
+
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+
+	sql := `create table test.t(a varchar(255) primary key)`
+	job := helper.DDL2Job(sql)
+	tableInfo := model.WrapTableInfo(0, "test", 1, job.BinlogInfo.TableInfo)
+
+	tableStatus := state.TableSinkSinking
+	var flushed atomic.Int64
+	txn := &model.SingleTableTxn{
+		PhysicalTableID: tableInfo.ID,
+		TableInfo:       tableInfo,
+		StartTs:         1,
+		CommitTs:        2,
+	}
+	for i := 0; i < 2; i++ {
+		txn.Rows = append(txn.Rows, &model.RowChangedEvent{
+			StartTs:         1,
+			CommitTs:        2,
+			PhysicalTableID: tableInfo.ID,
+			TableInfo:       tableInfo,
+			Columns: model.Columns2ColumnDatas(
+				[]*model.Column{{Name: "a", Value: fmt.Sprintf("a%d", i)}}, tableInfo),
+		})
+	}
+	require.NoError(t, s.WriteEvents(&dmlsink.TxnCallbackableEvent{
+		Event:     txn,
+		Callback:  func() { flushed.Inc() },
+		SinkState: &tableStatus,
+	}))
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, errCh, 0)
+
+	producer := s.alive.worker.producer.(*dmlproducer.MockDMLProducer)
+	txnID := fmt.Sprintf("1:%d", tableInfo.ID)
+	metadata := producer.GetEvents("transactions", 0)
+	require.Len(t, metadata, 2)
+	for i, status := range []string{"BEGIN", "END"} {
+		var value struct {
+			Payload struct {
+				Status          string `json:"status"`
+				ID              string `json:"id"`
+				EventCount      *int64 `json:"event_count"`
+				DataCollections []struct {
+					DataCollection string `json:"data_collection"`
+					EventCount     int64  `json:"event_count"`
+				} `json:"data_collections"`
+			} `json:"payload"`
+		}
+		require.NoError(t, json.Unmarshal(metadata[i].Value, &value))
+		require.Equal(t, status, value.Payload.Status)
+		require.Equal(t, txnID, value.Payload.ID)
+		if status == "END" {
+			require.Equal(t, int64(2), *value.Payload.EventCount)
+			require.Len(t, value.Payload.DataCollections, 1)
+			require.Equal(t, "test.t", value.Payload.DataCollections[0].DataCollection)
+			require.Equal(t, int64(2), value.Payload.DataCollections[0].EventCount)
+		} else {
+			require.Nil(t, value.Payload.EventCount)
+		}
+	}
+
+	rows := producer.GetEvents(kafka.DefaultMockTopicName, 0)
+	require.Len(t, rows, 2)
+	for i, row := range rows {
+		var value struct {
+			Payload struct {
+				Transaction struct {
+					ID         string `json:"id"`
+					TotalOrder int64  `json:"total_order"`
+				} `json:"transaction"`
+			} `json:"payload"`
+		}
+		require.NoError(t, json.Unmarshal(row.Value, &value))
+		require.Equal(t, txnID, value.Payload.Transaction.ID)
+		require.Equal(t, int64(i+1), value.Payload.Transaction.TotalOrder)
+	}
+}
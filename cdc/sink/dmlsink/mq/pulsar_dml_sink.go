@@ -125,6 +125,7 @@ func NewPulsarDMLSink(
 
 	s := newDMLSink(ctx, changefeedID, p, nil, topicManager, eventRouter, trans, encoderGroup,
 		protocol, scheme, pConfig.GetOutputRawChangeEvent(), errCh)
+	s.txnMetadataEncoder = getTxnMetadataEncoder(encoderBuilder)
 
 	return s, nil
 }
@@ -0,0 +1,322 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
+	"github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/mq"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink/state"
+	"github.com/pingcap/tiflow/pkg/chann"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// txnProducerIdleTimeout is the duration after which the transactional producer
+// of a table is closed if no event of the table is received, the table is
+// likely removed from this capture.
+const txnProducerIdleTimeout = 5 * time.Minute
+
+// txnProducer is the transactional producer of a table.
+type txnProducer struct {
+	producer kafka.TransactionalProducer
+	lastUsed time.Time
+}
+
+// txnWorker sends messages to kafka in transactions.
+//
+// All events of a WriteEvents call come from one table and they are all the
+// events of the table up to a resolved ts. They are written in one transaction,
+// and the callbacks are called after the transaction is committed, so the
+// checkpoint of the table never passes the messages which are not committed.
+//
+// Each table has its own producer with a stable transactional id. When the
+// table is moved to another capture, the producer created by the new capture
+// fences the old one, and the messages of the stale capture are aborted.
+//
+// The delivery is still at-least-once. The progress of the sink is only
+// recorded by the checkpoint of the changefeed, which is not a part of the
+// kafka transaction. If the changefeed is restarted or the table is moved
+// after a transaction is committed but before the checkpoint passes it, the
+// events are replicated from the checkpoint again and the committed
+// transaction is sent twice. Consumers still need to be idempotent, such as
+// deduplicating the rows by the commit ts.
+type txnWorker struct {
+	// changeFeedID indicates this sink belongs to which processor(changefeed).
+	changeFeedID model.ChangeFeedID
+	// protocol indicates the protocol used by this sink.
+	protocol config.Protocol
+	// batchChan caches the events of each WriteEvents call.
+	// It is an unbounded channel.
+	batchChan *chann.DrainableChann[[]mqEvent]
+
+	encoderBuilder codec.RowEventEncoderBuilder
+	encoder        codec.RowEventEncoder
+
+	factory kafka.Factory
+	// transactionalID is the prefix of the transactional id of each table.
+	transactionalID string
+	producers       map[model.TableID]*txnProducer
+
+	metricsCollector kafka.MetricsCollector
+	// statistics is used to record DML metrics.
+	statistics *metrics.Statistics
+}
+
+func newTxnWorker(
+	id model.ChangeFeedID,
+	protocol config.Protocol,
+	factory kafka.Factory,
+	transactionalID string,
+	encoderBuilder codec.RowEventEncoderBuilder,
+	metricsCollector kafka.MetricsCollector,
+) *txnWorker {
+	return &txnWorker{
+		changeFeedID:     id,
+		protocol:         protocol,
+		batchChan:        chann.NewAutoDrainChann[[]mqEvent](),
+		encoderBuilder:   encoderBuilder,
+		encoder:          encoderBuilder.Build(),
+		factory:          factory,
+		transactionalID:  transactionalID,
+		producers:        make(map[model.TableID]*txnProducer),
+		metricsCollector: metricsCollector,
+		statistics:       metrics.NewStatistics(id, sink.RowSink),
+	}
+}
+
+// run starts a loop that keeps sending the batches in transactions
+// until it encounters an error or is interrupted.
+func (w *txnWorker) run(ctx context.Context) (retErr error) {
+	defer func() {
+		log.Info("MQ sink transaction worker exited", zap.Error(retErr),
+			zap.String("namespace", w.changeFeedID.Namespace),
+			zap.String("changefeed", w.changeFeedID.ID),
+			zap.String("protocol", w.protocol.String()),
+		)
+	}()
+
+	g, ctx := errgroup.WithContext(ctx)
+	if w.metricsCollector != nil {
+		g.Go(func() error {
+			w.metricsCollector.Run(ctx)
+			return nil
+		})
+	}
+	g.Go(func() error {
+		return w.sendBatches(ctx)
+	})
+	return g.Wait()
+}
+
+func (w *txnWorker) sendBatches(ctx context.Context) error {
+	metricSendMessageDuration := mq.WorkerSendMessageDuration.WithLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+	defer mq.WorkerSendMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+
+	ticker := time.NewTicker(txnProducerIdleTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+			w.closeIdleProducers()
+		case batch, ok := <-w.batchChan.Out():
+			if !ok {
+				log.Warn("MQ sink transaction worker channel closed",
+					zap.String("namespace", w.changeFeedID.Namespace),
+					zap.String("changefeed", w.changeFeedID.ID))
+				return nil
+			}
+			start := time.Now()
+			if err := w.sendBatch(ctx, batch); err != nil {
+				return errors.Trace(err)
+			}
+			metricSendMessageDuration.Observe(time.Since(start).Seconds())
+		}
+	}
+}
+
+// sendBatch encodes the events of a table and sends them in one transaction.
+func (w *txnWorker) sendBatch(ctx context.Context, batch []mqEvent) error {
+	events := make([]mqEvent, 0, len(batch))
+	var metadata []mqEvent
+	for _, event := range batch {
+		if event.message != nil {
+			metadata = append(metadata, event)
+			continue
+		}
+		// Skip this event when the table is stopping.
+		if event.rowEvent.GetTableSinkState() != state.TableSinkSinking {
+			event.rowEvent.Callback()
+			continue
+		}
+		w.statistics.ObserveRows(event.rowEvent.Event)
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		// The transaction metadata is skipped along with the events of the stopping table.
+		for _, event := range metadata {
+			event.message.Callback()
+		}
+		return nil
+	}
+	tableID := events[0].rowEvent.Event.GetTableID()
+
+	// Group events by the TopicPartitionKey, the order of events
+	// in the same partition is kept.
+	var keys []model.TopicPartitionKey
+	groupedEvents := make(map[model.TopicPartitionKey][]*dmlsink.RowChangeCallbackableEvent)
+	for _, event := range events {
+		if _, ok := groupedEvents[event.key]; !ok {
+			keys = append(keys, event.key)
+		}
+		groupedEvents[event.key] = append(groupedEvents[event.key], event.rowEvent)
+	}
+	var (
+		messages []*kafka.TxnMessage
+		rows     int
+		bytes    int64
+	)
+	for _, key := range keys {
+		for _, event := range groupedEvents[key] {
+			if err := w.encoder.AppendRowChangedEvent(ctx, key.Topic, event.Event, event.Callback); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, message := range w.encoder.Build() {
+			setPartitionKey(message, key)
+			messages = append(messages, &kafka.TxnMessage{
+				Topic:     key.Topic,
+				Partition: key.Partition,
+				Message:   message,
+			})
+			rows += message.GetRowsCount()
+			bytes += int64(message.Length())
+		}
+	}
+
+	// The transaction metadata events are committed along with the events,
+	// the order of them in the transaction topic is kept.
+	for _, event := range metadata {
+		messages = append(messages, &kafka.TxnMessage{
+			Topic:     event.key.Topic,
+			Partition: event.key.Partition,
+			Message:   event.message,
+		})
+	}
+
+	producer, err := w.getProducer(ctx, tableID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
+		if err := producer.SendMessagesInTxn(ctx, messages); err != nil {
+			return 0, 0, err
+		}
+		return rows, bytes, nil
+	})
+	if err != nil {
+		if cerror.ErrKafkaProducerFenced.Equal(err) {
+			w.removeProducer(tableID)
+			// The table has been moved to another capture during the transaction,
+			// the new owner replicates the events again, so it's safe to drop them.
+			if events[0].rowEvent.GetTableSinkState() != state.TableSinkSinking {
+				log.Info("transactional producer of stopped table is fenced, skip the events",
+					zap.String("namespace", w.changeFeedID.Namespace),
+					zap.String("changefeed", w.changeFeedID.ID),
+					zap.Int64("tableID", tableID),
+					zap.Error(err))
+				for _, event := range events {
+					event.rowEvent.Callback()
+				}
+				for _, event := range metadata {
+					event.message.Callback()
+				}
+				return nil
+			}
+		}
+		return err
+	}
+	for _, message := range messages {
+		if message.Message.Callback != nil {
+			message.Message.Callback()
+		}
+	}
+	return nil
+}
+
+// getProducer returns the transactional producer of the table,
+// it's created on demand with the transactional id of the table.
+func (w *txnWorker) getProducer(
+	ctx context.Context, tableID model.TableID,
+) (kafka.TransactionalProducer, error) {
+	if p, ok := w.producers[tableID]; ok {
+		p.lastUsed = time.Now()
+		return p.producer, nil
+	}
+	transactionalID := fmt.Sprintf("%s-%d", w.transactionalID, tableID)
+	producer, err := w.factory.TransactionalProducer(ctx, transactionalID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+	}
+	log.Info("transactional producer created",
+		zap.String("namespace", w.changeFeedID.Namespace),
+		zap.String("changefeed", w.changeFeedID.ID),
+		zap.Int64("tableID", tableID),
+		zap.String("transactionalID", transactionalID))
+	w.producers[tableID] = &txnProducer{producer: producer, lastUsed: time.Now()}
+	return producer, nil
+}
+
+func (w *txnWorker) removeProducer(tableID model.TableID) {
+	if p, ok := w.producers[tableID]; ok {
+		p.producer.Close()
+		delete(w.producers, tableID)
+	}
+}
+
+func (w *txnWorker) closeIdleProducers() {
+	for tableID, p := range w.producers {
+		if time.Since(p.lastUsed) >= txnProducerIdleTimeout {
+			log.Info("close idle transactional producer",
+				zap.String("namespace", w.changeFeedID.Namespace),
+				zap.String("changefeed", w.changeFeedID.ID),
+				zap.Int64("tableID", tableID))
+			w.removeProducer(tableID)
+		}
+	}
+}
+
+func (w *txnWorker) close() {
+	w.batchChan.CloseAndDrain()
+	for tableID := range w.producers {
+		w.removeProducer(tableID)
+	}
+	w.encoderBuilder.CleanMetrics()
+	w.statistics.Close()
+	mq.WorkerSendMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace, w.changeFeedID.ID)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -46,6 +47,8 @@ const (
 type mqEvent struct {
 	key      model.TopicPartitionKey
 	rowEvent *dmlsink.RowChangeCallbackableEvent
+	// message is an encoded transaction metadata event, rowEvent is nil if it's set.
+	message *common.Message
 }
 
 // worker will send messages to the DML producer on a batch basis.
@@ -133,6 +136,12 @@ func (w *worker) nonBatchEncodeRun(ctx context.Context) error {
 					zap.String("changefeed", w.changeFeedID.ID))
 				return nil
 			}
+			if event.message != nil {
+				if err := w.encoderGroup.AddMessages(ctx, event.key, event.message); err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
 			if event.rowEvent.GetTableSinkState() != state.TableSinkSinking {
 				event.rowEvent.Callback()
 				log.Debug("Skip event of stopped table",
@@ -180,6 +189,13 @@ func (w *worker) batchEncodeRun(ctx context.Context) (retErr error) {
 		metricBatchDuration.Observe(time.Since(start).Seconds())
 
 		msgs := msgsBuf[:msgCount]
+		// A transaction metadata event always ends the batch, it's added after
+		// the rows to keep the order between them.
+		var txnMetadata *mqEvent
+		if last := msgs[len(msgs)-1]; last.message != nil {
+			txnMetadata = &last
+			msgs = msgs[:len(msgs)-1]
+		}
 		// Group messages by its TopicPartitionKey before adding them to the encoder group.
 		groupedMsgs := w.group(msgs)
 		for key, msg := range groupedMsgs {
@@ -187,11 +203,17 @@ func (w *worker) batchEncodeRun(ctx context.Context) (retErr error) {
 				return errors.Trace(err)
 			}
 		}
+		if txnMetadata != nil {
+			if err := w.encoderGroup.AddMessages(ctx, txnMetadata.key, txnMetadata.message); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	}
 }
 
 // batch collects a batch of messages from w.msgChan into buffer.
-// It returns the number of messages collected.
+// It returns the number of messages collected, the batch is ended
+// after a transaction metadata event.
 // Note: It will block until at least one message is received.
 func (w *worker) batch(
 	ctx context.Context, buffer []mqEvent, flushInterval time.Duration,
@@ -208,6 +230,10 @@ func (w *worker) batch(
 			log.Warn("MQ sink flush worker channel closed")
 			return msgCount, nil
 		}
+		if msg.message != nil {
+			buffer[msgCount] = msg
+			return msgCount + 1, nil
+		}
 		if msg.rowEvent != nil {
 			w.statistics.ObserveRows(msg.rowEvent.Event)
 			buffer[msgCount] = msg
@@ -228,6 +254,10 @@ func (w *worker) batch(
 				return msgCount, nil
 			}
 
+			if msg.message != nil {
+				buffer[msgCount] = msg
+				return msgCount + 1, nil
+			}
 			if msg.rowEvent != nil {
 				w.statistics.ObserveRows(msg.rowEvent.Event)
 				buffer[msgCount] = msg
@@ -286,7 +316,7 @@ func (w *worker) sendMessages(ctx context.Context) error {
 			for _, message := range future.Messages {
 				start := time.Now()
 				if err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
-					message.SetPartitionKey(future.Key.PartitionKey)
+					setPartitionKey(message, future.Key)
 					if err := w.producer.AsyncSendMessage(
 						ctx,
 						future.Key.Topic,
@@ -304,6 +334,16 @@ func (w *worker) sendMessages(ctx context.Context) error {
 	}
 }
 
+// setPartitionKey sets the partition key of the message, the key is also sent
+// as the key of the kafka message if the partition is computed by hashing it,
+// so the default partitioner of kafka computes the same partition.
+func setPartitionKey(message *common.Message, key model.TopicPartitionKey) {
+	message.SetPartitionKey(key.PartitionKey)
+	if key.HashedKey {
+		message.Key = []byte(key.PartitionKey)
+	}
+}
+
 func (w *worker) close() {
 	w.msgChan.CloseAndDrain()
 	w.producer.Close()
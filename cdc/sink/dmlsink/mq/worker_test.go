@@ -164,6 +164,59 @@ func TestBatchEncode_Batch(t *testing.T) {
 	require.Equal(t, 512, endIndex)
 }
 
+func TestBatchEncode_BatchEndsWithTxnMetadata(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker, _ := newBatchEncodeWorker(t)
+	defer worker.close()
+	key := model.TopicPartitionKey{
+		Topic:     "test",
+		Partition: 1,
+	}
+	txnKey := model.TopicPartitionKey{
+		Topic:     "transactions",
+		Partition: 0,
+	}
+	tableStatus := state.TableSinkSinking
+	cols := []*model.Column{{Name: "col1", Type: 1, Value: "aa"}}
+	tableInfo := model.BuildTableInfo("a", "b", cols, nil)
+	row := &model.RowChangedEvent{
+		CommitTs:  1,
+		TableInfo: tableInfo,
+		Columns:   model.Columns2ColumnDatas(cols, tableInfo),
+	}
+
+	worker.msgChan.In() <- mqEvent{key: txnKey, message: &common.Message{}}
+	for i := 0; i < 3; i++ {
+		worker.msgChan.In() <- mqEvent{
+			key: key,
+			rowEvent: &dmlsink.RowChangeCallbackableEvent{
+				Event:     row,
+				Callback:  func() {},
+				SinkState: &tableStatus,
+			},
+		}
+	}
+	worker.msgChan.In() <- mqEvent{key: txnKey, message: &common.Message{}}
+
+	// The BEGIN event is batched alone, and the END event ends the batch of rows.
+	batch := make([]mqEvent, 512)
+	endIndex, err := worker.batch(ctx, batch, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, endIndex)
+	require.NotNil(t, batch[0].message)
+
+	endIndex, err = worker.batch(ctx, batch, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 4, endIndex)
+	for i := 0; i < 3; i++ {
+		require.NotNil(t, batch[i].rowEvent)
+	}
+	require.NotNil(t, batch[3].message)
+}
+
 func TestBatchEncode_Group(t *testing.T) {
 	t.Parallel()
 
@@ -579,3 +632,16 @@ func TestNonBatchEncodeWorker_Abort(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+func TestSetPartitionKey(t *testing.T) {
+	t.Parallel()
+
+	message := &common.Message{}
+	setPartitionKey(message, model.TopicPartitionKey{PartitionKey: "123"})
+	require.Equal(t, "123", message.GetPartitionKey())
+	require.Nil(t, message.Key)
+
+	// the hashed key is sent as the key of the kafka message.
+	setPartitionKey(message, model.TopicPartitionKey{PartitionKey: `["1","23"]`, HashedKey: true})
+	require.Equal(t, []byte(`["1","23"]`), message.Key)
+}
@@ -30,10 +30,13 @@ type txnEvent struct {
 	*dmlsink.TxnCallbackableEvent
 	start            time.Time
 	conflictResolved time.Time
+	// serializeTable makes the transactions of the same table conflict with
+	// each other, so they are written in order.
+	serializeTable bool
 }
 
-func newTxnEvent(event *dmlsink.TxnCallbackableEvent) *txnEvent {
-	return &txnEvent{TxnCallbackableEvent: event, start: time.Now()}
+func newTxnEvent(event *dmlsink.TxnCallbackableEvent, serializeTable bool) *txnEvent {
+	return &txnEvent{TxnCallbackableEvent: event, start: time.Now(), serializeTable: serializeTable}
 }
 
 func (e *txnEvent) OnConflictResolved() {
@@ -42,7 +45,29 @@ func (e *txnEvent) OnConflictResolved() {
 
 // ConflictKeys implements causality.txnEvent interface.
 func (e *txnEvent) ConflictKeys() []uint64 {
-	return genTxnKeys(e.TxnCallbackableEvent.Event)
+	keys := genTxnKeys(e.TxnCallbackableEvent.Event)
+	if e.serializeTable && len(e.Event.Rows) != 0 {
+		// use the logical table ID since the partitions of a table share
+		// the same checkpoint.
+		keys = appendTableKey(keys, e.Event.Rows[0].TableInfo.ID)
+	}
+	return keys
+}
+
+// appendTableKey appends the hash key of the table to the keys if it's absent.
+func appendTableKey(keys []uint64, tableID int64) []uint64 {
+	hasher := fnv.New32a()
+	key := genTableKey(tableID)
+	if n, err := hasher.Write(key); n != len(key) || err != nil {
+		log.Panic("transaction key hash fail")
+	}
+	tableKey := uint64(hasher.Sum32())
+	for _, k := range keys {
+		if k == tableKey {
+			return keys
+		}
+	}
+	return append(keys, tableKey)
 }
 
 // genTxnKeys returns deduplicated hash keys of a transaction.
@@ -93,13 +118,17 @@ func genRowKeys(row *model.RowChangedEvent) [][]byte {
 		// use table ID as key if no key generated (no PK/UK),
 		// no concurrence for rows in the same table.
 		log.Debug("Use table id as the key", zap.Int64("tableID", row.GetTableID()))
-		tableKey := make([]byte, 8)
-		binary.BigEndian.PutUint64(tableKey, uint64(row.GetTableID()))
-		keys = [][]byte{tableKey}
+		keys = [][]byte{genTableKey(row.GetTableID())}
 	}
 	return keys
 }
 
+func genTableKey(tableID int64) []byte {
+	tableKey := make([]byte, 8)
+	binary.BigEndian.PutUint64(tableKey, uint64(tableID))
+	return tableKey
+}
+
 func genKeyList(columns []*model.ColumnData, tb *model.TableInfo, iIdx int, colIdx []int, tableID int64) []byte {
 	var key []byte
 	for _, i := range colIdx {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink"
 	"github.com/stretchr/testify/require"
 )
 
@@ -199,3 +200,45 @@ func TestGenKeys(t *testing.T) {
 		require.Equal(t, tc.expected, keys)
 	}
 }
+
+func TestConflictKeysWithSerializeTable(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := model.BuildTableInfo("test", "t", []*model.Column{
+		{
+			Name: "id",
+			Type: mysql.TypeLong,
+			Flag: model.BinaryFlag | model.HandleKeyFlag | model.PrimaryKeyFlag,
+		},
+	}, [][]int{{0}})
+	newEvent := func(id int, serializeTable bool) *txnEvent {
+		return newTxnEvent(&dmlsink.TxnCallbackableEvent{
+			Event: &model.SingleTableTxn{
+				Rows: []*model.RowChangedEvent{{
+					PhysicalTableID: 47,
+					TableInfo:       tableInfo,
+					Columns: model.Columns2ColumnDatas([]*model.Column{
+						{Name: "id", Value: id},
+					}, tableInfo),
+				}},
+			},
+		}, serializeTable)
+	}
+	intersect := func(a, b []uint64) bool {
+		for _, x := range a {
+			for _, y := range b {
+				if x == y {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	// the transactions on different rows don't conflict.
+	require.False(t, intersect(newEvent(1, false).ConflictKeys(), newEvent(2, false).ConflictKeys()))
+	// the transactions of the same table conflict if the table is serialized.
+	first := newEvent(1, true).ConflictKeys()
+	require.Len(t, first, 2)
+	require.True(t, intersect(first, newEvent(2, true).ConflictKeys()))
+}
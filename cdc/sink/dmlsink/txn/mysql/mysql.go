@@ -38,7 +38,9 @@ import (
 	"github.com/pingcap/tiflow/cdc/sink/metrics/txn"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/integrity"
 	"github.com/pingcap/tiflow/pkg/retry"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -60,11 +62,13 @@ const (
 )
 
 type mysqlBackend struct {
-	workerID    int
-	changefeed  string
-	db          *sql.DB
-	cfg         *pmysql.Config
-	dmlMaxRetry uint64
+	workerID     int
+	changefeed   string
+	changefeedID model.ChangeFeedID
+	clusterID    string
+	db           *sql.DB
+	cfg          *pmysql.Config
+	dmlMaxRetry  uint64
 
 	events []*dmlsink.TxnCallbackableEvent
 	rows   int
@@ -79,6 +83,9 @@ type mysqlBackend struct {
 	// Indicate if the CachePrepStmts should be enabled or not
 	cachePrepStmts   bool
 	maxAllowedPacket int64
+
+	// checksumVerifier is not nil if the row level checksum is verified before writing.
+	checksumVerifier *common.ChecksumVerifier
 }
 
 // NewMySQLBackends creates a new MySQL sink using schema storage
@@ -115,6 +122,12 @@ func NewMySQLBackends(
 		return nil, err
 	}
 
+	if cfg.EnableCheckpointTable {
+		if err = pmysql.CreateCheckpointTable(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+
 	// By default, cache-prep-stmts=true, an LRU cache is used for prepared statements,
 	// two connections are required to process a transaction.
 	// The first connection is held in the tx variable, which is used to manage the transaction.
@@ -173,15 +186,24 @@ func NewMySQLBackends(
 		maxAllowedPacket = int64(variable.DefMaxAllowedPacket)
 	}
 
+	var checksumVerifier *common.ChecksumVerifier
+	if cfg.VerifyChecksum {
+		reporter := integrity.NewCorruptionReporter(
+			changefeedID.Namespace, changefeedID.ID, "mysql", cfg.Integrity)
+		checksumVerifier = common.NewRowChecksumVerifier(reporter, config.GetGlobalServerConfig().TZ)
+	}
+
 	backends := make([]*mysqlBackend, 0, cfg.WorkerCount)
 	for i := 0; i < cfg.WorkerCount; i++ {
 		backends = append(backends, &mysqlBackend{
-			workerID:    i,
-			changefeed:  changefeed,
-			db:          db,
-			cfg:         cfg,
-			dmlMaxRetry: defaultDMLMaxRetry,
-			statistics:  statistics,
+			workerID:     i,
+			changefeed:   changefeed,
+			changefeedID: changefeedID,
+			clusterID:    config.GetGlobalServerConfig().ClusterID,
+			db:           db,
+			cfg:          cfg,
+			dmlMaxRetry:  defaultDMLMaxRetry,
+			statistics:   statistics,
 
 			metricTxnSinkDMLBatchCommit:     txn.SinkDMLBatchCommit.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 			metricTxnSinkDMLBatchCallback:   txn.SinkDMLBatchCallback.WithLabelValues(changefeedID.Namespace, changefeedID.ID),
@@ -189,13 +211,15 @@ func NewMySQLBackends(
 			stmtCache:                       stmtCache,
 			cachePrepStmts:                  cachePrepStmts,
 			maxAllowedPacket:                maxAllowedPacket,
+			checksumVerifier:                checksumVerifier,
 		})
 	}
 
 	log.Info("MySQL backends is created",
 		zap.String("changefeed", changefeed),
 		zap.Int("workerCount", cfg.WorkerCount),
-		zap.Bool("forceReplicate", cfg.ForceReplicate))
+		zap.Bool("forceReplicate", cfg.ForceReplicate),
+		zap.Bool("enableCheckpointTable", cfg.EnableCheckpointTable))
 	return backends, nil
 }
 
@@ -224,6 +248,10 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 		s.statistics.ObserveRows(event.Event.Rows...)
 	}
 
+	if err := s.verifyChecksum(); err != nil {
+		return errors.Trace(err)
+	}
+
 	dmls := s.prepareDMLs()
 	log.Debug("prepare DMLs", zap.String("changefeed", s.changefeed), zap.Any("rows", s.rows),
 		zap.Strings("sqls", dmls.sqls), zap.Any("values", dmls.values))
@@ -256,6 +284,9 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 
 // Close implements interface backend.
 func (s *mysqlBackend) Close() (err error) {
+	if s.checksumVerifier != nil {
+		s.checksumVerifier.Close()
+	}
 	if s.stmtCache != nil {
 		s.stmtCache.Purge()
 	}
@@ -266,6 +297,30 @@ func (s *mysqlBackend) Close() (err error) {
 	return
 }
 
+// verifyChecksum verifies the row level checksum of the buffered rows,
+// the mismatched rows are reported and written as is if the error is not returned.
+func (s *mysqlBackend) verifyChecksum() error {
+	if s.checksumVerifier == nil {
+		return nil
+	}
+	for _, event := range s.events {
+		for _, row := range event.Event.Rows {
+			if row.Checksum == nil {
+				continue
+			}
+			if err := s.checksumVerifier.VerifyRow(row); err != nil {
+				log.Error("row level checksum mismatch",
+					zap.String("changefeed", s.changefeed),
+					zap.Int("workerID", s.workerID),
+					zap.Uint64("commitTs", row.CommitTs),
+					zap.Error(err))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // MaxFlushInterval implements interface backend.
 func (s *mysqlBackend) MaxFlushInterval() time.Duration {
 	return maxFlushInterval
@@ -516,6 +571,9 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 
 	rowCount := 0
 	approximateSize := int64(0)
+	// checkpoints are the commit ts of the last transactions of the tables,
+	// which are upserted into the checkpoint table in the same transaction.
+	var checkpoints []pmysql.CheckpointRow
 	for _, event := range s.events {
 		if len(event.Event.Rows) == 0 {
 			continue
@@ -523,6 +581,9 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 		rowCount += len(event.Event.Rows)
 
 		firstRow := event.Event.Rows[0]
+		if s.cfg.EnableCheckpointTable {
+			checkpoints = updateCheckpoints(checkpoints, firstRow.TableInfo.TableName, event.Event.CommitTs)
+		}
 		if len(startTs) == 0 || startTs[len(startTs)-1] != firstRow.StartTs {
 			startTs = append(startTs, firstRow.StartTs)
 		}
@@ -611,6 +672,13 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 		}
 	}
 
+	if len(checkpoints) != 0 {
+		query, args := pmysql.PrepareCheckpointUpsert(s.clusterID, s.changefeedID, checkpoints...)
+		sqls = append(sqls, query)
+		values = append(values, args)
+		approximateSize += int64(len(query))
+	}
+
 	if len(callbacks) == 0 {
 		callbacks = nil
 	}
@@ -625,6 +693,25 @@ func (s *mysqlBackend) prepareDMLs() *preparedDMLs {
 	}
 }
 
+// updateCheckpoints sets the checkpoint of the table to the commit ts.
+func updateCheckpoints(
+	checkpoints []pmysql.CheckpointRow, table model.TableName, commitTs model.Ts,
+) []pmysql.CheckpointRow {
+	for i := range checkpoints {
+		if checkpoints[i].Schema == table.Schema && checkpoints[i].Table == table.Table {
+			if checkpoints[i].CheckpointTs < commitTs {
+				checkpoints[i].CheckpointTs = commitTs
+			}
+			return checkpoints
+		}
+	}
+	return append(checkpoints, pmysql.CheckpointRow{
+		Schema:       table.Schema,
+		Table:        table.Table,
+		CheckpointTs: commitTs,
+	})
+}
+
 // execute SQLs in the multi statements way.
 func (s *mysqlBackend) multiStmtExecute(
 	ctx context.Context, dmls *preparedDMLs, tx *sql.Tx, writeTimeout time.Duration,
@@ -238,6 +238,56 @@ func TestPrepareDML(t *testing.T) {
 	}
 }
 
+func TestPrepareDMLWithCheckpointTable(t *testing.T) {
+	t.Parallel()
+
+	tableInfoA := model.BuildTableInfo("test", "a", []*model.Column{
+		{Name: "id", Type: mysql.TypeLong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+	}, [][]int{{0}})
+	tableInfoB := model.BuildTableInfo("test", "b", []*model.Column{
+		{Name: "id", Type: mysql.TypeLong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+	}, [][]int{{0}})
+	newTxn := func(tableInfo *model.TableInfo, commitTs model.Ts) *dmlsink.TxnCallbackableEvent {
+		return &dmlsink.TxnCallbackableEvent{
+			Event: &model.SingleTableTxn{
+				CommitTs: commitTs,
+				Rows: []*model.RowChangedEvent{{
+					StartTs:   commitTs - 1,
+					CommitTs:  commitTs,
+					TableInfo: tableInfo,
+					Columns: model.Columns2ColumnDatas([]*model.Column{
+						{Name: "id", Value: int64(commitTs)},
+					}, tableInfo),
+				}},
+			},
+		}
+	}
+
+	ms := newMySQLBackendWithoutDB()
+	ms.cfg.EnableCheckpointTable = true
+	ms.clusterID = "default"
+	ms.changefeedID = model.DefaultChangeFeedID("test")
+	ms.events = []*dmlsink.TxnCallbackableEvent{
+		newTxn(tableInfoA, 10), newTxn(tableInfoB, 11), newTxn(tableInfoA, 12),
+	}
+	ms.rows = 3
+	dmls := ms.prepareDMLs()
+	require.Len(t, dmls.sqls, 4)
+	require.Equal(t, "INSERT INTO `tidb_cdc`.`checkpoint_v1` "+
+		"(ticdc_cluster_id, namespace, changefeed, schema_name, table_name, checkpoint_ts) "+
+		"VALUES (?,?,?,?,?,?),(?,?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE checkpoint_ts = VALUES(checkpoint_ts)", dmls.sqls[3])
+	require.Equal(t, []interface{}{
+		"default", "default", "test", "test", "a", uint64(12),
+		"default", "default", "test", "test", "b", uint64(11),
+	}, dmls.values[3])
+
+	// the checkpoint table is disabled
+	ms.cfg.EnableCheckpointTable = false
+	dmls = ms.prepareDMLs()
+	require.Len(t, dmls.sqls, 3)
+}
+
 func TestAdjustSQLMode(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
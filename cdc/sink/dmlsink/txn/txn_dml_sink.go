@@ -56,6 +56,9 @@ type dmlSink struct {
 	statistics *metrics.Statistics
 
 	scheme string
+	// serializeTables is true if the transactions of a table are written in
+	// order, it's required by the checkpoint table of the mysql sink.
+	serializeTables bool
 }
 
 // GetDBConnImpl is the implementation of pmysql.IDBConnectionFactory.
@@ -71,6 +74,11 @@ func NewMySQLSink(
 	errCh chan<- error,
 	conflictDetectorSlots uint64,
 ) (*dmlSink, error) {
+	serializeTables, err := pmysql.IsCheckpointTableEnabled(sinkURI, replicaConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	statistics := metrics.NewStatistics(changefeedID, sink.TxnSink)
 
@@ -89,6 +97,7 @@ func NewMySQLSink(
 	s.statistics = statistics
 	s.cancel = cancel
 	s.scheme = sink.GetScheme(sinkURI)
+	s.serializeTables = serializeTables
 
 	return s, nil
 }
@@ -156,7 +165,7 @@ func (s *dmlSink) WriteEvents(txnEvents ...*dmlsink.TxnCallbackableEvent) error
 			txn.Callback()
 			continue
 		}
-		s.alive.conflictDetector.Add(newTxnEvent(txn))
+		s.alive.conflictDetector.Add(newTxnEvent(txn, s.serializeTables))
 	}
 	return nil
 }
@@ -37,6 +37,10 @@ type TableSink interface {
 	// that have been flushed to the downstream.
 	// This is a thread-safe method.
 	GetLastSyncedTs() model.Ts
+	// GetPendingEventCount returns the number of events and resolved ts
+	// that are written to the backend sink but not flushed to the downstream.
+	// This is a thread-safe method.
+	GetPendingEventCount() int
 	// Close closes the table sink.
 	// After it returns, no more events will be sent out from this capture.
 	Close()
@@ -195,6 +195,12 @@ func (e *EventTableSink[E, P]) GetLastSyncedTs() model.Ts {
 	return e.lastSyncedTs.getLastSyncedTs()
 }
 
+// GetPendingEventCount returns the number of events and resolved ts
+// that are written to the backend sink but not flushed to the downstream.
+func (e *EventTableSink[E, P]) GetPendingEventCount() int {
+	return e.progressTracker.trackingCount()
+}
+
 // Close closes the table sink.
 // After it returns, no more events will be sent out from this capture.
 func (e *EventTableSink[E, P]) Close() {
@@ -17,8 +17,9 @@ import (
 	"fmt"
 	"strings"
 
-	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/filter"
@@ -26,9 +27,14 @@ import (
 )
 
 var (
-	table   string
-	ddl     string
-	cfgPath string
+	table          string
+	ddl            string
+	ddlType        string
+	changedColumns string
+	cfgPath        string
+	samplePath     string
+	sinkURI        string
+	partitionNum   int32
 )
 
 func main() {
@@ -40,6 +46,17 @@ func main() {
 	rootCmd.Flags().StringVarP(&cfgPath, "config", "c", "", "changefeed config file path")
 	rootCmd.Flags().StringVarP(&table, "table", "t", "", "table name, format: [schema].[table] ")
 	rootCmd.Flags().StringVarP(&ddl, "ddl", "d", "", "ddl query")
+	rootCmd.Flags().StringVar(&ddlType, "ddl-type", string(bf.CreateTable),
+		"ddl event type of the ddl query, such as 'drop table' or 'add column'")
+	rootCmd.Flags().StringVar(&changedColumns, "changed-columns", "",
+		"columns changed by an update event, format: [column1],[column2]")
+	rootCmd.Flags().StringVar(&samplePath, "sample", "",
+		"json file of sample tables and rows, simulate the changefeed on them if specified")
+	rootCmd.Flags().StringVar(&sinkURI, "sink-uri", "",
+		"sink uri of the changefeed, used to simulate the topic and partition dispatching")
+	rootCmd.Flags().Int32Var(&partitionNum, "partition-num", 0,
+		"partition number of each topic used in simulation, "+
+			"default to the partition-num in sink uri or 3")
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 	}
@@ -49,6 +66,10 @@ func runFilter(cmd *cobra.Command, args []string) {
 	// fmt.Printf("Filter Rules: %v\n", filterRules)
 	// fmt.Printf("Schema Name: %s\n", schemaName)
 	// fmt.Printf("Table Name: %s\n", tableName)
+	if samplePath != "" {
+		runSimulator()
+		return
+	}
 	cfg := &config.ReplicaConfig{}
 	err := util.StrictDecodeFile(cfgPath, "cdc filter helper", cfg)
 	if err != nil {
@@ -69,6 +90,8 @@ func runFilter(cmd *cobra.Command, args []string) {
 	target := "table"
 	if ddl != "" {
 		target = "ddl"
+	} else if changedColumns != "" {
+		target = "update"
 	}
 
 	switch target {
@@ -80,8 +103,12 @@ func runFilter(cmd *cobra.Command, args []string) {
 		}
 		fmt.Printf("Table: %s, Not matched filter rule\n", table)
 	case "ddl":
-		ddlType := timodel.ActionCreateTable
-		discard := ft.ShouldDiscardDDL(ddlType, tableAndSchema[0], tableAndSchema[1])
+		actionType, ok := filter.DDLActionFromEventType(bf.EventType(strings.ToLower(ddlType)))
+		if !ok {
+			fmt.Printf("the ddl type is invalid: %s\n", ddlType)
+			return
+		}
+		discard := ft.ShouldDiscardDDL(actionType, tableAndSchema[0], tableAndSchema[1])
 		if discard {
 			fmt.Printf("DDL: %s, should be discard by event filter rule\n", ddl)
 			return
@@ -89,7 +116,7 @@ func runFilter(cmd *cobra.Command, args []string) {
 		ignored, err := ft.ShouldIgnoreDDLEvent(&model.DDLEvent{
 			StartTs: uint64(0),
 			Query:   ddl,
-			Type:    ddlType,
+			Type:    actionType,
 			TableInfo: &model.TableInfo{
 				TableName: model.TableName{
 					Schema: tableAndSchema[0],
@@ -106,8 +133,62 @@ func runFilter(cmd *cobra.Command, args []string) {
 			return
 		}
 		fmt.Printf("DDL: %s, should not be discard by event filter rule\n", ddl)
+	case "update":
+		ignored, err := shouldIgnoreUpdate(ft, tableAndSchema[0], tableAndSchema[1],
+			strings.Split(changedColumns, ","))
+		if err != nil {
+			fmt.Printf("filter update error: %s, error: %v\n", changedColumns, err)
+			return
+		}
+		if ignored {
+			fmt.Printf("Update changed columns: %s, should be ignored by event filter rule\n", changedColumns)
+			return
+		}
+		fmt.Printf("Update changed columns: %s, should not be ignored by event filter rule\n", changedColumns)
 	default:
 		fmt.Printf("unknown target: %s", target)
 
 	}
 }
+
+// shouldIgnoreUpdate checks whether an update event which only changes
+// the given columns of the table is ignored by the event filter rules.
+// Value expressions are not evaluated, since there is no row data.
+func shouldIgnoreUpdate(ft filter.Filter, schema, table string, columns []string) (bool, error) {
+	cols := make([]*model.Column, 0, len(columns))
+	for _, name := range columns {
+		cols = append(cols, &model.Column{
+			Name: strings.TrimSpace(name),
+			Type: mysql.TypeVarchar,
+			Flag: model.NullableFlag,
+		})
+	}
+	tableInfo := model.BuildTableInfo(schema, table, cols, nil)
+	preColumns := make([]*model.ColumnData, 0, len(cols))
+	newColumns := make([]*model.ColumnData, 0, len(cols))
+	for _, col := range tableInfo.Columns {
+		preColumns = append(preColumns, &model.ColumnData{ColumnID: col.ID, Value: "old"})
+		newColumns = append(newColumns, &model.ColumnData{ColumnID: col.ID, Value: "new"})
+	}
+	return ft.ShouldIgnoreDMLEvent(&model.RowChangedEvent{
+		TableInfo:  tableInfo,
+		PreColumns: preColumns,
+		Columns:    newColumns,
+	}, model.RowChangedDatums{}, tableInfo)
+}
+
+func runSimulator() {
+	s, err := newSimulator(cfgPath, sinkURI, partitionNum)
+	if err != nil {
+		fmt.Printf("simulator create error: %v\n", err)
+		return
+	}
+	sample, err := readSampleFile(samplePath)
+	if err != nil {
+		fmt.Printf("read sample file error: %v\n", err)
+		return
+	}
+	if err := s.run(sample); err != nil {
+		fmt.Printf("simulate error: %v\n", err)
+	}
+}
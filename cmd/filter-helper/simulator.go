@@ -0,0 +1,409 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/transformer/columnselector"
+	sinkutil "github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/sink"
+	tiflowutil "github.com/pingcap/tiflow/pkg/util"
+)
+
+// sampleFile is the content of the json file used by the simulator, e.g.
+//
+//	{
+//	  "tables": [{
+//	    "schema": "test", "table": "user",
+//	    "columns": [
+//	      {"name": "id", "type": "bigint", "primary-key": true},
+//	      {"name": "name", "type": "varchar", "nullable": true}
+//	    ],
+//	    "rows": [
+//	      {"type": "insert", "columns": {"id": 1, "name": "alice"}},
+//	      {"type": "update", "pre-columns": {"id": 1, "name": "alice"}, "columns": {"id": 1, "name": "bob"}},
+//	      {"type": "delete", "pre-columns": {"id": 1, "name": "bob"}}
+//	    ]
+//	  }]
+//	}
+type sampleFile struct {
+	Tables []*sampleTable `json:"tables"`
+}
+
+type sampleTable struct {
+	Schema  string          `json:"schema"`
+	Table   string          `json:"table"`
+	Columns []*sampleColumn `json:"columns"`
+	Rows    []*sampleRow    `json:"rows"`
+}
+
+type sampleColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary-key"`
+	Nullable   bool   `json:"nullable"`
+}
+
+type sampleRow struct {
+	// Type is one of insert, update and delete.
+	Type       string                 `json:"type"`
+	PreColumns map[string]interface{} `json:"pre-columns"`
+	Columns    map[string]interface{} `json:"columns"`
+}
+
+// sampleColumnTypes is the column types supported by the simulator.
+var sampleColumnTypes = map[string]byte{
+	"tinyint":   mysql.TypeTiny,
+	"smallint":  mysql.TypeShort,
+	"int":       mysql.TypeLong,
+	"bigint":    mysql.TypeLonglong,
+	"float":     mysql.TypeFloat,
+	"double":    mysql.TypeDouble,
+	"char":      mysql.TypeString,
+	"varchar":   mysql.TypeVarchar,
+	"text":      mysql.TypeBlob,
+	"varbinary": mysql.TypeVarchar,
+}
+
+// simulator simulates how a changefeed handles the sample tables and rows.
+type simulator struct {
+	// filter is built from the whole config.
+	filter filter.Filter
+	// ruleFilters contains one filter for each event filter rule,
+	// it is used to tell which rule ignores an event.
+	ruleFilters []filter.Filter
+
+	// eventRouter and columnSelector are only set for mq sinks.
+	eventRouter    *dispatcher.EventRouter
+	columnSelector *columnselector.ColumnSelector
+	partitionNum   int32
+
+	// out is where the simulation result is printed to.
+	out io.Writer
+}
+
+func newSimulator(cfgPath, sinkURIStr string, partitionNum int32) (*simulator, error) {
+	cfg := config.GetDefaultReplicaConfig()
+	if err := util.StrictDecodeFile(cfgPath, "cdc filter helper", cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &simulator{partitionNum: partitionNum, out: os.Stdout}
+
+	var sinkURI *url.URL
+	if sinkURIStr != "" {
+		var err error
+		sinkURI, err = url.Parse(sinkURIStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := cfg.ValidateAndAdjust(sinkURI); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	var err error
+	s.filter, err = filter.NewFilter(cfg, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rule := range cfg.Filter.EventFilters {
+		ruleCfg := cfg.Clone()
+		ruleCfg.Filter = &config.FilterConfig{
+			EventFilters: []*config.EventFilterRule{rule},
+		}
+		f, err := filter.NewFilter(ruleCfg, "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s.ruleFilters = append(s.ruleFilters, f)
+	}
+
+	if sinkURI == nil || !sink.IsMQScheme(sink.GetScheme(sinkURI)) {
+		return s, nil
+	}
+	topic, err := sinkutil.GetTopic(sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	protocol, err := sinkutil.GetProtocol(tiflowutil.GetOrZero(cfg.Sink.Protocol))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.eventRouter, err = dispatcher.NewEventRouter(cfg, protocol, topic, sink.GetScheme(sinkURI))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.columnSelector, err = columnselector.New(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.partitionNum <= 0 {
+		s.partitionNum = 3
+		if v := sinkURI.Query().Get("partition-num"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			s.partitionNum = int32(n)
+		}
+	}
+	return s, nil
+}
+
+func readSampleFile(path string) (*sampleFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	res := &sampleFile{}
+	if err := decoder.Decode(res); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
+
+// run prints the simulation result of all the sample tables.
+func (s *simulator) run(sample *sampleFile) error {
+	tableInfos := make([]*model.TableInfo, 0, len(sample.Tables))
+	for _, t := range sample.Tables {
+		ti, err := buildSampleTableInfo(t)
+		if err != nil {
+			return err
+		}
+		tableInfos = append(tableInfos, ti)
+	}
+
+	if err := s.filter.Verify(tableInfos); err != nil {
+		fmt.Fprintf(s.out, "Verify event filter rules failed: %v\n", err)
+	}
+	if s.eventRouter != nil {
+		if err := s.eventRouter.VerifyTables(tableInfos); err != nil {
+			fmt.Fprintf(s.out, "Verify dispatch rules failed: %v\n", err)
+		}
+		if err := s.columnSelector.VerifyTables(tableInfos, s.eventRouter); err != nil {
+			fmt.Fprintf(s.out, "Verify column selectors failed: %v\n", err)
+		}
+	}
+
+	for i, t := range sample.Tables {
+		ti := tableInfos[i]
+		if s.filter.ShouldIgnoreTable(t.Schema, t.Table) {
+			fmt.Fprintf(s.out, "Table: %s, not replicated\n", ti.TableName)
+			continue
+		}
+		fmt.Fprintf(s.out, "Table: %s, replicated\n", ti.TableName)
+		if s.eventRouter != nil {
+			d := s.eventRouter.GetPartitionDispatcher(t.Schema, t.Table)
+			fmt.Fprintf(s.out, "  Partition dispatcher: %s\n",
+				strings.TrimPrefix(fmt.Sprintf("%T", d), "*partition."))
+		}
+		for j, row := range t.Rows {
+			if err := s.simulateRow(j, ti, row); err != nil {
+				fmt.Fprintf(s.out, "  Row %d (%s): simulate failed: %v\n", j, row.Type, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *simulator) simulateRow(idx int, ti *model.TableInfo, row *sampleRow) error {
+	event, rawRow, err := buildSampleRow(ti, row)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "  Row %d (%s):\n", idx, row.Type)
+
+	ignored, err := s.filter.ShouldIgnoreDMLEvent(event, rawRow, ti)
+	if err != nil {
+		return err
+	}
+	fired := make([]string, 0)
+	for i, f := range s.ruleFilters {
+		ruleIgnored, err := f.ShouldIgnoreDMLEvent(event, rawRow, ti)
+		if err != nil {
+			return err
+		}
+		if ruleIgnored {
+			fired = append(fired, fmt.Sprintf("event-filters[%d]", i))
+		}
+	}
+	if len(fired) != 0 {
+		fmt.Fprintf(s.out, "    Fired event filters: %s\n", strings.Join(fired, ", "))
+	}
+	if ignored {
+		fmt.Fprintf(s.out, "    Ignored by event filter rule\n")
+		return nil
+	}
+	if s.eventRouter == nil {
+		fmt.Fprintf(s.out, "    Replicated\n")
+		return nil
+	}
+
+	topic := s.eventRouter.GetTopicForRowChange(event)
+	if err := s.columnSelector.Apply(event); err != nil {
+		return err
+	}
+	partition, key, err := s.eventRouter.GetPartitionForRowChange(event, s.partitionNum)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "    Topic: %s, Partition: %d, Key: %s\n", topic, partition, key)
+	fmt.Fprintf(s.out, "    Columns: %s\n", strings.Join(retainedColumns(event), ", "))
+	return nil
+}
+
+// retainedColumns returns the names of columns kept by the column selector.
+func retainedColumns(event *model.RowChangedEvent) []string {
+	columns := event.Columns
+	if len(columns) == 0 {
+		columns = event.PreColumns
+	}
+	res := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if col == nil {
+			continue
+		}
+		res = append(res, event.TableInfo.ForceGetColumnName(col.ColumnID))
+	}
+	return res
+}
+
+func buildSampleTableInfo(t *sampleTable) (*model.TableInfo, error) {
+	columns := make([]*model.Column, 0, len(t.Columns))
+	pkOffsets := make([]int, 0)
+	for i, c := range t.Columns {
+		tp, ok := sampleColumnTypes[strings.ToLower(c.Type)]
+		if !ok {
+			return nil, errors.Errorf("unsupported column type %s of column %s.%s.%s",
+				c.Type, t.Schema, t.Table, c.Name)
+		}
+		col := &model.Column{Name: c.Name, Type: tp}
+		if strings.EqualFold(c.Type, "varbinary") {
+			col.Charset = "binary"
+			col.Flag.SetIsBinary()
+		}
+		if c.Nullable {
+			col.Flag.SetIsNullable()
+		}
+		if c.PrimaryKey {
+			col.Flag.SetIsPrimaryKey()
+			col.Flag.SetIsHandleKey()
+			pkOffsets = append(pkOffsets, i)
+		}
+		columns = append(columns, col)
+	}
+	var indexColumns [][]int
+	if len(pkOffsets) != 0 {
+		indexColumns = [][]int{pkOffsets}
+	}
+	return model.BuildTableInfo(t.Schema, t.Table, columns, indexColumns), nil
+}
+
+// buildSampleRow builds the row changed event and the raw datums of a sample row,
+// the raw datums are used to evaluate the expression filters.
+func buildSampleRow(
+	ti *model.TableInfo, row *sampleRow,
+) (*model.RowChangedEvent, model.RowChangedDatums, error) {
+	event := &model.RowChangedEvent{
+		TableInfo: ti,
+	}
+	var (
+		rawRow model.RowChangedDatums
+		err    error
+	)
+	switch strings.ToLower(row.Type) {
+	case "insert":
+		event.Columns, rawRow.RowDatums, err = buildSampleColumns(ti, row.Columns)
+	case "update":
+		event.PreColumns, rawRow.PreRowDatums, err = buildSampleColumns(ti, row.PreColumns)
+		if err == nil {
+			event.Columns, rawRow.RowDatums, err = buildSampleColumns(ti, row.Columns)
+		}
+	case "delete":
+		event.PreColumns, rawRow.PreRowDatums, err = buildSampleColumns(ti, row.PreColumns)
+	default:
+		err = errors.Errorf("unknown row type %s, only support insert, update and delete", row.Type)
+	}
+	return event, rawRow, err
+}
+
+func buildSampleColumns(
+	ti *model.TableInfo, values map[string]interface{},
+) ([]*model.ColumnData, []types.Datum, error) {
+	if len(values) == 0 {
+		return nil, nil, errors.New("the columns of the sample row is empty")
+	}
+	columns := make([]*model.ColumnData, len(ti.Columns))
+	datums := make([]types.Datum, len(ti.Columns))
+	for i, colInfo := range ti.Columns {
+		value, datum, err := convertSampleValue(colInfo.GetType(), values[colInfo.Name.O])
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "column %s", colInfo.Name.O)
+		}
+		columns[i] = &model.ColumnData{ColumnID: colInfo.ID, Value: value}
+		datums[i] = datum
+	}
+	return columns, datums, nil
+}
+
+// convertSampleValue converts a json value to the value of a column and its datum,
+// the value has the same type as the one generated by the mounter.
+func convertSampleValue(tp byte, v interface{}) (interface{}, types.Datum, error) {
+	if v == nil {
+		return nil, types.Datum{}, nil
+	}
+	switch tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong, mysql.TypeLonglong:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, types.Datum{}, errors.Errorf("invalid integer value %v", v)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, types.Datum{}, errors.Trace(err)
+		}
+		return i, types.NewIntDatum(i), nil
+	case mysql.TypeFloat, mysql.TypeDouble:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, types.Datum{}, errors.Errorf("invalid float value %v", v)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, types.Datum{}, errors.Trace(err)
+		}
+		return f, types.NewFloat64Datum(f), nil
+	default:
+		s := fmt.Sprintf("%v", v)
+		return []byte(s), types.NewStringDatum(s), nil
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newSampleTableForTest() *sampleTable {
+	return &sampleTable{
+		Schema: "test",
+		Table:  "user",
+		Columns: []*sampleColumn{
+			{Name: "id", Type: "bigint", PrimaryKey: true},
+			{Name: "name", Type: "varchar", Nullable: true},
+			{Name: "secret", Type: "varbinary", Nullable: true},
+		},
+	}
+}
+
+func TestConvertSampleValue(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		tp       byte
+		value    interface{}
+		expected interface{}
+		datum    types.Datum
+		hasErr   bool
+	}{
+		{name: "null", tp: mysql.TypeLong, value: nil, expected: nil, datum: types.Datum{}},
+		{
+			name: "integer", tp: mysql.TypeLonglong, value: json.Number("42"),
+			expected: int64(42), datum: types.NewIntDatum(42),
+		},
+		{name: "invalid integer", tp: mysql.TypeTiny, value: json.Number("1.5"), hasErr: true},
+		{name: "string as integer", tp: mysql.TypeLong, value: "1", hasErr: true},
+		{
+			name: "float", tp: mysql.TypeDouble, value: json.Number("1.5"),
+			expected: 1.5, datum: types.NewFloat64Datum(1.5),
+		},
+		{name: "string as float", tp: mysql.TypeFloat, value: "1.5", hasErr: true},
+		{
+			name: "string", tp: mysql.TypeVarchar, value: "alice",
+			expected: []byte("alice"), datum: types.NewStringDatum("alice"),
+		},
+		{
+			name: "number as string", tp: mysql.TypeBlob, value: json.Number("7"),
+			expected: []byte("7"), datum: types.NewStringDatum("7"),
+		},
+	}
+	for _, tc := range cases {
+		value, datum, err := convertSampleValue(tc.tp, tc.value)
+		if tc.hasErr {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, value, tc.name)
+		require.Equal(t, tc.datum, datum, tc.name)
+	}
+}
+
+func TestBuildSampleRow(t *testing.T) {
+	t.Parallel()
+
+	ti, err := buildSampleTableInfo(newSampleTableForTest())
+	require.NoError(t, err)
+	require.Equal(t, []string{"id"}, ti.GetPrimaryKeyColumnNames())
+
+	alice := map[string]interface{}{"id": json.Number("1"), "name": "alice"}
+	bob := map[string]interface{}{"id": json.Number("1"), "name": "bob", "secret": "s"}
+	cases := []struct {
+		name       string
+		row        *sampleRow
+		hasColumns bool
+		hasPre     bool
+		hasErr     bool
+	}{
+		{name: "insert", row: &sampleRow{Type: "insert", Columns: alice}, hasColumns: true},
+		{
+			name: "update", row: &sampleRow{Type: "UPDATE", PreColumns: alice, Columns: bob},
+			hasColumns: true, hasPre: true,
+		},
+		{name: "delete", row: &sampleRow{Type: "delete", PreColumns: bob}, hasPre: true},
+		{name: "update without pre columns", row: &sampleRow{Type: "update", Columns: bob}, hasErr: true},
+		{name: "insert without columns", row: &sampleRow{Type: "insert"}, hasErr: true},
+		{name: "unknown type", row: &sampleRow{Type: "replace", Columns: alice}, hasErr: true},
+		{
+			name: "invalid value",
+			row: &sampleRow{Type: "insert", Columns: map[string]interface{}{
+				"id": "not a number",
+			}},
+			hasErr: true,
+		},
+	}
+	for _, tc := range cases {
+		event, rawRow, err := buildSampleRow(ti, tc.row)
+		if tc.hasErr {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.hasColumns, len(event.Columns) != 0, tc.name)
+		require.Equal(t, tc.hasColumns, len(rawRow.RowDatums) != 0, tc.name)
+		require.Equal(t, tc.hasPre, len(event.PreColumns) != 0, tc.name)
+		require.Equal(t, tc.hasPre, len(rawRow.PreRowDatums) != 0, tc.name)
+	}
+
+	// the missing column is null.
+	event, rawRow, err := buildSampleRow(ti, &sampleRow{Type: "insert", Columns: alice})
+	require.NoError(t, err)
+	require.Len(t, event.Columns, 3)
+	require.Equal(t, int64(1), event.Columns[0].Value)
+	require.Equal(t, []byte("alice"), event.Columns[1].Value)
+	require.Nil(t, event.Columns[2].Value)
+	require.True(t, rawRow.RowDatums[2].IsNull())
+}
+
+func TestSimulator(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "changefeed.toml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`
+[filter]
+rules = ['test.*']
+[[filter.event-filters]]
+matcher = ['test.user']
+ignore-insert-value-expr = "id > 100"
+
+[sink]
+protocol = "canal-json"
+dispatchers = [
+  {matcher = ['test.*'], topic = "{schema}_{table}", partition = "index-value"},
+]
+column-selectors = [
+  {matcher = ['test.user'], columns = ['*', '!secret']},
+]
+`), 0o644))
+
+	alice := map[string]interface{}{"id": json.Number("1"), "name": "alice", "secret": "s"}
+	bob := map[string]interface{}{"id": json.Number("101"), "name": "bob", "secret": "s"}
+	user := newSampleTableForTest()
+	user.Rows = []*sampleRow{
+		{Type: "insert", Columns: alice},
+		{Type: "insert", Columns: bob},
+		{Type: "delete", PreColumns: alice},
+	}
+	sample := &sampleFile{Tables: []*sampleTable{
+		user,
+		{
+			Schema:  "other",
+			Table:   "t",
+			Columns: []*sampleColumn{{Name: "id", Type: "int", PrimaryKey: true}},
+		},
+	}}
+
+	cases := []struct {
+		name     string
+		sinkURI  string
+		expected []string
+	}{
+		{
+			name: "without sink",
+			expected: []string{
+				"Table: test.user, replicated",
+				"  Row 0 (insert):",
+				"    Replicated",
+				"  Row 1 (insert):",
+				"    Fired event filters: event-filters[0]",
+				"    Ignored by event filter rule",
+				"  Row 2 (delete):",
+				"    Replicated",
+				"Table: other.t, not replicated",
+			},
+		},
+		{
+			name:    "kafka sink",
+			sinkURI: "kafka://127.0.0.1:9092/default?protocol=canal-json&partition-num=4",
+			expected: []string{
+				"Table: test.user, replicated",
+				"  Partition dispatcher: IndexValueDispatcher",
+				"  Row 0 (insert):",
+				// the key is the hash of the primary key value, 1368233040 % 4 = 0.
+				"    Topic: test_user, Partition: 0, Key: 1368233040",
+				"    Columns: id, name",
+				"  Row 1 (insert):",
+				"    Fired event filters: event-filters[0]",
+				"    Ignored by event filter rule",
+				"  Row 2 (delete):",
+				"    Topic: test_user, Partition: 0, Key: 1368233040",
+				"    Columns: id, name",
+				"Table: other.t, not replicated",
+			},
+		},
+	}
+	for _, tc := range cases {
+		s, err := newSimulator(cfgPath, tc.sinkURI, 0)
+		require.NoError(t, err, tc.name)
+		out := &bytes.Buffer{}
+		s.out = out
+		require.NoError(t, s.run(sample), tc.name)
+		require.Equal(t, tc.expected, strings.Split(strings.TrimSpace(out.String()), "\n"), tc.name)
+	}
+}
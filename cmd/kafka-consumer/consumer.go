@@ -21,11 +21,16 @@ import (
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/mqconsumer"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-func getPartitionNum(o *option) (int32, error) {
+// readMessageTimeout is the max time to block on reading a message,
+// so that the consumer can exit in time.
+const readMessageTimeout = 100 * time.Millisecond
+
+func getPartitionNum(o *option, topic string) (int32, error) {
 	configMap := &kafka.ConfigMap{
 		"bootstrap.servers": strings.Join(o.address, ","),
 	}
@@ -43,7 +48,7 @@ func getPartitionNum(o *option) (int32, error) {
 
 	timeout := 3000
 	for i := 0; i <= o.retryTime; i++ {
-		resp, err := admin.GetMetadata(&o.topic, false, timeout)
+		resp, err := admin.GetMetadata(&topic, false, timeout)
 		if err != nil {
 			if err.(kafka.Error).Code() == kafka.ErrTransport {
 				log.Info("retry get partition number", zap.Int("retryTime", i), zap.Int("timeout", timeout))
@@ -52,40 +57,32 @@ func getPartitionNum(o *option) (int32, error) {
 			}
 			return 0, errors.Trace(err)
 		}
-		if topicDetail, ok := resp.Topics[o.topic]; ok {
+		if topicDetail, ok := resp.Topics[topic]; ok {
 			numPartitions := int32(len(topicDetail.Partitions))
 			log.Info("get partition number of topic",
-				zap.String("topic", o.topic),
+				zap.String("topic", topic),
 				zap.Int32("partitionNum", numPartitions))
 			return numPartitions, nil
 		}
-		log.Info("retry get partition number", zap.String("topic", o.topic))
+		log.Info("retry get partition number", zap.String("topic", topic))
 		time.Sleep(1 * time.Second)
 	}
-	return 0, errors.Errorf("get partition number(%s) timeout", o.topic)
+	return 0, errors.Errorf("get partition number(%s) timeout", topic)
 }
 
 type consumer struct {
+	option *option
 	client *kafka.Consumer
-	writer *writer
+	writer *mqconsumer.Writer
+	// partitionNums caches the partition number of each assigned topic.
+	partitionNums map[string]int32
 }
 
 // newConsumer will create a consumer client.
 func newConsumer(ctx context.Context, o *option) *consumer {
-	partitionNum, err := getPartitionNum(o)
-	if err != nil {
-		log.Panic("cannot get the partition number", zap.String("topic", o.topic), zap.Error(err))
-	}
-	if o.partitionNum == 0 {
-		o.partitionNum = partitionNum
-	}
-	topics := strings.Split(o.topic, ",")
-	if len(topics) == 0 {
-		log.Panic("no topic provided for the consumer")
-	}
 	configMap := &kafka.ConfigMap{
 		"bootstrap.servers": strings.Join(o.address, ","),
-		"group.id":          o.groupID,
+		"group.id":          o.ConsumerID,
 		// Start reading from the first message of each assigned
 		// partition if there are no previously committed offsets
 		// for this group.
@@ -107,19 +104,129 @@ func newConsumer(ctx context.Context, o *option) *consumer {
 	if err != nil {
 		log.Panic("create kafka consumer failed", zap.Error(err))
 	}
-	err = client.SubscribeTopics(topics, nil)
+	writer, err := mqconsumer.NewWriter(ctx, o.Option)
 	if err != nil {
-		log.Panic("subscribe topics failed", zap.Error(err))
+		log.Panic("create the writer failed", zap.Error(err))
+	}
+	c := &consumer{
+		option:        o,
+		writer:        writer,
+		client:        client,
+		partitionNums: make(map[string]int32),
+	}
+	// topics start with `^` are subscribed as regular expressions.
+	err = client.SubscribeTopics(o.Topics, func(client *kafka.Consumer, event kafka.Event) error {
+		return c.rebalance(ctx, client, event)
+	})
+	if err != nil {
+		log.Panic("subscribe topics failed", zap.Strings("topics", o.Topics), zap.Error(err))
+	}
+	return c
+}
+
+func (c *consumer) getPartitionNum(topic string) int32 {
+	if c.option.partitionNum != 0 {
+		return c.option.partitionNum
+	}
+	if partitionNum, ok := c.partitionNums[topic]; ok {
+		return partitionNum
 	}
-	return &consumer{
-		writer: newWriter(ctx, o),
-		client: client,
+	partitionNum, err := getPartitionNum(c.option, topic)
+	if err != nil {
+		log.Panic("cannot get the partition number", zap.String("topic", topic), zap.Error(err))
+	}
+	c.partitionNums[topic] = partitionNum
+	return partitionNum
+}
+
+// rebalance is called in the consume goroutine when the assignment changes.
+// The progress of the revoked partitions is persisted before other consumers
+// take them over, the assigned partitions are resumed from the persisted progress.
+func (c *consumer) rebalance(ctx context.Context, client *kafka.Consumer, event kafka.Event) error {
+	switch e := event.(type) {
+	case kafka.AssignedPartitions:
+		log.Info("partitions assigned", zap.Int("count", len(e.Partitions)))
+		for _, tp := range e.Partitions {
+			// the partition number may be changed if a topic is recreated.
+			delete(c.partitionNums, *tp.Topic)
+		}
+		assignments := make([]mqconsumer.Assignment, 0, len(e.Partitions))
+		for _, tp := range e.Partitions {
+			assignments = append(assignments, mqconsumer.Assignment{
+				TopicPartition: mqconsumer.TopicPartition{Topic: *tp.Topic, Partition: tp.Partition},
+				PartitionNum:   c.getPartitionNum(*tp.Topic),
+			})
+		}
+		offsets, err := c.writer.AddPartitions(ctx, assignments)
+		if err != nil {
+			log.Panic("restore the progress of assigned partitions failed", zap.Error(err))
+		}
+		return client.Assign(toKafkaOffsets(offsets))
+	case kafka.RevokedPartitions:
+		// the partitions may be already assigned to other consumers if the assignment
+		// is lost, the progress must not be persisted to overwrite theirs.
+		lost := client.AssignmentLost()
+		log.Info("partitions revoked", zap.Int("count", len(e.Partitions)), zap.Bool("lost", lost))
+		partitions := make([]mqconsumer.TopicPartition, 0, len(e.Partitions))
+		for _, tp := range e.Partitions {
+			partitions = append(partitions, mqconsumer.TopicPartition{Topic: *tp.Topic, Partition: tp.Partition})
+		}
+		offsets, err := c.writer.RemovePartitions(ctx, partitions, !lost)
+		if err != nil {
+			log.Panic("persist the progress of revoked partitions failed", zap.Error(err))
+		}
+		c.commitOffsets(offsets)
+		return client.Unassign()
+	default:
+		log.Warn("unexpected rebalance event", zap.Any("event", event))
+	}
+	return nil
+}
+
+// toKafkaOffsets converts the offsets of the writer, the partition without
+// checkpoint is resumed from the offset committed to kafka.
+func toKafkaOffsets(offsets []mqconsumer.PartitionOffset) []kafka.TopicPartition {
+	result := make([]kafka.TopicPartition, 0, len(offsets))
+	for _, o := range offsets {
+		topic := o.Topic
+		offset := kafka.Offset(o.Offset)
+		if o.Offset == mqconsumer.InvalidOffset {
+			offset = kafka.OffsetStored
+		}
+		result = append(result, kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: o.Partition,
+			Offset:    offset,
+		})
+	}
+	return result
+}
+
+func (c *consumer) commitOffsets(offsets []mqconsumer.PartitionOffset) {
+	if len(offsets) == 0 {
+		return
+	}
+	// the offsets committed to kafka are only used to observe the consumer lag,
+	// the consumer resumes from the progress persisted in the downstream.
+	committed, err := c.client.CommitOffsets(toKafkaOffsets(offsets))
+	if err != nil {
+		log.Warn("commit offsets failed, just continue", zap.Error(err))
+		return
+	}
+	for _, tp := range committed {
+		log.Debug("commit offset success",
+			zap.String("topic", *tp.Topic), zap.Int32("partition", tp.Partition),
+			zap.Any("offset", tp.Offset))
 	}
 }
 
 // Consume will read message from Kafka.
 func (c *consumer) Consume(ctx context.Context) {
 	defer func() {
+		if offsets, ok := c.writer.Checkpoint(context.Background(), true); ok {
+			c.commitOffsets(offsets)
+		}
+		c.writer.Close()
 		if err := c.client.Close(); err != nil {
 			log.Panic("close kafka consumer failed", zap.Error(err))
 		}
@@ -131,25 +238,28 @@ func (c *consumer) Consume(ctx context.Context) {
 			return
 		default:
 		}
-		msg, err := c.client.ReadMessage(-1)
+		msg, err := c.client.ReadMessage(readMessageTimeout)
 		if err != nil {
+			if kerr, ok := err.(kafka.Error); ok && kerr.IsTimeout() {
+				continue
+			}
 			log.Error("read message failed, just continue to retry", zap.Error(err))
 			continue
 		}
-		needCommit := c.writer.WriteMessage(ctx, msg)
+		needCommit := c.writer.WriteMessage(ctx, &mqconsumer.Message{
+			TopicPartition: mqconsumer.TopicPartition{
+				Topic:     *msg.TopicPartition.Topic,
+				Partition: msg.TopicPartition.Partition,
+			},
+			Offset: int64(msg.TopicPartition.Offset),
+			Key:    msg.Key,
+			Value:  msg.Value,
+		})
 		if !needCommit {
 			continue
 		}
-
-		topicPartition, err := c.client.CommitMessage(msg)
-		if err != nil {
-			log.Error("commit message failed, just continue",
-				zap.String("topic", *msg.TopicPartition.Topic), zap.Int32("partition", msg.TopicPartition.Partition),
-				zap.Any("offset", msg.TopicPartition.Offset), zap.Error(err))
-			continue
+		if offsets, ok := c.writer.Checkpoint(ctx, false); ok {
+			c.commitOffsets(offsets)
 		}
-		log.Debug("commit message success",
-			zap.String("topic", topicPartition[0].String()), zap.Int32("partition", topicPartition[0].Partition),
-			zap.Any("offset", topicPartition[0].Offset))
 	}
 }
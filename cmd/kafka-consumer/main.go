@@ -17,7 +17,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"net/http"
 	_ "net/http/pprof"
 	"net/url"
 	"os"
@@ -28,8 +27,11 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/logutil"
+	"github.com/pingcap/tiflow/pkg/mqconsumer"
 	"github.com/pingcap/tiflow/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
@@ -42,17 +44,23 @@ func main() {
 	consumerOption := newOption()
 	flag.StringVar(&configFile, "config", "", "config file for changefeed")
 	flag.StringVar(&upstreamURIStr, "upstream-uri", "", "Kafka uri")
-	flag.StringVar(&consumerOption.downstreamURI, "downstream-uri", "", "downstream sink uri")
-	flag.StringVar(&consumerOption.schemaRegistryURI, "schema-registry-uri", "", "schema registry uri")
-	flag.StringVar(&consumerOption.upstreamTiDBDSN, "upstream-tidb-dsn", "", "upstream TiDB DSN")
-	flag.StringVar(&consumerOption.groupID, "consumer-group-id", groupID, "consumer group id")
+	flag.StringVar(&consumerOption.DownstreamURI, "downstream-uri", "", "downstream sink uri")
+	flag.StringVar(&consumerOption.SchemaRegistryURI, "schema-registry-uri", "", "schema registry uri")
+	flag.StringVar(&consumerOption.UpstreamTiDBDSN, "upstream-tidb-dsn", "", "upstream TiDB DSN")
+	flag.StringVar(&consumerOption.SchemaHistoryPath, "schema-history", "",
+		"schema history file exported from the changefeed, used when the table schema is not received")
+	flag.StringVar(&consumerOption.ConsumerID, "consumer-group-id", groupID, "consumer group id")
 	flag.StringVar(&consumerOption.logPath, "log-file", "cdc_kafka_consumer.log", "log file path")
 	flag.StringVar(&consumerOption.logLevel, "log-level", "info", "log file path")
-	flag.StringVar(&consumerOption.timezone, "tz", "System", "Specify time zone of Kafka consumer")
+	flag.StringVar(&consumerOption.Timezone, "tz", "System", "Specify time zone of Kafka consumer")
 	flag.StringVar(&consumerOption.ca, "ca", "", "CA certificate path for Kafka SSL connection")
 	flag.StringVar(&consumerOption.cert, "cert", "", "Certificate path for Kafka SSL connection")
 	flag.StringVar(&consumerOption.key, "key", "", "Private key path for Kafka SSL connection")
 	flag.BoolVar(&consumerOption.enableProfiling, "enable-profiling", false, "enable pprof profiling")
+	flag.StringVar(&consumerOption.statusAddr, "status-addr", "",
+		"address of the http server to serve status, metrics and pprof, :6060 is used if profiling is enabled")
+	flag.DurationVar(&consumerOption.CheckpointInterval, "checkpoint-interval", mqconsumer.DefaultCheckpointInterval,
+		"min interval to persist the consumer progress to the downstream")
 	flag.Parse()
 
 	err := logutil.InitLogger(&logutil.Config{
@@ -78,18 +86,24 @@ func main() {
 	if err != nil {
 		log.Panic("adjust consumer option failed", zap.Error(err))
 	}
+	// the downstream sinks read the time zone from the server config.
+	config.GetGlobalServerConfig().TZ = consumerOption.Timezone
+
+	registry := prometheus.NewRegistry()
+	mqconsumer.InitMetrics(registry)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	consumer := newConsumer(ctx, consumerOption)
 	var wg sync.WaitGroup
-	if consumerOption.enableProfiling {
-		log.Info("profiling is enabled")
+	statusAddr := consumerOption.statusAddr
+	if statusAddr == "" && consumerOption.enableProfiling {
+		statusAddr = ":6060"
+	}
+	if statusAddr != "" {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err = http.ListenAndServe(":6060", nil); err != nil {
-				log.Panic("cannot start the pprof", zap.Error(err))
-			}
+			mqconsumer.RunStatusServer(ctx, statusAddr, consumer.writer, registry, consumerOption.enableProfiling)
 		}()
 	}
 	wg.Add(1)
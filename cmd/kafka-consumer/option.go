@@ -14,8 +14,8 @@
 package main
 
 import (
-	"math"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/config"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/mqconsumer"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
@@ -38,35 +39,20 @@ var (
 )
 
 type option struct {
+	// Option is shared by the writer of all MQ consumers.
+	*mqconsumer.Option
+
 	address      []string
 	version      string
-	topic        string
 	partitionNum int32
-	groupID      string
-
-	maxMessageBytes int
-	maxBatchSize    int
-
-	protocol config.Protocol
-
-	codecConfig *common.Config
-	// the replicaConfig of the changefeed which produce data to the kafka topic
-	replicaConfig *config.ReplicaConfig
 
 	logPath       string
 	logLevel      string
-	timezone      string
 	ca, cert, key string
 
-	downstreamURI string
-
-	// avro schema registry uri should be set if the encoding protocol is avro
-	schemaRegistryURI string
-
-	// upstreamTiDBDSN is the dsn of the upstream TiDB cluster
-	upstreamTiDBDSN string
-
 	enableProfiling bool
+	// statusAddr is the address of the status and metrics http server.
+	statusAddr string
 
 	// connect kafka retry times, default 30
 	retryTime int
@@ -76,11 +62,10 @@ type option struct {
 
 func newOption() *option {
 	return &option{
-		version:         defaultVersion,
-		maxMessageBytes: math.MaxInt64,
-		maxBatchSize:    math.MaxInt64,
-		retryTime:       defaultRetryTime,
-		timeout:         defaultTimeout,
+		Option:    mqconsumer.NewOption("kafka-consumer"),
+		version:   defaultVersion,
+		retryTime: defaultRetryTime,
+		timeout:   defaultTimeout,
 	}
 }
 
@@ -90,9 +75,24 @@ func (o *option) Adjust(upstreamURI *url.URL, configFile string) error {
 	if s != "" {
 		o.version = s
 	}
-	o.topic = strings.TrimFunc(upstreamURI.Path, func(r rune) bool {
+	topics := strings.TrimFunc(upstreamURI.Path, func(r rune) bool {
 		return r == '/'
 	})
+	for _, topic := range strings.Split(topics, ",") {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+		if strings.HasPrefix(topic, "^") {
+			if _, err := regexp.Compile(topic); err != nil {
+				return cerror.Annotatef(err, "invalid topic regular expression %s", topic)
+			}
+		}
+		o.Topics = append(o.Topics, topic)
+	}
+	if len(o.Topics) == 0 {
+		log.Panic("no topic provided for the consumer")
+	}
 	o.address = strings.Split(upstreamURI.Host, ",")
 
 	s = upstreamURI.Query().Get("partition-num")
@@ -110,7 +110,7 @@ func (o *option) Adjust(upstreamURI *url.URL, configFile string) error {
 		if err != nil {
 			log.Panic("invalid max-message-bytes of upstream-uri")
 		}
-		o.maxMessageBytes = c
+		o.MaxMessageBytes = c
 	}
 
 	s = upstreamURI.Query().Get("max-batch-size")
@@ -119,7 +119,7 @@ func (o *option) Adjust(upstreamURI *url.URL, configFile string) error {
 		if err != nil {
 			log.Panic("invalid max-batch-size of upstream-uri")
 		}
-		o.maxBatchSize = c
+		o.MaxBatchSize = c
 	}
 
 	s = upstreamURI.Query().Get("protocol")
@@ -130,7 +130,7 @@ func (o *option) Adjust(upstreamURI *url.URL, configFile string) error {
 	if err != nil {
 		log.Panic("invalid protocol", zap.Error(err), zap.String("protocol", s))
 	}
-	o.protocol = protocol
+	o.Protocol = protocol
 
 	replicaConfig := config.GetDefaultReplicaConfig()
 	// the TiDB source ID should never be set to 0
@@ -145,32 +145,36 @@ func (o *option) Adjust(upstreamURI *url.URL, configFile string) error {
 			return cerror.Trace(err)
 		}
 	}
-	o.replicaConfig = replicaConfig
+	o.ReplicaConfig = replicaConfig
 
-	o.codecConfig = common.NewConfig(protocol)
-	if err = o.codecConfig.Apply(upstreamURI, o.replicaConfig); err != nil {
+	o.CodecConfig = common.NewConfig(protocol)
+	if err = o.CodecConfig.Apply(upstreamURI, o.ReplicaConfig); err != nil {
 		return cerror.Trace(err)
 	}
-	tz, err := util.GetTimezone(o.timezone)
+	tz, err := util.GetTimezone(o.Timezone)
 	if err != nil {
 		return cerrors.Trace(err)
 	}
-	o.codecConfig.TimeZone = tz
+	o.CodecConfig.TimeZone = tz
 
 	if protocol == config.ProtocolAvro {
-		o.codecConfig.AvroEnableWatermark = true
+		o.CodecConfig.AvroEnableWatermark = true
+	}
+	if err := o.LoadSchemaHistory(); err != nil {
+		return cerror.Trace(err)
 	}
 
 	log.Info("consumer option adjusted",
 		zap.String("configFile", configFile),
 		zap.String("address", strings.Join(o.address, ",")),
 		zap.String("version", o.version),
-		zap.String("topic", o.topic),
+		zap.Strings("topics", o.Topics),
 		zap.Int32("partitionNum", o.partitionNum),
-		zap.String("groupID", o.groupID),
-		zap.Int("maxMessageBytes", o.maxMessageBytes),
-		zap.Int("maxBatchSize", o.maxBatchSize),
+		zap.String("consumerID", o.ConsumerID),
+		zap.Int("maxMessageBytes", o.MaxMessageBytes),
+		zap.Int("maxBatchSize", o.MaxBatchSize),
 		zap.String("upstreamURI", upstreamURI.String()),
-		zap.String("downstreamURI", o.downstreamURI))
+		zap.String("downstreamURI", o.DownstreamURI),
+		zap.Duration("checkpointInterval", o.CheckpointInterval))
 	return nil
 }
@@ -0,0 +1,239 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/apache/pulsar-client-go/pulsar/auth"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/mqconsumer"
+	tpulsar "github.com/pingcap/tiflow/pkg/sink/pulsar"
+	"go.uber.org/zap"
+)
+
+// newPulsarClient creates a pulsar client
+func newPulsarClient(option *ConsumerOption) (pulsar.Client, error) {
+	var pulsarURL string
+	if len(option.ca) != 0 {
+		pulsarURL = "pulsar+ssl" + "://" + option.address[0]
+	} else {
+		pulsarURL = "pulsar" + "://" + option.address[0]
+	}
+
+	clientOption := pulsar.ClientOptions{
+		URL:    pulsarURL,
+		Logger: tpulsar.NewPulsarLogger(log.L()),
+	}
+	if len(option.ca) != 0 {
+		clientOption.TLSTrustCertsFilePath = option.ca
+		clientOption.TLSCertificateFile = option.cert
+		clientOption.TLSKeyFilePath = option.key
+	}
+
+	var authentication pulsar.Authentication
+	if len(option.oauth2PrivateKey) != 0 {
+		authentication = pulsar.NewAuthenticationOAuth2(map[string]string{
+			auth.ConfigParamIssuerURL: option.oauth2IssuerURL,
+			auth.ConfigParamAudience:  option.oauth2Audience,
+			auth.ConfigParamKeyFile:   option.oauth2PrivateKey,
+			auth.ConfigParamClientID:  option.oauth2ClientID,
+			auth.ConfigParamScope:     option.oauth2Scope,
+			auth.ConfigParamType:      auth.ConfigParamTypeClientCredentials,
+		})
+		log.Info("oauth2 authentication is enabled", zap.String("issuer url", option.oauth2IssuerURL))
+		clientOption.Authentication = authentication
+	}
+	if len(option.mtlsAuthTLSCertificatePath) != 0 {
+		authentication = pulsar.NewAuthenticationTLS(option.mtlsAuthTLSCertificatePath, option.mtlsAuthTLSPrivateKeyPath)
+		log.Info("mtls authentication is enabled",
+			zap.String("cert", option.mtlsAuthTLSCertificatePath),
+			zap.String("key", option.mtlsAuthTLSPrivateKeyPath),
+		)
+		clientOption.Authentication = authentication
+	}
+
+	client, err := pulsar.NewClient(clientOption)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+// pendingMessage is a message received but not acknowledged yet.
+type pendingMessage struct {
+	offset int64
+	// partitionTopic is the full name of the partition topic of the message.
+	partitionTopic string
+	id             pulsar.MessageID
+}
+
+type consumer struct {
+	option   *ConsumerOption
+	client   pulsar.Client
+	consumer pulsar.Consumer
+	writer   *mqconsumer.Writer
+
+	// partitions maps the full name of each partition topic to the topic.
+	partitions map[string]mqconsumer.TopicPartition
+	// nextOffsets is the local offset of the next message received from each topic,
+	// pulsar message IDs cannot be used since they are not continuous.
+	nextOffsets map[mqconsumer.TopicPartition]int64
+	// pending is the messages not acknowledged of each topic, ordered by the offset.
+	pending map[mqconsumer.TopicPartition][]pendingMessage
+}
+
+// newConsumer subscribes all topics and assigns them to the writer. The DDLs
+// and checkpoints are not broadcast to every partition of a pulsar topic, so
+// all partitions of a topic are consumed as one partition of the writer, the
+// exclusive subscription guarantees that DDLs are executed after the DMLs.
+func newConsumer(ctx context.Context, o *ConsumerOption) (*consumer, error) {
+	client, err := newPulsarClient(o)
+	if err != nil {
+		return nil, errors.Annotate(err, "can't create pulsar client")
+	}
+	c := &consumer{
+		option:      o,
+		client:      client,
+		partitions:  make(map[string]mqconsumer.TopicPartition),
+		nextOffsets: make(map[mqconsumer.TopicPartition]int64),
+		pending:     make(map[mqconsumer.TopicPartition][]pendingMessage),
+	}
+
+	var assignments []mqconsumer.Assignment
+	for _, topic := range o.Topics {
+		partitionTopics, err := client.TopicPartitions(topic)
+		if err != nil {
+			client.Close()
+			return nil, errors.Annotatef(err, "can't get the partitions of topic %s", topic)
+		}
+		log.Info("get partition number of topic",
+			zap.String("topic", topic), zap.Int("partitionNum", len(partitionTopics)))
+		tp := mqconsumer.TopicPartition{Topic: topic, Partition: 0}
+		for _, partitionTopic := range partitionTopics {
+			c.partitions[partitionTopic] = tp
+		}
+		assignments = append(assignments, mqconsumer.Assignment{
+			TopicPartition: tp,
+			PartitionNum:   1,
+		})
+	}
+
+	c.writer, err = mqconsumer.NewWriter(ctx, o.Option)
+	if err != nil {
+		client.Close()
+		return nil, errors.Trace(err)
+	}
+	// the offsets are local, the consumer resumes from the acknowledged
+	// position of the subscription, replayed events are skipped by the writer.
+	if _, err = c.writer.AddPartitions(ctx, assignments); err != nil {
+		client.Close()
+		return nil, errors.Trace(err)
+	}
+
+	c.consumer, err = client.Subscribe(pulsar.ConsumerOptions{
+		Topics:                      o.Topics,
+		SubscriptionName:            o.ConsumerID,
+		Type:                        pulsar.Exclusive,
+		SubscriptionInitialPosition: pulsar.SubscriptionPositionEarliest,
+	})
+	if err != nil {
+		client.Close()
+		return nil, errors.Annotate(err, "can't create pulsar consumer")
+	}
+	return c, nil
+}
+
+// ack acknowledges all messages before the offsets cumulatively,
+// they have been written to the downstream.
+func (c *consumer) ack(offsets []mqconsumer.PartitionOffset) {
+	for _, o := range offsets {
+		pending := c.pending[o.TopicPartition]
+		i := 0
+		// the cumulative ack only applies to the partition topic of the message ID,
+		// so the last message of each partition topic is acknowledged.
+		last := make(map[string]pendingMessage)
+		for i < len(pending) && pending[i].offset < o.Offset {
+			last[pending[i].partitionTopic] = pending[i]
+			i++
+		}
+		if i == 0 {
+			continue
+		}
+		acked := true
+		for partitionTopic, msg := range last {
+			if err := c.consumer.AckIDCumulative(msg.id); err != nil {
+				log.Warn("ack message failed, just continue", zap.String("topic", o.Topic),
+					zap.String("partitionTopic", partitionTopic), zap.Int64("offset", msg.offset),
+					zap.Error(err))
+				acked = false
+			}
+		}
+		if !acked {
+			continue
+		}
+		c.pending[o.TopicPartition] = pending[i:]
+		log.Debug("ack message success", zap.String("topic", o.Topic),
+			zap.Int64("offset", pending[i-1].offset))
+	}
+}
+
+// Consume will read message from pulsar.
+func (c *consumer) Consume(ctx context.Context) {
+	defer func() {
+		if offsets, ok := c.writer.Checkpoint(context.Background(), true); ok {
+			c.ack(offsets)
+		}
+		c.writer.Close()
+		c.consumer.Close()
+		c.client.Close()
+	}()
+	msgChan := c.consumer.Chan()
+	for {
+		var msg pulsar.ConsumerMessage
+		select {
+		case <-ctx.Done():
+			log.Info("consumer exist: context cancelled")
+			return
+		case msg = <-msgChan:
+		}
+		tp, ok := c.partitions[msg.Topic()]
+		if !ok {
+			log.Panic("message received from unknown topic",
+				zap.String("topic", msg.Topic()), zap.Any("messageID", msg.ID()))
+		}
+		offset := c.nextOffsets[tp]
+		c.nextOffsets[tp] = offset + 1
+		c.pending[tp] = append(c.pending[tp], pendingMessage{
+			offset:         offset,
+			partitionTopic: msg.Topic(),
+			id:             msg.ID(),
+		})
+
+		needCommit := c.writer.WriteMessage(ctx, &mqconsumer.Message{
+			TopicPartition: tp,
+			Offset:         offset,
+			Key:            []byte(msg.Key()),
+			Value:          msg.Payload(),
+		})
+		if !needCommit {
+			continue
+		}
+		if offsets, ok := c.writer.Checkpoint(ctx, false); ok {
+			c.ack(offsets)
+		}
+	}
+}
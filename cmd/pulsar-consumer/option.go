@@ -0,0 +1,161 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cmdUtil "github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/mqconsumer"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+const defaultSubscriptionName = "pulsar-test-subscription"
+
+// ConsumerOption represents the options of the pulsar consumer
+type ConsumerOption struct {
+	// Option is shared by the writer of all MQ consumers.
+	*mqconsumer.Option
+
+	address []string
+
+	logPath       string
+	logLevel      string
+	ca, cert, key string
+
+	oauth2PrivateKey string
+	oauth2IssuerURL  string
+	oauth2ClientID   string
+	oauth2Scope      string
+	oauth2Audience   string
+
+	mtlsAuthTLSCertificatePath string
+	mtlsAuthTLSPrivateKeyPath  string
+
+	enableProfiling bool
+	// statusAddr is the address of the status and metrics http server.
+	statusAddr string
+}
+
+func newConsumerOption() *ConsumerOption {
+	o := &ConsumerOption{
+		Option: mqconsumer.NewOption("pulsar-consumer"),
+	}
+	o.ConsumerID = defaultSubscriptionName
+	// the offsets of pulsar messages are assigned by the consumer, and the
+	// messages are dispatched to partitions by the hash of the message key.
+	o.LocalOffset = true
+	o.SkipPartitionCheck = true
+	return o
+}
+
+// Adjust the consumer option by the upstream uri passed in parameters.
+func (o *ConsumerOption) Adjust(upstreamURI *url.URL, configFile string) error {
+	topics := strings.TrimFunc(upstreamURI.Path, func(r rune) bool {
+		return r == '/'
+	})
+	for _, topic := range strings.Split(topics, ",") {
+		topic = strings.TrimSpace(topic)
+		if topic != "" {
+			o.Topics = append(o.Topics, topic)
+		}
+	}
+	if len(o.Topics) == 0 {
+		return errors.New("no topic provided for the consumer")
+	}
+	o.address = strings.Split(upstreamURI.Host, ",")
+
+	s := upstreamURI.Query().Get("max-message-bytes")
+	if s != "" {
+		c, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.Annotate(err, "invalid max-message-bytes of upstream-uri")
+		}
+		o.MaxMessageBytes = c
+	}
+
+	s = upstreamURI.Query().Get("max-batch-size")
+	if s != "" {
+		c, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.Annotate(err, "invalid max-batch-size of upstream-uri")
+		}
+		o.MaxBatchSize = c
+	}
+
+	o.Protocol = config.ProtocolCanalJSON
+	s = upstreamURI.Query().Get("protocol")
+	if s != "" {
+		protocol, err := config.ParseSinkProtocolFromString(s)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		o.Protocol = protocol
+	}
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	// the TiDB source ID should never be set to 0
+	replicaConfig.Sink.TiDBSourceID = 1
+	replicaConfig.Sink.Protocol = util.AddressOf(o.Protocol.String())
+	if configFile != "" {
+		err := cmdUtil.StrictDecodeFile(configFile, "pulsar consumer", replicaConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err = filter.VerifyTableRules(replicaConfig.Filter); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	o.ReplicaConfig = replicaConfig
+
+	// the large message handle, such as claim check, is applied from the
+	// changefeed config file, which should be the same as the producer.
+	o.CodecConfig = common.NewConfig(o.Protocol)
+	if err := o.CodecConfig.Apply(upstreamURI, o.ReplicaConfig); err != nil {
+		return errors.Trace(err)
+	}
+	tz, err := util.GetTimezone(o.Timezone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	o.CodecConfig.TimeZone = tz
+
+	if o.Protocol == config.ProtocolAvro {
+		o.CodecConfig.AvroEnableWatermark = true
+	}
+	if err := o.LoadSchemaHistory(); err != nil {
+		return errors.Trace(err)
+	}
+
+	log.Info("consumer option adjusted",
+		zap.String("configFile", configFile),
+		zap.String("address", strings.Join(o.address, ",")),
+		zap.Strings("topics", o.Topics),
+		zap.String("subscriptionName", o.ConsumerID),
+		zap.Any("protocol", o.Protocol),
+		zap.Any("largeMessageHandle", o.CodecConfig.LargeMessageHandle),
+		zap.Int("maxMessageBytes", o.MaxMessageBytes),
+		zap.Int("maxBatchSize", o.MaxBatchSize),
+		zap.String("downstreamURI", o.DownstreamURI),
+		zap.Duration("checkpointInterval", o.CheckpointInterval))
+	return nil
+}
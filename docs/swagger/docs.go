@@ -834,6 +834,53 @@ var doc = `{
                 }
             }
         },
+        "/api/v2/changefeeds/import": {
+            "post": {
+                "description": "create a changefeed from a document exported by the export API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed",
+                    "v2"
+                ],
+                "summary": "Import a changefeed",
+                "parameters": [
+                    {
+                        "description": "import config",
+                        "name": "changefeed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedImportConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangeFeedInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v2/changefeeds/{changefeed_id}": {
             "get": {
                 "description": "get detail information of a changefeed",
@@ -992,9 +1039,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/changefeeds/{changefeed_id}/pause": {
+        "/api/v2/changefeeds/{changefeed_id}/clone": {
             "post": {
-                "description": "Pause a changefeed",
+                "description": "create a changefeed with the config of an existing changefeed in the same cluster",
                 "consumes": [
                     "application/json"
                 ],
@@ -1005,7 +1052,7 @@ var doc = `{
                     "changefeed",
                     "v2"
                 ],
-                "summary": "Pause a changefeed",
+                "summary": "Clone a changefeed",
                 "parameters": [
                     {
                         "type": "string",
@@ -1019,13 +1066,22 @@ var doc = `{
                         "description": "default",
                         "name": "namespace",
                         "in": "query"
+                    },
+                    {
+                        "description": "clone config",
+                        "name": "changefeed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedCloneConfig"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.EmptyResponse"
+                            "$ref": "#/definitions/v2.ChangeFeedInfo"
                         }
                     },
                     "400": {
@@ -1043,12 +1099,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/changefeeds/{changefeed_id}/resume": {
-            "post": {
-                "description": "Resume a changefeed",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v2/changefeeds/{changefeed_id}/export": {
+            "get": {
+                "description": "export a changefeed as a portable document, the secrets in it are replaced with variables",
                 "produces": [
                     "application/json"
                 ],
@@ -1056,7 +1109,7 @@ var doc = `{
                     "changefeed",
                     "v2"
                 ],
-                "summary": "Resume a changefeed",
+                "summary": "Export a changefeed",
                 "parameters": [
                     {
                         "type": "string",
@@ -1072,20 +1125,17 @@ var doc = `{
                         "in": "query"
                     },
                     {
-                        "description": "resume config",
-                        "name": "resumeConfig",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v2.ResumeChangefeedConfig"
-                        }
+                        "type": "string",
+                        "description": "now or checkpoint",
+                        "name": "start_ts_policy",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.EmptyResponse"
+                            "$ref": "#/definitions/v2.ChangefeedExport"
                         }
                     },
                     "400": {
@@ -1103,9 +1153,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/changefeeds/{changefeed_id}/synced": {
-            "get": {
-                "description": "get the synced status of a changefeed",
+        "/api/v2/changefeeds/{changefeed_id}/pause": {
+            "post": {
+                "description": "Pause a changefeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1116,7 +1166,7 @@ var doc = `{
                     "changefeed",
                     "v2"
                 ],
-                "summary": "Get synced status",
+                "summary": "Pause a changefeed",
                 "parameters": [
                     {
                         "type": "string",
@@ -1136,7 +1186,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.SyncedStatus"
+                            "$ref": "#/definitions/v2.EmptyResponse"
                         }
                     },
                     "400": {
@@ -1154,22 +1204,43 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/health": {
+        "/api/v2/changefeeds/{changefeed_id}/resolved_ts_diagnosis": {
             "get": {
-                "description": "Check the health status of a TiCDC cluster",
+                "description": "Walk through the replication pipeline of a changefeed on all captures,\nand report the slowest table spans of each stage and the stage which\nholds back the checkpoint ts the most",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "common",
+                    "changefeed",
                     "v2"
                 ],
-                "summary": "Check the health status of a TiCDC cluster",
+                "summary": "Diagnose the resolved ts of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of table spans of each stage, default 5",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.EmptyResponse"
+                            "$ref": "#/definitions/model.ResolvedTsDiagnosis"
                         }
                     },
                     "400": {
@@ -1187,9 +1258,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/log": {
+        "/api/v2/changefeeds/{changefeed_id}/resume": {
             "post": {
-                "description": "change TiCDC log level dynamically",
+                "description": "Resume a changefeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1197,18 +1268,31 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "common",
+                    "changefeed",
                     "v2"
                 ],
-                "summary": "Change TiCDC log level",
+                "summary": "Resume a changefeed",
                 "parameters": [
                     {
-                        "description": "log level",
-                        "name": "log_level",
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "resume config",
+                        "name": "resumeConfig",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v2.LogLevelReq"
+                            "$ref": "#/definitions/v2.ResumeChangefeedConfig"
                         }
                     }
                 ],
@@ -1224,29 +1308,59 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/model.HTTPError"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
                     }
                 }
             }
         },
-        "/api/v2/owner/resign": {
-            "post": {
-                "description": "Notify the current controller to resign",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v2/changefeeds/{changefeed_id}/schema_history": {
+            "get": {
+                "description": "Export the versioned table definitions of a changefeed between start_ts and end_ts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "owner",
+                    "changefeed",
                     "v2"
                 ],
-                "summary": "Notify the controller to resign",
+                "summary": "Export the schema history of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start ts",
+                        "name": "start_ts",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end ts",
+                        "name": "end_ts",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.EmptyResponse"
+                            "$ref": "#/definitions/schemahistory.History"
                         }
                     },
                     "400": {
@@ -1264,25 +1378,43 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/processors": {
+        "/api/v2/changefeeds/{changefeed_id}/statistics": {
             "get": {
-                "description": "list all processors in the TiCDC cluster",
+                "description": "Get the throughput, the executed DDLs, the lag of each table, the slowest\ntables and the sorter and sink queue sizes of a changefeed, which are\naggregated by the owner from the heartbeats of the processors",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "processor",
+                    "changefeed",
                     "v2"
                 ],
-                "summary": "List processors",
+                "summary": "Get the statistics of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of the slowest tables, default 10",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/v2.ProcessorCommonInfo"
-                            }
+                            "$ref": "#/definitions/model.ChangefeedStatistics"
                         }
                     },
                     "400": {
@@ -1300,21 +1432,24 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/processors/{changefeed_id}/{capture_id}": {
+        "/api/v2/changefeeds/{changefeed_id}/synced": {
             "get": {
-                "description": "get the detail information of a processor",
+                "description": "get the synced status of a changefeed",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "processor",
+                    "changefeed",
                     "v2"
                 ],
-                "summary": "Get processor detail information",
+                "summary": "Get synced status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "changefeed ID",
+                        "description": "changefeed_id",
                         "name": "changefeed_id",
                         "in": "path",
                         "required": true
@@ -1324,20 +1459,13 @@ var doc = `{
                         "description": "default",
                         "name": "namespace",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "capture ID",
-                        "name": "capture_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.ProcessorDetail"
+                            "$ref": "#/definitions/v2.SyncedStatus"
                         }
                     },
                     "400": {
@@ -1355,12 +1483,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v2/status": {
+        "/api/v2/health": {
             "get": {
-                "description": "This API is a synchronous interface. If the request is successful,",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Check the health status of a TiCDC cluster",
                 "produces": [
                     "application/json"
                 ],
@@ -1368,12 +1493,12 @@ var doc = `{
                     "common",
                     "v2"
                 ],
-                "summary": "Get the status information of a TiCDC node",
+                "summary": "Check the health status of a TiCDC cluster",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v2.ServerStatus"
+                            "$ref": "#/definitions/v2.EmptyResponse"
                         }
                     },
                     "400": {
@@ -1390,31 +1515,1045 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "config.CSVConfig": {
-            "type": "object",
-            "properties": {
-                "binary-encoding-method": {
-                    "description": "encoding method of binary type",
-                    "type": "string"
-                },
-                "delimiter": {
-                    "description": "delimiter between fields, it can be 1 character or at most 2 characters\nIt can not be CR or LF or contains CR or LF.\nIt should have exclusive characters with quote.",
-                    "type": "string"
-                },
-                "include-commit-ts": {
-                    "description": "whether to include commit ts",
-                    "type": "boolean"
-                },
-                "null": {
-                    "description": "representation of null values",
-                    "type": "string"
-                },
-                "output-handle-key": {
-                    "description": "output handle key",
-                    "type": "boolean"
+        },
+        "/api/v2/log": {
+            "post": {
+                "description": "change TiCDC log level dynamically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common",
+                    "v2"
+                ],
+                "summary": "Change TiCDC log level",
+                "parameters": [
+                    {
+                        "description": "log level",
+                        "name": "log_level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.LogLevelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/namespaces": {
+            "get": {
+                "description": "list the namespaces which have settings or changefeeds",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "namespace",
+                    "v2"
+                ],
+                "summary": "List namespaces",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.Namespace"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create the settings of a namespace, including the default\nreplica config, the quota and the labels",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "namespace",
+                    "v2"
+                ],
+                "summary": "Create a namespace",
+                "parameters": [
+                    {
+                        "description": "namespace config",
+                        "name": "namespace",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.NamespaceConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Namespace"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/namespaces/{namespace}": {
+            "get": {
+                "description": "get the settings and the usage of a namespace",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "namespace",
+                    "v2"
+                ],
+                "summary": "Get a namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Namespace"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "replace the settings of a namespace, the changefeeds created\nbefore are not affected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "namespace",
+                    "v2"
+                ],
+                "summary": "Update a namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "namespace config",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.NamespaceConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Namespace"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove the settings and the changefeed templates of a\nnamespace which has no changefeed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "namespace",
+                    "v2"
+                ],
+                "summary": "Remove a namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/namespaces/{namespace}/changefeed_config": {
+            "get": {
+                "description": "get the sink uri and the replica config a new changefeed in\nthe namespace inherits, with an optional changefeed template",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "namespace",
+                    "v2"
+                ],
+                "summary": "Get the inherited changefeed config of a namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "changefeed template name",
+                        "name": "template",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/owner/resign": {
+            "post": {
+                "description": "Notify the current controller to resign",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner",
+                    "v2"
+                ],
+                "summary": "Notify the controller to resign",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/processors": {
+            "get": {
+                "description": "list all processors in the TiCDC cluster",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor",
+                    "v2"
+                ],
+                "summary": "List processors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.ProcessorCommonInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/processors/{changefeed_id}/{capture_id}": {
+            "get": {
+                "description": "get the detail information of a processor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor",
+                    "v2"
+                ],
+                "summary": "Get processor detail information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed ID",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "capture ID",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ProcessorDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/processors/{changefeed_id}/{capture_id}/resolved_ts_diagnosis": {
+            "get": {
+                "description": "Walk through the replication pipeline of the tables of a changefeed\nreplicated by a capture",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor",
+                    "v2"
+                ],
+                "summary": "Diagnose the resolved ts of a processor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of table spans of each stage, default 5",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcessorDiagnosis"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/status": {
+            "get": {
+                "description": "This API is a synchronous interface. If the request is successful,",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common",
+                    "v2"
+                ],
+                "summary": "Get the status information of a TiCDC node",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ServerStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/templates": {
+            "get": {
+                "description": "list all changefeed templates of a namespace",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template",
+                    "v2"
+                ],
+                "summary": "List changefeed templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.ChangefeedTemplate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a named changefeed config which changefeeds can be created with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template",
+                    "v2"
+                ],
+                "summary": "Create a changefeed template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "changefeed template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/templates/{template_name}": {
+            "get": {
+                "description": "get a changefeed template of a namespace",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template",
+                    "v2"
+                ],
+                "summary": "Get a changefeed template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "template_name",
+                        "name": "template_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "replace a changefeed template, the changefeeds created\nwith it before are not affected, an empty sink uri keeps\nthe sink uri of the template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template",
+                    "v2"
+                ],
+                "summary": "Update a changefeed template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "template_name",
+                        "name": "template_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "changefeed template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ChangefeedTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove a changefeed template, the changefeeds created\nwith it are not affected",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template",
+                    "v2"
+                ],
+                "summary": "Remove a changefeed template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "template_name",
+                        "name": "template_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/upstreams": {
+            "get": {
+                "description": "list all upstreams of a namespace, including the default upstream",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstream",
+                    "v2"
+                ],
+                "summary": "List upstreams",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v2.Upstream"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "register an upstream TiDB cluster with a name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstream",
+                    "v2"
+                ],
+                "summary": "Create an upstream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "upstream config",
+                        "name": "upstream",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.CreateUpstreamConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Upstream"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/upstreams/{upstream_id}": {
+            "delete": {
+                "description": "remove an upstream which is not used by any changefeed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstream",
+                    "v2"
+                ],
+                "summary": "Remove an upstream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "upstream_id",
+                        "name": "upstream_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/upstreams/{upstream_id}/gc_safepoint": {
+            "get": {
+                "description": "get the gc safepoint and the service gc safepoints of an upstream",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstream",
+                    "v2"
+                ],
+                "summary": "Get the gc safepoints of an upstream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "upstream_id",
+                        "name": "upstream_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.UpstreamGCSafePoint"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/upstreams/{upstream_id}/health": {
+            "get": {
+                "description": "check the pd members and the tso service of an upstream",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstream",
+                    "v2"
+                ],
+                "summary": "Get the health of an upstream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "upstream_id",
+                        "name": "upstream_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "default",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.UpstreamHealth"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "config.CSVConfig": {
+            "type": "object",
+            "properties": {
+                "binary-encoding-method": {
+                    "description": "encoding method of binary type",
+                    "type": "string"
+                },
+                "delimiter": {
+                    "description": "delimiter between fields, it can be 1 character or at most 2 characters\nIt can not be CR or LF or contains CR or LF.\nIt should have exclusive characters with quote.",
+                    "type": "string"
+                },
+                "include-commit-ts": {
+                    "description": "whether to include commit ts",
+                    "type": "boolean"
+                },
+                "null": {
+                    "description": "representation of null values",
+                    "type": "string"
+                },
+                "output-handle-key": {
+                    "description": "output handle key",
+                    "type": "boolean"
                 },
                 "output-old-value": {
                     "description": "output old value",
@@ -1501,6 +2640,14 @@ var doc = `{
             "properties": {
                 "output-old-value": {
                     "type": "boolean"
+                },
+                "schema-change-topic": {
+                    "description": "SchemaChangeTopic is the topic which all the DDL events are sent to,\nas the schema change topic of Debezium. If it's empty, the DDL events\nare dispatched by the topic dispatchers.",
+                    "type": "string"
+                },
+                "transaction-topic": {
+                    "description": "TransactionTopic is the topic which the BEGIN and END transaction\nmetadata events are sent to. If it's empty, no transaction metadata\nis emitted.",
+                    "type": "string"
                 }
             }
         },
@@ -1879,384 +3026,751 @@ var doc = `{
                     "description": "ContentCompatible is only available when the downstream is MQ.",
                     "type": "boolean"
                 },
-                "csv": {
-                    "description": "CSVConfig is only available when the downstream is Storage.",
-                    "$ref": "#/definitions/config.CSVConfig"
+                "csv": {
+                    "description": "CSVConfig is only available when the downstream is Storage.",
+                    "$ref": "#/definitions/config.CSVConfig"
+                },
+                "date-separator": {
+                    "description": "DateSeparator is only available when the downstream is Storage.",
+                    "type": "string"
+                },
+                "debezium": {
+                    "description": "DebeziumConfig related configurations",
+                    "$ref": "#/definitions/config.DebeziumConfig"
+                },
+                "debezium-disable-schema": {
+                    "description": "Debezium only. Whether schema should be excluded in the output.",
+                    "type": "boolean"
+                },
+                "delete-only-output-handle-key-columns": {
+                    "description": "DeleteOnlyOutputHandleKeyColumns is only available when the downstream is MQ.",
+                    "type": "boolean"
+                },
+                "dispatchers": {
+                    "description": "DispatchRules is only available when the downstream is MQ.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.DispatchRule"
+                    }
+                },
+                "enable-kafka-sink-v2": {
+                    "description": "EnableKafkaSinkV2 enabled then the kafka-go sink will be used.\nIt is only available when the downstream is MQ.",
+                    "type": "boolean"
+                },
+                "enable-partition-separator": {
+                    "description": "EnablePartitionSeparator is only available when the downstream is Storage.",
+                    "type": "boolean"
+                },
+                "encoder-concurrency": {
+                    "description": "EncoderConcurrency is only available when the downstream is MQ.",
+                    "type": "integer"
+                },
+                "file-index-digit": {
+                    "description": "FileIndexWidth is only available when the downstream is Storage",
+                    "type": "integer"
+                },
+                "kafka-config": {
+                    "$ref": "#/definitions/config.KafkaConfig"
+                },
+                "mysql-config": {
+                    "$ref": "#/definitions/config.MySQLConfig"
+                },
+                "only-output-updated-columns": {
+                    "description": "OnlyOutputUpdatedColumns is only available when the downstream is MQ.",
+                    "type": "boolean"
+                },
+                "open": {
+                    "description": "OpenProtocol related configurations",
+                    "$ref": "#/definitions/config.OpenProtocolConfig"
+                },
+                "protocol": {
+                    "description": "Protocol is NOT available when the downstream is DB.",
+                    "type": "string"
+                },
+                "pulsar-config": {
+                    "$ref": "#/definitions/config.PulsarConfig"
+                },
+                "safe-mode": {
+                    "description": "SafeMode is only available when the downstream is DB.",
+                    "type": "boolean"
+                },
+                "schema-registry": {
+                    "description": "SchemaRegistry is only available when the downstream is MQ using avro protocol.",
+                    "type": "string"
+                },
+                "send-all-bootstrap-at-start": {
+                    "description": "SendAllBootstrapAtStart determines whether to send all tables bootstrap message at changefeed start.",
+                    "type": "boolean"
+                },
+                "send-bootstrap-in-msg-count": {
+                    "description": "SendBootstrapInMsgCount means bootstrap messages are being sent every SendBootstrapInMsgCount row change messages.",
+                    "type": "integer"
+                },
+                "send-bootstrap-interval-in-sec": {
+                    "description": "Simple Protocol only config, use to control the behavior of sending bootstrap message.\nNote: When one of the following conditions is set to negative value,\nbootstrap sending function will be disabled.\nSendBootstrapIntervalInSec is the interval in seconds to send bootstrap message.",
+                    "type": "integer"
+                },
+                "send-bootstrap-to-all-partition": {
+                    "description": "SendBootstrapToAllPartition determines whether to send bootstrap message to all partitions.\nIf set to false, bootstrap message will only be sent to the first partition of each topic.\nDefault value is true.",
+                    "type": "boolean"
+                },
+                "terminator": {
+                    "description": "Terminator is NOT available when the downstream is DB.",
+                    "type": "string"
+                },
+                "transaction-atomicity": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Capture": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "cluster_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_owner": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.CaptureTaskStatus": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
+                },
+                "table_ids": {
+                    "description": "Table list, containing tables that processor should process",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "table_operations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.TableOperation"
+                    }
+                }
+            }
+        },
+        "model.ChangefeedCommonInfo": {
+            "type": "object",
+            "properties": {
+                "checkpoint_time": {
+                    "type": "string"
+                },
+                "checkpoint_tso": {
+                    "type": "integer"
+                },
+                "error": {
+                    "$ref": "#/definitions/model.RunningError"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "upstream_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChangefeedConfig": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "filter_rules": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "force_replicate": {
+                    "description": "if true, force to replicate some ineligible tables",
+                    "type": "boolean",
+                    "default": false
+                },
+                "ignore_ineligible_table": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "ignore_txn_start_ts": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "mounter_worker_num": {
+                    "type": "integer",
+                    "default": 16
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "sink_config": {
+                    "$ref": "#/definitions/config.SinkConfig"
+                },
+                "sink_uri": {
+                    "type": "string"
+                },
+                "start_ts": {
+                    "type": "integer"
+                },
+                "target_ts": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "description": "timezone used when checking sink uri",
+                    "type": "string",
+                    "default": "system"
+                }
+            }
+        },
+        "model.ChangefeedDetail": {
+            "type": "object",
+            "properties": {
+                "checkpoint_time": {
+                    "type": "string"
+                },
+                "checkpoint_tso": {
+                    "type": "integer"
+                },
+                "create_time": {
+                    "type": "string"
+                },
+                "creator_version": {
+                    "type": "string"
+                },
+                "error": {
+                    "$ref": "#/definitions/model.RunningError"
+                },
+                "error_history": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "resolved_ts": {
+                    "type": "integer"
+                },
+                "sink_uri": {
+                    "type": "string"
                 },
-                "date-separator": {
-                    "description": "DateSeparator is only available when the downstream is Storage.",
+                "sort_engine": {
                     "type": "string"
                 },
-                "debezium": {
-                    "description": "DebeziumConfig related configurations",
-                    "$ref": "#/definitions/config.DebeziumConfig"
+                "start_ts": {
+                    "type": "integer"
                 },
-                "debezium-disable-schema": {
-                    "description": "Debezium only. Whether schema should be excluded in the output.",
-                    "type": "boolean"
+                "state": {
+                    "type": "string"
                 },
-                "delete-only-output-handle-key-columns": {
-                    "description": "DeleteOnlyOutputHandleKeyColumns is only available when the downstream is MQ.",
-                    "type": "boolean"
+                "target_ts": {
+                    "type": "integer"
                 },
-                "dispatchers": {
-                    "description": "DispatchRules is only available when the downstream is MQ.",
+                "task_status": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config.DispatchRule"
+                        "$ref": "#/definitions/model.CaptureTaskStatus"
                     }
                 },
-                "enable-kafka-sink-v2": {
-                    "description": "EnableKafkaSinkV2 enabled then the kafka-go sink will be used.\nIt is only available when the downstream is MQ.",
-                    "type": "boolean"
+                "upstream_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChangefeedStatistics": {
+            "type": "object",
+            "properties": {
+                "bytes_per_second": {
+                    "type": "number"
                 },
-                "enable-partition-separator": {
-                    "description": "EnablePartitionSeparator is only available when the downstream is Storage.",
-                    "type": "boolean"
+                "checkpoint_lag": {
+                    "description": "CheckpointLag and ResolvedLag are the seconds between the checkpoint\nts, the resolved ts and the current ts of the upstream.",
+                    "type": "number"
                 },
-                "encoder-concurrency": {
-                    "description": "EncoderConcurrency is only available when the downstream is MQ.",
+                "checkpoint_ts": {
                     "type": "integer"
                 },
-                "file-index-digit": {
-                    "description": "FileIndexWidth is only available when the downstream is Storage",
+                "current_ts": {
                     "type": "integer"
                 },
-                "kafka-config": {
-                    "$ref": "#/definitions/config.KafkaConfig"
+                "emitted_bytes": {
+                    "type": "integer"
                 },
-                "mysql-config": {
-                    "$ref": "#/definitions/config.MySQLConfig"
+                "emitted_rows": {
+                    "type": "integer"
                 },
-                "only-output-updated-columns": {
-                    "description": "OnlyOutputUpdatedColumns is only available when the downstream is MQ.",
-                    "type": "boolean"
+                "executed_ddls": {
+                    "description": "ExecutedDDLs is the number of DDLs executed since the changefeed is\nstarted by the current owner.",
+                    "type": "integer"
                 },
-                "open": {
-                    "description": "OpenProtocol related configurations",
-                    "$ref": "#/definitions/config.OpenProtocolConfig"
+                "resolved_lag": {
+                    "type": "number"
                 },
-                "protocol": {
-                    "description": "Protocol is NOT available when the downstream is DB.",
+                "resolved_ts": {
+                    "type": "integer"
+                },
+                "rows_per_second": {
+                    "description": "RowsPerSecond and BytesPerSecond are the throughput of the sink\nmeasured in the last statistics interval.",
+                    "type": "number"
+                },
+                "sink_pending_events": {
+                    "type": "integer"
+                },
+                "slowest_tables": {
+                    "description": "SlowestTables are the tables with the largest checkpoint lag.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TableStatistics"
+                    }
+                },
+                "sorter_disk_usage": {
+                    "type": "integer"
+                },
+                "tables": {
+                    "description": "Tables are ordered by table id.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TableStatistics"
+                    }
+                }
+            }
+        },
+        "model.HTTPError": {
+            "type": "object",
+            "properties": {
+                "error_code": {
                     "type": "string"
                 },
-                "pulsar-config": {
-                    "$ref": "#/definitions/config.PulsarConfig"
+                "error_msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MoveTableReq": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
                 },
-                "safe-mode": {
-                    "description": "SafeMode is only available when the downstream is DB.",
-                    "type": "boolean"
+                "table_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.OwnerDiagnosis": {
+            "type": "object",
+            "properties": {
+                "barrier_ts": {
+                    "description": "BarrierTs and BarrierType are the minimum barrier except DDLs,\ne.g. a sync point or the target ts of the changefeed.",
+                    "type": "integer"
                 },
-                "schema-registry": {
-                    "description": "SchemaRegistry is only available when the downstream is MQ using avro protocol.",
+                "barrier_type": {
                     "type": "string"
                 },
-                "send-all-bootstrap-at-start": {
-                    "description": "SendAllBootstrapAtStart determines whether to send all tables bootstrap message at changefeed start.",
-                    "type": "boolean"
+                "checkpoint_ts": {
+                    "type": "integer"
                 },
-                "send-bootstrap-in-msg-count": {
-                    "description": "SendBootstrapInMsgCount means bootstrap messages are being sent every SendBootstrapInMsgCount row change messages.",
+                "ddl_resolved_ts": {
+                    "description": "DDLResolvedTs is the resolved ts of the DDL puller.",
                     "type": "integer"
                 },
-                "send-bootstrap-interval-in-sec": {
-                    "description": "Simple Protocol only config, use to control the behavior of sending bootstrap message.\nNote: When one of the following conditions is set to negative value,\nbootstrap sending function will be disabled.\nSendBootstrapIntervalInSec is the interval in seconds to send bootstrap message.",
+                "pending_ddls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PendingDDLDiagnosis"
+                    }
+                },
+                "resolved_ts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PendingDDLDiagnosis": {
+            "type": "object",
+            "properties": {
+                "commit_ts": {
                     "type": "integer"
                 },
-                "send-bootstrap-to-all-partition": {
-                    "description": "SendBootstrapToAllPartition determines whether to send bootstrap message to all partitions.\nIf set to false, bootstrap message will only be sent to the first partition of each topic.\nDefault value is true.",
+                "executing": {
                     "type": "boolean"
                 },
-                "terminator": {
-                    "description": "Terminator is NOT available when the downstream is DB.",
+                "query": {
                     "type": "string"
                 },
-                "transaction-atomicity": {
+                "table_name": {
                     "type": "string"
                 }
             }
         },
-        "model.Capture": {
+        "model.ProcessorCommonInfo": {
             "type": "object",
             "properties": {
-                "address": {
+                "capture_id": {
                     "type": "string"
                 },
-                "cluster_id": {
+                "changefeed_id": {
                     "type": "string"
                 },
-                "id": {
+                "namespace": {
                     "type": "string"
-                },
-                "is_owner": {
-                    "type": "boolean"
                 }
             }
         },
-        "model.CaptureTaskStatus": {
+        "model.ProcessorDetail": {
             "type": "object",
             "properties": {
-                "capture_id": {
-                    "type": "string"
-                },
                 "table_ids": {
-                    "description": "Table list, containing tables that processor should process",
+                    "description": "All table ids that this processor are replicating.",
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
+                }
+            }
+        },
+        "model.ProcessorDiagnosis": {
+            "type": "object",
+            "properties": {
+                "capture_id": {
+                    "type": "string"
                 },
-                "table_operations": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/model.TableOperation"
+                "current_ts": {
+                    "type": "integer"
+                },
+                "slowest_table": {
+                    "description": "SlowestTable is the table with the smallest checkpoint ts.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TableDiagnosis"
+                        }
+                    ]
+                },
+                "sorter_disk_usage": {
+                    "description": "SorterDiskUsage is the disk usage of the sort engine of the changefeed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.SorterDiskUsage"
+                        }
+                    ]
+                },
+                "stages": {
+                    "description": "Stages are the slowest table spans of each stage.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StageDiagnosis"
                     }
                 }
             }
         },
-        "model.ChangefeedCommonInfo": {
+        "model.ResolvedTsDiagnosis": {
             "type": "object",
             "properties": {
-                "checkpoint_time": {
-                    "type": "string"
+                "blocking": {
+                    "description": "Blocking is the stage of the slowest table which holds back the\ncheckpoint ts the most.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.StageDiagnosis"
+                        }
+                    ]
                 },
-                "checkpoint_tso": {
+                "checkpoint_ts": {
                     "type": "integer"
                 },
-                "error": {
-                    "$ref": "#/definitions/model.RunningError"
+                "current_ts": {
+                    "type": "integer"
                 },
-                "id": {
+                "errors": {
+                    "description": "Errors are the captures that fail to report their processors.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "owner": {
+                    "$ref": "#/definitions/model.OwnerDiagnosis"
+                },
+                "resolved_ts": {
+                    "type": "integer"
+                },
+                "slowest_table": {
+                    "$ref": "#/definitions/model.TableDiagnosis"
+                },
+                "sorter_disk_usage": {
+                    "description": "SorterDiskUsage is the disk usage of the sort engine on each capture.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.SorterDiskUsage"
+                    }
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StageDiagnosis"
+                    }
+                }
+            }
+        },
+        "model.RunningError": {
+            "type": "object",
+            "properties": {
+                "addr": {
                     "type": "string"
                 },
-                "namespace": {
+                "code": {
                     "type": "string"
                 },
-                "state": {
+                "message": {
                     "type": "string"
                 },
-                "upstream_id": {
-                    "type": "integer"
+                "time": {
+                    "type": "string"
                 }
             }
         },
-        "model.ChangefeedConfig": {
+        "model.ServerStatus": {
             "type": "object",
             "properties": {
-                "changefeed_id": {
+                "cluster_id": {
                     "type": "string"
                 },
-                "filter_rules": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "git_hash": {
+                    "type": "string"
                 },
-                "force_replicate": {
-                    "description": "if true, force to replicate some ineligible tables",
-                    "type": "boolean",
-                    "default": false
+                "id": {
+                    "type": "string"
                 },
-                "ignore_ineligible_table": {
-                    "type": "boolean",
-                    "default": false
+                "is_owner": {
+                    "type": "boolean"
                 },
-                "ignore_txn_start_ts": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "liveness": {
+                    "type": "integer"
                 },
-                "mounter_worker_num": {
-                    "type": "integer",
-                    "default": 16
+                "pid": {
+                    "type": "integer"
                 },
-                "namespace": {
+                "version": {
                     "type": "string"
+                }
+            }
+        },
+        "model.SorterDiskUsage": {
+            "type": "object",
+            "properties": {
+                "capture_quota_bytes": {
+                    "type": "integer"
                 },
-                "sink_config": {
-                    "$ref": "#/definitions/config.SinkConfig"
+                "capture_used_bytes": {
+                    "description": "CaptureUsedBytes and CaptureQuotaBytes are the usage and the quota\nof all sort engines on the capture.",
+                    "type": "integer"
                 },
-                "sink_uri": {
-                    "type": "string"
+                "exceeded": {
+                    "description": "Exceeded is true if any of the quotas is exceeded.",
+                    "type": "boolean"
                 },
-                "start_ts": {
+                "quota_bytes": {
+                    "description": "QuotaBytes is the quota of the changefeed, 0 means no limit.",
                     "type": "integer"
                 },
-                "target_ts": {
+                "used_bytes": {
                     "type": "integer"
-                },
-                "timezone": {
-                    "description": "timezone used when checking sink uri",
-                    "type": "string",
-                    "default": "system"
                 }
             }
         },
-        "model.ChangefeedDetail": {
+        "model.StageDiagnosis": {
             "type": "object",
             "properties": {
-                "checkpoint_time": {
-                    "type": "string"
-                },
-                "checkpoint_tso": {
-                    "type": "integer"
-                },
-                "create_time": {
+                "capture_id": {
                     "type": "string"
                 },
-                "creator_version": {
+                "end_key": {
                     "type": "string"
                 },
-                "error": {
-                    "$ref": "#/definitions/model.RunningError"
-                },
-                "error_history": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "lag": {
+                    "description": "Lag is the seconds that the stage holds back the resolved ts compared\nwith the stage before it, the lag of the kv-client stage is compared\nwith the current ts of the upstream.",
+                    "type": "number"
                 },
-                "id": {
+                "message": {
                     "type": "string"
                 },
-                "namespace": {
-                    "type": "string"
+                "pending_events": {
+                    "description": "PendingEvents is only set in the sink stage.",
+                    "type": "integer"
                 },
-                "resolved_ts": {
+                "region_id": {
+                    "description": "RegionID and StoreID are only set in the kv-client stage.",
                     "type": "integer"
                 },
-                "sink_uri": {
-                    "type": "string"
+                "resolved_ts": {
+                    "type": "integer"
                 },
-                "sort_engine": {
+                "stage": {
                     "type": "string"
                 },
-                "start_ts": {
-                    "type": "integer"
-                },
-                "state": {
+                "start_key": {
+                    "description": "StartKey and EndKey are hex encoded.",
                     "type": "string"
                 },
-                "target_ts": {
+                "store_id": {
                     "type": "integer"
                 },
-                "task_status": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.CaptureTaskStatus"
-                    }
-                },
-                "upstream_id": {
+                "table_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "model.HTTPError": {
-            "type": "object",
-            "properties": {
-                "error_code": {
-                    "type": "string"
                 },
-                "error_msg": {
+                "table_name": {
                     "type": "string"
                 }
             }
         },
-        "model.MoveTableReq": {
+        "model.TableDiagnosis": {
             "type": "object",
             "properties": {
                 "capture_id": {
                     "type": "string"
                 },
-                "table_id": {
+                "checkpoint_ts": {
                     "type": "integer"
-                }
-            }
-        },
-        "model.ProcessorCommonInfo": {
-            "type": "object",
-            "properties": {
-                "capture_id": {
+                },
+                "end_key": {
                     "type": "string"
                 },
-                "changefeed_id": {
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StageDiagnosis"
+                    }
+                },
+                "start_key": {
                     "type": "string"
                 },
-                "namespace": {
+                "table_id": {
+                    "type": "integer"
+                },
+                "table_name": {
                     "type": "string"
                 }
             }
         },
-        "model.ProcessorDetail": {
+        "model.TableOperation": {
             "type": "object",
             "properties": {
-                "table_ids": {
-                    "description": "All table ids that this processor are replicating.",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "boundary_ts": {
+                    "description": "if the operation is a delete operation, BoundaryTs is checkpoint ts\nif the operation is an add operation, BoundaryTs is start ts",
+                    "type": "integer"
+                },
+                "delete": {
+                    "type": "boolean"
+                },
+                "flag": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
                 }
             }
         },
-        "model.RunningError": {
+        "model.TableStatistics": {
             "type": "object",
             "properties": {
-                "addr": {
-                    "type": "string"
+                "checkpoint_lag": {
+                    "description": "CheckpointLag is the seconds between the checkpoint ts and the\ncurrent ts of the upstream.",
+                    "type": "number"
                 },
-                "code": {
-                    "type": "string"
+                "checkpoint_ts": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string"
+                "emitted_bytes": {
+                    "type": "integer"
                 },
-                "time": {
+                "emitted_rows": {
+                    "description": "EmittedRows and EmittedBytes are the number and the approximate bytes\nof the rows emitted to the sink since the table is added to the capture\nwhich replicates it.",
+                    "type": "integer"
+                },
+                "resolved_ts": {
+                    "type": "integer"
+                },
+                "sink_pending_events": {
+                    "description": "SinkPendingEvents is the number of events written to the sink but not\nflushed to the downstream.",
+                    "type": "integer"
+                },
+                "sorter_disk_usage": {
+                    "description": "SorterDiskUsage is the approximate bytes of the events of the table\nin the sort engine.",
+                    "type": "integer"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "table_name": {
                     "type": "string"
                 }
             }
         },
-        "model.ServerStatus": {
+        "schemahistory.History": {
             "type": "object",
             "properties": {
-                "cluster_id": {
-                    "type": "string"
-                },
-                "git_hash": {
+                "changefeed": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "is_owner": {
-                    "type": "boolean"
-                },
-                "liveness": {
+                "end_ts": {
                     "type": "integer"
                 },
-                "pid": {
+                "format_version": {
                     "type": "integer"
                 },
-                "version": {
+                "namespace": {
                     "type": "string"
+                },
+                "start_ts": {
+                    "type": "integer"
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemahistory.TableVersion"
+                    }
                 }
             }
         },
-        "model.TableOperation": {
+        "schemahistory.TableVersion": {
             "type": "object",
             "properties": {
-                "boundary_ts": {
-                    "description": "if the operation is a delete operation, BoundaryTs is checkpoint ts\nif the operation is an add operation, BoundaryTs is start ts",
+                "schema": {
+                    "type": "string"
+                },
+                "schema_id": {
                     "type": "integer"
                 },
-                "delete": {
-                    "type": "boolean"
+                "table": {
+                    "type": "string"
                 },
-                "flag": {
+                "table_id": {
                     "type": "integer"
                 },
-                "status": {
+                "table_info": {
+                    "type": "object"
+                },
+                "version": {
                     "type": "integer"
                 }
             }
@@ -2363,6 +3877,26 @@ var doc = `{
                 }
             }
         },
+        "v2.ChangefeedCloneConfig": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace is the namespace of the new changefeed, it's the namespace\nof the source changefeed if it's empty.",
+                    "type": "string"
+                },
+                "sink_uri": {
+                    "description": "SinkURI is the sink uri of the source changefeed if it's empty.",
+                    "type": "string"
+                },
+                "start_ts": {
+                    "description": "StartTs is the checkpoint ts of the source changefeed if it's 0.",
+                    "type": "integer"
+                }
+            }
+        },
         "v2.ChangefeedCommonInfo": {
             "type": "object",
             "properties": {
@@ -2430,6 +3964,83 @@ var doc = `{
                 },
                 "target_ts": {
                     "type": "integer"
+                },
+                "template": {
+                    "description": "Template is the name of a changefeed template in the namespace, the\nchangefeed inherits the sink uri and the replica config of it.",
+                    "type": "string"
+                },
+                "upstream_name": {
+                    "description": "UpstreamName is the name of a registered upstream, the changefeed is\ncreated against it instead of PDConfig if it's set.",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.ChangefeedExport": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "checkpoint_ts": {
+                    "description": "CheckpointTs is the checkpoint ts of the changefeed when it's exported.",
+                    "type": "integer"
+                },
+                "format_version": {
+                    "type": "integer"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "replica_config": {
+                    "$ref": "#/definitions/v2.ReplicaConfig"
+                },
+                "sink_uri": {
+                    "description": "SinkURI is templated, the secrets in it are replaced with\nvariables like ${SINK_PASSWORD}.",
+                    "type": "string"
+                },
+                "start_ts_policy": {
+                    "type": "string"
+                },
+                "target_ts": {
+                    "type": "integer"
+                },
+                "variables": {
+                    "description": "Variables are the names of the variables in SinkURI and ReplicaConfig,\ntheir values must be provided when the document is imported.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v2.ChangefeedImportConfig": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "document": {
+                    "$ref": "#/definitions/v2.ChangefeedExport"
+                },
+                "namespace": {
+                    "description": "Namespace, ID, StartTs and TargetTs override the ones derived from\nthe document if they are set.",
+                    "type": "string"
+                },
+                "start_ts": {
+                    "type": "integer"
+                },
+                "target_ts": {
+                    "type": "integer"
+                },
+                "upstream_name": {
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "Variables are the values of the variables in the document.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -2440,13 +4051,34 @@ var doc = `{
                     "description": "EnableTableAcrossNodes set true to split one table to multiple spans and\ndistribute to multiple TiCDC nodes.",
                     "type": "boolean"
                 },
-                "region_threshold": {
-                    "description": "RegionThreshold is the region count threshold of splitting a table.",
-                    "type": "integer"
+                "region_threshold": {
+                    "description": "RegionThreshold is the region count threshold of splitting a table.",
+                    "type": "integer"
+                },
+                "write_key_threshold": {
+                    "description": "WriteKeyThreshold is the written keys threshold of splitting a table.",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.ChangefeedTemplate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "replica_config": {
+                    "description": "ReplicaConfig is a partial replica config which overrides the default\nreplica config of the namespace.",
+                    "type": "object"
                 },
-                "write_key_threshold": {
-                    "description": "WriteKeyThreshold is the written keys threshold of splitting a table.",
-                    "type": "integer"
+                "sink_uri": {
+                    "type": "string"
                 }
             }
         },
@@ -2565,11 +4197,46 @@ var doc = `{
                 }
             }
         },
+        "v2.CreateUpstreamConfig": {
+            "type": "object",
+            "properties": {
+                "ca_path": {
+                    "type": "string"
+                },
+                "cert_allowed_cn": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cert_path": {
+                    "type": "string"
+                },
+                "key_path": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pd_addrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "v2.DebeziumConfig": {
             "type": "object",
             "properties": {
                 "output_old_value": {
                     "type": "boolean"
+                },
+                "schema_change_topic": {
+                    "type": "string"
+                },
+                "transaction_topic": {
+                    "type": "string"
                 }
             }
         },
@@ -2582,6 +4249,9 @@ var doc = `{
                         "type": "string"
                     }
                 },
+                "hash_function": {
+                    "type": "string"
+                },
                 "index": {
                     "type": "string"
                 },
@@ -2594,8 +4264,20 @@ var doc = `{
                 "partition": {
                     "type": "string"
                 },
+                "partition_num": {
+                    "type": "integer"
+                },
+                "partitions": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "topic": {
                     "type": "string"
+                },
+                "topic_config": {
+                    "$ref": "#/definitions/v2.TopicConfig"
                 }
             }
         },
@@ -2605,6 +4287,10 @@ var doc = `{
         "v2.EventFilterRule": {
             "type": "object",
             "properties": {
+                "ignore_ddl_expr": {
+                    "description": "sql expression on ddl metadata",
+                    "type": "string"
+                },
                 "ignore_delete_value_expr": {
                     "type": "string"
                 },
@@ -2625,6 +4311,13 @@ var doc = `{
                         "type": "string"
                     }
                 },
+                "ignore_update_changed_columns": {
+                    "description": "columns that an update is ignored if it only changes them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "ignore_update_new_value_expr": {
                     "type": "string"
                 },
@@ -2686,12 +4379,32 @@ var doc = `{
                 }
             }
         },
+        "v2.HookConfig": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "v2.IntegrityConfig": {
             "type": "object",
             "properties": {
                 "corruption_handle_level": {
                     "type": "string"
                 },
+                "corruption_log_dir": {
+                    "type": "string"
+                },
                 "integrity_check_level": {
                     "type": "string"
                 }
@@ -2861,6 +4574,9 @@ var doc = `{
                 "enable_cache_prepared_statement": {
                     "type": "boolean"
                 },
+                "enable_checkpoint_table": {
+                    "type": "boolean"
+                },
                 "enable_multi_statement": {
                     "type": "boolean"
                 },
@@ -2894,6 +4610,9 @@ var doc = `{
                 "timeout": {
                     "type": "string"
                 },
+                "verify_checksum": {
+                    "type": "boolean"
+                },
                 "worker_count": {
                     "type": "integer"
                 },
@@ -2902,6 +4621,71 @@ var doc = `{
                 }
             }
         },
+        "v2.Namespace": {
+            "type": "object",
+            "properties": {
+                "changefeeds": {
+                    "description": "Changefeeds are the changefeeds in the namespace.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "default_replica_config": {
+                    "description": "DefaultReplicaConfig is a partial replica config, the changefeeds\ncreated in the namespace inherit the fields set in it.",
+                    "type": "object"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "memory_quota_used": {
+                    "description": "MemoryQuotaUsed is the sum of the memory quota of the changefeeds.",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quota": {
+                    "$ref": "#/definitions/v2.NamespaceQuota"
+                }
+            }
+        },
+        "v2.NamespaceConfig": {
+            "type": "object",
+            "properties": {
+                "default_replica_config": {
+                    "description": "DefaultReplicaConfig is a partial replica config, the changefeeds\ncreated in the namespace inherit the fields set in it.",
+                    "type": "object"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quota": {
+                    "$ref": "#/definitions/v2.NamespaceQuota"
+                }
+            }
+        },
+        "v2.NamespaceQuota": {
+            "type": "object",
+            "properties": {
+                "max_changefeeds": {
+                    "type": "integer"
+                },
+                "max_memory_quota": {
+                    "description": "MaxMemoryQuota is the max sum of the memory quota of the changefeeds\nin the namespace, in bytes.",
+                    "type": "integer"
+                }
+            }
+        },
         "v2.OpenProtocolConfig": {
             "type": "object",
             "properties": {
@@ -2910,6 +4694,20 @@ var doc = `{
                 }
             }
         },
+        "v2.PDMemberHealth": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "boolean"
+                }
+            }
+        },
         "v2.ProcessorCommonInfo": {
             "type": "object",
             "properties": {
@@ -3048,6 +4846,12 @@ var doc = `{
                 "force_replicate": {
                     "type": "boolean"
                 },
+                "hooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.HookConfig"
+                    }
+                },
                 "ignore_ineligible_table": {
                     "type": "boolean"
                 },
@@ -3060,12 +4864,18 @@ var doc = `{
                 "mounter": {
                     "$ref": "#/definitions/v2.MounterConfig"
                 },
+                "schedule": {
+                    "$ref": "#/definitions/v2.ScheduleConfig"
+                },
                 "scheduler": {
                     "$ref": "#/definitions/v2.ChangefeedSchedulerConfig"
                 },
                 "sink": {
                     "$ref": "#/definitions/v2.SinkConfig"
                 },
+                "sorter_disk_quota": {
+                    "type": "integer"
+                },
                 "sql_mode": {
                     "description": "Deprecated: we don't use this field since v8.0.0.",
                     "type": "string"
@@ -3127,6 +4937,27 @@ var doc = `{
                 }
             }
         },
+        "v2.ScheduleConfig": {
+            "type": "object",
+            "properties": {
+                "delayed_start": {
+                    "description": "DelayedStart allows the start-ts of the changefeed to be later than the\ncurrent tso, the changefeed waits until the upstream reaches it.",
+                    "type": "boolean"
+                },
+                "pause_cron": {
+                    "description": "PauseCron is a standard cron expression, the changefeed is paused when\nit fires.",
+                    "type": "string"
+                },
+                "resume_cron": {
+                    "description": "ResumeCron is a standard cron expression, the changefeed paused is\nresumed when it fires.",
+                    "type": "string"
+                },
+                "time_zone": {
+                    "description": "TimeZone is the time zone that the cron expressions are evaluated in.",
+                    "type": "string"
+                }
+            }
+        },
         "v2.ServerStatus": {
             "type": "object",
             "properties": {
@@ -3153,6 +4984,20 @@ var doc = `{
                 }
             }
         },
+        "v2.ServiceGCSafePoint": {
+            "type": "object",
+            "properties": {
+                "expired_at": {
+                    "type": "integer"
+                },
+                "safe_point": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "string"
+                }
+            }
+        },
         "v2.SinkConfig": {
             "type": "object",
             "properties": {
@@ -3283,6 +5128,134 @@ var doc = `{
                     "type": "integer"
                 }
             }
+        },
+        "v2.TopicConfig": {
+            "type": "object",
+            "properties": {
+                "allow_partition_growth": {
+                    "type": "boolean"
+                },
+                "cleanup_policy": {
+                    "type": "string"
+                },
+                "configs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "partition_num": {
+                    "type": "integer"
+                },
+                "replication_factor": {
+                    "type": "integer"
+                },
+                "retention_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.Upstream": {
+            "type": "object",
+            "properties": {
+                "ca_path": {
+                    "type": "string"
+                },
+                "cert_allowed_cn": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cert_path": {
+                    "type": "string"
+                },
+                "changefeeds": {
+                    "description": "Changefeeds are the changefeeds replicating from the upstream.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "description": "IsDefault is true if it's the upstream that the cdc cluster\nis deployed with.",
+                    "type": "boolean"
+                },
+                "key_path": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pd_addrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v2.UpstreamGCSafePoint": {
+            "type": "object",
+            "properties": {
+                "cdc_safe_point": {
+                    "description": "CDCSafePoint is the service gc safepoint of the cdc cluster,\nit's nil if there is no changefeed replicating from the upstream.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v2.ServiceGCSafePoint"
+                        }
+                    ]
+                },
+                "cdc_service_id": {
+                    "description": "CDCServiceID is the gc service id of the cdc cluster.",
+                    "type": "string"
+                },
+                "gc_safe_point": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "service_safe_points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.ServiceGCSafePoint"
+                    }
+                }
+            }
+        },
+        "v2.UpstreamHealth": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.PDMemberHealth"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tso": {
+                    "description": "Tso is the current tso of the upstream, it's zero if the pd is unavailable.",
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -60,6 +60,8 @@ type CDCRESTInterface interface {
 type BasicAuth struct {
 	User     string
 	Password string
+	// Token is the bearer token, it takes precedence over the user and password.
+	Token string
 }
 
 // CDCRESTClient defines a TiCDC RESTful client
@@ -41,14 +41,16 @@ type Config struct {
 }
 
 // parseAuthentication parses the authentication information from the config and
-// removes the user and password from the values.
+// removes the user, password and token from the values.
 func (c *Config) parseAuthentication() {
 	c.authentication = BasicAuth{
 		User:     c.Values.Get(api.APIOpVarTiCDCUser),
 		Password: c.Values.Get(api.APIOpVarTiCDCPassword),
+		Token:    c.Values.Get(api.APIOpVarTiCDCToken),
 	}
 	c.Values.Del(api.APIOpVarTiCDCUser)
 	c.Values.Del(api.APIOpVarTiCDCPassword)
+	c.Values.Del(api.APIOpVarTiCDCToken)
 }
 
 // defaultServerURLFromConfig is used to build base URL and api path.
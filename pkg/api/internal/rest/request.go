@@ -258,7 +258,11 @@ func (r *Request) newHTTPRequest(ctx context.Context) (*http.Request, error) {
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.headers
-	req.SetBasicAuth(r.basicAuth.User, r.basicAuth.Password)
+	if r.basicAuth.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.basicAuth.Token)
+	} else {
+		req.SetBasicAuth(r.basicAuth.User, r.basicAuth.Password)
+	}
 	return req, nil
 }
 
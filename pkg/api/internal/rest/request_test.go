@@ -90,6 +90,35 @@ func TestRequestHeader(t *testing.T) {
 	_ = req.Do(context.Background())
 }
 
+func TestRequestAuthorization(t *testing.T) {
+	var authorization string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	newClient := func(values url.Values) *CDCRESTClient {
+		c, err := CDCRESTClientFromConfig(&Config{
+			Host:    testServer.URL,
+			APIPath: "/api",
+			Version: "v2",
+			Values:  values,
+		})
+		require.Nil(t, err)
+		return c
+	}
+
+	c := newClient(url.Values{"user": {"root"}, "password": {"secret"}})
+	require.Nil(t, c.Get().WithPrefix("/test").Do(context.Background()).Error())
+	require.True(t, strings.HasPrefix(authorization, "Basic "))
+
+	// the token takes precedence over the user and password.
+	c = newClient(url.Values{"user": {"root"}, "password": {"secret"}, "token": {"token-a"}})
+	require.Nil(t, c.Get().WithPrefix("/test").Do(context.Background()).Error())
+	require.Equal(t, "Bearer token-a", authorization)
+}
+
 func TestRequestDoContext(t *testing.T) {
 	received := make(chan struct{})
 	blocked := make(chan struct{})
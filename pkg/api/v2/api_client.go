@@ -31,6 +31,9 @@ type APIV2Interface interface {
 	StatusGetter
 	CapturesGetter
 	ProcessorsGetter
+	UpstreamsGetter
+	NamespacesGetter
+	TemplatesGetter
 }
 
 // APIV2Client implements APIV1Interface and it is used to interact with cdc owner http api.
@@ -92,6 +95,30 @@ func (c *APIV2Client) Processors() ProcessorInterface {
 	return newProcessors(c)
 }
 
+// Upstreams returns an UpstreamInterface abstracting upstream operations.
+func (c *APIV2Client) Upstreams() UpstreamInterface {
+	if c == nil {
+		return nil
+	}
+	return newUpstreams(c)
+}
+
+// Namespaces returns a NamespaceInterface abstracting namespace operations.
+func (c *APIV2Client) Namespaces() NamespaceInterface {
+	if c == nil {
+		return nil
+	}
+	return newNamespaces(c)
+}
+
+// Templates returns a TemplateInterface abstracting changefeed template operations.
+func (c *APIV2Client) Templates() TemplateInterface {
+	if c == nil {
+		return nil
+	}
+	return newTemplates(c)
+}
+
 // NewAPIClient creates a new APIV1Client.
 func NewAPIClient(serverAddr string, credential *security.Credential, values url.Values) (*APIV2Client, error) {
 	c := &rest.Config{}
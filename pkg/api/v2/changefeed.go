@@ -16,10 +16,12 @@ package v2
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	v2 "github.com/pingcap/tiflow/cdc/api/v2"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/api/internal/rest"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
 )
 
 // ChangefeedsGetter has a method to return a ChangefeedInterface.
@@ -47,6 +49,23 @@ type ChangefeedInterface interface {
 	Get(ctx context.Context, namespace string, name string) (*v2.ChangeFeedInfo, error)
 	// List lists all changefeeds
 	List(ctx context.Context, namespace string, state string) ([]v2.ChangefeedCommonInfo, error)
+	// SchemaHistory exports the schema history of a changefeed between startTs and endTs
+	SchemaHistory(ctx context.Context, namespace string, name string,
+		startTs, endTs uint64) (*schemahistory.History, error)
+	// ResolvedTsDiagnosis reports which stage holds back the checkpoint of a changefeed
+	ResolvedTsDiagnosis(ctx context.Context, namespace string, name string,
+		limit int) (*model.ResolvedTsDiagnosis, error)
+	// Export exports a changefeed as a portable document
+	Export(ctx context.Context, namespace string, name string,
+		startTsPolicy string) (*v2.ChangefeedExport, error)
+	// Import creates a changefeed from an exported document
+	Import(ctx context.Context, cfg *v2.ChangefeedImportConfig) (*v2.ChangeFeedInfo, error)
+	// Clone creates a changefeed from an existing changefeed
+	Clone(ctx context.Context, cfg *v2.ChangefeedCloneConfig,
+		namespace string, name string) (*v2.ChangeFeedInfo, error)
+	// Statistics gets the throughput and the lag of a changefeed
+	Statistics(ctx context.Context, namespace string, name string,
+		limit int) (*model.ChangefeedStatistics, error)
 }
 
 // changefeeds implements ChangefeedInterface
@@ -157,3 +176,92 @@ func (c *changefeeds) List(ctx context.Context,
 		Into(result)
 	return result.Items, err
 }
+
+// SchemaHistory exports the schema history of a changefeed between startTs and endTs,
+// the ts which is 0 is decided by the server.
+func (c *changefeeds) SchemaHistory(ctx context.Context,
+	namespace string, name string, startTs, endTs uint64,
+) (*schemahistory.History, error) {
+	result := new(schemahistory.History)
+	u := fmt.Sprintf("changefeeds/%s/schema_history?namespace=%s", name, namespace)
+	req := c.client.Get().WithURI(u)
+	if startTs != 0 {
+		req = req.WithParam("start_ts", strconv.FormatUint(startTs, 10))
+	}
+	if endTs != 0 {
+		req = req.WithParam("end_ts", strconv.FormatUint(endTs, 10))
+	}
+	err := req.Do(ctx).Into(result)
+	return result, err
+}
+
+// ResolvedTsDiagnosis reports which stage holds back the checkpoint of a changefeed,
+// at most limit table spans of each stage are returned, the limit which is 0 is
+// decided by the server.
+func (c *changefeeds) ResolvedTsDiagnosis(ctx context.Context,
+	namespace string, name string, limit int,
+) (*model.ResolvedTsDiagnosis, error) {
+	result := new(model.ResolvedTsDiagnosis)
+	u := fmt.Sprintf("changefeeds/%s/resolved_ts_diagnosis?namespace=%s", name, namespace)
+	req := c.client.Get().WithURI(u)
+	if limit != 0 {
+		req = req.WithParam("limit", strconv.Itoa(limit))
+	}
+	err := req.Do(ctx).Into(result)
+	return result, err
+}
+
+// Export exports a changefeed as a portable document, the startTsPolicy
+// which is empty is decided by the server.
+func (c *changefeeds) Export(ctx context.Context,
+	namespace string, name string, startTsPolicy string,
+) (*v2.ChangefeedExport, error) {
+	result := new(v2.ChangefeedExport)
+	u := fmt.Sprintf("changefeeds/%s/export?namespace=%s", name, namespace)
+	req := c.client.Get().WithURI(u)
+	if startTsPolicy != "" {
+		req = req.WithParam("start_ts_policy", startTsPolicy)
+	}
+	err := req.Do(ctx).Into(result)
+	return result, err
+}
+
+// Import creates a changefeed from an exported document
+func (c *changefeeds) Import(ctx context.Context,
+	cfg *v2.ChangefeedImportConfig,
+) (*v2.ChangeFeedInfo, error) {
+	result := &v2.ChangeFeedInfo{}
+	err := c.client.Post().
+		WithURI("changefeeds/import").
+		WithBody(cfg).
+		Do(ctx).Into(result)
+	return result, err
+}
+
+// Clone creates a changefeed from an existing changefeed
+func (c *changefeeds) Clone(ctx context.Context,
+	cfg *v2.ChangefeedCloneConfig, namespace string, name string,
+) (*v2.ChangeFeedInfo, error) {
+	result := &v2.ChangeFeedInfo{}
+	u := fmt.Sprintf("changefeeds/%s/clone?namespace=%s", name, namespace)
+	err := c.client.Post().
+		WithURI(u).
+		WithBody(cfg).
+		Do(ctx).Into(result)
+	return result, err
+}
+
+// Statistics gets the throughput and the lag of a changefeed, at most limit
+// slowest tables are returned, the limit which is 0 is decided by the server.
+func (c *changefeeds) Statistics(ctx context.Context,
+	namespace string, name string, limit int,
+) (*model.ChangefeedStatistics, error) {
+	result := new(model.ChangefeedStatistics)
+	u := fmt.Sprintf("changefeeds/%s/statistics?namespace=%s", name, namespace)
+	req := c.client.Get().WithURI(u)
+	if limit != 0 {
+		req = req.WithParam("limit", strconv.Itoa(limit))
+	}
+	err := req.Do(ctx).Into(result)
+	return result, err
+}
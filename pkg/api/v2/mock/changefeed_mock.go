@@ -10,7 +10,9 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	model "github.com/pingcap/tiflow/cdc/model"
 	v20 "github.com/pingcap/tiflow/pkg/api/v2"
+	schemahistory "github.com/pingcap/tiflow/pkg/schemahistory"
 )
 
 // MockChangefeedsGetter is a mock of ChangefeedsGetter interface.
@@ -73,6 +75,21 @@ func (m *MockChangefeedInterface) EXPECT() *MockChangefeedInterfaceMockRecorder
 	return m.recorder
 }
 
+// Clone mocks base method.
+func (m *MockChangefeedInterface) Clone(ctx context.Context, cfg *v2.ChangefeedCloneConfig, namespace, name string) (*v2.ChangeFeedInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, cfg, namespace, name)
+	ret0, _ := ret[0].(*v2.ChangeFeedInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockChangefeedInterfaceMockRecorder) Clone(ctx, cfg, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockChangefeedInterface)(nil).Clone), ctx, cfg, namespace, name)
+}
+
 // Create mocks base method.
 func (m *MockChangefeedInterface) Create(ctx context.Context, cfg *v2.ChangefeedConfig) (*v2.ChangeFeedInfo, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +119,21 @@ func (mr *MockChangefeedInterfaceMockRecorder) Delete(ctx, namespace, name inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockChangefeedInterface)(nil).Delete), ctx, namespace, name)
 }
 
+// Export mocks base method.
+func (m *MockChangefeedInterface) Export(ctx context.Context, namespace, name, startTsPolicy string) (*v2.ChangefeedExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, namespace, name, startTsPolicy)
+	ret0, _ := ret[0].(*v2.ChangefeedExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockChangefeedInterfaceMockRecorder) Export(ctx, namespace, name, startTsPolicy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockChangefeedInterface)(nil).Export), ctx, namespace, name, startTsPolicy)
+}
+
 // Get mocks base method.
 func (m *MockChangefeedInterface) Get(ctx context.Context, namespace, name string) (*v2.ChangeFeedInfo, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +149,21 @@ func (mr *MockChangefeedInterfaceMockRecorder) Get(ctx, namespace, name interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockChangefeedInterface)(nil).Get), ctx, namespace, name)
 }
 
+// Import mocks base method.
+func (m *MockChangefeedInterface) Import(ctx context.Context, cfg *v2.ChangefeedImportConfig) (*v2.ChangeFeedInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, cfg)
+	ret0, _ := ret[0].(*v2.ChangeFeedInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockChangefeedInterfaceMockRecorder) Import(ctx, cfg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockChangefeedInterface)(nil).Import), ctx, cfg)
+}
+
 // List mocks base method.
 func (m *MockChangefeedInterface) List(ctx context.Context, namespace, state string) ([]v2.ChangefeedCommonInfo, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +193,21 @@ func (mr *MockChangefeedInterfaceMockRecorder) Pause(ctx, namespace, name interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockChangefeedInterface)(nil).Pause), ctx, namespace, name)
 }
 
+// ResolvedTsDiagnosis mocks base method.
+func (m *MockChangefeedInterface) ResolvedTsDiagnosis(ctx context.Context, namespace, name string, limit int) (*model.ResolvedTsDiagnosis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvedTsDiagnosis", ctx, namespace, name, limit)
+	ret0, _ := ret[0].(*model.ResolvedTsDiagnosis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolvedTsDiagnosis indicates an expected call of ResolvedTsDiagnosis.
+func (mr *MockChangefeedInterfaceMockRecorder) ResolvedTsDiagnosis(ctx, namespace, name, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvedTsDiagnosis", reflect.TypeOf((*MockChangefeedInterface)(nil).ResolvedTsDiagnosis), ctx, namespace, name, limit)
+}
+
 // Resume mocks base method.
 func (m *MockChangefeedInterface) Resume(ctx context.Context, cfg *v2.ResumeChangefeedConfig, namespace, name string) error {
 	m.ctrl.T.Helper()
@@ -160,6 +222,36 @@ func (mr *MockChangefeedInterfaceMockRecorder) Resume(ctx, cfg, namespace, name
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockChangefeedInterface)(nil).Resume), ctx, cfg, namespace, name)
 }
 
+// SchemaHistory mocks base method.
+func (m *MockChangefeedInterface) SchemaHistory(ctx context.Context, namespace, name string, startTs, endTs uint64) (*schemahistory.History, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaHistory", ctx, namespace, name, startTs, endTs)
+	ret0, _ := ret[0].(*schemahistory.History)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaHistory indicates an expected call of SchemaHistory.
+func (mr *MockChangefeedInterfaceMockRecorder) SchemaHistory(ctx, namespace, name, startTs, endTs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaHistory", reflect.TypeOf((*MockChangefeedInterface)(nil).SchemaHistory), ctx, namespace, name, startTs, endTs)
+}
+
+// Statistics mocks base method.
+func (m *MockChangefeedInterface) Statistics(ctx context.Context, namespace, name string, limit int) (*model.ChangefeedStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statistics", ctx, namespace, name, limit)
+	ret0, _ := ret[0].(*model.ChangefeedStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Statistics indicates an expected call of Statistics.
+func (mr *MockChangefeedInterfaceMockRecorder) Statistics(ctx, namespace, name, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statistics", reflect.TypeOf((*MockChangefeedInterface)(nil).Statistics), ctx, namespace, name, limit)
+}
+
 // Update mocks base method.
 func (m *MockChangefeedInterface) Update(ctx context.Context, cfg *v2.ChangefeedConfig, namespace, name string) (*v2.ChangeFeedInfo, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,226 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/pkg/api/internal/rest"
+)
+
+// NamespacesGetter has a method to return a NamespaceInterface.
+type NamespacesGetter interface {
+	Namespaces() NamespaceInterface
+}
+
+// NamespaceInterface has methods to work with Namespace items.
+// We can also mock the namespace operations by implement this interface.
+type NamespaceInterface interface {
+	// List lists all namespaces
+	List(ctx context.Context) ([]v2.Namespace, error)
+	// Get gets a namespace by name
+	Get(ctx context.Context, name string) (*v2.Namespace, error)
+	// Create creates the settings of a namespace
+	Create(ctx context.Context, cfg *v2.NamespaceConfig) (*v2.Namespace, error)
+	// Update updates the settings of a namespace
+	Update(ctx context.Context, name string, cfg *v2.NamespaceConfig) (*v2.Namespace, error)
+	// Delete removes the settings and templates of a namespace
+	Delete(ctx context.Context, name string) error
+	// GetChangefeedConfig gets the config a new changefeed of the namespace
+	// starts from, optionally with a changefeed template applied
+	GetChangefeedConfig(ctx context.Context, name string,
+		template string) (*v2.ChangefeedConfig, error)
+}
+
+// namespaces implements NamespaceInterface
+type namespaces struct {
+	client rest.CDCRESTInterface
+}
+
+// newNamespaces returns namespaces
+func newNamespaces(c *APIV2Client) *namespaces {
+	return &namespaces{
+		client: c.RESTClient(),
+	}
+}
+
+// List lists all namespaces
+func (c *namespaces) List(ctx context.Context) ([]v2.Namespace, error) {
+	result := &v2.ListResponse[v2.Namespace]{}
+	err := c.client.Get().
+		WithURI("namespaces").
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
+
+// Get gets a namespace by name
+func (c *namespaces) Get(ctx context.Context, name string) (*v2.Namespace, error) {
+	result := &v2.Namespace{}
+	u := fmt.Sprintf("namespaces/%s", name)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Create creates the settings of a namespace
+func (c *namespaces) Create(ctx context.Context,
+	cfg *v2.NamespaceConfig,
+) (*v2.Namespace, error) {
+	result := &v2.Namespace{}
+	err := c.client.Post().
+		WithURI("namespaces").
+		WithBody(cfg).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Update updates the settings of a namespace
+func (c *namespaces) Update(ctx context.Context, name string,
+	cfg *v2.NamespaceConfig,
+) (*v2.Namespace, error) {
+	result := &v2.Namespace{}
+	u := fmt.Sprintf("namespaces/%s", name)
+	err := c.client.Put().
+		WithURI(u).
+		WithBody(cfg).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Delete removes the settings and templates of a namespace
+func (c *namespaces) Delete(ctx context.Context, name string) error {
+	u := fmt.Sprintf("namespaces/%s", name)
+	return c.client.Delete().
+		WithURI(u).
+		Do(ctx).Error()
+}
+
+// GetChangefeedConfig gets the config a new changefeed of the namespace
+// starts from, optionally with a changefeed template applied
+func (c *namespaces) GetChangefeedConfig(ctx context.Context, name string,
+	template string,
+) (*v2.ChangefeedConfig, error) {
+	result := &v2.ChangefeedConfig{}
+	u := fmt.Sprintf("namespaces/%s/changefeed_config?template=%s",
+		name, url.QueryEscape(template))
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// TemplatesGetter has a method to return a TemplateInterface.
+type TemplatesGetter interface {
+	Templates() TemplateInterface
+}
+
+// TemplateInterface has methods to work with ChangefeedTemplate items.
+// We can also mock the template operations by implement this interface.
+type TemplateInterface interface {
+	// List lists all changefeed templates of a namespace
+	List(ctx context.Context, namespace string) ([]v2.ChangefeedTemplate, error)
+	// Get gets a changefeed template by name
+	Get(ctx context.Context, namespace string, name string) (*v2.ChangefeedTemplate, error)
+	// Create creates a changefeed template
+	Create(ctx context.Context, namespace string,
+		template *v2.ChangefeedTemplate) (*v2.ChangefeedTemplate, error)
+	// Update updates a changefeed template
+	Update(ctx context.Context, namespace string, name string,
+		template *v2.ChangefeedTemplate) (*v2.ChangefeedTemplate, error)
+	// Delete removes a changefeed template by name
+	Delete(ctx context.Context, namespace string, name string) error
+}
+
+// templates implements TemplateInterface
+type templates struct {
+	client rest.CDCRESTInterface
+}
+
+// newTemplates returns templates
+func newTemplates(c *APIV2Client) *templates {
+	return &templates{
+		client: c.RESTClient(),
+	}
+}
+
+// List lists all changefeed templates of a namespace
+func (c *templates) List(ctx context.Context,
+	namespace string,
+) ([]v2.ChangefeedTemplate, error) {
+	result := &v2.ListResponse[v2.ChangefeedTemplate]{}
+	u := fmt.Sprintf("templates?namespace=%s", namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
+
+// Get gets a changefeed template by name
+func (c *templates) Get(ctx context.Context, namespace string,
+	name string,
+) (*v2.ChangefeedTemplate, error) {
+	result := &v2.ChangefeedTemplate{}
+	u := fmt.Sprintf("templates/%s?namespace=%s", name, namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Create creates a changefeed template
+func (c *templates) Create(ctx context.Context, namespace string,
+	template *v2.ChangefeedTemplate,
+) (*v2.ChangefeedTemplate, error) {
+	result := &v2.ChangefeedTemplate{}
+	u := fmt.Sprintf("templates?namespace=%s", namespace)
+	err := c.client.Post().
+		WithURI(u).
+		WithBody(template).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Update updates a changefeed template
+func (c *templates) Update(ctx context.Context, namespace string, name string,
+	template *v2.ChangefeedTemplate,
+) (*v2.ChangefeedTemplate, error) {
+	result := &v2.ChangefeedTemplate{}
+	u := fmt.Sprintf("templates/%s?namespace=%s", name, namespace)
+	err := c.client.Put().
+		WithURI(u).
+		WithBody(template).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Delete removes a changefeed template by name
+func (c *templates) Delete(ctx context.Context, namespace string, name string) error {
+	u := fmt.Sprintf("templates/%s?namespace=%s", name, namespace)
+	return c.client.Delete().
+		WithURI(u).
+		Do(ctx).Error()
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/pkg/api/internal/rest"
+)
+
+// UpstreamsGetter has a method to return an UpstreamInterface.
+type UpstreamsGetter interface {
+	Upstreams() UpstreamInterface
+}
+
+// UpstreamInterface has methods to work with Upstream items.
+// We can also mock the upstream operations by implement this interface.
+type UpstreamInterface interface {
+	// List lists all upstreams of a namespace
+	List(ctx context.Context, namespace string) ([]v2.Upstream, error)
+	// Create registers an upstream with a name
+	Create(ctx context.Context, namespace string,
+		cfg *v2.CreateUpstreamConfig) (*v2.Upstream, error)
+	// Delete removes an upstream by id
+	Delete(ctx context.Context, namespace string, id uint64) error
+	// Health checks the health of an upstream
+	Health(ctx context.Context, namespace string, id uint64) (*v2.UpstreamHealth, error)
+	// GCSafePoint gets the gc safepoints of an upstream
+	GCSafePoint(ctx context.Context, namespace string,
+		id uint64) (*v2.UpstreamGCSafePoint, error)
+}
+
+// upstreams implements UpstreamInterface
+type upstreams struct {
+	client rest.CDCRESTInterface
+}
+
+// newUpstreams returns upstreams
+func newUpstreams(c *APIV2Client) *upstreams {
+	return &upstreams{
+		client: c.RESTClient(),
+	}
+}
+
+// List lists all upstreams of a namespace
+func (c *upstreams) List(ctx context.Context, namespace string) ([]v2.Upstream, error) {
+	result := &v2.ListResponse[v2.Upstream]{}
+	u := fmt.Sprintf("upstreams?namespace=%s", namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
+
+// Create registers an upstream with a name
+func (c *upstreams) Create(ctx context.Context, namespace string,
+	cfg *v2.CreateUpstreamConfig,
+) (*v2.Upstream, error) {
+	result := &v2.Upstream{}
+	u := fmt.Sprintf("upstreams?namespace=%s", namespace)
+	err := c.client.Post().
+		WithURI(u).
+		WithBody(cfg).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Delete removes an upstream by id
+func (c *upstreams) Delete(ctx context.Context, namespace string, id uint64) error {
+	u := fmt.Sprintf("upstreams/%d?namespace=%s", id, namespace)
+	return c.client.Delete().
+		WithURI(u).
+		Do(ctx).Error()
+}
+
+// Health checks the health of an upstream
+func (c *upstreams) Health(ctx context.Context, namespace string,
+	id uint64,
+) (*v2.UpstreamHealth, error) {
+	result := &v2.UpstreamHealth{}
+	u := fmt.Sprintf("upstreams/%d/health?namespace=%s", id, namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// GCSafePoint gets the gc safepoints of an upstream
+func (c *upstreams) GCSafePoint(ctx context.Context, namespace string,
+	id uint64,
+) (*v2.UpstreamGCSafePoint, error) {
+	result := &v2.UpstreamGCSafePoint{}
+	u := fmt.Sprintf("upstreams/%d/gc_safepoint?namespace=%s", id, namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/redo"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
 	"github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/prometheus/client_golang/prometheus"
@@ -61,6 +62,9 @@ type RedoApplierConfig struct {
 	SinkURI string
 	Storage string
 	Dir     string
+	// SchemaHistory is the path of the schema history exported from the
+	// upstream, it's used to restore the table info of the DDL events.
+	SchemaHistory string
 }
 
 // RedoApplier implements a redo log applier
@@ -71,6 +75,8 @@ type RedoApplier struct {
 
 	ddlSink         ddlsink.Sink
 	appliedDDLCount uint64
+	// schemaHistory is nil if the schema history is not provided.
+	schemaHistory *schemahistory.History
 
 	memQuota     *memquota.MemQuota
 	pendingQuota uint64
@@ -284,6 +290,7 @@ func (ra *RedoApplier) applyDDL(
 	if shouldSkip() {
 		return nil
 	}
+	ra.fillTableInfo(ddl)
 	log.Warn("apply DDL", zap.Any("ddl", ddl))
 	// Wait all tables to flush data before applying DDL.
 	// TODO: only block tables that are affected by this DDL.
@@ -299,6 +306,24 @@ func (ra *RedoApplier) applyDDL(
 	return nil
 }
 
+// fillTableInfo replaces the table info of the DDL event, which only carries
+// the table name in the redo log, with the version in the schema history.
+func (ra *RedoApplier) fillTableInfo(ddl *model.DDLEvent) {
+	if ra.schemaHistory == nil {
+		return
+	}
+	name := ddl.TableInfo.TableName
+	info, ok := ra.schemaHistory.Lookup(name.Schema, name.Table, ddl.CommitTs)
+	if !ok {
+		log.Warn("table info not found in the schema history",
+			zap.String("schema", name.Schema),
+			zap.String("table", name.Table),
+			zap.Uint64("commitTs", ddl.CommitTs))
+		return
+	}
+	ddl.TableInfo = info
+}
+
 func (ra *RedoApplier) applyRow(
 	row *model.RowChangedEvent, checkpointTs model.Ts,
 ) error {
@@ -724,6 +749,11 @@ func (ra *RedoApplier) ReadMeta(ctx context.Context) (checkpointTs uint64, resol
 func (ra *RedoApplier) Apply(egCtx context.Context) (err error) {
 	eg, egCtx := errgroup.WithContext(egCtx)
 
+	if ra.cfg.SchemaHistory != "" {
+		if ra.schemaHistory, err = schemahistory.ReadFile(ra.cfg.SchemaHistory); err != nil {
+			return err
+		}
+	}
 	if ra.rd, err = createRedoReader(egCtx, ra.cfg); err != nil {
 		return err
 	}
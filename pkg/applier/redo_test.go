@@ -24,11 +24,13 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/phayes/freeport"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	mysqlParser "github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/cdc/redo/reader"
 	mysqlDDL "github.com/pingcap/tiflow/cdc/sink/ddlsink/mysql"
 	"github.com/pingcap/tiflow/cdc/sink/dmlsink/txn"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
 	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
 	"github.com/stretchr/testify/require"
 )
@@ -493,6 +495,42 @@ func TestApplyMeetSinkError(t *testing.T) {
 	require.Regexp(t, "CDC:ErrMySQLConnectionError", err)
 }
 
+func TestFillTableInfoFromSchemaHistory(t *testing.T) {
+	tableInfo := &timodel.TableInfo{
+		ID:       10,
+		Name:     pmodel.NewCIStr("t1"),
+		UpdateTS: 90,
+		Columns: []*timodel.ColumnInfo{
+			{ID: 1, Name: pmodel.NewCIStr("a"), State: timodel.StatePublic},
+		},
+	}
+	history := schemahistory.NewHistory(model.DefaultChangeFeedID("test"), 80, 120)
+	history.Add(1, "test", tableInfo)
+
+	ap := NewRedoApplier(&RedoApplierConfig{})
+	ddl := &model.DDLEvent{
+		CommitTs:  100,
+		TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test", Table: "t1"}},
+	}
+	// the table info is not changed without the schema history.
+	ap.fillTableInfo(ddl)
+	require.Nil(t, ddl.TableInfo.TableInfo)
+
+	ap.schemaHistory = history
+	ap.fillTableInfo(ddl)
+	require.NotNil(t, ddl.TableInfo.TableInfo)
+	require.Equal(t, int64(10), ddl.TableInfo.ID)
+	require.Len(t, ddl.TableInfo.Columns, 1)
+	require.Equal(t, "test", ddl.TableInfo.TableName.Schema)
+
+	ddl = &model.DDLEvent{
+		CommitTs:  100,
+		TableInfo: &model.TableInfo{TableName: model.TableName{Schema: "test", Table: "t2"}},
+	}
+	ap.fillTableInfo(ddl)
+	require.Nil(t, ddl.TableInfo.TableInfo)
+}
+
 func getMockDB(t *testing.T) *sql.DB {
 	// normal db
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
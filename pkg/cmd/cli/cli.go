@@ -33,6 +33,7 @@ func NewCmdCli() *cobra.Command {
 
 	// Binding the `cli` command flags.
 	cf.AddFlags(cmds)
+	util.AddOutputFlags(cmds)
 	cmds.PersistentPreRun = func(cmd *cobra.Command, args []string) {
 		// Here we will initialize the logging configuration and set the current default context.
 		cancel := util.InitCmd(cmd, &logutil.Config{Level: cf.GetLogLevel()})
@@ -57,6 +58,9 @@ func NewCmdCli() *cobra.Command {
 	cmds.AddCommand(newCmdProcessor(f))
 	cmds.AddCommand(newCmdTso(f))
 	cmds.AddCommand(newCmdUnsafe(f))
+	cmds.AddCommand(newCmdUpstream(f))
+	cmds.AddCommand(newCmdNamespace(f))
+	cmds.AddCommand(newCmdTemplate(f))
 	cmds.AddCommand(newConfigureCredentials())
 
 	return cmds
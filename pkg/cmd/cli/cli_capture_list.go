@@ -53,22 +53,27 @@ func (o *listCaptureOptions) complete(f factory.Factory) error {
 func (o *listCaptureOptions) run(cmd *cobra.Command) error {
 	ctx := cmdcontext.GetDefaultContext()
 
-	raw, err := o.apiv2Client.Captures().List(ctx)
-	if err != nil {
-		return err
-	}
-	captures := make([]*capture, 0, len(raw))
-	for _, c := range raw {
-		captures = append(captures,
-			&capture{
-				ID:            c.ID,
-				IsOwner:       c.IsOwner,
-				AdvertiseAddr: c.AdvertiseAddr,
-				ClusterID:     c.ClusterID,
-			})
-	}
-
-	return util.JSONPrint(cmd, captures)
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		raw, err := o.apiv2Client.Captures().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The v2 API models are printed if the output format is specified.
+		if util.GetOutputFormat(cmd) != "" {
+			return raw, nil
+		}
+		captures := make([]*capture, 0, len(raw))
+		for _, c := range raw {
+			captures = append(captures,
+				&capture{
+					ID:            c.ID,
+					IsOwner:       c.IsOwner,
+					AdvertiseAddr: c.AdvertiseAddr,
+					ClusterID:     c.ClusterID,
+				})
+		}
+		return captures, nil
+	})
 }
 
 // newCmdListCapture creates the `cli capture list` command.
@@ -33,6 +33,9 @@ type mockAPIV2Client struct {
 	unsafes     apiv2client.UnsafeInterface
 	captures    apiv2client.CaptureInterface
 	processors  apiv2client.ProcessorInterface
+	upstreams   apiv2client.UpstreamInterface
+	namespaces  apiv2client.NamespaceInterface
+	templates   apiv2client.TemplateInterface
 }
 
 func (f *mockAPIV2Client) Changefeeds() apiv2client.ChangefeedInterface {
@@ -55,6 +58,18 @@ func (f *mockAPIV2Client) Processors() apiv2client.ProcessorInterface {
 	return f.processors
 }
 
+func (f *mockAPIV2Client) Upstreams() apiv2client.UpstreamInterface {
+	return f.upstreams
+}
+
+func (f *mockAPIV2Client) Namespaces() apiv2client.NamespaceInterface {
+	return f.namespaces
+}
+
+func (f *mockAPIV2Client) Templates() apiv2client.TemplateInterface {
+	return f.templates
+}
+
 type mockFactory struct {
 	factory.Factory
 	captures    *mock.MockCaptureInterface
@@ -63,6 +78,9 @@ type mockFactory struct {
 	status      *mock.MockStatusInterface
 	tso         *mock.MockTsoInterface
 	unsafes     *mock.MockUnsafeInterface
+	upstreams   *mock.MockUpstreamInterface
+	namespaces  *mock.MockNamespaceInterface
+	templates   *mock.MockTemplateInterface
 }
 
 func newMockFactory(ctrl *gomock.Controller) *mockFactory {
@@ -72,6 +90,9 @@ func newMockFactory(ctrl *gomock.Controller) *mockFactory {
 	statuses := mock.NewMockStatusInterface(ctrl)
 	unsafes := mock.NewMockUnsafeInterface(ctrl)
 	tso := mock.NewMockTsoInterface(ctrl)
+	upstreams := mock.NewMockUpstreamInterface(ctrl)
+	namespaces := mock.NewMockNamespaceInterface(ctrl)
+	templates := mock.NewMockTemplateInterface(ctrl)
 	return &mockFactory{
 		captures:    cps,
 		changefeeds: cf,
@@ -79,6 +100,9 @@ func newMockFactory(ctrl *gomock.Controller) *mockFactory {
 		status:      statuses,
 		tso:         tso,
 		unsafes:     unsafes,
+		upstreams:   upstreams,
+		namespaces:  namespaces,
+		templates:   templates,
 	}
 }
 
@@ -89,6 +113,9 @@ func (f *mockFactory) APIV2Client() (apiv2client.APIV2Interface, error) {
 		tso:         f.tso,
 		unsafes:     f.unsafes,
 		processors:  f.processors,
+		upstreams:   f.upstreams,
+		namespaces:  f.namespaces,
+		templates:   f.templates,
 	}, nil
 }
 
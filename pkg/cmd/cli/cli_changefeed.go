@@ -26,14 +26,19 @@ func newCmdChangefeed(f factory.Factory) *cobra.Command {
 		Args:  cobra.NoArgs,
 	}
 
+	cmds.AddCommand(newCmdCloneChangefeed(f))
 	cmds.AddCommand(newCmdCreateChangefeed(f))
+	cmds.AddCommand(newCmdDiagnoseChangefeed(f))
 	cmds.AddCommand(newCmdUpdateChangefeed(f))
+	cmds.AddCommand(newCmdExportChangefeed(f))
+	cmds.AddCommand(newCmdImportChangefeed(f))
 	cmds.AddCommand(newCmdStatisticsChangefeed(f))
 	cmds.AddCommand(newCmdListChangefeed(f))
 	cmds.AddCommand(newCmdPauseChangefeed(f))
 	cmds.AddCommand(newCmdQueryChangefeed(f))
 	cmds.AddCommand(newCmdRemoveChangefeed(f))
 	cmds.AddCommand(newCmdResumeChangefeed(f))
+	cmds.AddCommand(newCmdSchemaHistoryChangefeed(f))
 
 	return cmds
 }
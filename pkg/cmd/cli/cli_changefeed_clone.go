@@ -0,0 +1,110 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// cloneChangefeedOptions defines flags for the `cli changefeed clone` command.
+type cloneChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID    string
+	namespace       string
+	newChangefeedID string
+	newNamespace    string
+	startTs         uint64
+	sinkURI         string
+}
+
+// newCloneChangefeedOptions creates new options for the `cli changefeed clone` command.
+func newCloneChangefeedOptions() *cloneChangefeedOptions {
+	return &cloneChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *cloneChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVar(&o.newChangefeedID, "new-changefeed-id", "", "ID of the new replication task (changefeed)")
+	cmd.PersistentFlags().StringVar(&o.newNamespace, "new-namespace", "",
+		"Namespace of the new replication task (changefeed), default to the namespace of the cloned one")
+	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0,
+		"Start ts of the new changefeed, default to the checkpoint ts of the cloned one")
+	cmd.PersistentFlags().StringVar(&o.sinkURI, "sink-uri", "",
+		"Sink URI of the new changefeed, default to the sink URI of the cloned one")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("new-changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *cloneChangefeedOptions) complete(f factory.Factory) error {
+	var err error
+	o.apiClient, err = f.APIV2Client()
+	return err
+}
+
+// run the `cli changefeed clone` command.
+func (o *cloneChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+	cfg := &v2.ChangefeedCloneConfig{
+		Namespace: o.newNamespace,
+		ID:        o.newChangefeedID,
+		StartTs:   o.startTs,
+		SinkURI:   o.sinkURI,
+	}
+	info, err := o.apiClient.Changefeeds().Clone(ctx, cfg, o.namespace, o.changefeedID)
+	if err != nil {
+		return err
+	}
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, info)
+	}
+	infoStr, err := info.Marshal()
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Clone changefeed successfully!\nID: %s\nInfo: %s\n", info.ID, infoStr)
+	return nil
+}
+
+// newCmdCloneChangefeed creates the `cli changefeed clone` command.
+func newCmdCloneChangefeed(f factory.Factory) *cobra.Command {
+	o := newCloneChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "clone",
+		Short: "Create a replication task (changefeed) with the config of an existing one",
+		Long: "Create a replication task (changefeed) with the config, the sink URI and " +
+			"the upstream of an existing one in the same cluster",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedCloneCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfV2 := mock.NewMockChangefeedInterface(ctrl)
+	f := &mockFactory{changefeeds: cfV2}
+
+	o := newCloneChangefeedOptions()
+	require.NoError(t, o.complete(f))
+	cmd := newCmdCloneChangefeed(f)
+	o.namespace = "default"
+	o.changefeedID = "abc"
+	o.newChangefeedID = "def"
+	o.sinkURI = "blackhole://"
+
+	cfV2.EXPECT().Clone(gomock.Any(), &v2.ChangefeedCloneConfig{
+		ID:      "def",
+		SinkURI: "blackhole://",
+	}, "default", "abc").Return(&v2.ChangeFeedInfo{ID: "def"}, nil)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, o.run(cmd))
+	require.Contains(t, b.String(), "Clone changefeed successfully!\nID: def")
+
+	cfV2.EXPECT().Clone(gomock.Any(), gomock.Any(), "default", "abc").
+		Return(nil, errors.New("test"))
+	require.Error(t, o.run(cmd))
+}
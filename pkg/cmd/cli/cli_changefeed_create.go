@@ -109,8 +109,13 @@ type createChangefeedOptions struct {
 	disableGCSafePointCheck bool
 	startTs                 uint64
 	timezone                string
+	upstreamName            string
+	template                string
 
 	cfg *config.ReplicaConfig
+	// templateSinkURI is the masked sink uri of the changefeed template,
+	// it is only used to check the config locally.
+	templateSinkURI string
 }
 
 // newCreateChangefeedOptions creates new options for the `cli changefeed create` command.
@@ -129,6 +134,10 @@ func (o *createChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVarP(&o.disableGCSafePointCheck, "disable-gc-check", "", false, "Disable GC safe point check")
 	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0, "Start ts of changefeed")
 	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "SYSTEM", "timezone used when checking sink uri (changefeed timezone is determined by cdc server)")
+	cmd.PersistentFlags().StringVar(&o.upstreamName, "upstream", "",
+		"Name of a registered upstream to replicate from, default to the upstream of the cluster")
+	cmd.PersistentFlags().StringVar(&o.template, "template", "",
+		"Name of a changefeed template of the namespace to create the changefeed from")
 	// we don't support specify these flags below when cdc version >= 6.2.0
 	_ = cmd.PersistentFlags().MarkHidden("tz")
 }
@@ -145,14 +154,17 @@ func (o *createChangefeedOptions) complete(f factory.Factory) error {
 
 // completeCfg complete the replica config from file and cmd flags.
 func (o *createChangefeedOptions) completeReplicaCfg() error {
-	cfg := config.GetDefaultReplicaConfig()
+	cfg, err := o.getBaseReplicaCfg(cmdcontext.GetDefaultContext())
+	if err != nil {
+		return err
+	}
 	if len(o.commonChangefeedOptions.configFile) > 0 {
 		if err := o.commonChangefeedOptions.strictDecodeConfig("TiCDC changefeed", cfg); err != nil {
 			return err
 		}
 	}
 
-	uri, err := url.Parse(o.commonChangefeedOptions.sinkURI)
+	uri, err := url.Parse(o.getSinkURI())
 	if err != nil {
 		return err
 	}
@@ -185,6 +197,35 @@ func (o *createChangefeedOptions) completeReplicaCfg() error {
 	return nil
 }
 
+// getBaseReplicaCfg gets the replica config that a new changefeed of the
+// namespace starts from, the config file and flags only override it.
+func (o *createChangefeedOptions) getBaseReplicaCfg(ctx context.Context) (*config.ReplicaConfig, error) {
+	cfg, err := o.apiClient.Namespaces().GetChangefeedConfig(ctx, o.namespace, o.template)
+	if err != nil {
+		if o.template != "" {
+			return nil, err
+		}
+		// the server may be an old version without namespace settings.
+		log.Warn("failed to get the changefeed config of the namespace, use the default config",
+			zap.String("namespace", o.namespace), zap.Error(err))
+		return config.GetDefaultReplicaConfig(), nil
+	}
+	o.templateSinkURI = cfg.SinkURI
+	if cfg.ReplicaConfig == nil {
+		return config.GetDefaultReplicaConfig(), nil
+	}
+	return cfg.ReplicaConfig.ToInternalReplicaConfig(), nil
+}
+
+// getSinkURI returns the sink uri used to check the config locally, the sink
+// uri of the template is used if it is not specified.
+func (o *createChangefeedOptions) getSinkURI() string {
+	if o.commonChangefeedOptions.sinkURI != "" {
+		return o.commonChangefeedOptions.sinkURI
+	}
+	return o.templateSinkURI
+}
+
 // validate checks that the provided attach options are specified.
 func (o *createChangefeedOptions) validate(cmd *cobra.Command) error {
 	if o.timezone != "SYSTEM" {
@@ -214,6 +255,10 @@ func (o *createChangefeedOptions) validate(cmd *cobra.Command) error {
 		return errors.New("creating changefeed with `--sort-dir`, it's invalid")
 	}
 
+	if o.upstreamName != "" && o.commonChangefeedOptions.upstreamPDAddrs != "" {
+		return errors.New("`--upstream` and `--upstream-pd` can not be specified at the same time")
+	}
+
 	switch o.commonChangefeedOptions.sortEngine {
 	case model.SortInMemory:
 	case model.SortInFile:
@@ -230,15 +275,42 @@ func (o *createChangefeedOptions) validate(cmd *cobra.Command) error {
 func (o *createChangefeedOptions) getChangefeedConfig() *v2.ChangefeedConfig {
 	replicaConfig := v2.ToAPIReplicaConfig(o.cfg)
 	upstreamConfig := o.getUpstreamConfig()
-	return &v2.ChangefeedConfig{
+	cfg := &v2.ChangefeedConfig{
 		ID:            o.changefeedID,
 		Namespace:     o.namespace,
 		StartTs:       o.startTs,
 		TargetTs:      o.commonChangefeedOptions.targetTs,
 		SinkURI:       o.commonChangefeedOptions.sinkURI,
+		Template:      o.template,
 		ReplicaConfig: replicaConfig,
 		PDConfig:      upstreamConfig.PDConfig,
 	}
+	if o.upstreamName != "" {
+		// the server resolves the pd addresses of a named upstream by itself
+		cfg.PDConfig = v2.PDConfig{}
+		cfg.UpstreamName = o.upstreamName
+	}
+	return cfg
+}
+
+// resolveUpstream fills the upstream flags with the named upstream registered
+// in the cluster, so that the tso query and table verification are sent to it.
+func (o *createChangefeedOptions) resolveUpstream(ctx context.Context) error {
+	upstreams, err := o.apiClient.Upstreams().List(ctx, o.namespace)
+	if err != nil {
+		return err
+	}
+	for _, up := range upstreams {
+		if up.Name != o.upstreamName {
+			continue
+		}
+		o.commonChangefeedOptions.upstreamPDAddrs = strings.Join(up.PDAddrs, ",")
+		o.commonChangefeedOptions.upstreamCaPath = up.CAPath
+		o.commonChangefeedOptions.upstreamCertPath = up.CertPath
+		o.commonChangefeedOptions.upstreamKeyPath = up.KeyPath
+		return nil
+	}
+	return errors.Errorf("upstream %s not found in namespace %s", o.upstreamName, o.namespace)
 }
 
 func (o *createChangefeedOptions) getUpstreamConfig() *v2.UpstreamConfig {
@@ -267,6 +339,12 @@ func (o *createChangefeedOptions) getUpstreamConfig() *v2.UpstreamConfig {
 
 // run the `cli changefeed create` command.
 func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) error {
+	if o.upstreamName != "" {
+		if err := o.resolveUpstream(ctx); err != nil {
+			return err
+		}
+	}
+
 	tso, err := o.apiClient.Tso().Query(ctx, o.getUpstreamConfig())
 	if err != nil {
 		return err
@@ -284,6 +362,14 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 
 	createChangefeedCfg := o.getChangefeedConfig()
 
+	// tables can't be read at a start-ts in the future, which is allowed
+	// for changefeeds with delayed start, verify them at the current tso.
+	currentTs := oracle.ComposeTS(tso.Timestamp, tso.LogicTime)
+	verifyTs := createChangefeedCfg.StartTs
+	if verifyTs > currentTs {
+		verifyTs = currentTs
+	}
+
 	verifyTableConfig := &v2.VerifyTableConfig{
 		PDConfig: v2.PDConfig{
 			PDAddrs:       createChangefeedCfg.PDAddrs,
@@ -293,8 +379,8 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 			CertAllowedCN: createChangefeedCfg.CertAllowedCN,
 		},
 		ReplicaConfig: createChangefeedCfg.ReplicaConfig,
-		StartTs:       createChangefeedCfg.StartTs,
-		SinkURI:       createChangefeedCfg.SinkURI,
+		StartTs:       verifyTs,
+		SinkURI:       o.getSinkURI(),
 	}
 
 	tables, err := o.apiClient.Changefeeds().VerifyTable(ctx, verifyTableConfig)
@@ -351,6 +437,10 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 		}
 		return err
 	}
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, info)
+	}
 	infoStr, err := info.Marshal()
 	if err != nil {
 		return err
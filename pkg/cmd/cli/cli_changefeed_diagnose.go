@@ -0,0 +1,85 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// diagnoseChangefeedOptions defines flags for the `cli changefeed diagnose` command.
+type diagnoseChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	limit        int
+}
+
+// newDiagnoseChangefeedOptions creates new options for the `cli changefeed diagnose` command.
+func newDiagnoseChangefeedOptions() *diagnoseChangefeedOptions {
+	return &diagnoseChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *diagnoseChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().IntVar(&o.limit, "limit", 0,
+		"Max number of the slowest table spans reported in each stage, default to 5")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *diagnoseChangefeedOptions) complete(f factory.Factory) error {
+	var err error
+	o.apiClient, err = f.APIV2Client()
+	return err
+}
+
+// run the `cli changefeed diagnose` command.
+func (o *diagnoseChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.apiClient.Changefeeds().ResolvedTsDiagnosis(ctx,
+			o.namespace, o.changefeedID, o.limit)
+	})
+}
+
+// newCmdDiagnoseChangefeed creates the `cli changefeed diagnose` command.
+func newCmdDiagnoseChangefeed(f factory.Factory) *cobra.Command {
+	o := newDiagnoseChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Diagnose which stage holds back the checkpoint of a replication task (changefeed)",
+		Long: "Walk through the replication pipeline of a replication task (changefeed), " +
+			"report the slowest table spans in the kv client, puller, sorter, redo log, " +
+			"barrier and sink stages, and the stage which holds back the checkpoint the most",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
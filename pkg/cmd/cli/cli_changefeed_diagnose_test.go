@@ -0,0 +1,56 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedDiagnoseCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfV2 := mock.NewMockChangefeedInterface(ctrl)
+	f := &mockFactory{changefeeds: cfV2}
+
+	o := newDiagnoseChangefeedOptions()
+	require.NoError(t, o.complete(f))
+	cmd := newCmdDiagnoseChangefeed(f)
+	o.namespace = "default"
+	o.changefeedID = "abc"
+	o.limit = 3
+
+	cfV2.EXPECT().ResolvedTsDiagnosis(gomock.Any(), "default", "abc", 3).
+		Return(&model.ResolvedTsDiagnosis{
+			CheckpointTs: 100,
+			Blocking: &model.StageDiagnosis{
+				Stage: model.DiagnosisStageKVClient, TableID: 1, RegionID: 2, StoreID: 3,
+			},
+		}, nil)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, o.run(cmd))
+	require.Contains(t, b.String(), `"stage": "kv-client"`)
+	require.Contains(t, b.String(), `"region_id": 2`)
+
+	cfV2.EXPECT().ResolvedTsDiagnosis(gomock.Any(), "default", "abc", 3).
+		Return(nil, errors.New("test"))
+	require.Error(t, o.run(cmd))
+}
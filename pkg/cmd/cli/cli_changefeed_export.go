@@ -0,0 +1,109 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pingcap/errors"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// exportChangefeedOptions defines flags for the `cli changefeed export` command.
+type exportChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID  string
+	namespace     string
+	startTsPolicy string
+	file          string
+}
+
+// newExportChangefeedOptions creates new options for the `cli changefeed export` command.
+func newExportChangefeedOptions() *exportChangefeedOptions {
+	return &exportChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *exportChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVar(&o.startTsPolicy, "start-ts-policy", "now",
+		"Where the imported changefeed starts from, "+
+			"now: the current ts of the upstream, checkpoint: the checkpoint ts of the changefeed")
+	cmd.PersistentFlags().StringVar(&o.file, "file", "",
+		"File to write the exported changefeed to, print to stdout if not specified")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *exportChangefeedOptions) complete(f factory.Factory) error {
+	var err error
+	o.apiClient, err = f.APIV2Client()
+	return err
+}
+
+// run the `cli changefeed export` command.
+func (o *exportChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+	doc, err := o.apiClient.Changefeeds().Export(ctx, o.namespace, o.changefeedID, o.startTsPolicy)
+	if err != nil {
+		return err
+	}
+	if o.file == "" {
+		return util.PrintOutput(cmd, doc)
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.WriteFile(o.file, data, 0o644); err != nil {
+		return errors.Trace(err)
+	}
+	cmd.Printf("Changefeed %s is exported to %s\n", o.changefeedID, o.file)
+	if len(doc.Variables) > 0 {
+		cmd.Printf("The values of the variables %s must be provided when importing it\n",
+			strings.Join(doc.Variables, ", "))
+	}
+	return nil
+}
+
+// newCmdExportChangefeed creates the `cli changefeed export` command.
+func newCmdExportChangefeed(f factory.Factory) *cobra.Command {
+	o := newExportChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "export",
+		Short: "Export a replication task (changefeed) as a portable document",
+		Long: "Export the config, the sink URI and the start ts policy of a replication task " +
+			"(changefeed) as a document which can be imported to another cluster, the secrets " +
+			"in it are replaced with variables like ${SINK_PASSWORD}",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// importChangefeedOptions defines flags for the `cli changefeed import` command.
+type importChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	file         string
+	changefeedID string
+	namespace    string
+	startTs      uint64
+	targetTs     uint64
+	variables    []string
+	upstreamName string
+}
+
+// newImportChangefeedOptions creates new options for the `cli changefeed import` command.
+func newImportChangefeedOptions() *importChangefeedOptions {
+	return &importChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *importChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.file, "file", "", "File of the exported changefeed")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "",
+		"Replication task (changefeed) Namespace, default to the one in the file")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "",
+		"Replication task (changefeed) ID, default to the one in the file")
+	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0,
+		"Start ts of changefeed, default to the one decided by the start ts policy in the file")
+	cmd.PersistentFlags().Uint64Var(&o.targetTs, "target-ts", 0,
+		"Target ts of changefeed, default to the one in the file")
+	cmd.PersistentFlags().StringArrayVar(&o.variables, "var", nil,
+		"Value of a variable in the file in the form of NAME=VALUE, "+
+			"the environment variable with the same name is used if it's not specified")
+	cmd.PersistentFlags().StringVar(&o.upstreamName, "upstream-name", "",
+		"Name of the registered upstream to replicate from, default to the upstream of the cluster")
+	_ = cmd.MarkPersistentFlagRequired("file")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *importChangefeedOptions) complete(f factory.Factory) error {
+	var err error
+	o.apiClient, err = f.APIV2Client()
+	return err
+}
+
+// getImportConfig reads the exported changefeed and builds the import config.
+func (o *importChangefeedOptions) getImportConfig() (*v2.ChangefeedImportConfig, error) {
+	data, err := os.ReadFile(o.file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := &v2.ChangefeedExport{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, errors.Annotatef(err, "invalid changefeed file %s", o.file)
+	}
+	values := make(map[string]string, len(doc.Variables))
+	for _, name := range doc.Variables {
+		if value, ok := os.LookupEnv(name); ok {
+			values[name] = value
+		}
+	}
+	for _, v := range o.variables {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, errors.Errorf("invalid variable %s, it should be NAME=VALUE", v)
+		}
+		values[name] = value
+	}
+	return &v2.ChangefeedImportConfig{
+		Document:     doc,
+		Namespace:    o.namespace,
+		ID:           o.changefeedID,
+		StartTs:      o.startTs,
+		TargetTs:     o.targetTs,
+		Variables:    values,
+		UpstreamName: o.upstreamName,
+	}, nil
+}
+
+// run the `cli changefeed import` command.
+func (o *importChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+	cfg, err := o.getImportConfig()
+	if err != nil {
+		return err
+	}
+	info, err := o.apiClient.Changefeeds().Import(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, info)
+	}
+	infoStr, err := info.Marshal()
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Import changefeed successfully!\nID: %s\nInfo: %s\n", info.ID, infoStr)
+	return nil
+}
+
+// newCmdImportChangefeed creates the `cli changefeed import` command.
+func newCmdImportChangefeed(f factory.Factory) *cobra.Command {
+	o := newImportChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "import",
+		Short: "Create a replication task (changefeed) from an exported file",
+		Long: "Create a replication task (changefeed) from a file exported by " +
+			"`cli changefeed export`, the values of the variables in the file are " +
+			"taken from --var and the environment variables",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedImportCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfV2 := mock.NewMockChangefeedInterface(ctrl)
+	f := &mockFactory{changefeeds: cfV2}
+
+	o := newImportChangefeedOptions()
+	require.NoError(t, o.complete(f))
+	cmd := newCmdImportChangefeed(f)
+
+	doc := &v2.ChangefeedExport{
+		FormatVersion: v2.ChangefeedExportFormatVersion,
+		Namespace:     "default",
+		ID:            "abc",
+		SinkURI:       "kafka://127.0.0.1:9092/topic?sasl-password=${SINK_SASL_PASSWORD}",
+		StartTsPolicy: v2.StartTsPolicyNow,
+		Variables:     []string{"SINK_SASL_PASSWORD", "KAFKA_SASL_PASSWORD"},
+	}
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	o.file = filepath.Join(t.TempDir(), "changefeed.json")
+	require.NoError(t, os.WriteFile(o.file, data, 0o644))
+	o.changefeedID = "def"
+	o.startTs = 100
+	o.variables = []string{"SINK_SASL_PASSWORD=a=b"}
+	t.Setenv("KAFKA_SASL_PASSWORD", "c")
+	t.Setenv("SINK_SASL_PASSWORD", "d")
+
+	cfV2.EXPECT().Import(gomock.Any(), &v2.ChangefeedImportConfig{
+		Document:  doc,
+		ID:        "def",
+		StartTs:   100,
+		Variables: map[string]string{"SINK_SASL_PASSWORD": "a=b", "KAFKA_SASL_PASSWORD": "c"},
+	}).Return(&v2.ChangeFeedInfo{ID: "def"}, nil)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, o.run(cmd))
+	require.Contains(t, b.String(), "Import changefeed successfully!\nID: def")
+
+	o.variables = []string{"SINK_SASL_PASSWORD"}
+	require.ErrorContains(t, o.run(cmd), "NAME=VALUE")
+
+	o.file = filepath.Join(t.TempDir(), "not-exist.json")
+	require.Error(t, o.run(cmd))
+}
@@ -17,8 +17,9 @@ import (
 	"time"
 
 	"github.com/pingcap/tiflow/cdc/api/owner"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
 	"github.com/pingcap/tiflow/cdc/model"
-	"github.com/pingcap/tiflow/pkg/api/v2"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
 	"github.com/pingcap/tiflow/pkg/cmd/context"
 	"github.com/pingcap/tiflow/pkg/cmd/factory"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
@@ -36,7 +37,7 @@ type changefeedCommonInfo struct {
 
 // listChangefeedOptions defines flags for the `cli changefeed list` command.
 type listChangefeedOptions struct {
-	apiClient v2.APIV2Interface
+	apiClient apiv2client.APIV2Interface
 
 	listAll   bool
 	namespace string
@@ -68,33 +69,42 @@ func (o *listChangefeedOptions) complete(f factory.Factory) error {
 func (o *listChangefeedOptions) run(cmd *cobra.Command) error {
 	ctx := context.GetDefaultContext()
 
-	raw, err := o.apiClient.Changefeeds().List(ctx, o.namespace, "all")
-	if err != nil {
-		return err
-	}
-	cfs := make([]*changefeedCommonInfo, 0, len(raw))
-
-	for _, cf := range raw {
-		if !o.listAll {
-			if cf.FeedState == model.StateFinished ||
-				cf.FeedState == model.StateRemoved {
-				continue
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		raw, err := o.apiClient.Changefeeds().List(ctx, o.namespace, "all")
+		if err != nil {
+			return nil, err
+		}
+		filtered := make([]v2.ChangefeedCommonInfo, 0, len(raw))
+		for _, cf := range raw {
+			if !o.listAll {
+				if cf.FeedState == model.StateFinished ||
+					cf.FeedState == model.StateRemoved {
+					continue
+				}
 			}
+			filtered = append(filtered, cf)
 		}
-		cfci := &changefeedCommonInfo{
-			ID:        cf.ID,
-			Namespace: cf.Namespace,
-			Summary: &owner.ChangefeedResp{
-				FeedState:    string(cf.FeedState),
-				TSO:          cf.CheckpointTSO,
-				Checkpoint:   time.Time(cf.CheckpointTime).Format(timeFormat),
-				RunningError: cf.RunningError,
-			},
+		// The v2 API models are printed if the output format is specified.
+		if util.GetOutputFormat(cmd) != "" {
+			return filtered, nil
 		}
-		cfs = append(cfs, cfci)
-	}
 
-	return util.JSONPrint(cmd, cfs)
+		cfs := make([]*changefeedCommonInfo, 0, len(filtered))
+		for _, cf := range filtered {
+			cfci := &changefeedCommonInfo{
+				ID:        cf.ID,
+				Namespace: cf.Namespace,
+				Summary: &owner.ChangefeedResp{
+					FeedState:    string(cf.FeedState),
+					TSO:          cf.CheckpointTSO,
+					Checkpoint:   time.Time(cf.CheckpointTime).Format(timeFormat),
+					RunningError: cf.RunningError,
+				},
+			}
+			cfs = append(cfs, cfci)
+		}
+		return cfs, nil
+	})
 }
 
 // newCmdListChangefeed creates the `cli changefeed list` command.
@@ -82,24 +82,35 @@ func (o *queryChangefeedOptions) complete(f factory.Factory) error {
 // run the `cli changefeed query` command.
 func (o *queryChangefeedOptions) run(cmd *cobra.Command) error {
 	ctx := context.Background()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.query(ctx, cmd)
+	})
+}
+
+// query queries the changefeed, the v2 API models are returned if the
+// output format is specified.
+func (o *queryChangefeedOptions) query(ctx context.Context, cmd *cobra.Command) (interface{}, error) {
 	if o.simplified {
 		infos, err := o.apiClientV2.Changefeeds().List(ctx, o.namespace, "all")
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		for _, info := range infos {
 			if info.ID == o.changefeedID {
-				return util.JSONPrint(cmd, info)
+				return info, nil
 			}
 		}
-		return cerror.ErrChangeFeedNotExists.GenWithStackByArgs(o.changefeedID)
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(o.changefeedID)
 	}
 
 	detail, err := o.apiClientV2.Changefeeds().Get(ctx, o.namespace, o.changefeedID)
 	if err != nil && cerror.ErrChangeFeedNotExists.NotEqual(err) {
-		return err
+		return nil, err
 	}
-	meta := &cfMeta{
+	if util.GetOutputFormat(cmd) != "" {
+		return detail, nil
+	}
+	return &cfMeta{
 		UpstreamID:     detail.UpstreamID,
 		Namespace:      detail.Namespace,
 		ID:             detail.ID,
@@ -115,8 +126,7 @@ func (o *queryChangefeedOptions) run(cmd *cobra.Command) error {
 		RunningError:   detail.Error,
 		CreatorVersion: detail.CreatorVersion,
 		TaskStatus:     detail.TaskStatus,
-	}
-	return util.JSONPrint(cmd, meta)
+	}, nil
 }
 
 // newCmdQueryChangefeed creates the `cli changefeed query` command.
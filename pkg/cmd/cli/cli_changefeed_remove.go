@@ -92,6 +92,10 @@ func (o *removeChangefeedOptions) run(cmd *cobra.Command) error {
 		err = nil
 	}
 
+	if err == nil && util.GetOutputFormat(cmd) != "" {
+		// The v2 API models are printed if the output format is specified.
+		return util.PrintOutput(cmd, changefeedDetail)
+	}
 	if err == nil {
 		cmd.Printf("Changefeed remove successfully.\nID: %s\nCheckpointTs: %d\nSinkURI: %s\n",
 			o.changefeedID, checkpointTs, sinkURI)
@@ -0,0 +1,101 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// schemaHistoryChangefeedOptions defines flags for the `cli changefeed schema-history` command.
+type schemaHistoryChangefeedOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	startTs      uint64
+	endTs        uint64
+	file         string
+}
+
+// newSchemaHistoryChangefeedOptions creates new options for the `cli changefeed schema-history` command.
+func newSchemaHistoryChangefeedOptions() *schemaHistoryChangefeedOptions {
+	return &schemaHistoryChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *schemaHistoryChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0,
+		"Start ts of the schema history, default to the end ts")
+	cmd.PersistentFlags().Uint64Var(&o.endTs, "end-ts", 0,
+		"End ts of the schema history, default to the checkpoint ts of the changefeed")
+	cmd.PersistentFlags().StringVar(&o.file, "file", "",
+		"File to write the schema history to, print to stdout if not specified")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *schemaHistoryChangefeedOptions) complete(f factory.Factory) error {
+	var err error
+	o.apiClient, err = f.APIV2Client()
+	return err
+}
+
+// run the `cli changefeed schema-history` command.
+func (o *schemaHistoryChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+	history, err := o.apiClient.Changefeeds().SchemaHistory(ctx,
+		o.namespace, o.changefeedID, o.startTs, o.endTs)
+	if err != nil {
+		return err
+	}
+	if o.file == "" {
+		return util.PrintOutput(cmd, history)
+	}
+	if err := history.WriteFile(o.file); err != nil {
+		return err
+	}
+	cmd.Printf("Schema history of %d table versions between %d and %d is exported to %s\n",
+		len(history.Tables), history.StartTs, history.EndTs, o.file)
+	return nil
+}
+
+// newCmdSchemaHistoryChangefeed creates the `cli changefeed schema-history` command.
+func newCmdSchemaHistoryChangefeed(f factory.Factory) *cobra.Command {
+	o := newSchemaHistoryChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "schema-history",
+		Short: "Export the schema history of a replication task (changefeed)",
+		Long: "Export the versioned table definitions of a replication task (changefeed) " +
+			"between two ts, the exported file can be used by the consumers and " +
+			"the redo applier when the upstream is unavailable",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedSchemaHistoryCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfV2 := mock.NewMockChangefeedInterface(ctrl)
+	f := &mockFactory{changefeeds: cfV2}
+
+	o := newSchemaHistoryChangefeedOptions()
+	require.NoError(t, o.complete(f))
+	cmd := newCmdSchemaHistoryChangefeed(f)
+	o.namespace = "default"
+	o.changefeedID = "abc"
+	o.startTs = 100
+	o.endTs = 200
+
+	history := schemahistory.NewHistory(model.DefaultChangeFeedID("abc"), 100, 200)
+	cfV2.EXPECT().SchemaHistory(gomock.Any(), "default", "abc", uint64(100), uint64(200)).
+		Return(history, nil)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, o.run(cmd))
+	require.Contains(t, b.String(), `"start_ts": 100`)
+
+	o.file = filepath.Join(t.TempDir(), "history.json")
+	cfV2.EXPECT().SchemaHistory(gomock.Any(), "default", "abc", uint64(100), uint64(200)).
+		Return(history, nil)
+	require.NoError(t, o.run(cmd))
+	exported, err := schemahistory.ReadFile(o.file)
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), exported.EndTs)
+
+	cfV2.EXPECT().SchemaHistory(gomock.Any(), "default", "abc", uint64(100), uint64(200)).
+		Return(nil, errors.New("test"))
+	require.Error(t, o.run(cmd))
+}
@@ -18,7 +18,7 @@ import (
 	"fmt"
 	"time"
 
-	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/cdc/model"
 	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
 	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
 	"github.com/pingcap/tiflow/pkg/cmd/factory"
@@ -31,6 +31,14 @@ import (
 type status struct {
 	SinkGap        string `json:"sink_gap"`
 	ReplicationGap string `json:"replication_gap"`
+	// The following fields are aggregated by the owner from the heartbeats
+	// of the processors.
+	RowsPerSecond     float64                 `json:"rows_per_second"`
+	BytesPerSecond    float64                 `json:"bytes_per_second"`
+	ExecutedDDLs      uint64                  `json:"executed_ddls"`
+	SorterDiskUsage   uint64                  `json:"sorter_disk_usage"`
+	SinkPendingEvents uint64                  `json:"sink_pending_events"`
+	SlowestTables     []model.TableStatistics `json:"slowest_tables"`
 }
 
 // statisticsChangefeedOptions defines flags for the `cli changefeed statistics` command.
@@ -40,6 +48,7 @@ type statisticsChangefeedOptions struct {
 	changefeedID string
 	namespace    string
 	interval     uint
+	limit        int
 }
 
 // newStatisticsChangefeedOptions creates new options for the `cli changefeed statistics` command.
@@ -53,6 +62,8 @@ func (o *statisticsChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
 	cmd.PersistentFlags().UintVarP(&o.interval, "interval", "I", 10, "Interval for outputing the latest statistics")
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().IntVar(&o.limit, "limit", 0,
+		"Max number of the slowest tables to output, default to the limit of the server")
 	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
 }
 
@@ -67,31 +78,33 @@ func (o *statisticsChangefeedOptions) complete(f factory.Factory) error {
 }
 
 // run cli command with api client
-func (o *statisticsChangefeedOptions) runCliWithAPIClient(ctx context.Context, cmd *cobra.Command, lastCount *uint64, lastTime *time.Time) error {
-	now := time.Now()
-	var count uint64
-
-	changefeed, err := o.apiClient.Changefeeds().Get(ctx, o.namespace, o.changefeedID)
+func (o *statisticsChangefeedOptions) runCliWithAPIClient(
+	ctx context.Context, cmd *cobra.Command, printer *util.Printer,
+) error {
+	statistics, err := o.apiClient.Changefeeds().Statistics(ctx,
+		o.namespace, o.changefeedID, o.limit)
 	if err != nil {
 		return err
 	}
-	ts, err := o.apiClient.Tso().Query(ctx,
-		&v2.UpstreamConfig{ID: changefeed.UpstreamID})
-	if err != nil {
-		return err
-	}
-
-	sinkGap := oracle.ExtractPhysical(changefeed.ResolvedTs) -
-		oracle.ExtractPhysical(changefeed.CheckpointTs)
-	replicationGap := ts.Timestamp - oracle.ExtractPhysical(changefeed.CheckpointTs)
-	statistics := status{
-		SinkGap:        fmt.Sprintf("%dms", sinkGap),
-		ReplicationGap: fmt.Sprintf("%dms", replicationGap),
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return printer.Print(statistics)
 	}
 
-	*lastCount = count
-	*lastTime = now
-	return util.JSONPrint(cmd, statistics)
+	sinkGap := oracle.ExtractPhysical(statistics.ResolvedTs) -
+		oracle.ExtractPhysical(statistics.CheckpointTs)
+	replicationGap := oracle.ExtractPhysical(statistics.CurrentTs) -
+		oracle.ExtractPhysical(statistics.CheckpointTs)
+	return printer.Print(status{
+		SinkGap:           fmt.Sprintf("%dms", sinkGap),
+		ReplicationGap:    fmt.Sprintf("%dms", replicationGap),
+		RowsPerSecond:     statistics.RowsPerSecond,
+		BytesPerSecond:    statistics.BytesPerSecond,
+		ExecutedDDLs:      statistics.ExecutedDDLs,
+		SorterDiskUsage:   statistics.SorterDiskUsage,
+		SinkPendingEvents: statistics.SinkPendingEvents,
+		SlowestTables:     statistics.SlowestTables,
+	})
 }
 
 // run the `cli changefeed statistics` command.
@@ -99,9 +112,9 @@ func (o *statisticsChangefeedOptions) run(cmd *cobra.Command) error {
 	ctx := cmdcontext.GetDefaultContext()
 
 	tick := time.NewTicker(time.Duration(o.interval) * time.Second)
-	var lastTime time.Time
-	var lastCount uint64
-	_ = o.runCliWithAPIClient(ctx, cmd, &lastCount, &lastTime)
+	// the statistics are only printed when they change in the watch mode.
+	printer := util.NewPrinter(cmd)
+	_ = o.runCliWithAPIClient(ctx, cmd, printer)
 	for {
 		select {
 		case <-ctx.Done():
@@ -109,7 +122,7 @@ func (o *statisticsChangefeedOptions) run(cmd *cobra.Command) error {
 				return err
 			}
 		case <-tick.C:
-			_ = o.runCliWithAPIClient(ctx, cmd, &lastCount, &lastTime)
+			_ = o.runCliWithAPIClient(ctx, cmd, printer)
 		}
 	}
 }
@@ -0,0 +1,66 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/api/v2/mock"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestChangefeedStatisticsCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfV2 := mock.NewMockChangefeedInterface(ctrl)
+	f := &mockFactory{changefeeds: cfV2}
+
+	o := newStatisticsChangefeedOptions()
+	require.NoError(t, o.complete(f))
+	cmd := newCmdStatisticsChangefeed(f)
+	o.namespace = "default"
+	o.changefeedID = "abc"
+	o.limit = 1
+
+	statistics := &model.ChangefeedStatistics{
+		CheckpointTs:   oracle.ComposeTS(1000, 0),
+		ResolvedTs:     oracle.ComposeTS(1500, 0),
+		CurrentTs:      oracle.ComposeTS(3000, 0),
+		RowsPerSecond:  12.5,
+		BytesPerSecond: 1024,
+		ExecutedDDLs:   2,
+		SlowestTables:  []model.TableStatistics{{TableID: 1, TableName: "`test`.`t1`"}},
+	}
+	cfV2.EXPECT().Statistics(gomock.Any(), "default", "abc", 1).
+		Return(statistics, nil)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, o.runCliWithAPIClient(context.Background(), cmd, util.NewPrinter(cmd)))
+	require.Contains(t, b.String(), `"sink_gap": "500ms"`)
+	require.Contains(t, b.String(), `"replication_gap": "2000ms"`)
+	require.Contains(t, b.String(), `"rows_per_second": 12.5`)
+	require.Contains(t, b.String(), `"executed_ddls": 2`)
+	require.Contains(t, b.String(), `"table_name": "`+"`test`.`t1`"+`"`)
+
+	cfV2.EXPECT().Statistics(gomock.Any(), "default", "abc", 1).
+		Return(nil, errors.New("test"))
+	require.Error(t, o.runCliWithAPIClient(context.Background(), cmd, util.NewPrinter(cmd)))
+}
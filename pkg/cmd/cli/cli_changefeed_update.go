@@ -156,6 +156,10 @@ func (o *updateChangefeedOptions) run(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, info)
+	}
 	infoStr, err := json.Marshal(info)
 	if err != nil {
 		return err
@@ -26,6 +26,7 @@ type configureCredentialsOptions struct{}
 func (o *configureCredentialsOptions) run(cmd *cobra.Command) error {
 	cmd.Println("1) TLS Client Certificate")
 	cmd.Println("2) TiDB User Credentials")
+	cmd.Println("3) Bearer Token")
 	cmd.Print("Select Credential Type [default 1]:")
 
 	option, err := readInput()
@@ -94,6 +95,16 @@ func (o *configureCredentialsOptions) run(cmd *cobra.Command) error {
 		if password != "" {
 			res.Password = password
 		}
+	case "3":
+		cmd.Printf("TiCDC Bearer Token [%s]:", res.Token)
+		token, err := readInput()
+		if err != nil {
+			cmd.Printf("Received invalid input: %s, abort the command.\n", err.Error())
+			return fmt.Errorf("invalid input")
+		}
+		if token != "" {
+			res.Token = token
+		}
 	default:
 		cmd.Printf("Received invalid input: %s, abort the command.\n", option)
 		return fmt.Errorf("invalid input")
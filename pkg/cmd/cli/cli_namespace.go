@@ -0,0 +1,104 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// newCmdNamespace creates the `cli namespace` command.
+func newCmdNamespace(f factory.Factory) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "namespace",
+		Short: "Manage the default config, quotas and labels of namespaces",
+		Args:  cobra.NoArgs,
+	}
+	cmds.AddCommand(
+		newCmdListNamespace(f),
+		newCmdQueryNamespace(f),
+		newCmdCreateNamespace(f),
+		newCmdUpdateNamespace(f),
+		newCmdRemoveNamespace(f),
+	)
+
+	return cmds
+}
+
+// decodePartialReplicaConfig decodes a replica config file and returns the
+// items that differ from the default config in the format of the v2 API, so
+// that the items not specified in the file keep following the defaults.
+func decodePartialReplicaConfig(path string) (json.RawMessage, error) {
+	cfg := config.GetDefaultReplicaConfig()
+	if err := util.StrictDecodeFile(path, "TiCDC changefeed", cfg); err != nil {
+		return nil, err
+	}
+	defaults, err := toJSONMap(v2.ToAPIReplicaConfig(config.GetDefaultReplicaConfig()))
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := toJSONMap(v2.ToAPIReplicaConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(diffJSONMap(defaults, decoded))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// diffJSONMap returns the items of the new map that differ from the base map,
+// the nested objects are compared item by item.
+func diffJSONMap(base, m map[string]interface{}) map[string]interface{} {
+	diff := make(map[string]interface{})
+	for k, v := range m {
+		baseValue, ok := base[k]
+		if !ok {
+			diff[k] = v
+			continue
+		}
+		baseObj, ok1 := baseValue.(map[string]interface{})
+		obj, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			if d := diffJSONMap(baseObj, obj); len(d) != 0 {
+				diff[k] = d
+			}
+			continue
+		}
+		if !reflect.DeepEqual(baseValue, v) {
+			diff[k] = v
+		}
+	}
+	return diff
+}
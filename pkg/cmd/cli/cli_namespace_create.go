@@ -0,0 +1,137 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// createNamespaceOptions defines flags for the `cli namespace create` and
+// `cli namespace update` commands.
+type createNamespaceOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	namespace         string
+	defaultConfigFile string
+	maxChangefeeds    int
+	maxMemoryQuota    uint64
+	labels            map[string]string
+}
+
+// newCreateNamespaceOptions creates new options for the `cli namespace create`
+// and `cli namespace update` commands.
+func newCreateNamespaceOptions() *createNamespaceOptions {
+	return &createNamespaceOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *createNamespaceOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Namespace")
+	cmd.PersistentFlags().StringVar(&o.defaultConfigFile, "default-config", "",
+		"Path of the configuration file with the default replica config of the new changefeeds, "+
+			"only the items different from the built-in defaults are stored")
+	cmd.PersistentFlags().IntVar(&o.maxChangefeeds, "max-changefeeds", 0,
+		"Max number of changefeeds in the namespace, 0 means no limit")
+	cmd.PersistentFlags().Uint64Var(&o.maxMemoryQuota, "max-memory-quota", 0,
+		"Max sum of the memory quota of the changefeeds in the namespace, 0 means no limit")
+	cmd.PersistentFlags().StringToStringVar(&o.labels, "label", nil,
+		"Labels of the namespace, e.g. --label team=a,env=prod")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *createNamespaceOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// apply sets the settings specified by the flags to the namespace config, the
+// other settings are kept.
+func (o *createNamespaceOptions) apply(cmd *cobra.Command, cfg *v2.NamespaceConfig) error {
+	flags := cmd.Flags()
+	if flags.Changed("default-config") {
+		cfg.DefaultReplicaConfig = nil
+		if o.defaultConfigFile != "" {
+			defaultConfig, err := decodePartialReplicaConfig(o.defaultConfigFile)
+			if err != nil {
+				return err
+			}
+			cfg.DefaultReplicaConfig = defaultConfig
+		}
+	}
+	if flags.Changed("max-changefeeds") {
+		cfg.Quota.MaxChangefeeds = o.maxChangefeeds
+	}
+	if flags.Changed("max-memory-quota") {
+		cfg.Quota.MaxMemoryQuota = o.maxMemoryQuota
+	}
+	if flags.Changed("label") {
+		cfg.Labels = o.labels
+	}
+	return nil
+}
+
+// printNamespace prints the namespace returned by the server.
+func (o *createNamespaceOptions) printNamespace(
+	cmd *cobra.Command, action string, namespace *v2.Namespace,
+) error {
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, namespace)
+	}
+	cmd.Printf("%s namespace successfully!\nName: %s\n", action, namespace.Name)
+	return nil
+}
+
+// run the `cli namespace create` command.
+func (o *createNamespaceOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	cfg := &v2.NamespaceConfig{Name: o.namespace}
+	if err := o.apply(cmd, cfg); err != nil {
+		return err
+	}
+	namespace, err := o.apiClient.Namespaces().Create(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	return o.printNamespace(cmd, "Create", namespace)
+}
+
+// newCmdCreateNamespace creates the `cli namespace create` command.
+func newCmdCreateNamespace(f factory.Factory) *cobra.Command {
+	o := newCreateNamespaceOptions()
+
+	command := &cobra.Command{
+		Use:   "create",
+		Short: "Create the settings of a namespace",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// listNamespaceOptions defines flags for the `cli namespace list` command.
+type listNamespaceOptions struct {
+	apiClient apiv2client.APIV2Interface
+}
+
+// newListNamespaceOptions creates new options for the `cli namespace list` command.
+func newListNamespaceOptions() *listNamespaceOptions {
+	return &listNamespaceOptions{}
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *listNamespaceOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// run the `cli namespace list` command.
+func (o *listNamespaceOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.apiClient.Namespaces().List(ctx)
+	})
+}
+
+// newCmdListNamespace creates the `cli namespace list` command.
+func newCmdListNamespace(f factory.Factory) *cobra.Command {
+	o := newListNamespaceOptions()
+
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List all namespaces with their settings and changefeeds",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	return command
+}
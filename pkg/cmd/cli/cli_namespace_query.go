@@ -0,0 +1,86 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// queryNamespaceOptions defines flags for the `cli namespace query` command.
+type queryNamespaceOptions struct {
+	apiClient apiv2client.APIV2Interface
+	namespace string
+	template  string
+	// changefeedConfig prints the config a new changefeed starts from.
+	changefeedConfig bool
+}
+
+// newQueryNamespaceOptions creates new options for the `cli namespace query` command.
+func newQueryNamespaceOptions() *queryNamespaceOptions {
+	return &queryNamespaceOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *queryNamespaceOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Namespace")
+	cmd.PersistentFlags().BoolVar(&o.changefeedConfig, "changefeed-config", false,
+		"Print the config that a new changefeed of the namespace starts from")
+	cmd.PersistentFlags().StringVar(&o.template, "template", "",
+		"Name of a changefeed template applied to the printed changefeed config")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *queryNamespaceOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// run the `cli namespace query` command.
+func (o *queryNamespaceOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		if o.changefeedConfig || o.template != "" {
+			return o.apiClient.Namespaces().GetChangefeedConfig(ctx, o.namespace, o.template)
+		}
+		return o.apiClient.Namespaces().Get(ctx, o.namespace)
+	})
+}
+
+// newCmdQueryNamespace creates the `cli namespace query` command.
+func newCmdQueryNamespace(f factory.Factory) *cobra.Command {
+	o := newQueryNamespaceOptions()
+
+	command := &cobra.Command{
+		Use:   "query",
+		Short: "Query the settings, changefeeds and memory quota usage of a namespace",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
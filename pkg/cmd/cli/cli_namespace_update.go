@@ -0,0 +1,59 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// runUpdate runs the `cli namespace update` command, only the settings
+// specified by the flags are changed.
+func (o *createNamespaceOptions) runUpdate(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	old, err := o.apiClient.Namespaces().Get(ctx, o.namespace)
+	if err != nil {
+		return err
+	}
+	cfg := old.NamespaceConfig
+	if err := o.apply(cmd, &cfg); err != nil {
+		return err
+	}
+	namespace, err := o.apiClient.Namespaces().Update(ctx, o.namespace, &cfg)
+	if err != nil {
+		return err
+	}
+	return o.printNamespace(cmd, "Update", namespace)
+}
+
+// newCmdUpdateNamespace creates the `cli namespace update` command.
+func newCmdUpdateNamespace(f factory.Factory) *cobra.Command {
+	o := newCreateNamespaceOptions()
+
+	command := &cobra.Command{
+		Use:   "update",
+		Short: "Update the settings of a namespace, the existing changefeeds are not affected",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.runUpdate(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
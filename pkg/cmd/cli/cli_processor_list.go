@@ -44,11 +44,9 @@ func (o *listProcessorOptions) complete(f factory.Factory) error {
 // run runs the `cli processor list` command.
 func (o *listProcessorOptions) run(cmd *cobra.Command) error {
 	ctx := cmdcontext.GetDefaultContext()
-	processors, err := o.apiClient.Processors().List(ctx)
-	if err != nil {
-		return err
-	}
-	return util.JSONPrint(cmd, processors)
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.apiClient.Processors().List(ctx)
+	})
 }
 
 // newCmdListProcessor creates the `cli processor list` command.
@@ -65,28 +65,32 @@ func (o *queryProcessorOptions) addFlags(cmd *cobra.Command) {
 
 // run cli cmd with api client
 func (o *queryProcessorOptions) runCliWithAPIClient(ctx context.Context, cmd *cobra.Command) error {
-	processor, err := o.apiClient.Processors().Get(ctx, o.namespace, o.changefeedID, o.captureID)
-	if err != nil {
-		return err
-	}
-
-	tables := make(map[int64]*model.TableReplicaInfo)
-	for _, tableID := range processor.Tables {
-		tables[tableID] = &model.TableReplicaInfo{
-			// to be compatible with old version `cli processor query`,
-			// set this field to 0
-			StartTs: 0,
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		processor, err := o.apiClient.Processors().Get(ctx, o.namespace, o.changefeedID, o.captureID)
+		if err != nil {
+			return nil, err
+		}
+		// The v2 API models are printed if the output format is specified.
+		if util.GetOutputFormat(cmd) != "" {
+			return processor, nil
 		}
-	}
 
-	meta := &processorMeta{
-		Status: &model.TaskStatus{
-			Tables: tables,
-			// Operations, AdminJobType and ModRevision are vacant
-		},
-	}
+		tables := make(map[int64]*model.TableReplicaInfo)
+		for _, tableID := range processor.Tables {
+			tables[tableID] = &model.TableReplicaInfo{
+				// to be compatible with old version `cli processor query`,
+				// set this field to 0
+				StartTs: 0,
+			}
+		}
 
-	return util.JSONPrint(cmd, meta)
+		return &processorMeta{
+			Status: &model.TaskStatus{
+				Tables: tables,
+				// Operations, AdminJobType and ModRevision are vacant
+			},
+		}, nil
+	})
 }
 
 // run runs the `cli processor query` command.
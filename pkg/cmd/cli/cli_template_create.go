@@ -0,0 +1,134 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// createTemplateOptions defines flags for the `cli template create` and
+// `cli template update` commands.
+type createTemplateOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	namespace   string
+	name        string
+	description string
+	sinkURI     string
+	configFile  string
+}
+
+// newCreateTemplateOptions creates new options for the `cli template create`
+// and `cli template update` commands.
+func newCreateTemplateOptions() *createTemplateOptions {
+	return &createTemplateOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *createTemplateOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Changefeed template Namespace")
+	cmd.PersistentFlags().StringVar(&o.name, "name", "", "Name of the changefeed template, unique in a namespace")
+	cmd.PersistentFlags().StringVar(&o.description, "description", "", "Description of the changefeed template")
+	cmd.PersistentFlags().StringVar(&o.sinkURI, "sink-uri", "",
+		"Sink uri of the changefeeds created from the template")
+	cmd.PersistentFlags().StringVar(&o.configFile, "config", "",
+		"Path of the configuration file with the replica config of the template, "+
+			"only the items different from the built-in defaults are stored")
+	_ = cmd.MarkPersistentFlagRequired("name")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *createTemplateOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// apply sets the fields specified by the flags to the changefeed template, the
+// other fields are kept.
+func (o *createTemplateOptions) apply(cmd *cobra.Command, template *v2.ChangefeedTemplate) error {
+	flags := cmd.Flags()
+	if flags.Changed("description") {
+		template.Description = o.description
+	}
+	if flags.Changed("sink-uri") {
+		template.SinkURI = o.sinkURI
+	}
+	if flags.Changed("config") {
+		template.ReplicaConfig = nil
+		if o.configFile != "" {
+			replicaConfig, err := decodePartialReplicaConfig(o.configFile)
+			if err != nil {
+				return err
+			}
+			template.ReplicaConfig = replicaConfig
+		}
+	}
+	return nil
+}
+
+// printTemplate prints the changefeed template returned by the server.
+func (o *createTemplateOptions) printTemplate(
+	cmd *cobra.Command, action string, template *v2.ChangefeedTemplate,
+) error {
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, template)
+	}
+	cmd.Printf("%s changefeed template successfully!\nNamespace: %s\nName: %s\n",
+		action, template.Namespace, template.Name)
+	return nil
+}
+
+// run the `cli template create` command.
+func (o *createTemplateOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	template := &v2.ChangefeedTemplate{Name: o.name}
+	if err := o.apply(cmd, template); err != nil {
+		return err
+	}
+	template, err := o.apiClient.Templates().Create(ctx, o.namespace, template)
+	if err != nil {
+		return err
+	}
+	return o.printTemplate(cmd, "Create", template)
+}
+
+// newCmdCreateTemplate creates the `cli template create` command.
+func newCmdCreateTemplate(f factory.Factory) *cobra.Command {
+	o := newCreateTemplateOptions()
+
+	command := &cobra.Command{
+		Use:   "create",
+		Short: "Create a changefeed template in a namespace",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// queryTemplateOptions defines flags for the `cli template query` command.
+type queryTemplateOptions struct {
+	apiClient apiv2client.APIV2Interface
+	namespace string
+	name      string
+}
+
+// newQueryTemplateOptions creates new options for the `cli template query` command.
+func newQueryTemplateOptions() *queryTemplateOptions {
+	return &queryTemplateOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *queryTemplateOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Changefeed template Namespace")
+	cmd.PersistentFlags().StringVar(&o.name, "name", "", "Name of the changefeed template")
+	_ = cmd.MarkPersistentFlagRequired("name")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *queryTemplateOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// run the `cli template query` command.
+func (o *queryTemplateOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.apiClient.Templates().Get(ctx, o.namespace, o.name)
+	})
+}
+
+// newCmdQueryTemplate creates the `cli template query` command.
+func newCmdQueryTemplate(f factory.Factory) *cobra.Command {
+	o := newQueryTemplateOptions()
+
+	command := &cobra.Command{
+		Use:   "query",
+		Short: "Query a changefeed template",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// removeTemplateOptions defines flags for the `cli template remove` command.
+type removeTemplateOptions struct {
+	apiClient apiv2client.APIV2Interface
+	namespace string
+	name      string
+}
+
+// newRemoveTemplateOptions creates new options for the `cli template remove` command.
+func newRemoveTemplateOptions() *removeTemplateOptions {
+	return &removeTemplateOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *removeTemplateOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Changefeed template Namespace")
+	cmd.PersistentFlags().StringVar(&o.name, "name", "", "Name of the changefeed template")
+	_ = cmd.MarkPersistentFlagRequired("name")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *removeTemplateOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// run the `cli template remove` command.
+func (o *removeTemplateOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	err := o.apiClient.Templates().Delete(ctx, o.namespace, o.name)
+	if err != nil {
+		cmd.Printf("Changefeed template remove failed.\nName: %s\nError: %s\n", o.name, err.Error())
+		return err
+	}
+	cmd.Printf("Changefeed template remove successfully.\nName: %s\n", o.name)
+	return nil
+}
+
+// newCmdRemoveTemplate creates the `cli template remove` command.
+func newCmdRemoveTemplate(f factory.Factory) *cobra.Command {
+	o := newRemoveTemplateOptions()
+
+	command := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a changefeed template, the changefeeds created from it are not affected",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// runUpdate runs the `cli template update` command, only the fields specified
+// by the flags are changed.
+func (o *createTemplateOptions) runUpdate(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	template, err := o.apiClient.Templates().Get(ctx, o.namespace, o.name)
+	if err != nil {
+		return err
+	}
+	// the sink uri returned by the server is masked, the server keeps the
+	// sink uri of the template if it is not specified.
+	template.SinkURI = ""
+	if err := o.apply(cmd, template); err != nil {
+		return err
+	}
+	template, err = o.apiClient.Templates().Update(ctx, o.namespace, o.name, template)
+	if err != nil {
+		return err
+	}
+	return o.printTemplate(cmd, "Update", template)
+}
+
+// newCmdUpdateTemplate creates the `cli template update` command.
+func newCmdUpdateTemplate(f factory.Factory) *cobra.Command {
+	o := newCreateTemplateOptions()
+
+	command := &cobra.Command{
+		Use:   "update",
+		Short: "Update a changefeed template, the changefeeds created from it are not affected",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.runUpdate(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -14,6 +14,7 @@
 package cli
 
 import (
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
 	"github.com/pingcap/tiflow/pkg/cmd/context"
 	"github.com/pingcap/tiflow/pkg/cmd/factory"
 	"github.com/pingcap/tiflow/pkg/cmd/util"
@@ -47,14 +48,17 @@ func (o *queryTsoOptions) complete(f factory.Factory) error {
 func (o *queryTsoOptions) run(cmd *cobra.Command) error {
 	ctx := context.GetDefaultContext()
 
-	ts, logic, err := o.pdClient.GetTS(ctx)
-	if err != nil {
-		return err
-	}
-
-	cmd.Println(oracle.ComposeTS(ts, logic))
-
-	return nil
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		ts, logic, err := o.pdClient.GetTS(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The v2 API models are printed if the output format is specified.
+		if util.GetOutputFormat(cmd) != "" {
+			return &v2.Tso{Timestamp: ts, LogicTime: logic}, nil
+		}
+		return oracle.ComposeTS(ts, logic), nil
+	})
 }
 
 // newCmdQueryTso creates the `cli tso query` command.
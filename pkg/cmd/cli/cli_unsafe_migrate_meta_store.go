@@ -0,0 +1,104 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/pingcap/tiflow/pkg/metastore"
+	"github.com/spf13/cobra"
+)
+
+// unsafeMigrateMetaStoreOptions defines flags for the
+// `cli unsafe migrate-meta-store` command.
+type unsafeMigrateMetaStoreOptions struct {
+	clusterID  string
+	metaStore  config.MetaStoreConfiguration
+	etcdClient *etcd.CDCEtcdClientImpl
+}
+
+// newUnsafeMigrateMetaStoreOptions creates new unsafeMigrateMetaStoreOptions
+// for the `cli unsafe migrate-meta-store` command.
+func newUnsafeMigrateMetaStoreOptions() *unsafeMigrateMetaStoreOptions {
+	return &unsafeMigrateMetaStoreOptions{}
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *unsafeMigrateMetaStoreOptions) complete(f factory.Factory) error {
+	cfg := &config.CDCV2{Enable: true, MetaStoreConfig: o.metaStore}
+	if err := cfg.ValidateAndAdjust(); err != nil {
+		return err
+	}
+
+	etcdClient, err := f.EtcdClient()
+	if err != nil {
+		return err
+	}
+	etcdClient.ClusterID = o.clusterID
+	o.etcdClient = etcdClient
+
+	return nil
+}
+
+func (o *unsafeMigrateMetaStoreOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.clusterID, "cluster-id", "default", "cdc cluster id")
+	cmd.Flags().StringVar(&o.metaStore.URI, "meta-store-uri", "",
+		"the uri of the meta store, for example mysql://root@127.0.0.1:3306/ticdc")
+	cmd.Flags().StringVar(&o.metaStore.SSLCa, "meta-store-ssl-ca", "",
+		"the path of the CA certificate file of the meta store")
+	cmd.Flags().StringVar(&o.metaStore.SSLCert, "meta-store-ssl-cert", "",
+		"the path of the certificate file of the meta store")
+	cmd.Flags().StringVar(&o.metaStore.SSLKey, "meta-store-ssl-key", "",
+		"the path of the private key file of the meta store")
+	_ = cmd.MarkFlagRequired("meta-store-uri")
+}
+
+// run runs the `cli unsafe migrate-meta-store` command.
+func (o *unsafeMigrateMetaStoreOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	defer o.etcdClient.Close()
+
+	count, err := metastore.MigrateFromEtcd(ctx,
+		o.etcdClient.GetEtcdClient().Unwrap(), &o.metaStore, o.clusterID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	cmd.Printf("%d keys of cluster %s migrated from etcd to the meta store\n",
+		count, o.clusterID)
+	return nil
+}
+
+// newCmdMigrateMetaStore creates the `cli unsafe migrate-meta-store` command.
+func newCmdMigrateMetaStore(f factory.Factory, commonOptions *unsafeCommonOptions) *cobra.Command {
+	o := newUnsafeMigrateMetaStoreOptions()
+
+	command := &cobra.Command{
+		Use:   "migrate-meta-store",
+		Short: "Copy the metadata of a stopped TiCDC cluster from etcd to the meta store, confirm that you know what this command will do and use it at your own risk",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(commonOptions.confirmMetaDelete(cmd))
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
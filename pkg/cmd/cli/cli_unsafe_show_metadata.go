@@ -51,6 +51,10 @@ func (o *unsafeShowMetadataOptions) run(cmd *cobra.Command) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, kvs)
+	}
 
 	for _, kv := range *kvs {
 		cmd.Printf("Key: %s, Value: %s\n", kv.Key, kv.Value)
@@ -0,0 +1,112 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"strings"
+
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// createUpstreamOptions defines flags for the `cli upstream create` command.
+type createUpstreamOptions struct {
+	apiClient apiv2client.APIV2Interface
+
+	namespace string
+	name      string
+	pdAddrs   string
+	caPath    string
+	certPath  string
+	keyPath   string
+}
+
+// newCreateUpstreamOptions creates new options for the `cli upstream create` command.
+func newCreateUpstreamOptions() *createUpstreamOptions {
+	return &createUpstreamOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *createUpstreamOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Upstream Namespace")
+	cmd.PersistentFlags().StringVar(&o.name, "name", "", "Name of the upstream, unique in a namespace")
+	cmd.PersistentFlags().StringVar(&o.pdAddrs, "upstream-pd", "",
+		"upstream PD address, use ',' to separate multiple PDs")
+	cmd.PersistentFlags().StringVar(&o.caPath, "upstream-ca", "",
+		"CA certificate path for TLS connection to upstream")
+	cmd.PersistentFlags().StringVar(&o.certPath, "upstream-cert", "",
+		"Certificate path for TLS connection to upstream")
+	cmd.PersistentFlags().StringVar(&o.keyPath, "upstream-key", "",
+		"Private key path for TLS connection to upstream")
+	_ = cmd.MarkPersistentFlagRequired("name")
+	_ = cmd.MarkPersistentFlagRequired("upstream-pd")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *createUpstreamOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// run the `cli upstream create` command.
+func (o *createUpstreamOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	cfg := &v2.CreateUpstreamConfig{
+		Name: o.name,
+		PDConfig: v2.PDConfig{
+			PDAddrs:  strings.Split(o.pdAddrs, ","),
+			CAPath:   o.caPath,
+			CertPath: o.certPath,
+			KeyPath:  o.keyPath,
+		},
+	}
+	up, err := o.apiClient.Upstreams().Create(ctx, o.namespace, cfg)
+	if err != nil {
+		return err
+	}
+	// The v2 API models are printed if the output format is specified.
+	if util.GetOutputFormat(cmd) != "" {
+		return util.PrintOutput(cmd, up)
+	}
+	cmd.Printf("Create upstream successfully!\nID: %d\nName: %s\n", up.ID, up.Name)
+	return nil
+}
+
+// newCmdCreateUpstream creates the `cli upstream create` command.
+func newCmdCreateUpstream(f factory.Factory) *cobra.Command {
+	o := newCreateUpstreamOptions()
+
+	command := &cobra.Command{
+		Use:   "create",
+		Short: "Register an upstream with a name",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// listUpstreamOptions defines flags for the `cli upstream list` command.
+type listUpstreamOptions struct {
+	apiClient apiv2client.APIV2Interface
+	namespace string
+}
+
+// newListUpstreamOptions creates new options for the `cli upstream list` command.
+func newListUpstreamOptions() *listUpstreamOptions {
+	return &listUpstreamOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *listUpstreamOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Upstream Namespace")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *listUpstreamOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// run the `cli upstream list` command.
+func (o *listUpstreamOptions) run(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.apiClient.Upstreams().List(ctx, o.namespace)
+	})
+}
+
+// newCmdListUpstream creates the `cli upstream list` command.
+func newCmdListUpstream(f factory.Factory) *cobra.Command {
+	o := newListUpstreamOptions()
+
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List all upstreams and the changefeeds replicating from them",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
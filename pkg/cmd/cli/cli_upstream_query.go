@@ -0,0 +1,107 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	apiv2client "github.com/pingcap/tiflow/pkg/api/v2"
+	"github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/factory"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// queryUpstreamOptions defines flags for the `cli upstream health` and
+// `cli upstream gc-safepoint` commands.
+type queryUpstreamOptions struct {
+	apiClient  apiv2client.APIV2Interface
+	namespace  string
+	upstreamID uint64
+}
+
+// newQueryUpstreamOptions creates new options for the upstream query commands.
+func newQueryUpstreamOptions() *queryUpstreamOptions {
+	return &queryUpstreamOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *queryUpstreamOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Upstream Namespace")
+	cmd.PersistentFlags().Uint64VarP(&o.upstreamID, "upstream-id", "u", 0, "Upstream ID")
+	_ = cmd.MarkPersistentFlagRequired("upstream-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *queryUpstreamOptions) complete(f factory.Factory) error {
+	client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClient = client
+	return nil
+}
+
+// runHealth runs the `cli upstream health` command.
+func (o *queryUpstreamOptions) runHealth(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.apiClient.Upstreams().Health(ctx, o.namespace, o.upstreamID)
+	})
+}
+
+// runGCSafePoint runs the `cli upstream gc-safepoint` command.
+func (o *queryUpstreamOptions) runGCSafePoint(cmd *cobra.Command) error {
+	ctx := context.GetDefaultContext()
+	return util.Watch(ctx, cmd, func() (interface{}, error) {
+		return o.apiClient.Upstreams().GCSafePoint(ctx, o.namespace, o.upstreamID)
+	})
+}
+
+// newCmdHealthUpstream creates the `cli upstream health` command.
+func newCmdHealthUpstream(f factory.Factory) *cobra.Command {
+	o := newQueryUpstreamOptions()
+
+	command := &cobra.Command{
+		Use:   "health",
+		Short: "Check the health of the PD members and the TSO of an upstream",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.runHealth(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
+
+// newCmdGCSafePointUpstream creates the `cli upstream gc-safepoint` command.
+func newCmdGCSafePointUpstream(f factory.Factory) *cobra.Command {
+	o := newQueryUpstreamOptions()
+
+	command := &cobra.Command{
+		Use:   "gc-safepoint",
+		Short: "Show the GC safepoint and the service GC safepoints of an upstream",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.runGCSafePoint(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pingcap/errors"
+	v2 "github.com/pingcap/tiflow/cdc/api/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamListCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+	cmd := newCmdListUpstream(f)
+	f.upstreams.EXPECT().List(gomock.Any(), "default").Return([]v2.Upstream{
+		{ID: 1, IsDefault: true, Changefeeds: []string{"a"}},
+		{ID: 2, Name: "up1"},
+	}, nil)
+	os.Args = []string{"list"}
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.Nil(t, cmd.Execute())
+	require.Contains(t, b.String(), `"name": "up1"`)
+
+	f.upstreams.EXPECT().List(gomock.Any(), "default").Return(nil, errors.New("test"))
+	o := newListUpstreamOptions()
+	o.namespace = "default"
+	require.NoError(t, o.complete(f))
+	require.NotNil(t, o.run(cmd))
+}
+
+func TestUpstreamCreateCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+	cmd := newCmdCreateUpstream(f)
+	f.upstreams.EXPECT().Create(gomock.Any(), "default", &v2.CreateUpstreamConfig{
+		Name: "up1",
+		PDConfig: v2.PDConfig{
+			PDAddrs: []string{"http://a:2379", "http://b:2379"},
+			CAPath:  "ca",
+		},
+	}).Return(&v2.Upstream{ID: 2, Name: "up1"}, nil)
+	os.Args = []string{
+		"create", "--name=up1",
+		"--upstream-pd=http://a:2379,http://b:2379", "--upstream-ca=ca",
+	}
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.Nil(t, cmd.Execute())
+	require.Contains(t, b.String(), "Create upstream successfully")
+
+	f.upstreams.EXPECT().Create(gomock.Any(), "default", gomock.Any()).
+		Return(nil, errors.New("test"))
+	o := newCreateUpstreamOptions()
+	o.namespace = "default"
+	o.pdAddrs = "http://a:2379"
+	require.NoError(t, o.complete(f))
+	require.NotNil(t, o.run(cmd))
+}
+
+func TestUpstreamRemoveCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+	cmd := newCmdRemoveUpstream(f)
+	f.upstreams.EXPECT().Delete(gomock.Any(), "default", uint64(2)).Return(nil)
+	os.Args = []string{"remove", "-u", "2"}
+	require.Nil(t, cmd.Execute())
+
+	f.upstreams.EXPECT().Delete(gomock.Any(), "default", uint64(3)).
+		Return(errors.New("ErrUpstreamInUse"))
+	o := newRemoveUpstreamOptions()
+	o.namespace = "default"
+	o.upstreamID = 3
+	require.NoError(t, o.complete(f))
+	require.NotNil(t, o.run(cmd))
+}
+
+func TestUpstreamQueryCli(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	f := newMockFactory(ctrl)
+
+	cmd := newCmdHealthUpstream(f)
+	f.upstreams.EXPECT().Health(gomock.Any(), "default", uint64(2)).
+		Return(&v2.UpstreamHealth{ID: 2, Healthy: true}, nil)
+	os.Args = []string{"health", "-u", "2"}
+	require.Nil(t, cmd.Execute())
+
+	cmd = newCmdGCSafePointUpstream(f)
+	f.upstreams.EXPECT().GCSafePoint(gomock.Any(), "default", uint64(2)).
+		Return(&v2.UpstreamGCSafePoint{ID: 2, GCSafePoint: 100}, nil)
+	os.Args = []string{"gc-safepoint", "-u", "2"}
+	require.Nil(t, cmd.Execute())
+
+	o := newQueryUpstreamOptions()
+	o.namespace = "default"
+	o.upstreamID = 2
+	require.NoError(t, o.complete(f))
+	f.upstreams.EXPECT().Health(gomock.Any(), "default", uint64(2)).
+		Return(nil, errors.New("test"))
+	require.NotNil(t, o.runHealth(cmd))
+	f.upstreams.EXPECT().GCSafePoint(gomock.Any(), "default", uint64(2)).
+		Return(nil, errors.New("test"))
+	require.NotNil(t, o.runGCSafePoint(cmd))
+}
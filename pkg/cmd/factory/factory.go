@@ -39,6 +39,7 @@ const (
 	// User Credential Environment Variables
 	envVarTiCDCUser     = "TICDC_USER"
 	envVarTiCDCPassword = "TICDC_PASSWORD"
+	envVarTiCDCToken    = "TICDC_TOKEN"
 	// TLS Client Certificate Environment Variables
 	envVarTiCDCCAPath   = "TICDC_CA_PATH"
 	envVarTiCDCCertPath = "TICDC_CERT_PATH"
@@ -69,6 +70,8 @@ type ClientAuth struct {
 	// User Credential
 	User     string `toml:"ticdc_user,omitempty"`
 	Password string `toml:"ticdc_password,omitempty"`
+	// Token is the bearer token, it takes precedence over the user credential.
+	Token string `toml:"ticdc_token,omitempty"`
 
 	// TLS Client Certificate
 	CaPath   string `toml:"ca_path,omitempty"`
@@ -202,6 +205,8 @@ func (c *ClientFlags) AddFlags(cmd *cobra.Command) {
 		"You can sqpecify it via environment variable TICDC_USER")
 	cmd.PersistentFlags().StringVar(&c.Password, "password", "", "Password for authentication. "+
 		"You can specify it via environment variable TICDC_PASSWORD")
+	cmd.PersistentFlags().StringVar(&c.Token, "token", "", "Bearer token for authentication. "+
+		"You can specify it via environment variable TICDC_TOKEN")
 }
 
 // GetCredential returns credential.
@@ -232,6 +237,11 @@ func (c *ClientFlags) completeUserCredential(cmd *cobra.Command) (err error) {
 		}
 		log.Info(fmt.Sprintf("cli authentication type: %s", authType))
 	}()
+	// The bearer token takes precedence over the user credential.
+	if c.Token != "" {
+		authType = "command line token"
+		return nil
+	}
 	// If user is specified via command line, password should be specified as well.
 	if c.User != "" {
 		if c.Password == "" {
@@ -250,7 +260,8 @@ func (c *ClientFlags) completeUserCredential(cmd *cobra.Command) (err error) {
 	authType = "environment variable"
 	c.User = os.Getenv(envVarTiCDCUser)
 	c.Password = os.Getenv(envVarTiCDCPassword)
-	if c.User != "" {
+	c.Token = os.Getenv(envVarTiCDCToken)
+	if c.User != "" || c.Token != "" {
 		return nil
 	}
 
@@ -263,6 +274,7 @@ func (c *ClientFlags) completeUserCredential(cmd *cobra.Command) (err error) {
 	if res != nil {
 		c.User = res.User
 		c.Password = res.Password
+		c.Token = res.Token
 	}
 	return nil
 }
@@ -304,6 +316,11 @@ func (c *ClientFlags) completeTLSClientCertificate(cmd *cobra.Command) {
 
 // GetAuthParameters returns the authentication parameters.
 func (c *ClientFlags) GetAuthParameters() url.Values {
+	if c.Token != "" {
+		return url.Values{
+			api.APIOpVarTiCDCToken: {c.Token},
+		}
+	}
 	if c.User == "" {
 		return nil
 	}
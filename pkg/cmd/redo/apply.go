@@ -35,6 +35,7 @@ type applyRedoOptions struct {
 	sinkURI              string
 	enableProfiling      bool
 	memoryLimitInGiBytes int64
+	schemaHistory        string
 }
 
 // newapplyRedoOptions creates new applyRedoOptions for the `redo apply` command.
@@ -50,6 +51,8 @@ func (o *applyRedoOptions) addFlags(cmd *cobra.Command) {
 	cmd.MarkFlagRequired("sink-uri") //nolint:errcheck
 	cmd.Flags().BoolVar(&o.enableProfiling, "enable-profiling", true, "enable pprof profiling")
 	cmd.Flags().Int64Var(&o.memoryLimitInGiBytes, "memory-limit", 10, "memory limit in GiB")
+	cmd.Flags().StringVar(&o.schemaHistory, "schema-history", "",
+		"schema history file exported from the changefeed, used to restore the table info of DDLs")
 }
 
 //nolint:unparam
@@ -99,9 +102,10 @@ func (o *applyRedoOptions) run(cmd *cobra.Command) error {
 	}
 
 	cfg := &applier.RedoApplierConfig{
-		Storage: o.storage,
-		SinkURI: o.sinkURI,
-		Dir:     o.dir,
+		Storage:       o.storage,
+		SinkURI:       o.sinkURI,
+		Dir:           o.dir,
+		SchemaHistory: o.schemaHistory,
 	}
 	ap := applier.NewRedoApplier(cfg)
 	err := ap.Apply(ctx)
@@ -186,6 +186,8 @@ func TestParseCfg(t *testing.T) {
 				ClientMaxBatchSize:           64 * 1024 * 1024,
 				ClientMaxBatchCount:          1024,
 				ClientRetryRateLimit:         1.0,
+				ClientCompression:            "snappy",
+				ClientBatchRTTThreshold:      config.TomlDuration(time.Millisecond * 5),
 				ServerMaxPendingMessageCount: 102400,
 				ServerAckInterval:            config.TomlDuration(time.Millisecond * 100),
 				ServerWorkerPoolSize:         8,
@@ -261,6 +263,8 @@ client-max-batch-interval = "500ms"
 client-max-batch-size = 999
 client-max-batch-count = 888
 client-retry-rate-limit = 100.0
+client-compression = "lz4"
+client-batch-rtt-threshold = "20ms"
 server-max-pending-message-count = 1024
 server-ack-interval = "1s"
 server-worker-pool-size = 16
@@ -333,6 +337,8 @@ check-balance-interval = "10s"
 				ClientMaxBatchSize:           999,
 				ClientMaxBatchCount:          888,
 				ClientRetryRateLimit:         100.0,
+				ClientCompression:            "lz4",
+				ClientBatchRTTThreshold:      config.TomlDuration(20 * time.Millisecond),
 				ServerMaxPendingMessageCount: 1024,
 				ServerAckInterval:            config.TomlDuration(1 * time.Second),
 				ServerWorkerPoolSize:         16,
@@ -472,6 +478,8 @@ cert-allowed-cn = ["dd","ee"]
 				ClientMaxBatchSize:           64 * 1024 * 1024,
 				ClientMaxBatchCount:          1024,
 				ClientRetryRateLimit:         1.0,
+				ClientCompression:            "snappy",
+				ClientBatchRTTThreshold:      config.TomlDuration(time.Millisecond * 5),
 				ServerMaxPendingMessageCount: 102400,
 				ServerAckInterval:            config.TomlDuration(time.Millisecond * 100),
 				ServerWorkerPoolSize:         8,
@@ -537,6 +545,8 @@ unknown3 = 3
 			ClientMaxBatchSize:           64 * 1024 * 1024,
 			ClientMaxBatchCount:          1024,
 			ClientRetryRateLimit:         1.0,
+			ClientCompression:            "snappy",
+			ClientBatchRTTThreshold:      config.TomlDuration(time.Millisecond * 5),
 			ServerMaxPendingMessageCount: 102400,
 			ServerAckInterval:            config.TomlDuration(time.Millisecond * 100),
 			ServerWorkerPoolSize:         8,
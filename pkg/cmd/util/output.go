@@ -0,0 +1,441 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// OutputFlag is the name of the global flag of the output format.
+	OutputFlag = "output"
+	// WatchFlag is the name of the global flag of the watch mode.
+	WatchFlag = "watch"
+	// WatchIntervalFlag is the name of the global flag of the query interval
+	// in the watch mode.
+	WatchIntervalFlag = "watch-interval"
+)
+
+// The output formats of the cli commands.
+const (
+	OutputFormatJSON  = "json"
+	OutputFormatYAML  = "yaml"
+	OutputFormatTable = "table"
+)
+
+// outputFormat implements pflag.Value to validate the output format.
+type outputFormat string
+
+func (f *outputFormat) String() string {
+	return string(*f)
+}
+
+func (f *outputFormat) Set(s string) error {
+	switch s {
+	case OutputFormatJSON, OutputFormatYAML, OutputFormatTable:
+		*f = outputFormat(s)
+		return nil
+	}
+	return errors.Errorf("invalid output format %s, it should be one of json, yaml and table", s)
+}
+
+func (f *outputFormat) Type() string {
+	return "string"
+}
+
+// AddOutputFlags adds the global flags controlling the output of the
+// cli commands to cmd.
+func AddOutputFlags(cmd *cobra.Command) {
+	format := outputFormat("")
+	cmd.PersistentFlags().VarP(&format, OutputFlag, "o",
+		"Output format, one of json, yaml and table. If it's not set, "+
+			"most commands print json and the others print messages")
+	cmd.PersistentFlags().Bool(WatchFlag, false,
+		"Keep querying and print the result only when it changes, "+
+			"one result per line in json and one document per result in yaml")
+	cmd.PersistentFlags().Duration(WatchIntervalFlag, 2*time.Second,
+		"Interval of querying in the watch mode")
+}
+
+// GetOutputFormat returns the output format given by the --output flag,
+// it's empty if the flag is not set.
+func GetOutputFormat(cmd *cobra.Command) string {
+	if flag := cmd.Flags().Lookup(OutputFlag); flag != nil {
+		return flag.Value.String()
+	}
+	return ""
+}
+
+// getWatchOptions returns whether the --watch flag is set and the interval.
+func getWatchOptions(cmd *cobra.Command) (bool, time.Duration) {
+	watch, err := cmd.Flags().GetBool(WatchFlag)
+	if err != nil || !watch {
+		return false, 0
+	}
+	interval, err := cmd.Flags().GetDuration(WatchIntervalFlag)
+	if err != nil || interval <= 0 {
+		interval = 2 * time.Second
+	}
+	return true, interval
+}
+
+// PrintOutput prints v in the format given by the --output flag, it's
+// printed in json if the flag is not set.
+func PrintOutput(cmd *cobra.Command, v interface{}) error {
+	out, err := formatOutput(v, GetOutputFormat(cmd), false)
+	if err != nil {
+		return err
+	}
+	cmd.Print(out)
+	return nil
+}
+
+// Printer prints the results of a query which is run repeatedly, the results
+// which are the same as the last one are skipped if the --watch flag is set.
+type Printer struct {
+	cmd         *cobra.Command
+	format      string
+	onlyChanges bool
+
+	last    string
+	printed bool
+}
+
+// NewPrinter creates a Printer with the output flags of cmd.
+func NewPrinter(cmd *cobra.Command) *Printer {
+	watch, _ := getWatchOptions(cmd)
+	return &Printer{
+		cmd:         cmd,
+		format:      GetOutputFormat(cmd),
+		onlyChanges: watch,
+	}
+}
+
+// Print prints v if it needs to be printed.
+func (p *Printer) Print(v interface{}) error {
+	out, err := formatOutput(v, p.format, p.onlyChanges)
+	if err != nil {
+		return err
+	}
+	if p.onlyChanges && p.printed && out == p.last {
+		return nil
+	}
+	if p.printed && p.format == OutputFormatTable {
+		p.cmd.Println()
+	}
+	p.last, p.printed = out, true
+	p.cmd.Print(out)
+	return nil
+}
+
+// Watch prints the result of fetch in the format given by the --output flag.
+// If the --watch flag is set, it keeps calling fetch every --watch-interval
+// and prints the result when it changes until ctx is done.
+func Watch(ctx context.Context, cmd *cobra.Command, fetch func() (interface{}, error)) error {
+	watch, interval := getWatchOptions(cmd)
+	if !watch {
+		v, err := fetch()
+		if err != nil {
+			return err
+		}
+		return PrintOutput(cmd, v)
+	}
+
+	printer := NewPrinter(cmd)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		v, err := fetch()
+		if err != nil {
+			// Keep watching, the error may be transient, e.g. the owner is moved.
+			cmd.PrintErrf("[WARN] %s\n", err)
+		} else if err := printer.Print(v); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatOutput formats v in the given format, the output is made compact
+// if it's a part of a stream.
+func formatOutput(v interface{}, format string, stream bool) (string, error) {
+	switch format {
+	case OutputFormatYAML, OutputFormatTable:
+		value, err := toOrderedValue(v)
+		if err != nil {
+			return "", err
+		}
+		if format == OutputFormatTable {
+			return formatTable(value), nil
+		}
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if stream {
+			return "---\n" + string(data), nil
+		}
+		return string(data), nil
+	default:
+		var data []byte
+		var err error
+		if stream {
+			data, err = json.Marshal(v)
+		} else {
+			data, err = json.MarshalIndent(v, "", "  ")
+		}
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return string(data) + "\n", nil
+	}
+}
+
+// toOrderedValue converts v to a value made of yaml.MapSlice, []interface{}
+// and scalars through its json encoding, so that the names and the order of
+// the fields are the same in all output formats.
+func toOrderedValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeOrderedValue(dec)
+}
+
+func decodeOrderedValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		var res interface{}
+		switch t {
+		case '{':
+			fields := yaml.MapSlice{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				value, err := decodeOrderedValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, yaml.MapItem{Key: key, Value: value})
+			}
+			res = fields
+		case '[':
+			items := []interface{}{}
+			for dec.More() {
+				item, err := decodeOrderedValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			res = items
+		default:
+			return nil, errors.Errorf("unexpected json delimiter %s", t)
+		}
+		// consume the closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return res, nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(t.String(), 10, 64); err == nil {
+			return u, nil
+		}
+		f, err := t.Float64()
+		return f, errors.Trace(err)
+	default:
+		// string, bool and nil.
+		return tok, nil
+	}
+}
+
+// formatTable formats an ordered value as a table. A list of objects has a
+// column per field, and an object has a row per field whose nested fields
+// are flattened as a.b.c.
+func formatTable(value interface{}) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	switch v := value.(type) {
+	case []interface{}:
+		columns := tableColumns(v)
+		if len(columns) == 0 {
+			for _, item := range v {
+				fmt.Fprintln(w, formatCell(item))
+			}
+			break
+		}
+		header := make([]string, 0, len(columns))
+		for _, column := range columns {
+			header = append(header, strings.ToUpper(column))
+		}
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+		for _, item := range v {
+			fields, _ := item.(yaml.MapSlice)
+			row := make([]string, 0, len(columns))
+			for _, column := range columns {
+				row = append(row, formatCell(lookupField(fields, column)))
+			}
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+	case yaml.MapSlice:
+		fmt.Fprintln(w, "FIELD\tVALUE")
+		flattenFields("", v, func(name string, value interface{}) {
+			fmt.Fprintf(w, "%s\t%s\n", name, formatCell(value))
+		})
+	default:
+		fmt.Fprintln(w, formatCell(v))
+	}
+	_ = w.Flush()
+	// Trim the padding of the empty cells at the end of the rows.
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// tableColumns returns the names of the fields of the objects in items in
+// the order they first appear.
+func tableColumns(items []interface{}) []string {
+	var columns []string
+	seen := make(map[string]struct{})
+	for _, item := range items {
+		fields, ok := item.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		for _, field := range fields {
+			name := fmt.Sprint(field.Key)
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				columns = append(columns, name)
+			}
+		}
+	}
+	return columns
+}
+
+func lookupField(fields yaml.MapSlice, name string) interface{} {
+	for _, field := range fields {
+		if fmt.Sprint(field.Key) == name {
+			return field.Value
+		}
+	}
+	return nil
+}
+
+// flattenFields calls fn with the flattened name of each non-object field,
+// the lists of objects are flattened with the indexes.
+func flattenFields(prefix string, fields yaml.MapSlice, fn func(string, interface{})) {
+	for _, field := range fields {
+		name := fmt.Sprint(field.Key)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		switch v := field.Value.(type) {
+		case yaml.MapSlice:
+			if len(v) == 0 {
+				fn(name, v)
+				continue
+			}
+			flattenFields(name, v, fn)
+		case []interface{}:
+			if len(tableColumns(v)) == 0 {
+				fn(name, v)
+				continue
+			}
+			for i, item := range v {
+				itemName := name + "." + strconv.Itoa(i)
+				if itemFields, ok := item.(yaml.MapSlice); ok && len(itemFields) > 0 {
+					flattenFields(itemName, itemFields, fn)
+				} else {
+					fn(itemName, item)
+				}
+			}
+		default:
+			fn(name, v)
+		}
+	}
+}
+
+// formatCell formats a value in a table cell, the lists and objects are
+// formatted in compact json.
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.NewReplacer("\t", " ", "\n", "\\n").Replace(v)
+	case yaml.MapSlice, []interface{}:
+		var b strings.Builder
+		writeCompactJSON(&b, v)
+		return b.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func writeCompactJSON(b *strings.Builder, value interface{}) {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		b.WriteByte('{')
+		for i, field := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeCompactJSON(b, fmt.Sprint(field.Key))
+			b.WriteByte(':')
+			writeCompactJSON(b, field.Value)
+		}
+		b.WriteByte('}')
+	case []interface{}:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeCompactJSON(b, item)
+		}
+		b.WriteByte(']')
+	default:
+		data, _ := json.Marshal(v)
+		b.Write(data)
+	}
+}
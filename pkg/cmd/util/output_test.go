@@ -0,0 +1,154 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+type testOutputItem struct {
+	ID      string            `json:"id"`
+	Count   uint64            `json:"count"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Details *testOutputDetail `json:"details,omitempty"`
+}
+
+type testOutputDetail struct {
+	State string `json:"state"`
+}
+
+func newTestOutputCommand(t *testing.T, args ...string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{Use: "test"}
+	AddOutputFlags(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	buf := bytes.NewBuffer(nil)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	return cmd, buf
+}
+
+func TestPrintOutput(t *testing.T) {
+	t.Parallel()
+
+	items := []testOutputItem{
+		{ID: "a", Count: 1, Details: &testOutputDetail{State: "normal"}},
+		{ID: "b", Count: 18446744073709551615},
+	}
+
+	cmd, buf := newTestOutputCommand(t)
+	require.Equal(t, "", GetOutputFormat(cmd))
+	require.NoError(t, PrintOutput(cmd, items[0]))
+	require.Equal(t, `{
+  "id": "a",
+  "count": 1,
+  "details": {
+    "state": "normal"
+  }
+}
+`, buf.String())
+
+	cmd, buf = newTestOutputCommand(t, "--output=yaml")
+	require.NoError(t, PrintOutput(cmd, items))
+	require.Equal(t, `- id: a
+  count: 1
+  details:
+    state: normal
+- id: b
+  count: 18446744073709551615
+`, buf.String())
+
+	cmd, buf = newTestOutputCommand(t, "-o", "table")
+	require.NoError(t, PrintOutput(cmd, items))
+	require.Equal(t, `ID  COUNT                 DETAILS
+a   1                     {"state":"normal"}
+b   18446744073709551615
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, PrintOutput(cmd, items[0]))
+	require.Equal(t, `FIELD          VALUE
+id             a
+count          1
+details.state  normal
+`, buf.String())
+
+	cmd = &cobra.Command{Use: "test"}
+	AddOutputFlags(cmd)
+	err := cmd.ParseFlags([]string{"--output=xml"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid output format xml")
+}
+
+func TestPrinterOnlyChanges(t *testing.T) {
+	t.Parallel()
+
+	cmd, buf := newTestOutputCommand(t, "--watch")
+	printer := NewPrinter(cmd)
+	require.NoError(t, printer.Print(testOutputItem{ID: "a", Count: 1}))
+	require.NoError(t, printer.Print(testOutputItem{ID: "a", Count: 1}))
+	require.NoError(t, printer.Print(testOutputItem{ID: "a", Count: 2}))
+	require.Equal(t, `{"id":"a","count":1}
+{"id":"a","count":2}
+`, buf.String())
+
+	// Everything is printed without the --watch flag.
+	cmd, buf = newTestOutputCommand(t)
+	printer = NewPrinter(cmd)
+	require.NoError(t, printer.Print(testOutputItem{ID: "a", Count: 1}))
+	require.NoError(t, printer.Print(testOutputItem{ID: "a", Count: 1}))
+	require.Equal(t, 2, strings.Count(buf.String(), `"id": "a"`))
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	cmd, buf := newTestOutputCommand(t)
+	calls := 0
+	require.NoError(t, Watch(context.Background(), cmd, func() (interface{}, error) {
+		calls++
+		return testOutputItem{ID: "a"}, nil
+	}))
+	require.Equal(t, 1, calls)
+	require.Contains(t, buf.String(), `"id": "a"`)
+
+	cmd, buf = newTestOutputCommand(t, "--output=yaml", "--watch", "--watch-interval=10ms")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls = 0
+	require.NoError(t, Watch(ctx, cmd, func() (interface{}, error) {
+		calls++
+		switch {
+		case calls == 2:
+			return nil, context.DeadlineExceeded
+		case calls >= 4:
+			cancel()
+			return testOutputItem{ID: "b"}, nil
+		}
+		return testOutputItem{ID: "a"}, nil
+	}))
+	require.Equal(t, `---
+id: a
+count: 0
+[WARN] context deadline exceeded
+---
+id: b
+count: 0
+`, buf.String())
+}
@@ -130,6 +130,7 @@ const (
   "sorter": {
     "sort-dir": "/tmp/sorter",
     "cache-size-in-mb": 128,
+    "max-disk-usage-in-mb": 0,
     "max-memory-percentage": 0,
     "max-memory-consumption": 0,
     "num-workerpool-goroutine": 0,
@@ -170,6 +171,8 @@ const (
       "client-max-batch-size": 67108864,
       "client-max-batch-count": 1024,
       "client-retry-rate-limit": 1,
+      "client-compression": "snappy",
+      "client-batch-rtt-threshold": 5000000,
       "server-max-pending-message-count": 102400,
       "server-ack-interval": 100000000,
       "server-worker-pool-size": 8,
@@ -201,6 +204,7 @@ const (
   },
   "cluster-id": "default",
   "gc-tuner-memory-threshold": 0,
+  "allow-hook-command": false,
   "per-table-memory-quota": 0,
   "max-memory-percentage": 0
 }`
@@ -35,4 +35,11 @@ type EventFilterRule struct {
 	IgnoreUpdateNewValueExpr string `toml:"ignore-update-new-value-expr" json:"ignore-update-new-value-expr"`
 	IgnoreUpdateOldValueExpr string `toml:"ignore-update-old-value-expr" json:"ignore-update-old-value-expr"`
 	IgnoreDeleteValueExpr    string `toml:"ignore-delete-value-expr" json:"ignore-delete-value-expr"`
+	// IgnoreUpdateChangedColumns ignores an update event if all the columns
+	// changed by it are in this list, e.g. ["last_seen_at"].
+	IgnoreUpdateChangedColumns []string `toml:"ignore-update-changed-columns" json:"ignore-update-changed-columns"`
+	// IgnoreDDLExpr is a sql expression evaluated on the DDL metadata,
+	// the available columns are schema_name, table_name, ddl_type and query,
+	// ddl_type is the name of the TiDB DDL action type, such as "add index".
+	IgnoreDDLExpr string `toml:"ignore-ddl-expr" json:"ignore-ddl-expr"`
 }
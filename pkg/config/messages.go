@@ -16,6 +16,7 @@ package config
 import (
 	"time"
 
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/p2p"
 )
@@ -28,6 +29,13 @@ type MessagesConfig struct {
 	ClientMaxBatchSize     int          `toml:"client-max-batch-size" json:"client-max-batch-size"`
 	ClientMaxBatchCount    int          `toml:"client-max-batch-count" json:"client-max-batch-count"`
 	ClientRetryRateLimit   float64      `toml:"client-retry-rate-limit" json:"client-retry-rate-limit"`
+	// ClientCompression is the compression proposed to the peers for message
+	// batches. It's used only if the peer supports it as well.
+	ClientCompression string `toml:"client-compression" json:"client-compression"`
+	// ClientBatchRTTThreshold is the round-trip time to a peer at which the
+	// message batches are allowed to reach the max size and count. Batches are
+	// flushed earlier to peers with a lower round-trip time.
+	ClientBatchRTTThreshold TomlDuration `toml:"client-batch-rtt-threshold" json:"client-batch-rtt-threshold"`
 
 	ServerMaxPendingMessageCount int          `toml:"server-max-pending-message-count" json:"server-max-pending-message-count"`
 	ServerAckInterval            TomlDuration `toml:"server-ack-interval" json:"server-ack-interval"`
@@ -52,6 +60,8 @@ var defaultMessageConfig = &MessagesConfig{
 	ClientMaxBatchSize:           64 * 1024 * 1024, // 64MB
 	ClientMaxBatchCount:          1024,
 	ClientRetryRateLimit:         1.0, // Once per second
+	ClientCompression:            compression.Snappy,
+	ClientBatchRTTThreshold:      TomlDuration(time.Millisecond * 5),
 	ServerMaxPendingMessageCount: 102400,
 	ServerAckInterval:            TomlDuration(time.Millisecond * 100),
 	ServerWorkerPoolSize:         8,
@@ -118,6 +128,21 @@ func (c *MessagesConfig) ValidateAndAdjust() error {
 		c.ClientRetryRateLimit = defaultMessageConfig.ClientRetryRateLimit
 	}
 
+	if c.ClientCompression == "" {
+		c.ClientCompression = defaultMessageConfig.ClientCompression
+	}
+	if !compression.Supported(c.ClientCompression) {
+		return cerrors.ErrInvalidServerOption.GenWithStackByArgs(
+			"client-compression " + c.ClientCompression + " is not supported")
+	}
+	if c.ClientBatchRTTThreshold == 0 {
+		c.ClientBatchRTTThreshold = defaultMessageConfig.ClientBatchRTTThreshold
+	}
+	if c.ClientBatchRTTThreshold < 0 {
+		return cerrors.ErrInvalidServerOption.GenWithStackByArgs(
+			"client-batch-rtt-threshold must not be negative")
+	}
+
 	if c.ServerMaxPendingMessageCount <= 0 {
 		c.ServerMaxPendingMessageCount = defaultMessageConfig.ServerMaxPendingMessageCount
 	}
@@ -161,6 +186,8 @@ func (c *MessagesConfig) Clone() *MessagesConfig {
 		ClientMaxBatchSize:           c.ClientMaxBatchSize,
 		ClientMaxBatchCount:          c.ClientMaxBatchCount,
 		ClientRetryRateLimit:         c.ClientRetryRateLimit,
+		ClientCompression:            c.ClientCompression,
+		ClientBatchRTTThreshold:      c.ClientBatchRTTThreshold,
 		ServerMaxPendingMessageCount: c.ServerMaxPendingMessageCount,
 		ServerAckInterval:            c.ServerAckInterval,
 		ServerWorkerPoolSize:         c.ServerWorkerPoolSize,
@@ -180,6 +207,8 @@ func (c *MessagesConfig) ToMessageClientConfig() *p2p.MessageClientConfig {
 		RetryRateLimitPerSecond: c.ClientRetryRateLimit,
 		DialTimeout:             clientDialTimeout,
 		MaxRecvMsgSize:          c.MaxRecvMsgSize,
+		Compression:             c.ClientCompression,
+		BatchRTTThreshold:       time.Duration(c.ClientBatchRTTThreshold),
 	}
 }
 
@@ -80,4 +80,16 @@ func TestMessagesConfigValidateAndAdjust(t *testing.T) {
 	illegalConfig.MaxRecvMsgSize = -1
 	err = illegalConfig.ValidateAndAdjust()
 	require.Error(t, err)
+
+	illegalConfig = defaultMessageConfig.Clone()
+	illegalConfig.ClientCompression = "zstd"
+	err = illegalConfig.ValidateAndAdjust()
+	require.Error(t, err)
+	require.Regexp(t, ".*ErrInvalidServerOption.*", err.Error())
+
+	illegalConfig = defaultMessageConfig.Clone()
+	illegalConfig.ClientBatchRTTThreshold = TomlDuration(-time.Millisecond)
+	err = illegalConfig.ValidateAndAdjust()
+	require.Error(t, err)
+	require.Regexp(t, ".*ErrInvalidServerOption.*", err.Error())
 }
@@ -134,6 +134,10 @@ type replicaConfig struct {
 	CaseSensitive    bool   `toml:"case-sensitive" json:"case-sensitive"`
 	ForceReplicate   bool   `toml:"force-replicate" json:"force-replicate"`
 	CheckGCSafePoint bool   `toml:"check-gc-safe-point" json:"check-gc-safe-point"`
+	// SorterDiskQuota is the max bytes of events that the changefeed can store
+	// in the sorter of each capture, the puller of the changefeed is blocked
+	// when it's exceeded. 0 means no limit.
+	SorterDiskQuota uint64 `toml:"sorter-disk-quota" json:"sorter-disk-quota,omitempty"`
 	// EnableSyncPoint is only available when the downstream is a Database.
 	EnableSyncPoint    *bool `toml:"enable-sync-point" json:"enable-sync-point,omitempty"`
 	EnableTableMonitor *bool `toml:"enable-table-monitor" json:"enable-table-monitor"`
@@ -156,10 +160,14 @@ type replicaConfig struct {
 	Consistent *ConsistentConfig `toml:"consistent" json:"consistent,omitempty"`
 	// Scheduler is the configuration for scheduler.
 	Scheduler *ChangefeedSchedulerConfig `toml:"scheduler" json:"scheduler"`
-	// Integrity is only available when the downstream is MQ.
+	// Integrity is only available when the downstream is Kafka or MySQL.
 	Integrity                    *integrity.Config   `toml:"integrity" json:"integrity"`
 	ChangefeedErrorStuckDuration *time.Duration      `toml:"changefeed-error-stuck-duration" json:"changefeed-error-stuck-duration,omitempty"`
 	SyncedStatus                 *SyncedStatusConfig `toml:"synced-status" json:"synced-status,omitempty"`
+	// Schedule pauses, resumes and delays the start of the changefeed by time.
+	Schedule *ScheduleConfig `toml:"schedule" json:"schedule,omitempty"`
+	// Hooks are notified when the state of the changefeed changes.
+	Hooks []*HookConfig `toml:"hooks" json:"hooks,omitempty"`
 
 	// Deprecated: we don't use this field since v8.0.0.
 	SQLMode string `toml:"sql-mode" json:"sql-mode"`
@@ -295,9 +303,11 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 	if c.Integrity != nil {
 		switch strings.ToLower(sinkURI.Scheme) {
 		case sink.KafkaScheme, sink.KafkaSSLScheme:
+		// the mysql sink verifies the checksum before writing if `verify-checksum` is set.
+		case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
 		default:
 			if c.Integrity.Enabled() {
-				log.Warn("integrity checksum only support kafka sink now, disable integrity")
+				log.Warn("integrity checksum only support kafka and mysql sink now, disable integrity")
 				c.Integrity.IntegrityCheckLevel = integrity.CheckLevelNone
 			}
 		}
@@ -314,6 +324,17 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 		}
 	}
 
+	if c.Schedule != nil {
+		if err := c.Schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, hook := range c.Hooks {
+		if err := hook.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if c.ChangefeedErrorStuckDuration != nil &&
 		*c.ChangefeedErrorStuckDuration < minChangeFeedErrorStuckDuration {
 		return cerror.ErrInvalidReplicaConfig.
@@ -359,4 +380,94 @@ func (c *ReplicaConfig) MaskSensitiveData() {
 	if c.Consistent != nil {
 		c.Consistent.MaskSensitiveData()
 	}
+	for _, hook := range c.Hooks {
+		hook.URL = util.MaskSensitiveDataInURI(hook.URL)
+	}
+}
+
+// sensitiveField is a field of ReplicaConfig which may contain secrets.
+type sensitiveField struct {
+	// name is the name of the template variable of the field.
+	name  string
+	value *string
+	// isURI is true if only the password and sensitive query parameters
+	// of the field are secrets.
+	isURI bool
+}
+
+// sensitiveFields returns the non-empty fields of ReplicaConfig which may
+// contain secrets.
+func (c *ReplicaConfig) sensitiveFields() []sensitiveField {
+	var fields []sensitiveField
+	add := func(name string, value *string, isURI bool) {
+		if value != nil && *value != "" {
+			fields = append(fields, sensitiveField{name: name, value: value, isURI: isURI})
+		}
+	}
+	if c.Sink != nil {
+		add("SCHEMA_REGISTRY", c.Sink.SchemaRegistry, true)
+		if k := c.Sink.KafkaConfig; k != nil {
+			add("KAFKA_SASL_PASSWORD", k.SASLPassword, false)
+			add("KAFKA_SASL_GSSAPI_PASSWORD", k.SASLGssAPIPassword, false)
+			add("KAFKA_SASL_OAUTH_CLIENT_SECRET", k.SASLOAuthClientSecret, false)
+			add("KAFKA_SASL_OAUTH_TOKEN_URL", k.SASLOAuthTokenURL, true)
+			add("KAFKA_KEY", k.Key, false)
+			if g := k.GlueSchemaRegistryConfig; g != nil {
+				add("GLUE_ACCESS_KEY", &g.AccessKey, false)
+				add("GLUE_SECRET_ACCESS_KEY", &g.SecretAccessKey, false)
+				add("GLUE_TOKEN", &g.Token, false)
+			}
+		}
+		if p := c.Sink.PulsarConfig; p != nil {
+			add("PULSAR_AUTHENTICATION_TOKEN", p.AuthenticationToken, false)
+			add("PULSAR_BASIC_PASSWORD", p.BasicPassword, false)
+			if p.OAuth2 != nil {
+				add("PULSAR_OAUTH2_PRIVATE_KEY", &p.OAuth2.OAuth2PrivateKey, false)
+			}
+		}
+	}
+	if c.Consistent != nil {
+		add("REDO_STORAGE", &c.Consistent.Storage, true)
+	}
+	for i, hook := range c.Hooks {
+		add(fmt.Sprintf("HOOK_%d_URL", i), &hook.URL, true)
+	}
+	return fields
+}
+
+// TemplateSensitiveData replaces the sensitive data in ReplicaConfig with
+// template variables like ${KAFKA_SASL_PASSWORD}, so that the config can be
+// exported without leaking secrets. It returns the names of the variables.
+func (c *ReplicaConfig) TemplateSensitiveData() ([]string, error) {
+	var names []string
+	for _, field := range c.sensitiveFields() {
+		if !field.isURI {
+			*field.value = "${" + field.name + "}"
+			names = append(names, field.name)
+			continue
+		}
+		templated, uriNames, err := util.TemplateSensitiveDataInURI(*field.value, field.name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		*field.value = templated
+		names = append(names, uriNames...)
+	}
+	return names, nil
+}
+
+// RenderSensitiveData replaces the template variables in ReplicaConfig with
+// the given values. It returns the names of the variables without a value.
+func (c *ReplicaConfig) RenderSensitiveData(values map[string]string) []string {
+	var missing []string
+	for _, field := range c.sensitiveFields() {
+		var fieldMissing []string
+		if field.isURI {
+			*field.value, fieldMissing = util.RenderURITemplate(*field.value, values)
+		} else {
+			*field.value, fieldMissing = util.RenderTemplate(*field.value, values)
+		}
+		missing = append(missing, fieldMissing...)
+	}
+	return missing
 }
@@ -420,3 +420,53 @@ func TestMaskSensitiveData(t *testing.T) {
 	require.Equal(t, "******", config.Sink.KafkaConfig.GlueSchemaRegistryConfig.Token)
 	require.Equal(t, "******", config.Sink.KafkaConfig.GlueSchemaRegistryConfig.AccessKey)
 }
+
+func TestTemplateSensitiveData(t *testing.T) {
+	t.Parallel()
+
+	config := GetDefaultReplicaConfig()
+	config.Sink.SchemaRegistry = aws.String("http://abc.com")
+	config.Sink.KafkaConfig = &KafkaConfig{
+		SASLOAuthTokenURL: aws.String("http://abc.com?password=bacd"),
+		SASLPassword:      aws.String("bacd"),
+		SASLUser:          aws.String("user"),
+		GlueSchemaRegistryConfig: &GlueSchemaRegistryConfig{
+			AccessKey:       "abc",
+			SecretAccessKey: "def",
+		},
+	}
+	config.Consistent.Storage = "s3://bucket/redo?access-key=abc&secret-access-key=def"
+
+	names, err := config.TemplateSensitiveData()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"KAFKA_SASL_PASSWORD",
+		"KAFKA_SASL_OAUTH_TOKEN_URL_PASSWORD",
+		"GLUE_ACCESS_KEY",
+		"GLUE_SECRET_ACCESS_KEY",
+		"REDO_STORAGE_ACCESS_KEY",
+		"REDO_STORAGE_SECRET_ACCESS_KEY",
+	}, names)
+	require.Equal(t, "http://abc.com", *config.Sink.SchemaRegistry)
+	require.Equal(t, "user", *config.Sink.KafkaConfig.SASLUser)
+	require.Equal(t, "${KAFKA_SASL_PASSWORD}", *config.Sink.KafkaConfig.SASLPassword)
+	require.Equal(t, "http://abc.com?password=${KAFKA_SASL_OAUTH_TOKEN_URL_PASSWORD}",
+		*config.Sink.KafkaConfig.SASLOAuthTokenURL)
+	require.Equal(t, "s3://bucket/redo?access-key=${REDO_STORAGE_ACCESS_KEY}&"+
+		"secret-access-key=${REDO_STORAGE_SECRET_ACCESS_KEY}", config.Consistent.Storage)
+
+	values := map[string]string{
+		"KAFKA_SASL_PASSWORD":                 "p&ss",
+		"KAFKA_SASL_OAUTH_TOKEN_URL_PASSWORD": "p&ss",
+		"GLUE_ACCESS_KEY":                     "abc",
+		"GLUE_SECRET_ACCESS_KEY":              "def",
+		"REDO_STORAGE_ACCESS_KEY":             "abc",
+	}
+	missing := config.RenderSensitiveData(values)
+	require.Equal(t, []string{"REDO_STORAGE_SECRET_ACCESS_KEY"}, missing)
+	require.Equal(t, "p&ss", *config.Sink.KafkaConfig.SASLPassword)
+	require.Equal(t, "http://abc.com?password=p%26ss", *config.Sink.KafkaConfig.SASLOAuthTokenURL)
+	require.Equal(t, "abc", config.Sink.KafkaConfig.GlueSchemaRegistryConfig.AccessKey)
+	require.Equal(t, "s3://bucket/redo?access-key=abc&"+
+		"secret-access-key=${REDO_STORAGE_SECRET_ACCESS_KEY}", config.Consistent.Storage)
+}
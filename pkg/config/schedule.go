@@ -0,0 +1,150 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+// ScheduleConfig pauses, resumes and delays the start of a changefeed by time.
+type ScheduleConfig struct {
+	// PauseCron is a standard cron expression, the changefeed is paused when
+	// it fires, e.g. "0 8 * * 1-5".
+	PauseCron string `toml:"pause-cron" json:"pause-cron,omitempty"`
+	// ResumeCron is a standard cron expression, the changefeed paused is
+	// resumed when it fires, e.g. "0 20 * * 1-5".
+	ResumeCron string `toml:"resume-cron" json:"resume-cron,omitempty"`
+	// TimeZone is the time zone that the cron expressions are evaluated in,
+	// the time zone of the owner is used if it's empty.
+	TimeZone string `toml:"time-zone" json:"time-zone,omitempty"`
+	// DelayedStart allows the start-ts of the changefeed to be later than the
+	// current tso, the changefeed waits until the upstream reaches it.
+	DelayedStart bool `toml:"delayed-start" json:"delayed-start,omitempty"`
+}
+
+// ParseCron parses a cron expression of the schedule, it returns nil if the
+// expression is empty.
+func (c *ScheduleConfig) ParseCron(spec string) (cron.Schedule, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, cerror.ErrInvalidReplicaConfig.GenWithStack(
+			"invalid cron expression %q in schedule: %s", spec, err.Error())
+	}
+	return schedule, nil
+}
+
+// Location returns the time zone that the cron expressions are evaluated in.
+func (c *ScheduleConfig) Location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, cerror.ErrInvalidReplicaConfig.GenWithStack(
+			"invalid time zone %q in schedule: %s", c.TimeZone, err.Error())
+	}
+	return loc, nil
+}
+
+// Validate checks the cron expressions and the time zone of the schedule.
+func (c *ScheduleConfig) Validate() error {
+	if _, err := c.ParseCron(c.PauseCron); err != nil {
+		return err
+	}
+	if _, err := c.ParseCron(c.ResumeCron); err != nil {
+		return err
+	}
+	_, err := c.Location()
+	return err
+}
+
+// hookEvents are the states of a changefeed that a hook can be notified of.
+var hookEvents = []string{"normal", "warning", "pending", "failed", "stopped", "finished"}
+
+// HookConfig notifies a webhook or runs a command when the state of a
+// changefeed changes.
+type HookConfig struct {
+	// Events are the states of the changefeed that trigger the hook, they
+	// can be "normal", "warning", "pending", "failed", "stopped" and "finished".
+	Events []string `toml:"events" json:"events"`
+	// URL is a http or https url that the hook events are posted to in json.
+	URL string `toml:"url" json:"url,omitempty"`
+	// Command is executed by `sh -c` on the owner with the hook event in json
+	// from the stdin, it's only allowed if `allow-hook-command` of the server
+	// is enabled.
+	Command string `toml:"command" json:"command,omitempty"`
+}
+
+// Validate checks the events and the target of the hook.
+func (c *HookConfig) Validate() error {
+	if len(c.Events) == 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStack("hook events can not be empty")
+	}
+	for _, event := range c.Events {
+		if !c.isValidEvent(event) {
+			return cerror.ErrInvalidReplicaConfig.GenWithStack(
+				"invalid hook event %q, supported events are [%s]",
+				event, strings.Join(hookEvents, ", "))
+		}
+	}
+	if (c.URL == "") == (c.Command == "") {
+		return cerror.ErrInvalidReplicaConfig.GenWithStack(
+			"exactly one of url and command should be set in a hook")
+	}
+	if c.URL != "" {
+		u, err := url.Parse(c.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return cerror.ErrInvalidReplicaConfig.GenWithStack(
+				"invalid hook url %q, a http or https url is required", c.URL)
+		}
+	}
+	return nil
+}
+
+// HasEvent returns true if the hook is triggered by the state.
+func (c *HookConfig) HasEvent(state string) bool {
+	for _, event := range c.Events {
+		if event == state {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements fmt.Stringer, the url is not printed since it may
+// contain credentials.
+func (c *HookConfig) String() string {
+	if c.Command != "" {
+		return fmt.Sprintf("command hook of %v", c.Events)
+	}
+	return fmt.Sprintf("webhook of %v", c.Events)
+}
+
+func (c *HookConfig) isValidEvent(event string) bool {
+	for _, e := range hookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -149,6 +149,9 @@ type ServerConfig struct {
 	Debug                  *DebugConfig         `toml:"debug" json:"debug"`
 	ClusterID              string               `toml:"cluster-id" json:"cluster-id"`
 	GcTunerMemoryThreshold uint64               `toml:"gc-tuner-memory-threshold" json:"gc-tuner-memory-threshold"`
+	// AllowHookCommand allows the changefeed hooks to execute commands on the
+	// owner, only webhooks are delivered if it's false.
+	AllowHookCommand bool `toml:"allow-hook-command" json:"allow-hook-command"`
 
 	// Deprecated: we don't use this field anymore.
 	PerTableMemoryQuota uint64 `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
@@ -240,6 +243,12 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 					"It's highly recommended to enable TLS to secure the communication")
 			}
 		}
+		if err := c.Security.ValidateClientUserRoles(); err != nil {
+			return err
+		}
+		if err := c.Security.ValidateClientAuth(); err != nil {
+			return err
+		}
 		if c.Security.IsTLSEnabled() {
 			var err error
 			_, err = c.Security.ToTLSConfig()
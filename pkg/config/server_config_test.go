@@ -90,6 +90,30 @@ func TestDBConfigValidateAndAdjust(t *testing.T) {
 	require.Error(t, conf.ValidateAndAdjust())
 }
 
+func TestSorterConfigValidateAndAdjust(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultServerConfig().Clone().Sorter
+
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.False(t, conf.TieringEnabled())
+	conf.Tiering = &SorterTieringConfig{}
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.False(t, conf.TieringEnabled())
+
+	conf.Tiering.StorageURI = "s3://bucket/prefix"
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.True(t, conf.TieringEnabled())
+	require.Equal(t, TomlDuration(DefaultSorterTieringMinLag), conf.Tiering.MinLag)
+	require.Equal(t, uint64(DefaultSorterTieringTableThresholdInMB), conf.Tiering.TableThresholdInMB)
+	require.Equal(t, uint64(DefaultSorterTieringRunSizeInMB), conf.Tiering.RunSizeInMB)
+
+	conf.Tiering.MinLag = -TomlDuration(time.Second)
+	require.Error(t, conf.ValidateAndAdjust())
+	conf.Tiering.MinLag = TomlDuration(time.Second)
+	conf.Tiering.RunSizeInMB = 1 << 20
+	require.Error(t, conf.ValidateAndAdjust())
+}
+
 func TestKVClientConfigValidateAndAdjust(t *testing.T) {
 	t.Parallel()
 	conf := GetDefaultServerConfig().Clone().KVClient
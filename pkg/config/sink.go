@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
@@ -391,7 +392,127 @@ type DispatchRule struct {
 	// Columns are set when using columns dispatcher.
 	Columns []string `toml:"columns" json:"columns"`
 
+	// HashFunction is the function to hash the partition key, it's one of
+	// default, murmur2, crc32 and xxhash. If it's not default, only the encoded
+	// values of the index or the columns are hashed, and the encoded key is sent
+	// as the key of the kafka message, so the partition is the same as the one
+	// computed by the default partitioner of kafka, Kafka Streams and Flink
+	// using murmur2. It's not supported by the protocols encoding the message key.
+	HashFunction string `toml:"hash-function" json:"hash-function,omitempty"`
+	// Partitions are the partitions the matched tables are dispatched to,
+	// the partition is selected from them by the partition dispatcher.
+	Partitions []int32 `toml:"partitions" json:"partitions,omitempty"`
+	// PartitionNum is the expected partition number of the topic,
+	// the changefeed fails if the topic has a different partition number.
+	PartitionNum int32 `toml:"partition-num" json:"partition-num,omitempty"`
+
 	TopicRule string `toml:"topic" json:"topic"`
+	// TopicConfig is used to create and reconcile the topics of the matched tables,
+	// the partition-num and replication-factor in the sink URI are used if it's not set.
+	TopicConfig *TopicConfig `toml:"topic-config" json:"topic-config,omitempty"`
+}
+
+// TopicConfig represents the configuration of the topics created by the Kafka sink.
+type TopicConfig struct {
+	PartitionNum      *int32 `toml:"partition-num" json:"partition-num,omitempty"`
+	ReplicationFactor *int16 `toml:"replication-factor" json:"replication-factor,omitempty"`
+	// AllowPartitionGrowth allows adding partitions to the existing topic if it has
+	// fewer partitions than partition-num, otherwise the drift is only warned.
+	AllowPartitionGrowth *bool `toml:"allow-partition-growth" json:"allow-partition-growth,omitempty"`
+	// RetentionMs is the `retention.ms` of the topic, -1 means no time limit.
+	RetentionMs *int64 `toml:"retention-ms" json:"retention-ms,omitempty"`
+	// CleanupPolicy is the `cleanup.policy` of the topic,
+	// it's one of delete, compact and "compact,delete".
+	CleanupPolicy *string `toml:"cleanup-policy" json:"cleanup-policy,omitempty"`
+	// Configs are other topic level configs, such as `min.compaction.lag.ms`.
+	Configs map[string]string `toml:"configs" json:"configs,omitempty"`
+}
+
+func (c *TopicConfig) validate(rule *DispatchRule) error {
+	if rule.TopicRule == "" {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"topic-config should be set with the topic, rule: %v", rule.Matcher)
+	}
+	if c.PartitionNum != nil {
+		if *c.PartitionNum <= 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"partition-num of the topic-config should be greater than 0, but got %d", *c.PartitionNum)
+		}
+		if rule.PartitionNum != 0 && rule.PartitionNum != *c.PartitionNum {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"partition-num of the topic-config %d is not equal to the partition-num %d of the rule",
+				*c.PartitionNum, rule.PartitionNum)
+		}
+	}
+	if c.ReplicationFactor != nil && *c.ReplicationFactor <= 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"replication-factor of the topic-config should be greater than 0, but got %d", *c.ReplicationFactor)
+	}
+	if c.RetentionMs != nil && *c.RetentionMs < -1 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"retention-ms of the topic-config should not be less than -1, but got %d", *c.RetentionMs)
+	}
+	if c.CleanupPolicy != nil {
+		switch strings.ReplaceAll(*c.CleanupPolicy, " ", "") {
+		case "delete", "compact", "compact,delete", "delete,compact":
+		default:
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"cleanup-policy %s of the topic-config is invalid", *c.CleanupPolicy)
+		}
+	}
+	return nil
+}
+
+func (r *DispatchRule) validateForKafka(sinkURI *url.URL, protocol Protocol) error {
+	if !hash.IsValidFunction(r.HashFunction) {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"hash-function %s is not supported, it should be one of default, murmur2, crc32 and xxhash",
+			r.HashFunction)
+	}
+	if !hash.IsDefaultFunction(r.HashFunction) {
+		// the hashed key is sent as the key of the message, which can't
+		// replace the key encoded by the protocol.
+		if protocol.HasMessageKey() {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"hash-function %s is not supported by the protocol %s, rule: %v",
+				r.HashFunction, protocol, r.Matcher)
+		}
+		if strings.EqualFold(r.PartitionRule, "ts") {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"hash-function %s is not supported by the ts partition dispatcher, rule: %v",
+				r.HashFunction, r.Matcher)
+		}
+	}
+	if !hash.IsDefaultFunction(r.HashFunction) || len(r.Partitions) != 0 ||
+		r.PartitionNum != 0 || r.TopicConfig != nil {
+		if !sink.IsMQScheme(sinkURI.Scheme) || sink.IsPulsarScheme(sinkURI.Scheme) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"hash-function, partitions, partition-num and topic-config are only supported "+
+					"by the kafka sink, rule: %v", r.Matcher)
+		}
+	}
+	if r.TopicConfig != nil {
+		if err := r.TopicConfig.validate(r); err != nil {
+			return err
+		}
+	}
+	if r.PartitionNum < 0 {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"partition-num should not be negative, but got %d", r.PartitionNum)
+	}
+	seen := make(map[int32]struct{}, len(r.Partitions))
+	for _, partition := range r.Partitions {
+		if partition < 0 || (r.PartitionNum != 0 && partition >= r.PartitionNum) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"partition %d is out of the range, rule: %v", partition, r.Matcher)
+		}
+		if _, ok := seen[partition]; ok {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"partition %d is duplicated, rule: %v", partition, r.Matcher)
+		}
+		seen[partition] = struct{}{}
+	}
+	return nil
 }
 
 // ColumnSelector represents a column selector for a table.
@@ -408,6 +529,16 @@ type CodecConfig struct {
 	AvroDecimalHandlingMode        *string `toml:"avro-decimal-handling-mode" json:"avro-decimal-handling-mode,omitempty"`
 	AvroBigintUnsignedHandlingMode *string `toml:"avro-bigint-unsigned-handling-mode" json:"avro-bigint-unsigned-handling-mode,omitempty"`
 	EncodingFormat                 *string `toml:"encoding-format" json:"encoding-format,omitempty"`
+	// AvroSubjectNameStrategy decides the subject of the schemas registered in
+	// the schema registry, can be `topic-name`, `record-name` or `topic-record-name`.
+	AvroSubjectNameStrategy *string `toml:"avro-subject-name-strategy" json:"avro-subject-name-strategy,omitempty"`
+	// AvroSchemaCompatibilityCheck checks whether the new schema of a table is
+	// compatible with the latest schema in the schema registry before the DDL
+	// is applied.
+	AvroSchemaCompatibilityCheck *bool `toml:"avro-schema-compatibility-check" json:"avro-schema-compatibility-check,omitempty"`
+	// AvroIncompatibleSchemaFallback decides what to do with an incompatible
+	// schema, can be `error` or `new-subject`.
+	AvroIncompatibleSchemaFallback *string `toml:"avro-incompatible-schema-fallback" json:"avro-incompatible-schema-fallback,omitempty"`
 }
 
 // KafkaConfig represents a kafka sink configuration
@@ -677,6 +808,15 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `toml:"enable-batch-dml" json:"enable-batch-dml,omitempty"`
 	EnableMultiStatement         *bool   `toml:"enable-multi-statement" json:"enable-multi-statement,omitempty"`
 	EnableCachePreparedStatement *bool   `toml:"enable-cache-prepared-statement" json:"enable-cache-prepared-statement,omitempty"`
+	// VerifyChecksum verifies the row level checksum before writing the rows,
+	// it only takes effect if the integrity check is enabled.
+	VerifyChecksum *bool `toml:"verify-checksum" json:"verify-checksum,omitempty"`
+	// EnableCheckpointTable upserts the checkpoints of the changefeed and its
+	// tables into `tidb_cdc.checkpoint_v1` of the downstream.
+	// Note that all transactions of a table are serialized if it's enabled,
+	// since the checkpoint of the table is written along with them, so the
+	// transactions of the same table are no longer written concurrently.
+	EnableCheckpointTable *bool `toml:"enable-checkpoint-table" json:"enable-checkpoint-table,omitempty"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -771,6 +911,9 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 			rule.PartitionRule = rule.DispatcherRule
 			rule.DispatcherRule = ""
 		}
+		if err := rule.validateForKafka(sinkURI, protocol); err != nil {
+			return err
+		}
 	}
 
 	if util.GetOrZero(s.EncoderConcurrency) < 0 {
@@ -1013,4 +1156,12 @@ type OpenProtocolConfig struct {
 // DebeziumConfig represents the configurations for debezium protocol encoding
 type DebeziumConfig struct {
 	OutputOldValue bool `toml:"output-old-value" json:"output-old-value"`
+	// SchemaChangeTopic is the topic which all the DDL events are sent to,
+	// as the schema change topic of Debezium. If it's empty, the DDL events
+	// are dispatched by the topic dispatchers.
+	SchemaChangeTopic string `toml:"schema-change-topic" json:"schema-change-topic,omitempty"`
+	// TransactionTopic is the topic which the BEGIN and END transaction
+	// metadata events are sent to. If it's empty, no transaction metadata
+	// is emitted.
+	TransactionTopic string `toml:"transaction-topic" json:"transaction-topic,omitempty"`
 }
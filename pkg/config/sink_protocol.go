@@ -47,6 +47,17 @@ func (p Protocol) IsBatchEncode() bool {
 	return p == ProtocolOpen || p == ProtocolCanal || p == ProtocolMaxwell || p == ProtocolCraft
 }
 
+// HasMessageKey returns whether the protocol encodes the key of the message,
+// the messages of other protocols are sent without the key.
+func (p Protocol) HasMessageKey() bool {
+	switch p {
+	case ProtocolOpen, ProtocolCraft, ProtocolMaxwell, ProtocolAvro, ProtocolDebezium:
+		return true
+	default:
+		return false
+	}
+}
+
 // ParseSinkProtocolFromString converts the protocol from string to Protocol enum type.
 func ParseSinkProtocolFromString(protocol string) (Protocol, error) {
 	switch strings.ToLower(protocol) {
@@ -438,6 +438,107 @@ func TestValidateAndAdjustStorageConfig(t *testing.T) {
 	require.Equal(t, 16, util.GetOrZero(s.Sink.FileIndexWidth))
 }
 
+func TestValidateAndAdjustPartitionMapping(t *testing.T) {
+	t.Parallel()
+
+	kafkaURI, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=canal-json")
+	require.NoError(t, err)
+	openProtocolURI, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=open-protocol")
+	require.NoError(t, err)
+	storageURI, err := url.Parse("file:///tmp/test?protocol=canal-json")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		sinkURI     *url.URL
+		rule        *DispatchRule
+		expectedErr string
+	}{
+		{
+			sinkURI: kafkaURI,
+			rule: &DispatchRule{
+				Matcher: []string{"test.*"}, PartitionRule: "index-value",
+				HashFunction: "murmur2", Partitions: []int32{0, 2}, PartitionNum: 4,
+			},
+		},
+		{
+			sinkURI:     kafkaURI,
+			rule:        &DispatchRule{Matcher: []string{"test.*"}, HashFunction: "md5"},
+			expectedErr: ".*hash-function md5 is not supported.*",
+		},
+		{
+			sinkURI:     openProtocolURI,
+			rule:        &DispatchRule{Matcher: []string{"test.*"}, HashFunction: "murmur2"},
+			expectedErr: ".*hash-function murmur2 is not supported by the protocol open-protocol.*",
+		},
+		{
+			sinkURI:     kafkaURI,
+			rule:        &DispatchRule{Matcher: []string{"test.*"}, PartitionRule: "ts", HashFunction: "crc32"},
+			expectedErr: ".*hash-function crc32 is not supported by the ts partition dispatcher.*",
+		},
+		{
+			sinkURI:     kafkaURI,
+			rule:        &DispatchRule{Matcher: []string{"test.*"}, Partitions: []int32{0, 4}, PartitionNum: 4},
+			expectedErr: ".*partition 4 is out of the range.*",
+		},
+		{
+			sinkURI:     kafkaURI,
+			rule:        &DispatchRule{Matcher: []string{"test.*"}, Partitions: []int32{1, 1}},
+			expectedErr: ".*partition 1 is duplicated.*",
+		},
+		{
+			sinkURI: kafkaURI,
+			rule: &DispatchRule{
+				Matcher: []string{"test.*"}, TopicRule: "{schema}_{table}",
+				TopicConfig: &TopicConfig{
+					PartitionNum:      util.AddressOf(int32(6)),
+					ReplicationFactor: util.AddressOf(int16(3)),
+					RetentionMs:       util.AddressOf(int64(-1)),
+					CleanupPolicy:     util.AddressOf("compact, delete"),
+				},
+			},
+		},
+		{
+			sinkURI: kafkaURI,
+			rule: &DispatchRule{
+				Matcher:     []string{"test.*"},
+				TopicConfig: &TopicConfig{PartitionNum: util.AddressOf(int32(6))},
+			},
+			expectedErr: ".*topic-config should be set with the topic.*",
+		},
+		{
+			sinkURI: kafkaURI,
+			rule: &DispatchRule{
+				Matcher: []string{"test.*"}, TopicRule: "{schema}_{table}", PartitionNum: 4,
+				TopicConfig: &TopicConfig{PartitionNum: util.AddressOf(int32(6))},
+			},
+			expectedErr: ".*is not equal to the partition-num 4 of the rule.*",
+		},
+		{
+			sinkURI: kafkaURI,
+			rule: &DispatchRule{
+				Matcher: []string{"test.*"}, TopicRule: "{schema}_{table}",
+				TopicConfig: &TopicConfig{CleanupPolicy: util.AddressOf("truncate")},
+			},
+			expectedErr: ".*cleanup-policy truncate of the topic-config is invalid.*",
+		},
+		{
+			sinkURI:     storageURI,
+			rule:        &DispatchRule{Matcher: []string{"test.*"}, Partitions: []int32{1}},
+			expectedErr: ".*are only supported by the kafka sink.*",
+		},
+	}
+	for _, tc := range testCases {
+		cfg := GetDefaultReplicaConfig().Sink
+		cfg.DispatchRules = []*DispatchRule{tc.rule}
+		err := cfg.validateAndAdjust(tc.sinkURI)
+		if tc.expectedErr == "" {
+			require.NoError(t, err)
+		} else {
+			require.Regexp(t, tc.expectedErr, err)
+		}
+	}
+}
+
 func TestShouldSendBootstrapMsg(t *testing.T) {
 	t.Parallel()
 	sinkConfig := GetDefaultReplicaConfig().Sink
@@ -14,11 +14,24 @@
 package config
 
 import (
+	"fmt"
 	"math"
+	"time"
 
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/pkg/errors"
 )
 
+const (
+	// DefaultSorterTieringMinLag is the default min lag of events to be tiered.
+	DefaultSorterTieringMinLag = 10 * time.Minute
+	// DefaultSorterTieringTableThresholdInMB is the default bytes of events of
+	// a table in the local sort engine to trigger tiering.
+	DefaultSorterTieringTableThresholdInMB = 64
+	// DefaultSorterTieringRunSizeInMB is the default max size of a run.
+	DefaultSorterTieringRunSizeInMB = 64
+)
+
 // SorterConfig represents sorter config for a changefeed
 type SorterConfig struct {
 	// the directory used to store the temporary files generated by the sorter
@@ -26,6 +39,12 @@ type SorterConfig struct {
 
 	// Cache size of sorter in MB.
 	CacheSizeInMB uint64 `toml:"cache-size-in-mb" json:"cache-size-in-mb"`
+	// The max disk usage in MB of the sorter of all changefeeds on the capture,
+	// the pullers are blocked when it's exceeded. 0 means no limit.
+	MaxDiskUsageInMB uint64 `toml:"max-disk-usage-in-mb" json:"max-disk-usage-in-mb"`
+	// Tiering moves cold sorted events to an external storage, it's disabled
+	// if it's nil or the storage URI is empty.
+	Tiering *SorterTieringConfig `toml:"tiering" json:"tiering,omitempty"`
 
 	// Deprecated: we don't use this field anymore.
 	MaxMemoryPercentage int `toml:"max-memory-percentage" json:"max-memory-percentage"`
@@ -44,5 +63,62 @@ func (c *SorterConfig) ValidateAndAdjust() error {
 	if c.CacheSizeInMB < 8 || c.CacheSizeInMB*uint64(1<<20) > uint64(math.MaxInt64) {
 		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("cache-size-in-mb should be greater than 8(MB)")
 	}
+	if c.MaxDiskUsageInMB > uint64(math.MaxInt64)>>20 {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("max-disk-usage-in-mb is too large")
+	}
+	if c.Tiering != nil {
+		return c.Tiering.ValidateAndAdjust()
+	}
+	return nil
+}
+
+// TieringEnabled returns whether sorted events are tiered to an external storage.
+func (c *SorterConfig) TieringEnabled() bool {
+	return c.Tiering != nil && c.Tiering.StorageURI != ""
+}
+
+// SorterTieringConfig represents the config of moving cold sorted events
+// to an external storage, e.g. when a changefeed is paused for days or the
+// downstream is unavailable for a long time.
+type SorterTieringConfig struct {
+	// StorageURI is the URI of the external storage, e.g. s3://bucket/prefix,
+	// gcs://bucket/prefix or file:///path.
+	StorageURI string `toml:"storage-uri" json:"storage-uri"`
+	// MinLag is the min lag of events compared with the resolved ts of the
+	// table to be tiered.
+	MinLag TomlDuration `toml:"min-lag" json:"min-lag"`
+	// TableThresholdInMB is the bytes of events of a table in the local sort
+	// engine to trigger tiering.
+	TableThresholdInMB uint64 `toml:"table-threshold-in-mb" json:"table-threshold-in-mb"`
+	// RunSizeInMB is the max size of a run of sorted events written to the
+	// external storage, a run is loaded into memory when it's fetched.
+	RunSizeInMB uint64 `toml:"run-size-in-mb" json:"run-size-in-mb"`
+}
+
+// ValidateAndAdjust validates and adjusts the tiering configuration.
+func (c *SorterTieringConfig) ValidateAndAdjust() error {
+	if c.StorageURI == "" {
+		return nil
+	}
+	if _, err := storage.ParseRawURL(c.StorageURI); err != nil {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs(
+			fmt.Sprintf("invalid tiering storage-uri: %s", err.Error()))
+	}
+	if c.MinLag == 0 {
+		c.MinLag = TomlDuration(DefaultSorterTieringMinLag)
+	}
+	if c.MinLag < 0 {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs("tiering min-lag should be positive")
+	}
+	if c.TableThresholdInMB == 0 {
+		c.TableThresholdInMB = DefaultSorterTieringTableThresholdInMB
+	}
+	if c.RunSizeInMB == 0 {
+		c.RunSizeInMB = DefaultSorterTieringRunSizeInMB
+	}
+	if c.TableThresholdInMB > uint64(math.MaxInt64)>>20 || c.RunSizeInMB > uint64(math.MaxInt32)>>20 {
+		return errors.ErrIllegalSorterParameter.GenWithStackByArgs(
+			"tiering table-threshold-in-mb or run-size-in-mb is too large")
+	}
 	return nil
 }
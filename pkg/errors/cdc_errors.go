@@ -215,6 +215,10 @@ var (
 		"kafka create topic failed",
 		errors.RFCCodeText("CDC:ErrKafkaCreateTopic"),
 	)
+	ErrKafkaCreatePartitions = errors.Normalize(
+		"kafka create partitions for topic %s failed",
+		errors.RFCCodeText("CDC:ErrKafkaCreatePartitions"),
+	)
 	ErrKafkaInvalidTopicExpression = errors.Normalize(
 		"invalid topic expression: %s ",
 		errors.RFCCodeText("CDC:ErrKafkaTopicExprInvalid"),
@@ -223,6 +227,14 @@ var (
 		"kafka config item not found",
 		errors.RFCCodeText("CDC:ErrKafkaConfigNotFound"),
 	)
+	ErrKafkaTransaction = errors.Normalize(
+		"kafka transaction %s failed",
+		errors.RFCCodeText("CDC:ErrKafkaTransaction"),
+	)
+	ErrKafkaProducerFenced = errors.Normalize(
+		"kafka transactional producer %s is fenced",
+		errors.RFCCodeText("CDC:ErrKafkaProducerFenced"),
+	)
 	// for pulsar
 	ErrPulsarSendMessage = errors.Normalize(
 		"pulsar send message failed",
@@ -383,6 +395,10 @@ var (
 		"schema manager API error, %s",
 		errors.RFCCodeText("CDC:ErrAvroSchemaAPIError"),
 	)
+	ErrAvroSchemaIncompatible = errors.Normalize(
+		"the new schema of subject %s is incompatible with the schema registry, %s",
+		errors.RFCCodeText("CDC:ErrAvroSchemaIncompatible"),
+	)
 	ErrAvroInvalidMessage = errors.Normalize(
 		"avro invalid message format, %s",
 		errors.RFCCodeText("CDC:ErrAvroInvalidMessage"),
@@ -568,6 +584,10 @@ var (
 		"can not found schema snapshot, the specified ts(%d) is less than gcTS(%d)",
 		errors.RFCCodeText("CDC:ErrSchemaStorageGCed"),
 	)
+	ErrSchemaHistoryInvalid = errors.Normalize(
+		"invalid schema history: %s",
+		errors.RFCCodeText("CDC:ErrSchemaHistoryInvalid"),
+	)
 	ErrSchemaSnapshotNotFound = errors.Normalize(
 		"can not found schema snapshot, ts: %d",
 		errors.RFCCodeText("CDC:ErrSchemaSnapshotNotFound"),
@@ -782,6 +802,10 @@ var (
 		"illegal parameter for sorter: %s",
 		errors.RFCCodeText("CDC:ErrIllegalSorterParameter"),
 	)
+	ErrSorterDiskQuotaExceeded = errors.Normalize(
+		"sorter disk usage exceeds the quota, %s",
+		errors.RFCCodeText("CDC:ErrSorterDiskQuotaExceeded"),
+	)
 	ErrConflictingFileLocks = errors.Normalize(
 		"file lock conflict: %s",
 		errors.RFCCodeText("ErrConflictingFileLocks"),
@@ -895,6 +919,40 @@ var (
 		"upstream has running import tasks, upstream-id: %d",
 		errors.RFCCodeText("CDC:ErrUpstreamHasRunningImport"),
 	)
+	ErrUpstreamAlreadyExists = errors.Normalize(
+		"upstream already exists, %s",
+		errors.RFCCodeText("CDC:ErrUpstreamAlreadyExists"),
+	)
+	ErrUpstreamInUse = errors.Normalize(
+		"upstream is used by changefeeds, upstream-id: %d, changefeeds: %v",
+		errors.RFCCodeText("CDC:ErrUpstreamInUse"),
+	)
+
+	// namespace error
+	ErrNamespaceNotFound = errors.Normalize(
+		"namespace not found, namespace: %s",
+		errors.RFCCodeText("CDC:ErrNamespaceNotFound"),
+	)
+	ErrNamespaceAlreadyExists = errors.Normalize(
+		"namespace already exists, namespace: %s",
+		errors.RFCCodeText("CDC:ErrNamespaceAlreadyExists"),
+	)
+	ErrNamespaceInUse = errors.Normalize(
+		"namespace has changefeeds, namespace: %s, changefeeds: %v",
+		errors.RFCCodeText("CDC:ErrNamespaceInUse"),
+	)
+	ErrNamespaceQuotaExceeded = errors.Normalize(
+		"namespace quota exceeded, namespace: %s, %s",
+		errors.RFCCodeText("CDC:ErrNamespaceQuotaExceeded"),
+	)
+	ErrChangefeedTemplateNotFound = errors.Normalize(
+		"changefeed template not found, namespace: %s, name: %s",
+		errors.RFCCodeText("CDC:ErrChangefeedTemplateNotFound"),
+	)
+	ErrChangefeedTemplateAlreadyExists = errors.Normalize(
+		"changefeed template already exists, namespace: %s, name: %s",
+		errors.RFCCodeText("CDC:ErrChangefeedTemplateAlreadyExists"),
+	)
 
 	// ReplicationSet error
 	ErrReplicationSetInconsistent = errors.Normalize(
@@ -1026,4 +1084,8 @@ var (
 		"user %s unauthorized, error: %s",
 		errors.RFCCodeText("CDC:ErrUnauthorized"),
 	)
+	ErrPermissionDenied = errors.Normalize(
+		"user %s permission denied, error: %s",
+		errors.RFCCodeText("CDC:ErrPermissionDenied"),
+	)
 )
@@ -59,6 +59,21 @@ func ChangefeedStatusKeyPrefix(clusterID, namespace string) string {
 	return NamespacedPrefix(clusterID, namespace) + ChangefeedStatusKey
 }
 
+// UpstreamInfoKeyPrefix is the prefix of upstream info keys
+func UpstreamInfoKeyPrefix(clusterID, namespace string) string {
+	return NamespacedPrefix(clusterID, namespace) + upstreamKey
+}
+
+// NamespaceInfoKeyPrefix is the prefix of namespace settings keys
+func NamespaceInfoKeyPrefix(clusterID string) string {
+	return SettingsKey(clusterID) + namespaceKey
+}
+
+// ChangefeedTemplateKeyPrefix is the prefix of changefeed template keys
+func ChangefeedTemplateKeyPrefix(clusterID, namespace string) string {
+	return SettingsKey(clusterID) + templateKey + "/" + namespace
+}
+
 // GetEtcdKeyChangeFeedList returns the prefix key of all changefeed config
 func GetEtcdKeyChangeFeedList(clusterID, namespace string) string {
 	return fmt.Sprintf("%s/changefeed/info", NamespacedPrefix(clusterID, namespace))
@@ -133,6 +148,44 @@ type CDCEtcdClient interface {
 		namespace string,
 	) (*model.UpstreamInfo, error)
 
+	GetUpstreamInfos(ctx context.Context,
+		namespace string,
+	) (map[model.UpstreamID]*model.UpstreamInfo, error)
+
+	CreateUpstreamInfo(ctx context.Context,
+		info *model.UpstreamInfo,
+		namespace string,
+	) error
+
+	DeleteUpstreamInfo(ctx context.Context,
+		upstreamID model.UpstreamID,
+		namespace string,
+	) error
+
+	GetNamespaceInfos(ctx context.Context) (map[string]*model.NamespaceInfo, error)
+
+	GetNamespaceInfo(ctx context.Context, namespace string) (*model.NamespaceInfo, error)
+
+	CreateNamespaceInfo(ctx context.Context, info *model.NamespaceInfo) error
+
+	UpdateNamespaceInfo(ctx context.Context, info *model.NamespaceInfo) error
+
+	DeleteNamespaceInfo(ctx context.Context, namespace string) error
+
+	GetChangefeedTemplates(ctx context.Context,
+		namespace string,
+	) (map[string]*model.ChangefeedTemplate, error)
+
+	GetChangefeedTemplate(ctx context.Context,
+		namespace, name string,
+	) (*model.ChangefeedTemplate, error)
+
+	CreateChangefeedTemplate(ctx context.Context, template *model.ChangefeedTemplate) error
+
+	UpdateChangefeedTemplate(ctx context.Context, template *model.ChangefeedTemplate) error
+
+	DeleteChangefeedTemplate(ctx context.Context, namespace, name string) error
+
 	GetGCServiceID() string
 
 	GetEnsureGCServiceID(tag string) string
@@ -200,6 +253,10 @@ func (c *CDCEtcdClientImpl) Close() error {
 // ClearAllCDCInfo delete all keys created by CDC
 func (c *CDCEtcdClientImpl) ClearAllCDCInfo(ctx context.Context) error {
 	_, err := c.Client.Delete(ctx, BaseKey(c.ClusterID), clientv3.WithPrefix())
+	if err != nil {
+		return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	_, err = c.Client.Delete(ctx, SettingsKey(c.ClusterID)+"/", clientv3.WithPrefix())
 	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
 }
 
@@ -234,7 +291,8 @@ func (c *CDCEtcdClientImpl) CheckMultipleCDCClusterExist(ctx context.Context) er
 	for _, kv := range resp.Kvs {
 		key := string(kv.Key)
 		if strings.HasPrefix(key, BaseKey(DefaultCDCClusterID)) ||
-			strings.HasPrefix(key, migrateBackupPrefix) {
+			strings.HasPrefix(key, migrateBackupPrefix) ||
+			strings.HasPrefix(key, settingsPrefix) {
 			continue
 		}
 		// skip the reserved cluster id
@@ -466,6 +524,24 @@ func (c *CDCEtcdClientImpl) saveChangefeedAndUpstreamInfo(
 			cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(upstreamEtcdKeyStr), "=", 0))
 			opsThen = append(opsThen, clientv3.OpPut(upstreamEtcdKeyStr, string(upstreamData)))
 		} else {
+			// keep the name of a registered upstream if the caller doesn't know it.
+			if upstreamInfo.Name == "" {
+				oldUpstreamInfo := &model.UpstreamInfo{}
+				if err := oldUpstreamInfo.Unmarshal(upstreamResp.Kvs[0].Value); err != nil {
+					return errors.Trace(err)
+				}
+				if oldUpstreamInfo.Name != "" {
+					upstreamInfo, err = upstreamInfo.Clone()
+					if err != nil {
+						return errors.Trace(err)
+					}
+					upstreamInfo.Name = oldUpstreamInfo.Name
+					upstreamData, err = upstreamInfo.Marshal()
+					if err != nil {
+						return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+					}
+				}
+			}
 			cmps = append(cmps,
 				clientv3.Compare(clientv3.ModRevision(upstreamEtcdKeyStr), "=", upstreamResp.Kvs[0].ModRevision))
 			if !bytes.Equal(upstreamResp.Kvs[0].Value, upstreamData) {
@@ -646,6 +722,326 @@ func (c *CDCEtcdClientImpl) GetUpstreamInfo(ctx context.Context,
 	return info, errors.Trace(err)
 }
 
+// GetUpstreamInfos returns all upstream infos of a namespace
+func (c *CDCEtcdClientImpl) GetUpstreamInfos(ctx context.Context,
+	namespace string,
+) (map[model.UpstreamID]*model.UpstreamInfo, error) {
+	resp, err := c.Client.Get(ctx, UpstreamInfoKeyPrefix(c.ClusterID, namespace)+"/",
+		clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	infos := make(map[model.UpstreamID]*model.UpstreamInfo, resp.Count)
+	for _, kv := range resp.Kvs {
+		info := &model.UpstreamInfo{}
+		if err := info.Unmarshal(kv.Value); err != nil {
+			return nil, errors.Trace(err)
+		}
+		infos[info.ID] = info
+	}
+	return infos, nil
+}
+
+// CreateUpstreamInfo stores an upstream info into etcd and
+// fails if it is already exists.
+func (c *CDCEtcdClientImpl) CreateUpstreamInfo(ctx context.Context,
+	info *model.UpstreamInfo,
+	namespace string,
+) error {
+	key := CDCKey{
+		Tp:         CDCKeyTypeUpStream,
+		ClusterID:  c.ClusterID,
+		UpstreamID: info.ID,
+		Namespace:  namespace,
+	}
+	keyStr := key.String()
+	value, err := info.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := c.Client.Txn(ctx,
+		[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(keyStr), "=", 0)},
+		[]clientv3.Op{clientv3.OpPut(keyStr, string(value))},
+		TxnEmptyOpsElse)
+	if err != nil {
+		return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if !resp.Succeeded {
+		return errors.ErrUpstreamAlreadyExists.GenWithStackByArgs(
+			fmt.Sprintf("upstream-id: %d", info.ID))
+	}
+	return nil
+}
+
+// DeleteUpstreamInfo deletes an upstream info from etcd
+func (c *CDCEtcdClientImpl) DeleteUpstreamInfo(ctx context.Context,
+	upstreamID model.UpstreamID,
+	namespace string,
+) error {
+	key := CDCKey{
+		Tp:         CDCKeyTypeUpStream,
+		ClusterID:  c.ClusterID,
+		UpstreamID: upstreamID,
+		Namespace:  namespace,
+	}
+	keyStr := key.String()
+	resp, err := c.Client.Delete(ctx, keyStr)
+	if err != nil {
+		return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if resp.Deleted == 0 {
+		return errors.ErrUpstreamNotFound.GenWithStackByArgs(upstreamID)
+	}
+	return nil
+}
+
+// GetNamespaceInfos returns the settings of all namespaces
+func (c *CDCEtcdClientImpl) GetNamespaceInfos(
+	ctx context.Context,
+) (map[string]*model.NamespaceInfo, error) {
+	resp, err := c.Client.Get(ctx, NamespaceInfoKeyPrefix(c.ClusterID)+"/",
+		clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	infos := make(map[string]*model.NamespaceInfo, resp.Count)
+	for _, kv := range resp.Kvs {
+		info := &model.NamespaceInfo{}
+		if err := info.Unmarshal(kv.Value); err != nil {
+			return nil, errors.Trace(err)
+		}
+		infos[info.Name] = info
+	}
+	return infos, nil
+}
+
+// GetNamespaceInfo returns the settings of a namespace
+func (c *CDCEtcdClientImpl) GetNamespaceInfo(
+	ctx context.Context, namespace string,
+) (*model.NamespaceInfo, error) {
+	key := CDCKey{
+		Tp:        CDCKeyTypeNamespace,
+		ClusterID: c.ClusterID,
+		Namespace: namespace,
+	}
+	resp, err := c.Client.Get(ctx, key.String())
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if resp.Count == 0 {
+		return nil, errors.ErrNamespaceNotFound.GenWithStackByArgs(namespace)
+	}
+	info := &model.NamespaceInfo{}
+	err = info.Unmarshal(resp.Kvs[0].Value)
+	return info, errors.Trace(err)
+}
+
+// CreateNamespaceInfo stores the settings of a namespace into etcd and
+// fails if they already exist.
+func (c *CDCEtcdClientImpl) CreateNamespaceInfo(
+	ctx context.Context, info *model.NamespaceInfo,
+) error {
+	key := CDCKey{
+		Tp:        CDCKeyTypeNamespace,
+		ClusterID: c.ClusterID,
+		Namespace: info.Name,
+	}
+	value, err := info.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ok, err := c.putWithRevision(ctx, key.String(), value, "=")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.ErrNamespaceAlreadyExists.GenWithStackByArgs(info.Name)
+	}
+	return nil
+}
+
+// UpdateNamespaceInfo overwrites the settings of a namespace and
+// fails if they don't exist.
+func (c *CDCEtcdClientImpl) UpdateNamespaceInfo(
+	ctx context.Context, info *model.NamespaceInfo,
+) error {
+	key := CDCKey{
+		Tp:        CDCKeyTypeNamespace,
+		ClusterID: c.ClusterID,
+		Namespace: info.Name,
+	}
+	value, err := info.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ok, err := c.putWithRevision(ctx, key.String(), value, ">")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.ErrNamespaceNotFound.GenWithStackByArgs(info.Name)
+	}
+	return nil
+}
+
+// DeleteNamespaceInfo deletes the settings of a namespace,
+// and the changefeed templates of it.
+func (c *CDCEtcdClientImpl) DeleteNamespaceInfo(
+	ctx context.Context, namespace string,
+) error {
+	key := CDCKey{
+		Tp:        CDCKeyTypeNamespace,
+		ClusterID: c.ClusterID,
+		Namespace: namespace,
+	}
+	keyStr := key.String()
+	resp, err := c.Client.Txn(ctx,
+		[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(keyStr), ">", 0)},
+		[]clientv3.Op{
+			clientv3.OpDelete(keyStr),
+			clientv3.OpDelete(ChangefeedTemplateKeyPrefix(c.ClusterID, namespace)+"/",
+				clientv3.WithPrefix()),
+		},
+		TxnEmptyOpsElse)
+	if err != nil {
+		return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if !resp.Succeeded {
+		return errors.ErrNamespaceNotFound.GenWithStackByArgs(namespace)
+	}
+	return nil
+}
+
+// GetChangefeedTemplates returns all changefeed templates of a namespace
+func (c *CDCEtcdClientImpl) GetChangefeedTemplates(ctx context.Context,
+	namespace string,
+) (map[string]*model.ChangefeedTemplate, error) {
+	resp, err := c.Client.Get(ctx, ChangefeedTemplateKeyPrefix(c.ClusterID, namespace)+"/",
+		clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	templates := make(map[string]*model.ChangefeedTemplate, resp.Count)
+	for _, kv := range resp.Kvs {
+		template := &model.ChangefeedTemplate{}
+		if err := template.Unmarshal(kv.Value); err != nil {
+			return nil, errors.Trace(err)
+		}
+		templates[template.Name] = template
+	}
+	return templates, nil
+}
+
+// GetChangefeedTemplate returns a changefeed template of a namespace
+func (c *CDCEtcdClientImpl) GetChangefeedTemplate(ctx context.Context,
+	namespace, name string,
+) (*model.ChangefeedTemplate, error) {
+	key := CDCKey{
+		Tp:           CDCKeyTypeChangefeedTemplate,
+		ClusterID:    c.ClusterID,
+		Namespace:    namespace,
+		TemplateName: name,
+	}
+	resp, err := c.Client.Get(ctx, key.String())
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if resp.Count == 0 {
+		return nil, errors.ErrChangefeedTemplateNotFound.GenWithStackByArgs(namespace, name)
+	}
+	template := &model.ChangefeedTemplate{}
+	err = template.Unmarshal(resp.Kvs[0].Value)
+	return template, errors.Trace(err)
+}
+
+// CreateChangefeedTemplate stores a changefeed template into etcd and
+// fails if it already exists.
+func (c *CDCEtcdClientImpl) CreateChangefeedTemplate(ctx context.Context,
+	template *model.ChangefeedTemplate,
+) error {
+	key := CDCKey{
+		Tp:           CDCKeyTypeChangefeedTemplate,
+		ClusterID:    c.ClusterID,
+		Namespace:    template.Namespace,
+		TemplateName: template.Name,
+	}
+	value, err := template.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ok, err := c.putWithRevision(ctx, key.String(), value, "=")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.ErrChangefeedTemplateAlreadyExists.GenWithStackByArgs(
+			template.Namespace, template.Name)
+	}
+	return nil
+}
+
+// UpdateChangefeedTemplate overwrites a changefeed template and
+// fails if it doesn't exist.
+func (c *CDCEtcdClientImpl) UpdateChangefeedTemplate(ctx context.Context,
+	template *model.ChangefeedTemplate,
+) error {
+	key := CDCKey{
+		Tp:           CDCKeyTypeChangefeedTemplate,
+		ClusterID:    c.ClusterID,
+		Namespace:    template.Namespace,
+		TemplateName: template.Name,
+	}
+	value, err := template.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ok, err := c.putWithRevision(ctx, key.String(), value, ">")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.ErrChangefeedTemplateNotFound.GenWithStackByArgs(
+			template.Namespace, template.Name)
+	}
+	return nil
+}
+
+// DeleteChangefeedTemplate deletes a changefeed template from etcd
+func (c *CDCEtcdClientImpl) DeleteChangefeedTemplate(ctx context.Context,
+	namespace, name string,
+) error {
+	key := CDCKey{
+		Tp:           CDCKeyTypeChangefeedTemplate,
+		ClusterID:    c.ClusterID,
+		Namespace:    namespace,
+		TemplateName: name,
+	}
+	resp, err := c.Client.Delete(ctx, key.String())
+	if err != nil {
+		return errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	if resp.Deleted == 0 {
+		return errors.ErrChangefeedTemplateNotFound.GenWithStackByArgs(namespace, name)
+	}
+	return nil
+}
+
+// putWithRevision puts the value if the mod revision of the key compares
+// with 0 by the given operator, "=" for a new key and ">" for an existing one,
+// and returns false if the comparison fails.
+func (c *CDCEtcdClientImpl) putWithRevision(
+	ctx context.Context, key string, value []byte, op string,
+) (bool, error) {
+	resp, err := c.Client.Txn(ctx,
+		[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(key), op, 0)},
+		[]clientv3.Op{clientv3.OpPut(key, string(value))},
+		TxnEmptyOpsElse)
+	if err != nil {
+		return false, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+	return resp.Succeeded, nil
+}
+
 // GcServiceIDForTest returns the gc service ID for tests
 func GcServiceIDForTest() string {
 	return fmt.Sprintf("ticdc-%s-%d", "default", 0)
@@ -276,6 +276,193 @@ func TestUpdateChangefeedAndUpstream(t *testing.T) {
 	require.Equal(t, changeFeedInfo.SinkURI, changefeedResult.SinkURI)
 }
 
+func TestCreateAndDeleteUpstreamInfo(t *testing.T) {
+	t.Parallel()
+
+	s := &Tester{}
+	s.SetUpTest(t)
+	defer s.TearDownTest(t)
+
+	ctx := context.Background()
+	upstreamInfo := &model.UpstreamInfo{
+		ID:          1,
+		Name:        "up1",
+		PDEndpoints: "http://127.0.0.1:2385",
+	}
+	err := s.client.CreateUpstreamInfo(ctx, upstreamInfo, model.DefaultNamespace)
+	require.NoError(t, err)
+	err = s.client.CreateUpstreamInfo(ctx, upstreamInfo, model.DefaultNamespace)
+	require.True(t, cerror.ErrUpstreamAlreadyExists.Equal(err))
+	err = s.client.CreateUpstreamInfo(ctx, &model.UpstreamInfo{ID: 2}, "test")
+	require.NoError(t, err)
+
+	infos, err := s.client.GetUpstreamInfos(ctx, model.DefaultNamespace)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, upstreamInfo, infos[1])
+
+	// the name of the upstream is kept when a changefeed updates it.
+	changefeedInfo := &model.ChangeFeedInfo{
+		UpstreamID: 1,
+		Namespace:  model.DefaultNamespace,
+		ID:         "test-upstream-name",
+		SinkURI:    "blackhole://",
+	}
+	err = s.client.CreateChangefeedInfo(ctx, &model.UpstreamInfo{
+		ID:          1,
+		PDEndpoints: "http://127.0.0.1:2386",
+	}, changefeedInfo)
+	require.NoError(t, err)
+	info, err := s.client.GetUpstreamInfo(ctx, 1, model.DefaultNamespace)
+	require.NoError(t, err)
+	require.Equal(t, "up1", info.Name)
+	require.Equal(t, "http://127.0.0.1:2386", info.PDEndpoints)
+
+	err = s.client.DeleteUpstreamInfo(ctx, 1, model.DefaultNamespace)
+	require.NoError(t, err)
+	err = s.client.DeleteUpstreamInfo(ctx, 1, model.DefaultNamespace)
+	require.True(t, cerror.ErrUpstreamNotFound.Equal(err))
+	infos, err = s.client.GetUpstreamInfos(ctx, model.DefaultNamespace)
+	require.NoError(t, err)
+	require.Len(t, infos, 0)
+}
+
+func TestNamespaceInfoAndChangefeedTemplate(t *testing.T) {
+	t.Parallel()
+
+	s := &Tester{}
+	s.SetUpTest(t)
+	defer s.TearDownTest(t)
+
+	ctx := context.Background()
+	nsInfo := &model.NamespaceInfo{
+		Name:                 "ns1",
+		DefaultReplicaConfig: []byte(`{"memory_quota":1024}`),
+		Quota:                model.NamespaceQuota{MaxChangefeeds: 2},
+		Labels:               map[string]string{"team": "a"},
+	}
+	_, err := s.client.GetNamespaceInfo(ctx, "ns1")
+	require.True(t, cerror.ErrNamespaceNotFound.Equal(err))
+	err = s.client.UpdateNamespaceInfo(ctx, nsInfo)
+	require.True(t, cerror.ErrNamespaceNotFound.Equal(err))
+	err = s.client.CreateNamespaceInfo(ctx, nsInfo)
+	require.NoError(t, err)
+	err = s.client.CreateNamespaceInfo(ctx, nsInfo)
+	require.True(t, cerror.ErrNamespaceAlreadyExists.Equal(err))
+	err = s.client.CreateNamespaceInfo(ctx, &model.NamespaceInfo{Name: "ns2"})
+	require.NoError(t, err)
+
+	nsInfo.Quota.MaxMemoryQuota = 4096
+	err = s.client.UpdateNamespaceInfo(ctx, nsInfo)
+	require.NoError(t, err)
+	info, err := s.client.GetNamespaceInfo(ctx, "ns1")
+	require.NoError(t, err)
+	require.Equal(t, nsInfo, info)
+	infos, err := s.client.GetNamespaceInfos(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, nsInfo, infos["ns1"])
+
+	template := &model.ChangefeedTemplate{
+		Namespace:     "ns1",
+		Name:          "tpl1",
+		SinkURI:       "blackhole://",
+		ReplicaConfig: []byte(`{"force_replicate":true}`),
+	}
+	_, err = s.client.GetChangefeedTemplate(ctx, "ns1", "tpl1")
+	require.True(t, cerror.ErrChangefeedTemplateNotFound.Equal(err))
+	err = s.client.UpdateChangefeedTemplate(ctx, template)
+	require.True(t, cerror.ErrChangefeedTemplateNotFound.Equal(err))
+	err = s.client.CreateChangefeedTemplate(ctx, template)
+	require.NoError(t, err)
+	err = s.client.CreateChangefeedTemplate(ctx, template)
+	require.True(t, cerror.ErrChangefeedTemplateAlreadyExists.Equal(err))
+	err = s.client.CreateChangefeedTemplate(ctx, &model.ChangefeedTemplate{
+		Namespace: "ns1",
+		Name:      "tpl2",
+	})
+	require.NoError(t, err)
+
+	template.Description = "blackhole sink"
+	err = s.client.UpdateChangefeedTemplate(ctx, template)
+	require.NoError(t, err)
+	tpl, err := s.client.GetChangefeedTemplate(ctx, "ns1", "tpl1")
+	require.NoError(t, err)
+	require.Equal(t, template, tpl)
+	templates, err := s.client.GetChangefeedTemplates(ctx, "ns1")
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	require.Equal(t, template, templates["tpl1"])
+
+	err = s.client.DeleteChangefeedTemplate(ctx, "ns1", "tpl2")
+	require.NoError(t, err)
+	err = s.client.DeleteChangefeedTemplate(ctx, "ns1", "tpl2")
+	require.True(t, cerror.ErrChangefeedTemplateNotFound.Equal(err))
+
+	// the templates are removed with the namespace.
+	err = s.client.DeleteNamespaceInfo(ctx, "ns1")
+	require.NoError(t, err)
+	err = s.client.DeleteNamespaceInfo(ctx, "ns1")
+	require.True(t, cerror.ErrNamespaceNotFound.Equal(err))
+	templates, err = s.client.GetChangefeedTemplates(ctx, "ns1")
+	require.NoError(t, err)
+	require.Len(t, templates, 0)
+	infos, err = s.client.GetNamespaceInfos(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+}
+
+func TestNamespaceAndTemplateKeysInvisibleToOldCaptures(t *testing.T) {
+	t.Parallel()
+
+	s := &Tester{}
+	s.SetUpTest(t)
+	defer s.TearDownTest(t)
+
+	ctx := context.Background()
+	err := s.client.CreateNamespaceInfo(ctx, &model.NamespaceInfo{Name: "ns1"})
+	require.NoError(t, err)
+	err = s.client.CreateChangefeedTemplate(ctx, &model.ChangefeedTemplate{
+		Namespace: "ns1",
+		Name:      "tpl1",
+	})
+	require.NoError(t, err)
+	err = s.client.PutCaptureInfo(ctx, &model.CaptureInfo{ID: "capture-1"}, 0)
+	require.NoError(t, err)
+
+	// The captures of old versions watch and parse all the keys under the
+	// BaseKey of the cluster, and only know the key types below.
+	knownByOldCaptures := map[CDCKeyType]struct{}{
+		CDCKeyTypeOwner:            {},
+		CDCKeyTypeCapture:          {},
+		CDCKeyTypeChangefeedInfo:   {},
+		CDCKeyTypeChangeFeedStatus: {},
+		CDCKeyTypeTaskPosition:     {},
+		CDCKeyTypeMetaVersion:      {},
+		CDCKeyTypeUpStream:         {},
+	}
+	resp, err := s.client.Client.Get(ctx, BaseKey(s.client.ClusterID),
+		clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Kvs)
+	for _, kv := range resp.Kvs {
+		k := new(CDCKey)
+		require.NoError(t, k.Parse(s.client.ClusterID, string(kv.Key)))
+		require.Contains(t, knownByOldCaptures, k.Tp, string(kv.Key))
+	}
+
+	// The settings are not taken as another cluster.
+	require.NoError(t, s.client.CheckMultipleCDCClusterExist(ctx))
+	// The settings are cleared with the cluster.
+	require.NoError(t, s.client.ClearAllCDCInfo(ctx))
+	infos, err := s.client.GetNamespaceInfos(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 0)
+	templates, err := s.client.GetChangefeedTemplates(ctx, "ns1")
+	require.NoError(t, err)
+	require.Len(t, templates, 0)
+}
+
 func TestGetAllCaptureLeases(t *testing.T) {
 	t.Parallel()
 
@@ -39,6 +39,10 @@ const (
 	// metaVersionKey is the key path for metadata version
 	metaVersionKey = "/meta/meta-version"
 	upstreamKey    = "/upstream"
+	// namespaceKey is the key path for namespace settings, under the settings prefix
+	namespaceKey = "/namespace"
+	// templateKey is the key path for changefeed templates, under the settings prefix
+	templateKey = "/template"
 
 	// DeletionCounterKey is the key path for the counter of deleted keys
 	DeletionCounterKey = metaPrefix + "/meta/ticdc-delete-etcd-key-count"
@@ -50,6 +54,11 @@ const (
 
 	// MigrateBackupPrefix is the prefix of backup keys during a migration
 	migrateBackupPrefix = "/tidb/cdc/__backup__"
+	// settingsPrefix is the prefix of the namespace settings and changefeed
+	// templates of the clusters. It's out of the BaseKey of any cluster, which is
+	// watched by the owner and processors, so the captures of old versions never
+	// receive these keys and fail to parse them during a rolling upgrade.
+	settingsPrefix = "/tidb/cdc/__settings__"
 )
 
 // CDCKeyType is the type of etcd key
@@ -65,6 +74,8 @@ const (
 	CDCKeyTypeTaskPosition
 	CDCKeyTypeMetaVersion
 	CDCKeyTypeUpStream
+	CDCKeyTypeNamespace
+	CDCKeyTypeChangefeedTemplate
 )
 
 // CDCKey represents an etcd key which is defined by TiCDC
@@ -99,6 +110,7 @@ type CDCKey struct {
 	ClusterID    string
 	UpstreamID   model.UpstreamID
 	Namespace    string
+	TemplateName string
 }
 
 // BaseKey is the common prefix of the keys with cluster id in CDC
@@ -109,6 +121,12 @@ func BaseKey(clusterID string) string {
 	return fmt.Sprintf("/tidb/cdc/%s", clusterID)
 }
 
+// SettingsKey is the common prefix of the namespace settings and changefeed
+// templates of a cluster.
+func SettingsKey(clusterID string) string {
+	return settingsPrefix + "/" + clusterID
+}
+
 // NamespacedPrefix returns the etcd prefix of changefeed data
 func NamespacedPrefix(clusterID, namespace string) string {
 	return BaseKey(clusterID) + "/" + namespace
@@ -116,6 +134,9 @@ func NamespacedPrefix(clusterID, namespace string) string {
 
 // Parse parses the given etcd key
 func (k *CDCKey) Parse(clusterID, key string) error {
+	if strings.HasPrefix(key, SettingsKey(clusterID)+"/") {
+		return k.parseSettings(clusterID, key[len(SettingsKey(clusterID)):])
+	}
 	if !strings.HasPrefix(key, BaseKey(clusterID)) {
 		return cerror.ErrInvalidEtcdKey.GenWithStackByArgs(key)
 	}
@@ -191,6 +212,27 @@ func (k *CDCKey) Parse(clusterID, key string) error {
 	return nil
 }
 
+// parseSettings parses the key under the settings prefix of the cluster.
+func (k *CDCKey) parseSettings(clusterID, key string) error {
+	k.ClusterID = clusterID
+	switch {
+	case strings.HasPrefix(key, namespaceKey+"/"):
+		k.Tp = CDCKeyTypeNamespace
+		k.Namespace = key[len(namespaceKey)+1:]
+	case strings.HasPrefix(key, templateKey+"/"):
+		splitKey := strings.SplitN(key[len(templateKey)+1:], "/", 2)
+		if len(splitKey) != 2 {
+			return cerror.ErrInvalidEtcdKey.GenWithStackByArgs(key)
+		}
+		k.Tp = CDCKeyTypeChangefeedTemplate
+		k.Namespace = splitKey[0]
+		k.TemplateName = splitKey[1]
+	default:
+		return cerror.ErrInvalidEtcdKey.GenWithStackByArgs(key)
+	}
+	return nil
+}
+
 func (k *CDCKey) String() string {
 	switch k.Tp {
 	case CDCKeyTypeOwner:
@@ -215,6 +257,10 @@ func (k *CDCKey) String() string {
 		return fmt.Sprintf("%s%s/%d",
 			NamespacedPrefix(k.ClusterID, k.Namespace),
 			upstreamKey, k.UpstreamID)
+	case CDCKeyTypeNamespace:
+		return SettingsKey(k.ClusterID) + namespaceKey + "/" + k.Namespace
+	case CDCKeyTypeChangefeedTemplate:
+		return SettingsKey(k.ClusterID) + templateKey + "/" + k.Namespace + "/" + k.TemplateName
 	}
 	log.Panic("unreachable")
 	return ""
@@ -107,6 +107,21 @@ func TestEtcdKey(t *testing.T) {
 			Namespace:  model.DefaultNamespace,
 			UpstreamID: 12345,
 		},
+	}, {
+		key: "/tidb/cdc/__settings__/default/template/default/test-template",
+		expected: &CDCKey{
+			Tp:           CDCKeyTypeChangefeedTemplate,
+			ClusterID:    DefaultCDCClusterID,
+			Namespace:    model.DefaultNamespace,
+			TemplateName: "test-template",
+		},
+	}, {
+		key: "/tidb/cdc/__settings__/default/namespace/test-ns",
+		expected: &CDCKey{
+			Tp:        CDCKeyTypeNamespace,
+			ClusterID: DefaultCDCClusterID,
+			Namespace: "test-ns",
+		},
 	}, {
 		key: fmt.Sprintf("%s%s", DefaultClusterAndMetaPrefix, metaVersionKey),
 		expected: &CDCKey{
@@ -153,6 +168,12 @@ func TestEtcdKeyParseError(t *testing.T) {
 	}, {
 		key:   "/tidb/cdc/default/default/abcd",
 		error: true,
+	}, {
+		key:   "/tidb/cdc/__settings__/default/template/test-template",
+		error: true,
+	}, {
+		key:   "/tidb/cdc/__settings__/default/abcd",
+		error: true,
 	}}
 	for _, tc := range testCases {
 		k := new(CDCKey)
@@ -164,7 +185,7 @@ func TestEtcdKeyParseError(t *testing.T) {
 		}
 	}
 	k := new(CDCKey)
-	k.Tp = CDCKeyTypeUpStream + 1
+	k.Tp = CDCKeyTypeChangefeedTemplate + 1
 	require.Panics(t, func() {
 		_ = k.String()
 	})
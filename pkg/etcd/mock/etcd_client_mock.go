@@ -135,6 +135,48 @@ func (mr *MockCDCEtcdClientMockRecorder) CreateChangefeedInfo(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangefeedInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).CreateChangefeedInfo), arg0, arg1, arg2)
 }
 
+// CreateChangefeedTemplate mocks base method.
+func (m *MockCDCEtcdClient) CreateChangefeedTemplate(ctx context.Context, template *model.ChangefeedTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChangefeedTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChangefeedTemplate indicates an expected call of CreateChangefeedTemplate.
+func (mr *MockCDCEtcdClientMockRecorder) CreateChangefeedTemplate(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangefeedTemplate", reflect.TypeOf((*MockCDCEtcdClient)(nil).CreateChangefeedTemplate), ctx, template)
+}
+
+// CreateNamespaceInfo mocks base method.
+func (m *MockCDCEtcdClient) CreateNamespaceInfo(ctx context.Context, info *model.NamespaceInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamespaceInfo", ctx, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNamespaceInfo indicates an expected call of CreateNamespaceInfo.
+func (mr *MockCDCEtcdClientMockRecorder) CreateNamespaceInfo(ctx, info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespaceInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).CreateNamespaceInfo), ctx, info)
+}
+
+// CreateUpstreamInfo mocks base method.
+func (m *MockCDCEtcdClient) CreateUpstreamInfo(ctx context.Context, info *model.UpstreamInfo, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpstreamInfo", ctx, info, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUpstreamInfo indicates an expected call of CreateUpstreamInfo.
+func (mr *MockCDCEtcdClientMockRecorder) CreateUpstreamInfo(ctx, info, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpstreamInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).CreateUpstreamInfo), ctx, info, namespace)
+}
+
 // DeleteCaptureInfo mocks base method.
 func (m *MockCDCEtcdClient) DeleteCaptureInfo(arg0 context.Context, arg1 model.CaptureID) error {
 	m.ctrl.T.Helper()
@@ -149,6 +191,48 @@ func (mr *MockCDCEtcdClientMockRecorder) DeleteCaptureInfo(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCaptureInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).DeleteCaptureInfo), arg0, arg1)
 }
 
+// DeleteChangefeedTemplate mocks base method.
+func (m *MockCDCEtcdClient) DeleteChangefeedTemplate(ctx context.Context, namespace string, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChangefeedTemplate", ctx, namespace, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChangefeedTemplate indicates an expected call of DeleteChangefeedTemplate.
+func (mr *MockCDCEtcdClientMockRecorder) DeleteChangefeedTemplate(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChangefeedTemplate", reflect.TypeOf((*MockCDCEtcdClient)(nil).DeleteChangefeedTemplate), ctx, namespace, name)
+}
+
+// DeleteNamespaceInfo mocks base method.
+func (m *MockCDCEtcdClient) DeleteNamespaceInfo(ctx context.Context, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNamespaceInfo", ctx, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNamespaceInfo indicates an expected call of DeleteNamespaceInfo.
+func (mr *MockCDCEtcdClientMockRecorder) DeleteNamespaceInfo(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNamespaceInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).DeleteNamespaceInfo), ctx, namespace)
+}
+
+// DeleteUpstreamInfo mocks base method.
+func (m *MockCDCEtcdClient) DeleteUpstreamInfo(ctx context.Context, upstreamID model.UpstreamID, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpstreamInfo", ctx, upstreamID, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpstreamInfo indicates an expected call of DeleteUpstreamInfo.
+func (mr *MockCDCEtcdClientMockRecorder) DeleteUpstreamInfo(ctx, upstreamID, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpstreamInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).DeleteUpstreamInfo), ctx, upstreamID, namespace)
+}
+
 // GetAllCDCInfo mocks base method.
 func (m *MockCDCEtcdClient) GetAllCDCInfo(ctx context.Context) ([]*mvccpb.KeyValue, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +310,36 @@ func (mr *MockCDCEtcdClientMockRecorder) GetChangeFeedStatus(ctx, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeFeedStatus", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangeFeedStatus), ctx, id)
 }
 
+// GetChangefeedTemplate mocks base method.
+func (m *MockCDCEtcdClient) GetChangefeedTemplate(ctx context.Context, namespace string, name string) (*model.ChangefeedTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangefeedTemplate", ctx, namespace, name)
+	ret0, _ := ret[0].(*model.ChangefeedTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangefeedTemplate indicates an expected call of GetChangefeedTemplate.
+func (mr *MockCDCEtcdClientMockRecorder) GetChangefeedTemplate(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangefeedTemplate", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangefeedTemplate), ctx, namespace, name)
+}
+
+// GetChangefeedTemplates mocks base method.
+func (m *MockCDCEtcdClient) GetChangefeedTemplates(ctx context.Context, namespace string) (map[string]*model.ChangefeedTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangefeedTemplates", ctx, namespace)
+	ret0, _ := ret[0].(map[string]*model.ChangefeedTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangefeedTemplates indicates an expected call of GetChangefeedTemplates.
+func (mr *MockCDCEtcdClientMockRecorder) GetChangefeedTemplates(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangefeedTemplates", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetChangefeedTemplates), ctx, namespace)
+}
+
 // GetClusterID mocks base method.
 func (m *MockCDCEtcdClient) GetClusterID() string {
 	m.ctrl.T.Helper()
@@ -282,6 +396,36 @@ func (mr *MockCDCEtcdClientMockRecorder) GetGCServiceID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGCServiceID", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetGCServiceID))
 }
 
+// GetNamespaceInfo mocks base method.
+func (m *MockCDCEtcdClient) GetNamespaceInfo(ctx context.Context, namespace string) (*model.NamespaceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespaceInfo", ctx, namespace)
+	ret0, _ := ret[0].(*model.NamespaceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamespaceInfo indicates an expected call of GetNamespaceInfo.
+func (mr *MockCDCEtcdClientMockRecorder) GetNamespaceInfo(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetNamespaceInfo), ctx, namespace)
+}
+
+// GetNamespaceInfos mocks base method.
+func (m *MockCDCEtcdClient) GetNamespaceInfos(ctx context.Context) (map[string]*model.NamespaceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespaceInfos", ctx)
+	ret0, _ := ret[0].(map[string]*model.NamespaceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamespaceInfos indicates an expected call of GetNamespaceInfos.
+func (mr *MockCDCEtcdClientMockRecorder) GetNamespaceInfos(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceInfos", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetNamespaceInfos), ctx)
+}
+
 // GetOwnerID mocks base method.
 func (m *MockCDCEtcdClient) GetOwnerID(arg0 context.Context) (model.CaptureID, error) {
 	m.ctrl.T.Helper()
@@ -327,6 +471,21 @@ func (mr *MockCDCEtcdClientMockRecorder) GetUpstreamInfo(ctx, upstreamID, namesp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetUpstreamInfo), ctx, upstreamID, namespace)
 }
 
+// GetUpstreamInfos mocks base method.
+func (m *MockCDCEtcdClient) GetUpstreamInfos(ctx context.Context, namespace string) (map[model.UpstreamID]*model.UpstreamInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamInfos", ctx, namespace)
+	ret0, _ := ret[0].(map[model.UpstreamID]*model.UpstreamInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpstreamInfos indicates an expected call of GetUpstreamInfos.
+func (mr *MockCDCEtcdClientMockRecorder) GetUpstreamInfos(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamInfos", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetUpstreamInfos), ctx, namespace)
+}
+
 // PutCaptureInfo mocks base method.
 func (m *MockCDCEtcdClient) PutCaptureInfo(arg0 context.Context, arg1 *model.CaptureInfo, arg2 clientv3.LeaseID) error {
 	m.ctrl.T.Helper()
@@ -368,3 +527,31 @@ func (mr *MockCDCEtcdClientMockRecorder) UpdateChangefeedAndUpstream(ctx, upstre
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangefeedAndUpstream", reflect.TypeOf((*MockCDCEtcdClient)(nil).UpdateChangefeedAndUpstream), ctx, upstreamInfo, changeFeedInfo)
 }
+
+// UpdateChangefeedTemplate mocks base method.
+func (m *MockCDCEtcdClient) UpdateChangefeedTemplate(ctx context.Context, template *model.ChangefeedTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChangefeedTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChangefeedTemplate indicates an expected call of UpdateChangefeedTemplate.
+func (mr *MockCDCEtcdClientMockRecorder) UpdateChangefeedTemplate(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangefeedTemplate", reflect.TypeOf((*MockCDCEtcdClient)(nil).UpdateChangefeedTemplate), ctx, template)
+}
+
+// UpdateNamespaceInfo mocks base method.
+func (m *MockCDCEtcdClient) UpdateNamespaceInfo(ctx context.Context, info *model.NamespaceInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNamespaceInfo", ctx, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNamespaceInfo indicates an expected call of UpdateNamespaceInfo.
+func (mr *MockCDCEtcdClientMockRecorder) UpdateNamespaceInfo(ctx, info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).UpdateNamespaceInfo), ctx, info)
+}
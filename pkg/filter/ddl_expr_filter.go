@@ -0,0 +1,222 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/pkg/expression"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// ddlMetaTableName is the name of the virtual table that
+	// a DDL expression is evaluated on.
+	ddlMetaTableName = "ddl"
+
+	// Columns of the virtual table, the order must be the same as
+	// the order of datums built by ddlMetaDatums.
+	ddlMetaSchemaColumn = "schema_name"
+	ddlMetaTableColumn  = "table_name"
+	ddlMetaTypeColumn   = "ddl_type"
+	ddlMetaQueryColumn  = "query"
+)
+
+// ddlMetaTableInfo is the virtual table info used to parse DDL expressions.
+// Every column is a string, the value of ddl_type is the name of the TiDB
+// DDL action type, such as "create table", "add index" or "drop index".
+var ddlMetaTableInfo = newDDLMetaTableInfo()
+
+func newDDLMetaTableInfo() *timodel.TableInfo {
+	columns := []string{
+		ddlMetaSchemaColumn,
+		ddlMetaTableColumn,
+		ddlMetaTypeColumn,
+		ddlMetaQueryColumn,
+	}
+	ti := &timodel.TableInfo{
+		Name:  pmodel.NewCIStr(ddlMetaTableName),
+		State: timodel.StatePublic,
+	}
+	for i, name := range columns {
+		col := &timodel.ColumnInfo{
+			ID:     int64(i + 1),
+			Name:   pmodel.NewCIStr(name),
+			Offset: i,
+			State:  timodel.StatePublic,
+		}
+		col.SetType(mysql.TypeVarchar)
+		col.SetCharset(mysql.UTF8MB4Charset)
+		col.SetCollate(mysql.UTF8MB4DefaultCollation)
+		ti.Columns = append(ti.Columns, col)
+	}
+	return ti
+}
+
+// ddlMetaDatums returns the datums of the virtual table row of a DDL event.
+func ddlMetaDatums(schema, table string, ddlType timodel.ActionType, query string) []types.Datum {
+	return []types.Datum{
+		types.NewStringDatum(schema),
+		types.NewStringDatum(table),
+		types.NewStringDatum(ddlType.String()),
+		types.NewStringDatum(query),
+	}
+}
+
+// ddlExprFilterRule only be used by ddlExprFilter.
+type ddlExprFilterRule struct {
+	mu sync.Mutex
+
+	tableMatcher tfilter.Filter
+	expr         expression.Expression
+	config       *config.EventFilterRule
+
+	sessCtx sessionctx.Context
+}
+
+func newDDLExprFilterRule(
+	sessCtx sessionctx.Context,
+	cfg *config.EventFilterRule,
+) (*ddlExprFilterRule, error) {
+	tf, err := tfilter.Parse(cfg.Matcher)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, cfg.Matcher)
+	}
+	// The virtual table never changes, so the expression can be
+	// built and verified once the rule is created.
+	e, err := expression.ParseSimpleExprWithTableInfo(
+		sessCtx.GetExprCtx(), cfg.IgnoreDDLExpr, ddlMetaTableInfo)
+	if err != nil {
+		if plannererrors.ErrUnknownColumn.Equal(err) {
+			log.Error("meet unknown column when generating ddl expression",
+				zap.String("expression", cfg.IgnoreDDLExpr),
+				zap.Error(err))
+			return nil, cerror.ErrExpressionColumnNotFound.
+				FastGenByArgs(getColumnFromError(err), ddlMetaTableName, cfg.IgnoreDDLExpr)
+		}
+		log.Error("failed to parse ddl expression", zap.Error(err))
+		return nil, cerror.ErrExpressionParseFailed.FastGenByArgs(cfg.IgnoreDDLExpr)
+	}
+	return &ddlExprFilterRule{
+		tableMatcher: tf,
+		expr:         e,
+		config:       cfg,
+		sessCtx:      sessCtx,
+	}, nil
+}
+
+func (r *ddlExprFilterRule) shouldSkipDDL(
+	schema, table string, ddlType timodel.ActionType, query string,
+) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row := chunk.MutRowFromDatums(ddlMetaDatums(schema, table, ddlType, query)).ToRow()
+	d, err := r.expr.Eval(r.sessCtx.GetExprCtx().GetEvalCtx(), row)
+	if err != nil {
+		log.Error("failed to eval ddl expression", zap.Error(err))
+		return false, errors.Trace(err)
+	}
+	if d.GetInt64() == 1 {
+		return true, nil
+	}
+	return false, nil
+}
+
+// ddlExprFilter is a filter that filters DDL events by SQL expression
+// on the DDL metadata.
+type ddlExprFilter struct {
+	rules []*ddlExprFilterRule
+}
+
+func newDDLExprFilter(
+	timezone string,
+	cfg *config.FilterConfig,
+) (*ddlExprFilter, error) {
+	res := &ddlExprFilter{}
+	sessCtx := utils.NewSessionCtx(map[string]string{
+		"time_zone": timezone,
+	})
+	for _, rule := range cfg.EventFilters {
+		if rule.IgnoreDDLExpr == "" {
+			continue
+		}
+		r, err := newDDLExprFilterRule(sessCtx, rule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res.rules = append(res.rules, r)
+	}
+	return res, nil
+}
+
+func (f *ddlExprFilter) getRules(schema, table string) []*ddlExprFilterRule {
+	res := make([]*ddlExprFilterRule, 0)
+	for _, rule := range f.rules {
+		if len(table) == 0 {
+			if rule.tableMatcher.MatchSchema(schema) {
+				res = append(res, rule)
+			}
+		} else {
+			if rule.tableMatcher.MatchTable(schema, table) {
+				res = append(res, rule)
+			}
+		}
+	}
+	return res
+}
+
+// shouldSkipDDL skips ddl event by sql expression.
+func (f *ddlExprFilter) shouldSkipDDL(ddl *model.DDLEvent) (bool, error) {
+	if len(f.rules) == 0 {
+		return false, nil
+	}
+	eventType := ddlToEventType(ddl.Type)
+	if eventType == bf.NullEvent {
+		return false, nil
+	}
+	schema := ddl.TableInfo.TableName.Schema
+	table := ddl.TableInfo.TableName.Table
+	// Keep the same behavior as sql event filter, a rename table ddl
+	// is matched by its old table name.
+	if eventType == bf.RenameTable && ddl.PreTableInfo != nil {
+		schema = ddl.PreTableInfo.TableName.Schema
+		table = ddl.PreTableInfo.TableName.Table
+	}
+	for _, rule := range f.getRules(schema, table) {
+		ignore, err := rule.shouldSkipDDL(schema, table, ddl.Type, ddl.Query)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if ignore {
+			return true, nil
+		}
+	}
+	return false, nil
+}
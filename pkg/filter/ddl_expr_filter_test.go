@@ -0,0 +1,175 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/pingcap/errors"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSkipDDLByExpr(t *testing.T) {
+	t.Parallel()
+
+	type innerCase struct {
+		schema    string
+		table     string
+		preSchema string
+		preTable  string
+		query     string
+		ddlType   timodel.ActionType
+		skip      bool
+	}
+
+	cfg := &config.FilterConfig{
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:       []string{"test.*"},
+				IgnoreDDLExpr: "ddl_type = 'drop table' and table_name like 'tmp\\_%'",
+			},
+			{
+				Matcher:       []string{"audit.*"},
+				IgnoreDDLExpr: "ddl_type in ('add index', 'drop index') or query like '%/* skip */%'",
+			},
+			{
+				Matcher:       []string{"*.*"},
+				IgnoreDDLExpr: "schema_name = 'archive'",
+			},
+		},
+	}
+	cases := []innerCase{
+		{
+			schema:  "test",
+			table:   "tmp_orders",
+			query:   "drop table tmp_orders",
+			ddlType: timodel.ActionDropTable,
+			skip:    true,
+		},
+		{
+			schema:  "test",
+			table:   "orders",
+			query:   "drop table orders",
+			ddlType: timodel.ActionDropTable,
+			skip:    false,
+		},
+		{
+			schema:  "test",
+			table:   "tmp_orders",
+			query:   "truncate table tmp_orders",
+			ddlType: timodel.ActionTruncateTable,
+			skip:    false,
+		},
+		{
+			schema:  "audit",
+			table:   "log",
+			query:   "alter table log add index idx_ts(ts)",
+			ddlType: timodel.ActionAddIndex,
+			skip:    true,
+		},
+		{
+			schema:  "audit",
+			table:   "log",
+			query:   "alter table log add column c int /* skip */",
+			ddlType: timodel.ActionAddColumn,
+			skip:    true,
+		},
+		{
+			schema:  "audit",
+			table:   "log",
+			query:   "alter table log add column c int",
+			ddlType: timodel.ActionAddColumn,
+			skip:    false,
+		},
+		{
+			schema:  "archive",
+			query:   "create database archive",
+			ddlType: timodel.ActionCreateSchema,
+			skip:    true,
+		},
+		{ // rename table is matched by its old name
+			schema:    "test",
+			table:     "orders",
+			preSchema: "test",
+			preTable:  "tmp_orders",
+			query:     "rename table tmp_orders to orders",
+			ddlType:   timodel.ActionRenameTable,
+			skip:      false,
+		},
+	}
+
+	f, err := newDDLExprFilter("", cfg)
+	require.NoError(t, err)
+	require.Len(t, f.rules, 3)
+	for _, c := range cases {
+		ddl := &model.DDLEvent{
+			TableInfo: &model.TableInfo{
+				TableName: model.TableName{
+					Schema: c.schema,
+					Table:  c.table,
+				},
+			},
+			Query: c.query,
+			Type:  c.ddlType,
+		}
+		if c.preTable != "" {
+			ddl.PreTableInfo = &model.TableInfo{
+				TableName: model.TableName{
+					Schema: c.preSchema,
+					Table:  c.preTable,
+				},
+			}
+		}
+		skip, err := f.shouldSkipDDL(ddl)
+		require.NoError(t, err)
+		require.Equal(t, c.skip, skip, "case: %+v", c)
+	}
+}
+
+func TestNewDDLExprFilterError(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expr   string
+		err    error
+		errMsg string
+	}{
+		{
+			expr:   "table_name = 't1' and column_name = 'c1'",
+			err:    cerror.ErrExpressionColumnNotFound,
+			errMsg: "Cannot find column 'column_name' from table 'ddl'",
+		},
+		{
+			expr:   "ddl_type = = 'drop table'",
+			err:    cerror.ErrExpressionParseFailed,
+			errMsg: "There is a syntax error in",
+		},
+	}
+	for _, c := range cases {
+		_, err := newDDLExprFilter("", &config.FilterConfig{
+			EventFilters: []*config.EventFilterRule{
+				{
+					Matcher:       []string{"*.*"},
+					IgnoreDDLExpr: c.expr,
+				},
+			},
+		})
+		require.True(t, errors.ErrorEqual(c.err, err), "case: %+v", c, err)
+		require.Contains(t, err.Error(), c.errMsg)
+	}
+}
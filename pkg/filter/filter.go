@@ -24,6 +24,9 @@ import (
 const (
 	// SyncPointTable is the tale name use to write ts-map when sync-point is enable.
 	SyncPointTable = "syncpoint_v1"
+	// CheckpointTable is the table name use to write the checkpoints of changefeeds
+	// when the checkpoint table of the mysql sink is enabled.
+	CheckpointTable = "checkpoint_v1"
 	// TiCDCSystemSchema is the schema only use by TiCDC.
 	TiCDCSystemSchema = "tidb_cdc"
 	// LightningTaskInfoSchema is the schema only generated by Lightning
@@ -120,6 +123,8 @@ type filter struct {
 	dmlExprFilter *dmlExprFilter
 	// sqlEventFilter is used to filter out dml/ddl event by its type or query.
 	sqlEventFilter *sqlEventFilter
+	// ddlExprFilter is used to filter out ddl event by its metadata.
+	ddlExprFilter *ddlExprFilter
 	// ignoreTxnStartTs is used to filter out dml/ddl event by its starsTs.
 	ignoreTxnStartTs []uint64
 }
@@ -143,10 +148,15 @@ func NewFilter(cfg *config.ReplicaConfig, tz string) (Filter, error) {
 	if err != nil {
 		return nil, err
 	}
+	ddlExprFilter, err := newDDLExprFilter(tz, cfg.Filter)
+	if err != nil {
+		return nil, err
+	}
 	return &filter{
 		tableFilter:      f,
 		dmlExprFilter:    dmlExprFilter,
 		sqlEventFilter:   sqlEventFilter,
+		ddlExprFilter:    ddlExprFilter,
 		ignoreTxnStartTs: cfg.Filter.IgnoreTxnStartTs,
 	}, nil
 }
@@ -154,7 +164,7 @@ func NewFilter(cfg *config.ReplicaConfig, tz string) (Filter, error) {
 // ShouldIgnoreDMLEvent checks if a DML event should be ignore by conditions below:
 // 0. By startTs.
 // 1. By table name.
-// 2. By type.
+// 2. By type and changed columns.
 // 3. By columns value.
 func (f *filter) ShouldIgnoreDMLEvent(
 	dml *model.RowChangedEvent,
@@ -198,6 +208,7 @@ func (f *filter) ShouldDiscardDDL(ddlType timodel.ActionType, schema, table stri
 // 0. By startTs.
 // 1. By ddl type.
 // 2. By ddl query.
+// 3. By expression on ddl metadata.
 //
 // If a ddl is ignored, it will be applied to cdc's schema storage,
 // but will not be sent to downstream.
@@ -213,7 +224,11 @@ func (f *filter) ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error) {
 	if f.shouldIgnoreStartTs(ddl.StartTs) {
 		return true, nil
 	}
-	return f.sqlEventFilter.shouldSkipDDL(ddl)
+	ignored, err := f.sqlEventFilter.shouldSkipDDL(ddl)
+	if err != nil || ignored {
+		return ignored, err
+	}
+	return f.ddlExprFilter.shouldSkipDDL(ddl)
 }
 
 // ShouldIgnoreTable returns true if the specified table should be ignored by this changefeed.
@@ -231,6 +246,9 @@ func (f *filter) ShouldIgnoreSchema(schema string) bool {
 }
 
 func (f *filter) Verify(tableInfos []*model.TableInfo) error {
+	if err := f.sqlEventFilter.verify(tableInfos); err != nil {
+		return err
+	}
 	return f.dmlExprFilter.verify(tableInfos)
 }
 
@@ -14,8 +14,11 @@
 package filter
 
 import (
+	"strings"
+
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
@@ -46,6 +49,11 @@ type sqlEventRule struct {
 	// which means not match `test.t1`.
 	tf tfilter.Filter
 	bf *bf.BinlogEvent
+	// ignoreUpdateChangedColumns contains the lower case names of columns,
+	// an update event which only changes these columns will be ignored.
+	ignoreUpdateChangedColumns map[string]struct{}
+	// config is only used to verify the rule against the table infos.
+	config *config.EventFilterRule
 }
 
 func newSQLEventFilterRule(cfg *config.EventFilterRule) (*sqlEventRule, error) {
@@ -55,7 +63,14 @@ func newSQLEventFilterRule(cfg *config.EventFilterRule) (*sqlEventRule, error) {
 	}
 
 	res := &sqlEventRule{
-		tf: tf,
+		tf:     tf,
+		config: cfg,
+	}
+	if len(cfg.IgnoreUpdateChangedColumns) != 0 {
+		res.ignoreUpdateChangedColumns = make(map[string]struct{}, len(cfg.IgnoreUpdateChangedColumns))
+		for _, col := range cfg.IgnoreUpdateChangedColumns {
+			res.ignoreUpdateChangedColumns[strings.ToLower(col)] = struct{}{}
+		}
 	}
 
 	if err := verifyIgnoreEvents(cfg.IgnoreEvent); err != nil {
@@ -78,6 +93,60 @@ func newSQLEventFilterRule(cfg *config.EventFilterRule) (*sqlEventRule, error) {
 	return res, nil
 }
 
+// verify checks that every column in ignore-update-changed-columns
+// exists in all the tables matched by this rule.
+func (r *sqlEventRule) verify(tableInfos []*model.TableInfo) error {
+	if len(r.ignoreUpdateChangedColumns) == 0 {
+		return nil
+	}
+	for _, ti := range tableInfos {
+		if !r.tf.MatchTable(ti.TableName.Schema, ti.TableName.Table) {
+			continue
+		}
+		for _, col := range r.config.IgnoreUpdateChangedColumns {
+			if timodel.FindColumnInfo(ti.Columns, strings.ToLower(col)) == nil {
+				return cerror.ErrExpressionColumnNotFound.FastGenByArgs(
+					col, ti.TableName.String(), strings.Join(r.config.IgnoreUpdateChangedColumns, ","))
+			}
+		}
+	}
+	return nil
+}
+
+// shouldSkipUpdateByChangedColumns returns true if all the columns changed
+// by the update event are in ignoreUpdateChangedColumns.
+// An update event which changes nothing is not skipped.
+func (r *sqlEventRule) shouldSkipUpdateByChangedColumns(event *model.RowChangedEvent) bool {
+	if len(r.ignoreUpdateChangedColumns) == 0 {
+		return false
+	}
+	preValues := make(map[int64]interface{}, len(event.PreColumns))
+	for _, col := range event.PreColumns {
+		if col != nil {
+			preValues[col.ColumnID] = col.Value
+		}
+	}
+	changed := false
+	for _, col := range event.Columns {
+		if col == nil {
+			continue
+		}
+		preValue, ok := preValues[col.ColumnID]
+		if ok && isColumnValueEqual(preValue, col.Value) {
+			continue
+		}
+		colInfo, ok := event.TableInfo.GetColumnInfo(col.ColumnID)
+		if !ok {
+			return false
+		}
+		if _, ok := r.ignoreUpdateChangedColumns[colInfo.Name.L]; !ok {
+			return false
+		}
+		changed = true
+	}
+	return changed
+}
+
 func verifyIgnoreEvents(types []bf.EventType) error {
 	typesMap := make(map[bf.EventType]struct{}, len(SupportedEventTypes()))
 	for _, et := range SupportedEventTypes() {
@@ -115,6 +184,17 @@ func (f *sqlEventFilter) addRule(cfg *config.EventFilterRule) error {
 	return nil
 }
 
+// verify checks if all rules in this filter is valid.
+func (f *sqlEventFilter) verify(tableInfos []*model.TableInfo) error {
+	for _, rule := range f.rules {
+		if err := rule.verify(tableInfos); err != nil {
+			log.Error("failed to verify sql event filter rule", zap.Error(err))
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func (f *sqlEventFilter) getRules(schema, table string) []*sqlEventRule {
 	res := make([]*sqlEventRule, 0)
 	for _, rule := range f.rules {
@@ -217,6 +297,9 @@ func (f *sqlEventFilter) shouldSkipDML(event *model.RowChangedEvent) (bool, erro
 		if action == bf.Ignore {
 			return true, nil
 		}
+		if et == bf.UpdateEvent && rule.shouldSkipUpdateByChangedColumns(event) {
+			return true, nil
+		}
 	}
 	return false, nil
 }
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap/errors"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -493,6 +494,137 @@ func TestShouldSkipDML(t *testing.T) {
 	}
 }
 
+func TestShouldSkipUpdateByChangedColumns(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.FilterConfig{
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:                    []string{"test.user"},
+				IgnoreUpdateChangedColumns: []string{"LAST_SEEN_AT", "visits"},
+			},
+		},
+	}
+	f, err := newSQLEventFilter(cfg)
+	require.NoError(t, err)
+
+	tableInfo := model.BuildTableInfo("test", "user", []*model.Column{
+		{Name: "id", Type: mysql.TypeLong},
+		{Name: "name", Type: mysql.TypeVarchar},
+		{Name: "last_seen_at", Type: mysql.TypeLonglong},
+		{Name: "visits", Type: mysql.TypeLong},
+	}, nil)
+	otherTableInfo := model.BuildTableInfo("test", "other", []*model.Column{
+		{Name: "id", Type: mysql.TypeLong},
+		{Name: "last_seen_at", Type: mysql.TypeLonglong},
+	}, nil)
+
+	buildColumns := func(ti *model.TableInfo, values map[string]interface{}) []*model.ColumnData {
+		res := make([]*model.ColumnData, 0, len(values))
+		for name, value := range values {
+			res = append(res, &model.ColumnData{
+				ColumnID: ti.ForceGetColumnIDByName(name),
+				Value:    value,
+			})
+		}
+		return res
+	}
+
+	cases := []struct {
+		ti   *model.TableInfo
+		pre  map[string]interface{}
+		post map[string]interface{}
+		skip bool
+	}{
+		{ // only last_seen_at changed
+			ti:   tableInfo,
+			pre:  map[string]interface{}{"id": 1, "name": []byte("a"), "last_seen_at": 1, "visits": 1},
+			post: map[string]interface{}{"id": 1, "name": []byte("a"), "last_seen_at": 2, "visits": 1},
+			skip: true,
+		},
+		{ // last_seen_at and visits changed
+			ti:   tableInfo,
+			pre:  map[string]interface{}{"id": 1, "name": []byte("a"), "last_seen_at": 1, "visits": 1},
+			post: map[string]interface{}{"id": 1, "name": []byte("a"), "last_seen_at": 2, "visits": 2},
+			skip: true,
+		},
+		{ // name changed too
+			ti:   tableInfo,
+			pre:  map[string]interface{}{"id": 1, "name": []byte("a"), "last_seen_at": 1, "visits": 1},
+			post: map[string]interface{}{"id": 1, "name": []byte("b"), "last_seen_at": 2, "visits": 1},
+			skip: false,
+		},
+		{ // nothing changed
+			ti:   tableInfo,
+			pre:  map[string]interface{}{"id": 1, "name": []byte("a"), "last_seen_at": 1, "visits": 1},
+			post: map[string]interface{}{"id": 1, "name": []byte("a"), "last_seen_at": 1, "visits": 1},
+			skip: false,
+		},
+		{ // table not matched
+			ti:   otherTableInfo,
+			pre:  map[string]interface{}{"id": 1, "last_seen_at": 1},
+			post: map[string]interface{}{"id": 1, "last_seen_at": 2},
+			skip: false,
+		},
+	}
+	for _, c := range cases {
+		event := &model.RowChangedEvent{
+			TableInfo:  c.ti,
+			PreColumns: buildColumns(c.ti, c.pre),
+			Columns:    buildColumns(c.ti, c.post),
+		}
+		skip, err := f.shouldSkipDML(event)
+		require.NoError(t, err)
+		require.Equal(t, c.skip, skip, "case: %+v", c)
+	}
+
+	// Insert and delete events are not affected.
+	skip, err := f.shouldSkipDML(&model.RowChangedEvent{
+		TableInfo: tableInfo,
+		Columns:   buildColumns(tableInfo, map[string]interface{}{"last_seen_at": 1}),
+	})
+	require.NoError(t, err)
+	require.False(t, skip)
+}
+
+func TestVerifyIgnoreUpdateChangedColumns(t *testing.T) {
+	t.Parallel()
+
+	tableInfos := []*model.TableInfo{
+		model.BuildTableInfo("test", "user", []*model.Column{
+			{Name: "id", Type: mysql.TypeLong},
+			{Name: "last_seen_at", Type: mysql.TypeLonglong},
+		}, nil),
+		model.BuildTableInfo("test", "order", []*model.Column{
+			{Name: "id", Type: mysql.TypeLong},
+		}, nil),
+	}
+
+	f, err := newSQLEventFilter(&config.FilterConfig{
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:                    []string{"test.user"},
+				IgnoreUpdateChangedColumns: []string{"Last_Seen_At"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, f.verify(tableInfos))
+
+	f, err = newSQLEventFilter(&config.FilterConfig{
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:                    []string{"test.*"},
+				IgnoreUpdateChangedColumns: []string{"last_seen_at"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	err = f.verify(tableInfos)
+	require.True(t, errors.ErrorEqual(cerror.ErrExpressionColumnNotFound, err), err)
+	require.Contains(t, err.Error(), "Cannot find column 'last_seen_at' from table 'test.order'")
+}
+
 func TestVerifyIgnoreEvents(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
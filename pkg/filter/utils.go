@@ -14,9 +14,11 @@
 package filter
 
 import (
+	"bytes"
 	"fmt"
 
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/types"
 	tifilter "github.com/pingcap/tidb/pkg/util/filter"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
@@ -63,6 +65,26 @@ func ddlToEventType(jobType timodel.ActionType) bf.EventType {
 	return bf.NullEvent
 }
 
+// DDLActionFromEventType returns the DDL action type of the given event type.
+// If several action types share the same event type, the smallest one is returned.
+// It is used by tools to build a DDL event from a human-readable event type.
+func DDLActionFromEventType(et bf.EventType) (timodel.ActionType, bool) {
+	var (
+		res   timodel.ActionType
+		found bool
+	)
+	for action, eventType := range ddlWhiteListMap {
+		if eventType != et {
+			continue
+		}
+		if !found || action < res {
+			res = action
+			found = true
+		}
+	}
+	return res, found
+}
+
 var alterTableSubType = []timodel.ActionType{
 	// table related DDLs
 	timodel.ActionRenameTable,
@@ -147,3 +169,24 @@ func completeExpression(suffix string) string {
 	}
 	return fmt.Sprintf("select * from t where %s", suffix)
 }
+
+// isColumnValueEqual checks whether the pre value and the new value of
+// a column in an update event are equal.
+func isColumnValueEqual(preValue, newValue interface{}) bool {
+	if preValue == nil || newValue == nil {
+		return preValue == newValue
+	}
+	preBytes, ok1 := preValue.([]byte)
+	newBytes, ok2 := newValue.([]byte)
+	if ok1 && ok2 {
+		return bytes.Equal(preBytes, newBytes)
+	}
+	preVector, ok1 := preValue.(types.VectorFloat32)
+	newVector, ok2 := newValue.(types.VectorFloat32)
+	if ok1 && ok2 {
+		return preVector.Compare(newVector) == 0
+	}
+	// the mounter uses the same table info to decode both values,
+	// so the values are of the same type.
+	return preValue == newValue
+}
@@ -16,7 +16,9 @@ package filter
 import (
 	"testing"
 
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	tifilter "github.com/pingcap/tidb/pkg/util/filter"
+	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -64,3 +66,18 @@ func TestVerifyTableRules(t *testing.T) {
 		}
 	}
 }
+
+func TestDDLActionFromEventType(t *testing.T) {
+	t.Parallel()
+
+	action, ok := DDLActionFromEventType(bf.CreateTable)
+	require.True(t, ok)
+	require.Equal(t, timodel.ActionCreateTable, action)
+
+	action, ok = DDLActionFromEventType(bf.AddColumn)
+	require.True(t, ok)
+	require.Equal(t, timodel.ActionAddColumn, action)
+
+	_, ok = DDLActionFromEventType(bf.InsertEvent)
+	require.False(t, ok)
+}
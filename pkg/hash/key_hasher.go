@@ -0,0 +1,147 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hash
+
+import (
+	"hash/crc32"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// FunctionDefault is the PositionInertia hash.
+	FunctionDefault = "default"
+	// FunctionMurmur2 is the murmur2 hash used by the default partitioner of
+	// the Kafka Java client, Kafka Streams and Flink.
+	FunctionMurmur2 = "murmur2"
+	// FunctionCRC32 is the IEEE crc32 hash.
+	FunctionCRC32 = "crc32"
+	// FunctionXXHash is the 64-bits xxhash, truncated to 32-bits.
+	FunctionXXHash = "xxhash"
+)
+
+// Hasher hashes the bytes written into it to a 32-bits value.
+type Hasher interface {
+	// Write writes the bytes into the Hasher.
+	Write(bss ...[]byte)
+	// Sum32 returns the 32-bits hash.
+	Sum32() uint32
+	// Reset resets the Hasher.
+	Reset()
+}
+
+// IsValidFunction returns true if the hash function is supported.
+func IsValidFunction(function string) bool {
+	switch strings.ToLower(function) {
+	case "", FunctionDefault, FunctionMurmur2, FunctionCRC32, FunctionXXHash:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDefaultFunction returns true if the hash function is the PositionInertia hash.
+func IsDefaultFunction(function string) bool {
+	function = strings.ToLower(function)
+	return function == "" || function == FunctionDefault
+}
+
+// NewHasher creates a Hasher by the hash function,
+// the PositionInertia is returned if the function is empty or unknown.
+func NewHasher(function string) Hasher {
+	switch strings.ToLower(function) {
+	case "", FunctionDefault:
+	case FunctionMurmur2, FunctionCRC32, FunctionXXHash:
+		return &KeyHasher{function: strings.ToLower(function)}
+	default:
+		log.Warn("the hash function is not default/murmur2/crc32/xxhash, use the default instead",
+			zap.String("function", function))
+	}
+	return NewPositionInertia()
+}
+
+// KeyHasher hashes the concatenation of the bytes written into it, so the result
+// is the same as hashing the serialized key by the clients of other systems.
+type KeyHasher struct {
+	function string
+	buf      []byte
+}
+
+// Write writes the bytes into the KeyHasher
+func (k *KeyHasher) Write(bss ...[]byte) {
+	for _, bs := range bss {
+		k.buf = append(k.buf, bs...)
+	}
+}
+
+// Sum32 returns the 32-bits hash, it's always positive for murmur2,
+// the same as the default partitioner of the Kafka Java client.
+func (k *KeyHasher) Sum32() uint32 {
+	switch k.function {
+	case FunctionMurmur2:
+		return uint32(Murmur2(k.buf)) & 0x7fffffff
+	case FunctionXXHash:
+		return uint32(xxhash.Sum64(k.buf))
+	default:
+		return crc32.ChecksumIEEE(k.buf)
+	}
+}
+
+// Reset resets the KeyHasher
+func (k *KeyHasher) Reset() {
+	k.buf = k.buf[:0]
+}
+
+// Murmur2 is the murmur2 hash implemented by the Kafka Java client,
+// see org.apache.kafka.common.utils.Utils#murmur2.
+func Murmur2(data []byte) int32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+	length := len(data)
+	h := seed ^ uint32(length)
+	length4 := length / 4
+	for i := 0; i < length4; i++ {
+		i4 := i * 4
+		k := uint32(data[i4]) | uint32(data[i4+1])<<8 | uint32(data[i4+2])<<16 | uint32(data[i4+3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length % 4 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hash
+
+import (
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMurmur2(t *testing.T) {
+	t.Parallel()
+
+	// the cases are the same as the Kafka Java client.
+	cases := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	for key, expected := range cases {
+		require.Equal(t, expected, Murmur2([]byte(key)), key)
+	}
+}
+
+func TestKeyHasher(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsValidFunction(""))
+	require.True(t, IsValidFunction("MURMUR2"))
+	require.False(t, IsValidFunction("md5"))
+	require.True(t, IsDefaultFunction(""))
+	require.False(t, IsDefaultFunction(FunctionXXHash))
+
+	_, ok := NewHasher(FunctionDefault).(*PositionInertia)
+	require.True(t, ok)
+	_, ok = NewHasher("md5").(*PositionInertia)
+	require.True(t, ok)
+
+	hasher := NewHasher(FunctionMurmur2)
+	hasher.Write([]byte("foo"), []byte("bar"))
+	require.Equal(t, uint32(-790332482&0x7fffffff), hasher.Sum32())
+	hasher.Reset()
+	hasher.Write([]byte("21"))
+	require.Equal(t, uint32(-973932308&0x7fffffff), hasher.Sum32())
+
+	hasher = NewHasher(FunctionCRC32)
+	hasher.Write([]byte("foo"), []byte("bar"))
+	require.Equal(t, crc32.ChecksumIEEE([]byte("foobar")), hasher.Sum32())
+
+	hasher = NewHasher(FunctionXXHash)
+	hasher.Write([]byte("foobar"))
+	sum := hasher.Sum32()
+	hasher.Reset()
+	hasher.Write([]byte("foo"), []byte("bar"))
+	require.Equal(t, sum, hasher.Sum32())
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// CorruptionRecord is a row whose checksum mismatched, it's written to the
+// corruption log as one JSON line.
+type CorruptionRecord struct {
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source"`
+	Schema   string    `json:"schema"`
+	Table    string    `json:"table"`
+	CommitTs uint64    `json:"commit-ts"`
+	// PreRow is true if the mismatch is found on the old value of the row.
+	PreRow   bool   `json:"pre-row,omitempty"`
+	Expected uint32 `json:"expected"`
+	Actual   uint32 `json:"actual"`
+	Reason   string `json:"reason,omitempty"`
+	// Columns holds the value of each column, for debugging.
+	Columns map[string]interface{} `json:"columns,omitempty"`
+}
+
+// CorruptionReporter reports the rows whose checksum mismatched, by the metrics
+// and the corruption log of the changefeed.
+type CorruptionReporter struct {
+	namespace   string
+	changefeed  string
+	source      string
+	errorHandle bool
+	logPath     string
+
+	mu      sync.Mutex
+	counter prometheus.Counter
+}
+
+// NewCorruptionReporter creates a CorruptionReporter, source is the component
+// which verifies the checksum, such as `mysql` or the protocol of the decoder.
+func NewCorruptionReporter(namespace, changefeed, source string, cfg *Config) *CorruptionReporter {
+	r := &CorruptionReporter{
+		namespace:  namespace,
+		changefeed: changefeed,
+		source:     source,
+		counter:    checksumMismatchCounter.WithLabelValues(namespace, changefeed, source),
+	}
+	if cfg != nil {
+		r.errorHandle = cfg.ErrorHandle()
+		if cfg.CorruptionLogDir != "" {
+			r.logPath = filepath.Join(cfg.CorruptionLogDir,
+				fmt.Sprintf("%s_%s.corruption.log", namespace, changefeed))
+		}
+	}
+	return r
+}
+
+// Report reports the corrupted row, it returns an error only if the corruption
+// handle level is `error`, the caller should mark the row as corrupted otherwise.
+func (r *CorruptionReporter) Report(record *CorruptionRecord) error {
+	r.counter.Inc()
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	if record.Source == "" {
+		record.Source = r.source
+	}
+	log.Warn("row checksum mismatch",
+		zap.String("namespace", r.namespace),
+		zap.String("changefeed", r.changefeed),
+		zap.String("source", record.Source),
+		zap.String("schema", record.Schema),
+		zap.String("table", record.Table),
+		zap.Uint64("commitTs", record.CommitTs),
+		zap.Bool("preRow", record.PreRow),
+		zap.Uint32("expected", record.Expected),
+		zap.Uint32("actual", record.Actual),
+		zap.String("reason", record.Reason))
+
+	if r.logPath != "" {
+		if err := r.appendLog(record); err != nil {
+			log.Warn("write the corruption log failed",
+				zap.String("namespace", r.namespace),
+				zap.String("changefeed", r.changefeed),
+				zap.String("path", r.logPath),
+				zap.Error(err))
+		}
+	}
+
+	if r.errorHandle {
+		return cerror.ErrCorruptedDataMutation.GenWithStackByArgs(r.namespace, r.changefeed)
+	}
+	return nil
+}
+
+// LogPath returns the path of the corruption log, empty if it's not enabled.
+func (r *CorruptionReporter) LogPath() string {
+	return r.logPath
+}
+
+// Close removes the metrics of the reporter.
+func (r *CorruptionReporter) Close() {
+	checksumMismatchCounter.DeleteLabelValues(r.namespace, r.changefeed, r.source)
+}
+
+func (r *CorruptionReporter) appendLog(record *CorruptionRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data = append(data, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(r.logPath), 0o755); err != nil {
+		return errors.Trace(err)
+	}
+	file, err := os.OpenFile(r.logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return errors.Trace(err)
+	}
+	return errors.Trace(file.Close())
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorruptionReporterWarn(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := &Config{
+		IntegrityCheckLevel:   CheckLevelCorrectness,
+		CorruptionHandleLevel: CorruptionHandleLevelWarn,
+		CorruptionLogDir:      dir,
+	}
+	reporter := NewCorruptionReporter("default", "warn-test", "mysql", cfg)
+	defer reporter.Close()
+	require.Equal(t, filepath.Join(dir, "default_warn-test.corruption.log"), reporter.LogPath())
+
+	for i := 0; i < 2; i++ {
+		err := reporter.Report(&CorruptionRecord{
+			Schema:   "test",
+			Table:    "t",
+			CommitTs: uint64(100 + i),
+			Expected: 1,
+			Actual:   2,
+			Columns:  map[string]interface{}{"id": i},
+		})
+		require.NoError(t, err)
+	}
+	metric := &dto.Metric{}
+	require.NoError(t, checksumMismatchCounter.
+		WithLabelValues("default", "warn-test", "mysql").Write(metric))
+	require.Equal(t, float64(2), metric.GetCounter().GetValue())
+
+	file, err := os.Open(reporter.LogPath())
+	require.NoError(t, err)
+	defer file.Close()
+
+	var records []CorruptionRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record CorruptionRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, 2)
+	require.Equal(t, "mysql", records[0].Source)
+	require.Equal(t, uint64(100), records[0].CommitTs)
+	require.Equal(t, uint64(101), records[1].CommitTs)
+	require.False(t, records[1].Time.IsZero())
+}
+
+func TestCorruptionReporterError(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		IntegrityCheckLevel:   CheckLevelCorrectness,
+		CorruptionHandleLevel: CorruptionHandleLevelError,
+	}
+	reporter := NewCorruptionReporter("default", "error-test", "canal-json", cfg)
+	defer reporter.Close()
+	require.Empty(t, reporter.LogPath())
+
+	err := reporter.Report(&CorruptionRecord{Schema: "test", Table: "t", Expected: 1, Actual: 2})
+	require.True(t, cerror.ErrCorruptedDataMutation.Equal(err))
+}
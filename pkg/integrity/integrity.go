@@ -23,6 +23,10 @@ import (
 type Config struct {
 	IntegrityCheckLevel   string `toml:"integrity-check-level" json:"integrity-check-level"`
 	CorruptionHandleLevel string `toml:"corruption-handle-level" json:"corruption-handle-level"`
+	// CorruptionLogDir is the directory of the corruption log, each changefeed
+	// appends the rows whose checksum mismatched to its own file in the directory.
+	// Empty means the corrupted rows are only logged.
+	CorruptionLogDir string `toml:"corruption-log-dir" json:"corruption-log-dir,omitempty"`
 }
 
 const (
@@ -0,0 +1,30 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// checksumMismatchCounter records the number of rows whose checksum mismatched.
+var checksumMismatchCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "integrity",
+		Name:      "checksum_mismatch_total",
+		Help:      "The number of rows whose checksum mismatched",
+	}, []string{"namespace", "changefeed", "source"})
+
+// InitMetrics registers all metrics in this file.
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(checksumMismatchCounter)
+}
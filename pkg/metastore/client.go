@@ -0,0 +1,176 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/engine/pkg/meta/mock"
+	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
+	"github.com/pingcap/tiflow/engine/pkg/orm"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/uuid"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy/adapter"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// NewClient returns an etcd client whose keys are stored in the MySQL
+// compatible meta store instead of etcd. All components of TiCDC which work
+// on etcd, such as the owner election, the EtcdWorker and the CDCEtcdClient,
+// run against the meta store through the client without any change.
+func NewClient(ctx context.Context, cfg *config.MetaStoreConfiguration) (*clientv3.Client, error) {
+	db, closeDB, err := openMySQL(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := newClient(ctx, db, metaModel.StoreTypeMySQL, closeDB)
+	if err != nil {
+		_ = closeDB()
+		return nil, err
+	}
+	return cli, nil
+}
+
+// NewMockClient returns an etcd client backed by an in-memory SQLite
+// database, which is a stand-in of the MySQL meta store in unit tests.
+func NewMockClient() (*clientv3.Client, error) {
+	db, closeDB, err := openSQLite()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	cli, err := newClient(ctx, db, metaModel.StoreTypeSQLite, closeDB)
+	if err != nil {
+		_ = closeDB()
+		return nil, err
+	}
+	return cli, nil
+}
+
+func openSQLite() (*gorm.DB, func() error, error) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewGenerator().NewString())
+	sqlDB, err := sql.Open(mock.ThreadeSafeSqliteDriverName, dsn)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// All queries share one connection, since the in-memory database is
+	// dropped once all connections are closed, and SQLite doesn't support
+	// concurrent writes.
+	sqlDB.SetMaxOpenConns(1)
+	db, err := orm.NewGormDB(sqlDB, metaModel.StoreTypeSQLite)
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, nil, err
+	}
+	return db, sqlDB.Close, nil
+}
+
+func openMySQL(cfg *config.MetaStoreConfiguration) (*gorm.DB, func() error, error) {
+	dsn, err := cfg.GenDSN()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	connector, err := dmysql.NewConnector(dsn)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	sqlDB := sql.OpenDB(connector)
+	db, err := orm.NewGormDB(sqlDB, metaModel.StoreTypeMySQL)
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, nil, err
+	}
+	return db, sqlDB.Close, nil
+}
+
+// newClient creates the store on db, and serves the etcd services of the
+// client with it. The store is stopped and closeDB is called once the client
+// is closed.
+func newClient(
+	ctx context.Context, db *gorm.DB, storeType metaModel.StoreType, closeDB func() error,
+) (*clientv3.Client, error) {
+	s, err := newStore(ctx, db, storeType)
+	if err != nil {
+		return nil, err
+	}
+
+	cli := clientv3.NewCtxClient(context.Background())
+	cli.KV = clientv3.NewKVFromKVClient(adapter.KvServerToKvClient(&kvServer{s: s}), cli)
+	cli.Lease = clientv3.NewLeaseFromLeaseClient(
+		adapter.LeaseServerToLeaseClient(&leaseServer{s: s}), cli, time.Second)
+	cli.Watcher = clientv3.NewWatchFromWatchClient(
+		adapter.WatchServerToWatchClient(&watchServer{s: s}), cli)
+	cli.Cluster = clientv3.NewClusterFromClusterClient(
+		adapter.ClusterServerToClusterClient(&clusterServer{s: s}), cli)
+
+	go func() {
+		s.run(cli.Ctx())
+		if err := closeDB(); err != nil {
+			log.Warn("fail to close the database of meta store", zap.Error(err))
+		}
+	}()
+	return cli, nil
+}
+
+// clusterServer implements the etcd Cluster service on the store, the store
+// has no members, and MemberList is used to check its health and to get its
+// cluster id.
+type clusterServer struct {
+	s *store
+}
+
+var _ pb.ClusterServer = (*clusterServer)(nil)
+
+var errMemberNotSupported = status.Error(codes.Unimplemented, "meta store has no members")
+
+// MemberList implements pb.ClusterServer.
+func (c *clusterServer) MemberList(ctx context.Context, _ *pb.MemberListRequest) (*pb.MemberListResponse, error) {
+	rev, err := c.s.currentRevision(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MemberListResponse{Header: c.s.header(rev)}, nil
+}
+
+// MemberAdd implements pb.ClusterServer.
+func (c *clusterServer) MemberAdd(context.Context, *pb.MemberAddRequest) (*pb.MemberAddResponse, error) {
+	return nil, errMemberNotSupported
+}
+
+// MemberRemove implements pb.ClusterServer.
+func (c *clusterServer) MemberRemove(context.Context, *pb.MemberRemoveRequest) (*pb.MemberRemoveResponse, error) {
+	return nil, errMemberNotSupported
+}
+
+// MemberUpdate implements pb.ClusterServer.
+func (c *clusterServer) MemberUpdate(context.Context, *pb.MemberUpdateRequest) (*pb.MemberUpdateResponse, error) {
+	return nil, errMemberNotSupported
+}
+
+// MemberPromote implements pb.ClusterServer.
+func (c *clusterServer) MemberPromote(context.Context, *pb.MemberPromoteRequest) (*pb.MemberPromoteResponse, error) {
+	return nil, errMemberNotSupported
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+func newTestClient(t *testing.T) *clientv3.Client {
+	cli, err := NewMockClient()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cli.Close() })
+	return cli
+}
+
+func TestKV(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cli := newTestClient(t)
+
+	put, err := cli.Put(ctx, "/a/1", "v1")
+	require.NoError(t, err)
+	rev := put.Header.Revision
+	_, err = cli.Put(ctx, "/a/2", "v2")
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "/b/1", "v3")
+	require.NoError(t, err)
+	put, err = cli.Put(ctx, "/a/1", "v4", clientv3.WithPrevKV())
+	require.NoError(t, err)
+	require.Equal(t, rev+3, put.Header.Revision)
+	require.Equal(t, "v1", string(put.PrevKv.Value))
+
+	resp, err := cli.Get(ctx, "/a/1")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	require.Equal(t, "v4", string(resp.Kvs[0].Value))
+	require.Equal(t, rev, resp.Kvs[0].CreateRevision)
+	require.Equal(t, rev+3, resp.Kvs[0].ModRevision)
+	require.Equal(t, int64(2), resp.Kvs[0].Version)
+
+	resp, err = cli.Get(ctx, "/a/", clientv3.WithPrefix(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Count)
+	require.Equal(t, "/a/2", string(resp.Kvs[0].Key))
+	require.Equal(t, "/a/1", string(resp.Kvs[1].Key))
+
+	resp, err = cli.Get(ctx, "/", clientv3.WithPrefix(), clientv3.WithLimit(1))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.Count)
+	require.Len(t, resp.Kvs, 1)
+	require.True(t, resp.More)
+
+	resp, err = cli.Get(ctx, "/", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.Count)
+	require.Empty(t, resp.Kvs)
+
+	// Historical reads are not supported.
+	_, err = cli.Get(ctx, "/a/1", clientv3.WithRev(rev))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+	_, err = cli.Get(ctx, "/a/1", clientv3.WithRev(rev+100))
+	require.ErrorIs(t, err, rpctypes.ErrFutureRev)
+
+	txn, err := cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision("/a/1"), "=", rev)).
+		Then(clientv3.OpPut("/a/1", "v5")).
+		Else(clientv3.OpGet("/a/1")).
+		Commit()
+	require.NoError(t, err)
+	require.False(t, txn.Succeeded)
+	require.Equal(t, "v4", string(txn.Responses[0].GetResponseRange().Kvs[0].Value))
+
+	txn, err = cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision("/a/1"), "=", rev+3),
+			clientv3.Compare(clientv3.CreateRevision("/c"), "=", 0)).
+		Then(clientv3.OpPut("/a/1", "v5"), clientv3.OpPut("/c", "v6")).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, txn.Succeeded)
+	require.Equal(t, rev+4, txn.Header.Revision)
+
+	del, err := cli.Delete(ctx, "/a/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), del.Deleted)
+	resp, err = cli.Get(ctx, "", clientv3.WithFromKey())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Count)
+}
+
+func TestLease(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cli := newTestClient(t)
+
+	grant, err := cli.Grant(ctx, 10)
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "/leased", "v", clientv3.WithLease(grant.ID))
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "/unknown", "v", clientv3.WithLease(grant.ID+100))
+	require.ErrorIs(t, err, rpctypes.ErrLeaseNotFound)
+
+	ttl, err := cli.TimeToLive(ctx, grant.ID, clientv3.WithAttachedKeys())
+	require.NoError(t, err)
+	require.Equal(t, int64(10), ttl.GrantedTTL)
+	require.Greater(t, ttl.TTL, int64(0))
+	require.Equal(t, [][]byte{[]byte("/leased")}, ttl.Keys)
+
+	keepAlive, err := cli.KeepAliveOnce(ctx, grant.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), keepAlive.TTL)
+
+	_, err = cli.Revoke(ctx, grant.ID)
+	require.NoError(t, err)
+	resp, err := cli.Get(ctx, "/leased")
+	require.NoError(t, err)
+	require.Zero(t, resp.Count)
+	_, err = cli.Revoke(ctx, grant.ID)
+	require.ErrorIs(t, err, rpctypes.ErrLeaseNotFound)
+
+	// Keys of an expired lease are deleted by the store.
+	grant, err = cli.Grant(ctx, 1)
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "/expired", "v", clientv3.WithLease(grant.ID))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		resp, err := cli.Get(ctx, "/expired")
+		require.NoError(t, err)
+		return resp.Count == 0
+	}, 10*time.Second, 100*time.Millisecond)
+	ttl, err = cli.TimeToLive(ctx, grant.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), ttl.TTL)
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli := newTestClient(t)
+
+	put, err := cli.Put(ctx, "/w/1", "v1")
+	require.NoError(t, err)
+	startRev := put.Header.Revision
+
+	ch := cli.Watch(ctx, "/w/", clientv3.WithPrefix(), clientv3.WithRev(startRev))
+	_, err = cli.Put(ctx, "/x", "ignored")
+	require.NoError(t, err)
+	_, err = cli.Delete(ctx, "/w/1")
+	require.NoError(t, err)
+
+	var events []*clientv3.Event
+	for len(events) < 2 {
+		select {
+		case resp := <-ch:
+			require.NoError(t, resp.Err())
+			events = append(events, resp.Events...)
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "watch events timeout")
+		}
+	}
+	require.Equal(t, mvccpb.PUT, events[0].Type)
+	require.Equal(t, "v1", string(events[0].Kv.Value))
+	require.Equal(t, startRev, events[0].Kv.ModRevision)
+	require.Equal(t, mvccpb.DELETE, events[1].Type)
+	require.Equal(t, "/w/1", string(events[1].Kv.Key))
+	require.Equal(t, startRev+2, events[1].Kv.ModRevision)
+
+	require.NoError(t, cli.RequestProgress(ctx))
+	select {
+	case resp := <-ch:
+		require.True(t, resp.IsProgressNotify())
+		require.Equal(t, startRev+2, resp.Header.Revision)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "progress notify timeout")
+	}
+}
+
+func TestElection(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cli := newTestClient(t)
+
+	sess1, err := concurrency.NewSession(cli, concurrency.WithTTL(5))
+	require.NoError(t, err)
+	defer sess1.Close()
+	sess2, err := concurrency.NewSession(cli, concurrency.WithTTL(5))
+	require.NoError(t, err)
+	defer sess2.Close()
+
+	e1 := concurrency.NewElection(sess1, "/owner")
+	e2 := concurrency.NewElection(sess2, "/owner")
+	require.NoError(t, e1.Campaign(ctx, "capture-1"))
+
+	elected := make(chan error, 1)
+	go func() {
+		elected <- e2.Campaign(ctx, "capture-2")
+	}()
+	leader, err := e1.Leader(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "capture-1", string(leader.Kvs[0].Value))
+
+	require.NoError(t, e1.Resign(ctx))
+	require.NoError(t, <-elected)
+	leader, err = e2.Leader(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "capture-2", string(leader.Kvs[0].Value))
+}
+
+func TestCDCEtcdClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cli := newTestClient(t)
+	cdcCli, err := etcd.NewCDCEtcdClient(ctx, cli, etcd.DefaultCDCClusterID)
+	require.NoError(t, err)
+
+	id := model.DefaultChangeFeedID("test")
+	info := &model.ChangeFeedInfo{
+		Namespace:  id.Namespace,
+		ID:         id.ID,
+		SinkURI:    "blackhole://",
+		UpstreamID: 1,
+	}
+	upstream := &model.UpstreamInfo{ID: 1, PDEndpoints: "http://127.0.0.1:2379"}
+	require.NoError(t, cdcCli.CreateChangefeedInfo(ctx, upstream, info))
+	require.Error(t, cdcCli.CreateChangefeedInfo(ctx, upstream, info))
+	got, err := cdcCli.GetChangeFeedInfo(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, info.SinkURI, got.SinkURI)
+
+	sess, err := concurrency.NewSession(cli, concurrency.WithTTL(5))
+	require.NoError(t, err)
+	defer sess.Close()
+	capture := &model.CaptureInfo{ID: "capture-1", AdvertiseAddr: "127.0.0.1:8300"}
+	require.NoError(t, cdcCli.PutCaptureInfo(ctx, capture, sess.Lease()))
+	_, captures, err := cdcCli.GetCaptures(ctx)
+	require.NoError(t, err)
+	require.Len(t, captures, 1)
+	require.Equal(t, capture.ID, captures[0].ID)
+}
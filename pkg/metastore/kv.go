@@ -0,0 +1,396 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pingcap/errors"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"gorm.io/gorm"
+)
+
+var sortColumns = map[pb.RangeRequest_SortTarget]string{
+	pb.RangeRequest_KEY:     "meta_key",
+	pb.RangeRequest_VERSION: "version",
+	pb.RangeRequest_CREATE:  "create_revision",
+	pb.RangeRequest_MOD:     "mod_revision",
+	pb.RangeRequest_VALUE:   "meta_value",
+}
+
+// kvServer implements the etcd KV service on the store.
+type kvServer struct {
+	s *store
+}
+
+var _ pb.KVServer = (*kvServer)(nil)
+
+// Range implements pb.KVServer. Only the latest revision can be read, since
+// the store doesn't keep the history of keys.
+func (k *kvServer) Range(ctx context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	var resp *pb.RangeResponse
+	err := k.s.view(ctx, func(tx *gorm.DB, row *revisionDO) error {
+		if req.Revision > row.Revision {
+			return rpctypes.ErrGRPCFutureRev
+		}
+		if req.Revision > 0 && req.Revision < row.Revision {
+			return rpctypes.ErrGRPCCompacted
+		}
+		var err error
+		resp, err = rangeKVs(tx, req)
+		if err != nil {
+			return err
+		}
+		resp.Header = k.s.header(row.Revision)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Put implements pb.KVServer.
+func (k *kvServer) Put(ctx context.Context, req *pb.PutRequest) (*pb.PutResponse, error) {
+	var resp *pb.PutResponse
+	err := k.s.update(ctx, func(t *writeTxn) error {
+		var err error
+		resp, err = t.put(req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRange implements pb.KVServer.
+func (k *kvServer) DeleteRange(ctx context.Context, req *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	var resp *pb.DeleteRangeResponse
+	err := k.s.update(ctx, func(t *writeTxn) error {
+		var err error
+		resp, err = t.deleteRange(req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Txn implements pb.KVServer.
+func (k *kvServer) Txn(ctx context.Context, req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	var resp *pb.TxnResponse
+	err := k.s.update(ctx, func(t *writeTxn) error {
+		var err error
+		resp, err = t.txn(req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Compact implements pb.KVServer, it removes the events of watchers whose
+// revisions are not greater than the given revision.
+func (k *kvServer) Compact(ctx context.Context, req *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	resp := &pb.CompactionResponse{}
+	err := k.s.update(ctx, func(t *writeTxn) error {
+		if req.Revision > t.row.Revision {
+			return rpctypes.ErrGRPCFutureRev
+		}
+		if req.Revision <= t.row.CompactRevision {
+			return rpctypes.ErrGRPCCompacted
+		}
+		resp.Header = t.newHeader()
+		return t.compact(req.Revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func rangeKVs(db *gorm.DB, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	query := whereKeyRange(db.Model(&kvDO{}), req.Key, req.RangeEnd)
+	if req.MinModRevision > 0 {
+		query = query.Where("mod_revision >= ?", req.MinModRevision)
+	}
+	if req.MaxModRevision > 0 {
+		query = query.Where("mod_revision <= ?", req.MaxModRevision)
+	}
+	if req.MinCreateRevision > 0 {
+		query = query.Where("create_revision >= ?", req.MinCreateRevision)
+	}
+	if req.MaxCreateRevision > 0 {
+		query = query.Where("create_revision <= ?", req.MaxCreateRevision)
+	}
+	query = query.Session(&gorm.Session{})
+
+	resp := &pb.RangeResponse{}
+	if err := query.Count(&resp.Count).Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	if req.CountOnly {
+		return resp, nil
+	}
+
+	// Keys are sorted in ascending order if the sort target is given
+	// without the sort order, which is the same as etcd.
+	sortOrder := req.SortOrder
+	if sortOrder == pb.RangeRequest_NONE && req.SortTarget != pb.RangeRequest_KEY {
+		sortOrder = pb.RangeRequest_ASCEND
+	}
+	column, ok := sortColumns[req.SortTarget]
+	if !ok {
+		return nil, errors.Errorf("unknown sort target %s", req.SortTarget)
+	}
+	if sortOrder == pb.RangeRequest_DESCEND {
+		column += " DESC"
+	}
+	query = query.Order(column).Order("meta_key")
+	if req.Limit > 0 {
+		query = query.Limit(int(req.Limit))
+	}
+	if req.KeysOnly {
+		query = query.Omit("meta_value")
+	}
+
+	var kvs []*kvDO
+	if err := query.Find(&kvs).Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp.Kvs = make([]*mvccpb.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		resp.Kvs = append(resp.Kvs, kv.toPB(req.KeysOnly))
+	}
+	resp.More = resp.Count > int64(len(kvs))
+	return resp, nil
+}
+
+func (t *writeTxn) get(key []byte) (*kvDO, error) {
+	var kvs []*kvDO
+	if err := t.tx.Where("meta_key = ?", key).Limit(1).Find(&kvs).Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(kvs) == 0 {
+		return nil, nil
+	}
+	return kvs[0], nil
+}
+
+func (t *writeTxn) put(req *pb.PutRequest) (*pb.PutResponse, error) {
+	if len(req.Key) == 0 {
+		return nil, rpctypes.ErrGRPCEmptyKey
+	}
+	prev, err := t.get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if prev == nil && (req.IgnoreValue || req.IgnoreLease) {
+		return nil, rpctypes.ErrGRPCKeyNotFound
+	}
+
+	resp := &pb.PutResponse{Header: t.newHeader()}
+	kv := &kvDO{
+		Key:            req.Key,
+		Value:          req.Value,
+		CreateRevision: t.writeRevision(),
+		ModRevision:    t.writeRevision(),
+		Version:        1,
+		Lease:          req.Lease,
+	}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+		if req.IgnoreValue {
+			kv.Value = prev.Value
+		}
+		if req.IgnoreLease {
+			kv.Lease = prev.Lease
+		}
+		if req.PrevKv {
+			resp.PrevKv = prev.toPB(false)
+		}
+	}
+	if kv.Lease != 0 && !req.IgnoreLease {
+		var count int64
+		if err := t.tx.Model(&leaseDO{}).Where("id = ?", kv.Lease).Count(&count).Error; err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return nil, rpctypes.ErrGRPCLeaseNotFound
+		}
+	}
+
+	if prev == nil {
+		err = t.tx.Create(kv).Error
+	} else {
+		err = t.tx.Model(&kvDO{}).Where("meta_key = ?", kv.Key).
+			Updates(map[string]interface{}{
+				"meta_value":   kv.Value,
+				"mod_revision": kv.ModRevision,
+				"version":      kv.Version,
+				"lease_id":     kv.Lease,
+			}).Error
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	t.addEvent(mvccpb.PUT, kv)
+	return resp, nil
+}
+
+func (t *writeTxn) deleteRange(req *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	if len(req.Key) == 0 {
+		return nil, rpctypes.ErrGRPCEmptyKey
+	}
+	var kvs []*kvDO
+	if err := whereKeyRange(t.tx, req.Key, req.RangeEnd).Find(&kvs).Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := t.deleteKVs(kvs); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.DeleteRangeResponse{Header: t.newHeader(), Deleted: int64(len(kvs))}
+	if req.PrevKv {
+		resp.PrevKvs = make([]*mvccpb.KeyValue, 0, len(kvs))
+		for _, kv := range kvs {
+			resp.PrevKvs = append(resp.PrevKvs, kv.toPB(false))
+		}
+	}
+	return resp, nil
+}
+
+func (t *writeTxn) txn(req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	succeeded := true
+	for _, c := range req.Compare {
+		ok, err := t.compare(c)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			succeeded = false
+			break
+		}
+	}
+	ops := req.Success
+	if !succeeded {
+		ops = req.Failure
+	}
+
+	resp := &pb.TxnResponse{
+		Header:    t.newHeader(),
+		Succeeded: succeeded,
+		Responses: make([]*pb.ResponseOp, 0, len(ops)),
+	}
+	for _, op := range ops {
+		var opResp *pb.ResponseOp
+		switch r := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			rangeResp, err := rangeKVs(t.tx, r.RequestRange)
+			if err != nil {
+				return nil, err
+			}
+			rangeResp.Header = t.newHeader()
+			opResp = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: rangeResp}}
+		case *pb.RequestOp_RequestPut:
+			putResp, err := t.put(r.RequestPut)
+			if err != nil {
+				return nil, err
+			}
+			opResp = &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: putResp}}
+		case *pb.RequestOp_RequestDeleteRange:
+			deleteResp, err := t.deleteRange(r.RequestDeleteRange)
+			if err != nil {
+				return nil, err
+			}
+			opResp = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: deleteResp}}
+		case *pb.RequestOp_RequestTxn:
+			txnResp, err := t.txn(r.RequestTxn)
+			if err != nil {
+				return nil, err
+			}
+			opResp = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseTxn{ResponseTxn: txnResp}}
+		default:
+			return nil, errors.Errorf("unknown txn request %T", op.Request)
+		}
+		resp.Responses = append(resp.Responses, opResp)
+	}
+	return resp, nil
+}
+
+// compare evaluates the compare of a txn, all keys in the range of the
+// compare must satisfy it, which is the same as etcd.
+func (t *writeTxn) compare(c *pb.Compare) (bool, error) {
+	var kvs []*kvDO
+	if err := whereKeyRange(t.tx, c.Key, c.RangeEnd).Find(&kvs).Error; err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(kvs) == 0 {
+		if c.Target == pb.Compare_VALUE {
+			return false, nil
+		}
+		return compareKV(c, &kvDO{}), nil
+	}
+	for _, kv := range kvs {
+		if !compareKV(c, kv) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func compareKV(c *pb.Compare, kv *kvDO) bool {
+	var result int
+	switch c.Target {
+	case pb.Compare_VALUE:
+		result = bytes.Compare(kv.Value, c.GetValue())
+	case pb.Compare_CREATE:
+		result = compareInt64(kv.CreateRevision, c.GetCreateRevision())
+	case pb.Compare_MOD:
+		result = compareInt64(kv.ModRevision, c.GetModRevision())
+	case pb.Compare_VERSION:
+		result = compareInt64(kv.Version, c.GetVersion())
+	case pb.Compare_LEASE:
+		result = compareInt64(kv.Lease, c.GetLease())
+	}
+	switch c.Result {
+	case pb.Compare_EQUAL:
+		return result == 0
+	case pb.Compare_NOT_EQUAL:
+		return result != 0
+	case pb.Compare_GREATER:
+		return result > 0
+	case pb.Compare_LESS:
+		return result < 0
+	}
+	return true
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"io"
+
+	"github.com/pingcap/errors"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"gorm.io/gorm"
+)
+
+// leaseServer implements the etcd Lease service on the store.
+type leaseServer struct {
+	s *store
+}
+
+var _ pb.LeaseServer = (*leaseServer)(nil)
+
+// LeaseGrant implements pb.LeaseServer.
+func (l *leaseServer) LeaseGrant(ctx context.Context, req *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	resp := &pb.LeaseGrantResponse{TTL: req.TTL}
+	err := l.s.update(ctx, func(t *writeTxn) error {
+		resp.ID = req.ID
+		if resp.ID == 0 {
+			t.row.LeaseID++
+			resp.ID = t.row.LeaseID
+		} else {
+			var count int64
+			if err := t.tx.Model(&leaseDO{}).Where("id = ?", req.ID).Count(&count).Error; err != nil {
+				return errors.Trace(err)
+			}
+			if count > 0 {
+				return rpctypes.ErrGRPCLeaseExist
+			}
+			if req.ID > t.row.LeaseID {
+				t.row.LeaseID = req.ID
+			}
+		}
+		resp.Header = t.newHeader()
+		return errors.Trace(t.tx.Model(&leaseDO{}).Create(map[string]interface{}{
+			"id":          resp.ID,
+			"ttl":         req.TTL,
+			"expire_time": gorm.Expr(t.s.nowExpr+" + ?", req.TTL*1000),
+		}).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// LeaseRevoke implements pb.LeaseServer.
+func (l *leaseServer) LeaseRevoke(ctx context.Context, req *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	resp := &pb.LeaseRevokeResponse{}
+	err := l.s.update(ctx, func(t *writeTxn) error {
+		revoked, err := t.revokeLease(req.ID, false)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return rpctypes.ErrGRPCLeaseNotFound
+		}
+		resp.Header = t.newHeader()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// LeaseKeepAlive implements pb.LeaseServer. A lease which has expired can't
+// be renewed even if it's not revoked yet, the TTL of the response is 0 in
+// this case, so that the client stops keeping it alive.
+func (l *leaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ttl, err := l.s.renewLease(stream.Context(), req.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		resp := &pb.LeaseKeepAliveResponse{Header: l.s.header(0), ID: req.ID, TTL: ttl}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// LeaseTimeToLive implements pb.LeaseServer.
+func (l *leaseServer) LeaseTimeToLive(
+	ctx context.Context, req *pb.LeaseTimeToLiveRequest,
+) (*pb.LeaseTimeToLiveResponse, error) {
+	resp := &pb.LeaseTimeToLiveResponse{ID: req.ID, TTL: -1}
+	err := l.s.view(ctx, func(tx *gorm.DB, row *revisionDO) error {
+		resp.Header = l.s.header(row.Revision)
+		var lease struct {
+			TTL       int64
+			Remaining int64
+		}
+		ret := tx.Model(&leaseDO{}).
+			Select("ttl, expire_time - "+l.s.nowExpr+" AS remaining").
+			Where("id = ?", req.ID).Limit(1).Scan(&lease)
+		if ret.Error != nil {
+			return errors.Trace(ret.Error)
+		}
+		if ret.RowsAffected == 0 || lease.Remaining < 0 {
+			return nil
+		}
+		resp.GrantedTTL = lease.TTL
+		// Round up the remaining time, a lease with 100ms left is alive.
+		resp.TTL = (lease.Remaining + 999) / 1000
+		if !req.Keys {
+			return nil
+		}
+		return errors.Trace(tx.Model(&kvDO{}).
+			Where("lease_id = ?", req.ID).Pluck("meta_key", &resp.Keys).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// LeaseLeases implements pb.LeaseServer.
+func (l *leaseServer) LeaseLeases(ctx context.Context, _ *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+	var ids []int64
+	err := l.s.db.WithContext(ctx).Model(&leaseDO{}).Order("id").Pluck("id", &ids).Error
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp := &pb.LeaseLeasesResponse{
+		Header: l.s.header(0),
+		Leases: make([]*pb.LeaseStatus, 0, len(ids)),
+	}
+	for _, id := range ids {
+		resp.Leases = append(resp.Leases, &pb.LeaseStatus{ID: id})
+	}
+	return resp, nil
+}
+
+// renewLease extends the expire time of an alive lease, it returns the TTL
+// of the lease, or 0 if the lease is not found or has expired.
+func (s *store) renewLease(ctx context.Context, id int64) (int64, error) {
+	var ttls []int64
+	alive := s.db.WithContext(ctx).Model(&leaseDO{}).
+		Where("id = ? AND expire_time >= "+s.nowExpr, id).Session(&gorm.Session{})
+	if err := alive.Pluck("ttl", &ttls).Error; err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(ttls) == 0 {
+		return 0, nil
+	}
+	err := alive.Update("expire_time", gorm.Expr(s.nowExpr+" + ttl * 1000")).Error
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return ttls[0], nil
+}
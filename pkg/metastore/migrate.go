@@ -0,0 +1,112 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// MigrateFromEtcd copies the metadata of a TiCDC cluster from etcd to the
+// meta store, and returns the number of copied keys.
+//
+// Keys attached to leases, such as the capture information and the owner
+// key, belong to alive captures and are not copied, so all captures of the
+// cluster must be stopped before the migration. The etcd cluster id is
+// copied as well, so that the GC service id of the cluster is kept.
+func MigrateFromEtcd(
+	ctx context.Context, src *clientv3.Client,
+	cfg *config.MetaStoreConfiguration, clusterID string,
+) (int, error) {
+	db, closeDB, err := openMySQL(cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := closeDB(); err != nil {
+			log.Warn("fail to close the database of meta store", zap.Error(err))
+		}
+	}()
+	dst, err := newStore(ctx, db, metaModel.StoreTypeMySQL)
+	if err != nil {
+		return 0, err
+	}
+	return migrateFromEtcd(ctx, src, dst, clusterID)
+}
+
+func migrateFromEtcd(
+	ctx context.Context, src *clientv3.Client, dst *store, clusterID string,
+) (int, error) {
+	captures, err := src.Get(ctx, etcd.CaptureInfoKeyPrefix(clusterID),
+		clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if captures.Count > 0 {
+		return 0, cerror.ErrMetaInvalidState.GenWithStackByArgs(fmt.Sprintf(
+			"%d captures of cluster %s are alive, stop them before migration",
+			captures.Count, clusterID))
+	}
+
+	prefix := etcd.BaseKey(clusterID) + "/"
+	resp, err := src.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	count := 0
+	err = dst.update(ctx, func(t *writeTxn) error {
+		var existing int64
+		err := whereKeyRange(t.tx.Model(&kvDO{}), []byte(prefix),
+			[]byte(clientv3.GetPrefixRangeEnd(prefix))).Count(&existing).Error
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if existing > 0 {
+			return cerror.ErrMetaInvalidState.GenWithStackByArgs(fmt.Sprintf(
+				"meta store already has %d keys of cluster %s", existing, clusterID))
+		}
+
+		for _, kv := range resp.Kvs {
+			if kv.Lease != 0 {
+				log.Info("skip the key attached to a lease",
+					zap.ByteString("key", kv.Key), zap.Int64("lease", kv.Lease))
+				continue
+			}
+			if _, err := t.put(&pb.PutRequest{Key: kv.Key, Value: kv.Value}); err != nil {
+				return err
+			}
+			count++
+		}
+		t.row.ClusterID = int64(resp.Header.ClusterId)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	dst.clusterID = resp.Header.ClusterId
+	log.Info("metadata migrated from etcd to meta store",
+		zap.String("clusterID", clusterID), zap.Int("keys", count))
+	return count, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
+	"github.com/pingcap/tiflow/pkg/etcd"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestMigrateFromEtcd(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	clientURL, e, err := etcd.SetupEmbedEtcd(t.TempDir())
+	require.NoError(t, err)
+	defer e.Close()
+	src, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{clientURL.String()},
+		DialTimeout: 3 * time.Second,
+	})
+	require.NoError(t, err)
+	defer src.Close()
+
+	db, closeDB, err := openSQLite()
+	require.NoError(t, err)
+	defer closeDB()
+	dst, err := newStore(ctx, db, metaModel.StoreTypeSQLite)
+	require.NoError(t, err)
+
+	clusterID := etcd.DefaultCDCClusterID
+	prefix := etcd.BaseKey(clusterID)
+	_, err = src.Put(ctx, prefix+"/default/changefeed/info/test", "info")
+	require.NoError(t, err)
+	_, err = src.Put(ctx, prefix+"/default/upstream/1", "upstream")
+	require.NoError(t, err)
+	_, err = src.Put(ctx, etcd.BaseKey("other")+"/default/changefeed/info/test", "other")
+	require.NoError(t, err)
+	grant, err := src.Grant(ctx, 10)
+	require.NoError(t, err)
+	_, err = src.Put(ctx, etcd.CaptureInfoKeyPrefix(clusterID)+"/capture-1", "capture",
+		clientv3.WithLease(grant.ID))
+	require.NoError(t, err)
+
+	// Captures of the cluster are alive.
+	_, err = migrateFromEtcd(ctx, src, dst, clusterID)
+	require.ErrorContains(t, err, "captures of cluster default are alive")
+
+	_, err = src.Revoke(ctx, grant.ID)
+	require.NoError(t, err)
+	count, err := migrateFromEtcd(ctx, src, dst, clusterID)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	var kvs []kvDO
+	require.NoError(t, db.Order("meta_key").Find(&kvs).Error)
+	require.Len(t, kvs, 2)
+	require.Equal(t, prefix+"/default/changefeed/info/test", string(kvs[0].Key))
+	require.Equal(t, "info", string(kvs[0].Value))
+
+	members, err := src.MemberList(ctx)
+	require.NoError(t, err)
+	require.Equal(t, members.Header.ClusterId, dst.clusterID)
+	row := &revisionDO{}
+	require.NoError(t, db.Where("id = ?", revisionRowID).First(row).Error)
+	require.Equal(t, int64(members.Header.ClusterId), row.ClusterID)
+
+	// The meta store must not be migrated twice.
+	_, err = migrateFromEtcd(ctx, src, dst, clusterID)
+	require.ErrorContains(t, err, "meta store already has 2 keys")
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import "go.etcd.io/etcd/api/v3/mvccpb"
+
+const (
+	tableNameKV       = "cdc_meta_kv"
+	tableNameEvent    = "cdc_meta_event"
+	tableNameLease    = "cdc_meta_lease"
+	tableNameRevision = "cdc_meta_revision"
+
+	// revisionRowID is the id of the only row of the revision table.
+	revisionRowID = 1
+)
+
+// kvDO mapped from table <cdc_meta_kv>, it stores the latest version of keys.
+type kvDO struct {
+	Key            []byte `gorm:"column:meta_key;type:varbinary(1024);primaryKey"`
+	Value          []byte `gorm:"column:meta_value;type:longblob"`
+	CreateRevision int64  `gorm:"column:create_revision;type:bigint(20);not null"`
+	ModRevision    int64  `gorm:"column:mod_revision;type:bigint(20);not null"`
+	Version        int64  `gorm:"column:version;type:bigint(20);not null"`
+	Lease          int64  `gorm:"column:lease_id;type:bigint(20);not null;index"`
+}
+
+// TableName returns the table name of kvDO.
+func (*kvDO) TableName() string {
+	return tableNameKV
+}
+
+func (kv *kvDO) toPB(keysOnly bool) *mvccpb.KeyValue {
+	ret := &mvccpb.KeyValue{
+		Key:            kv.Key,
+		CreateRevision: kv.CreateRevision,
+		ModRevision:    kv.ModRevision,
+		Version:        kv.Version,
+		Lease:          kv.Lease,
+	}
+	if !keysOnly {
+		ret.Value = kv.Value
+	}
+	return ret
+}
+
+// eventDO mapped from table <cdc_meta_event>, it stores the changes of keys
+// which are not compacted yet, watchers read their events from it.
+type eventDO struct {
+	Revision int64 `gorm:"column:revision;type:bigint(20);primaryKey;autoIncrement:false"`
+	// Sub is the index of the event in the transaction of the revision.
+	Sub            int64  `gorm:"column:sub;type:bigint(20);primaryKey;autoIncrement:false"`
+	Type           int32  `gorm:"column:event_type;type:int(11);not null"`
+	Key            []byte `gorm:"column:meta_key;type:varbinary(1024);not null"`
+	Value          []byte `gorm:"column:meta_value;type:longblob"`
+	CreateRevision int64  `gorm:"column:create_revision;type:bigint(20);not null"`
+	ModRevision    int64  `gorm:"column:mod_revision;type:bigint(20);not null"`
+	Version        int64  `gorm:"column:version;type:bigint(20);not null"`
+	Lease          int64  `gorm:"column:lease_id;type:bigint(20);not null"`
+	// CreateTime is the unix milliseconds when the event is written.
+	CreateTime int64 `gorm:"column:create_time;type:bigint(20);not null;index"`
+}
+
+// TableName returns the table name of eventDO.
+func (*eventDO) TableName() string {
+	return tableNameEvent
+}
+
+func (e *eventDO) toPB() *mvccpb.Event {
+	return &mvccpb.Event{
+		Type: mvccpb.Event_EventType(e.Type),
+		Kv: &mvccpb.KeyValue{
+			Key:            e.Key,
+			Value:          e.Value,
+			CreateRevision: e.CreateRevision,
+			ModRevision:    e.ModRevision,
+			Version:        e.Version,
+			Lease:          e.Lease,
+		},
+	}
+}
+
+// leaseDO mapped from table <cdc_meta_lease>.
+type leaseDO struct {
+	ID  int64 `gorm:"column:id;type:bigint(20);primaryKey;autoIncrement:false"`
+	TTL int64 `gorm:"column:ttl;type:bigint(20);not null"`
+	// ExpireTime is the unix milliseconds of the clock of the database when
+	// the lease expires.
+	ExpireTime int64 `gorm:"column:expire_time;type:bigint(20);not null;index"`
+}
+
+// TableName returns the table name of leaseDO.
+func (*leaseDO) TableName() string {
+	return tableNameLease
+}
+
+// revisionDO mapped from table <cdc_meta_revision>, the only row of the
+// table is locked by every write transaction to serialize the writes.
+type revisionDO struct {
+	ID       int32 `gorm:"column:id;type:int(11);primaryKey;autoIncrement:false"`
+	Revision int64 `gorm:"column:revision;type:bigint(20);not null"`
+	// CompactRevision is the largest revision whose events are removed.
+	CompactRevision int64 `gorm:"column:compact_revision;type:bigint(20);not null"`
+	// LeaseID is the id of the last granted lease.
+	LeaseID int64 `gorm:"column:lease_id;type:bigint(20);not null"`
+	// ClusterID is the cluster id in the response headers, it's stored as
+	// int64 since SQLite doesn't support unsigned 64-bit integers.
+	ClusterID int64 `gorm:"column:cluster_id;type:bigint(20);not null"`
+}
+
+// TableName returns the table name of revisionDO.
+func (*revisionDO) TableName() string {
+	return tableNameRevision
+}
@@ -0,0 +1,372 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	metaModel "github.com/pingcap/tiflow/engine/pkg/meta/model"
+	"github.com/pingcap/tiflow/pkg/notify"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// watchPollInterval is the interval for watchers to poll the events
+	// written by other captures, the events written by the current capture
+	// are notified at once.
+	watchPollInterval = 100 * time.Millisecond
+	// maintainInterval is the interval to revoke the expired leases.
+	maintainInterval = 500 * time.Millisecond
+	// compactInterval is the interval to remove the events which are older
+	// than eventRetention. A watcher falling behind more than eventRetention
+	// is canceled with ErrCompacted, which is the same as etcd.
+	compactInterval = time.Minute
+	eventRetention  = 10 * time.Minute
+
+	// The unix milliseconds of the clock of the database. Leases are expired
+	// by the clock of the database, so that the clock skew among captures
+	// doesn't shorten or extend the leases.
+	mysqlNowExpr  = "CAST(UNIX_TIMESTAMP(NOW(3)) * 1000 AS SIGNED)"
+	sqliteNowExpr = "CAST((julianday('now') - 2440587.5) * 86400000 AS INTEGER)"
+)
+
+// store stores keys in a SQL database with the data model of etcd, every
+// write transaction increases the revision of the store, and the changes of
+// keys are saved as events for watchers.
+type store struct {
+	db        *gorm.DB
+	nowExpr   string
+	clusterID uint64
+	// notifier notifies the watchers of the current capture that new events
+	// are written.
+	notifier *notify.Notifier
+}
+
+func newStore(ctx context.Context, db *gorm.DB, storeType metaModel.StoreType) (*store, error) {
+	s := &store{
+		db:       db,
+		nowExpr:  mysqlNowExpr,
+		notifier: new(notify.Notifier),
+	}
+	if storeType == metaModel.StoreTypeSQLite {
+		s.nowExpr = sqliteNowExpr
+	}
+	if err := s.initialize(ctx); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (s *store) initialize(ctx context.Context) error {
+	models := []interface{}{&kvDO{}, &eventDO{}, &leaseDO{}, &revisionDO{}}
+	for i := 0; ; i++ {
+		err := s.db.WithContext(ctx).AutoMigrate(models...)
+		if err == nil {
+			break
+		}
+		// Captures may create the tables concurrently, migrate again to make
+		// sure the remaining tables are created if the 'table exists' error
+		// is returned.
+		errMySQL, ok := errors.Cause(err).(*mysql.MySQLError)
+		if !ok || errMySQL.Number != mysqlerr.ER_TABLE_EXISTS_ERROR || i >= 3 {
+			return errors.Trace(err)
+		}
+		log.Info("meet 'table exists' error when creating meta store tables, retry",
+			zap.Error(err))
+	}
+
+	// The revision of an empty store is 1, which is the same as etcd, so the
+	// first write gets revision 2. Some clients, such as the election of etcd,
+	// rely on it since they take revision 0 as unset.
+	row := &revisionDO{ID: revisionRowID, Revision: 1, ClusterID: rand.Int63()}
+	err := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(row).Error
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.db.WithContext(ctx).Where("id = ?", revisionRowID).First(row).Error; err != nil {
+		return errors.Trace(err)
+	}
+	s.clusterID = uint64(row.ClusterID)
+	return nil
+}
+
+func (s *store) header(rev int64) *pb.ResponseHeader {
+	return &pb.ResponseHeader{ClusterId: s.clusterID, Revision: rev}
+}
+
+// view runs fn in a transaction, so that fn reads a consistent snapshot of
+// the store whose revision is row.Revision.
+func (s *store) view(ctx context.Context, fn func(tx *gorm.DB, row *revisionDO) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := &revisionDO{}
+		if err := tx.Where("id = ?", revisionRowID).First(row).Error; err != nil {
+			return errors.Trace(err)
+		}
+		return fn(tx, row)
+	})
+}
+
+// update runs fn in a transaction which locks the revision row, so that all
+// write transactions of the store are serialized.
+func (s *store) update(ctx context.Context, fn func(t *writeTxn) error) error {
+	var t *writeTxn
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := &revisionDO{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", revisionRowID).First(row).Error
+		if err != nil {
+			return errors.Trace(err)
+		}
+		origin := *row
+		t = &writeTxn{s: s, tx: tx, row: row}
+		if err := fn(t); err != nil {
+			return err
+		}
+
+		if len(t.events) > 0 {
+			row.Revision++
+			now := time.Now().UnixMilli()
+			for i, e := range t.events {
+				e.Revision = row.Revision
+				e.Sub = int64(i)
+				e.CreateTime = now
+			}
+			if err := tx.Create(t.events).Error; err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if *row != origin {
+			if err := tx.Model(&revisionDO{}).Where("id = ?", revisionRowID).
+				Updates(map[string]interface{}{
+					"revision":         row.Revision,
+					"compact_revision": row.CompactRevision,
+					"lease_id":         row.LeaseID,
+					"cluster_id":       row.ClusterID,
+				}).Error; err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, h := range t.headers {
+			h.Revision = row.Revision
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(t.events) > 0 {
+		s.notifier.Notify()
+	}
+	return nil
+}
+
+func (s *store) currentRevision(ctx context.Context) (int64, error) {
+	row := &revisionDO{}
+	if err := s.db.WithContext(ctx).Where("id = ?", revisionRowID).First(row).Error; err != nil {
+		return 0, errors.Trace(err)
+	}
+	return row.Revision, nil
+}
+
+// readEvents reads the events of keys in [key, end) whose revisions are not
+// less than startRev, along with the current revision and the compacted
+// revision of the store.
+func (s *store) readEvents(
+	ctx context.Context, key, end []byte, startRev int64,
+) (events []*eventDO, rev int64, compactRev int64, err error) {
+	err = s.view(ctx, func(tx *gorm.DB, row *revisionDO) error {
+		rev, compactRev = row.Revision, row.CompactRevision
+		if startRev <= compactRev || startRev > rev {
+			return nil
+		}
+		return errors.Trace(whereKeyRange(tx, key, end).
+			Where("revision >= ?", startRev).
+			Order("revision, sub").Find(&events).Error)
+	})
+	return
+}
+
+// run revokes the expired leases and removes the stale events periodically
+// until ctx is done.
+func (s *store) run(ctx context.Context) {
+	defer s.notifier.Close()
+
+	ticker := time.NewTicker(maintainInterval)
+	defer ticker.Stop()
+	lastCompactTime := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.revokeExpiredLeases(ctx); err != nil && ctx.Err() == nil {
+			log.Warn("meta store fails to revoke expired leases", zap.Error(err))
+		}
+		if time.Since(lastCompactTime) < compactInterval {
+			continue
+		}
+		lastCompactTime = time.Now()
+		if err := s.compactEvents(ctx, lastCompactTime.Add(-eventRetention)); err != nil && ctx.Err() == nil {
+			log.Warn("meta store fails to compact events", zap.Error(err))
+		}
+	}
+}
+
+func (s *store) revokeExpiredLeases(ctx context.Context) error {
+	var ids []int64
+	err := s.db.WithContext(ctx).Model(&leaseDO{}).
+		Where("expire_time < "+s.nowExpr).Pluck("id", &ids).Error
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, id := range ids {
+		var revoked bool
+		err := s.update(ctx, func(t *writeTxn) error {
+			var err error
+			revoked, err = t.revokeLease(id, true)
+			return err
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if revoked {
+			log.Info("meta store lease expired", zap.Int64("lease", id))
+		}
+	}
+	return nil
+}
+
+// compactEvents removes the events written before the given time.
+func (s *store) compactEvents(ctx context.Context, before time.Time) error {
+	return s.update(ctx, func(t *writeTxn) error {
+		var rev int64
+		err := t.tx.Model(&eventDO{}).
+			Select("COALESCE(MAX(revision), 0)").
+			Where("create_time < ?", before.UnixMilli()).Scan(&rev).Error
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if rev <= t.row.CompactRevision {
+			return nil
+		}
+		return t.compact(rev)
+	})
+}
+
+// writeTxn is a transaction of store.update, the keys written by it are
+// assigned with the revision next to the current one.
+type writeTxn struct {
+	s   *store
+	tx  *gorm.DB
+	row *revisionDO
+	// events are the changes of keys written by the transaction.
+	events []*eventDO
+	// headers are the response headers returned by the transaction, their
+	// revisions are filled after the transaction is done.
+	headers []*pb.ResponseHeader
+}
+
+func (t *writeTxn) newHeader() *pb.ResponseHeader {
+	h := t.s.header(t.row.Revision)
+	t.headers = append(t.headers, h)
+	return h
+}
+
+// writeRevision returns the revision of the keys written by the transaction.
+func (t *writeTxn) writeRevision() int64 {
+	return t.row.Revision + 1
+}
+
+func (t *writeTxn) addEvent(typ mvccpb.Event_EventType, kv *kvDO) {
+	t.events = append(t.events, &eventDO{
+		Type:           int32(typ),
+		Key:            kv.Key,
+		Value:          kv.Value,
+		CreateRevision: kv.CreateRevision,
+		ModRevision:    kv.ModRevision,
+		Version:        kv.Version,
+		Lease:          kv.Lease,
+	})
+}
+
+// deleteKVs deletes the given keys, the keys must be read in the transaction.
+func (t *writeTxn) deleteKVs(kvs []*kvDO) error {
+	if len(kvs) == 0 {
+		return nil
+	}
+	keys := make([][]byte, 0, len(kvs))
+	for _, kv := range kvs {
+		keys = append(keys, kv.Key)
+		t.addEvent(mvccpb.DELETE, &kvDO{Key: kv.Key, ModRevision: t.writeRevision()})
+	}
+	return errors.Trace(t.tx.Where("meta_key IN ?", keys).Delete(&kvDO{}).Error)
+}
+
+// revokeLease deletes the lease and the keys attached to it. If onlyExpired
+// is true, the lease is revoked only if it has expired.
+func (t *writeTxn) revokeLease(id int64, onlyExpired bool) (bool, error) {
+	query := t.tx.Where("id = ?", id)
+	if onlyExpired {
+		query = query.Where("expire_time < " + t.s.nowExpr)
+	}
+	ret := query.Delete(&leaseDO{})
+	if ret.Error != nil {
+		return false, errors.Trace(ret.Error)
+	}
+	if ret.RowsAffected == 0 {
+		return false, nil
+	}
+	var kvs []*kvDO
+	if err := t.tx.Where("lease_id = ?", id).Find(&kvs).Error; err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, t.deleteKVs(kvs)
+}
+
+// compact removes the events whose revisions are not greater than rev.
+func (t *writeTxn) compact(rev int64) error {
+	err := t.tx.Where("revision <= ?", rev).Delete(&eventDO{}).Error
+	if err != nil {
+		return errors.Trace(err)
+	}
+	t.row.CompactRevision = rev
+	return nil
+}
+
+// whereKeyRange limits the query to keys in [key, end) with the same
+// semantics as etcd, and returns a query which can be reused.
+func whereKeyRange(db *gorm.DB, key, end []byte) *gorm.DB {
+	switch {
+	case len(end) == 0:
+		db = db.Where("meta_key = ?", key)
+	case len(end) == 1 && end[0] == 0:
+		db = db.Where("meta_key >= ?", key)
+	default:
+		db = db.Where("meta_key >= ? AND meta_key < ?", key, end)
+	}
+	return db.Session(&gorm.Session{})
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/pingcap/log"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// watchServer implements the etcd Watch service on the store. The previous
+// key values of events are not supported, since the store doesn't keep the
+// history of keys.
+type watchServer struct {
+	s *store
+}
+
+var _ pb.WatchServer = (*watchServer)(nil)
+
+// Watch implements pb.WatchServer.
+func (w *watchServer) Watch(stream pb.Watch_WatchServer) error {
+	ws := &watchStream{
+		s:        w.s,
+		stream:   stream,
+		watchers: make(map[int64]*watcher),
+	}
+	defer ws.close()
+
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch r := req.RequestUnion.(type) {
+		case *pb.WatchRequest_CreateRequest:
+			err = ws.create(ctx, r.CreateRequest)
+		case *pb.WatchRequest_CancelRequest:
+			err = ws.cancel(r.CancelRequest.WatchId)
+		case *pb.WatchRequest_ProgressRequest:
+			err = ws.progress()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// watchStream is a stream of watch requests, each watcher of the stream
+// polls its events from the store in a separate goroutine.
+type watchStream struct {
+	s      *store
+	stream pb.Watch_WatchServer
+	// sendMu serializes the responses sent by watchers.
+	sendMu sync.Mutex
+
+	mu       sync.Mutex
+	nextID   int64
+	watchers map[int64]*watcher
+	wg       sync.WaitGroup
+}
+
+type watcher struct {
+	id       int64
+	key      []byte
+	end      []byte
+	noPut    bool
+	noDelete bool
+	// nextRev is the revision of the next event to send.
+	nextRev atomic.Int64
+	cancel  context.CancelFunc
+}
+
+func (ws *watchStream) send(resp *pb.WatchResponse) error {
+	ws.sendMu.Lock()
+	defer ws.sendMu.Unlock()
+	return ws.stream.Send(resp)
+}
+
+func (ws *watchStream) create(ctx context.Context, req *pb.WatchCreateRequest) error {
+	rev, err := ws.s.currentRevision(ctx)
+	if err != nil {
+		return err
+	}
+	w := &watcher{key: req.Key, end: req.RangeEnd}
+	for _, filter := range req.Filters {
+		switch filter {
+		case pb.WatchCreateRequest_NOPUT:
+			w.noPut = true
+		case pb.WatchCreateRequest_NODELETE:
+			w.noDelete = true
+		}
+	}
+	if req.StartRevision > 0 {
+		w.nextRev.Store(req.StartRevision)
+	} else {
+		w.nextRev.Store(rev + 1)
+	}
+
+	var watchCtx context.Context
+	watchCtx, w.cancel = context.WithCancel(ctx)
+	ws.mu.Lock()
+	w.id = ws.nextID
+	ws.nextID++
+	ws.watchers[w.id] = w
+	ws.mu.Unlock()
+
+	// The created response must be sent before any event of the watcher.
+	err = ws.send(&pb.WatchResponse{Header: ws.s.header(rev), WatchId: w.id, Created: true})
+	if err != nil {
+		w.cancel()
+		return err
+	}
+	ws.wg.Add(1)
+	go func() {
+		defer ws.wg.Done()
+		ws.run(watchCtx, w)
+	}()
+	return nil
+}
+
+func (ws *watchStream) cancel(id int64) error {
+	ws.mu.Lock()
+	w, ok := ws.watchers[id]
+	delete(ws.watchers, id)
+	ws.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	w.cancel()
+	return ws.send(&pb.WatchResponse{
+		Header:   ws.s.header(w.nextRev.Load() - 1),
+		WatchId:  id,
+		Canceled: true,
+	})
+}
+
+// progress sends a progress notification to each watcher, which means that
+// all events before the revision of the notification have been sent.
+func (ws *watchStream) progress() error {
+	ws.mu.Lock()
+	watchers := make([]*watcher, 0, len(ws.watchers))
+	for _, w := range ws.watchers {
+		watchers = append(watchers, w)
+	}
+	ws.mu.Unlock()
+	for _, w := range watchers {
+		err := ws.send(&pb.WatchResponse{
+			Header:  ws.s.header(w.nextRev.Load() - 1),
+			WatchId: w.id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ws *watchStream) run(ctx context.Context, w *watcher) {
+	receiver, err := ws.s.notifier.NewReceiver(watchPollInterval)
+	if err != nil {
+		// The store is closed.
+		return
+	}
+	defer receiver.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-receiver.C:
+			if !ok {
+				return
+			}
+		}
+
+		nextRev := w.nextRev.Load()
+		events, rev, compactRev, err := ws.s.readEvents(ctx, w.key, w.end, nextRev)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Warn("meta store watcher fails to read events",
+					zap.ByteString("key", w.key), zap.Error(err))
+			}
+			continue
+		}
+		if nextRev <= compactRev {
+			// The client closes the watch channel with ErrCompacted.
+			_ = ws.send(&pb.WatchResponse{
+				Header:          ws.s.header(rev),
+				WatchId:         w.id,
+				Canceled:        true,
+				CompactRevision: compactRev,
+			})
+			return
+		}
+		if rev < nextRev {
+			continue
+		}
+		w.nextRev.Store(rev + 1)
+
+		resp := &pb.WatchResponse{Header: ws.s.header(rev), WatchId: w.id}
+		for _, e := range events {
+			if (e.Type == int32(mvccpb.PUT) && w.noPut) ||
+				(e.Type == int32(mvccpb.DELETE) && w.noDelete) {
+				continue
+			}
+			resp.Events = append(resp.Events, e.toPB())
+		}
+		if len(resp.Events) == 0 {
+			continue
+		}
+		if err := ws.send(resp); err != nil {
+			return
+		}
+	}
+}
+
+func (ws *watchStream) close() {
+	ws.mu.Lock()
+	for _, w := range ws.watchers {
+		w.cancel()
+	}
+	ws.watchers = nil
+	ws.mu.Unlock()
+	ws.wg.Wait()
+}
@@ -0,0 +1,367 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	stdErrors "errors"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"github.com/pingcap/tiflow/pkg/sink"
+	pmysql "github.com/pingcap/tiflow/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+const (
+	partitionCheckpointTable = "mq_consumer_partition_checkpoint"
+	tableCheckpointTable     = "mq_consumer_table_checkpoint"
+	ddlCheckpointTable       = "mq_consumer_ddl_checkpoint"
+)
+
+var createCheckpointTableSQLs = []string{
+	`CREATE TABLE IF NOT EXISTS ` + filter.TiCDCSystemSchema + `.` + partitionCheckpointTable + `
+	(
+		consumer_id varchar(255) NOT NULL,
+		topic varchar(249) NOT NULL,
+		partition_id int NOT NULL,
+		next_offset bigint NOT NULL,
+		watermark bigint unsigned NOT NULL,
+		watermark_offset bigint NOT NULL,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, topic, partition_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS ` + filter.TiCDCSystemSchema + `.` + tableCheckpointTable + `
+	(
+		consumer_id varchar(255) NOT NULL,
+		topic varchar(249) NOT NULL,
+		partition_id int NOT NULL,
+		schema_name varchar(64) NOT NULL,
+		table_name varchar(64) NOT NULL,
+		resolved_ts bigint unsigned NOT NULL,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, topic, partition_id, schema_name, table_name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS ` + filter.TiCDCSystemSchema + `.` + ddlCheckpointTable + `
+	(
+		consumer_id varchar(255) NOT NULL,
+		commit_ts bigint unsigned NOT NULL,
+		query_digest char(64) NOT NULL,
+		query text NOT NULL,
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, commit_ts, query_digest)
+	)`,
+}
+
+// partitionCheckpoint is the persisted progress of one topic partition.
+// Every event before offset whose commitTs is not larger than the resolved
+// ts of its table has been written to the downstream.
+type partitionCheckpoint struct {
+	topic     string
+	partition int32
+	// offset is the first offset to consume after restart.
+	offset int64
+	// watermark is the resolved ts which all table sinks have been flushed to.
+	watermark       uint64
+	watermarkOffset int64
+	// tables is the resolved ts of each table, keyed by the quoted table name.
+	tables map[string]*tableCheckpoint
+}
+
+type tableCheckpoint struct {
+	schema     string
+	table      string
+	resolvedTs uint64
+}
+
+// ddlCheckpoint records the DDLs with the max commitTs executed downstream,
+// a rename tables DDL may be split into several DDL events with the same commitTs.
+type ddlCheckpoint struct {
+	commitTs uint64
+	digests  map[string]struct{}
+}
+
+func newDDLCheckpoint() *ddlCheckpoint {
+	return &ddlCheckpoint{digests: make(map[string]struct{})}
+}
+
+// executed returns true if the DDL has been executed downstream.
+func (c *ddlCheckpoint) executed(ddl *model.DDLEvent) bool {
+	if ddl.CommitTs != c.commitTs {
+		return ddl.CommitTs < c.commitTs
+	}
+	_, ok := c.digests[queryDigest(ddl.Query)]
+	return ok
+}
+
+func (c *ddlCheckpoint) advance(ddl *model.DDLEvent) {
+	if ddl.CommitTs > c.commitTs {
+		c.commitTs = ddl.CommitTs
+		c.digests = make(map[string]struct{})
+	}
+	c.digests[queryDigest(ddl.Query)] = struct{}{}
+}
+
+func queryDigest(query string) string {
+	digest := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(digest[:])
+}
+
+// checkpointStore persists the consumer progress, so that the consumer
+// can resume from where it stopped.
+type checkpointStore interface {
+	// Load returns the checkpoint of the topic partition, nil if not found.
+	Load(ctx context.Context, topic string, partition int32) (*partitionCheckpoint, error)
+	// Save persists the checkpoints atomically.
+	Save(ctx context.Context, checkpoints []*partitionCheckpoint) error
+	// LoadDDL returns the checkpoint of executed DDLs.
+	LoadDDL(ctx context.Context) (*ddlCheckpoint, error)
+	// SaveDDL records the DDL as executed.
+	SaveDDL(ctx context.Context, ddl *model.DDLEvent) error
+	Close() error
+}
+
+// newCheckpointStore creates a checkpoint store in the downstream if it
+// is MySQL compatible, otherwise the progress is only kept in memory.
+func newCheckpointStore(ctx context.Context, o *Option) (checkpointStore, error) {
+	sinkURI, err := url.Parse(o.DownstreamURI)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !sink.IsMySQLCompatibleScheme(strings.ToLower(sinkURI.Scheme)) {
+		log.Warn("downstream is not MySQL compatible, the consumer progress is not persisted",
+			zap.String("downstreamURI", o.DownstreamURI))
+		return &memoryCheckpointStore{}, nil
+	}
+	return newMySQLCheckpointStore(ctx, o, sinkURI)
+}
+
+type mysqlCheckpointStore struct {
+	db         *sql.DB
+	consumerID string
+}
+
+func newMySQLCheckpointStore(
+	ctx context.Context, o *Option, sinkURI *url.URL,
+) (*mysqlCheckpointStore, error) {
+	cfg := pmysql.NewConfig()
+	changefeed := model.DefaultChangeFeedID(o.Name)
+	if err := cfg.Apply(o.Timezone, changefeed, sinkURI, o.ReplicaConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+	dsnStr, err := pmysql.GenerateDSN(ctx, sinkURI, cfg, pmysql.CreateMySQLDBConn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	db, err := pmysql.CreateMySQLDBConn(ctx, dsnStr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &mysqlCheckpointStore{
+		db:         db,
+		consumerID: o.ConsumerID,
+	}
+	if err = s.createTables(ctx); err != nil {
+		_ = db.Close()
+		return nil, errors.Trace(err)
+	}
+	log.Info("consumer checkpoint store created",
+		zap.String("consumerID", o.ConsumerID), zap.String("schema", filter.TiCDCSystemSchema))
+	return s, nil
+}
+
+func (s *mysqlCheckpointStore) createTables(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+filter.TiCDCSystemSchema)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLQueryError,
+			errors.WithMessage(err, "failed to create checkpoint schema;"))
+	}
+	for _, query := range createCheckpointTableSQLs {
+		if _, err = s.db.ExecContext(ctx, query); err != nil {
+			return cerror.WrapError(cerror.ErrMySQLQueryError,
+				errors.WithMessage(err, "failed to create checkpoint table;"))
+		}
+	}
+	return nil
+}
+
+func (s *mysqlCheckpointStore) Load(
+	ctx context.Context, topic string, partition int32,
+) (*partitionCheckpoint, error) {
+	query := "SELECT next_offset, watermark, watermark_offset FROM " +
+		filter.TiCDCSystemSchema + "." + partitionCheckpointTable +
+		" WHERE consumer_id = ? AND topic = ? AND partition_id = ?"
+	result := &partitionCheckpoint{
+		topic:     topic,
+		partition: partition,
+		tables:    make(map[string]*tableCheckpoint),
+	}
+	err := s.db.QueryRowContext(ctx, query, s.consumerID, topic, partition).
+		Scan(&result.offset, &result.watermark, &result.watermarkOffset)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+
+	query = "SELECT schema_name, table_name, resolved_ts FROM " +
+		filter.TiCDCSystemSchema + "." + tableCheckpointTable +
+		" WHERE consumer_id = ? AND topic = ? AND partition_id = ?"
+	rows, err := s.db.QueryContext(ctx, query, s.consumerID, topic, partition)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		t := &tableCheckpoint{}
+		if err = rows.Scan(&t.schema, &t.table, &t.resolvedTs); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		result.tables[quotes.QuoteSchema(t.schema, t.table)] = t
+	}
+	if err = rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return result, nil
+}
+
+func (s *mysqlCheckpointStore) Save(ctx context.Context, checkpoints []*partitionCheckpoint) error {
+	if len(checkpoints) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, "save checkpoint: begin Tx fail;"))
+	}
+	partitionSQL := "INSERT INTO " + filter.TiCDCSystemSchema + "." + partitionCheckpointTable +
+		" (consumer_id, topic, partition_id, next_offset, watermark, watermark_offset) VALUES (?, ?, ?, ?, ?, ?)" +
+		" ON DUPLICATE KEY UPDATE next_offset = VALUES(next_offset), watermark = VALUES(watermark)," +
+		" watermark_offset = VALUES(watermark_offset)"
+	tableSQL := "INSERT INTO " + filter.TiCDCSystemSchema + "." + tableCheckpointTable +
+		" (consumer_id, topic, partition_id, schema_name, table_name, resolved_ts) VALUES (?, ?, ?, ?, ?, ?)" +
+		" ON DUPLICATE KEY UPDATE resolved_ts = VALUES(resolved_ts)"
+	for _, c := range checkpoints {
+		_, err = tx.ExecContext(ctx, partitionSQL, s.consumerID, c.topic, c.partition,
+			c.offset, c.watermark, c.watermarkOffset)
+		if err != nil {
+			return s.rollback(tx, err)
+		}
+		for _, t := range c.tables {
+			_, err = tx.ExecContext(ctx, tableSQL, s.consumerID, c.topic, c.partition,
+				t.schema, t.table, t.resolvedTs)
+			if err != nil {
+				return s.rollback(tx, err)
+			}
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, "save checkpoint: commit Tx fail;"))
+	}
+	return nil
+}
+
+func (s *mysqlCheckpointStore) LoadDDL(ctx context.Context) (*ddlCheckpoint, error) {
+	query := "SELECT commit_ts, query_digest FROM " + filter.TiCDCSystemSchema + "." + ddlCheckpointTable +
+		" WHERE consumer_id = ? AND commit_ts = (SELECT MAX(commit_ts) FROM " +
+		filter.TiCDCSystemSchema + "." + ddlCheckpointTable + " WHERE consumer_id = ?)"
+	rows, err := s.db.QueryContext(ctx, query, s.consumerID, s.consumerID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	result := newDDLCheckpoint()
+	for rows.Next() {
+		var digest string
+		if err = rows.Scan(&result.commitTs, &digest); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		result.digests[digest] = struct{}{}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return result, nil
+}
+
+func (s *mysqlCheckpointStore) SaveDDL(ctx context.Context, ddl *model.DDLEvent) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, "save ddl checkpoint: begin Tx fail;"))
+	}
+	// only the DDLs with the max commitTs are required to skip the executed ones.
+	_, err = tx.ExecContext(ctx, "DELETE FROM "+filter.TiCDCSystemSchema+"."+ddlCheckpointTable+
+		" WHERE consumer_id = ? AND commit_ts < ?", s.consumerID, ddl.CommitTs)
+	if err != nil {
+		return s.rollback(tx, err)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO "+filter.TiCDCSystemSchema+"."+ddlCheckpointTable+
+		" (consumer_id, commit_ts, query_digest, query) VALUES (?, ?, ?, ?)",
+		s.consumerID, ddl.CommitTs, queryDigest(ddl.Query), ddl.Query)
+	if err != nil {
+		return s.rollback(tx, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, "save ddl checkpoint: commit Tx fail;"))
+	}
+	return nil
+}
+
+func (s *mysqlCheckpointStore) rollback(tx *sql.Tx, err error) error {
+	if rbErr := tx.Rollback(); rbErr != nil {
+		log.Error("failed to rollback the checkpoint transaction", zap.Error(rbErr))
+	}
+	return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+}
+
+func (s *mysqlCheckpointStore) Close() error {
+	return s.db.Close()
+}
+
+// memoryCheckpointStore is used when the downstream cannot persist
+// the checkpoint, the consumer starts from the offsets committed to the MQ.
+type memoryCheckpointStore struct{}
+
+func (s *memoryCheckpointStore) Load(context.Context, string, int32) (*partitionCheckpoint, error) {
+	return nil, nil
+}
+
+func (s *memoryCheckpointStore) Save(context.Context, []*partitionCheckpoint) error {
+	return nil
+}
+
+func (s *memoryCheckpointStore) LoadDDL(context.Context) (*ddlCheckpoint, error) {
+	return newDDLCheckpoint(), nil
+}
+
+func (s *memoryCheckpointStore) SaveDDL(context.Context, *model.DDLEvent) error {
+	return nil
+}
+
+func (s *memoryCheckpointStore) Close() error {
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDDLCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := newDDLCheckpoint()
+	ddl1 := &model.DDLEvent{CommitTs: 10, Query: "RENAME TABLE a TO b"}
+	ddl2 := &model.DDLEvent{CommitTs: 10, Query: "RENAME TABLE c TO d"}
+	ddl3 := &model.DDLEvent{CommitTs: 20, Query: "CREATE TABLE e (id int)"}
+	require.False(t, checkpoint.executed(ddl1))
+
+	checkpoint.advance(ddl1)
+	require.True(t, checkpoint.executed(ddl1))
+	// the DDLs split from a rename tables DDL share the same commitTs.
+	require.False(t, checkpoint.executed(ddl2))
+	require.False(t, checkpoint.executed(ddl3))
+
+	checkpoint.advance(ddl2)
+	checkpoint.advance(ddl3)
+	require.True(t, checkpoint.executed(ddl1))
+	require.True(t, checkpoint.executed(ddl2))
+	require.True(t, checkpoint.executed(ddl3))
+	require.Len(t, checkpoint.digests, 1)
+}
+
+func TestMySQLCheckpointStore(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	store := &mysqlCheckpointStore{db: db, consumerID: "consumer"}
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT next_offset, watermark, watermark_offset FROM tidb_cdc.mq_consumer_partition_checkpoint"+
+		" WHERE consumer_id = ? AND topic = ? AND partition_id = ?").
+		WithArgs("consumer", "topic", int32(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"next_offset", "watermark", "watermark_offset"}))
+	checkpoint, err := store.Load(ctx, "topic", 0)
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+
+	mock.ExpectQuery("SELECT next_offset, watermark, watermark_offset FROM tidb_cdc.mq_consumer_partition_checkpoint"+
+		" WHERE consumer_id = ? AND topic = ? AND partition_id = ?").
+		WithArgs("consumer", "topic", int32(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"next_offset", "watermark", "watermark_offset"}).
+			AddRow(100, 1000, 90))
+	mock.ExpectQuery("SELECT schema_name, table_name, resolved_ts FROM tidb_cdc.mq_consumer_table_checkpoint"+
+		" WHERE consumer_id = ? AND topic = ? AND partition_id = ?").
+		WithArgs("consumer", "topic", int32(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "table_name", "resolved_ts"}).
+			AddRow("test", "t", 900))
+	checkpoint, err = store.Load(ctx, "topic", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), checkpoint.offset)
+	require.Equal(t, uint64(1000), checkpoint.watermark)
+	require.Equal(t, int64(90), checkpoint.watermarkOffset)
+	require.Equal(t, uint64(900), checkpoint.tables["`test`.`t`"].resolvedTs)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tidb_cdc.mq_consumer_partition_checkpoint"+
+		" (consumer_id, topic, partition_id, next_offset, watermark, watermark_offset) VALUES (?, ?, ?, ?, ?, ?)"+
+		" ON DUPLICATE KEY UPDATE next_offset = VALUES(next_offset), watermark = VALUES(watermark),"+
+		" watermark_offset = VALUES(watermark_offset)").
+		WithArgs("consumer", "topic", int32(1), int64(100), uint64(1000), int64(90)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO tidb_cdc.mq_consumer_table_checkpoint"+
+		" (consumer_id, topic, partition_id, schema_name, table_name, resolved_ts) VALUES (?, ?, ?, ?, ?, ?)"+
+		" ON DUPLICATE KEY UPDATE resolved_ts = VALUES(resolved_ts)").
+		WithArgs("consumer", "topic", int32(1), "test", "t", uint64(900)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	require.NoError(t, store.Save(ctx, []*partitionCheckpoint{checkpoint}))
+
+	ddl := &model.DDLEvent{CommitTs: 20, Query: "CREATE TABLE t (id int)"}
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM tidb_cdc.mq_consumer_ddl_checkpoint WHERE consumer_id = ? AND commit_ts < ?").
+		WithArgs("consumer", uint64(20)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT IGNORE INTO tidb_cdc.mq_consumer_ddl_checkpoint"+
+		" (consumer_id, commit_ts, query_digest, query) VALUES (?, ?, ?, ?)").
+		WithArgs("consumer", uint64(20), queryDigest(ddl.Query), ddl.Query).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	require.NoError(t, store.SaveDDL(ctx, ddl))
+
+	mock.ExpectQuery("SELECT commit_ts, query_digest FROM tidb_cdc.mq_consumer_ddl_checkpoint"+
+		" WHERE consumer_id = ? AND commit_ts = (SELECT MAX(commit_ts) FROM tidb_cdc.mq_consumer_ddl_checkpoint"+
+		" WHERE consumer_id = ?)").
+		WithArgs("consumer", "consumer").
+		WillReturnRows(sqlmock.NewRows([]string{"commit_ts", "query_digest"}).
+			AddRow(20, queryDigest(ddl.Query)))
+	ddlCheckpoint, err := store.LoadDDL(ctx)
+	require.NoError(t, err)
+	require.True(t, ddlCheckpoint.executed(ddl))
+
+	mock.ExpectClose()
+	require.NoError(t, store.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/avro"
+	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
+	"github.com/pingcap/tiflow/pkg/sink/codec/debezium"
+	"github.com/pingcap/tiflow/pkg/sink/codec/open"
+	"github.com/pingcap/tiflow/pkg/sink/codec/simple"
+	"go.uber.org/zap"
+)
+
+// NewDecoder will create a new event decoder for the topic.
+// The large message handle, such as claim check and handle key only,
+// is configured by the codec config.
+func NewDecoder(
+	ctx context.Context, option *Option, topic string, upstreamTiDB *sql.DB,
+) (codec.RowEventDecoder, error) {
+	var (
+		decoder codec.RowEventDecoder
+		err     error
+	)
+	switch option.Protocol {
+	case config.ProtocolOpen, config.ProtocolDefault:
+		decoder, err = open.NewBatchDecoder(ctx, option.CodecConfig, upstreamTiDB)
+	case config.ProtocolCanalJSON:
+		decoder, err = canal.NewBatchDecoder(ctx, option.CodecConfig, upstreamTiDB)
+	case config.ProtocolAvro:
+		schemaM, err := avro.NewConfluentSchemaManager(ctx, option.SchemaRegistryURI, nil)
+		if err != nil {
+			return decoder, cerror.Trace(err)
+		}
+		decoder = avro.NewDecoder(option.CodecConfig, schemaM, topic, upstreamTiDB)
+	case config.ProtocolSimple:
+		decoder, err = simple.NewDecoder(ctx, option.CodecConfig, upstreamTiDB)
+	case config.ProtocolDebezium:
+		decoder = debezium.NewDecoder(option.CodecConfig, upstreamTiDB)
+	default:
+		return nil, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(option.Protocol)
+	}
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	return decoder, err
+}
+
+// OpenDB opens the database by the dsn.
+func OpenDB(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		log.Error("open db failed", zap.Error(err))
+		return nil, cerror.Trace(err)
+	}
+
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(10 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err = db.PingContext(ctx); err != nil {
+		log.Error("ping db failed", zap.String("dsn", dsn), zap.Error(err))
+		return nil, cerror.Trace(err)
+	}
+	log.Info("open db success", zap.String("dsn", dsn))
+	return db, nil
+}
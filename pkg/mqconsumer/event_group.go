@@ -11,12 +11,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package mqconsumer
 
 import (
 	"sort"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/pingcap/log"
 	"github.com/pingcap/tiflow/cdc/model"
 	"go.uber.org/zap"
@@ -27,22 +26,26 @@ type eventsGroup struct {
 	partition int32
 	tableID   int64
 
-	events        []*model.RowChangedEvent
+	events []*model.RowChangedEvent
+	// offsets is the offset of the message of each event.
+	offsets       []int64
 	highWatermark uint64
 }
 
-// NewEventsGroup will create new event group.
-func NewEventsGroup(partition int32, tableID int64) *eventsGroup {
+// newEventsGroup will create new event group.
+func newEventsGroup(partition int32, tableID int64) *eventsGroup {
 	return &eventsGroup{
 		partition: partition,
 		tableID:   tableID,
 		events:    make([]*model.RowChangedEvent, 0, 1024),
+		offsets:   make([]int64, 0, 1024),
 	}
 }
 
 // Append will append an event to event groups.
-func (g *eventsGroup) Append(row *model.RowChangedEvent, offset kafka.Offset) {
+func (g *eventsGroup) Append(row *model.RowChangedEvent, offset int64) {
 	g.events = append(g.events, row)
+	g.offsets = append(g.offsets, offset)
 	if row.CommitTs > g.highWatermark {
 		g.highWatermark = row.CommitTs
 	}
@@ -57,14 +60,17 @@ func (g *eventsGroup) Append(row *model.RowChangedEvent, offset kafka.Offset) {
 		zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns))
 }
 
-// Resolve will get events where CommitTs is less than resolveTs.
-func (g *eventsGroup) Resolve(resolve uint64) []*model.RowChangedEvent {
+// Resolve will get events where CommitTs is less than resolveTs,
+// and the offsets of these events.
+func (g *eventsGroup) Resolve(resolve uint64) ([]*model.RowChangedEvent, []int64) {
 	i := sort.Search(len(g.events), func(i int) bool {
 		return g.events[i].CommitTs > resolve
 	})
 
 	result := g.events[:i]
+	offsets := g.offsets[:i]
 	g.events = g.events[i:]
+	g.offsets = g.offsets[i:]
 	if len(result) != 0 && len(g.events) != 0 {
 		log.Warn("not all events resolved",
 			zap.Int32("partition", g.partition), zap.Int64("tableID", g.tableID),
@@ -72,5 +78,19 @@ func (g *eventsGroup) Resolve(resolve uint64) []*model.RowChangedEvent {
 			zap.Uint64("resolveTs", resolve), zap.Uint64("firstCommitTs", g.events[0].CommitTs))
 	}
 
-	return result
+	return result, offsets
+}
+
+// minOffset returns the min offset of the events not resolved yet.
+func (g *eventsGroup) minOffset() (int64, bool) {
+	if len(g.offsets) == 0 {
+		return 0, false
+	}
+	result := g.offsets[0]
+	for _, offset := range g.offsets[1:] {
+		if offset < result {
+			result = offset
+		}
+	}
+	return result, true
 }
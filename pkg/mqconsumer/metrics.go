@@ -0,0 +1,90 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	sinkmetrics "github.com/pingcap/tiflow/cdc/sink/metrics"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// receivedMessageCounter records the number of messages received.
+	receivedMessageCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "mq_consumer",
+			Name:      "received_message_count",
+			Help:      "The number of messages received by the consumer.",
+		}, []string{"topic", "partition"})
+	// receivedEventCounter records the number of events decoded from messages.
+	receivedEventCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "mq_consumer",
+			Name:      "received_event_count",
+			Help:      "The number of events decoded by the consumer.",
+		}, []string{"topic", "type"})
+	// partitionWatermarkGauge records the watermark of each partition.
+	partitionWatermarkGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "mq_consumer",
+			Name:      "partition_watermark",
+			Help:      "The physical time(ms) of the watermark of each partition.",
+		}, []string{"topic", "partition"})
+	// checkpointTsGauge records the ts all assigned partitions are flushed to.
+	checkpointTsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "mq_consumer",
+			Name:      "checkpoint_ts",
+			Help:      "The physical time(ms) which all assigned partitions are flushed to downstream.",
+		})
+	// checkpointSaveDuration records the duration of persisting the checkpoint.
+	checkpointSaveDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "mq_consumer",
+			Name:      "checkpoint_save_duration",
+			Help:      "Bucketed histogram of persisting checkpoint time (s).",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 18), // 1ms~131s
+		})
+	// rebalanceCounter records the number of partition assignment changes.
+	rebalanceCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "mq_consumer",
+			Name:      "rebalance_count",
+			Help:      "The number of partitions assigned or revoked by rebalance.",
+		}, []string{"type"})
+)
+
+// InitMetrics registers all metrics used by the consumer.
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	registry.MustRegister(prometheus.NewGoCollector())
+
+	registry.MustRegister(receivedMessageCounter)
+	registry.MustRegister(receivedEventCounter)
+	registry.MustRegister(partitionWatermarkGauge)
+	registry.MustRegister(checkpointTsGauge)
+	registry.MustRegister(checkpointSaveDuration)
+	registry.MustRegister(rebalanceCounter)
+
+	// metrics of the downstream sinks.
+	sinkmetrics.InitMetrics(registry)
+	// checksum mismatches found by the decoders.
+	integrity.InitMetrics(registry)
+}
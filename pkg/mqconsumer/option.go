@@ -0,0 +1,127 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"math"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// DefaultCheckpointInterval is the default min interval to persist the progress.
+const DefaultCheckpointInterval = time.Second
+
+// InvalidOffset means the offset is unknown.
+const InvalidOffset int64 = -1
+
+// Option is the option of the writer shared by MQ consumers.
+type Option struct {
+	// Name is the name of the consumer, such as kafka-consumer,
+	// it's used as the changefeed ID of the downstream sinks.
+	Name string
+	// ConsumerID identifies the progress persisted in the downstream, such as
+	// the kafka consumer group ID, consumers with the same ID share the progress.
+	ConsumerID string
+	Topics     []string
+
+	Protocol    config.Protocol
+	CodecConfig *common.Config
+	// the replicaConfig of the changefeed which produce data to the topics
+	ReplicaConfig *config.ReplicaConfig
+	// Timezone is used to connect to the downstream. The sinks read the time
+	// zone from the global server config, which is set by the consumer binary.
+	Timezone string
+
+	DownstreamURI string
+	// avro schema registry uri should be set if the encoding protocol is avro
+	SchemaRegistryURI string
+	// UpstreamTiDBDSN is the dsn of the upstream TiDB cluster, it's used to
+	// query the whole row if only the handle key is sent by the producer.
+	UpstreamTiDBDSN string
+	// SchemaHistoryPath is the path of the schema history exported from the
+	// upstream, it's used to decode the messages when the upstream is unavailable.
+	SchemaHistoryPath string
+
+	MaxMessageBytes int
+	MaxBatchSize    int
+
+	// CheckpointInterval is the min interval to persist the progress.
+	CheckpointInterval time.Duration
+	// LocalOffset is true if the offsets of messages are only meaningful in the
+	// current process, the persisted offsets are not used to resume consuming.
+	LocalOffset bool
+	// SkipPartitionCheck is true if the partition of a message is not calculated
+	// by the event router, such as pulsar dispatches messages by the key hash.
+	SkipPartitionCheck bool
+}
+
+// NewOption returns the option with default values.
+func NewOption(name string) *Option {
+	return &Option{
+		Name:               name,
+		MaxMessageBytes:    math.MaxInt64,
+		MaxBatchSize:       math.MaxInt64,
+		CheckpointInterval: DefaultCheckpointInterval,
+	}
+}
+
+// LoadSchemaHistory loads the schema history into the codec config if the path is set.
+func (o *Option) LoadSchemaHistory() error {
+	if o.SchemaHistoryPath == "" {
+		return nil
+	}
+	history, err := schemahistory.ReadFile(o.SchemaHistoryPath)
+	if err != nil {
+		return err
+	}
+	o.CodecConfig.SchemaHistory = history
+	log.Info("schema history loaded",
+		zap.String("path", o.SchemaHistoryPath),
+		zap.Uint64("startTs", history.StartTs),
+		zap.Uint64("endTs", history.EndTs),
+		zap.Int("tableVersions", len(history.Tables)))
+	return nil
+}
+
+// TopicPartition is a partition of a topic.
+type TopicPartition struct {
+	Topic     string
+	Partition int32
+}
+
+// Assignment is a partition assigned to the consumer.
+type Assignment struct {
+	TopicPartition
+	// PartitionNum is the partition number of the topic.
+	PartitionNum int32
+}
+
+// PartitionOffset is the offset of a partition.
+type PartitionOffset struct {
+	TopicPartition
+	Offset int64
+}
+
+// Message is a message received from the MQ.
+type Message struct {
+	TopicPartition
+	Offset int64
+	Key    []byte
+	Value  []byte
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/tablesink"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// eventOffset is the offset of an event sent to the table sink but not flushed yet.
+type eventOffset struct {
+	commitTs uint64
+	offset   int64
+}
+
+type partitionProgress struct {
+	topic           string
+	partition       int32
+	partitionNum    int32
+	watermark       uint64
+	watermarkOffset int64
+	// lastOffset is the offset of the latest consumed message.
+	lastOffset int64
+	// flushedTs is the resolved ts which all table sinks are flushed to.
+	flushedTs uint64
+	unflushed []eventOffset
+
+	tableSinkMap map[model.TableID]tablesink.TableSink
+	tableNames   map[model.TableID]model.TableName
+	// tableCheckpoints is restored from the checkpoint store, events whose
+	// commitTs is not larger than it have been written to the downstream.
+	tableCheckpoints map[string]uint64
+	eventGroups      map[model.TableID]*eventsGroup
+	decoder          codec.RowEventDecoder
+}
+
+func newPartitionProgress(
+	topic string, partition, partitionNum int32, decoder codec.RowEventDecoder,
+) *partitionProgress {
+	return &partitionProgress{
+		topic:            topic,
+		partition:        partition,
+		partitionNum:     partitionNum,
+		lastOffset:       InvalidOffset,
+		eventGroups:      make(map[model.TableID]*eventsGroup),
+		tableSinkMap:     make(map[model.TableID]tablesink.TableSink),
+		tableNames:       make(map[model.TableID]model.TableName),
+		tableCheckpoints: make(map[string]uint64),
+		decoder:          decoder,
+	}
+}
+
+// restore the progress from the checkpoint, events before the checkpoint
+// offset are not consumed again, events after it but already written to
+// the downstream are skipped by the watermark and table checkpoints.
+func (p *partitionProgress) restore(checkpoint *partitionCheckpoint, localOffset bool) {
+	p.watermark = checkpoint.watermark
+	p.flushedTs = checkpoint.watermark
+	if localOffset {
+		// the offsets of the last process are meaningless, all the watermarks
+		// received before a larger one are considered as replayed.
+		p.watermarkOffset = math.MaxInt64
+	} else {
+		p.watermarkOffset = checkpoint.watermarkOffset
+		p.lastOffset = checkpoint.offset - 1
+	}
+	for name, table := range checkpoint.tables {
+		p.tableCheckpoints[name] = table.resolvedTs
+	}
+	partitionWatermarkGauge.WithLabelValues(p.topic, strconv.Itoa(int(p.partition))).
+		Set(float64(oracle.ExtractPhysical(p.watermark)))
+	log.Info("partition progress restored",
+		zap.String("topic", p.topic), zap.Int32("partition", p.partition),
+		zap.Int64("offset", checkpoint.offset), zap.Uint64("watermark", checkpoint.watermark),
+		zap.Int64("watermarkOffset", checkpoint.watermarkOffset),
+		zap.Int("tables", len(checkpoint.tables)), zap.Bool("localOffset", localOffset))
+}
+
+func (p *partitionProgress) updateWatermark(newWatermark uint64, offset int64) {
+	watermark := p.loadWatermark()
+	if newWatermark >= watermark {
+		p.watermark = newWatermark
+		p.watermarkOffset = offset
+		partitionWatermarkGauge.WithLabelValues(p.topic, strconv.Itoa(int(p.partition))).
+			Set(float64(oracle.ExtractPhysical(newWatermark)))
+		log.Info("watermark received", zap.String("topic", p.topic),
+			zap.Int32("partition", p.partition), zap.Int64("offset", offset),
+			zap.Uint64("watermark", newWatermark))
+		return
+	}
+	if offset > p.watermarkOffset {
+		log.Panic("partition resolved ts fallback",
+			zap.String("topic", p.topic), zap.Int32("partition", p.partition),
+			zap.Uint64("newWatermark", newWatermark), zap.Int64("offset", offset),
+			zap.Uint64("watermark", watermark), zap.Int64("watermarkOffset", p.watermarkOffset))
+	}
+	log.Warn("partition resolved ts fall back, ignore it, since consumer read old offset message",
+		zap.String("topic", p.topic), zap.Int32("partition", p.partition),
+		zap.Uint64("newWatermark", newWatermark), zap.Int64("offset", offset),
+		zap.Uint64("watermark", watermark), zap.Int64("watermarkOffset", p.watermarkOffset))
+}
+
+func (p *partitionProgress) loadWatermark() uint64 {
+	return p.watermark
+}
+
+// markFlushed is called after all table sinks are flushed to the resolved ts.
+func (p *partitionProgress) markFlushed(resolvedTs uint64) {
+	if resolvedTs <= p.flushedTs {
+		return
+	}
+	p.flushedTs = resolvedTs
+	unflushed := p.unflushed[:0]
+	for _, e := range p.unflushed {
+		if e.commitTs > resolvedTs {
+			unflushed = append(unflushed, e)
+		}
+	}
+	p.unflushed = unflushed
+}
+
+// resumeOffset returns the first offset to consume if the consumer restarts,
+// all events before it are written to the downstream.
+func (p *partitionProgress) resumeOffset() int64 {
+	result := p.lastOffset + 1
+	for _, group := range p.eventGroups {
+		if offset, ok := group.minOffset(); ok && offset < result {
+			result = offset
+		}
+	}
+	for _, e := range p.unflushed {
+		if e.offset < result {
+			result = e.offset
+		}
+	}
+	return result
+}
+
+func (p *partitionProgress) checkpoint(offset int64) *partitionCheckpoint {
+	result := &partitionCheckpoint{
+		topic:           p.topic,
+		partition:       p.partition,
+		offset:          offset,
+		watermark:       p.flushedTs,
+		watermarkOffset: p.watermarkOffset,
+		tables:          make(map[string]*tableCheckpoint, len(p.tableSinkMap)),
+	}
+	for tableID, tableSink := range p.tableSinkMap {
+		name := p.tableNames[tableID]
+		// the table sink may be created after the last flush,
+		// its checkpoint is not larger than the flushed ts.
+		resolvedTs := tableSink.GetCheckpointTs().Ts
+		if resolvedTs > p.flushedTs {
+			resolvedTs = p.flushedTs
+		}
+		// all partitions of a partitioned table share the same checkpoint.
+		key := quotes.QuoteSchema(name.Schema, name.Table)
+		if t, ok := result.tables[key]; ok {
+			if resolvedTs < t.resolvedTs {
+				t.resolvedTs = resolvedTs
+			}
+			continue
+		}
+		result.tables[key] = &tableCheckpoint{
+			schema:     name.Schema,
+			table:      name.Table,
+			resolvedTs: resolvedTs,
+		}
+	}
+	return result
+}
+
+func (p *partitionProgress) close() {
+	for _, tableSink := range p.tableSinkMap {
+		tableSink.Close()
+	}
+	partitionWatermarkGauge.DeleteLabelValues(p.topic, strconv.Itoa(int(p.partition)))
+}
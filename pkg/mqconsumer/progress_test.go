@@ -0,0 +1,127 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newRowForTest(tableID int64, commitTs uint64) *model.RowChangedEvent {
+	columns := []*model.Column{{
+		Name: "a",
+		Type: mysql.TypeLong,
+		Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+	}}
+	return &model.RowChangedEvent{
+		CommitTs:        commitTs,
+		PhysicalTableID: tableID,
+		TableInfo:       model.BuildTableInfo("test", "t", columns, [][]int{{0}}),
+	}
+}
+
+func TestEventsGroupResolve(t *testing.T) {
+	t.Parallel()
+
+	group := newEventsGroup(0, 1)
+	_, ok := group.minOffset()
+	require.False(t, ok)
+
+	group.Append(newRowForTest(1, 10), 3)
+	group.Append(newRowForTest(1, 20), 4)
+	group.Append(newRowForTest(1, 30), 5)
+	offset, ok := group.minOffset()
+	require.True(t, ok)
+	require.Equal(t, int64(3), offset)
+	require.Equal(t, uint64(30), group.highWatermark)
+
+	events, offsets := group.Resolve(20)
+	require.Len(t, events, 2)
+	require.Equal(t, []int64{3, 4}, offsets)
+	offset, ok = group.minOffset()
+	require.True(t, ok)
+	require.Equal(t, int64(5), offset)
+}
+
+func TestPartitionProgressResumeOffset(t *testing.T) {
+	t.Parallel()
+
+	progress := newPartitionProgress("topic", 0, 3, nil)
+	require.Equal(t, int64(0), progress.resumeOffset())
+
+	progress.lastOffset = 10
+	require.Equal(t, int64(11), progress.resumeOffset())
+
+	// the events not resolved yet should be consumed again.
+	group := newEventsGroup(0, 1)
+	group.Append(newRowForTest(1, 100), 8)
+	progress.eventGroups[1] = group
+	require.Equal(t, int64(8), progress.resumeOffset())
+
+	// the events sent to the table sink but not flushed should be consumed again.
+	progress.unflushed = []eventOffset{{commitTs: 50, offset: 5}, {commitTs: 60, offset: 6}}
+	require.Equal(t, int64(5), progress.resumeOffset())
+
+	progress.markFlushed(50)
+	require.Equal(t, uint64(50), progress.flushedTs)
+	require.Equal(t, int64(6), progress.resumeOffset())
+
+	// the flushed ts never falls back.
+	progress.markFlushed(40)
+	require.Equal(t, uint64(50), progress.flushedTs)
+	require.Len(t, progress.unflushed, 1)
+
+	progress.markFlushed(60)
+	require.Empty(t, progress.unflushed)
+	require.Equal(t, int64(8), progress.resumeOffset())
+}
+
+func TestPartitionProgressRestore(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := &partitionCheckpoint{
+		topic:           "topic",
+		partition:       1,
+		offset:          100,
+		watermark:       1000,
+		watermarkOffset: 90,
+		tables: map[string]*tableCheckpoint{
+			"`test`.`t`": {schema: "test", table: "t", resolvedTs: 900},
+		},
+	}
+
+	progress := newPartitionProgress("topic", 1, 3, nil)
+	progress.restore(checkpoint, false)
+	require.Equal(t, uint64(1000), progress.watermark)
+	require.Equal(t, uint64(1000), progress.flushedTs)
+	require.Equal(t, int64(90), progress.watermarkOffset)
+	require.Equal(t, int64(100), progress.resumeOffset())
+	require.Equal(t, uint64(900), progress.tableCheckpoints["`test`.`t`"])
+
+	// the local offsets of the last process are not restored,
+	// the replayed watermarks are ignored instead of panic.
+	progress = newPartitionProgress("topic", 1, 3, nil)
+	progress.restore(checkpoint, true)
+	require.Equal(t, InvalidOffset, progress.lastOffset)
+	require.Equal(t, int64(math.MaxInt64), progress.watermarkOffset)
+	progress.updateWatermark(500, 0)
+	require.Equal(t, uint64(1000), progress.watermark)
+	progress.updateWatermark(1100, 1)
+	require.Equal(t, uint64(1100), progress.watermark)
+	require.Equal(t, int64(1), progress.watermarkOffset)
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// PartitionStatus is the progress of one assigned partition.
+type PartitionStatus struct {
+	Topic           string `json:"topic"`
+	Partition       int32  `json:"partition"`
+	Watermark       uint64 `json:"watermark"`
+	WatermarkOffset int64  `json:"watermark_offset"`
+	FlushedTs       uint64 `json:"flushed_ts"`
+	LastOffset      int64  `json:"last_offset"`
+	ResumeOffset    int64  `json:"resume_offset"`
+	PendingEvents   int    `json:"pending_events"`
+	TableCount      int    `json:"table_count"`
+}
+
+// ConsumerStatus is returned by the status http endpoint.
+type ConsumerStatus struct {
+	ConsumerID         string            `json:"consumer_id"`
+	Topics             []string          `json:"topics"`
+	Protocol           string            `json:"protocol"`
+	MinWatermark       uint64            `json:"min_watermark"`
+	PendingDDLCount    int               `json:"pending_ddl_count"`
+	DDLCheckpointTs    uint64            `json:"ddl_checkpoint_ts"`
+	LastCheckpointTime time.Time         `json:"last_checkpoint_time"`
+	Partitions         []PartitionStatus `json:"partitions"`
+}
+
+// Status returns the current progress of the writer.
+func (w *Writer) Status() *ConsumerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	result := &ConsumerStatus{
+		ConsumerID:         w.option.ConsumerID,
+		Topics:             w.option.Topics,
+		Protocol:           w.option.Protocol.String(),
+		MinWatermark:       w.getMinWatermark(),
+		PendingDDLCount:    len(w.ddlList),
+		DDLCheckpointTs:    w.ddlCheckpoint.commitTs,
+		LastCheckpointTime: w.lastCheckpointTime,
+		Partitions:         make([]PartitionStatus, 0, len(w.progresses)),
+	}
+	for _, p := range w.progresses {
+		pending := len(p.unflushed)
+		for _, group := range p.eventGroups {
+			pending += len(group.events)
+		}
+		result.Partitions = append(result.Partitions, PartitionStatus{
+			Topic:           p.topic,
+			Partition:       p.partition,
+			Watermark:       p.watermark,
+			WatermarkOffset: p.watermarkOffset,
+			FlushedTs:       p.flushedTs,
+			LastOffset:      p.lastOffset,
+			ResumeOffset:    w.resumeOffset(p),
+			PendingEvents:   pending,
+			TableCount:      len(p.tableSinkMap),
+		})
+	}
+	sort.Slice(result.Partitions, func(i, j int) bool {
+		if result.Partitions[i].Topic != result.Partitions[j].Topic {
+			return result.Partitions[i].Topic < result.Partitions[j].Topic
+		}
+		return result.Partitions[i].Partition < result.Partitions[j].Partition
+	})
+	return result
+}
+
+// RunStatusServer serves the status, metrics and pprof if profiling is enabled,
+// it blocks until the context is canceled.
+func RunStatusServer(
+	ctx context.Context, addr string, w *Writer,
+	registry *prometheus.Registry, enableProfiling bool,
+) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/status", func(resp http.ResponseWriter, _ *http.Request) {
+		resp.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(resp).Encode(w.Status()); err != nil {
+			log.Warn("write the consumer status failed", zap.Error(err))
+		}
+	})
+	if enableProfiling {
+		// pprof handlers are registered to the default serve mux.
+		mux.Handle("/debug/", http.DefaultServeMux)
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			log.Warn("close the status server failed", zap.Error(err))
+		}
+	}()
+	log.Info("status server started", zap.String("addr", addr),
+		zap.Bool("enableProfiling", enableProfiling))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Panic("cannot start the status server", zap.String("addr", addr), zap.Error(err))
+	}
+}
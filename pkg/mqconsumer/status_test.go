@@ -0,0 +1,77 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterStatus(t *testing.T) {
+	t.Parallel()
+
+	w := newWriterForTest("t1", "t2")
+	w.option.ConsumerID = "consumer"
+	w.option.Protocol = config.ProtocolCanalJSON
+	status := w.Status()
+	require.Equal(t, "consumer", status.ConsumerID)
+	require.Equal(t, "canal-json", status.Protocol)
+	require.Equal(t, []string{"t1", "t2"}, status.Topics)
+	require.Equal(t, uint64(0), status.MinWatermark)
+	require.Empty(t, status.Partitions)
+
+	p1 := newPartitionProgress("t2", 0, 1, nil)
+	p1.watermark = 300
+	p1.lastOffset = 30
+	p2 := newPartitionProgress("t1", 1, 2, nil)
+	p2.watermark = 200
+	p2.flushedTs = 150
+	p2.lastOffset = 20
+	p2.unflushed = []eventOffset{{commitTs: 180, offset: 15}}
+	group := newEventsGroup(1, 1)
+	group.Append(newRowForTest(1, 250), 18)
+	p2.eventGroups[1] = group
+	p3 := newPartitionProgress("t1", 0, 2, nil)
+	p3.watermark = 400
+	p3.lastOffset = 40
+	w.progresses[TopicPartition{Topic: "t2", Partition: 0}] = p1
+	w.progresses[TopicPartition{Topic: "t1", Partition: 1}] = p2
+	w.progresses[TopicPartition{Topic: "t1", Partition: 0}] = p3
+	w.appendDDL(&model.DDLEvent{CommitTs: 500, Query: "CREATE TABLE a (id int)"}, "t1", 35)
+	w.ddlCheckpoint.advance(&model.DDLEvent{CommitTs: 100, Query: "CREATE TABLE b (id int)"})
+
+	status = w.Status()
+	require.Equal(t, uint64(200), status.MinWatermark)
+	require.Equal(t, 1, status.PendingDDLCount)
+	require.Equal(t, uint64(100), status.DDLCheckpointTs)
+	// the partitions are sorted by topic and partition.
+	require.Equal(t, []PartitionStatus{
+		{
+			Topic: "t1", Partition: 0, Watermark: 400, LastOffset: 40,
+			// the DDL not executed yet is received again after restart.
+			ResumeOffset: 35,
+		},
+		{
+			Topic: "t1", Partition: 1, Watermark: 200, FlushedTs: 150, LastOffset: 20,
+			ResumeOffset: 15, PendingEvents: 2,
+		},
+		{
+			Topic: "t2", Partition: 0, Watermark: 300, LastOffset: 30,
+			ResumeOffset: 31,
+		},
+	}, status.Partitions)
+}
@@ -0,0 +1,751 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink"
+	ddlsinkfactory "github.com/pingcap/tiflow/cdc/sink/ddlsink/factory"
+	eventsinkfactory "github.com/pingcap/tiflow/cdc/sink/dmlsink/factory"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dispatcher"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/quotes"
+	"github.com/pingcap/tiflow/pkg/sink"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/simple"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// pendingDDL is a DDL received but not executed yet.
+type pendingDDL struct {
+	*model.DDLEvent
+	topic  string
+	offset int64
+}
+
+// Writer decodes the messages received from the MQ, and writes the events
+// to the downstream in the order of commitTs. The progress of each partition
+// is persisted, so that the consumer can resume from where it stopped.
+type Writer struct {
+	option *Option
+	// mu protects the fields below, since the status is read by the http server.
+	mu sync.Mutex
+
+	ddlList []*pendingDDL
+	// ddlWithMaxCommitTs is the DDL with max commitTs received from each topic.
+	ddlWithMaxCommitTs map[string]*model.DDLEvent
+	ddlCheckpoint      *ddlCheckpoint
+	ddlSink            ddlsink.Sink
+
+	// sinkFactory is used to create table sink for each table.
+	sinkFactory *eventsinkfactory.SinkFactory
+	progresses  map[TopicPartition]*partitionProgress
+	// decoders is the decoder of each topic.
+	decoders     map[string]codec.RowEventDecoder
+	upstreamTiDB *sql.DB
+
+	checkpointStore    checkpointStore
+	lastCheckpointTime time.Time
+
+	eventRouter *dispatcher.EventRouter
+}
+
+// NewWriter creates a writer which writes events to the downstream.
+func NewWriter(ctx context.Context, o *Option) (*Writer, error) {
+	w := &Writer{
+		option:             o,
+		progresses:         make(map[TopicPartition]*partitionProgress),
+		decoders:           make(map[string]codec.RowEventDecoder),
+		ddlWithMaxCommitTs: make(map[string]*model.DDLEvent),
+	}
+	var err error
+	if o.UpstreamTiDBDSN != "" {
+		w.upstreamTiDB, err = OpenDB(ctx, o.UpstreamTiDBDSN)
+		if err != nil {
+			return nil, cerror.Trace(err)
+		}
+	}
+
+	if !o.SkipPartitionCheck {
+		// the default topic is only used to calculate the topic of events,
+		// which is not checked by the consumer.
+		w.eventRouter, err = dispatcher.NewEventRouter(o.ReplicaConfig, o.Protocol, o.Topics[0], sink.KafkaScheme)
+		if err != nil {
+			return nil, cerror.Trace(err)
+		}
+		log.Info("event router created", zap.Any("protocol", o.Protocol),
+			zap.Strings("topics", o.Topics), zap.Any("dispatcherRules", o.ReplicaConfig.Sink.DispatchRules))
+	}
+
+	errChan := make(chan error, 1)
+	changefeed := model.DefaultChangeFeedID(o.Name)
+	f, err := eventsinkfactory.New(ctx, changefeed, o.DownstreamURI, o.ReplicaConfig, errChan, nil)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	w.sinkFactory = f
+
+	go func() {
+		err := <-errChan
+		if !errors.Is(cerror.Cause(err), context.Canceled) {
+			log.Error("error on running consumer", zap.Error(err))
+		} else {
+			log.Info("consumer exited")
+		}
+	}()
+
+	w.ddlSink, err = ddlsinkfactory.New(ctx, changefeed, o.DownstreamURI, o.ReplicaConfig)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+
+	w.checkpointStore, err = newCheckpointStore(ctx, o)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	w.ddlCheckpoint, err = w.checkpointStore.LoadDDL(ctx)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	log.Info("ddl checkpoint loaded", zap.Uint64("commitTs", w.ddlCheckpoint.commitTs))
+	return w, nil
+}
+
+func (w *Writer) getDecoder(ctx context.Context, topic string) (codec.RowEventDecoder, error) {
+	if decoder, ok := w.decoders[topic]; ok {
+		return decoder, nil
+	}
+	decoder, err := NewDecoder(ctx, w.option, topic, w.upstreamTiDB)
+	if err != nil {
+		return nil, cerror.Annotatef(err, "cannot create the decoder of topic %s", topic)
+	}
+	w.decoders[topic] = decoder
+	return decoder, nil
+}
+
+// AddPartitions creates the progress of the assigned partitions by restoring
+// from the checkpoint store, returns the offsets to start consuming from,
+// the offset is InvalidOffset if no checkpoint found.
+func (w *Writer) AddPartitions(
+	ctx context.Context, partitions []Assignment,
+) ([]PartitionOffset, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, tp := range partitions {
+		if _, ok := w.progresses[tp.TopicPartition]; ok {
+			return nil, cerror.Errorf("partition %d of topic %s is already assigned",
+				tp.Partition, tp.Topic)
+		}
+	}
+
+	assigned := make(map[string]int32)
+	partitionNums := make(map[string]int32)
+	result := make([]PartitionOffset, 0, len(partitions))
+	for _, tp := range partitions {
+		decoder, err := w.getDecoder(ctx, tp.Topic)
+		if err != nil {
+			return nil, err
+		}
+		progress := newPartitionProgress(tp.Topic, tp.Partition, tp.PartitionNum, decoder)
+		checkpoint, err := w.checkpointStore.Load(ctx, tp.Topic, tp.Partition)
+		if err != nil {
+			return nil, cerror.Trace(err)
+		}
+		offset := InvalidOffset
+		if checkpoint != nil {
+			progress.restore(checkpoint, w.option.LocalOffset)
+			if !w.option.LocalOffset {
+				offset = checkpoint.offset
+			}
+		}
+		w.progresses[tp.TopicPartition] = progress
+		assigned[tp.Topic]++
+		partitionNums[tp.Topic] = tp.PartitionNum
+		result = append(result, PartitionOffset{TopicPartition: tp.TopicPartition, Offset: offset})
+		log.Info("partition assigned", zap.String("topic", tp.Topic),
+			zap.Int32("partition", tp.Partition), zap.Int64("offset", offset))
+	}
+	for topic, count := range assigned {
+		if count < partitionNums[topic] {
+			// DDLs are only executed after all partitions of the topic pass the
+			// commitTs, the consumer cannot know the progress of other consumers.
+			log.Warn("not all partitions of the topic are assigned to the consumer, "+
+				"DDL events may be executed before the DML events of other partitions",
+				zap.String("topic", topic), zap.Int32("assigned", count),
+				zap.Int32("partitionNum", partitionNums[topic]))
+		}
+	}
+	rebalanceCounter.WithLabelValues("assign").Add(float64(len(partitions)))
+	return result, nil
+}
+
+// RemovePartitions persists the checkpoint of the revoked partitions and drops
+// their progress, returns the offsets to commit. The events not flushed yet will
+// be consumed again by the new owner of the partitions.
+func (w *Writer) RemovePartitions(
+	ctx context.Context, partitions []TopicPartition, persist bool,
+) ([]PartitionOffset, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var (
+		checkpoints = make([]*partitionCheckpoint, 0, len(partitions))
+		result      = make([]PartitionOffset, 0, len(partitions))
+		revoked     = make(map[TopicPartition]struct{}, len(partitions))
+	)
+	for _, tp := range partitions {
+		progress, ok := w.progresses[tp]
+		if !ok {
+			continue
+		}
+		revoked[tp] = struct{}{}
+		if progress.lastOffset == InvalidOffset {
+			continue
+		}
+		offset := w.resumeOffset(progress)
+		checkpoints = append(checkpoints, progress.checkpoint(offset))
+		result = append(result, PartitionOffset{TopicPartition: tp, Offset: offset})
+	}
+	if persist {
+		if err := w.checkpointStore.Save(ctx, checkpoints); err != nil {
+			return nil, cerror.Trace(err)
+		}
+	}
+
+	// DDLs received from the revoked partition will be received again.
+	ddlList := w.ddlList[:0]
+	for _, ddl := range w.ddlList {
+		if _, ok := revoked[TopicPartition{Topic: ddl.topic, Partition: 0}]; !ok {
+			ddlList = append(ddlList, ddl)
+		}
+	}
+	w.ddlList = ddlList
+	for tp := range revoked {
+		if tp.Partition == 0 {
+			delete(w.ddlWithMaxCommitTs, tp.Topic)
+		}
+		w.progresses[tp].close()
+		delete(w.progresses, tp)
+		log.Info("partition revoked", zap.String("topic", tp.Topic),
+			zap.Int32("partition", tp.Partition), zap.Bool("persisted", persist))
+	}
+	rebalanceCounter.WithLabelValues("revoke").Add(float64(len(revoked)))
+	if !persist {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// resumeOffset returns the first offset to consume of the partition after restart,
+// the DDLs not executed yet should be received again.
+func (w *Writer) resumeOffset(progress *partitionProgress) int64 {
+	result := progress.resumeOffset()
+	if progress.partition != 0 {
+		return result
+	}
+	for _, ddl := range w.ddlList {
+		if ddl.topic == progress.topic && ddl.offset < result {
+			result = ddl.offset
+		}
+	}
+	return result
+}
+
+// Checkpoint persists the progress of all assigned partitions if the checkpoint
+// interval is reached or force is true, returns the offsets to commit, all events
+// before the offsets have been written to the downstream.
+func (w *Writer) Checkpoint(ctx context.Context, force bool) ([]PartitionOffset, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !force && time.Since(w.lastCheckpointTime) < w.option.CheckpointInterval {
+		return nil, false
+	}
+	var (
+		checkpoints = make([]*partitionCheckpoint, 0, len(w.progresses))
+		offsets     = make([]PartitionOffset, 0, len(w.progresses))
+	)
+	for tp, progress := range w.progresses {
+		if progress.lastOffset == InvalidOffset {
+			continue
+		}
+		offset := w.resumeOffset(progress)
+		checkpoints = append(checkpoints, progress.checkpoint(offset))
+		offsets = append(offsets, PartitionOffset{TopicPartition: tp, Offset: offset})
+	}
+	start := time.Now()
+	if err := w.checkpointStore.Save(ctx, checkpoints); err != nil {
+		log.Error("save the consumer checkpoint failed, retry later", zap.Error(err))
+		return nil, false
+	}
+	checkpointSaveDuration.Observe(time.Since(start).Seconds())
+	w.lastCheckpointTime = time.Now()
+	if len(w.progresses) != 0 {
+		checkpointTs := uint64(math.MaxUint64)
+		for _, progress := range w.progresses {
+			if progress.flushedTs < checkpointTs {
+				checkpointTs = progress.flushedTs
+			}
+		}
+		checkpointTsGauge.Set(float64(oracle.ExtractPhysical(checkpointTs)))
+	}
+	return offsets, len(offsets) != 0
+}
+
+// Close closes all table sinks and the checkpoint store.
+func (w *Writer) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, progress := range w.progresses {
+		progress.close()
+	}
+	if err := w.checkpointStore.Close(); err != nil {
+		log.Warn("close the checkpoint store failed", zap.Error(err))
+	}
+}
+
+// append DDL wait to be handled, only consider the constraint among DDLs.
+// for DDL a / b received in the order from the same topic, a.CommitTs < b.CommitTs should be true.
+func (w *Writer) appendDDL(ddl *model.DDLEvent, topic string, offset int64) {
+	// the DDL is received again after restart or rebalance.
+	if w.ddlCheckpoint.executed(ddl) {
+		log.Info("ignore the DDL already executed",
+			zap.String("topic", topic), zap.Any("offset", offset),
+			zap.Uint64("commitTs", ddl.CommitTs), zap.String("DDL", ddl.Query))
+		return
+	}
+
+	// DDL CommitTs fallback, just crash it to indicate the bug.
+	ddlWithMaxCommitTs := w.ddlWithMaxCommitTs[topic]
+	if ddlWithMaxCommitTs != nil && ddl.CommitTs < ddlWithMaxCommitTs.CommitTs {
+		log.Warn("DDL CommitTs < maxCommitTsDDL.CommitTs",
+			zap.String("topic", topic),
+			zap.Uint64("commitTs", ddl.CommitTs),
+			zap.Uint64("maxCommitTs", ddlWithMaxCommitTs.CommitTs),
+			zap.String("DDL", ddl.Query))
+		return
+	}
+
+	// A rename tables DDL job contains multiple DDL events with same CommitTs.
+	// So to tell if a DDL is redundant or not, we must check the equivalence of
+	// the current DDL and the DDL with max CommitTs.
+	if ddl == ddlWithMaxCommitTs {
+		log.Warn("ignore redundant DDL, the DDL is equal to ddlWithMaxCommitTs",
+			zap.Uint64("commitTs", ddl.CommitTs), zap.String("DDL", ddl.Query))
+		return
+	}
+	w.ddlWithMaxCommitTs[topic] = ddl
+
+	// The same DDL may be sent to multiple topics, keep the DDLs ordered by commitTs.
+	for _, pending := range w.ddlList {
+		if pending.CommitTs == ddl.CommitTs && pending.Query == ddl.Query {
+			log.Info("ignore redundant DDL, the DDL is received from another topic",
+				zap.String("topic", topic), zap.String("receivedFrom", pending.topic),
+				zap.Uint64("commitTs", ddl.CommitTs), zap.String("DDL", ddl.Query))
+			return
+		}
+	}
+	i := sort.Search(len(w.ddlList), func(i int) bool {
+		return w.ddlList[i].CommitTs > ddl.CommitTs
+	})
+	w.ddlList = append(w.ddlList, nil)
+	copy(w.ddlList[i+1:], w.ddlList[i:])
+	w.ddlList[i] = &pendingDDL{DDLEvent: ddl, topic: topic, offset: offset}
+	log.Info("DDL message received", zap.String("topic", topic), zap.Any("offset", offset),
+		zap.Uint64("commitTs", ddl.CommitTs), zap.String("DDL", ddl.Query))
+}
+
+func (w *Writer) getFrontDDL() *model.DDLEvent {
+	if len(w.ddlList) > 0 {
+		return w.ddlList[0].DDLEvent
+	}
+	return nil
+}
+
+func (w *Writer) popDDL() {
+	if len(w.ddlList) > 0 {
+		w.ddlList = w.ddlList[1:]
+	}
+}
+
+func (w *Writer) getMinWatermark() uint64 {
+	// no partition assigned, nothing can be flushed.
+	if len(w.progresses) == 0 {
+		return 0
+	}
+	result := uint64(math.MaxUint64)
+	for _, p := range w.progresses {
+		watermark := p.loadWatermark()
+		if watermark < result {
+			result = watermark
+		}
+	}
+	return result
+}
+
+// partition progress could be executed at the same time
+func (w *Writer) forEachPartition(fn func(p *partitionProgress)) {
+	var wg sync.WaitGroup
+	for _, p := range w.progresses {
+		wg.Add(1)
+		go func(p *partitionProgress) {
+			defer wg.Done()
+			fn(p)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// Write will synchronously write data downstream
+func (w *Writer) Write(ctx context.Context, messageType model.MessageType) bool {
+	watermark := w.getMinWatermark()
+	var todoDDL *model.DDLEvent
+	for {
+		todoDDL = w.getFrontDDL()
+		// watermark is the min value for all partitions,
+		// the DDL only executed by the first partition, other partitions may be slow
+		// so that the watermark can be smaller than the DDL's commitTs,
+		// which means some DML events may not be consumed yet, so cannot execute the DDL right now.
+		if todoDDL == nil || todoDDL.CommitTs > watermark {
+			break
+		}
+		// flush DMLs
+		w.forEachPartition(func(sink *partitionProgress) {
+			syncFlushRowChangedEvents(ctx, sink, todoDDL.CommitTs)
+		})
+		// DDL can be executed, do it first.
+		if err := w.ddlSink.WriteDDLEvent(ctx, todoDDL); err != nil {
+			log.Panic("write DDL event failed", zap.Error(err),
+				zap.String("DDL", todoDDL.Query), zap.Uint64("commitTs", todoDDL.CommitTs))
+		}
+		// the DDL must not be executed again after restart.
+		w.ddlCheckpoint.advance(todoDDL)
+		if err := w.checkpointStore.SaveDDL(ctx, todoDDL); err != nil {
+			log.Panic("save the DDL checkpoint failed", zap.Error(err),
+				zap.String("DDL", todoDDL.Query), zap.Uint64("commitTs", todoDDL.CommitTs))
+		}
+		w.popDDL()
+	}
+
+	if messageType == model.MessageTypeResolved {
+		w.forEachPartition(func(sink *partitionProgress) {
+			syncFlushRowChangedEvents(ctx, sink, watermark)
+		})
+	}
+
+	// The DDL events will only execute in partition0
+	if messageType == model.MessageTypeDDL && todoDDL != nil {
+		log.Info("DDL event will be flushed in the future",
+			zap.Uint64("watermark", watermark),
+			zap.Uint64("CommitTs", todoDDL.CommitTs),
+			zap.String("Query", todoDDL.Query))
+		return false
+	}
+	return true
+}
+
+// WriteMessage decodes the message and writes the events to the downstream,
+// returns true if the events are flushed and the progress should be committed.
+func (w *Writer) WriteMessage(ctx context.Context, message *Message) bool {
+	var (
+		key       = message.Key
+		value     = message.Value
+		topic     = message.Topic
+		partition = message.Partition
+		offset    = message.Offset
+	)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	progress, ok := w.progresses[message.TopicPartition]
+	if !ok {
+		// the message is read before the partition revoked, it will be consumed by the new owner.
+		log.Warn("message received from the partition not assigned, ignore it",
+			zap.String("topic", topic), zap.Int32("partition", partition), zap.Int64("offset", offset))
+		return false
+	}
+	receivedMessageCounter.WithLabelValues(topic, strconv.Itoa(int(partition))).Inc()
+	if err := progress.decoder.AddKeyValue(key, value); err != nil {
+		log.Panic("add key value to the decoder failed", zap.String("topic", topic),
+			zap.Int32("partition", partition), zap.Any("offset", offset), zap.Error(err))
+	}
+	var (
+		counter     int
+		needFlush   bool
+		messageType model.MessageType
+	)
+	for {
+		ty, hasNext, err := progress.decoder.HasNext()
+		if err != nil {
+			log.Panic("decode message key failed", zap.String("topic", topic),
+				zap.Int32("partition", partition), zap.Any("offset", offset), zap.Error(err))
+		}
+		if !hasNext {
+			break
+		}
+		counter++
+		// If the message containing only one event exceeds the length limit, CDC will allow it and issue a warning.
+		if len(key)+len(value) > w.option.MaxMessageBytes && counter > 1 {
+			log.Panic("max-messages-bytes exceeded",
+				zap.Int32("partition", partition), zap.Any("offset", offset),
+				zap.Int("max-message-bytes", w.option.MaxMessageBytes),
+				zap.Int("receivedBytes", len(key)+len(value)))
+		}
+		messageType = ty
+		receivedEventCounter.WithLabelValues(topic, messageTypeLabel(messageType)).Inc()
+		switch messageType {
+		case model.MessageTypeDDL:
+			// for some protocol, DDL would be dispatched to all partitions,
+			// Consider that DDL a, b, c received from partition-0, the latest DDL is c,
+			// if we receive `a` from partition-1, which would be seemed as DDL regression,
+			// then cause the consumer panic, but it was a duplicate one.
+			// so we only handle DDL received from partition-0 should be enough.
+			// but all DDL event messages should be consumed.
+			ddl, err := progress.decoder.NextDDLEvent()
+			if err != nil {
+				log.Panic("decode message value failed", zap.String("topic", topic),
+					zap.Int32("partition", partition), zap.Any("offset", offset),
+					zap.ByteString("value", value), zap.Error(err))
+			}
+
+			if dec, ok := progress.decoder.(*simple.Decoder); ok {
+				cachedEvents := dec.GetCachedEvents()
+				for _, row := range cachedEvents {
+					w.checkPartition(row, progress, offset)
+					log.Info("simple protocol cached event resolved, append to the group",
+						zap.Int64("tableID", row.GetTableID()), zap.Uint64("commitTs", row.CommitTs),
+						zap.Int32("partition", partition), zap.Any("offset", offset))
+					w.appendRow2Group(row, progress, offset)
+				}
+			}
+
+			// the Query maybe empty if using simple protocol, it's comes from `bootstrap` event, no need to handle it.
+			if ddl.Query == "" {
+				continue
+			}
+
+			if partition == 0 {
+				w.appendDDL(ddl, topic, offset)
+			}
+			needFlush = true
+		case model.MessageTypeRow:
+			row, err := progress.decoder.NextRowChangedEvent()
+			if err != nil {
+				log.Panic("decode message value failed", zap.String("topic", topic),
+					zap.Int32("partition", partition), zap.Any("offset", offset),
+					zap.ByteString("value", value),
+					zap.Error(err))
+			}
+			// when using simple protocol, the row may be nil, since it's table info not received yet,
+			// it's cached in the decoder, so just continue here.
+			if w.option.Protocol == config.ProtocolSimple && row == nil {
+				continue
+			}
+			w.checkPartition(row, progress, offset)
+			w.appendRow2Group(row, progress, offset)
+		case model.MessageTypeResolved:
+			newWatermark, err := progress.decoder.NextResolvedEvent()
+			if err != nil {
+				log.Panic("decode message value failed", zap.String("topic", topic),
+					zap.Int32("partition", partition), zap.Any("offset", offset),
+					zap.ByteString("value", value), zap.Error(err))
+			}
+
+			progress.updateWatermark(newWatermark, offset)
+			w.resolveRowChangedEvents(progress, newWatermark)
+			needFlush = true
+		default:
+			log.Panic("unknown message type", zap.Any("messageType", messageType),
+				zap.Int32("partition", partition), zap.Any("offset", offset))
+		}
+	}
+
+	progress.lastOffset = offset
+
+	if counter > w.option.MaxBatchSize {
+		log.Panic("Open Protocol max-batch-size exceeded",
+			zap.Int("max-batch-size", w.option.MaxBatchSize), zap.Int("actual-batch-size", counter),
+			zap.Int32("partition", partition), zap.Any("offset", offset))
+	}
+
+	if !needFlush {
+		return false
+	}
+	// flush when received DDL event or resolvedTs
+	return w.Write(ctx, messageType)
+}
+
+func (w *Writer) resolveRowChangedEvents(progress *partitionProgress, newWatermark uint64) {
+	for tableID, group := range progress.eventGroups {
+		events, offsets := group.Resolve(newWatermark)
+		if len(events) == 0 {
+			continue
+		}
+		for i, event := range events {
+			progress.unflushed = append(progress.unflushed, eventOffset{
+				commitTs: event.CommitTs,
+				offset:   offsets[i],
+			})
+		}
+		tableSink, ok := progress.tableSinkMap[tableID]
+		if !ok {
+			tableSink = w.sinkFactory.CreateTableSinkForConsumer(
+				model.DefaultChangeFeedID(w.option.Name),
+				spanz.TableIDToComparableSpan(tableID),
+				events[0].CommitTs,
+			)
+			progress.tableSinkMap[tableID] = tableSink
+			progress.tableNames[tableID] = events[0].TableInfo.TableName
+		}
+		tableSink.AppendRowChangedEvents(events...)
+	}
+}
+
+func (w *Writer) checkPartition(row *model.RowChangedEvent, progress *partitionProgress, offset int64) {
+	if w.option.SkipPartitionCheck {
+		return
+	}
+	partition := progress.partition
+	target, _, err := w.eventRouter.GetPartitionForRowChange(row, progress.partitionNum)
+	if err != nil {
+		log.Panic("cannot calculate partition for the row changed event",
+			zap.String("topic", progress.topic), zap.Int32("partition", partition), zap.Any("offset", offset),
+			zap.Int32("partitionNum", progress.partitionNum), zap.Int64("tableID", row.GetTableID()),
+			zap.Error(err), zap.Any("event", row))
+	}
+	if partition != target {
+		log.Panic("RowChangedEvent dispatched to wrong partition",
+			zap.String("topic", progress.topic), zap.Int32("partition", partition), zap.Int32("expected", target),
+			zap.Int32("partitionNum", progress.partitionNum), zap.Any("offset", offset),
+			zap.Int64("tableID", row.GetTableID()), zap.Any("row", row),
+		)
+	}
+}
+
+func (w *Writer) appendRow2Group(row *model.RowChangedEvent, progress *partitionProgress, offset int64) {
+	// if the MQ cluster is normal, this should not hit.
+	// else if the cluster is abnormal, the consumer may consume old message, then cause the watermark fallback.
+	watermark := progress.loadWatermark()
+	partition := progress.partition
+
+	// the row is written to the downstream before the consumer restarts.
+	name := quotes.QuoteSchema(row.TableInfo.GetSchemaName(), row.TableInfo.GetTableName())
+	if checkpointTs, ok := progress.tableCheckpoints[name]; ok && row.CommitTs <= checkpointTs {
+		log.Debug("RowChangedEvent already written to the downstream, ignore it",
+			zap.String("topic", progress.topic), zap.Int32("partition", partition),
+			zap.String("table", name), zap.Uint64("commitTs", row.CommitTs),
+			zap.Uint64("checkpointTs", checkpointTs), zap.Any("offset", offset))
+		return
+	}
+
+	tableID := row.GetTableID()
+	group := progress.eventGroups[tableID]
+	if group == nil {
+		group = newEventsGroup(partition, tableID)
+		progress.eventGroups[tableID] = group
+	}
+	if row.CommitTs < watermark {
+		log.Warn("RowChanged Event fallback row, since les than the partition watermark, ignore it",
+			zap.Int64("tableID", tableID), zap.Int32("partition", partition),
+			zap.Uint64("commitTs", row.CommitTs), zap.Any("offset", offset),
+			zap.Uint64("watermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
+			zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
+			zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
+			zap.String("protocol", w.option.Protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
+		return
+	}
+	if row.CommitTs >= group.highWatermark {
+		group.Append(row, offset)
+		return
+	}
+	switch w.option.Protocol {
+	case config.ProtocolSimple, config.ProtocolOpen, config.ProtocolCanalJSON:
+		// simple protocol set the table id for all row message, it can be known which table the row message belongs to,
+		// also consider the table partition.
+		// open protocol set the partition table id if the table is partitioned.
+		// for normal table, the table id is generated by the fake table id generator by using schema and table name.
+		// so one event group for one normal table or one table partition, replayed messages can be ignored.
+		log.Warn("RowChangedEvent fallback row, since less than the group high watermark, ignore it",
+			zap.Int64("tableID", tableID), zap.Int32("partition", partition),
+			zap.Uint64("commitTs", row.CommitTs), zap.Any("offset", offset),
+			zap.Uint64("highWatermark", group.highWatermark),
+			zap.Any("partitionWatermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
+			zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
+			zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
+			zap.String("protocol", w.option.Protocol.String()), zap.Bool("IsPartition", row.TableInfo.TableName.IsPartition))
+		return
+	default:
+	}
+	log.Warn("RowChangedEvent fallback row, since less than the group high watermark, do not ignore it",
+		zap.Int64("tableID", tableID), zap.Int32("partition", partition),
+		zap.Uint64("commitTs", row.CommitTs), zap.Any("offset", offset),
+		zap.Uint64("highWatermark", group.highWatermark),
+		zap.Any("partitionWatermark", watermark), zap.Any("watermarkOffset", progress.watermarkOffset),
+		zap.String("schema", row.TableInfo.GetSchemaName()), zap.String("table", row.TableInfo.GetTableName()),
+		zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
+		zap.String("protocol", w.option.Protocol.String()))
+	group.Append(row, offset)
+}
+
+func syncFlushRowChangedEvents(ctx context.Context, progress *partitionProgress, watermark uint64) {
+	resolvedTs := model.NewResolvedTs(watermark)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Warn("sync flush row changed event canceled", zap.Error(ctx.Err()))
+			return
+		default:
+		}
+		flushedResolvedTs := true
+		for _, tableSink := range progress.tableSinkMap {
+			if err := tableSink.UpdateResolvedTs(resolvedTs); err != nil {
+				log.Panic("Failed to update resolved ts", zap.Error(err))
+			}
+			if tableSink.GetCheckpointTs().Less(resolvedTs) {
+				flushedResolvedTs = false
+			}
+		}
+		if flushedResolvedTs {
+			progress.markFlushed(watermark)
+			return
+		}
+	}
+}
+
+func messageTypeLabel(messageType model.MessageType) string {
+	switch messageType {
+	case model.MessageTypeDDL:
+		return "ddl"
+	case model.MessageTypeRow:
+		return "row"
+	case model.MessageTypeResolved:
+		return "resolved"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/stretchr/testify/require"
+)
+
+func newWriterForTest(topics ...string) *Writer {
+	option := NewOption("test")
+	option.Topics = topics
+	w := &Writer{
+		option:             option,
+		progresses:         make(map[TopicPartition]*partitionProgress),
+		decoders:           make(map[string]codec.RowEventDecoder),
+		ddlWithMaxCommitTs: make(map[string]*model.DDLEvent),
+		ddlCheckpoint:      newDDLCheckpoint(),
+		checkpointStore:    &memoryCheckpointStore{},
+	}
+	for _, topic := range topics {
+		w.decoders[topic] = nil
+	}
+	return w
+}
+
+func TestWriterAppendDDL(t *testing.T) {
+	t.Parallel()
+
+	w := newWriterForTest("t1", "t2")
+	ddl1 := &model.DDLEvent{CommitTs: 10, Query: "CREATE TABLE a (id int)"}
+	ddl2 := &model.DDLEvent{CommitTs: 20, Query: "CREATE TABLE b (id int)"}
+	ddl3 := &model.DDLEvent{CommitTs: 15, Query: "CREATE TABLE c (id int)"}
+
+	w.appendDDL(ddl1, "t1", 1)
+	w.appendDDL(ddl2, "t1", 2)
+	// DDLs received from different topics are ordered by commitTs.
+	w.appendDDL(ddl3, "t2", 5)
+	// the same DDL is received from another topic.
+	w.appendDDL(&model.DDLEvent{CommitTs: 20, Query: "CREATE TABLE b (id int)"}, "t2", 6)
+	require.Len(t, w.ddlList, 3)
+	require.Equal(t, uint64(10), w.ddlList[0].CommitTs)
+	require.Equal(t, uint64(15), w.ddlList[1].CommitTs)
+	require.Equal(t, "t2", w.ddlList[1].topic)
+	require.Equal(t, uint64(20), w.ddlList[2].CommitTs)
+	require.Equal(t, "t1", w.ddlList[2].topic)
+
+	// the DDL falls back in the same topic is ignored.
+	w.appendDDL(&model.DDLEvent{CommitTs: 5, Query: "CREATE TABLE d (id int)"}, "t1", 7)
+	require.Len(t, w.ddlList, 3)
+
+	// the DDL executed before restart is ignored.
+	w = newWriterForTest("t1")
+	w.ddlCheckpoint.advance(ddl2)
+	w.appendDDL(ddl1, "t1", 1)
+	w.appendDDL(ddl2, "t1", 2)
+	require.Empty(t, w.ddlList)
+}
+
+func TestWriterPartitionsAndCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	w := newWriterForTest("t1")
+	offsets, err := w.AddPartitions(ctx, []Assignment{
+		{TopicPartition: TopicPartition{Topic: "t1", Partition: 0}, PartitionNum: 2},
+		{TopicPartition: TopicPartition{Topic: "t1", Partition: 1}, PartitionNum: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, offsets, 2)
+	for _, offset := range offsets {
+		require.Equal(t, InvalidOffset, offset.Offset)
+	}
+	// the partition assigned twice is rejected without changing the assignment.
+	_, err = w.AddPartitions(ctx, []Assignment{
+		{TopicPartition: TopicPartition{Topic: "t1", Partition: 2}, PartitionNum: 3},
+		{TopicPartition: TopicPartition{Topic: "t1", Partition: 0}, PartitionNum: 2},
+	})
+	require.Error(t, err)
+	require.Len(t, w.progresses, 2)
+	// no message received, nothing to checkpoint.
+	offsets, ok := w.Checkpoint(ctx, true)
+	require.False(t, ok)
+	require.Empty(t, offsets)
+
+	p0 := w.progresses[TopicPartition{Topic: "t1", Partition: 0}]
+	p1 := w.progresses[TopicPartition{Topic: "t1", Partition: 1}]
+	p0.lastOffset = 10
+	p1.lastOffset = 20
+	// the DDL not executed yet should be received again from partition 0.
+	w.appendDDL(&model.DDLEvent{CommitTs: 10, Query: "CREATE TABLE a (id int)"}, "t1", 7)
+	group := newEventsGroup(1, 1)
+	group.Append(newRowForTest(1, 100), 15)
+	p1.eventGroups[1] = group
+
+	offsets, ok = w.Checkpoint(ctx, true)
+	require.True(t, ok)
+	result := make(map[TopicPartition]int64)
+	for _, offset := range offsets {
+		result[offset.TopicPartition] = offset.Offset
+	}
+	require.Equal(t, map[TopicPartition]int64{
+		{Topic: "t1", Partition: 0}: 7,
+		{Topic: "t1", Partition: 1}: 15,
+	}, result)
+
+	// the checkpoint is throttled by the interval.
+	_, ok = w.Checkpoint(ctx, false)
+	require.False(t, ok)
+
+	offsets, err = w.RemovePartitions(ctx, []TopicPartition{{Topic: "t1", Partition: 0}}, true)
+	require.NoError(t, err)
+	require.Equal(t, []PartitionOffset{
+		{TopicPartition: TopicPartition{Topic: "t1", Partition: 0}, Offset: 7},
+	}, offsets)
+	// the DDLs received from the revoked partition are dropped.
+	require.Empty(t, w.ddlList)
+	require.Len(t, w.progresses, 1)
+
+	// the progress is not returned if the assignment is lost.
+	offsets, err = w.RemovePartitions(ctx, []TopicPartition{{Topic: "t1", Partition: 1}}, false)
+	require.NoError(t, err)
+	require.Empty(t, offsets)
+	require.Empty(t, w.progresses)
+	require.Equal(t, uint64(0), w.getMinWatermark())
+}
+
+type mockCheckpointStore struct {
+	memoryCheckpointStore
+	checkpoints map[TopicPartition]*partitionCheckpoint
+	saved       []*partitionCheckpoint
+}
+
+func (s *mockCheckpointStore) Load(
+	_ context.Context, topic string, partition int32,
+) (*partitionCheckpoint, error) {
+	return s.checkpoints[TopicPartition{Topic: topic, Partition: partition}], nil
+}
+
+func (s *mockCheckpointStore) Save(_ context.Context, checkpoints []*partitionCheckpoint) error {
+	s.saved = append(s.saved, checkpoints...)
+	return nil
+}
+
+func TestWriterResumeFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	newStore := func() *mockCheckpointStore {
+		return &mockCheckpointStore{
+			checkpoints: map[TopicPartition]*partitionCheckpoint{
+				{Topic: "t1", Partition: 0}: {
+					topic:           "t1",
+					partition:       0,
+					offset:          100,
+					watermark:       1000,
+					watermarkOffset: 90,
+					tables: map[string]*tableCheckpoint{
+						"`test`.`t`": {schema: "test", table: "t", resolvedTs: 1200},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name        string
+		localOffset bool
+		expected    map[TopicPartition]int64
+	}{
+		{
+			name: "resume from the persisted offset",
+			expected: map[TopicPartition]int64{
+				{Topic: "t1", Partition: 0}: 100,
+				{Topic: "t1", Partition: 1}: InvalidOffset,
+			},
+		},
+		{
+			name:        "resume from the offsets committed to the MQ",
+			localOffset: true,
+			expected: map[TopicPartition]int64{
+				{Topic: "t1", Partition: 0}: InvalidOffset,
+				{Topic: "t1", Partition: 1}: InvalidOffset,
+			},
+		},
+	}
+	for _, tc := range cases {
+		ctx := context.Background()
+		w := newWriterForTest("t1")
+		w.option.LocalOffset = tc.localOffset
+		store := newStore()
+		w.checkpointStore = store
+
+		offsets, err := w.AddPartitions(ctx, []Assignment{
+			{TopicPartition: TopicPartition{Topic: "t1", Partition: 0}, PartitionNum: 2},
+			{TopicPartition: TopicPartition{Topic: "t1", Partition: 1}, PartitionNum: 2},
+		})
+		require.NoError(t, err, tc.name)
+		result := make(map[TopicPartition]int64)
+		for _, offset := range offsets {
+			result[offset.TopicPartition] = offset.Offset
+		}
+		require.Equal(t, tc.expected, result, tc.name)
+
+		p0 := w.progresses[TopicPartition{Topic: "t1", Partition: 0}]
+		require.Equal(t, uint64(1000), p0.watermark, tc.name)
+		require.Equal(t, uint64(1000), p0.flushedTs, tc.name)
+
+		// the rows written to the downstream before restart are skipped.
+		w.appendRow2Group(newRowForTest(1, 1100), p0, 101)
+		w.appendRow2Group(newRowForTest(1, 1200), p0, 102)
+		require.Empty(t, p0.eventGroups, tc.name)
+		w.appendRow2Group(newRowForTest(1, 1300), p0, 103)
+		require.Len(t, p0.eventGroups[1].events, 1, tc.name)
+
+		// the revoked partition is checkpointed before it is handed over.
+		p0.lastOffset = 103
+		offsets, err = w.RemovePartitions(ctx, []TopicPartition{{Topic: "t1", Partition: 0}}, true)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, []PartitionOffset{
+			{TopicPartition: TopicPartition{Topic: "t1", Partition: 0}, Offset: 103},
+		}, offsets, tc.name)
+		require.Len(t, store.saved, 1, tc.name)
+		require.Equal(t, int64(103), store.saved[0].offset, tc.name)
+		require.Equal(t, uint64(1000), store.saved[0].watermark, tc.name)
+	}
+}
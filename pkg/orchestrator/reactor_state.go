@@ -286,6 +286,9 @@ func (s *ChangefeedReactorState) ResumeChangefeed(overwriteCheckpointTs uint64)
 				CheckpointTs:      overwriteCheckpointTs,
 				MinTableBarrierTs: overwriteCheckpointTs,
 				AdminJobType:      model.AdminNone,
+				// undelivered hook events and the schedule survive the overwrite
+				PendingHookEvents: status.PendingHookEvents,
+				ScheduleFiredAt:   status.ScheduleFiredAt,
 			}
 			log.Info("overwriting the tableCheckpoint ts",
 				zap.String("namespace", s.ID.Namespace),
@@ -417,6 +420,73 @@ func (s *ChangefeedReactorState) UpdateChangefeedState(feedState model.FeedState
 	})
 }
 
+// AddHookEvents appends hook events to the changefeed status, where they are
+// kept until delivered. The oldest events are dropped if there are too many.
+func (s *ChangefeedReactorState) AddHookEvents(events ...*model.PendingHookEvent) {
+	if len(events) == 0 {
+		return
+	}
+	s.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		if status == nil {
+			return status, false, nil
+		}
+		status.PendingHookEvents = append(status.PendingHookEvents, events...)
+		if dropped := len(status.PendingHookEvents) - model.MaxPendingHookEvents; dropped > 0 {
+			log.Warn("too many pending hook events, drop the oldest ones",
+				zap.String("namespace", s.ID.Namespace),
+				zap.String("changefeed", s.ID.ID),
+				zap.Int("dropped", dropped))
+			status.PendingHookEvents = status.PendingHookEvents[dropped:]
+		}
+		return status, true, nil
+	})
+}
+
+// RemoveHookEvent removes a delivered hook event from the changefeed status.
+func (s *ChangefeedReactorState) RemoveHookEvent(id uint64) {
+	s.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		if status == nil {
+			return status, false, nil
+		}
+		for i, pending := range status.PendingHookEvents {
+			if pending.Event.ID != id {
+				continue
+			}
+			events := make([]*model.PendingHookEvent, 0, len(status.PendingHookEvents)-1)
+			events = append(events, status.PendingHookEvents[:i]...)
+			events = append(events, status.PendingHookEvents[i+1:]...)
+			if len(events) == 0 {
+				events = nil
+			}
+			status.PendingHookEvents = events
+			return status, true, nil
+		}
+		return status, false, nil
+	})
+}
+
+// SetScheduleFiredAt sets the last time the schedule of the changefeed fired
+// to the changefeed status, a zero time clears it.
+func (s *ChangefeedReactorState) SetScheduleFiredAt(firedAt time.Time) {
+	s.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		if status == nil {
+			return status, false, nil
+		}
+		if firedAt.IsZero() {
+			if status.ScheduleFiredAt == nil {
+				return status, false, nil
+			}
+			status.ScheduleFiredAt = nil
+			return status, true, nil
+		}
+		if status.ScheduleFiredAt != nil && status.ScheduleFiredAt.Equal(firedAt) {
+			return status, false, nil
+		}
+		status.ScheduleFiredAt = &firedAt
+		return status, true, nil
+	})
+}
+
 // UpdatePendingChange implements the ReactorState interface
 func (s *ChangefeedReactorState) UpdatePendingChange() {
 }
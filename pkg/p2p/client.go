@@ -62,6 +62,13 @@ type MessageClientConfig struct {
 	ClientVersion string
 	// MaxRecvMsgSize is the maximum message size in bytes TiCDC can receive.
 	MaxRecvMsgSize int
+	// The compression proposed to the server for message batches.
+	// Empty string or "none" means no compression.
+	Compression string
+	// The round-trip time to the server at which batches are allowed to reach
+	// MaxBatchBytes and MaxBatchCount. Batches are flushed earlier if the
+	// round-trip time is lower. Zero means batches always use the max limits.
+	BatchRTTThreshold time.Duration
 }
 
 type localMessageClient struct {
@@ -14,9 +14,14 @@
 package p2p
 
 import (
+	"time"
+
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/errors"
 	proto "github.com/pingcap/tiflow/proto/p2p"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
 )
 
 const (
@@ -24,8 +29,26 @@ const (
 	// 4096 is reasonable given the scenarios under which
 	// the peer-message system is used.
 	maxPreallocBatchSize = 4096
+
+	// minCompressBatchBytes is the minimum size in bytes of a batch
+	// to be compressed, as compressing smaller batches saves little traffic.
+	minCompressBatchBytes = 1024
+
+	// The limits of a batch adapted to the round-trip time are at least
+	// 1/minBatchLimitDivisor of the configured ones.
+	minBatchLimitDivisor = 16
 )
 
+// peerState is what a client negotiates with and measures on the server
+// of its current stream. It is shared by the client and its batch senders.
+type peerState struct {
+	// compression is the compression accepted by the server.
+	// Empty means that batches are sent uncompressed.
+	compression atomic.String
+	// rtt is the latest round-trip time to the server.
+	rtt atomic.Duration
+}
+
 var _ clientBatchSender[MessageEntry] = &grpcClientBatchSender{}
 
 // clientBatchSender is a batch sender that
@@ -43,9 +66,20 @@ type grpcClientBatchSender struct {
 
 	maxEntryCount int
 	maxSizeBytes  int
+
+	// peer is nil if the batches are neither compressed
+	// nor adapted to the round-trip time.
+	peer *peerState
+	// rttThreshold is the round-trip time at which the batches reach
+	// maxEntryCount and maxSizeBytes. Zero disables the adaption.
+	rttThreshold time.Duration
+
+	metricsBatchSize        prometheus.Observer
+	metricsBatchBytes       prometheus.Observer
+	metricsCompressionRatio prometheus.Observer
 }
 
-func newClientBatchSender(stream MessageClientStream, maxEntryCount, maxSizeBytes int) clientBatchSender[MessageEntry] {
+func newClientBatchSender(stream MessageClientStream, maxEntryCount, maxSizeBytes int) *grpcClientBatchSender {
 	sliceCap := maxEntryCount
 	if sliceCap > maxPreallocBatchSize {
 		sliceCap = maxPreallocBatchSize
@@ -69,12 +103,40 @@ func (s *grpcClientBatchSender) Append(msg MessageEntry) error {
 	s.buffer = append(s.buffer, msg)
 	s.sizeBytes += msg.Size()
 
-	if len(s.buffer) >= s.maxEntryCount || s.sizeBytes >= s.maxSizeBytes {
+	maxEntryCount, maxSizeBytes := s.limits()
+	if len(s.buffer) >= maxEntryCount || s.sizeBytes >= maxSizeBytes {
 		return s.Flush()
 	}
 	return nil
 }
 
+// limits returns the limits of a batch adapted to the round-trip time to the
+// server. A batch is flushed early on a fast link to keep the latency low, and
+// it grows to the configured limits as the round-trip time reaches rttThreshold,
+// so that fewer round trips are spent on a slow link.
+func (s *grpcClientBatchSender) limits() (maxEntryCount, maxSizeBytes int) {
+	if s.peer == nil || s.rttThreshold <= 0 {
+		return s.maxEntryCount, s.maxSizeBytes
+	}
+	rtt := s.peer.rtt.Load()
+	if rtt <= 0 || rtt >= s.rttThreshold {
+		return s.maxEntryCount, s.maxSizeBytes
+	}
+	ratio := float64(rtt) / float64(s.rttThreshold)
+	return adaptBatchLimit(s.maxEntryCount, ratio), adaptBatchLimit(s.maxSizeBytes, ratio)
+}
+
+func adaptBatchLimit(limit int, ratio float64) int {
+	adapted := int(float64(limit) * ratio)
+	if lower := limit / minBatchLimitDivisor; adapted < lower {
+		adapted = lower
+	}
+	if adapted < 1 {
+		adapted = 1
+	}
+	return adapted
+}
+
 // Flush flushes the batch.
 func (s *grpcClientBatchSender) Flush() error {
 	failpoint.Inject("ClientBatchSenderInjectError", func() {
@@ -87,8 +149,50 @@ func (s *grpcClientBatchSender) Flush() error {
 
 	var messagePacket proto.MessagePacket
 	messagePacket.Entries = s.buffer
+	if err := s.compress(&messagePacket); err != nil {
+		return errors.Trace(err)
+	}
+	if s.peer != nil {
+		messagePacket.SendTime = time.Now().UnixNano()
+	}
+	if s.metricsBatchSize != nil {
+		s.metricsBatchSize.Observe(float64(len(s.buffer)))
+		s.metricsBatchBytes.Observe(float64(messagePacket.Size()))
+	}
 	err := s.stream.Send(&messagePacket)
 	s.sizeBytes = 0
 	s.buffer = s.buffer[:0]
 	return err
 }
+
+// compress replaces the entries of the packet with the compressed ones,
+// if the server has accepted a compression and the batch is large enough.
+func (s *grpcClientBatchSender) compress(packet *proto.MessagePacket) error {
+	if s.peer == nil || s.sizeBytes < minCompressBatchBytes {
+		return nil
+	}
+	cc := s.peer.compression.Load()
+	if cc == "" {
+		return nil
+	}
+
+	data, err := packet.Marshal()
+	if err != nil {
+		return errors.WrapError(errors.ErrPeerMessageEncodeError, err)
+	}
+	compressed, err := compression.Encode(cc, data)
+	if err != nil {
+		return errors.WrapError(errors.ErrPeerMessageEncodeError, err)
+	}
+	if len(compressed) >= len(data) {
+		// The batch is incompressible, send it as is.
+		return nil
+	}
+	packet.Entries = nil
+	packet.Compression = cc
+	packet.CompressedEntries = compressed
+	if s.metricsCompressionRatio != nil {
+		s.metricsCompressionRatio.Observe(float64(len(data)) / float64(len(compressed)))
+	}
+	return nil
+}
@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
+	"github.com/pingcap/tiflow/pkg/compression"
 	proto "github.com/pingcap/tiflow/proto/p2p"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -110,3 +112,104 @@ func TestClientBatchSenderFlush(t *testing.T) {
 	err := sender.Flush()
 	require.NoError(t, err)
 }
+
+func TestClientBatchSenderCompression(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	grpcStream := newMockSendMessageClient(ctx)
+	sender := newClientBatchSender(grpcStream, math.MaxInt64, math.MaxInt64)
+	sender.peer = &peerState{}
+	sender.peer.compression.Store(compression.Snappy)
+
+	for i := 1; i <= 100; i++ {
+		err := sender.Append(&proto.MessageEntry{
+			Topic:    "test-topic",
+			Content:  []byte(fmt.Sprintf("test-content-%d", i)),
+			Sequence: int64(i),
+		})
+		require.NoError(t, err)
+	}
+
+	grpcStream.On("Send", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		msg := args.Get(0).(*proto.MessagePacket)
+		require.Equal(t, compression.Snappy, msg.Compression)
+		require.Empty(t, msg.Entries)
+		require.NotZero(t, msg.SendTime)
+
+		entries, err := decompressEntries(msg)
+		require.NoError(t, err)
+		require.Len(t, entries, 100)
+		for i, entry := range entries {
+			require.Equal(t, "test-topic", entry.Topic)
+			require.Equal(t, int64(i+1), entry.Sequence)
+			require.Equal(t, fmt.Sprintf("test-content-%d", i+1), string(entry.Content))
+		}
+	}).Once()
+	require.NoError(t, sender.Flush())
+
+	// A small batch is sent uncompressed.
+	grpcStream.On("Send", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		msg := args.Get(0).(*proto.MessagePacket)
+		require.Empty(t, msg.Compression)
+		require.Len(t, msg.Entries, 1)
+	}).Once()
+	err := sender.Append(&proto.MessageEntry{
+		Topic:    "test-topic",
+		Content:  []byte("test-content-101"),
+		Sequence: 101,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sender.Flush())
+	grpcStream.AssertNumberOfCalls(t, "Send", 2)
+}
+
+func TestClientBatchSenderAdaptiveLimits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	grpcStream := newMockSendMessageClient(ctx)
+	sender := newClientBatchSender(grpcStream, 1024, 64*1024)
+
+	count, size := sender.limits()
+	require.Equal(t, 1024, count)
+	require.Equal(t, 64*1024, size)
+
+	sender.peer = &peerState{}
+	sender.rttThreshold = 8 * time.Millisecond
+	// The round-trip time is unknown yet.
+	count, size = sender.limits()
+	require.Equal(t, 1024, count)
+	require.Equal(t, 64*1024, size)
+
+	sender.peer.rtt.Store(2 * time.Millisecond)
+	count, size = sender.limits()
+	require.Equal(t, 256, count)
+	require.Equal(t, 16*1024, size)
+
+	sender.peer.rtt.Store(time.Microsecond)
+	count, size = sender.limits()
+	require.Equal(t, 1024/minBatchLimitDivisor, count)
+	require.Equal(t, 64*1024/minBatchLimitDivisor, size)
+
+	sender.peer.rtt.Store(time.Second)
+	count, size = sender.limits()
+	require.Equal(t, 1024, count)
+	require.Equal(t, 64*1024, size)
+
+	// A batch is flushed earlier on a fast link.
+	sender.peer.rtt.Store(time.Microsecond)
+	grpcStream.On("Send", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		msg := args.Get(0).(*proto.MessagePacket)
+		require.Len(t, msg.Entries, 1024/minBatchLimitDivisor)
+	})
+	for i := 1; i <= 1024/minBatchLimitDivisor; i++ {
+		err := sender.Append(&proto.MessageEntry{
+			Topic:    "test-topic",
+			Content:  []byte(fmt.Sprintf("test-%d", i)),
+			Sequence: int64(i),
+		})
+		require.NoError(t, err)
+	}
+	grpcStream.AssertNumberOfCalls(t, "Send", 1)
+}
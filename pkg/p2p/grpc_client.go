@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/compression"
 	"github.com/pingcap/tiflow/pkg/container/queue"
 	cerrors "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/p2p/internal"
@@ -50,6 +51,9 @@ type grpcMessageClient struct {
 	// config is read only
 	config *MessageClientConfig
 
+	// peer is reset each time a stream is launched.
+	peer peerState
+
 	// newSender is used to create a new sender.
 	// It can be replaced to unit test MessageClient.
 	newSenderFn func(MessageClientStream) clientBatchSender[MessageEntry]
@@ -59,27 +63,58 @@ type grpcMessageClient struct {
 
 type topicEntry struct {
 	sentMessageMu sync.Mutex
-	sentMessages  queue.ChunkQueue[*p2p.MessageEntry]
+	sentMessages  queue.ChunkQueue[*sentMessage]
 
 	nextSeq  atomic.Int64
 	ack      atomic.Int64
 	lastSent atomic.Int64
 }
 
+// sentMessage is a message sent but not yet acknowledged by the server.
+type sentMessage struct {
+	*p2p.MessageEntry
+	sentAt time.Time
+}
+
 // NewGrpcMessageClient creates a new MessageClient
 // senderID is an identifier for the local node.
 func NewGrpcMessageClient(senderID NodeID, config *MessageClientConfig) *grpcMessageClient {
-	return &grpcMessageClient{
-		sendCh:   internal.NewSendChan(int64(config.SendChannelSize)),
-		topics:   make(map[string]*topicEntry),
-		senderID: senderID,
-		closeCh:  make(chan struct{}),
-		config:   config,
-		newSenderFn: func(stream MessageClientStream) clientBatchSender[MessageEntry] {
-			return newClientBatchSender(stream, config.MaxBatchBytes, config.MaxBatchCount)
-		},
+	c := &grpcMessageClient{
+		sendCh:    internal.NewSendChan(int64(config.SendChannelSize)),
+		topics:    make(map[string]*topicEntry),
+		senderID:  senderID,
+		closeCh:   make(chan struct{}),
+		config:    config,
 		connector: newClientConnector(),
 	}
+	c.newSenderFn = c.newBatchSender
+	return c
+}
+
+func (c *grpcMessageClient) newBatchSender(stream MessageClientStream) clientBatchSender[MessageEntry] {
+	peerAddr := unknownPeerLabel
+	peer, ok := gRPCPeer.FromContext(stream.Context())
+	if ok {
+		peerAddr = peer.Addr.String()
+	}
+	labels := prometheus.Labels{"to": peerAddr}
+
+	sender := newClientBatchSender(stream, c.config.MaxBatchCount, c.config.MaxBatchBytes)
+	sender.peer = &c.peer
+	sender.rttThreshold = c.config.BatchRTTThreshold
+	sender.metricsBatchSize = clientMessageBatchHistogram.With(labels)
+	sender.metricsBatchBytes = clientMessageBatchBytesHistogram.With(labels)
+	sender.metricsCompressionRatio = clientCompressionRatioHistogram.With(labels)
+	return sender
+}
+
+// proposedCompression returns the compression to be proposed to the server,
+// or an empty string if batches should not be compressed.
+func (c *grpcMessageClient) proposedCompression() string {
+	if c.config.Compression == compression.None {
+		return ""
+	}
+	return c.config.Compression
 }
 
 // Run launches background goroutines for MessageClient to work.
@@ -131,6 +166,7 @@ func (c *grpcMessageClient) Run(
 			ReceiverId:           receiverID,
 			Epoch:                epoch,
 			ClientVersion:        c.config.ClientVersion,
+			Compression:          c.proposedCompression(),
 			SenderAdvertisedAddr: c.config.AdvertisedAddr,
 		}
 
@@ -153,6 +189,11 @@ func (c *grpcMessageClient) launchStream(ctx context.Context, gRPCClient p2p.CDC
 	cancelCtx, cancelStream := context.WithCancel(ctx)
 	defer cancelStream()
 
+	// Batches are sent uncompressed until the server of the new stream
+	// accepts the proposed compression.
+	c.peer.compression.Store("")
+	c.peer.rtt.Store(0)
+
 	clientStream, err := gRPCClient.SendMessage(cancelCtx)
 	if err != nil {
 		return errors.Trace(err)
@@ -195,6 +236,7 @@ func (c *grpcMessageClient) runTx(ctx context.Context, stream MessageClientStrea
 	metricsClientMessageCount := clientMessageCount.With(prometheus.Labels{
 		"to": peerAddr,
 	})
+	metricsClientTopicBytes := make(map[Topic]prometheus.Counter)
 
 	ticker := time.NewTicker(c.config.BatchSendInterval)
 	defer ticker.Stop()
@@ -233,10 +275,16 @@ func (c *grpcMessageClient) runTx(ctx context.Context, stream MessageClientStrea
 		}
 
 		tpk.sentMessageMu.Lock()
-		tpk.sentMessages.Push(msg)
+		tpk.sentMessages.Push(&sentMessage{MessageEntry: msg, sentAt: time.Now()})
 		tpk.sentMessageMu.Unlock()
 
 		metricsClientMessageCount.Inc()
+		topicBytes, ok := metricsClientTopicBytes[msg.Topic]
+		if !ok {
+			topicBytes = clientTopicBytes.WithLabelValues(peerAddr, msg.Topic)
+			metricsClientTopicBytes[msg.Topic] = topicBytes
+		}
+		topicBytes.Add(float64(len(msg.Content)))
 
 		log.Debug("Sending Message",
 			zap.String("topic", msg.Topic),
@@ -310,6 +358,9 @@ func (c *grpcMessageClient) runRx(ctx context.Context, stream MessageClientStrea
 	metricsClientAckCount := clientAckCount.With(prometheus.Labels{
 		"from": peerAddr,
 	})
+	metricsClientRTT := clientRTTHistogram.With(prometheus.Labels{
+		"to": peerAddr,
+	})
 
 	for {
 		select {
@@ -331,6 +382,20 @@ func (c *grpcMessageClient) runRx(ctx context.Context, stream MessageClientStrea
 			return cerrors.ErrPeerMessageServerClosed.GenWithStackByArgs(resp.GetErrorMessage())
 		}
 
+		if cc := resp.GetCompression(); cc != "" && cc == c.proposedCompression() {
+			if c.peer.compression.Swap(cc) == "" {
+				log.Info("peer-to-peer client: compression accepted by server",
+					zap.String("peerAddr", peerAddr), zap.String("compression", cc))
+			}
+		}
+		if resp.GetEchoSendTime() != 0 {
+			rtt := time.Duration(time.Now().UnixNano() - resp.GetEchoSendTime() - resp.GetEchoHoldTime())
+			if rtt > 0 {
+				c.peer.rtt.Store(rtt)
+				metricsClientRTT.Observe(rtt.Seconds())
+			}
+		}
+
 		metricsClientAckCount.Inc()
 
 		for _, ack := range resp.GetAck() {
@@ -343,11 +408,20 @@ func (c *grpcMessageClient) runRx(ctx context.Context, stream MessageClientStrea
 			}
 
 			tpk.ack.Store(ack.GetLastSeq())
+			var lastAcked *sentMessage
 			tpk.sentMessageMu.Lock()
-			tpk.sentMessages.RangeAndPop(func(msg *p2p.MessageEntry) bool {
-				return msg.Sequence <= ack.GetLastSeq()
+			tpk.sentMessages.RangeAndPop(func(msg *sentMessage) bool {
+				if msg.Sequence <= ack.GetLastSeq() {
+					lastAcked = msg
+					return true
+				}
+				return false
 			})
 			tpk.sentMessageMu.Unlock()
+			if lastAcked != nil {
+				clientTopicAckDuration.WithLabelValues(peerAddr, ack.GetTopic()).
+					Observe(time.Since(lastAcked.sentAt).Seconds())
+			}
 		}
 	}
 }
@@ -387,7 +461,7 @@ func (c *grpcMessageClient) sendMessage(ctx context.Context, topic Topic, value
 
 	if !ok {
 		tpk = &topicEntry{
-			sentMessages: *queue.NewChunkQueue[*p2p.MessageEntry](),
+			sentMessages: *queue.NewChunkQueue[*sentMessage](),
 		}
 		tpk.nextSeq.Store(0)
 		c.topicMu.Lock()
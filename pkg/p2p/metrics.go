@@ -61,6 +61,13 @@ var (
 		Buckets:   prometheus.ExponentialBuckets(8.0, 2, 16),
 	}, []string{"from"})
 
+	serverTopicBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_server",
+		Name:      "topic_bytes",
+		Help:      "size in bytes of message contents received per topic",
+	}, []string{"from", "topic"})
+
 	serverAckCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "ticdc",
 		Subsystem: "message_server",
@@ -100,6 +107,55 @@ var (
 		Name:      "ack_count",
 		Help:      "count of ack messages received",
 	}, []string{"from"})
+
+	clientMessageBatchHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_client",
+		Name:      "message_batch_size",
+		Help:      "size in number of messages of message batches sent",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"to"})
+
+	// clientMessageBatchBytesHistogram records the wire sizes as reported by protobuf,
+	// which are the sizes after compression.
+	clientMessageBatchBytesHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_client",
+		Name:      "message_batch_bytes",
+		Help:      "size in bytes of message batches sent",
+		Buckets:   prometheus.ExponentialBuckets(8.0, 2, 16),
+	}, []string{"to"})
+
+	clientCompressionRatioHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_client",
+		Name:      "compression_ratio",
+		Help:      "ratio of the uncompressed size to the compressed size of message batches",
+		Buckets:   prometheus.LinearBuckets(1, 0.5, 16),
+	}, []string{"to"})
+
+	clientRTTHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_client",
+		Name:      "rtt_seconds",
+		Help:      "round-trip time to the message server",
+		Buckets:   prometheus.ExponentialBuckets(0.0001 /* 0.1 ms */, 2, 18),
+	}, []string{"to"})
+
+	clientTopicBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_client",
+		Name:      "topic_bytes",
+		Help:      "size in bytes of message contents sent per topic",
+	}, []string{"to", "topic"})
+
+	clientTopicAckDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ticdc",
+		Subsystem: "message_client",
+		Name:      "topic_ack_duration_seconds",
+		Help:      "duration from sending a message to receiving its ack per topic",
+		Buckets:   prometheus.ExponentialBuckets(0.001 /* 1 ms */, 2, 16),
+	}, []string{"to", "topic"})
 )
 
 // InitMetrics initializes metrics used by pkg/p2p
@@ -111,8 +167,15 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(serverMessageBatchBytesHistogram)
 	registry.MustRegister(serverAckCount)
 	registry.MustRegister(serverRepeatedMessageCount)
+	registry.MustRegister(serverTopicBytes)
 	registry.MustRegister(grpcClientMetrics)
 	registry.MustRegister(clientCount)
 	registry.MustRegister(clientMessageCount)
 	registry.MustRegister(clientAckCount)
+	registry.MustRegister(clientMessageBatchHistogram)
+	registry.MustRegister(clientMessageBatchBytesHistogram)
+	registry.MustRegister(clientCompressionRatioHistogram)
+	registry.MustRegister(clientRTTHistogram)
+	registry.MustRegister(clientTopicBytes)
+	registry.MustRegister(clientTopicAckDuration)
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/workerpool"
 	"github.com/pingcap/tiflow/proto/p2p"
@@ -711,6 +712,22 @@ func (m *MessageServer) SendMessage(stream p2p.CDCPeerToPeer_SendMessageServer)
 	defer metricsServerStreamCount.Sub(1)
 
 	sendCh := make(chan p2p.SendMessageResponse, m.config.SendChannelSize)
+	if cc := packet.Meta.GetCompression(); cc != "" {
+		// Accept the compression proposed by the client, so that it starts
+		// to compress message batches. Clients of older versions don't
+		// propose any compression.
+		if compression.Supported(cc) {
+			// sendCh is empty, so the response never blocks.
+			sendCh <- p2p.SendMessageResponse{
+				ExitReason:  p2p.ExitReason_OK,
+				Compression: cc,
+			}
+		} else {
+			log.Warn("peer-to-peer message server: unsupported compression",
+				zap.String("peerID", packet.Meta.SenderId),
+				zap.String("compression", cc))
+		}
+	}
 	streamHandle := newStreamHandle(packet.Meta, sendCh)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -757,6 +774,7 @@ func (m *MessageServer) SendMessage(stream p2p.CDCPeerToPeer_SendMessageServer)
 				if err := rl.Wait(ctx); err != nil {
 					return errors.Trace(err)
 				}
+				streamHandle.Echo(&resp)
 				if err := stream.Send(&resp); err != nil {
 					return errors.Trace(err)
 				}
@@ -822,7 +840,13 @@ func (m *MessageServer) receive(
 			return errors.Trace(err)
 		}
 
-		batchSize := len(packet.GetEntries())
+		streamHandle.RecordPacket(packet.GetSendTime())
+		entries, err := decompressEntries(packet)
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		batchSize := len(entries)
 		log.Debug("received packet", zap.String("streamHandle", streamHandle.GetStreamMeta().SenderId),
 			zap.Int("numEntries", batchSize))
 
@@ -831,7 +855,6 @@ func (m *MessageServer) receive(
 		metricsServerMessageBatchHistogram.Observe(float64(batchSize))
 		metricsServerMessageCount.Add(float64(batchSize))
 
-		entries := packet.GetEntries()
 		if batchSize > 0 {
 			if messageServerReportsIndividualMessageSize /* true for now */ {
 				// Note that this can be costly if the number of messages is huge.
@@ -840,13 +863,16 @@ func (m *MessageServer) receive(
 				for _, entry := range entries {
 					messageWireSize := entry.Size()
 					metricsServerMessageBytesHistogram.Observe(float64(messageWireSize))
+					serverTopicBytes.WithLabelValues(
+						streamHandle.GetStreamMeta().SenderAdvertisedAddr, entry.Topic).
+						Add(float64(len(entry.Content)))
 				}
 			}
 
 			// See the comment above on why use scheduleTaskBlocking.
 			if err := m.scheduleTaskBlocking(ctx, taskOnMessageBatch{
 				streamMeta:     streamHandle.GetStreamMeta(),
-				messageEntries: packet.GetEntries(),
+				messageEntries: entries,
 			}); err != nil {
 				return errors.Trace(err)
 			}
@@ -854,6 +880,23 @@ func (m *MessageServer) receive(
 	}
 }
 
+// decompressEntries returns the entries of a packet, which are decompressed
+// if they are compressed by the client.
+func decompressEntries(packet *p2p.MessagePacket) ([]*p2p.MessageEntry, error) {
+	if packet.GetCompression() == "" {
+		return packet.GetEntries(), nil
+	}
+	data, err := compression.Decode(packet.GetCompression(), packet.GetCompressedEntries())
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPeerMessageDecodeError, err)
+	}
+	var entries p2p.MessagePacket
+	if err := entries.Unmarshal(data); err != nil {
+		return nil, cerror.WrapError(cerror.ErrPeerMessageDecodeError, err)
+	}
+	return entries.Entries, nil
+}
+
 func (m *MessageServer) handleRawMessage(ctx context.Context, entry RawMessageEntry) {
 	handler, ok := m.handlers[entry.topic]
 	if !ok {
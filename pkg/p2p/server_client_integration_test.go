@@ -25,6 +25,7 @@ import (
 	"github.com/phayes/freeport"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/pkg/compression"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/proto/p2p"
@@ -44,7 +45,10 @@ var clientConfig4Testing = &MessageClientConfig{
 	MaxRecvMsgSize:          4 * 1024 * 1024, // 4MB
 }
 
-type serverConfigOpt = func(config *MessageServerConfig)
+type (
+	serverConfigOpt = func(config *MessageServerConfig)
+	clientConfigOpt = func(config *MessageClientConfig)
+)
 
 //nolint:unparam
 func newServerForIntegrationTesting(t *testing.T, serverID string, configOpts ...serverConfigOpt) (server *MessageServer, addr string, cancel func()) {
@@ -84,6 +88,7 @@ func runP2PIntegrationTest(
 	size int,
 	numTopics int,
 	clientConcurrency int,
+	clientConfigOpts ...clientConfigOpt,
 ) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -134,7 +139,11 @@ func runP2PIntegrationTest(
 		}()
 	}
 
-	client := NewGrpcMessageClient("test-client-1", clientConfig4Testing)
+	clientConfig := *clientConfig4Testing
+	for _, opt := range clientConfigOpts {
+		opt(&clientConfig)
+	}
+	client := NewGrpcMessageClient("test-client-1", &clientConfig)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -192,6 +201,31 @@ func TestMessageClientBasicMultiTopics(t *testing.T) {
 	runP2PIntegrationTest(ctx, t, defaultMessageBatchSizeLarge, 4, 4)
 }
 
+func TestMessageClientCompression(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), defaultTimeout)
+	defer cancel()
+
+	for _, cc := range []string{compression.Snappy, compression.LZ4} {
+		runP2PIntegrationTest(ctx, t, defaultMessageBatchSizeLarge, 4, 1,
+			func(config *MessageClientConfig) {
+				config.Compression = cc
+				// Batch more messages so that batches are large enough to be compressed.
+				config.BatchSendInterval = time.Millisecond * 10
+			})
+	}
+}
+
+func TestMessageClientAdaptiveBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), defaultTimeout)
+	defer cancel()
+
+	runP2PIntegrationTest(ctx, t, defaultMessageBatchSizeLarge, 4, 1,
+		func(config *MessageClientConfig) {
+			config.Compression = compression.Snappy
+			config.BatchRTTThreshold = time.Second
+		})
+}
+
 func TestMessageClientServerRestart(t *testing.T) {
 	_ = failpoint.Enable("github.com/pingcap/tiflow/pkg/p2p/ServerInjectServerRestart", "1%return(true)")
 	defer func() {
@@ -16,6 +16,7 @@ package p2p
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/proto/p2p"
@@ -40,6 +41,13 @@ type streamHandle struct {
 
 	// read-only
 	streamMeta *p2p.StreamMeta
+
+	// lastPacketSendTime and lastPacketRecvTime are the client side send time
+	// and the server side receive time of the latest packet, which are echoed
+	// to the client for it to measure the round-trip time.
+	echoMu             sync.Mutex
+	lastPacketSendTime int64
+	lastPacketRecvTime time.Time
 }
 
 // newStreamHandle returns a new streamHandle.
@@ -92,6 +100,30 @@ func (s *streamHandle) Close() {
 	close(s.sendCh)
 }
 
+// RecordPacket records the send time of a packet received from the stream.
+func (s *streamHandle) RecordPacket(sendTime int64) {
+	if sendTime == 0 {
+		// The client doesn't measure the round-trip time.
+		return
+	}
+	s.echoMu.Lock()
+	defer s.echoMu.Unlock()
+	s.lastPacketSendTime = sendTime
+	s.lastPacketRecvTime = time.Now()
+}
+
+// Echo sets the send time of the latest packet, and how long it has been
+// held by the server, to the response.
+func (s *streamHandle) Echo(response *p2p.SendMessageResponse) {
+	s.echoMu.Lock()
+	defer s.echoMu.Unlock()
+	if s.lastPacketSendTime == 0 {
+		return
+	}
+	response.EchoSendTime = s.lastPacketSendTime
+	response.EchoHoldTime = int64(time.Since(s.lastPacketRecvTime))
+}
+
 // GetStreamMeta returns the metadata associated with the stream.
 func (s *streamHandle) GetStreamMeta() *p2p.StreamMeta {
 	return s.streamMeta
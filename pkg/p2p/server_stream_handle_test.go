@@ -103,3 +103,21 @@ func TestGetStreamMeta(t *testing.T) {
 	h := newStreamHandle(mockStreamMeta, nil)
 	require.Equal(t, mockStreamMeta, h.GetStreamMeta())
 }
+
+func TestStreamHandleEcho(t *testing.T) {
+	t.Parallel()
+	h := newStreamHandle(mockStreamMeta, make(chan proto.SendMessageResponse))
+
+	// Nothing is echoed before a packet with send time is received.
+	var resp proto.SendMessageResponse
+	h.RecordPacket(0)
+	h.Echo(&resp)
+	require.Zero(t, resp.EchoSendTime)
+	require.Zero(t, resp.EchoHoldTime)
+
+	h.RecordPacket(12345)
+	time.Sleep(10 * time.Millisecond)
+	h.Echo(&resp)
+	require.Equal(t, int64(12345), resp.EchoSendTime)
+	require.GreaterOrEqual(t, resp.EchoHoldTime, int64(10*time.Millisecond))
+}
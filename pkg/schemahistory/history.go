@@ -0,0 +1,193 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemahistory
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pingcap/errors"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// FormatVersion is the version of the schema history format written by
+// this package. It is bumped when an incompatible change is made.
+const FormatVersion = 1
+
+// TableVersion is a version of a table definition. The version is the
+// `UpdateTS` of the TiDB table info, which is the same version carried
+// by the schema of the simple protocol and the cloud storage sink.
+type TableVersion struct {
+	SchemaID  int64              `json:"schema_id"`
+	Schema    string             `json:"schema"`
+	TableID   int64              `json:"table_id"`
+	Table     string             `json:"table"`
+	Version   uint64             `json:"version"`
+	TableInfo *timodel.TableInfo `json:"table_info"`
+}
+
+// History is the schema history of the tables of a changefeed between
+// StartTs and EndTs. For every table, it contains the version effective
+// at StartTs and all the versions created until EndTs.
+type History struct {
+	FormatVersion int    `json:"format_version"`
+	Namespace     string `json:"namespace,omitempty"`
+	Changefeed    string `json:"changefeed,omitempty"`
+	StartTs       uint64 `json:"start_ts"`
+	EndTs         uint64 `json:"end_ts"`
+
+	Tables []*TableVersion `json:"tables"`
+}
+
+// NewHistory creates an empty schema history between startTs and endTs.
+func NewHistory(changefeedID model.ChangeFeedID, startTs, endTs uint64) *History {
+	return &History{
+		FormatVersion: FormatVersion,
+		Namespace:     changefeedID.Namespace,
+		Changefeed:    changefeedID.ID,
+		StartTs:       startTs,
+		EndTs:         endTs,
+		Tables:        make([]*TableVersion, 0),
+	}
+}
+
+// Add adds a version of the table to the history, a version which is
+// already in the history is ignored.
+func (h *History) Add(schemaID int64, schema string, info *timodel.TableInfo) {
+	for _, v := range h.Tables {
+		if v.TableID == info.ID && v.Version == info.UpdateTS {
+			return
+		}
+	}
+	h.Tables = append(h.Tables, &TableVersion{
+		SchemaID:  schemaID,
+		Schema:    schema,
+		TableID:   info.ID,
+		Table:     info.Name.O,
+		Version:   info.UpdateTS,
+		TableInfo: info,
+	})
+}
+
+// Sort sorts the versions by schema, table and version.
+func (h *History) Sort() {
+	sort.SliceStable(h.Tables, func(i, j int) bool {
+		a, b := h.Tables[i], h.Tables[j]
+		if a.Schema != b.Schema {
+			return a.Schema < b.Schema
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		return a.Version < b.Version
+	})
+}
+
+// Validate checks whether the history can be used.
+func (h *History) Validate() error {
+	if h.FormatVersion <= 0 || h.FormatVersion > FormatVersion {
+		return cerror.ErrSchemaHistoryInvalid.GenWithStackByArgs(
+			"unsupported format version")
+	}
+	if h.StartTs > h.EndTs {
+		return cerror.ErrSchemaHistoryInvalid.GenWithStackByArgs(
+			"start ts is greater than end ts")
+	}
+	for _, v := range h.Tables {
+		if v.TableInfo == nil || v.Schema == "" || v.Table == "" {
+			return cerror.ErrSchemaHistoryInvalid.GenWithStackByArgs(
+				"table definition is incomplete")
+		}
+	}
+	return nil
+}
+
+// TableInfos returns all versions of all tables in the history.
+func (h *History) TableInfos() []*model.TableInfo {
+	result := make([]*model.TableInfo, 0, len(h.Tables))
+	for _, v := range h.Tables {
+		result = append(result, v.toTableInfo())
+	}
+	return result
+}
+
+// Lookup returns the version of the table which is effective at ts,
+// that is the latest version which is not greater than ts.
+func (h *History) Lookup(schema, table string, ts uint64) (*model.TableInfo, bool) {
+	var found *TableVersion
+	for _, v := range h.Tables {
+		if v.Schema != schema || v.Table != table || v.Version > ts {
+			continue
+		}
+		if found == nil || v.Version > found.Version {
+			found = v
+		}
+	}
+	if found == nil {
+		return nil, false
+	}
+	return found.toTableInfo(), true
+}
+
+func (v *TableVersion) toTableInfo() *model.TableInfo {
+	// the table info is shared between lookups, clone it to avoid
+	// the callers modifying the history.
+	return model.WrapTableInfo(v.SchemaID, v.Schema, v.Version, v.TableInfo.Clone())
+}
+
+// Encode writes the history to w in JSON.
+func (h *History) Encode(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errors.Trace(encoder.Encode(h))
+}
+
+// Decode reads a history in JSON from r and validates it.
+func Decode(r io.Reader) (*History, error) {
+	h := &History{}
+	if err := json.NewDecoder(r).Decode(h); err != nil {
+		return nil, cerror.WrapError(cerror.ErrSchemaHistoryInvalid, err)
+	}
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// WriteFile writes the history to the file at path.
+func (h *History) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := h.Encode(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return errors.Trace(f.Close())
+}
+
+// ReadFile reads the history from the file at path.
+func ReadFile(path string) (*History, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	return Decode(f)
+}
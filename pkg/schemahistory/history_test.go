@@ -0,0 +1,111 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemahistory
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newTableInfo(id int64, name string, version uint64, columns ...string) *timodel.TableInfo {
+	info := &timodel.TableInfo{
+		ID:       id,
+		Name:     pmodel.NewCIStr(name),
+		UpdateTS: version,
+	}
+	for i, column := range columns {
+		info.Columns = append(info.Columns, &timodel.ColumnInfo{
+			ID:     int64(i + 1),
+			Name:   pmodel.NewCIStr(column),
+			Offset: i,
+			State:  timodel.StatePublic,
+		})
+	}
+	return info
+}
+
+func TestHistoryLookup(t *testing.T) {
+	t.Parallel()
+
+	h := NewHistory(model.DefaultChangeFeedID("test"), 100, 300)
+	h.Add(1, "test", newTableInfo(10, "t", 200, "a", "b"))
+	h.Add(1, "test", newTableInfo(10, "t", 50, "a"))
+	// the same version is only added once.
+	h.Add(1, "test", newTableInfo(10, "t", 50, "a"))
+	h.Add(1, "test", newTableInfo(11, "t1", 80, "c"))
+	h.Sort()
+	require.NoError(t, h.Validate())
+	require.Len(t, h.Tables, 3)
+	require.Equal(t, uint64(50), h.Tables[0].Version)
+	require.Equal(t, uint64(200), h.Tables[1].Version)
+	require.Equal(t, "t1", h.Tables[2].Table)
+
+	_, ok := h.Lookup("test", "t", 49)
+	require.False(t, ok)
+
+	info, ok := h.Lookup("test", "t", 199)
+	require.True(t, ok)
+	require.Equal(t, uint64(50), info.UpdateTS)
+	require.Len(t, info.Columns, 1)
+	require.Equal(t, "test", info.TableName.Schema)
+	require.Equal(t, "t", info.TableName.Table)
+
+	info, ok = h.Lookup("test", "t", 300)
+	require.True(t, ok)
+	require.Equal(t, uint64(200), info.UpdateTS)
+	require.Len(t, info.Columns, 2)
+
+	_, ok = h.Lookup("test", "t2", 300)
+	require.False(t, ok)
+
+	require.Len(t, h.TableInfos(), 3)
+}
+
+func TestHistoryEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	h := NewHistory(model.DefaultChangeFeedID("test"), 100, 300)
+	h.Add(1, "test", newTableInfo(10, "t", 200, "a", "b"))
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, h.Encode(buf))
+	decoded, err := Decode(buf)
+	require.NoError(t, err)
+	require.Equal(t, h.StartTs, decoded.StartTs)
+	require.Equal(t, h.EndTs, decoded.EndTs)
+	require.Equal(t, "test", decoded.Changefeed)
+	require.Len(t, decoded.Tables, 1)
+	require.Equal(t, "b", decoded.Tables[0].TableInfo.Columns[1].Name.O)
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	require.NoError(t, h.WriteFile(path))
+	decoded, err = ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, decoded.Tables, 1)
+
+	_, err = Decode(bytes.NewBufferString(`{"format_version": 2}`))
+	require.ErrorContains(t, err, "unsupported format version")
+	_, err = Decode(bytes.NewBufferString(`{"format_version": 1, "start_ts": 2, "end_ts": 1}`))
+	require.ErrorContains(t, err, "start ts is greater than end ts")
+	_, err = Decode(bytes.NewBufferString(`{"format_version": 1, "tables": [{"schema": "test"}]}`))
+	require.ErrorContains(t, err, "table definition is incomplete")
+	_, err = Decode(bytes.NewBufferString(`not json`))
+	require.Error(t, err)
+}
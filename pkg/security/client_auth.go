@@ -0,0 +1,409 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+const defaultJWTUserClaim = "sub"
+
+// ClientAuthConfig is the config of the authentication methods of the OpenAPI
+// besides the user and password of the upstream TiDB.
+type ClientAuthConfig struct {
+	// TokenFile is the path of the static bearer tokens. Each line of the file
+	// is a token and the user it belongs to, separated by whitespaces.
+	TokenFile string `toml:"token-file" json:"token-file"`
+	// JWKSFile is the path of the JSON Web Key Set which is used to verify
+	// the JWT bearer tokens.
+	JWKSFile string `toml:"jwks-file" json:"jwks-file"`
+	// JWTIssuer is the expected `iss` claim of JWT, it's not checked if empty.
+	JWTIssuer string `toml:"jwt-issuer" json:"jwt-issuer"`
+	// JWTAudience is the expected `aud` claim of JWT, it's not checked if empty.
+	JWTAudience string `toml:"jwt-audience" json:"jwt-audience"`
+	// JWTUserClaim is the claim which holds the user of JWT, `sub` by default.
+	JWTUserClaim string `toml:"jwt-user-claim" json:"jwt-user-claim"`
+	// CertIdentity uses the common name of the verified client certificate
+	// as the user, mTLS must be enabled.
+	CertIdentity bool `toml:"cert-identity" json:"cert-identity"`
+}
+
+// IsTokenEnabled returns true if the bearer token authentication is enabled.
+func (c *ClientAuthConfig) IsTokenEnabled() bool {
+	return c != nil && (c.TokenFile != "" || c.JWKSFile != "")
+}
+
+// IsCertIdentityEnabled returns true if the client certificate identity is enabled.
+func (c *ClientAuthConfig) IsCertIdentityEnabled() bool {
+	return c != nil && c.CertIdentity
+}
+
+// ValidateClientAuth checks the client authentication config.
+func (s *Credential) ValidateClientAuth() error {
+	if s.ClientAuth == nil {
+		return nil
+	}
+	if !s.ClientUserRequired {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"client-user-required should be true when client-auth is set")
+	}
+	if s.ClientAuth.CertIdentity && (!s.MTLS || !s.IsTLSEnabled()) {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"mtls should be enabled when client-auth.cert-identity is true")
+	}
+	if s.ClientAuth.JWKSFile == "" &&
+		(s.ClientAuth.JWTIssuer != "" || s.ClientAuth.JWTAudience != "") {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"client-auth.jwks-file should be set when the JWT claims are specified")
+	}
+	// Load the files once to report the errors on startup.
+	authenticator := NewClientAuthenticator(s.ClientAuth)
+	if s.ClientAuth.TokenFile != "" {
+		if _, err := authenticator.loadTokens(); err != nil {
+			return errors.ErrInvalidServerOption.GenWithStack(
+				"invalid client-auth.token-file: %s", err.Error())
+		}
+	}
+	if s.ClientAuth.JWKSFile != "" {
+		if _, err := authenticator.loadKeys(); err != nil {
+			return errors.ErrInvalidServerOption.GenWithStack(
+				"invalid client-auth.jwks-file: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// ClientAuthenticator authenticates the OpenAPI clients by bearer tokens or
+// client certificates. The token file and the JWKS file are reloaded once
+// they are modified, so the tokens and keys can be rotated without restart.
+type ClientAuthenticator struct {
+	cfg *ClientAuthConfig
+
+	mu           sync.Mutex
+	tokens       map[string]string
+	tokenModTime time.Time
+	keys         map[string]interface{}
+	jwksModTime  time.Time
+}
+
+// NewClientAuthenticator creates a ClientAuthenticator.
+func NewClientAuthenticator(cfg *ClientAuthConfig) *ClientAuthenticator {
+	return &ClientAuthenticator{cfg: cfg}
+}
+
+// AuthenticateToken returns the user of the bearer token. The static tokens
+// are checked first, then the token is verified as a JWT.
+func (a *ClientAuthenticator) AuthenticateToken(token string) (string, error) {
+	if !a.cfg.IsTokenEnabled() {
+		return "", errors.New("bearer token authentication is not enabled")
+	}
+	if token == "" {
+		return "", errors.New("empty bearer token")
+	}
+	if a.cfg.TokenFile != "" {
+		tokens, err := a.loadTokens()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if user, ok := matchToken(tokens, token); ok {
+			return user, nil
+		}
+	}
+	if a.cfg.JWKSFile != "" && strings.Count(token, ".") == 2 {
+		return a.verifyJWT(token)
+	}
+	return "", errors.New("invalid bearer token")
+}
+
+// AuthenticateCert returns the common name of the verified client certificate.
+func (a *ClientAuthenticator) AuthenticateCert(state *tls.ConnectionState) (string, error) {
+	if !a.cfg.IsCertIdentityEnabled() {
+		return "", errors.New("client certificate identity is not enabled")
+	}
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", errors.New("no verified client certificate")
+	}
+	cn := state.VerifiedChains[0][0].Subject.CommonName
+	if cn == "" {
+		return "", errors.New("the common name of the client certificate is empty")
+	}
+	return cn, nil
+}
+
+// matchToken compares all tokens in constant time to avoid leaking
+// the tokens by the timing.
+func matchToken(tokens map[string]string, token string) (string, bool) {
+	var (
+		user  string
+		found bool
+	)
+	for t, u := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			user, found = u, true
+		}
+	}
+	return user, found
+}
+
+func (a *ClientAuthenticator) loadTokens() (map[string]string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	info, err := os.Stat(a.cfg.TokenFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if a.tokens != nil && info.ModTime().Equal(a.tokenModTime) {
+		return a.tokens, nil
+	}
+	data, err := os.ReadFile(a.cfg.TokenFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tokens, err := parseTokens(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	a.tokens, a.tokenModTime = tokens, info.ModTime()
+	return tokens, nil
+}
+
+// parseTokens parses the static tokens, empty lines and lines start with
+// `#` are ignored.
+func parseTokens(data []byte) (map[string]string, error) {
+	tokens := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("line %d should be a token and a user", lineNo)
+		}
+		if _, ok := tokens[fields[0]]; ok {
+			return nil, errors.Errorf("line %d has a duplicated token", lineNo)
+		}
+		tokens[fields[0]] = fields[1]
+	}
+	return tokens, errors.Trace(scanner.Err())
+}
+
+func (a *ClientAuthenticator) loadKeys() (map[string]interface{}, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	info, err := os.Stat(a.cfg.JWKSFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if a.keys != nil && info.ModTime().Equal(a.jwksModTime) {
+		return a.keys, nil
+	}
+	data, err := os.ReadFile(a.cfg.JWKSFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	a.keys, a.jwksModTime = keys, info.ModTime()
+	return keys, nil
+}
+
+func (a *ClientAuthenticator) verifyJWT(token string) (string, error) {
+	keys, err := a.loadKeys()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	claims := jwt.MapClaims{}
+	// The exp, nbf and iat claims are verified by the parser if present.
+	_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		default:
+			return nil, errors.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		key, ok := keys[kid]
+		if !ok {
+			return nil, errors.Errorf("key %s is not found in the jwks", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return "", errors.Annotate(err, "invalid jwt")
+	}
+	// A token without exp never expires, which is not accepted.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return "", errors.New("invalid jwt: claim exp is not found")
+	}
+	if a.cfg.JWTIssuer != "" && !claims.VerifyIssuer(a.cfg.JWTIssuer, true) {
+		return "", errors.New("invalid jwt: unexpected issuer")
+	}
+	if a.cfg.JWTAudience != "" && !verifyAudience(claims, a.cfg.JWTAudience) {
+		return "", errors.New("invalid jwt: unexpected audience")
+	}
+	userClaim := a.cfg.JWTUserClaim
+	if userClaim == "" {
+		userClaim = defaultJWTUserClaim
+	}
+	user, _ := claims[userClaim].(string)
+	if user == "" {
+		return "", errors.Errorf("invalid jwt: claim %s is not found", userClaim)
+	}
+	return user, nil
+}
+
+// verifyAudience checks the `aud` claim, which is either a string or
+// an array of strings.
+func verifyAudience(claims jwt.MapClaims, audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonWebKey is a public key in the JSON Web Key Set, see RFC 7517.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA public key
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC public key
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS parses the RSA and EC public keys for signature in the JWKS.
+func parseJWKS(data []byte) (map[string]interface{}, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var (
+			key interface{}
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsaPublicKey()
+		case "EC":
+			key, err = k.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid key %s", k.Kid)
+		}
+		if _, ok := keys[k.Kid]; ok {
+			return nil, errors.Errorf("duplicated key %s", k.Kid)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signature key is found")
+	}
+	return keys, nil
+}
+
+func (k *jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid rsa exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k *jsonWebKey) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, errors.Errorf("unsupported curve %s", k.Crv)
+	}
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("the point is not on the curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("empty key parameter")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateStaticToken(t *testing.T) {
+	t.Parallel()
+
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("# automation tokens\ntoken-a ops\n\ntoken-b monitor\n"), 0o600))
+	authenticator := NewClientAuthenticator(&ClientAuthConfig{TokenFile: tokenFile})
+
+	user, err := authenticator.AuthenticateToken("token-a")
+	require.NoError(t, err)
+	require.Equal(t, "ops", user)
+	_, err = authenticator.AuthenticateToken("token-c")
+	require.ErrorContains(t, err, "invalid bearer token")
+
+	// the token file is reloaded once it's modified.
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-c ops\n"), 0o600))
+	require.NoError(t, os.Chtimes(tokenFile, time.Now(), time.Now().Add(time.Minute)))
+	user, err = authenticator.AuthenticateToken("token-c")
+	require.NoError(t, err)
+	require.Equal(t, "ops", user)
+	_, err = authenticator.AuthenticateToken("token-a")
+	require.Error(t, err)
+
+	_, err = parseTokens([]byte("token-a"))
+	require.ErrorContains(t, err, "line 1 should be a token and a user")
+	_, err = parseTokens([]byte("token-a ops\ntoken-a monitor"))
+	require.ErrorContains(t, err, "line 2 has a duplicated token")
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []jsonWebKey{
+			{
+				Kty: "RSA", Kid: "rsa", Use: "sig",
+				N: encodeBigInt(rsaKey.N), E: encodeBigInt(big.NewInt(int64(rsaKey.E))),
+			},
+			{
+				Kty: "EC", Kid: "ec", Crv: "P-256",
+				X: encodeBigInt(ecKey.X), Y: encodeBigInt(ecKey.Y),
+			},
+		},
+	})
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	authenticator := NewClientAuthenticator(&ClientAuthConfig{
+		JWKSFile:     jwksFile,
+		JWTIssuer:    "https://idp.example.com",
+		JWTAudience:  "ticdc",
+		JWTUserClaim: "preferred_username",
+	})
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		require.NoError(t, err)
+		return s
+	}
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":                "https://idp.example.com",
+			"aud":                []string{"ticdc", "others"},
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"preferred_username": "ops",
+		}
+	}
+
+	user, err := authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "rsa", rsaKey, claims()))
+	require.NoError(t, err)
+	require.Equal(t, "ops", user)
+	user, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodES256, "ec", ecKey, claims()))
+	require.NoError(t, err)
+	require.Equal(t, "ops", user)
+
+	// the key id does not match the signing key.
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "ec", rsaKey, claims()))
+	require.ErrorContains(t, err, "invalid jwt")
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "unknown", rsaKey, claims()))
+	require.ErrorContains(t, err, "key unknown is not found")
+	// the symmetric signing methods are not allowed.
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodHS256, "rsa", []byte("secret"), claims()))
+	require.ErrorContains(t, err, "unexpected signing method HS256")
+
+	expired := claims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "rsa", rsaKey, expired))
+	require.ErrorContains(t, err, "expired")
+
+	noExpiration := claims()
+	delete(noExpiration, "exp")
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "rsa", rsaKey, noExpiration))
+	require.ErrorContains(t, err, "claim exp is not found")
+
+	wrongIssuer := claims()
+	wrongIssuer["iss"] = "https://other.example.com"
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "rsa", rsaKey, wrongIssuer))
+	require.ErrorContains(t, err, "unexpected issuer")
+
+	wrongAudience := claims()
+	wrongAudience["aud"] = "others"
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "rsa", rsaKey, wrongAudience))
+	require.ErrorContains(t, err, "unexpected audience")
+
+	noUser := claims()
+	delete(noUser, "preferred_username")
+	_, err = authenticator.AuthenticateToken(sign(jwt.SigningMethodRS256, "rsa", rsaKey, noUser))
+	require.ErrorContains(t, err, "claim preferred_username is not found")
+}
+
+func TestAuthenticateCert(t *testing.T) {
+	t.Parallel()
+
+	state := &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{
+			{Subject: pkix.Name{CommonName: "automation"}},
+		}},
+	}
+	_, err := NewClientAuthenticator(&ClientAuthConfig{}).AuthenticateCert(state)
+	require.ErrorContains(t, err, "client certificate identity is not enabled")
+
+	authenticator := NewClientAuthenticator(&ClientAuthConfig{CertIdentity: true})
+	user, err := authenticator.AuthenticateCert(state)
+	require.NoError(t, err)
+	require.Equal(t, "automation", user)
+	_, err = authenticator.AuthenticateCert(&tls.ConnectionState{})
+	require.ErrorContains(t, err, "no verified client certificate")
+	_, err = authenticator.AuthenticateCert(nil)
+	require.Error(t, err)
+}
+
+func TestValidateClientAuth(t *testing.T) {
+	t.Parallel()
+
+	credential := &Credential{ClientAuth: &ClientAuthConfig{CertIdentity: true}}
+	err := credential.ValidateClientAuth()
+	require.ErrorContains(t, err, "client-user-required should be true")
+
+	credential.ClientUserRequired = true
+	err = credential.ValidateClientAuth()
+	require.ErrorContains(t, err, "mtls should be enabled")
+
+	credential.ClientAuth = &ClientAuthConfig{JWTIssuer: "https://idp.example.com"}
+	err = credential.ValidateClientAuth()
+	require.ErrorContains(t, err, "client-auth.jwks-file should be set")
+
+	credential.ClientAuth = &ClientAuthConfig{TokenFile: filepath.Join(t.TempDir(), "not-exist")}
+	err = credential.ValidateClientAuth()
+	require.ErrorContains(t, err, "invalid client-auth.token-file")
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, []byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`), 0o600))
+	credential.ClientAuth = &ClientAuthConfig{JWKSFile: jwksFile}
+	err = credential.ValidateClientAuth()
+	require.ErrorContains(t, err, "no signature key is found")
+
+	credential.ClientAuth = nil
+	require.NoError(t, credential.ValidateClientAuth())
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"github.com/pingcap/tiflow/pkg/errors"
+)
+
+// Permission is the permission required to call an OpenAPI.
+type Permission int
+
+const (
+	// PermissionRead is required by the APIs which only query the status.
+	PermissionRead Permission = iota + 1
+	// PermissionWrite is required by the APIs which create, update, pause,
+	// resume or remove changefeeds.
+	PermissionWrite
+	// PermissionAdmin is required by the APIs which affect the whole cluster,
+	// such as draining captures, resigning the owner and the unsafe APIs.
+	PermissionAdmin
+)
+
+// String implements fmt.Stringer.
+func (p Permission) String() string {
+	switch p {
+	case PermissionRead:
+		return "read"
+	case PermissionWrite:
+		return "write"
+	case PermissionAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// ClientRole is the role of a client user of the OpenAPI.
+type ClientRole string
+
+const (
+	// ClientRoleViewer can only call the read-only APIs.
+	ClientRoleViewer ClientRole = "viewer"
+	// ClientRoleOperator can manage changefeeds besides the viewer permissions.
+	ClientRoleOperator ClientRole = "operator"
+	// ClientRoleAdmin can call all APIs.
+	ClientRoleAdmin ClientRole = "admin"
+)
+
+// Grants returns true if the role is granted the permission.
+func (r ClientRole) Grants(p Permission) bool {
+	switch r {
+	case ClientRoleViewer:
+		return p == PermissionRead
+	case ClientRoleOperator:
+		return p == PermissionRead || p == PermissionWrite
+	case ClientRoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// ClientUserRole is the role of a client user, and the changefeed
+// namespaces the user can access.
+type ClientUserRole struct {
+	Role ClientRole `toml:"role" json:"role"`
+	// Namespaces limits the namespaces of the changefeeds the user can access,
+	// all namespaces are accessible if it's empty. A user limited to some
+	// namespaces can't call the APIs which require PermissionAdmin.
+	Namespaces []string `toml:"namespaces" json:"namespaces,omitempty"`
+}
+
+// adminUserRole is the role of the users if no role is configured,
+// all allowed users can call all APIs for compatibility.
+var adminUserRole = &ClientUserRole{Role: ClientRoleAdmin}
+
+// Allows checks whether the user role is granted the permission on the namespace,
+// an empty namespace means the API does not access the changefeeds of any namespace.
+func (r *ClientUserRole) Allows(p Permission, namespace string) bool {
+	if !r.Role.Grants(p) {
+		return false
+	}
+	if len(r.Namespaces) == 0 {
+		return true
+	}
+	if p == PermissionAdmin {
+		return false
+	}
+	return namespace == "" || r.AllowsNamespace(namespace)
+}
+
+// AllowsNamespace checks whether the user can access the namespace.
+func (r *ClientUserRole) AllowsNamespace(namespace string) bool {
+	if len(r.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range r.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRBACEnabled returns true if the roles of client users are configured.
+func (s *Credential) IsRBACEnabled() bool {
+	return len(s.ClientUserRoles) != 0
+}
+
+// GetClientUserRole returns the role of the client user, all users are
+// admins if the roles are not configured.
+func (s *Credential) GetClientUserRole(user string) (*ClientUserRole, bool) {
+	if !s.IsRBACEnabled() {
+		return adminUserRole, true
+	}
+	role, ok := s.ClientUserRoles[user]
+	return role, ok
+}
+
+// ValidateClientUserRoles checks the roles of client users, each allowed
+// user must have a valid role if the roles are configured.
+func (s *Credential) ValidateClientUserRoles() error {
+	if !s.IsRBACEnabled() {
+		return nil
+	}
+	if !s.ClientUserRequired {
+		return errors.ErrInvalidServerOption.GenWithStack(
+			"client-user-required should be true when client-user-roles is set")
+	}
+	allowed := make(map[string]struct{}, len(s.ClientAllowedUser))
+	for _, user := range s.ClientAllowedUser {
+		allowed[user] = struct{}{}
+		if _, ok := s.ClientUserRoles[user]; !ok {
+			return errors.ErrInvalidServerOption.GenWithStack(
+				"the role of client user %s is not specified in client-user-roles", user)
+		}
+	}
+	for user, role := range s.ClientUserRoles {
+		if _, ok := allowed[user]; !ok {
+			return errors.ErrInvalidServerOption.GenWithStack(
+				"client user %s in client-user-roles is not in client-allowed-user", user)
+		}
+		if role == nil {
+			return errors.ErrInvalidServerOption.GenWithStack(
+				"the role of client user %s is empty", user)
+		}
+		switch role.Role {
+		case ClientRoleViewer, ClientRoleOperator, ClientRoleAdmin:
+		default:
+			return errors.ErrInvalidServerOption.GenWithStack(
+				"invalid role %s of client user %s, only viewer, operator and admin are supported",
+				role.Role, user)
+		}
+	}
+	return nil
+}
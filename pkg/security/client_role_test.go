@@ -0,0 +1,97 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientUserRoleAllows(t *testing.T) {
+	t.Parallel()
+
+	viewer := &ClientUserRole{Role: ClientRoleViewer}
+	require.True(t, viewer.Allows(PermissionRead, "default"))
+	require.False(t, viewer.Allows(PermissionWrite, "default"))
+	require.False(t, viewer.Allows(PermissionAdmin, ""))
+
+	operator := &ClientUserRole{Role: ClientRoleOperator, Namespaces: []string{"team-a"}}
+	require.True(t, operator.Allows(PermissionRead, ""))
+	require.True(t, operator.Allows(PermissionWrite, "team-a"))
+	require.False(t, operator.Allows(PermissionWrite, "default"))
+	require.False(t, operator.Allows(PermissionAdmin, ""))
+
+	admin := &ClientUserRole{Role: ClientRoleAdmin}
+	require.True(t, admin.Allows(PermissionAdmin, ""))
+	require.True(t, admin.Allows(PermissionWrite, "team-a"))
+	// the cluster level APIs are not allowed if the admin is limited to some namespaces.
+	admin.Namespaces = []string{"team-a"}
+	require.False(t, admin.Allows(PermissionAdmin, ""))
+	require.True(t, admin.Allows(PermissionWrite, "team-a"))
+
+	require.False(t, (&ClientUserRole{Role: "unknown"}).Allows(PermissionRead, ""))
+}
+
+func TestGetClientUserRole(t *testing.T) {
+	t.Parallel()
+
+	// all allowed users are admins if no role is configured.
+	credential := &Credential{ClientUserRequired: true, ClientAllowedUser: []string{"root"}}
+	require.NoError(t, credential.ValidateClientUserRoles())
+	role, ok := credential.GetClientUserRole("root")
+	require.True(t, ok)
+	require.Equal(t, ClientRoleAdmin, role.Role)
+
+	credential.ClientAllowedUser = []string{"root", "monitor"}
+	credential.ClientUserRoles = map[string]*ClientUserRole{
+		"root":    {Role: ClientRoleAdmin},
+		"monitor": {Role: ClientRoleViewer},
+	}
+	require.NoError(t, credential.ValidateClientUserRoles())
+	role, ok = credential.GetClientUserRole("monitor")
+	require.True(t, ok)
+	require.Equal(t, ClientRoleViewer, role.Role)
+	_, ok = credential.GetClientUserRole("unknown")
+	require.False(t, ok)
+}
+
+func TestValidateClientUserRoles(t *testing.T) {
+	t.Parallel()
+
+	credential := &Credential{
+		ClientAllowedUser: []string{"root", "ops"},
+		ClientUserRoles: map[string]*ClientUserRole{
+			"root": {Role: ClientRoleAdmin},
+		},
+	}
+	err := credential.ValidateClientUserRoles()
+	require.ErrorContains(t, err, "client-user-required should be true")
+
+	credential.ClientUserRequired = true
+	err = credential.ValidateClientUserRoles()
+	require.ErrorContains(t, err, "the role of client user ops is not specified")
+
+	credential.ClientUserRoles["ops"] = &ClientUserRole{Role: "owner"}
+	err = credential.ValidateClientUserRoles()
+	require.ErrorContains(t, err, "invalid role owner of client user ops")
+
+	credential.ClientUserRoles["ops"] = &ClientUserRole{Role: ClientRoleOperator}
+	credential.ClientUserRoles["guest"] = &ClientUserRole{Role: ClientRoleViewer}
+	err = credential.ValidateClientUserRoles()
+	require.ErrorContains(t, err, "client user guest in client-user-roles is not in client-allowed-user")
+
+	delete(credential.ClientUserRoles, "guest")
+	require.NoError(t, credential.ValidateClientUserRoles())
+}
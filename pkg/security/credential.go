@@ -46,6 +46,14 @@ type Credential struct {
 
 	ClientUserRequired bool     `toml:"client-user-required" json:"client-user-required"`
 	ClientAllowedUser  []string `toml:"client-allowed-user" json:"client-allowed-user"`
+	// ClientUserRoles is the role of each allowed user, all allowed users
+	// are admins if it's not set. The APIs to set the log level, drain a
+	// capture and resign the owner are only authorized if it's set.
+	ClientUserRoles map[string]*ClientUserRole `toml:"client-user-roles" json:"client-user-roles,omitempty"`
+	// ClientAuth enables the bearer token and the client certificate
+	// authentication of the OpenAPI, the authenticated user must be in
+	// ClientAllowedUser too.
+	ClientAuth *ClientAuthConfig `toml:"client-auth" json:"client-auth,omitempty"`
 }
 
 // Value implements the driver.Valuer interface
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"sort"
 	"strconv"
@@ -45,6 +46,11 @@ type BatchEncoder struct {
 	schemaM   SchemaManager
 	result    []*common.Message
 
+	// incompatibleSubjects records the table version whose schema is incompatible
+	// with each subject, the schema of the version is registered to a new subject
+	// if the fallback is enabled.
+	incompatibleSubjects map[string]uint64
+
 	config *common.Config
 }
 
@@ -116,8 +122,65 @@ func (a *BatchEncoder) encodeKey(ctx context.Context, topic string, e *model.Row
 	return data, nil
 }
 
-func topicName2SchemaSubjects(topicName, subjectSuffix string) string {
-	return topicName + subjectSuffix
+// getSubject returns the subject of the key or value schema by the subject
+// name strategy. The key and value schemas have the same record name, so the
+// subject suffix is kept for the record name strategies to distinguish them.
+func (a *BatchEncoder) getSubject(topic string, tableName model.TableName, subjectSuffix string) string {
+	switch a.config.AvroSubjectNameStrategy {
+	case common.SubjectNameStrategyRecordName:
+		return a.getRecordFullName(tableName) + subjectSuffix
+	case common.SubjectNameStrategyTopicRecordName:
+		return topic + "-" + a.getRecordFullName(tableName) + subjectSuffix
+	default:
+		return topic + subjectSuffix
+	}
+}
+
+// getRecordFullName returns the fully-qualified name of the record of the table.
+func (a *BatchEncoder) getRecordFullName(tableName model.TableName) string {
+	return getAvroNamespace(a.namespace, tableName.Schema) + "." + common.SanitizeName(tableName.Table)
+}
+
+// getVersionedSubject returns the subject which the incompatible schema of
+// the table version is registered to.
+func getVersionedSubject(subject string, tableVersion uint64) string {
+	return fmt.Sprintf("%s-v%d", subject, tableVersion)
+}
+
+// getSchemaCodec gets the codec of the subject, the schema is registered to a
+// new subject if it's incompatible with the subject and the fallback is enabled.
+func (a *BatchEncoder) getSchemaCodec(
+	ctx context.Context, subject string, tableVersion uint64, schemaGen SchemaGenerator,
+) (*goavro.Codec, []byte, error) {
+	fallback := a.config.AvroIncompatibleSchemaFallback == common.IncompatibleSchemaFallbackNewSubject
+	if fallback {
+		if version, ok := a.incompatibleSubjects[subject]; ok && version == tableVersion {
+			subject = getVersionedSubject(subject, tableVersion)
+		}
+	}
+	avroCodec, header, err := a.schemaM.GetCachedOrRegister(ctx, subject, tableVersion, schemaGen)
+	if err == nil {
+		return avroCodec, header, nil
+	}
+	if !fallback || !cerror.ErrAvroSchemaIncompatible.Equal(err) {
+		return nil, nil, errors.Trace(err)
+	}
+
+	newSubject := getVersionedSubject(subject, tableVersion)
+	log.Warn("avro: the schema is incompatible, register it to a new subject",
+		zap.String("subject", subject),
+		zap.String("newSubject", newSubject),
+		zap.Uint64("tableVersion", tableVersion),
+		zap.Error(err))
+	if a.incompatibleSubjects == nil {
+		a.incompatibleSubjects = make(map[string]uint64)
+	}
+	a.incompatibleSubjects[subject] = tableVersion
+	avroCodec, header, err = a.schemaM.GetCachedOrRegister(ctx, newSubject, tableVersion, schemaGen)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return avroCodec, header, nil
 }
 
 func (a *BatchEncoder) getValueSchemaCodec(
@@ -132,8 +195,8 @@ func (a *BatchEncoder) getValueSchemaCodec(
 		return schema, nil
 	}
 
-	subject := topicName2SchemaSubjects(topic, valueSchemaSuffix)
-	avroCodec, header, err := a.schemaM.GetCachedOrRegister(ctx, subject, tableVersion, schemaGen)
+	subject := a.getSubject(topic, tableName, valueSchemaSuffix)
+	avroCodec, header, err := a.getSchemaCodec(ctx, subject, tableVersion, schemaGen)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -152,8 +215,8 @@ func (a *BatchEncoder) getKeySchemaCodec(
 		return schema, nil
 	}
 
-	subject := topicName2SchemaSubjects(topic, keySchemaSuffix)
-	avroCodec, header, err := a.schemaM.GetCachedOrRegister(ctx, subject, tableVersion, schemaGen)
+	subject := a.getSubject(topic, tableName, keySchemaSuffix)
+	avroCodec, header, err := a.getSchemaCodec(ctx, subject, tableVersion, schemaGen)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -251,6 +314,84 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 	return nil
 }
 
+// CheckDDLSchema implements codec.DDLSchemaChecker. It checks whether the new
+// key and value schemas of the table are compatible with the subjects, so an
+// incompatible evolution is reported before the DDL is applied, rather than
+// failing when the rows are produced.
+func (a *BatchEncoder) CheckDDLSchema(ctx context.Context, topic string, ddl *model.DDLEvent) error {
+	if !a.config.AvroSchemaCompatibilityCheck {
+		return nil
+	}
+	switch ddl.Type {
+	case timodel.ActionDropTable, timodel.ActionDropSchema,
+		timodel.ActionCreateView, timodel.ActionDropView:
+		return nil
+	}
+	tableInfo := ddl.TableInfo
+	if tableInfo == nil || tableInfo.TableInfo == nil || tableInfo.TableName.Table == "" {
+		return nil
+	}
+	topic = sanitizeTopic(topic)
+
+	var keyColumns, valueColumns avroEncodeInput
+	keyColumns.TableInfo, valueColumns.TableInfo = tableInfo, tableInfo
+	for _, colInfo := range tableInfo.GetColInfosForRowChangedEvent() {
+		col := &model.ColumnData{ColumnID: colInfo.ID}
+		valueColumns.columns = append(valueColumns.columns, col)
+		valueColumns.colInfos = append(valueColumns.colInfos, colInfo)
+		if tableInfo.ForceGetColumnFlagType(colInfo.ID).IsHandleKey() {
+			keyColumns.columns = append(keyColumns.columns, col)
+			keyColumns.colInfos = append(keyColumns.colInfos, colInfo)
+		}
+	}
+
+	if len(keyColumns.columns) != 0 {
+		schema, err := a.key2AvroSchema(tableInfo.TableName, keyColumns)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		subject := a.getSubject(topic, tableInfo.TableName, keySchemaSuffix)
+		if err := a.checkCompatibility(ctx, subject, schema, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(valueColumns.columns) != 0 {
+		schema, err := a.value2AvroSchema(tableInfo.TableName, valueColumns)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		subject := a.getSubject(topic, tableInfo.TableName, valueSchemaSuffix)
+		if err := a.checkCompatibility(ctx, subject, schema, ddl); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (a *BatchEncoder) checkCompatibility(
+	ctx context.Context, subject string, schema string, ddl *model.DDLEvent,
+) error {
+	compatible, err := a.schemaM.CheckCompatibility(ctx, subject, schema)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if compatible {
+		return nil
+	}
+	if a.config.AvroIncompatibleSchemaFallback == common.IncompatibleSchemaFallbackNewSubject {
+		log.Warn("avro: the new schema is incompatible, it will be registered to a new subject",
+			zap.String("subject", subject),
+			zap.String("newSubject", getVersionedSubject(subject, ddl.TableInfo.Version)),
+			zap.String("query", ddl.Query),
+			zap.Uint64("commitTs", ddl.CommitTs))
+		return nil
+	}
+	return cerror.ErrAvroSchemaIncompatible.GenWithStackByArgs(subject,
+		fmt.Sprintf("the DDL %q changes the schema incompatibly, "+
+			"set avro-incompatible-schema-fallback to new-subject to register it to a new subject",
+			ddl.Query))
+}
+
 // EncodeCheckpointEvent only encode checkpoint event if the watermark event is enabled
 // it's only used for the testing purpose.
 func (a *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
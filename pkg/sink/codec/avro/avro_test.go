@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/sink/codec/utils"
 	"github.com/pingcap/tiflow/pkg/uuid"
@@ -391,3 +392,99 @@ func TestArvoAppendRowChangedEventWithCallback(t *testing.T) {
 		require.Equal(t, expected, count, "expected one callback be called")
 	}
 }
+
+func TestAvroSubjectNameStrategy(t *testing.T) {
+	t.Parallel()
+
+	codecConfig := common.NewConfig(config.ProtocolAvro)
+	encoder := NewAvroEncoder(model.DefaultNamespace, nil, codecConfig).(*BatchEncoder)
+	tableName := model.TableName{Schema: "test", Table: "t-1"}
+
+	require.Equal(t, "topic-value", encoder.getSubject("topic", tableName, valueSchemaSuffix))
+	require.Equal(t, "topic-key", encoder.getSubject("topic", tableName, keySchemaSuffix))
+
+	codecConfig.AvroSubjectNameStrategy = common.SubjectNameStrategyRecordName
+	require.Equal(t, "default.test.t_1-value", encoder.getSubject("topic", tableName, valueSchemaSuffix))
+	require.Equal(t, "default.test.t_1-key", encoder.getSubject("topic", tableName, keySchemaSuffix))
+
+	codecConfig.AvroSubjectNameStrategy = common.SubjectNameStrategyTopicRecordName
+	require.Equal(t, "topic-default.test.t_1-value", encoder.getSubject("topic", tableName, valueSchemaSuffix))
+	require.Equal(t, "topic-default.test.t_1-key", encoder.getSubject("topic", tableName, keySchemaSuffix))
+}
+
+func TestAvroSchemaCompatibilityCheck(t *testing.T) {
+	codecConfig := common.NewConfig(config.ProtocolAvro)
+	codecConfig.AvroSchemaCompatibilityCheck = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	encoder, err := SetupEncoderAndSchemaRegistry4Testing(ctx, codecConfig)
+	defer TeardownEncoderAndSchemaRegistry4Testing()
+	require.NoError(t, err)
+	testingRegistry.backward = true
+
+	newTableInfo := func(version uint64, cols ...*model.Column) *model.TableInfo {
+		cols = append([]*model.Column{{
+			Name: "id",
+			Type: mysql.TypeLonglong,
+			Flag: model.HandleKeyFlag | model.PrimaryKeyFlag,
+		}}, cols...)
+		tableInfo := model.BuildTableInfo("test", "t", cols, [][]int{{0}})
+		tableInfo.Version = version
+		return tableInfo
+	}
+	newRow := func(tableInfo *model.TableInfo) *model.RowChangedEvent {
+		cols := make([]*model.Column, 0, len(tableInfo.Columns))
+		for _, col := range tableInfo.Columns {
+			cols = append(cols, &model.Column{Name: col.Name.O, Value: int64(1)})
+		}
+		return &model.RowChangedEvent{
+			CommitTs:  tableInfo.Version,
+			TableInfo: tableInfo,
+			Columns:   model.Columns2ColumnDatas(cols, tableInfo),
+		}
+	}
+	newDDL := func(tableInfo *model.TableInfo, query string) *model.DDLEvent {
+		return &model.DDLEvent{
+			CommitTs:  tableInfo.Version,
+			Type:      timodel.ActionAddColumn,
+			Query:     query,
+			TableInfo: tableInfo,
+		}
+	}
+
+	// the subject does not exist, so the schema is compatible.
+	v1 := newTableInfo(1)
+	require.NoError(t, encoder.CheckDDLSchema(ctx, "topic", newDDL(v1, "create table t(id bigint primary key)")))
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "topic", newRow(v1), nil))
+
+	// a nullable column has the default value, it's backward compatible.
+	v2 := newTableInfo(2, &model.Column{Name: "a", Type: mysql.TypeLonglong, Flag: model.NullableFlag})
+	require.NoError(t, encoder.CheckDDLSchema(ctx, "topic", newDDL(v2, "alter table t add column a bigint")))
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "topic", newRow(v2), nil))
+
+	// a not null column without default value is incompatible.
+	v3 := newTableInfo(3,
+		&model.Column{Name: "a", Type: mysql.TypeLonglong, Flag: model.NullableFlag},
+		&model.Column{Name: "b", Type: mysql.TypeLonglong})
+	ddl := newDDL(v3, "alter table t add column b bigint not null")
+	err = encoder.CheckDDLSchema(ctx, "topic", ddl)
+	require.True(t, cerror.ErrAvroSchemaIncompatible.Equal(err))
+	require.ErrorContains(t, err, "topic-value")
+	err = encoder.AppendRowChangedEvent(ctx, "topic", newRow(v3), nil)
+	require.True(t, cerror.ErrAvroSchemaIncompatible.Equal(err))
+
+	// the incompatible schema is registered to a new subject.
+	codecConfig.AvroIncompatibleSchemaFallback = common.IncompatibleSchemaFallbackNewSubject
+	require.NoError(t, encoder.CheckDDLSchema(ctx, "topic", ddl))
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "topic", newRow(v3), nil))
+	require.NoError(t, encoder.AppendRowChangedEvent(ctx, "topic", newRow(v3), nil))
+	require.Contains(t, testingRegistry.subjects, "topic-value-v3")
+	require.Equal(t, uint64(3), encoder.incompatibleSubjects["topic-value"])
+	require.Len(t, encoder.Build(), 4)
+
+	// the check is skipped if it's disabled.
+	codecConfig.AvroSchemaCompatibilityCheck = false
+	codecConfig.AvroIncompatibleSchemaFallback = common.IncompatibleSchemaFallbackError
+	require.NoError(t, encoder.CheckDDLSchema(ctx, "topic", ddl))
+}
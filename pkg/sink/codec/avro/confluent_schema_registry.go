@@ -64,6 +64,10 @@ type registerResponse struct {
 	SchemaID int `json:"id"`
 }
 
+type compatibilityResponse struct {
+	IsCompatible bool `json:"is_compatible"`
+}
+
 type lookupResponse struct {
 	Name     string `json:"name"`
 	SchemaID int    `json:"id"`
@@ -124,19 +128,9 @@ func (m *confluentSchemaManager) Register(
 	id := schemaID{}
 	log.Info("confluentSchemaManager", zap.String("schemaDefinition", schemaDefinition), zap.String("schemaName", schemaName))
 
-	buffer := new(bytes.Buffer)
-	err := json.Compact(buffer, []byte(schemaDefinition))
-	if err != nil {
-		log.Error("Could not compact schema", zap.Error(err))
-		return id, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
-	}
-	reqBody := registerRequest{
-		Schema: buffer.String(),
-	}
-	payload, err := json.Marshal(&reqBody)
+	payload, err := newRegisterPayload(schemaDefinition)
 	if err != nil {
-		log.Error("Could not marshal request to the Registry", zap.Error(err))
-		return id, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+		return id, err
 	}
 	uri := m.registryURL + "/subjects/" + url.QueryEscape(schemaName) + "/versions"
 	log.Info("Registering schema", zap.String("uri", uri), zap.ByteString("payload", payload))
@@ -164,10 +158,18 @@ func (m *confluentSchemaManager) Register(
 		return id, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
 	}
 
-	if resp.StatusCode != 200 {
+	if resp.StatusCode == http.StatusConflict {
 		// https://docs.confluent.io/platform/current/schema-registry/develop/api.html \
 		// #post--subjects-(string-%20subject)-versions
 		// 409 for incompatible schema
+		log.Error("The schema is incompatible with the Registry",
+			zap.String("uri", uri),
+			zap.ByteString("requestBody", payload),
+			zap.ByteString("responseBody", body))
+		return id, cerror.ErrAvroSchemaIncompatible.GenWithStackByArgs(schemaName, string(body))
+	}
+
+	if resp.StatusCode != 200 {
 		log.Error(
 			"Failed to register schema to the Registry, HTTP error",
 			zap.Int("status", resp.StatusCode),
@@ -201,6 +203,86 @@ func (m *confluentSchemaManager) Register(
 	return id, nil
 }
 
+// CheckCompatibility tests the schema against the latest version of the subject,
+// the compatibility level configured in the Registry is applied.
+// The schema is compatible if the subject does not exist.
+func (m *confluentSchemaManager) CheckCompatibility(
+	ctx context.Context,
+	schemaName string,
+	schemaDefinition string,
+) (bool, error) {
+	payload, err := newRegisterPayload(schemaDefinition)
+	if err != nil {
+		return false, err
+	}
+	uri := m.registryURL + "/compatibility/subjects/" + url.QueryEscape(schemaName) + "/versions/latest"
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, bytes.NewReader(payload))
+	if err != nil {
+		log.Error("Failed to NewRequestWithContext", zap.Error(err))
+		return false, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	req.Header.Add(
+		"Accept",
+		"application/vnd.schemaregistry.v1+json, application/vnd.schemaregistry+json, "+
+			"application/json",
+	)
+	req.Header.Add("Content-Type", "application/vnd.schemaregistry.v1+json")
+	resp, err := httpRetry(ctx, m.credential, req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Error("Failed to read response from Registry", zap.Error(err))
+		return false, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		log.Info("Subject not found in Registry, skip the compatibility check",
+			zap.String("uri", uri))
+		return true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Error("Failed to check schema compatibility, HTTP error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("uri", uri),
+			zap.ByteString("responseBody", body))
+		return false, cerror.ErrAvroSchemaAPIError.GenWithStack(
+			"Failed to check schema compatibility, HTTP error %d", resp.StatusCode)
+	}
+
+	var jsonResp compatibilityResponse
+	if err := json.Unmarshal(body, &jsonResp); err != nil {
+		log.Error("Failed to parse result from Registry", zap.Error(err))
+		return false, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	log.Info("Checked schema compatibility",
+		zap.String("subject", schemaName),
+		zap.Bool("compatible", jsonResp.IsCompatible))
+	return jsonResp.IsCompatible, nil
+}
+
+// newRegisterPayload builds the request body of a schema,
+// the Schema Registry expects the JSON to be without newline characters.
+func newRegisterPayload(schemaDefinition string) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	err := json.Compact(buffer, []byte(schemaDefinition))
+	if err != nil {
+		log.Error("Could not compact schema", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	reqBody := registerRequest{
+		Schema: buffer.String(),
+	}
+	payload, err := json.Marshal(&reqBody)
+	if err != nil {
+		log.Error("Could not marshal request to the Registry", zap.Error(err))
+		return nil, cerror.WrapError(cerror.ErrAvroSchemaAPIError, err)
+	}
+	return payload, nil
+}
+
 // Lookup the cached schema entry first, if not found, fetch from the Registry server.
 func (m *confluentSchemaManager) Lookup(
 	ctx context.Context,
@@ -39,6 +39,7 @@ type decoder struct {
 
 	upstreamTiDB     *sql.DB
 	tableIDAllocator *common.FakeTableIDAllocator
+	checksumVerifier *common.ChecksumVerifier
 
 	schemaM SchemaManager
 
@@ -59,6 +60,7 @@ func NewDecoder(
 		schemaM:          schemaM,
 		upstreamTiDB:     db,
 		tableIDAllocator: common.NewFakeTableIDAllocator(),
+		checksumVerifier: common.NewChecksumVerifier(config, config.Protocol.String(), db),
 	}
 }
 
@@ -162,7 +164,7 @@ func (d *decoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	}
 
 	if found {
-		if err = common.VerifyChecksum(event, d.upstreamTiDB); err != nil {
+		if err = d.checksumVerifier.Verify(event); err != nil {
 			return nil, errors.Trace(err)
 		}
 	}
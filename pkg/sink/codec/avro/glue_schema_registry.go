@@ -250,6 +250,14 @@ func (m *glueSchemaManager) ClearRegistry(ctx context.Context, schemaSubject str
 	return nil
 }
 
+// CheckCompatibility implements SchemaManager. The schemas are created with
+// the NONE compatibility, so any new schema is compatible.
+func (m *glueSchemaManager) CheckCompatibility(
+	ctx context.Context, schemaName string, schemaDefinition string,
+) (bool, error) {
+	return true, nil
+}
+
 func (m *glueSchemaManager) RegistryType() string {
 	return m.registryType
 }
@@ -32,15 +32,48 @@ type mockRegistry struct {
 	mu       sync.Mutex
 	subjects map[string]*mockConfluentRegistrySchema
 	newID    int
+	// backward checks the BACKWARD compatibility of the new schemas,
+	// all schemas are compatible if it's false.
+	backward bool
 }
 
+// isCompatible checks whether the new schema can read the data written by the
+// old schema, the fields added by the new schema must have default values.
+func (r *mockRegistry) isCompatible(oldSchema, newSchema string) bool {
+	if !r.backward {
+		return true
+	}
+	var oldTop, newTop avroSchemaTop
+	if json.Unmarshal([]byte(oldSchema), &oldTop) != nil ||
+		json.Unmarshal([]byte(newSchema), &newTop) != nil {
+		return false
+	}
+	oldFields := make(map[interface{}]struct{}, len(oldTop.Fields))
+	for _, field := range oldTop.Fields {
+		oldFields[field["name"]] = struct{}{}
+	}
+	for _, field := range newTop.Fields {
+		if _, ok := oldFields[field["name"]]; ok {
+			continue
+		}
+		if _, ok := field["default"]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// testingRegistry is the registry started by startHTTPInterceptForTestingRegistry.
+var testingRegistry *mockRegistry
+
 func startHTTPInterceptForTestingRegistry() {
 	httpmock.Activate()
 
-	registry := mockRegistry{
+	registry := &mockRegistry{
 		subjects: make(map[string]*mockConfluentRegistrySchema),
 		newID:    1,
 	}
+	testingRegistry = registry
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -78,6 +111,10 @@ func startHTTPInterceptForTestingRegistry() {
 			} else {
 				if item.content == reqData.Schema {
 					respData.SchemaID = item.ID
+				} else if !registry.isCompatible(item.content, reqData.Schema) {
+					registry.mu.Unlock()
+					return httpmock.NewStringResponse(409,
+						`{"error_code":409,"message":"Schema being registered is incompatible"}`), nil
 				} else {
 					item.content = reqData.Schema
 					item.version++
@@ -90,6 +127,34 @@ func startHTTPInterceptForTestingRegistry() {
 			return httpmock.NewJsonResponse(200, &respData)
 		})
 
+	httpmock.RegisterResponder("POST", `=~^http://127.0.0.1:8081/compatibility/subjects/(.+)/versions/latest`,
+		func(req *http.Request) (*http.Response, error) {
+			subject, err := httpmock.GetSubmatch(req, 1)
+			if err != nil {
+				return nil, err
+			}
+			reqBody, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			var reqData registerRequest
+			err = json.Unmarshal(reqBody, &reqData)
+			if err != nil {
+				return nil, err
+			}
+
+			registry.mu.Lock()
+			defer registry.mu.Unlock()
+			item, exists := registry.subjects[subject]
+			if !exists {
+				return httpmock.NewStringResponse(404,
+					`{"error_code":40401,"message":"Subject not found"}`), nil
+			}
+			return httpmock.NewJsonResponse(200, &compatibilityResponse{
+				IsCompatible: registry.isCompatible(item.content, reqData.Schema),
+			})
+		})
+
 	httpmock.RegisterResponder("GET", `=~^http://127.0.0.1:8081/schemas/ids/(.+)`,
 		func(req *http.Request) (*http.Response, error) {
 			id, err := httpmock.GetSubmatchAsInt(req, 1)
@@ -27,6 +27,9 @@ type SchemaManager interface {
 		tableVersion uint64, schemaGen SchemaGenerator) (*goavro.Codec, []byte, error)
 	RegistryType() string
 	ClearRegistry(ctx context.Context, schemaName string) error
+	// CheckCompatibility checks whether the schema is compatible with the latest
+	// schema of the subject, under the compatibility level of the registry.
+	CheckCompatibility(ctx context.Context, schemaName string, schemaDefinition string) (bool, error)
 }
 
 // SchemaGenerator represents a function that returns an Avro schema in JSON.
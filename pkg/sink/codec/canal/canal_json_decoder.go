@@ -99,6 +99,7 @@ type batchDecoder struct {
 	partitionInfoCache map[tableKey]*timodel.PartitionInfo
 
 	tableIDAllocator *common.FakeTableIDAllocator
+	checksumVerifier *common.ChecksumVerifier
 }
 
 // NewBatchDecoder return a decoder for canal-json
@@ -131,6 +132,7 @@ func NewBatchDecoder(
 		tableInfoCache:     make(map[tableKey]*model.TableInfo),
 		partitionInfoCache: make(map[tableKey]*timodel.PartitionInfo),
 		tableIDAllocator:   common.NewFakeTableIDAllocator(),
+		checksumVerifier:   common.NewChecksumVerifier(codecConfig, codecConfig.Protocol.String(), db),
 	}, nil
 }
 
@@ -365,6 +367,14 @@ func (b *batchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the decoded values have the same golang type as the mounter's,
+	// so no conversion is required to calculate the checksum.
+	if withExtension && b.config.EnableRowChecksum {
+		err = b.checksumVerifier.VerifyColumns(result, message.Extensions.Checksum, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return result, nil
 }
 
@@ -256,6 +256,15 @@ func newJSONMessageForDML(
 		out.RawString("\"commitTs\":")
 		out.Uint64(e.CommitTs)
 
+		if config.EnableRowChecksum && e.Checksum != nil {
+			checksum, err := json.Marshal(common.NewRowChecksum(e))
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrCanalEncodeFailed, err)
+			}
+			out.RawString(",\"checksum\":")
+			out.Raw(checksum, nil)
+		}
+
 		// only send handle key may happen in 2 cases:
 		// 1. delete event, and set only handle key config. no need to encode `onlyHandleKey` field
 		// 2. event larger than the max message size, and enable large message handle to the `handleKeyOnly`, encode `onlyHandleKey` field
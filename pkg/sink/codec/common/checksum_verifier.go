@@ -0,0 +1,304 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"database/sql"
+	"hash/crc32"
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"go.uber.org/zap"
+)
+
+// RowChecksum is the row level checksum carried by the protocols which encode
+// the columns by name, such as canal-json, open-protocol and debezium.
+type RowChecksum struct {
+	Version   int    `json:"version"`
+	Corrupted bool   `json:"corrupted"`
+	Current   uint32 `json:"current"`
+	Previous  uint32 `json:"previous"`
+	// Columns is the name of the columns ordered by the column ID,
+	// it's the order to calculate the checksum.
+	Columns []string `json:"columns"`
+}
+
+// NewRowChecksum returns the checksum of the event, nil if the event has no checksum.
+func NewRowChecksum(e *model.RowChangedEvent) *RowChecksum {
+	if e.Checksum == nil {
+		return nil
+	}
+	colInfos := e.TableInfo.GetColInfosForRowChangedEvent()
+	ids := make([]int64, 0, len(colInfos))
+	for _, colInfo := range colInfos {
+		ids = append(ids, colInfo.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	columns := make([]string, 0, len(ids))
+	for _, id := range ids {
+		columns = append(columns, e.TableInfo.ForceGetColumnName(id))
+	}
+	return &RowChecksum{
+		Version:   e.Checksum.Version,
+		Corrupted: e.Checksum.Corrupted,
+		Current:   e.Checksum.Current,
+		Previous:  e.Checksum.Previous,
+		Columns:   columns,
+	}
+}
+
+// ToChecksum returns the checksum set to the decoded event.
+func (c *RowChecksum) ToChecksum() *integrity.Checksum {
+	return &integrity.Checksum{
+		Version:   c.Version,
+		Corrupted: c.Corrupted,
+		Current:   c.Current,
+		Previous:  c.Previous,
+	}
+}
+
+// ChecksumValueConverter converts the decoded value of the column to the golang
+// type used to calculate the checksum, see buildChecksumBytes for the detail.
+// It returns false if the original value cannot be rebuilt from the message,
+// the verification of the row is skipped then.
+type ChecksumValueConverter func(colInfo *timodel.ColumnInfo, value interface{}) (interface{}, bool)
+
+// ChecksumVerifier verifies the row level checksum of the decoded events,
+// the mismatched rows are reported to the metrics and the corruption log.
+type ChecksumVerifier struct {
+	db       *sql.DB
+	location string
+	reporter *integrity.CorruptionReporter
+}
+
+// NewChecksumVerifier creates a ChecksumVerifier, source is the protocol of the decoder.
+// The upstream TiDB is queried to verify the checksum again if db is not nil.
+func NewChecksumVerifier(config *Config, source string, db *sql.DB) *ChecksumVerifier {
+	integrityConfig := config.Integrity
+	if integrityConfig == nil {
+		// keep the decoder failed on the mismatch if the integrity is not configured.
+		integrityConfig = &integrity.Config{
+			IntegrityCheckLevel:   integrity.CheckLevelCorrectness,
+			CorruptionHandleLevel: integrity.CorruptionHandleLevelError,
+		}
+	}
+	location := "Local"
+	if config.TimeZone != nil {
+		location = config.TimeZone.String()
+	}
+	return &ChecksumVerifier{
+		db:       db,
+		location: location,
+		reporter: integrity.NewCorruptionReporter(
+			config.ChangefeedID.Namespace, config.ChangefeedID.ID, source, integrityConfig),
+	}
+}
+
+// NewRowChecksumVerifier creates a ChecksumVerifier for the events sent by the mounter,
+// the timestamp values of the events are formatted in the location.
+func NewRowChecksumVerifier(reporter *integrity.CorruptionReporter, location string) *ChecksumVerifier {
+	return &ChecksumVerifier{
+		location: location,
+		reporter: reporter,
+	}
+}
+
+// VerifyRow verifies the checksum of the event sent by the mounter before it's
+// written to the downstream.
+func (v *ChecksumVerifier) VerifyRow(event *model.RowChangedEvent) error {
+	return v.VerifyColumns(event, NewRowChecksum(event), mounterValue)
+}
+
+// Verify verifies the checksum of the event decoded by the protocols which keep
+// the columns ordered by the column ID, such as avro and simple.
+func (v *ChecksumVerifier) Verify(event *model.RowChangedEvent) error {
+	if event.Checksum == nil {
+		return nil
+	}
+	if event.Checksum.Corrupted {
+		return v.report(event, event.Columns, false, event.Checksum.Current, 0,
+			"the row is marked as corrupted by the upstream")
+	}
+	// if expected is 0, it means the checksum is not enabled, so we don't need to verify it.
+	if expected := event.Checksum.Current; expected != 0 {
+		actual, err := calculateChecksum(event.Columns, event.TableInfo.Columns)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if actual != expected {
+			return v.report(event, event.Columns, false, expected, actual, "current checksum mismatch")
+		}
+	}
+	if expected := event.Checksum.Previous; expected != 0 {
+		actual, err := calculateChecksum(event.PreColumns, event.TableInfo.Columns)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if actual != expected {
+			return v.report(event, event.PreColumns, true, expected, actual, "previous checksum mismatch")
+		}
+	}
+	return v.queryUpstream(event)
+}
+
+// VerifyColumns verifies the checksum of the event decoded by the protocols which
+// encode the columns by name, the columns are ordered by checksum.Columns.
+// The verification is skipped if any column is absent, such as the message
+// only contains the handle key columns.
+func (v *ChecksumVerifier) VerifyColumns(
+	event *model.RowChangedEvent, checksum *RowChecksum, convert ChecksumValueConverter,
+) error {
+	if checksum == nil {
+		return nil
+	}
+	event.Checksum = checksum.ToChecksum()
+	if checksum.Corrupted {
+		return v.report(event, event.Columns, false, checksum.Current, 0,
+			"the row is marked as corrupted by the upstream")
+	}
+
+	nameToColInfo := make(map[string]*timodel.ColumnInfo, len(event.TableInfo.Columns))
+	for _, colInfo := range event.TableInfo.Columns {
+		nameToColInfo[colInfo.Name.O] = colInfo
+	}
+	colInfos := make([]*timodel.ColumnInfo, 0, len(checksum.Columns))
+	for _, name := range checksum.Columns {
+		colInfo, ok := nameToColInfo[name]
+		if !ok {
+			log.Debug("skip the checksum verification since the column not found",
+				zap.String("schema", event.TableInfo.GetSchemaName()),
+				zap.String("table", event.TableInfo.GetTableName()),
+				zap.String("column", name))
+			return nil
+		}
+		colInfos = append(colInfos, colInfo)
+	}
+
+	if expected := checksum.Current; expected != 0 && len(event.Columns) != 0 {
+		actual, ok, err := v.calculateByColumnInfos(event.Columns, colInfos, convert)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok && actual != expected {
+			return v.report(event, event.Columns, false, expected, actual, "current checksum mismatch")
+		}
+	}
+	if expected := checksum.Previous; expected != 0 && len(event.PreColumns) != 0 {
+		actual, ok, err := v.calculateByColumnInfos(event.PreColumns, colInfos, convert)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok && actual != expected {
+			return v.report(event, event.PreColumns, true, expected, actual, "previous checksum mismatch")
+		}
+	}
+	return v.queryUpstream(event)
+}
+
+// Close releases the resources of the verifier.
+func (v *ChecksumVerifier) Close() {
+	v.reporter.Close()
+}
+
+// calculateByColumnInfos calculates the checksum of the columns in the order of colInfos,
+// it returns false if any column is absent or cannot be rebuilt.
+func (v *ChecksumVerifier) calculateByColumnInfos(
+	columns []*model.ColumnData, colInfos []*timodel.ColumnInfo, convert ChecksumValueConverter,
+) (uint32, bool, error) {
+	values := make(map[int64]interface{}, len(columns))
+	for _, col := range columns {
+		if col != nil {
+			values[col.ColumnID] = col.Value
+		}
+	}
+
+	var (
+		checksum uint32
+		err      error
+	)
+	buf := make([]byte, 0)
+	for _, colInfo := range colInfos {
+		value, ok := values[colInfo.ID]
+		if !ok {
+			return 0, false, nil
+		}
+		if convert != nil && value != nil {
+			value, ok = convert(colInfo, value)
+			if !ok {
+				log.Debug("skip the checksum verification since the value cannot be rebuilt",
+					zap.String("column", colInfo.Name.O))
+				return 0, false, nil
+			}
+		}
+		// the timestamp is encoded in the time zone of the changefeed.
+		if s, ok := value.(string); ok && colInfo.GetType() == mysql.TypeTimestamp {
+			value = map[string]interface{}{"value": s, "location": v.location}
+		}
+		buf, err = buildChecksumBytes(buf[:0], value, colInfo.GetType())
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		checksum = crc32.Update(checksum, crc32.IEEETable, buf)
+	}
+	return checksum, true, nil
+}
+
+// mounterValue converts the value decoded by the mounter to the type used by buildChecksumBytes.
+func mounterValue(_ *timodel.ColumnInfo, value interface{}) (interface{}, bool) {
+	if vector, ok := value.(types.VectorFloat32); ok {
+		return vector.String(), true
+	}
+	return value, true
+}
+
+func (v *ChecksumVerifier) queryUpstream(event *model.RowChangedEvent) error {
+	if v.db == nil {
+		return nil
+	}
+	// also query the upstream TiDB to get the columns-level checksum
+	return queryRowChecksum(context.Background(), v.db, event)
+}
+
+func (v *ChecksumVerifier) report(
+	event *model.RowChangedEvent, columns []*model.ColumnData,
+	preRow bool, expected, actual uint32, reason string,
+) error {
+	values := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		if col == nil {
+			continue
+		}
+		if colInfo, ok := event.TableInfo.GetColumnInfo(col.ColumnID); ok {
+			values[colInfo.Name.O] = col.Value
+		}
+	}
+	event.Checksum.Corrupted = true
+	return v.reporter.Report(&integrity.CorruptionRecord{
+		Schema:   event.TableInfo.GetSchemaName(),
+		Table:    event.TableInfo.GetTableName(),
+		CommitTs: event.CommitTs,
+		PreRow:   preRow,
+		Expected: expected,
+		Actual:   actual,
+		Reason:   reason,
+		Columns:  values,
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/stretchr/testify/require"
+)
+
+func newChecksumTestEvent(t *testing.T) *model.RowChangedEvent {
+	tableInfo := model.BuildTableInfo("test", "t", []*model.Column{
+		{Name: "id", Type: mysql.TypeLonglong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+		{Name: "name", Type: mysql.TypeVarchar},
+	}, [][]int{{0}})
+	event := &model.RowChangedEvent{
+		TableInfo: tableInfo,
+		CommitTs:  100,
+		Columns: model.Columns2ColumnDatas([]*model.Column{
+			{Name: "id", Value: int64(1)},
+			{Name: "name", Value: []byte("tidb")},
+		}, tableInfo),
+	}
+
+	verifier := NewRowChecksumVerifier(nil, "UTC")
+	checksum := &RowChecksum{Columns: []string{"id", "name"}}
+	current, ok, err := verifier.calculateByColumnInfos(event.Columns, event.TableInfo.Columns, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	checksum.Current = current
+	event.Checksum = checksum.ToChecksum()
+	return event
+}
+
+func TestNewRowChecksum(t *testing.T) {
+	t.Parallel()
+
+	event := newChecksumTestEvent(t)
+	checksum := NewRowChecksum(event)
+	require.Equal(t, []string{"id", "name"}, checksum.Columns)
+	require.Equal(t, event.Checksum, checksum.ToChecksum())
+
+	event.Checksum = nil
+	require.Nil(t, NewRowChecksum(event))
+}
+
+func TestChecksumVerifierVerifyColumns(t *testing.T) {
+	t.Parallel()
+
+	codecConfig := NewConfig(config.ProtocolCanalJSON)
+	codecConfig.ChangefeedID = model.DefaultChangeFeedID("checksum-verifier-test")
+	codecConfig.Integrity = &integrity.Config{
+		IntegrityCheckLevel:   integrity.CheckLevelCorrectness,
+		CorruptionHandleLevel: integrity.CorruptionHandleLevelWarn,
+		CorruptionLogDir:      t.TempDir(),
+	}
+	verifier := NewChecksumVerifier(codecConfig, "canal-json", nil)
+	defer verifier.Close()
+
+	event := newChecksumTestEvent(t)
+	checksum := NewRowChecksum(event)
+	require.NoError(t, verifier.VerifyColumns(event, checksum, nil))
+	require.False(t, event.Checksum.Corrupted)
+
+	// the mismatch is only reported if the corruption handle level is warn.
+	checksum.Current++
+	require.NoError(t, verifier.VerifyColumns(event, checksum, nil))
+	require.True(t, event.Checksum.Corrupted)
+
+	// the verification is skipped if any column is absent.
+	event = newChecksumTestEvent(t)
+	checksum = NewRowChecksum(event)
+	checksum.Current++
+	checksum.Columns = append(checksum.Columns, "absent")
+	require.NoError(t, verifier.VerifyColumns(event, checksum, nil))
+	require.False(t, event.Checksum.Corrupted)
+
+	// the decoder fails on the mismatch if the integrity is not configured.
+	codecConfig.Integrity = nil
+	verifier = NewChecksumVerifier(codecConfig, "canal-json", nil)
+	defer verifier.Close()
+	event = newChecksumTestEvent(t)
+	checksum = NewRowChecksum(event)
+	checksum.Current++
+	err := verifier.VerifyColumns(event, checksum, nil)
+	require.True(t, cerror.ErrCorruptedDataMutation.Equal(err))
+}
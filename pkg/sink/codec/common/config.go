@@ -25,6 +25,8 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
@@ -49,12 +51,21 @@ type Config struct {
 
 	EnableTiDBExtension bool
 	EnableRowChecksum   bool
+	// Integrity decides how the checksum mismatch found by the decoders is handled.
+	Integrity *integrity.Config
 
 	// avro only
 	AvroConfluentSchemaRegistry    string
 	AvroDecimalHandlingMode        string
 	AvroBigintUnsignedHandlingMode string
 	AvroGlueSchemaRegistry         *config.GlueSchemaRegistryConfig
+	// AvroSubjectNameStrategy decides the subject of the key and value schemas.
+	AvroSubjectNameStrategy string
+	// AvroSchemaCompatibilityCheck checks the compatibility of the new schema
+	// of a table against the schema registry before the DDL is applied.
+	AvroSchemaCompatibilityCheck bool
+	// AvroIncompatibleSchemaFallback decides what to do with an incompatible schema.
+	AvroIncompatibleSchemaFallback string
 	// EnableWatermarkEvent set to true, avro encode DDL and checkpoint event
 	// and send to the downstream kafka, they cannot be consumed by the confluent official consumer
 	// and would cause error, so this is only used for ticdc internal testing purpose, should not be
@@ -81,6 +92,10 @@ type Config struct {
 
 	// for the simple protocol, can be "json" and "avro", default to "json"
 	EncodingFormat EncodingFormatType
+	// SchemaHistory is the schema history exported from the upstream, the simple
+	// protocol decoder uses it to decode the messages of which the table schema
+	// is not received, such as the schema is sent before the consuming position.
+	SchemaHistory *schemahistory.History
 
 	// Currently only Debezium protocol is aware of the time zone
 	TimeZone *time.Location
@@ -89,6 +104,12 @@ type Config struct {
 	DebeziumDisableSchema bool
 	// Debezium only. Whether before value should be included in the output.
 	DebeziumOutputOldValue bool
+	// Debezium only. The topic of the DDL events, empty means the DDL events
+	// are dispatched by the topic dispatchers.
+	DebeziumSchemaChangeTopic string
+	// Debezium only. The topic of the transaction metadata events, empty means
+	// the transaction metadata is disabled.
+	DebeziumTransactionTopic string
 }
 
 // EncodingFormatType is the type of encoding format
@@ -116,6 +137,8 @@ func NewConfig(protocol config.Protocol) *Config {
 		AvroDecimalHandlingMode:        "precise",
 		AvroBigintUnsignedHandlingMode: "long",
 		AvroEnableWatermark:            false,
+		AvroSubjectNameStrategy:        SubjectNameStrategyTopicName,
+		AvroIncompatibleSchemaFallback: IncompatibleSchemaFallbackError,
 
 		OnlyOutputUpdatedColumns:   false,
 		DeleteOnlyHandleKeyColumns: false,
@@ -138,6 +161,8 @@ const (
 	codecOPTAvroBigintUnsignedHandlingMode = "avro-bigint-unsigned-handling-mode"
 	codecOPTAvroSchemaRegistry             = "schema-registry"
 	coderOPTAvroGlueSchemaRegistry         = "glue-schema-registry"
+	codecOPTAvroSubjectNameStrategy        = "avro-subject-name-strategy"
+	codecOPTAvroIncompatibleSchemaFallback = "avro-incompatible-schema-fallback"
 )
 
 const (
@@ -149,6 +174,20 @@ const (
 	BigintUnsignedHandlingModeString = "string"
 	// BigintUnsignedHandlingModeLong is the long mode for unsigned bigint handling
 	BigintUnsignedHandlingModeLong = "long"
+
+	// SubjectNameStrategyTopicName names the subject by the topic, it's the default strategy.
+	SubjectNameStrategyTopicName = "topic-name"
+	// SubjectNameStrategyRecordName names the subject by the fully-qualified record name.
+	SubjectNameStrategyRecordName = "record-name"
+	// SubjectNameStrategyTopicRecordName names the subject by the topic and
+	// the fully-qualified record name.
+	SubjectNameStrategyTopicRecordName = "topic-record-name"
+
+	// IncompatibleSchemaFallbackError returns an error on an incompatible schema.
+	IncompatibleSchemaFallbackError = "error"
+	// IncompatibleSchemaFallbackNewSubject registers an incompatible schema
+	// to a new subject suffixed with the table version.
+	IncompatibleSchemaFallbackNewSubject = "new-subject"
 )
 
 type urlConfig struct {
@@ -157,6 +196,9 @@ type urlConfig struct {
 	MaxMessageBytes                *int    `form:"max-message-bytes"`
 	AvroDecimalHandlingMode        *string `form:"avro-decimal-handling-mode"`
 	AvroBigintUnsignedHandlingMode *string `form:"avro-bigint-unsigned-handling-mode"`
+	AvroSubjectNameStrategy        *string `form:"avro-subject-name-strategy"`
+	AvroSchemaCompatibilityCheck   *bool   `form:"avro-schema-compatibility-check"`
+	AvroIncompatibleSchemaFallback *string `form:"avro-incompatible-schema-fallback"`
 
 	// AvroEnableWatermark is the option for enabling watermark in avro protocol
 	// only used for internal testing, do not set this in the production environment since the
@@ -206,6 +248,17 @@ func (c *Config) Apply(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) er
 		*urlParameter.AvroBigintUnsignedHandlingMode != "" {
 		c.AvroBigintUnsignedHandlingMode = *urlParameter.AvroBigintUnsignedHandlingMode
 	}
+	if urlParameter.AvroSubjectNameStrategy != nil &&
+		*urlParameter.AvroSubjectNameStrategy != "" {
+		c.AvroSubjectNameStrategy = *urlParameter.AvroSubjectNameStrategy
+	}
+	if urlParameter.AvroSchemaCompatibilityCheck != nil {
+		c.AvroSchemaCompatibilityCheck = *urlParameter.AvroSchemaCompatibilityCheck
+	}
+	if urlParameter.AvroIncompatibleSchemaFallback != nil &&
+		*urlParameter.AvroIncompatibleSchemaFallback != "" {
+		c.AvroIncompatibleSchemaFallback = *urlParameter.AvroIncompatibleSchemaFallback
+	}
 	if urlParameter.AvroEnableWatermark != nil {
 		if c.EnableTiDBExtension && c.Protocol == config.ProtocolAvro {
 			c.AvroEnableWatermark = *urlParameter.AvroEnableWatermark
@@ -247,6 +300,8 @@ func (c *Config) Apply(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) er
 		}
 		if replicaConfig.Sink.Debezium != nil {
 			c.DebeziumOutputOldValue = replicaConfig.Sink.Debezium.OutputOldValue
+			c.DebeziumSchemaChangeTopic = replicaConfig.Sink.Debezium.SchemaChangeTopic
+			c.DebeziumTransactionTopic = replicaConfig.Sink.Debezium.TransactionTopic
 		}
 	}
 	if urlParameter.OnlyOutputUpdatedColumns != nil {
@@ -255,6 +310,7 @@ func (c *Config) Apply(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) er
 
 	if replicaConfig.Integrity != nil {
 		c.EnableRowChecksum = replicaConfig.Integrity.Enabled()
+		c.Integrity = replicaConfig.Integrity
 	}
 
 	c.DeleteOnlyHandleKeyColumns = util.GetOrZero(replicaConfig.Sink.DeleteOnlyOutputHandleKeyColumns)
@@ -311,6 +367,9 @@ func mergeConfig(
 				dest.AvroEnableWatermark = codecConfig.AvroEnableWatermark
 				dest.AvroDecimalHandlingMode = codecConfig.AvroDecimalHandlingMode
 				dest.AvroBigintUnsignedHandlingMode = codecConfig.AvroBigintUnsignedHandlingMode
+				dest.AvroSubjectNameStrategy = codecConfig.AvroSubjectNameStrategy
+				dest.AvroSchemaCompatibilityCheck = codecConfig.AvroSchemaCompatibilityCheck
+				dest.AvroIncompatibleSchemaFallback = codecConfig.AvroIncompatibleSchemaFallback
 				dest.EncodingFormatType = codecConfig.EncodingFormat
 			}
 		}
@@ -383,6 +442,28 @@ func (c *Config) Validate() error {
 			)
 		}
 
+		switch c.AvroSubjectNameStrategy {
+		case SubjectNameStrategyTopicName, SubjectNameStrategyRecordName, SubjectNameStrategyTopicRecordName:
+		default:
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s value could only be "%s", "%s" or "%s"`,
+				codecOPTAvroSubjectNameStrategy,
+				SubjectNameStrategyTopicName,
+				SubjectNameStrategyRecordName,
+				SubjectNameStrategyTopicRecordName,
+			)
+		}
+
+		if c.AvroIncompatibleSchemaFallback != IncompatibleSchemaFallbackError &&
+			c.AvroIncompatibleSchemaFallback != IncompatibleSchemaFallbackNewSubject {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s value could only be "%s" or "%s"`,
+				codecOPTAvroIncompatibleSchemaFallback,
+				IncompatibleSchemaFallbackError,
+				IncompatibleSchemaFallbackNewSubject,
+			)
+		}
+
 		if c.EnableRowChecksum {
 			if !(c.EnableTiDBExtension && c.AvroDecimalHandlingMode == DecimalHandlingModeString &&
 				c.AvroBigintUnsignedHandlingMode == BigintUnsignedHandlingModeString) {
@@ -396,6 +477,25 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Protocol == config.ProtocolDebezium {
+		if c.DebeziumTransactionTopic != "" &&
+			c.DebeziumTransactionTopic == c.DebeziumSchemaChangeTopic {
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`Debezium protocol requires different topics for the schema change events and the transaction metadata events, but both are "%s"`,
+				c.DebeziumTransactionTopic)
+		}
+	}
+
+	// the checksum is carried by the TiDB extension fields.
+	if c.EnableRowChecksum && !c.EnableTiDBExtension {
+		switch c.Protocol {
+		case config.ProtocolCanalJSON, config.ProtocolDebezium:
+			return cerror.ErrCodecInvalidConfig.GenWithStack(
+				`%s protocol with row level checksum, should set "%s" to "true"`,
+				c.Protocol, codecOPTEnableTiDBExtension)
+		}
+	}
+
 	if c.MaxMessageBytes <= 0 {
 		return cerror.ErrCodecInvalidConfig.Wrap(
 			errors.Errorf("invalid max-message-bytes %d", c.MaxMessageBytes),
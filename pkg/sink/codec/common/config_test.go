@@ -40,6 +40,40 @@ func TestNewConfig(t *testing.T) {
 	require.NotNil(t, c.LargeMessageHandle)
 }
 
+func TestConfigApplyValidate4RowChecksumByName(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Integrity.IntegrityCheckLevel = integrity.CheckLevelCorrectness
+	replicaConfig.Integrity.CorruptionLogDir = "/tmp/corruption"
+
+	for _, tc := range []struct {
+		uri     string
+		invalid bool
+	}{
+		{uri: "kafka://127.0.0.1:9092/abc?protocol=canal-json&enable-tidb-extension=true"},
+		{uri: "kafka://127.0.0.1:9092/abc?protocol=canal-json", invalid: true},
+		{uri: "kafka://127.0.0.1:9092/abc?protocol=debezium&enable-tidb-extension=true"},
+		{uri: "kafka://127.0.0.1:9092/abc?protocol=debezium", invalid: true},
+		{uri: "kafka://127.0.0.1:9092/abc?protocol=open-protocol"},
+	} {
+		sinkURI, err := url.Parse(tc.uri)
+		require.NoError(t, err)
+		p, err := config.ParseSinkProtocolFromString(sinkURI.Query().Get("protocol"))
+		require.NoError(t, err)
+
+		c := NewConfig(p)
+		require.NoError(t, c.Apply(sinkURI, replicaConfig))
+		require.True(t, c.EnableRowChecksum)
+		require.Equal(t, "/tmp/corruption", c.Integrity.CorruptionLogDir)
+		if tc.invalid {
+			require.ErrorIs(t, c.Validate(), cerror.ErrCodecInvalidConfig)
+		} else {
+			require.NoError(t, c.Validate())
+		}
+	}
+}
+
 func TestConfigApplyValidate4EnableRowChecksum(t *testing.T) {
 	t.Parallel()
 
@@ -175,6 +209,82 @@ func TestAvroForceReplicate(t *testing.T) {
 	require.ErrorIs(t, err, cerror.ErrCodecInvalidConfig)
 }
 
+func TestAvroSchemaEvolutionConfig(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	uri := "kafka://127.0.0.1:9092/abc?protocol=avro&schema-registry=http://127.0.0.1:8081"
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+
+	codecConfig := NewConfig(config.ProtocolAvro)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.NoError(t, codecConfig.Validate())
+	require.Equal(t, SubjectNameStrategyTopicName, codecConfig.AvroSubjectNameStrategy)
+	require.False(t, codecConfig.AvroSchemaCompatibilityCheck)
+	require.Equal(t, IncompatibleSchemaFallbackError, codecConfig.AvroIncompatibleSchemaFallback)
+
+	replicaConfig.Sink.KafkaConfig = &config.KafkaConfig{
+		CodecConfig: &config.CodecConfig{
+			AvroSubjectNameStrategy:        util.AddressOf(SubjectNameStrategyRecordName),
+			AvroSchemaCompatibilityCheck:   util.AddressOf(true),
+			AvroIncompatibleSchemaFallback: util.AddressOf(IncompatibleSchemaFallbackNewSubject),
+		},
+	}
+	codecConfig = NewConfig(config.ProtocolAvro)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.NoError(t, codecConfig.Validate())
+	require.Equal(t, SubjectNameStrategyRecordName, codecConfig.AvroSubjectNameStrategy)
+	require.True(t, codecConfig.AvroSchemaCompatibilityCheck)
+	require.Equal(t, IncompatibleSchemaFallbackNewSubject, codecConfig.AvroIncompatibleSchemaFallback)
+
+	// the sink uri takes precedence over the replica config.
+	sinkURI, err = url.Parse(uri + "&avro-subject-name-strategy=topic-record-name")
+	require.NoError(t, err)
+	codecConfig = NewConfig(config.ProtocolAvro)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.Equal(t, SubjectNameStrategyTopicRecordName, codecConfig.AvroSubjectNameStrategy)
+
+	sinkURI, err = url.Parse(uri + "&avro-subject-name-strategy=table-name")
+	require.NoError(t, err)
+	codecConfig = NewConfig(config.ProtocolAvro)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.ErrorContains(t, codecConfig.Validate(), "avro-subject-name-strategy value could only be")
+
+	sinkURI, err = url.Parse(uri + "&avro-incompatible-schema-fallback=skip")
+	require.NoError(t, err)
+	codecConfig = NewConfig(config.ProtocolAvro)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.ErrorContains(t, codecConfig.Validate(), "avro-incompatible-schema-fallback value could only be")
+}
+
+func TestDebeziumTopicsConfig(t *testing.T) {
+	t.Parallel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/abc?protocol=debezium")
+	require.NoError(t, err)
+
+	codecConfig := NewConfig(config.ProtocolDebezium)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.NoError(t, codecConfig.Validate())
+	require.Empty(t, codecConfig.DebeziumSchemaChangeTopic)
+	require.Empty(t, codecConfig.DebeziumTransactionTopic)
+
+	replicaConfig.Sink.Debezium.SchemaChangeTopic = "schema_changes"
+	replicaConfig.Sink.Debezium.TransactionTopic = "transactions"
+	codecConfig = NewConfig(config.ProtocolDebezium)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.NoError(t, codecConfig.Validate())
+	require.Equal(t, "schema_changes", codecConfig.DebeziumSchemaChangeTopic)
+	require.Equal(t, "transactions", codecConfig.DebeziumTransactionTopic)
+
+	replicaConfig.Sink.Debezium.TransactionTopic = "schema_changes"
+	codecConfig = NewConfig(config.ProtocolDebezium)
+	require.NoError(t, codecConfig.Apply(sinkURI, replicaConfig))
+	require.ErrorContains(t, codecConfig.Validate(), "requires different topics")
+}
+
 func TestConfigApplyValidate(t *testing.T) {
 	t.Parallel()
 
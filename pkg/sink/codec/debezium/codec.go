@@ -70,6 +70,13 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 		if c.config.EnableTiDBExtension {
 			// The followings are TiDB extended fields
 			writer.WriteStringField("tidb_type", getTiDBType(ft))
+			// the fsp is required by the consumer to rebuild the value for the checksum.
+			if c.config.EnableRowChecksum {
+				switch col.GetType() {
+				case mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration:
+					writer.WriteIntField("tidb_fsp", ft.GetDecimal())
+				}
+			}
 		}
 		switch col.GetType() {
 		case mysql.TypeBit:
@@ -974,12 +981,15 @@ func (c *dbzCodec) EncodeValue(
 				// The followings are TiDB extended fields
 				jWriter.WriteUint64Field("commit_ts", e.CommitTs)
 				jWriter.WriteStringField("cluster_id", c.clusterID)
+				if c.config.EnableTiDBExtension && c.config.EnableRowChecksum && e.Checksum != nil {
+					jWriter.WriteAnyField("checksum", common.NewRowChecksum(e))
+				}
 			})
 
 			// ts_ms: displays the time at which the connector processed the event
 			// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-create-events
 			jWriter.WriteInt64Field("ts_ms", c.nowFunc().UnixMilli())
-			jWriter.WriteNullField("transaction")
+			c.writeTransactionBlock(jWriter, e)
 			if e.IsInsert() {
 				// op: Mandatory string that describes the type of operation that caused the connector to generate the event.
 				// Valid values are:
@@ -1648,3 +1658,143 @@ func (c *dbzCodec) EncodeCheckpointEvent(
 	})
 	return err
 }
+
+// getTxnID returns the id of the transaction in the transaction metadata.
+// The tables are replicated independently, so the changes of a transaction
+// are reported per table, and the table id makes the id unique.
+func getTxnID(startTs uint64, physicalTableID int64) string {
+	return fmt.Sprintf("%d:%d", startTs, physicalTableID)
+}
+
+// writeTransactionBlock writes the transaction block of the row changed event,
+// which links the event to the BEGIN and END events of the transaction metadata.
+func (c *dbzCodec) writeTransactionBlock(writer *util.JSONWriter, e *model.RowChangedEvent) {
+	if c.config.DebeziumTransactionTopic == "" || e.TxnEventOrder == 0 {
+		writer.WriteNullField("transaction")
+		return
+	}
+	writer.WriteObjectField("transaction", func() {
+		writer.WriteStringField("id", getTxnID(e.StartTs, e.GetTableID()))
+		// All the events of the transaction belong to one table,
+		// so the total order equals the order in the data collection.
+		writer.WriteInt64Field("total_order", e.TxnEventOrder)
+		writer.WriteInt64Field("data_collection_order", e.TxnEventOrder)
+	})
+}
+
+// EncodeTxnEvent encodes the BEGIN or END event of the transaction into the
+// debezium transaction metadata event.
+// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-transaction-metadata
+func (c *dbzCodec) EncodeTxnEvent(
+	txn *model.SingleTableTxn,
+	isBegin bool,
+	keyDest io.Writer,
+	dest io.Writer,
+) error {
+	keyJWriter := util.BorrowJSONWriter(keyDest)
+	jWriter := util.BorrowJSONWriter(dest)
+	defer util.ReturnJSONWriter(keyJWriter)
+	defer util.ReturnJSONWriter(jWriter)
+
+	id := getTxnID(txn.StartTs, txn.GetPhysicalTableID())
+	commitTime := oracle.GetTimeFromTS(txn.CommitTs)
+	// message key
+	keyJWriter.WriteObject(func() {
+		keyJWriter.WriteObjectField("payload", func() {
+			keyJWriter.WriteStringField("id", id)
+		})
+		if !c.config.DebeziumDisableSchema {
+			keyJWriter.WriteObjectField("schema", func() {
+				keyJWriter.WriteStringField("type", "struct")
+				keyJWriter.WriteStringField("name", "io.debezium.connector.common.TransactionMetadataKey")
+				keyJWriter.WriteBoolField("optional", false)
+				keyJWriter.WriteArrayField("fields", func() {
+					keyJWriter.WriteObjectElement(func() {
+						keyJWriter.WriteStringField("field", "id")
+						keyJWriter.WriteBoolField("optional", false)
+						keyJWriter.WriteStringField("type", "string")
+					})
+				})
+			})
+		}
+	})
+	// message value
+	jWriter.WriteObject(func() {
+		jWriter.WriteObjectField("payload", func() {
+			if isBegin {
+				jWriter.WriteStringField("status", "BEGIN")
+			} else {
+				jWriter.WriteStringField("status", "END")
+			}
+			jWriter.WriteStringField("id", id)
+			// ts_ms: the time that the transaction is committed in the database.
+			jWriter.WriteInt64Field("ts_ms", commitTime.UnixMilli())
+			// event_count and data_collections are only set in the END event.
+			if isBegin {
+				jWriter.WriteNullField("event_count")
+				jWriter.WriteNullField("data_collections")
+				return
+			}
+			eventCount := int64(len(txn.Rows))
+			jWriter.WriteInt64Field("event_count", eventCount)
+			jWriter.WriteArrayField("data_collections", func() {
+				jWriter.WriteObjectElement(func() {
+					jWriter.WriteStringField("data_collection", fmt.Sprintf("%s.%s",
+						txn.TableInfo.GetSchemaName(), txn.TableInfo.GetTableName()))
+					jWriter.WriteInt64Field("event_count", eventCount)
+				})
+			})
+		})
+		if !c.config.DebeziumDisableSchema {
+			jWriter.WriteObjectField("schema", func() {
+				jWriter.WriteStringField("type", "struct")
+				jWriter.WriteStringField("name", "io.debezium.connector.common.TransactionMetadataValue")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteArrayField("fields", func() {
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("field", "status")
+						jWriter.WriteBoolField("optional", false)
+						jWriter.WriteStringField("type", "string")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("field", "id")
+						jWriter.WriteBoolField("optional", false)
+						jWriter.WriteStringField("type", "string")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("field", "ts_ms")
+						jWriter.WriteBoolField("optional", false)
+						jWriter.WriteStringField("type", "int64")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("field", "event_count")
+						jWriter.WriteBoolField("optional", true)
+						jWriter.WriteStringField("type", "int64")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("field", "data_collections")
+						jWriter.WriteBoolField("optional", true)
+						jWriter.WriteStringField("type", "array")
+						jWriter.WriteObjectField("items", func() {
+							jWriter.WriteStringField("type", "struct")
+							jWriter.WriteBoolField("optional", false)
+							jWriter.WriteArrayField("fields", func() {
+								jWriter.WriteObjectElement(func() {
+									jWriter.WriteStringField("field", "data_collection")
+									jWriter.WriteBoolField("optional", false)
+									jWriter.WriteStringField("type", "string")
+								})
+								jWriter.WriteObjectElement(func() {
+									jWriter.WriteStringField("field", "event_count")
+									jWriter.WriteBoolField("optional", false)
+									jWriter.WriteStringField("type", "int64")
+								})
+							})
+						})
+					})
+				})
+			})
+		}
+	})
+	return nil
+}
@@ -712,6 +712,93 @@ func TestCheckPointEvent(t *testing.T) {
 	}`, buf.String())
 }
 
+func TestTxnEvent(t *testing.T) {
+	codec := &dbzCodec{
+		config:    common.NewConfig(config.ProtocolDebezium),
+		clusterID: "test_cluster",
+		nowFunc:   func() time.Time { return time.Unix(1701326309, 0) },
+	}
+	codec.config.DebeziumDisableSchema = true
+
+	tableInfo := model.BuildTableInfo("test", "table1", []*model.Column{{
+		Name: "id",
+		Type: mysql.TypeLong,
+		Flag: model.PrimaryKeyFlag | model.HandleKeyFlag,
+	}}, [][]int{{0}})
+	txn := &model.SingleTableTxn{
+		PhysicalTableID: 100,
+		TableInfo:       tableInfo,
+		StartTs:         446266400629063681,
+		CommitTs:        446266400629063682,
+		Rows:            []*model.RowChangedEvent{{}, {}},
+	}
+	keyBuf := bytes.NewBuffer(nil)
+	buf := bytes.NewBuffer(nil)
+	err := codec.EncodeTxnEvent(txn, true, keyBuf, buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `
+	{
+		"payload": {
+			"id": "446266400629063681:100"
+		}
+	}`, keyBuf.String())
+	require.JSONEq(t, `
+	{
+		"payload": {
+			"status": "BEGIN",
+			"id": "446266400629063681:100",
+			"ts_ms": 1702371218220,
+			"event_count": null,
+			"data_collections": null
+		}
+	}`, buf.String())
+
+	keyBuf.Reset()
+	buf.Reset()
+	err = codec.EncodeTxnEvent(txn, false, keyBuf, buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `
+	{
+		"payload": {
+			"status": "END",
+			"id": "446266400629063681:100",
+			"ts_ms": 1702371218220,
+			"event_count": 2,
+			"data_collections": [
+				{
+					"data_collection": "test.table1",
+					"event_count": 2
+				}
+			]
+		}
+	}`, buf.String())
+
+	// the row changed event refers to the transaction only if the
+	// transaction metadata is enabled.
+	e := &model.RowChangedEvent{
+		StartTs:         446266400629063681,
+		CommitTs:        446266400629063682,
+		PhysicalTableID: 100,
+		TableInfo:       tableInfo,
+		Columns: model.Columns2ColumnDatas([]*model.Column{{
+			Name:  "id",
+			Value: int64(1),
+		}}, tableInfo),
+		TxnEventOrder: 2,
+	}
+	buf.Reset()
+	err = codec.EncodeValue(e, buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `"transaction":null`)
+
+	codec.config.DebeziumTransactionTopic = "transactions"
+	buf.Reset()
+	err = codec.EncodeValue(e, buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(),
+		`"transaction":{"id":"446266400629063681:100","total_order":2,"data_collection_order":2}`)
+}
+
 func TestEncodeInsert(t *testing.T) {
 	codec := &dbzCodec{
 		config:    common.NewConfig(config.ProtocolDebezium),
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,12 +39,17 @@ import (
 	"go.uber.org/zap"
 )
 
+// goTimeStringLayout is the layout of time.Time.String(), the date and
+// datetime values are decoded in this layout.
+const goTimeStringLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
 // Decoder implement the RowEventDecoder interface
 type Decoder struct {
 	config *common.Config
 
 	upstreamTiDB     *sql.DB
 	tableIDAllocator *common.FakeTableIDAllocator
+	checksumVerifier *common.ChecksumVerifier
 
 	keyPayload   map[string]interface{}
 	keySchema    map[string]interface{}
@@ -60,6 +66,7 @@ func NewDecoder(
 		config:           config,
 		upstreamTiDB:     db,
 		tableIDAllocator: common.NewFakeTableIDAllocator(),
+		checksumVerifier: common.NewChecksumVerifier(config, config.Protocol.String(), db),
 	}
 }
 
@@ -160,9 +167,130 @@ func (d *Decoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 		event.Columns = assembleColumnData(after, tableInfo)
 	}
 	event.PhysicalTableID = d.tableIDAllocator.AllocateTableID(tableInfo.GetSchemaName(), tableInfo.GetTableName())
+	if d.config.EnableRowChecksum {
+		if err := d.verifyChecksum(event); err != nil {
+			return nil, err
+		}
+	}
 	return event, nil
 }
 
+// verifyChecksum verifies the checksum carried by the source block.
+func (d *Decoder) verifyChecksum(event *model.RowChangedEvent) error {
+	source := d.valuePayload["source"].(map[string]interface{})
+	raw, ok := source["checksum"]
+	if !ok || raw == nil {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	checksum := new(common.RowChecksum)
+	if err = json.Unmarshal(data, checksum); err != nil {
+		return errors.Trace(err)
+	}
+	// the null value is encoded as the default value of the column,
+	// so the original value cannot be told if it equals to the default value.
+	if d.hasDefaultValue("before") || d.hasDefaultValue("after") {
+		event.Checksum = checksum.ToChecksum()
+		return nil
+	}
+	return d.checksumVerifier.VerifyColumns(event, checksum, checksumValue)
+}
+
+// hasDefaultValue returns true if any column of the row equals to the default value.
+func (d *Decoder) hasDefaultValue(field string) bool {
+	row, ok := d.valuePayload[field].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	fields := d.valueSchema["fields"].([]interface{})
+	after := fields[1].(map[string]interface{})
+	for _, column := range after["fields"].([]interface{}) {
+		col := column.(map[string]interface{})
+		defaultValue, ok := col["default"]
+		if !ok {
+			continue
+		}
+		if value, ok := row[col["field"].(string)]; ok && value == defaultValue {
+			return true
+		}
+	}
+	return false
+}
+
+// checksumValue rebuilds the value used by the checksum calculation from the
+// decoded value, see buildChecksumBytes for the golang type of each mysql type.
+func checksumValue(colInfo *timodel.ColumnInfo, value interface{}) (interface{}, bool) {
+	ft := &colInfo.FieldType
+	switch ft.GetType() {
+	case mysql.TypeFloat:
+		v, ok := value.(json.Number)
+		if !ok {
+			return nil, false
+		}
+		f, err := strconv.ParseFloat(v.String(), 32)
+		if err != nil {
+			return nil, false
+		}
+		return float32(f), true
+	case mysql.TypeDouble:
+		v, ok := value.(json.Number)
+		if !ok {
+			return nil, false
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, false
+		}
+		return f, true
+	case mysql.TypeYear:
+		v, ok := value.(json.Number)
+		if !ok {
+			return nil, false
+		}
+		i, err := v.Int64()
+		if err != nil {
+			return nil, false
+		}
+		return i, true
+	case mysql.TypeDate, mysql.TypeNewDate:
+		t, err := time.Parse(goTimeStringLayout, value.(string))
+		if err != nil {
+			return nil, false
+		}
+		return t.Format("2006-01-02"), true
+	case mysql.TypeDatetime:
+		t, err := time.Parse(goTimeStringLayout, value.(string))
+		if err != nil {
+			return nil, false
+		}
+		return types.NewTime(types.FromGoTime(t), mysql.TypeDatetime, ft.GetDecimal()).String(), true
+	case mysql.TypeTimestamp:
+		// the timestamp is encoded as the ZonedTimestamp in UTC.
+		t, err := time.Parse(time.RFC3339Nano, value.(string))
+		if err != nil {
+			return nil, false
+		}
+		return map[string]interface{}{
+			"value":    types.NewTime(types.FromGoTime(t), mysql.TypeTimestamp, ft.GetDecimal()).String(),
+			"location": time.UTC.String(),
+		}, true
+	case mysql.TypeDuration:
+		v, _, err := types.ParseDuration(types.DefaultStmtNoWarningContext, value.(string), ft.GetDecimal())
+		if err != nil {
+			return nil, false
+		}
+		return v.String(), true
+	case mysql.TypeNewDecimal, mysql.TypeEnum, mysql.TypeSet:
+		// the decimal is encoded as a float, the enum and set are encoded by the name,
+		// the original value cannot be rebuilt.
+		return nil, false
+	}
+	return value, true
+}
+
 func (d *Decoder) getCommitTs() uint64 {
 	source := d.valuePayload["source"].(map[string]interface{})
 	commitTs, err := source["commit_ts"].(json.Number).Int64()
@@ -205,7 +333,11 @@ func (d *Decoder) getTableInfo() *model.TableInfo {
 		tidbType := col["tidb_type"].(string)
 		optional := col["optional"].(bool)
 		fieldType := parseTiDBType(tidbType, optional)
-		if fieldType.GetType() == mysql.TypeDatetime {
+		if fsp, ok := col["tidb_fsp"].(json.Number); ok {
+			if v, err := fsp.Int64(); err == nil {
+				fieldType.SetDecimal(int(v))
+			}
+		} else if fieldType.GetType() == mysql.TypeDatetime {
 			name := col["name"].(string)
 			if name == "io.debezium.time.MicroTimestamp" {
 				fieldType.SetDecimal(6)
@@ -161,6 +161,58 @@ func (d *BatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error
 	return result, nil
 }
 
+// SchemaChangeTopic implements the SchemaChangeTopicRouter interface
+func (d *BatchEncoder) SchemaChangeTopic() string {
+	return d.config.DebeziumSchemaChangeTopic
+}
+
+// TransactionTopic implements the TxnMetadataEncoder interface
+func (d *BatchEncoder) TransactionTopic() string {
+	return d.config.DebeziumTransactionTopic
+}
+
+// EncodeTxnEvents implements the TxnMetadataEncoder interface
+func (d *BatchEncoder) EncodeTxnEvents(
+	txn *model.SingleTableTxn,
+) (*common.Message, *common.Message, error) {
+	begin, err := d.encodeTxnEvent(txn, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	end, err := d.encodeTxnEvent(txn, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return begin, end, nil
+}
+
+func (d *BatchEncoder) encodeTxnEvent(txn *model.SingleTableTxn, isBegin bool) (*common.Message, error) {
+	keyBuf := bytes.Buffer{}
+	valueBuf := bytes.Buffer{}
+	err := d.codec.EncodeTxnEvent(txn, isBegin, &keyBuf, &valueBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := common.Compress(
+		d.config.ChangefeedID,
+		d.config.LargeMessageHandle.LargeMessageHandleCompression,
+		keyBuf.Bytes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	value, err := common.Compress(
+		d.config.ChangefeedID,
+		d.config.LargeMessageHandle.LargeMessageHandleCompression,
+		valueBuf.Bytes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewMsg(config.ProtocolDebezium, key, value, txn.CommitTs,
+		model.MessageTypeUnknown, txn.TableInfo.GetSchemaNamePtr(), txn.TableInfo.GetTableNamePtr()), nil
+}
+
 // Build implements the RowEventEncoder interface
 func (d *BatchEncoder) Build() []*common.Message {
 	if len(d.messages) == 0 {
@@ -173,7 +225,7 @@ func (d *BatchEncoder) Build() []*common.Message {
 }
 
 // newBatchEncoder creates a new Debezium BatchEncoder.
-func newBatchEncoder(c *common.Config, clusterID string) codec.RowEventEncoder {
+func newBatchEncoder(c *common.Config, clusterID string) *BatchEncoder {
 	batch := &BatchEncoder{
 		messages: nil,
 		config:   c,
@@ -204,5 +256,13 @@ func (b *batchEncoderBuilder) Build() codec.RowEventEncoder {
 	return newBatchEncoder(b.config, b.clusterID)
 }
 
+// BuildTxnMetadataEncoder implements the TxnMetadataEncoderBuilder interface
+func (b *batchEncoderBuilder) BuildTxnMetadataEncoder() codec.TxnMetadataEncoder {
+	if b.config.DebeziumTransactionTopic == "" {
+		return nil
+	}
+	return newBatchEncoder(b.config, b.clusterID)
+}
+
 // CleanMetrics do nothing
 func (b *batchEncoderBuilder) CleanMetrics() {}
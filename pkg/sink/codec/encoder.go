@@ -39,6 +39,42 @@ type DDLEventBatchEncoder interface {
 	EncodeDDLEvent(e *model.DDLEvent) (*common.Message, error)
 }
 
+// DDLSchemaChecker is implemented by the encoders which register the table
+// schemas to a schema registry, it checks the new schema of the table before
+// the DDL is applied.
+type DDLSchemaChecker interface {
+	// CheckDDLSchema checks whether the new schema of the table changed by the
+	// DDL can be registered for the topic which the rows of the table are sent to.
+	CheckDDLSchema(ctx context.Context, topic string, ddl *model.DDLEvent) error
+}
+
+// SchemaChangeTopicRouter is implemented by the encoders which may send all
+// the DDL events to a dedicated topic, so that the DDL history is kept in order.
+type SchemaChangeTopicRouter interface {
+	// SchemaChangeTopic returns the topic of the DDL events, empty means the
+	// DDL events are dispatched by the event router.
+	SchemaChangeTopic() string
+}
+
+// TxnMetadataEncoder is implemented by the encoders which emit the transaction
+// boundaries to a dedicated topic.
+type TxnMetadataEncoder interface {
+	// TransactionTopic returns the topic of the transaction metadata events,
+	// empty means the transaction metadata is disabled.
+	TransactionTopic() string
+	// EncodeTxnEvents encodes the BEGIN and END events of the transaction.
+	// It must be safe to be called concurrently.
+	EncodeTxnEvents(txn *model.SingleTableTxn) (begin *common.Message, end *common.Message, err error)
+}
+
+// TxnMetadataEncoderBuilder is implemented by the encoder builders whose
+// encoders can emit the transaction metadata events.
+type TxnMetadataEncoderBuilder interface {
+	// BuildTxnMetadataEncoder builds the transaction metadata encoder,
+	// it returns nil if the transaction metadata is disabled.
+	BuildTxnMetadataEncoder() TxnMetadataEncoder
+}
+
 // MessageBuilder is an abstraction to build message.
 type MessageBuilder interface {
 	// Build builds the batch and returns the bytes of key and value.
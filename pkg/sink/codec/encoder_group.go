@@ -42,6 +42,9 @@ type EncoderGroup interface {
 	// AddEvents add events into the group and encode them by one of the encoders in the group.
 	// Note: The caller should make sure all events should belong to the same topic and partition.
 	AddEvents(ctx context.Context, key model.TopicPartitionKey, events ...*dmlsink.RowChangeCallbackableEvent) error
+	// AddMessages add the already encoded messages into the group, they are output
+	// in order with the events added before and after them.
+	AddMessages(ctx context.Context, key model.TopicPartitionKey, messages ...*common.Message) error
 	// Output returns a channel produce futures
 	Output() <-chan *future
 }
@@ -206,6 +209,27 @@ func (g *encoderGroup) AddEvents(
 	return nil
 }
 
+func (g *encoderGroup) AddMessages(
+	ctx context.Context,
+	key model.TopicPartitionKey,
+	messages ...*common.Message,
+) error {
+	// The messages don't need to be encoded, so the future is ready immediately.
+	future := &future{
+		Key:      key,
+		Messages: messages,
+		done:     make(chan struct{}),
+	}
+	close(future.done)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case g.outputCh <- future:
+	}
+	return nil
+}
+
 func (g *encoderGroup) Output() <-chan *future {
 	return g.outputCh
 }
@@ -50,6 +50,7 @@ type BatchDecoder struct {
 	upstreamTiDB *sql.DB
 
 	tableIDAllocator *common.FakeTableIDAllocator
+	checksumVerifier *common.ChecksumVerifier
 }
 
 // NewBatchDecoder creates a new BatchDecoder.
@@ -76,6 +77,7 @@ func NewBatchDecoder(ctx context.Context, config *common.Config, db *sql.DB) (co
 		storage:          externalStorage,
 		upstreamTiDB:     db,
 		tableIDAllocator: common.NewFakeTableIDAllocator(),
+		checksumVerifier: common.NewChecksumVerifier(config, config.Protocol.String(), db),
 	}, nil
 }
 
@@ -153,6 +155,9 @@ func (b *BatchDecoder) HasNext() (model.MessageType, bool, error) {
 			return b.nextKey.Type, false, errors.Trace(err)
 		}
 		b.nextEvent = b.msgToRowChange(b.nextKey, rowMsg)
+		if err = b.checksumVerifier.VerifyColumns(b.nextEvent, rowMsg.Checksum, checksumValue); err != nil {
+			return b.nextKey.Type, false, errors.Trace(err)
+		}
 	}
 
 	return b.nextKey.Type, true, nil
@@ -347,6 +352,9 @@ func (b *BatchDecoder) assembleEventFromClaimCheckStorage(ctx context.Context) (
 	}
 
 	event := b.msgToRowChange(msgKey, rowMsg)
+	if err = b.checksumVerifier.VerifyColumns(event, rowMsg.Checksum, checksumValue); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	return event, nil
 }
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink/codec"
@@ -54,6 +55,8 @@ type messageRow struct {
 	Update     map[string]internal.Column `json:"u,omitempty"`
 	PreColumns map[string]internal.Column `json:"p,omitempty"`
 	Delete     map[string]internal.Column `json:"d,omitempty"`
+	// Checksum is only set when the row level checksum is enabled.
+	Checksum *common.RowChecksum `json:"checksum,omitempty"`
 }
 
 func (m *messageRow) encode() ([]byte, error) {
@@ -162,6 +165,10 @@ func rowChangeToMsg(
 			return nil, nil, cerror.ErrOpenProtocolCodecInvalidData.GenWithStack("not found handle key columns for the insert event")
 		}
 	}
+	// the checksum cannot be verified by the handle key columns only.
+	if config.EnableRowChecksum && e.Checksum != nil && !largeMessageOnlyHandleKeyColumns {
+		value.Checksum = common.NewRowChecksum(e)
+	}
 
 	return key, value, nil
 }
@@ -200,6 +207,14 @@ func (b *BatchDecoder) msgToRowChange(key *internal.MessageKey, value *messageRo
 	return e
 }
 
+// checksumValue converts the decoded value to the type used by the checksum calculation.
+func checksumValue(_ *timodel.ColumnInfo, value interface{}) (interface{}, bool) {
+	if vector, ok := value.(types.VectorFloat32); ok {
+		return vector.String(), true
+	}
+	return value, true
+}
+
 func rowChangeColumns2CodecColumns(cols []*model.ColumnData, tb *model.TableInfo, onlyHandleKeyColumns bool) map[string]internal.Column {
 	jsonCols := make(map[string]internal.Column, len(cols))
 	for _, col := range cols {
@@ -37,8 +37,9 @@ type Decoder struct {
 
 	marshaller marshaller
 
-	upstreamTiDB *sql.DB
-	storage      storage.ExternalStorage
+	upstreamTiDB     *sql.DB
+	storage          storage.ExternalStorage
+	checksumVerifier *common.ChecksumVerifier
 
 	value []byte
 	msg   *message
@@ -69,15 +70,23 @@ func NewDecoder(ctx context.Context, config *common.Config, db *sql.DB) (*Decode
 			GenWithStack("handle-key-only is enabled, but upstream TiDB is not provided")
 	}
 
+	memo := newMemoryTableInfoProvider()
+	if config.SchemaHistory != nil {
+		for _, info := range config.SchemaHistory.TableInfos() {
+			memo.Write(info)
+		}
+	}
+
 	m, err := newMarshaller(config)
 	return &Decoder{
 		config:     config,
 		marshaller: m,
 
-		storage:      externalStorage,
-		upstreamTiDB: db,
+		storage:          externalStorage,
+		upstreamTiDB:     db,
+		checksumVerifier: common.NewChecksumVerifier(config, config.Protocol.String(), db),
 
-		memo:           newMemoryTableInfoProvider(),
+		memo:           memo,
 		cachedMessages: list.New(),
 	}, errors.Trace(err)
 }
@@ -157,7 +166,7 @@ func (d *Decoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 		return nil, nil
 	}
 
-	event, err := buildRowChangedEvent(d.msg, tableInfo, d.config.EnableRowChecksum, d.upstreamTiDB)
+	event, err := buildRowChangedEvent(d.msg, tableInfo, d.config.EnableRowChecksum, d.checksumVerifier)
 	d.msg = nil
 
 	log.Debug("row changed event assembled", zap.Any("event", event))
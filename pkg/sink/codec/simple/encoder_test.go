@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/pingcap/tiflow/pkg/schemahistory"
 	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	mock_simple "github.com/pingcap/tiflow/pkg/sink/codec/simple/mock"
 	"github.com/pingcap/tiflow/pkg/sink/codec/utils"
@@ -1358,6 +1359,51 @@ func TestEncodeDMLBeforeDDL(t *testing.T) {
 	}
 }
 
+func TestDecodeDMLWithSchemaHistory(t *testing.T) {
+	helper := entry.NewSchemaTestHelper(t)
+	defer helper.Close()
+
+	sql := `create table test.t(a int primary key, b int)`
+	ddlEvent := helper.DDL2Event(sql)
+
+	sql = `insert into test.t values (1, 2)`
+	row := helper.DML2Event(sql, "test", "t")
+
+	ctx := context.Background()
+	codecConfig := common.NewConfig(config.ProtocolSimple)
+
+	b, err := NewBuilder(ctx, codecConfig)
+	require.NoError(t, err)
+	enc := b.Build()
+
+	err = enc.AppendRowChangedEvent(ctx, "", row, func() {})
+	require.NoError(t, err)
+
+	messages := enc.Build()
+	require.Len(t, messages, 1)
+
+	// the DDL event is not consumed, the table schema is imported from the history.
+	history := schemahistory.NewHistory(model.DefaultChangeFeedID("test"), ddlEvent.CommitTs, row.CommitTs)
+	history.Add(ddlEvent.TableInfo.SchemaID, ddlEvent.TableInfo.TableName.Schema, ddlEvent.TableInfo.TableInfo)
+	codecConfig.SchemaHistory = history
+	dec, err := NewDecoder(ctx, codecConfig, nil)
+	require.NoError(t, err)
+
+	err = dec.AddKeyValue(messages[0].Key, messages[0].Value)
+	require.NoError(t, err)
+
+	messageType, hasNext, err := dec.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, messageType)
+
+	decodedRow, err := dec.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.NotNil(t, decodedRow)
+	require.Equal(t, row.CommitTs, decodedRow.CommitTs)
+	require.Equal(t, ddlEvent.TableInfo.ID, decodedRow.TableInfo.ID)
+}
+
 func TestEncodeBootstrapEvent(t *testing.T) {
 	helper := entry.NewSchemaTestHelper(t)
 	defer helper.Close()
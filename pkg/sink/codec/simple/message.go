@@ -14,7 +14,6 @@
 package simple
 
 import (
-	"database/sql"
 	"encoding/base64"
 	"fmt"
 	"sort"
@@ -382,7 +381,7 @@ func newDDLEvent(msg *message) *model.DDLEvent {
 
 // buildRowChangedEvent converts from message to RowChangedEvent.
 func buildRowChangedEvent(
-	msg *message, tableInfo *model.TableInfo, enableRowChecksum bool, db *sql.DB,
+	msg *message, tableInfo *model.TableInfo, enableRowChecksum bool, verifier *common.ChecksumVerifier,
 ) (*model.RowChangedEvent, error) {
 	result := &model.RowChangedEvent{
 		CommitTs:        msg.CommitTs,
@@ -400,12 +399,12 @@ func buildRowChangedEvent(
 			Version:   msg.Checksum.Version,
 		}
 
-		err := common.VerifyChecksum(result, db)
-		if err != nil || msg.Checksum.Corrupted {
+		// the mismatch is reported by the verifier, it returns error only if
+		// the corruption handle level is `error`.
+		if err := verifier.Verify(result); err != nil {
 			log.Warn("consumer detect checksum corrupted",
 				zap.String("schema", msg.Schema), zap.String("table", msg.Table), zap.Error(err))
 			return nil, cerror.ErrDecodeFailed.GenWithStackByArgs("checksum corrupted")
-
 		}
 	}
 
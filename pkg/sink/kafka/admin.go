@@ -15,6 +15,7 @@ package kafka
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -136,10 +137,14 @@ func (a *saramaAdminClient) GetTopicsMeta(
 				zap.Error(meta.Err))
 			continue
 		}
-		result[meta.Name] = TopicDetail{
+		detail := TopicDetail{
 			Name:          meta.Name,
 			NumPartitions: int32(len(meta.Partitions)),
 		}
+		if len(meta.Partitions) != 0 {
+			detail.ReplicationFactor = int16(len(meta.Partitions[0].Replicas))
+		}
+		result[meta.Name] = detail
 	}
 	return result, nil
 }
@@ -166,6 +171,13 @@ func (a *saramaAdminClient) CreateTopic(
 		NumPartitions:     detail.NumPartitions,
 		ReplicationFactor: detail.ReplicationFactor,
 	}
+	if len(detail.ConfigEntries) != 0 {
+		request.ConfigEntries = make(map[string]*string, len(detail.ConfigEntries))
+		for name, value := range detail.ConfigEntries {
+			value := value
+			request.ConfigEntries[name] = &value
+		}
+	}
 
 	err := a.admin.CreateTopic(detail.Name, request, validateOnly)
 	// Ignore the already exists error because it's not harmful.
@@ -175,6 +187,12 @@ func (a *saramaAdminClient) CreateTopic(
 	return nil
 }
 
+func (a *saramaAdminClient) CreatePartitions(
+	_ context.Context, topic string, count int32,
+) error {
+	return a.admin.CreatePartitions(topic, count, nil, false)
+}
+
 func (a *saramaAdminClient) Close() {
 	if err := a.admin.Close(); err != nil {
 		log.Warn("close admin client meet error",
@@ -183,3 +201,85 @@ func (a *saramaAdminClient) Close() {
 			zap.Error(err))
 	}
 }
+
+// ReconcileTopic makes the existing topic match the desired detail. The partitions are
+// added only if allowPartitionGrowth is true and the topic has fewer partitions than
+// desired, since adding partitions changes the partition of the keys. The other drifts,
+// such as the partition number, the replication factor and the topic level configs,
+// are only warned since they can not be fixed without interrupting the consumers.
+// It returns the partition number of the topic after reconciled.
+func ReconcileTopic(
+	ctx context.Context,
+	admin ClusterAdminClient,
+	changefeed model.ChangeFeedID,
+	actual TopicDetail,
+	desired *TopicDetail,
+	allowPartitionGrowth bool,
+) (int32, error) {
+	numPartitions := actual.NumPartitions
+	if desired.NumPartitions > actual.NumPartitions && !allowPartitionGrowth {
+		log.Warn("the topic has fewer partitions than desired, "+
+			"set allow-partition-growth to add partitions to it",
+			zap.String("namespace", changefeed.Namespace),
+			zap.String("changefeed", changefeed.ID),
+			zap.String("topic", desired.Name),
+			zap.Int32("partitionNumber", actual.NumPartitions),
+			zap.Int32("desiredPartitionNumber", desired.NumPartitions))
+	} else if desired.NumPartitions > actual.NumPartitions {
+		if err := admin.CreatePartitions(ctx, desired.Name, desired.NumPartitions); err != nil {
+			return 0, cerror.WrapError(cerror.ErrKafkaCreatePartitions, err, desired.Name)
+		}
+		log.Info("Kafka admin client add partitions to the topic",
+			zap.String("namespace", changefeed.Namespace),
+			zap.String("changefeed", changefeed.ID),
+			zap.String("topic", desired.Name),
+			zap.Int32("oldPartitionNumber", actual.NumPartitions),
+			zap.Int32("newPartitionNumber", desired.NumPartitions))
+		numPartitions = desired.NumPartitions
+	} else if desired.NumPartitions < actual.NumPartitions {
+		log.Warn("the topic has more partitions than desired, partitions can not be removed",
+			zap.String("namespace", changefeed.Namespace),
+			zap.String("changefeed", changefeed.ID),
+			zap.String("topic", desired.Name),
+			zap.Int32("partitionNumber", actual.NumPartitions),
+			zap.Int32("desiredPartitionNumber", desired.NumPartitions))
+	}
+
+	if actual.ReplicationFactor != 0 && desired.ReplicationFactor != 0 &&
+		actual.ReplicationFactor != desired.ReplicationFactor {
+		log.Warn("the replication factor of the topic drifts from the desired",
+			zap.String("namespace", changefeed.Namespace),
+			zap.String("changefeed", changefeed.ID),
+			zap.String("topic", desired.Name),
+			zap.Int16("replicationFactor", actual.ReplicationFactor),
+			zap.Int16("desiredReplicationFactor", desired.ReplicationFactor))
+	}
+
+	names := make([]string, 0, len(desired.ConfigEntries))
+	for name := range desired.ConfigEntries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := admin.GetTopicConfig(ctx, desired.Name, name)
+		if err != nil {
+			log.Warn("get the topic config failed, skip reconciling it",
+				zap.String("namespace", changefeed.Namespace),
+				zap.String("changefeed", changefeed.ID),
+				zap.String("topic", desired.Name),
+				zap.String("configName", name),
+				zap.Error(err))
+			continue
+		}
+		if value != desired.ConfigEntries[name] {
+			log.Warn("the topic config drifts from the desired",
+				zap.String("namespace", changefeed.Namespace),
+				zap.String("changefeed", changefeed.ID),
+				zap.String("topic", desired.Name),
+				zap.String("configName", name),
+				zap.String("configValue", value),
+				zap.String("desiredConfigValue", desired.ConfigEntries[name]))
+		}
+	}
+	return numPartitions, nil
+}
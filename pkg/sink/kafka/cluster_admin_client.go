@@ -22,6 +22,8 @@ type TopicDetail struct {
 	Name              string
 	NumPartitions     int32
 	ReplicationFactor int16
+	// ConfigEntries are the topic level configs, only used to create the topic.
+	ConfigEntries map[string]string
 }
 
 // Broker represents a Kafka broker.
@@ -52,6 +54,9 @@ type ClusterAdminClient interface {
 	// CreateTopic creates a new topic.
 	CreateTopic(ctx context.Context, detail *TopicDetail, validateOnly bool) error
 
+	// CreatePartitions increases the partition number of the topic to the count.
+	CreatePartitions(ctx context.Context, topic string, count int32) error
+
 	// Close shuts down the admin client.
 	Close()
 }
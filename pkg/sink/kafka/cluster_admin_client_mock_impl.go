@@ -193,6 +193,27 @@ func (c *ClusterAdminClientMockImpl) CreateTopic(
 	c.topics[detail.Name] = &topicDetail{
 		TopicDetail: *detail,
 	}
+	if len(detail.ConfigEntries) != 0 {
+		c.topicConfigs[detail.Name] = make(map[string]string, len(detail.ConfigEntries))
+		for name, value := range detail.ConfigEntries {
+			c.topicConfigs[detail.Name][name] = value
+		}
+	}
+	return nil
+}
+
+// CreatePartitions implement the ClusterAdminClient interface
+func (c *ClusterAdminClientMockImpl) CreatePartitions(
+	_ context.Context, topic string, count int32,
+) error {
+	detail, ok := c.topics[topic]
+	if !ok {
+		return sarama.ErrUnknownTopicOrPartition
+	}
+	if count <= detail.NumPartitions {
+		return sarama.ErrInvalidPartitions
+	}
+	detail.NumPartitions = count
 	return nil
 }
 
@@ -15,6 +15,7 @@ package kafka
 
 import (
 	"context"
+	stdErrors "errors"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -35,6 +36,10 @@ type Factory interface {
 	SyncProducer(ctx context.Context) (SyncProducer, error)
 	// AsyncProducer creates an async producer to writer message to kafka
 	AsyncProducer(ctx context.Context, failpointCh chan error) (AsyncProducer, error)
+	// TransactionalProducer creates a producer to write messages to kafka in
+	// transactions, the producer created before with the same transactional id
+	// is fenced, no matter it's created by this process or not.
+	TransactionalProducer(ctx context.Context, transactionalID string) (TransactionalProducer, error)
 	// MetricsCollector returns the kafka metrics collector
 	MetricsCollector(role util.Role, adminClient ClusterAdminClient) MetricsCollector
 }
@@ -82,6 +87,26 @@ type AsyncProducer interface {
 	AsyncRunCallback(ctx context.Context) error
 }
 
+// TransactionalProducer is the kafka producer which writes messages in transactions,
+// messages in one transaction are visible to the `read_committed` consumers atomically.
+type TransactionalProducer interface {
+	// SendMessagesInTxn produces the messages in one transaction, and returns
+	// only when the transaction is committed or aborted. ErrKafkaProducerFenced
+	// is returned if the producer has been fenced by another producer with the
+	// same transactional id, the producer can not be used anymore in this case.
+	SendMessagesInTxn(ctx context.Context, messages []*TxnMessage) error
+
+	// Close shuts down the producer, the ongoing transaction is aborted.
+	Close()
+}
+
+// TxnMessage is a message to be sent to the given partition in a transaction.
+type TxnMessage struct {
+	Topic     string
+	Partition int32
+	Message   *common.Message
+}
+
 type saramaSyncProducer struct {
 	id       model.ChangeFeedID
 	client   sarama.Client
@@ -157,6 +182,97 @@ func (p *saramaSyncProducer) Close() {
 	}()
 }
 
+type saramaTransactionalProducer struct {
+	id              model.ChangeFeedID
+	transactionalID string
+	client          sarama.Client
+	producer        sarama.SyncProducer
+}
+
+func (p *saramaTransactionalProducer) SendMessagesInTxn(
+	_ context.Context, messages []*TxnMessage,
+) error {
+	return sendMessagesInTxn(p.producer, p.transactionalID, messages)
+}
+
+func (p *saramaTransactionalProducer) Close() {
+	go func() {
+		// Close it asynchronously for the same reason as the sync producer,
+		// the uncommitted transaction is aborted by the broker after timeout.
+		start := time.Now()
+		if err := p.producer.Close(); err != nil {
+			log.Warn("Close kafka transactional producer with error",
+				zap.String("namespace", p.id.Namespace),
+				zap.String("changefeed", p.id.ID),
+				zap.String("transactionalID", p.transactionalID),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+		}
+		if err := p.client.Close(); err != nil {
+			log.Warn("Close kafka transactional producer client with error",
+				zap.String("namespace", p.id.Namespace),
+				zap.String("changefeed", p.id.ID),
+				zap.String("transactionalID", p.transactionalID),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+		}
+		log.Info("Kafka transactional producer closed",
+			zap.String("namespace", p.id.Namespace),
+			zap.String("changefeed", p.id.ID),
+			zap.String("transactionalID", p.transactionalID),
+			zap.Duration("duration", time.Since(start)))
+	}()
+}
+
+// sendMessagesInTxn sends the messages in one transaction by the transactional
+// sarama producer, the transaction is aborted if any message failed.
+func sendMessagesInTxn(
+	producer sarama.SyncProducer, transactionalID string, messages []*TxnMessage,
+) error {
+	if err := producer.BeginTxn(); err != nil {
+		return wrapTxnError(producer, transactionalID, err)
+	}
+	msgs := make([]*sarama.ProducerMessage, 0, len(messages))
+	for _, m := range messages {
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic:     m.Topic,
+			Key:       sarama.ByteEncoder(m.Message.Key),
+			Value:     sarama.ByteEncoder(m.Message.Value),
+			Partition: m.Partition,
+		})
+	}
+	err := producer.SendMessages(msgs)
+	if err == nil {
+		err = producer.CommitTxn()
+	}
+	if err != nil {
+		// the transaction can't be aborted after a fatal error,
+		// the producer must be recreated.
+		if producer.TxnStatus()&sarama.ProducerTxnFlagFatalError == 0 {
+			if abortErr := producer.AbortTxn(); abortErr != nil {
+				log.Warn("abort kafka transaction failed",
+					zap.String("transactionalID", transactionalID),
+					zap.Error(abortErr))
+			}
+		}
+		return wrapTxnError(producer, transactionalID, err)
+	}
+	return nil
+}
+
+func wrapTxnError(producer sarama.SyncProducer, transactionalID string, err error) error {
+	if stdErrors.Is(err, sarama.ErrProducerFenced) ||
+		producer.TxnStatus()&sarama.ProducerTxnFlagFatalError != 0 {
+		// Do not wrap the sarama error here, otherwise the fenced error can
+		// not be recognized by ErrKafkaProducerFenced.Equal.
+		log.Warn("kafka transactional producer is fenced",
+			zap.String("transactionalID", transactionalID),
+			zap.Error(err))
+		return cerror.ErrKafkaProducerFenced.GenWithStackByArgs(transactionalID)
+	}
+	return cerror.ErrKafkaTransaction.Wrap(err).GenWithStackByArgs(transactionalID)
+}
+
 type saramaAsyncProducer struct {
 	client       sarama.Client
 	producer     sarama.AsyncProducer
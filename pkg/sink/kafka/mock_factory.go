@@ -15,6 +15,7 @@ package kafka
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/IBM/sarama"
@@ -30,6 +31,10 @@ import (
 type MockFactory struct {
 	o            *Options
 	changefeedID model.ChangeFeedID
+
+	mu sync.Mutex
+	// txnProducers is the latest transactional producer of each transactional id.
+	txnProducers map[string]*MockTransactionalProducer
 }
 
 // NewMockFactory constructs a Factory with mock implementation.
@@ -39,6 +44,7 @@ func NewMockFactory(
 	return &MockFactory{
 		o:            o,
 		changefeedID: changefeedID,
+		txnProducers: make(map[string]*MockTransactionalProducer),
 	}, nil
 }
 
@@ -78,6 +84,31 @@ func (f *MockFactory) AsyncProducer(
 	}, nil
 }
 
+// TransactionalProducer creates a transactional producer,
+// the producer created before with the same transactional id is fenced.
+func (f *MockFactory) TransactionalProducer(
+	ctx context.Context,
+	transactionalID string,
+) (TransactionalProducer, error) {
+	config, err := NewSaramaTransactionalConfig(ctx, f.o, transactionalID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	t := ctx.Value("testing.T").(*testing.T)
+	p := &MockTransactionalProducer{
+		Producer:        mocks.NewSyncProducer(t, config),
+		transactionalID: transactionalID,
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if old, ok := f.txnProducers[transactionalID]; ok {
+		old.fence()
+	}
+	f.txnProducers[transactionalID] = p
+	return p, nil
+}
+
 // MetricsCollector returns the metric collector
 func (f *MockFactory) MetricsCollector(
 	_ util.Role, _ ClusterAdminClient,
@@ -124,6 +155,54 @@ func (m *MockSaramaSyncProducer) Close() {
 	m.Producer.Close()
 }
 
+// MockTransactionalProducer is a mock implementation of TransactionalProducer interface,
+// all transactions are committed successfully unless the producer is fenced.
+type MockTransactionalProducer struct {
+	Producer        *mocks.SyncProducer
+	transactionalID string
+
+	mu        sync.Mutex
+	fenced    bool
+	committed []*TxnMessage
+}
+
+// SendMessagesInTxn implement the TransactionalProducer interface.
+func (p *MockTransactionalProducer) SendMessagesInTxn(
+	_ context.Context, messages []*TxnMessage,
+) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fenced {
+		return cerror.ErrKafkaProducerFenced.GenWithStackByArgs(p.transactionalID)
+	}
+	for range messages {
+		p.Producer.ExpectSendMessageAndSucceed()
+	}
+	if err := sendMessagesInTxn(p.Producer, p.transactionalID, messages); err != nil {
+		return err
+	}
+	p.committed = append(p.committed, messages...)
+	return nil
+}
+
+// CommittedMessages returns all messages committed by the producer.
+func (p *MockTransactionalProducer) CommittedMessages() []*TxnMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*TxnMessage(nil), p.committed...)
+}
+
+func (p *MockTransactionalProducer) fence() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fenced = true
+}
+
+// Close implement the TransactionalProducer interface.
+func (p *MockTransactionalProducer) Close() {
+	_ = p.Producer.Close()
+}
+
 // MockSaramaAsyncProducer is a mock implementation of AsyncProducer interface.
 type MockSaramaAsyncProducer struct {
 	AsyncProducer *mocks.AsyncProducer
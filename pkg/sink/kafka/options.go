@@ -62,6 +62,12 @@ const (
 	// See: https://kafka.apache.org/documentation/#brokerconfigs_min.insync.replicas and
 	// https://kafka.apache.org/documentation/#topicconfigs_min.insync.replicas
 	MinInsyncReplicasConfigName = "min.insync.replicas"
+	// RetentionMsConfigName is the maximum time a log is retained before it's discarded.
+	// See: https://kafka.apache.org/documentation/#topicconfigs_retention.ms
+	RetentionMsConfigName = "retention.ms"
+	// CleanupPolicyConfigName is the retention policy to use on log segments, delete or compact.
+	// See: https://kafka.apache.org/documentation/#topicconfigs_cleanup.policy
+	CleanupPolicyConfigName = "cleanup.policy"
 )
 
 const (
@@ -138,6 +144,8 @@ type urlConfig struct {
 	Cert                         *string `form:"cert"`
 	Key                          *string `form:"key"`
 	InsecureSkipVerify           *bool   `form:"insecure-skip-verify"`
+	EnableTransaction            *bool   `form:"enable-transaction"`
+	TransactionalID              *string `form:"transactional-id"`
 }
 
 // Options stores user specified configurations
@@ -170,6 +178,16 @@ type Options struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// EnableTransaction makes the DML producer write the events of each table
+	// up to a resolved ts in one kafka transaction, consumers with the
+	// `read_committed` isolation level never see the uncommitted messages.
+	// The committed transactions may be sent again after the changefeed
+	// restarts from its checkpoint, so the delivery is at-least-once.
+	EnableTransaction bool
+	// TransactionalID is the prefix of the transactional id of each table,
+	// default to the namespace and the ID of the changefeed.
+	TransactionalID string
 }
 
 // NewOptions returns a default Kafka configuration
@@ -308,6 +326,15 @@ func (o *Options) Apply(changefeedID model.ChangeFeedID,
 		o.RequiredAcks = r
 	}
 
+	if urlParameter.EnableTransaction != nil {
+		o.EnableTransaction = *urlParameter.EnableTransaction
+	}
+	if o.EnableTransaction {
+		if err := o.applyTransaction(changefeedID, urlParameter); err != nil {
+			return err
+		}
+	}
+
 	err = o.applySASL(urlParameter, replicaConfig)
 	if err != nil {
 		return err
@@ -362,6 +389,21 @@ func mergeConfig(
 	return dest, nil
 }
 
+func (o *Options) applyTransaction(changefeedID model.ChangeFeedID, params *urlConfig) error {
+	// the idempotent producer, which is required by the transaction,
+	// only works if all in-sync replicas acknowledge the message.
+	if o.RequiredAcks != WaitForAll {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"required-acks must be %d if the transaction is enabled, but got %d",
+			WaitForAll, o.RequiredAcks)
+	}
+	o.TransactionalID = fmt.Sprintf("ticdc-%s-%s", changefeedID.Namespace, changefeedID.ID)
+	if params.TransactionalID != nil && *params.TransactionalID != "" {
+		o.TransactionalID = *params.TransactionalID
+	}
+	return nil
+}
+
 func (o *Options) applyTLS(params *urlConfig) error {
 	if params.CA != nil && *params.CA != "" {
 		o.Credential.CAPath = *params.CA
@@ -532,6 +574,56 @@ type AutoCreateTopicConfig struct {
 	AutoCreate        bool
 	PartitionNum      int32
 	ReplicationFactor int16
+
+	// TopicConfigOf returns the topic config set by the dispatch rules,
+	// nil if it's not set for the topic.
+	TopicConfigOf func(topic string) *config.TopicConfig
+}
+
+// DesiredTopicDetail returns the detail of the topic set by the dispatch rules,
+// the unset fields are filled by the partition number and replication factor above.
+// It returns nil if the topic config is not set for the topic.
+func (c *AutoCreateTopicConfig) DesiredTopicDetail(topic string) *TopicDetail {
+	if c.TopicConfigOf == nil {
+		return nil
+	}
+	topicConfig := c.TopicConfigOf(topic)
+	if topicConfig == nil {
+		return nil
+	}
+
+	detail := &TopicDetail{
+		Name:              topic,
+		NumPartitions:     c.PartitionNum,
+		ReplicationFactor: c.ReplicationFactor,
+		ConfigEntries:     make(map[string]string, len(topicConfig.Configs)+2),
+	}
+	if topicConfig.PartitionNum != nil {
+		detail.NumPartitions = *topicConfig.PartitionNum
+	}
+	if topicConfig.ReplicationFactor != nil {
+		detail.ReplicationFactor = *topicConfig.ReplicationFactor
+	}
+	for name, value := range topicConfig.Configs {
+		detail.ConfigEntries[name] = value
+	}
+	if topicConfig.RetentionMs != nil {
+		detail.ConfigEntries[RetentionMsConfigName] = strconv.FormatInt(*topicConfig.RetentionMs, 10)
+	}
+	if topicConfig.CleanupPolicy != nil {
+		detail.ConfigEntries[CleanupPolicyConfigName] = strings.ReplaceAll(*topicConfig.CleanupPolicy, " ", "")
+	}
+	return detail
+}
+
+// AllowPartitionGrowth returns whether the partitions can be added to the
+// existing topic to match the topic config set by the dispatch rules.
+func (c *AutoCreateTopicConfig) AllowPartitionGrowth(topic string) bool {
+	if c.TopicConfigOf == nil {
+		return false
+	}
+	topicConfig := c.TopicConfigOf(topic)
+	return topicConfig != nil && topicConfig.AllowPartitionGrowth != nil && *topicConfig.AllowPartitionGrowth
 }
 
 // DeriveTopicConfig derive a `topicConfig` from the `Options`
@@ -191,6 +191,33 @@ func TestTimeout(t *testing.T) {
 	require.Equal(t, 2*time.Minute, options.WriteTimeout)
 }
 
+func TestTransaction(t *testing.T) {
+	options := NewOptions()
+	require.False(t, options.EnableTransaction)
+
+	changefeedID := model.DefaultChangeFeedID("test")
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/kafka-test?enable-transaction=true")
+	require.NoError(t, err)
+	err = options.Apply(changefeedID, sinkURI, config.GetDefaultReplicaConfig())
+	require.NoError(t, err)
+	require.True(t, options.EnableTransaction)
+	require.Equal(t, "ticdc-default-test", options.TransactionalID)
+
+	options = NewOptions()
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092/kafka-test?enable-transaction=true" +
+		"&transactional-id=cdc-orders")
+	require.NoError(t, err)
+	err = options.Apply(changefeedID, sinkURI, config.GetDefaultReplicaConfig())
+	require.NoError(t, err)
+	require.Equal(t, "cdc-orders", options.TransactionalID)
+
+	options = NewOptions()
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092/kafka-test?enable-transaction=true&required-acks=1")
+	require.NoError(t, err)
+	err = options.Apply(changefeedID, sinkURI, config.GetDefaultReplicaConfig())
+	require.True(t, cerror.ErrKafkaInvalidConfig.Equal(err))
+}
+
 func TestAdjustConfigTopicNotExist(t *testing.T) {
 	// When the topic does not exist, use the broker's configuration to create the topic.
 	adminClient := NewClusterAdminClientMockImpl()
@@ -148,6 +148,30 @@ func NewSaramaConfig(ctx context.Context, o *Options) (*sarama.Config, error) {
 	return config, nil
 }
 
+// NewSaramaTransactionalConfig return the config of the transactional producer
+// with the given transactional id.
+func NewSaramaTransactionalConfig(
+	ctx context.Context, o *Options, transactionalID string,
+) (*sarama.Config, error) {
+	config, err := NewSaramaConfig(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"kafka transaction requires kafka version 0.11.0.0 or later, but got %s",
+			config.Version.String())
+	}
+	// The idempotent producer keeps the messages in order by the sequence
+	// number even if it retries, so the retry can be enabled safely.
+	config.Producer.Idempotent = true
+	config.Producer.Retry.Max = 3
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Net.MaxOpenRequests = 1
+	config.Producer.Transaction.ID = transactionalID
+	return config, nil
+}
+
 func completeSaramaSASLConfig(ctx context.Context, config *sarama.Config, o *Options) error {
 	if o.SASL != nil && o.SASL.SASLMechanism != "" {
 		config.Net.SASL.Enable = true
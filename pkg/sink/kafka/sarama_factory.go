@@ -135,6 +135,51 @@ func (f *saramaFactory) AsyncProducer(
 	}, nil
 }
 
+// TransactionalProducer returns a transactional producer,
+// it should be the caller's responsibility to close the producer
+func (f *saramaFactory) TransactionalProducer(
+	ctx context.Context,
+	transactionalID string,
+) (TransactionalProducer, error) {
+	return NewSaramaTransactionalProducer(ctx, f.option, f.changefeedID, transactionalID, f.registry)
+}
+
+// NewSaramaTransactionalProducer creates a transactional producer with sarama,
+// it's also used by the kafka-go factory since kafka-go does not support transactions.
+func NewSaramaTransactionalProducer(
+	ctx context.Context,
+	o *Options,
+	changefeedID model.ChangeFeedID,
+	transactionalID string,
+	registry metrics.Registry,
+) (TransactionalProducer, error) {
+	config, err := NewSaramaTransactionalConfig(ctx, o, transactionalID)
+	if err != nil {
+		return nil, err
+	}
+	if registry != nil {
+		config.MetricRegistry = registry
+	}
+
+	client, err := sarama.NewClient(o.BrokerEndpoints, config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// the producer id is initialized here, the producer created before with
+	// the same transactional id is fenced, and its ongoing transaction is aborted.
+	p, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.Trace(err)
+	}
+	return &saramaTransactionalProducer{
+		id:              changefeedID,
+		transactionalID: transactionalID,
+		client:          client,
+		producer:        p,
+	}, nil
+}
+
 func (f *saramaFactory) MetricsCollector(
 	role util.Role,
 	adminClient ClusterAdminClient,
@@ -19,6 +19,8 @@ import (
 
 	"github.com/IBM/sarama"
 	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -101,3 +103,41 @@ func TestAsyncProducer(t *testing.T) {
 	require.NotNil(t, async)
 	async.Close()
 }
+
+func TestTransactionalProducerFenced(t *testing.T) {
+	t.Parallel()
+
+	o := NewOptions()
+	o.Version = "2.6.0"
+	o.IsAssignedVersion = true
+	o.BrokerEndpoints = []string{"127.0.0.1:9092"}
+	o.ClientID = "sarama-test"
+	f, err := NewMockFactory(o, model.DefaultChangeFeedID("sarama-test"))
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), "testing.T", t)
+	p1, err := f.TransactionalProducer(ctx, "txn-1")
+	require.NoError(t, err)
+	defer p1.Close()
+	messages := []*TxnMessage{
+		{Topic: "test", Partition: 0, Message: &common.Message{Key: []byte("k1")}},
+		{Topic: "test", Partition: 1, Message: &common.Message{Key: []byte("k2")}},
+	}
+	require.NoError(t, p1.SendMessagesInTxn(ctx, messages))
+	require.Len(t, p1.(*MockTransactionalProducer).CommittedMessages(), 2)
+
+	// the producer with another transactional id is not affected.
+	p2, err := f.TransactionalProducer(ctx, "txn-2")
+	require.NoError(t, err)
+	defer p2.Close()
+	require.NoError(t, p1.SendMessagesInTxn(ctx, messages))
+
+	// the producer is fenced by the new one with the same transactional id.
+	p3, err := f.TransactionalProducer(ctx, "txn-1")
+	require.NoError(t, err)
+	defer p3.Close()
+	err = p1.SendMessagesInTxn(ctx, messages)
+	require.True(t, cerror.ErrKafkaProducerFenced.Equal(err))
+	require.Len(t, p1.(*MockTransactionalProducer).CommittedMessages(), 4)
+	require.NoError(t, p3.SendMessagesInTxn(ctx, messages))
+}
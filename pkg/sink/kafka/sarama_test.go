@@ -23,6 +23,7 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/stretchr/testify/require"
 )
@@ -390,3 +391,21 @@ func TestSaramaTimeout(t *testing.T) {
 	require.Equal(t, options.WriteTimeout, saramaConfig.Net.WriteTimeout)
 	require.Equal(t, options.ReadTimeout, saramaConfig.Net.ReadTimeout)
 }
+
+func TestNewSaramaTransactionalConfig(t *testing.T) {
+	ctx := context.Background()
+	options := NewOptions()
+	options.ClientID = "test-client"
+	options.Version = "0.10.2.0"
+	options.IsAssignedVersion = true
+	_, err := NewSaramaTransactionalConfig(ctx, options, "txn-id")
+	require.True(t, cerror.ErrKafkaInvalidConfig.Equal(err))
+
+	options.Version = "2.6.0"
+	cfg, err := NewSaramaTransactionalConfig(ctx, options, "txn-id")
+	require.NoError(t, err)
+	require.True(t, cfg.Producer.Idempotent)
+	require.Equal(t, "txn-id", cfg.Producer.Transaction.ID)
+	require.Equal(t, sarama.WaitForAll, cfg.Producer.RequiredAcks)
+	require.NoError(t, cfg.Validate())
+}
@@ -175,10 +175,14 @@ func (a *admin) GetTopicsMeta(
 				zap.String("topic", topic.Name), zap.Error(topic.Error))
 			continue
 		}
-		result[topic.Name] = pkafka.TopicDetail{
+		detail := pkafka.TopicDetail{
 			Name:          topic.Name,
 			NumPartitions: int32(len(topic.Partitions)),
 		}
+		if len(topic.Partitions) != 0 {
+			detail.ReplicationFactor = int16(len(topic.Partitions[0].Replicas))
+		}
+		result[topic.Name] = detail
 	}
 	return result, nil
 }
@@ -205,14 +209,19 @@ func (a *admin) CreateTopic(
 	detail *pkafka.TopicDetail,
 	validateOnly bool,
 ) error {
+	topicConfig := kafka.TopicConfig{
+		Topic:             detail.Name,
+		NumPartitions:     int(detail.NumPartitions),
+		ReplicationFactor: int(detail.ReplicationFactor),
+	}
+	for name, value := range detail.ConfigEntries {
+		topicConfig.ConfigEntries = append(topicConfig.ConfigEntries, kafka.ConfigEntry{
+			ConfigName:  name,
+			ConfigValue: value,
+		})
+	}
 	request := &kafka.CreateTopicsRequest{
-		Topics: []kafka.TopicConfig{
-			{
-				Topic:             detail.Name,
-				NumPartitions:     int(detail.NumPartitions),
-				ReplicationFactor: int(detail.ReplicationFactor),
-			},
-		},
+		Topics:       []kafka.TopicConfig{topicConfig},
 		ValidateOnly: validateOnly,
 	}
 
@@ -230,6 +239,26 @@ func (a *admin) CreateTopic(
 	return nil
 }
 
+func (a *admin) CreatePartitions(ctx context.Context, topic string, count int32) error {
+	response, err := a.client.CreatePartitions(ctx, &kafka.CreatePartitionsRequest{
+		Topics: []kafka.TopicPartitionsConfig{
+			{
+				Name:  topic,
+				Count: count,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, err := range response.Errors {
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func (a *admin) Close() {
 	log.Info("admin client start closing",
 		zap.String("namespace", a.changefeedID.Namespace),
@@ -30,4 +30,7 @@ type Client interface {
 	CreateTopics(
 		ctx context.Context, req *kafka.CreateTopicsRequest,
 	) (*kafka.CreateTopicsResponse, error)
+	CreatePartitions(
+		ctx context.Context, req *kafka.CreatePartitionsRequest,
+	) (*kafka.CreatePartitionsResponse, error)
 }
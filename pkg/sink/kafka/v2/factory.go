@@ -260,6 +260,15 @@ func (f *factory) AsyncProducer(
 	return aw, nil
 }
 
+// TransactionalProducer creates a transactional producer to write messages to kafka,
+// kafka-go does not support transactions, so the sarama implementation is used.
+func (f *factory) TransactionalProducer(
+	ctx context.Context,
+	transactionalID string,
+) (pkafka.TransactionalProducer, error) {
+	return pkafka.NewSaramaTransactionalProducer(ctx, f.options, f.changefeedID, transactionalID, nil)
+}
+
 // MetricsCollector returns the kafka metrics collector
 func (f *factory) MetricsCollector(
 	role util.Role,
@@ -35,6 +35,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CreatePartitions mocks base method.
+func (m *MockClient) CreatePartitions(ctx context.Context, req *kafka.CreatePartitionsRequest) (*kafka.CreatePartitionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartitions", ctx, req)
+	ret0, _ := ret[0].(*kafka.CreatePartitionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartitions indicates an expected call of CreatePartitions.
+func (mr *MockClientMockRecorder) CreatePartitions(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartitions", reflect.TypeOf((*MockClient)(nil).CreatePartitions), ctx, req)
+}
+
 // CreateTopics mocks base method.
 func (m *MockClient) CreateTopics(ctx context.Context, req *kafka.CreateTopicsRequest) (*kafka.CreateTopicsResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,95 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/pingcap/tiflow/pkg/quotes"
+)
+
+// The checkpoint table records the checkpoints of changefeeds in the downstream.
+// The row with empty schema and table names is the checkpoint of the changefeed,
+// it's upserted when the checkpoint of the changefeed advances. Other rows are
+// the checkpoints of tables, they are upserted in the same transactions as the
+// data of the tables. The checkpoint of a table is the larger one of its row
+// and the row of the changefeed.
+const createCheckpointTableSQL = `CREATE TABLE IF NOT EXISTS %s (
+	ticdc_cluster_id varchar(128) NOT NULL,
+	namespace varchar(128) NOT NULL,
+	changefeed varchar(128) NOT NULL,
+	schema_name varchar(64) NOT NULL,
+	table_name varchar(64) NOT NULL,
+	checkpoint_ts bigint unsigned NOT NULL,
+	updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (ticdc_cluster_id, namespace, changefeed, schema_name, table_name)
+)`
+
+// CheckpointTableName returns the quoted name of the checkpoint table.
+func CheckpointTableName() string {
+	return quotes.QuoteSchema(filter.TiCDCSystemSchema, filter.CheckpointTable)
+}
+
+// CreateCheckpointTable creates the checkpoint table if it doesn't exist.
+func CreateCheckpointTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+quotes.QuoteName(filter.TiCDCSystemSchema))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, "failed to create checkpoint table;"))
+	}
+	_, err = db.ExecContext(ctx, fmt.Sprintf(createCheckpointTableSQL, CheckpointTableName()))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, "failed to create checkpoint table;"))
+	}
+	return nil
+}
+
+// CheckpointRow is a row of the checkpoint table, the schema and table
+// names are empty for the checkpoint of the changefeed.
+type CheckpointRow struct {
+	Schema       string
+	Table        string
+	CheckpointTs model.Ts
+}
+
+// PrepareCheckpointUpsert returns the SQL to upsert the checkpoint rows of the changefeed.
+func PrepareCheckpointUpsert(
+	clusterID string, changefeedID model.ChangeFeedID, rows ...CheckpointRow,
+) (string, []interface{}) {
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO ")
+	builder.WriteString(CheckpointTableName())
+	builder.WriteString(" (ticdc_cluster_id, namespace, changefeed, schema_name, table_name, checkpoint_ts) VALUES ")
+	args := make([]interface{}, 0, len(rows)*6)
+	for i, row := range rows {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("(?,?,?,?,?,?)")
+		args = append(args, clusterID, changefeedID.Namespace, changefeedID.ID,
+			row.Schema, row.Table, row.CheckpointTs)
+	}
+	// The checkpoint may go back when the changefeed restarts from an older
+	// checkpoint, which is expected since the data is also rewritten.
+	builder.WriteString(" ON DUPLICATE KEY UPDATE checkpoint_ts = VALUES(checkpoint_ts)")
+	return builder.String(), args
+}
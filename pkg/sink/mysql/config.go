@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/integrity"
 	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -99,6 +100,8 @@ type urlConfig struct {
 	EnableMultiStatement         *bool   `form:"multi-stmt-enable"`
 	EnableCachePreparedStatement *bool   `form:"cache-prep-stmts"`
 	HasVectorType                *bool   `form:"has-vector-type"`
+	VerifyChecksum               *bool   `form:"verify-checksum"`
+	EnableCheckpointTable        *bool   `form:"enable-checkpoint-table"`
 }
 
 // Config is the configs for MySQL backend.
@@ -126,6 +129,17 @@ type Config struct {
 	BatchDMLEnable  bool
 	MultiStmtEnable bool
 	CachePrepStmts  bool
+
+	// VerifyChecksum is true if the row level checksum is verified before writing.
+	VerifyChecksum bool
+	// Integrity decides how the checksum mismatch is handled,
+	// only set if VerifyChecksum is true.
+	Integrity *integrity.Config
+
+	// EnableCheckpointTable is true if the checkpoints are written into the
+	// checkpoint table of the downstream. The transactions of a table are
+	// written one by one if it's enabled, see txnEvent.ConflictKeys.
+	EnableCheckpointTable bool
 }
 
 // NewConfig returns the default mysql backend config.
@@ -205,6 +219,11 @@ func (c *Config) Apply(
 	getHasVectorType(urlParameter, &c.HasVectorType)
 	getMultiStmtEnable(urlParameter, &c.MultiStmtEnable)
 	getCachePrepStmts(urlParameter, &c.CachePrepStmts)
+	getVerifyChecksum(urlParameter, replicaConfig.Integrity, &c.VerifyChecksum)
+	if c.VerifyChecksum {
+		c.Integrity = replicaConfig.Integrity
+	}
+	getEnableCheckpointTable(urlParameter, &c.EnableCheckpointTable)
 	c.ForceReplicate = replicaConfig.ForceReplicate
 
 	// Note(dongmen): The TiDBSourceID should never be 0 here, but we have found that
@@ -248,37 +267,60 @@ func mergeConfig(
 			dest.EnableBatchDML = mConfig.EnableBatchDML
 			dest.EnableMultiStatement = mConfig.EnableMultiStatement
 			dest.EnableCachePreparedStatement = mConfig.EnableCachePreparedStatement
+			dest.VerifyChecksum = mConfig.VerifyChecksum
 		}
 	}
 	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
 		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
 	}
+	// mergo doesn't override a field with a pointer to false, so the sink uri
+	// is resolved explicitly to take precedence over the replica config.
+	if urlParameters.EnableCheckpointTable == nil &&
+		replicaConfig != nil && replicaConfig.Sink != nil && replicaConfig.Sink.MySQLConfig != nil {
+		dest.EnableCheckpointTable = replicaConfig.Sink.MySQLConfig.EnableCheckpointTable
+	}
 	return dest, nil
 }
 
 // IsSinkSafeMode returns whether the sink is in safe mode.
 func IsSinkSafeMode(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) (bool, error) {
+	urlParameter, err := parseURLConfig(sinkURI, replicaConfig)
+	if err != nil {
+		return false, err
+	}
+	if urlParameter.SafeMode == nil {
+		return defaultSafeMode, nil
+	}
+	return *urlParameter.SafeMode, nil
+}
+
+// IsCheckpointTableEnabled returns whether the checkpoint table of the sink is enabled.
+func IsCheckpointTableEnabled(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) (bool, error) {
+	urlParameter, err := parseURLConfig(sinkURI, replicaConfig)
+	if err != nil {
+		return false, err
+	}
+	var enabled bool
+	getEnableCheckpointTable(urlParameter, &enabled)
+	return enabled, nil
+}
+
+// parseURLConfig parses the sink URI parameters and merges them with the replica config.
+func parseURLConfig(sinkURI *url.URL, replicaConfig *config.ReplicaConfig) (*urlConfig, error) {
 	if sinkURI == nil {
-		return false, cerror.ErrMySQLInvalidConfig.GenWithStack("fail to open MySQL sink, empty SinkURI")
+		return nil, cerror.ErrMySQLInvalidConfig.GenWithStack("fail to open MySQL sink, empty SinkURI")
 	}
 
 	scheme := strings.ToLower(sinkURI.Scheme)
 	if !sink.IsMySQLCompatibleScheme(scheme) {
-		return false, cerror.ErrMySQLInvalidConfig.GenWithStack("can't create MySQL sink with unsupported scheme: %s", scheme)
+		return nil, cerror.ErrMySQLInvalidConfig.GenWithStack("can't create MySQL sink with unsupported scheme: %s", scheme)
 	}
 	req := &http.Request{URL: sinkURI}
 	urlParameter := &urlConfig{}
 	if err := binding.Query.Bind(req, urlParameter); err != nil {
-		return false, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
-	}
-	var err error
-	if urlParameter, err = mergeConfig(replicaConfig, urlParameter); err != nil {
-		return false, err
-	}
-	if urlParameter.SafeMode == nil {
-		return defaultSafeMode, nil
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
 	}
-	return *urlParameter.SafeMode, nil
+	return mergeConfig(replicaConfig, urlParameter)
 }
 
 func getWorkerCount(values *urlConfig, workerCount *int) error {
@@ -496,3 +538,21 @@ func getCachePrepStmts(values *urlConfig, cachePrepStmts *bool) {
 		*cachePrepStmts = *values.EnableCachePreparedStatement
 	}
 }
+
+func getEnableCheckpointTable(values *urlConfig, enableCheckpointTable *bool) {
+	if values.EnableCheckpointTable != nil {
+		*enableCheckpointTable = *values.EnableCheckpointTable
+	}
+}
+
+func getVerifyChecksum(values *urlConfig, integrityConfig *integrity.Config, verifyChecksum *bool) {
+	if values.VerifyChecksum == nil || !*values.VerifyChecksum {
+		return
+	}
+	// the checksum is only calculated by the mounter if the integrity check is enabled.
+	if integrityConfig == nil || !integrityConfig.Enabled() {
+		log.Warn("verify-checksum is ignored since the integrity check is not enabled")
+		return
+	}
+	*verifyChecksum = true
+}
@@ -26,6 +26,7 @@ import (
 	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/integrity"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
@@ -204,6 +205,73 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	require.Equal(t, expected, cfg)
 }
 
+func TestApplyVerifyChecksum(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse("mysql://127.0.0.1:3306/?verify-checksum=true")
+	require.NoError(t, err)
+
+	// the integrity check is not enabled, the checksum cannot be verified.
+	cfg := NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, config.GetDefaultReplicaConfig())
+	require.NoError(t, err)
+	require.False(t, cfg.VerifyChecksum)
+	require.Nil(t, cfg.Integrity)
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Integrity.IntegrityCheckLevel = integrity.CheckLevelCorrectness
+	cfg = NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.NoError(t, err)
+	require.True(t, cfg.VerifyChecksum)
+	require.Equal(t, replicaConfig.Integrity, cfg.Integrity)
+
+	// set by the replica config.
+	uri, err = url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{VerifyChecksum: util.AddressOf(true)}
+	cfg = NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.NoError(t, err)
+	require.True(t, cfg.VerifyChecksum)
+}
+
+func TestApplyEnableCheckpointTable(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse("mysql://127.0.0.1:3306/")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	cfg := NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.NoError(t, err)
+	require.False(t, cfg.EnableCheckpointTable)
+	enabled, err := IsCheckpointTableEnabled(uri, replicaConfig)
+	require.NoError(t, err)
+	require.False(t, enabled)
+
+	// set by the replica config.
+	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{EnableCheckpointTable: util.AddressOf(true)}
+	cfg = NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.NoError(t, err)
+	require.True(t, cfg.EnableCheckpointTable)
+	enabled, err = IsCheckpointTableEnabled(uri, replicaConfig)
+	require.NoError(t, err)
+	require.True(t, enabled)
+
+	// the sink uri overrides the replica config.
+	uri, err = url.Parse("mysql://127.0.0.1:3306/?enable-checkpoint-table=false")
+	require.NoError(t, err)
+	cfg = NewConfig()
+	err = cfg.Apply("UTC", model.DefaultChangeFeedID("test"), uri, replicaConfig)
+	require.NoError(t, err)
+	require.False(t, cfg.EnableCheckpointTable)
+	enabled, err = IsCheckpointTableEnabled(uri, replicaConfig)
+	require.NoError(t, err)
+	require.False(t, enabled)
+}
+
 func TestParseSinkURIOverride(t *testing.T) {
 	t.Parallel()
 
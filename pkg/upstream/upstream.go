@@ -311,6 +311,11 @@ func (up *Upstream) IsClosed() bool {
 	return atomic.LoadInt32(&up.status) == closed
 }
 
+// GetEtcdClient returns the etcd client of the upstream PD cluster.
+func (up *Upstream) GetEtcdClient() *etcd.Client {
+	return up.etcdCli
+}
+
 // resetIdleTime set the upstream idle time to true
 func (up *Upstream) resetIdleTime() {
 	up.mu.Lock()
@@ -14,8 +14,11 @@
 package util
 
 import (
+	"fmt"
 	"net"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/pingcap/log"
@@ -104,13 +107,136 @@ func MaskSensitiveDataInURI(uri string) string {
 	}
 	queries := uriParsed.Query()
 	for key := range queries {
-		lower := strings.ToLower(key)
-		for _, secretKey := range sensitiveQueryParameterNames {
-			if strings.Contains(lower, secretKey) {
-				queries.Set(key, "xxxxx")
-			}
+		if isSensitiveQueryParameter(key) {
+			queries.Set(key, "xxxxx")
 		}
 	}
 	uriParsed.RawQuery = queries.Encode()
 	return uriParsed.Redacted()
 }
+
+func isSensitiveQueryParameter(key string) bool {
+	lower := strings.ToLower(key)
+	for _, secretKey := range sensitiveQueryParameterNames {
+		if strings.Contains(lower, secretKey) {
+			return true
+		}
+	}
+	return false
+}
+
+var templateVariableRegexp = regexp.MustCompile(`\$\{([A-Z0-9_]+)\}`)
+
+// TemplateVariableName returns the name of a template variable made of
+// the prefix and the name, e.g. ("sink", "sasl-password") => SINK_SASL_PASSWORD.
+func TemplateVariableName(prefix, name string) string {
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// TemplateSensitiveDataInURI replaces the password and the sensitive query
+// parameters of the uri with template variables like ${SINK_PASSWORD}.
+// It returns the templated uri and the names of the variables.
+func TemplateSensitiveDataInURI(uri, prefix string) (string, []string, error) {
+	uriParsed, err := url.Parse(uri)
+	if err != nil {
+		return "", nil, err
+	}
+	// url.URL escapes braces, so the variables are written as plain markers
+	// first and replaced after the uri is encoded.
+	var names, replacements []string
+	variable := func(name string) string {
+		names = append(names, TemplateVariableName(prefix, name))
+		marker := fmt.Sprintf("tmplvar%dx", len(names))
+		replacements = append(replacements, marker, "${"+names[len(names)-1]+"}")
+		return marker
+	}
+	if uriParsed.User != nil {
+		if _, ok := uriParsed.User.Password(); ok {
+			uriParsed.User = url.UserPassword(uriParsed.User.Username(), variable("password"))
+		}
+	}
+	queries := uriParsed.Query()
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		if isSensitiveQueryParameter(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		queries.Set(key, variable(key))
+	}
+	if len(keys) > 0 {
+		uriParsed.RawQuery = queries.Encode()
+	}
+	if len(names) == 0 {
+		return uri, nil, nil
+	}
+	return strings.NewReplacer(replacements...).Replace(uriParsed.String()), names, nil
+}
+
+// TemplateVariables returns the names of the template variables in s.
+func TemplateVariables(s string) []string {
+	var names []string
+	for _, match := range templateVariableRegexp.FindAllStringSubmatch(s, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// RenderTemplate replaces the template variables in s with the given values.
+// It returns the names of the variables which have no value, they are kept
+// as is in the result.
+func RenderTemplate(s string, values map[string]string) (string, []string) {
+	return renderTemplate(s, values, func(v string) string { return v })
+}
+
+// RenderURITemplate is like RenderTemplate, but escapes the values so that
+// they can be used as the password or the query parameters of an uri.
+func RenderURITemplate(uri string, values map[string]string) (string, []string) {
+	return renderTemplate(uri, values, escapeURIComponent)
+}
+
+func renderTemplate(
+	s string, values map[string]string, escape func(string) string,
+) (string, []string) {
+	var missing []string
+	res := templateVariableRegexp.ReplaceAllStringFunc(s, func(v string) string {
+		name := templateVariableRegexp.FindStringSubmatch(v)[1]
+		value, ok := values[name]
+		if !ok {
+			missing = append(missing, name)
+			return v
+		}
+		return escape(value)
+	})
+	return res, missing
+}
+
+// escapeURIComponent escapes all bytes except the unreserved characters
+// defined in RFC 3986, the result is valid in both userinfo and query.
+func escapeURIComponent(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
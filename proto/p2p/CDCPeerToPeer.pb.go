@@ -140,6 +140,9 @@ type StreamMeta struct {
 	Epoch      int64  `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
 	// fields required for compatibility check
 	ClientVersion string `protobuf:"bytes,50,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	// the compression proposed by the client for message batches.
+	// The server accepts it by returning the same compression in a response.
+	Compression string `protobuf:"bytes,51,opt,name=compression,proto3" json:"compression,omitempty"`
 	// fields for metrics, logging, debugging, etc.
 	SenderAdvertisedAddr string `protobuf:"bytes,100,opt,name=sender_advertised_addr,json=senderAdvertisedAddr,proto3" json:"sender_advertised_addr,omitempty"`
 }
@@ -205,6 +208,13 @@ func (m *StreamMeta) GetClientVersion() string {
 	return ""
 }
 
+func (m *StreamMeta) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
 func (m *StreamMeta) GetSenderAdvertisedAddr() string {
 	if m != nil {
 		return m.SenderAdvertisedAddr
@@ -216,6 +226,14 @@ type MessagePacket struct {
 	Meta *StreamMeta `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
 	// multiple messages can be batched.
 	Entries []*MessageEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// the compression of compressed_entries. Empty means that the entries
+	// are not compressed.
+	Compression string `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"`
+	// compressed entries, marshaled as a MessagePacket with only entries set.
+	CompressedEntries []byte `protobuf:"bytes,4,opt,name=compressed_entries,json=compressedEntries,proto3" json:"compressed_entries,omitempty"`
+	// the unix time in nanoseconds when the packet is sent, which is echoed
+	// by the server to measure the round-trip time.
+	SendTime int64 `protobuf:"varint,5,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`
 }
 
 func (m *MessagePacket) Reset()         { *m = MessagePacket{} }
@@ -265,6 +283,27 @@ func (m *MessagePacket) GetEntries() []*MessageEntry {
 	return nil
 }
 
+func (m *MessagePacket) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *MessagePacket) GetCompressedEntries() []byte {
+	if m != nil {
+		return m.CompressedEntries
+	}
+	return nil
+}
+
+func (m *MessagePacket) GetSendTime() int64 {
+	if m != nil {
+		return m.SendTime
+	}
+	return 0
+}
+
 type Ack struct {
 	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
 	// the sequence of an already processed message.
@@ -323,6 +362,13 @@ type SendMessageResponse struct {
 	Ack          []*Ack     `protobuf:"bytes,1,rep,name=ack,proto3" json:"ack,omitempty"`
 	ExitReason   ExitReason `protobuf:"varint,2,opt,name=exit_reason,json=exitReason,proto3,enum=p2p.ExitReason" json:"exit_reason,omitempty"`
 	ErrorMessage string     `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// the compression accepted by the server.
+	Compression string `protobuf:"bytes,4,opt,name=compression,proto3" json:"compression,omitempty"`
+	// send_time of the latest packet received by the server.
+	EchoSendTime int64 `protobuf:"varint,5,opt,name=echo_send_time,json=echoSendTime,proto3" json:"echo_send_time,omitempty"`
+	// nanoseconds elapsed between receiving the latest packet and
+	// sending this response.
+	EchoHoldTime int64 `protobuf:"varint,6,opt,name=echo_hold_time,json=echoHoldTime,proto3" json:"echo_hold_time,omitempty"`
 }
 
 func (m *SendMessageResponse) Reset()         { *m = SendMessageResponse{} }
@@ -379,6 +425,27 @@ func (m *SendMessageResponse) GetErrorMessage() string {
 	return ""
 }
 
+func (m *SendMessageResponse) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *SendMessageResponse) GetEchoSendTime() int64 {
+	if m != nil {
+		return m.EchoSendTime
+	}
+	return 0
+}
+
+func (m *SendMessageResponse) GetEchoHoldTime() int64 {
+	if m != nil {
+		return m.EchoHoldTime
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("p2p.ExitReason", ExitReason_name, ExitReason_value)
 	proto.RegisterType((*MessageEntry)(nil), "p2p.MessageEntry")
@@ -391,44 +458,49 @@ func init() {
 func init() { proto.RegisterFile("CDCPeerToPeer.proto", fileDescriptor_6560df28dddfd2cc) }
 
 var fileDescriptor_6560df28dddfd2cc = []byte{
-	// 578 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xae, 0x9b, 0x6d, 0x6d, 0x5f, 0xd7, 0x2d, 0xb8, 0x15, 0x84, 0x22, 0x95, 0xaa, 0x13, 0x52,
-	0x05, 0xd2, 0x98, 0x0a, 0xe2, 0x8a, 0x42, 0x1a, 0x41, 0xb4, 0x35, 0xad, 0x92, 0x14, 0x24, 0x2e,
-	0x51, 0x48, 0x9e, 0x46, 0xd4, 0x2d, 0xce, 0x6c, 0x33, 0x8d, 0x7f, 0xc0, 0x09, 0xf1, 0x87, 0xb8,
-	0x73, 0xdc, 0x91, 0x23, 0xda, 0xfe, 0x08, 0xaa, 0x93, 0x6e, 0x9d, 0x10, 0x17, 0xcb, 0xdf, 0xf7,
-	0x3d, 0xbf, 0xcf, 0xfe, 0x9e, 0x0c, 0x6d, 0x6b, 0x6c, 0xcd, 0x10, 0x79, 0xc0, 0x96, 0xeb, 0x7e,
-	0xce, 0x99, 0x64, 0x54, 0xcb, 0x47, 0x79, 0xb7, 0x73, 0xcc, 0x8e, 0x99, 0xc2, 0xcf, 0x97, 0xbb,
-	0x42, 0x1a, 0x7c, 0x84, 0xed, 0x09, 0x0a, 0x11, 0x1d, 0xa3, 0x9d, 0x49, 0xfe, 0x95, 0x76, 0x60,
-	0x53, 0xb2, 0x3c, 0x8d, 0x0d, 0xd2, 0x27, 0xc3, 0x86, 0x57, 0x00, 0x6a, 0x40, 0x2d, 0x66, 0x99,
-	0xc4, 0x4c, 0x1a, 0xd5, 0x3e, 0x19, 0x6e, 0x7b, 0x2b, 0x48, 0xbb, 0x50, 0x17, 0x78, 0xf6, 0x05,
-	0xb3, 0x18, 0x0d, 0xad, 0x4f, 0x86, 0x9a, 0x77, 0x83, 0x07, 0x3f, 0x09, 0x80, 0x2f, 0x39, 0x46,
-	0xa7, 0x13, 0x94, 0x11, 0x7d, 0x04, 0x0d, 0x81, 0x59, 0x82, 0x3c, 0x4c, 0x93, 0xb2, 0x7d, 0xbd,
-	0x20, 0x9c, 0x84, 0x3e, 0x86, 0x26, 0xc7, 0x18, 0xd3, 0xf3, 0x42, 0xae, 0x2a, 0x19, 0x56, 0x94,
-	0x93, 0x2c, 0x2f, 0x86, 0x39, 0x8b, 0x3f, 0x97, 0x2e, 0x05, 0xa0, 0x4f, 0x60, 0x27, 0x3e, 0x49,
-	0x31, 0x93, 0xe1, 0x39, 0x72, 0x91, 0xb2, 0xcc, 0x18, 0xa9, 0x93, 0xad, 0x82, 0x7d, 0x5f, 0x90,
-	0xf4, 0x25, 0xdc, 0x2f, 0xad, 0xa3, 0xe4, 0x1c, 0xb9, 0x4c, 0x05, 0x26, 0x61, 0x94, 0x24, 0xdc,
-	0x48, 0x54, 0x79, 0xa7, 0x50, 0xcd, 0x1b, 0xd1, 0x4c, 0x12, 0x3e, 0x88, 0xa0, 0x55, 0x66, 0x33,
-	0x8b, 0xe2, 0x05, 0x4a, 0xba, 0x07, 0x1b, 0xa7, 0x28, 0x23, 0x75, 0xf9, 0xe6, 0x68, 0x77, 0x3f,
-	0x1f, 0xe5, 0xfb, 0xb7, 0x0f, 0xf4, 0x94, 0x48, 0x9f, 0x41, 0x0d, 0x33, 0xc9, 0x53, 0x14, 0x46,
-	0xb5, 0xaf, 0x0d, 0x9b, 0xa3, 0x7b, 0xaa, 0x6e, 0x3d, 0x65, 0x6f, 0x55, 0x31, 0x78, 0x05, 0x9a,
-	0x19, 0x2f, 0xfe, 0x93, 0xfa, 0x43, 0xa8, 0x9f, 0x44, 0x42, 0x86, 0x02, 0xcf, 0x54, 0x20, 0x9a,
-	0x57, 0x5b, 0x62, 0x1f, 0xcf, 0x06, 0xdf, 0x08, 0xb4, 0x7d, 0xcc, 0x92, 0xb2, 0xab, 0x87, 0x22,
-	0x67, 0x99, 0x40, 0xda, 0x05, 0x2d, 0x8a, 0x17, 0x06, 0x51, 0xc6, 0x75, 0x65, 0x6c, 0xc6, 0x0b,
-	0x6f, 0x49, 0xd2, 0x03, 0x68, 0xe2, 0x45, 0x2a, 0x43, 0x8e, 0x91, 0x60, 0x99, 0xea, 0xb8, 0x53,
-	0x3e, 0xc2, 0xbe, 0x48, 0xa5, 0xa7, 0x68, 0x0f, 0xf0, 0x66, 0x4f, 0xf7, 0xa0, 0x85, 0x9c, 0x33,
-	0x1e, 0x9e, 0x16, 0x36, 0x2a, 0xfb, 0x86, 0xb7, 0xad, 0xc8, 0xd2, 0xfa, 0xe9, 0x77, 0x02, 0x70,
-	0x7b, 0x9e, 0x36, 0xa1, 0x36, 0x77, 0x0f, 0xdd, 0xe9, 0x07, 0x57, 0xaf, 0xd0, 0x2d, 0xa8, 0x4e,
-	0x0f, 0x75, 0x42, 0x5b, 0xd0, 0xb0, 0xa6, 0xee, 0x5b, 0xdb, 0x0f, 0xec, 0xb1, 0x5e, 0xa5, 0x6d,
-	0xd8, 0xb5, 0xcc, 0x59, 0x30, 0xf7, 0xec, 0xd0, 0x9f, 0x3b, 0x96, 0x33, 0xb6, 0x75, 0x8d, 0x76,
-	0x40, 0xf7, 0x03, 0xf3, 0xc8, 0x0e, 0xad, 0xa9, 0xeb, 0xda, 0x56, 0xe0, 0x4c, 0x5d, 0x7d, 0x83,
-	0x1a, 0xd0, 0x19, 0xcf, 0x67, 0x47, 0x8e, 0x65, 0x06, 0x77, 0x94, 0x4d, 0xfa, 0x00, 0xda, 0xab,
-	0x26, 0xce, 0x38, 0x9c, 0x38, 0xfe, 0xc4, 0x0c, 0xac, 0x77, 0xfa, 0xd6, 0x68, 0x06, 0xad, 0x3b,
-	0x9f, 0x80, 0xbe, 0x86, 0xe6, 0x5a, 0x56, 0x94, 0xae, 0xcf, 0xa3, 0x98, 0x6c, 0xd7, 0x28, 0x66,
-	0xf9, 0x6f, 0xa2, 0x43, 0x72, 0x40, 0xde, 0x18, 0xbf, 0xae, 0x7a, 0xe4, 0xf2, 0xaa, 0x47, 0xfe,
-	0x5c, 0xf5, 0xc8, 0x8f, 0xeb, 0x5e, 0xe5, 0xf2, 0xba, 0x57, 0xf9, 0x7d, 0xdd, 0xab, 0x7c, 0xda,
-	0x52, 0xbf, 0xe8, 0xc5, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe3, 0x85, 0x98, 0x36, 0x77, 0x03,
-	0x00, 0x00,
+	// 664 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x4d, 0x6f, 0xdb, 0x46,
+	0x10, 0xd5, 0x8a, 0xb2, 0x24, 0x8f, 0x24, 0x5b, 0x5e, 0x09, 0x2d, 0xab, 0x02, 0xaa, 0x20, 0xb7,
+	0x80, 0xd0, 0xa2, 0xae, 0x21, 0x17, 0xb9, 0x06, 0x0c, 0x45, 0xc4, 0x84, 0xad, 0x0f, 0x90, 0x54,
+	0x02, 0xe4, 0x42, 0x30, 0xe4, 0xc0, 0x26, 0x24, 0x71, 0xe9, 0xe5, 0xc6, 0x70, 0xfe, 0x44, 0x90,
+	0x9f, 0x95, 0x5b, 0x7c, 0xcc, 0x2d, 0x81, 0xfd, 0x1f, 0x72, 0x0e, 0xb4, 0xa4, 0x3e, 0x6c, 0x23,
+	0x17, 0x62, 0xe7, 0xbd, 0xc7, 0xd9, 0x99, 0x37, 0x83, 0x85, 0x86, 0x3e, 0xd0, 0x27, 0x88, 0xdc,
+	0x61, 0xcb, 0xef, 0x51, 0xcc, 0x99, 0x60, 0x54, 0x89, 0xfb, 0x71, 0xab, 0x79, 0xc1, 0x2e, 0x98,
+	0x8c, 0xff, 0x5b, 0x9e, 0x52, 0xaa, 0xfb, 0x06, 0xaa, 0x43, 0x4c, 0x12, 0xef, 0x02, 0x8d, 0x48,
+	0xf0, 0xf7, 0xb4, 0x09, 0x3b, 0x82, 0xc5, 0xa1, 0xaf, 0x92, 0x0e, 0xe9, 0xed, 0x5a, 0x69, 0x40,
+	0x55, 0x28, 0xf9, 0x2c, 0x12, 0x18, 0x09, 0x35, 0xdf, 0x21, 0xbd, 0xaa, 0xb5, 0x0a, 0x69, 0x0b,
+	0xca, 0x09, 0x5e, 0xbd, 0xc3, 0xc8, 0x47, 0x55, 0xe9, 0x90, 0x9e, 0x62, 0xad, 0xe3, 0xee, 0x57,
+	0x02, 0x60, 0x0b, 0x8e, 0xde, 0x62, 0x88, 0xc2, 0xa3, 0xbf, 0xc3, 0x6e, 0x82, 0x51, 0x80, 0xdc,
+	0x0d, 0x83, 0x2c, 0x7d, 0x39, 0x05, 0xcc, 0x80, 0xfe, 0x01, 0x15, 0x8e, 0x3e, 0x86, 0xd7, 0x29,
+	0x9d, 0x97, 0x34, 0xac, 0x20, 0x33, 0x58, 0x16, 0x86, 0x31, 0xf3, 0x2f, 0xb3, 0x5b, 0xd2, 0x80,
+	0xfe, 0x05, 0x7b, 0xfe, 0x3c, 0xc4, 0x48, 0xb8, 0xd7, 0xc8, 0x93, 0x90, 0x45, 0x6a, 0x5f, 0xfe,
+	0x59, 0x4b, 0xd1, 0x57, 0x29, 0x48, 0x3b, 0x50, 0xf1, 0xd9, 0x22, 0xe6, 0x98, 0x48, 0xcd, 0x89,
+	0xd4, 0x6c, 0x43, 0xf4, 0x7f, 0xf8, 0x25, 0x2b, 0xce, 0x0b, 0xae, 0x91, 0x8b, 0x30, 0xc1, 0xc0,
+	0xf5, 0x82, 0x80, 0xab, 0x81, 0x14, 0x37, 0x53, 0x56, 0x5b, 0x93, 0x5a, 0x10, 0xf0, 0xee, 0x67,
+	0x02, 0xb5, 0xcc, 0xbe, 0x89, 0xe7, 0xcf, 0x50, 0xd0, 0x43, 0x28, 0x2c, 0x50, 0x78, 0xb2, 0xbf,
+	0x4a, 0x7f, 0xff, 0x28, 0xee, 0xc7, 0x47, 0x1b, 0x0f, 0x2c, 0x49, 0xd2, 0x7f, 0xa0, 0x84, 0x91,
+	0xe0, 0x21, 0x26, 0x6a, 0xbe, 0xa3, 0xf4, 0x2a, 0xfd, 0x03, 0xa9, 0xdb, 0x1e, 0x84, 0xb5, 0x52,
+	0x3c, 0xae, 0x5d, 0x79, 0x5a, 0xfb, 0xbf, 0x40, 0x57, 0x21, 0x06, 0xee, 0x2a, 0x73, 0x41, 0x0e,
+	0xea, 0x60, 0xc3, 0x18, 0x59, 0xc2, 0x6c, 0x0e, 0xae, 0x08, 0x17, 0xa8, 0xee, 0xac, 0x66, 0x16,
+	0x05, 0x4e, 0xb8, 0xc0, 0xee, 0x33, 0x50, 0x34, 0x7f, 0xf6, 0x93, 0x35, 0xf8, 0x0d, 0xca, 0x73,
+	0x2f, 0x11, 0x6e, 0x82, 0x57, 0x72, 0x42, 0x8a, 0x55, 0x5a, 0xc6, 0x36, 0x5e, 0x75, 0xbf, 0x13,
+	0x68, 0xd8, 0x18, 0x05, 0x59, 0x0f, 0x16, 0x26, 0x31, 0x8b, 0x12, 0xa4, 0x2d, 0x50, 0x3c, 0x7f,
+	0xa6, 0x12, 0xd9, 0x66, 0x59, 0xb6, 0xa9, 0xf9, 0x33, 0x6b, 0x09, 0xd2, 0x63, 0xa8, 0xe0, 0x4d,
+	0x28, 0x5c, 0x8e, 0x5e, 0xc2, 0x22, 0x99, 0x71, 0x2f, 0xb3, 0xcc, 0xb8, 0x09, 0x85, 0x25, 0x61,
+	0x0b, 0x70, 0x7d, 0xa6, 0x87, 0x50, 0x43, 0xce, 0x19, 0x77, 0x17, 0xe9, 0x35, 0x99, 0x1b, 0x55,
+	0x09, 0x66, 0x57, 0x3f, 0x36, 0xac, 0xf0, 0xd4, 0xb0, 0x3f, 0x61, 0x0f, 0xfd, 0x4b, 0xe6, 0x3e,
+	0xb6, 0xa1, 0xba, 0x44, 0xed, 0xcc, 0x8a, 0xb5, 0xea, 0x92, 0xcd, 0x33, 0x55, 0x71, 0xa3, 0x3a,
+	0x65, 0x73, 0xa9, 0xfa, 0xfb, 0x03, 0x01, 0xd8, 0x54, 0x4b, 0x2b, 0x50, 0x9a, 0x8e, 0xce, 0x46,
+	0xe3, 0xd7, 0xa3, 0x7a, 0x8e, 0x16, 0x21, 0x3f, 0x3e, 0xab, 0x13, 0x5a, 0x83, 0x5d, 0x7d, 0x3c,
+	0x7a, 0x69, 0xd8, 0x8e, 0x31, 0xa8, 0xe7, 0x69, 0x03, 0xf6, 0x75, 0x6d, 0xe2, 0x4c, 0x2d, 0xc3,
+	0xb5, 0xa7, 0xa6, 0x6e, 0x0e, 0x8c, 0xba, 0x42, 0x9b, 0x50, 0xb7, 0x1d, 0xed, 0xdc, 0x70, 0xf5,
+	0xf1, 0x68, 0x64, 0xe8, 0x8e, 0x39, 0x1e, 0xd5, 0x0b, 0x54, 0x85, 0xe6, 0x60, 0x3a, 0x39, 0x37,
+	0x75, 0xcd, 0x79, 0xc0, 0xec, 0xd0, 0x5f, 0xa1, 0xb1, 0x4a, 0x62, 0x0e, 0xdc, 0xa1, 0x69, 0x0f,
+	0x35, 0x47, 0x3f, 0xad, 0x17, 0xfb, 0x13, 0xa8, 0x3d, 0x78, 0x03, 0xe8, 0x73, 0xa8, 0x6c, 0x4d,
+	0x86, 0xd2, 0xed, 0x5d, 0x4b, 0xb7, 0xb6, 0xa5, 0xa6, 0x7b, 0xfa, 0x74, 0x7e, 0x3d, 0x72, 0x4c,
+	0x5e, 0xa8, 0x9f, 0xee, 0xda, 0xe4, 0xf6, 0xae, 0x4d, 0xbe, 0xdd, 0xb5, 0xc9, 0xc7, 0xfb, 0x76,
+	0xee, 0xf6, 0xbe, 0x9d, 0xfb, 0x72, 0xdf, 0xce, 0xbd, 0x2d, 0xca, 0x47, 0xe4, 0xe4, 0xc7, 0x00,
+	0x01, 0x51, 0xa6, 0xab, 0x76, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -620,6 +692,15 @@ func (m *StreamMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xa2
 	}
+	if len(m.Compression) > 0 {
+		i -= len(m.Compression)
+		copy(dAtA[i:], m.Compression)
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(len(m.Compression)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x9a
+	}
 	if len(m.ClientVersion) > 0 {
 		i -= len(m.ClientVersion)
 		copy(dAtA[i:], m.ClientVersion)
@@ -671,6 +752,25 @@ func (m *MessagePacket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SendTime != 0 {
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(m.SendTime))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.CompressedEntries) > 0 {
+		i -= len(m.CompressedEntries)
+		copy(dAtA[i:], m.CompressedEntries)
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(len(m.CompressedEntries)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Compression) > 0 {
+		i -= len(m.Compression)
+		copy(dAtA[i:], m.Compression)
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(len(m.Compression)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Entries) > 0 {
 		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -755,6 +855,23 @@ func (m *SendMessageResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EchoHoldTime != 0 {
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(m.EchoHoldTime))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.EchoSendTime != 0 {
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(m.EchoSendTime))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Compression) > 0 {
+		i -= len(m.Compression)
+		copy(dAtA[i:], m.Compression)
+		i = encodeVarintCDCPeerToPeer(dAtA, i, uint64(len(m.Compression)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.ErrorMessage) > 0 {
 		i -= len(m.ErrorMessage)
 		copy(dAtA[i:], m.ErrorMessage)
@@ -836,6 +953,10 @@ func (m *StreamMeta) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovCDCPeerToPeer(uint64(l))
 	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 2 + l + sovCDCPeerToPeer(uint64(l))
+	}
 	l = len(m.SenderAdvertisedAddr)
 	if l > 0 {
 		n += 2 + l + sovCDCPeerToPeer(uint64(l))
@@ -859,6 +980,17 @@ func (m *MessagePacket) Size() (n int) {
 			n += 1 + l + sovCDCPeerToPeer(uint64(l))
 		}
 	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 1 + l + sovCDCPeerToPeer(uint64(l))
+	}
+	l = len(m.CompressedEntries)
+	if l > 0 {
+		n += 1 + l + sovCDCPeerToPeer(uint64(l))
+	}
+	if m.SendTime != 0 {
+		n += 1 + sovCDCPeerToPeer(uint64(m.SendTime))
+	}
 	return n
 }
 
@@ -897,6 +1029,16 @@ func (m *SendMessageResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCDCPeerToPeer(uint64(l))
 	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 1 + l + sovCDCPeerToPeer(uint64(l))
+	}
+	if m.EchoSendTime != 0 {
+		n += 1 + sovCDCPeerToPeer(uint64(m.EchoSendTime))
+	}
+	if m.EchoHoldTime != 0 {
+		n += 1 + sovCDCPeerToPeer(uint64(m.EchoHoldTime))
+	}
 	return n
 }
 
@@ -1185,6 +1327,38 @@ func (m *StreamMeta) Unmarshal(dAtA []byte) error {
 			}
 			m.ClientVersion = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 51:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 100:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field SenderAdvertisedAddr", wireType)
@@ -1337,6 +1511,91 @@ func (m *MessagePacket) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedEntries", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressedEntries = append(m.CompressedEntries[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompressedEntries == nil {
+				m.CompressedEntries = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendTime", wireType)
+			}
+			m.SendTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SendTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCDCPeerToPeer(dAtA[iNdEx:])
@@ -1573,6 +1832,76 @@ func (m *SendMessageResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.ErrorMessage = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCDCPeerToPeer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EchoSendTime", wireType)
+			}
+			m.EchoSendTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EchoSendTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EchoHoldTime", wireType)
+			}
+			m.EchoHoldTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCDCPeerToPeer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EchoHoldTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCDCPeerToPeer(dAtA[iNdEx:])